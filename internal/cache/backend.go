@@ -0,0 +1,17 @@
+package cache
+
+// Backend is a storage of action outputs.
+// Cache is the local on-disk implementation, Remote shares the outputs between machines.
+type Backend interface {
+	// GetBytes returns the output bytes of the action ID, or an error satisfying IsErrMissing.
+	GetBytes(id ActionID) ([]byte, Entry, error)
+	// PutBytes stores the bytes as the output of the action ID.
+	PutBytes(id ActionID, data []byte) error
+	// Trim removes old entries.
+	Trim()
+}
+
+var (
+	_ Backend = &Cache{}
+	_ Backend = &Remote{}
+)
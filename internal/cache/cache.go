@@ -149,31 +149,49 @@ func (c *Cache) get(id ActionID) (Entry, error) {
 		return Entry{}, err
 	}
 	fileName := c.fileName(id, "a")
-	f, err := os.Open(fileName)
+	eid, entry, err := readEntry(fileName)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return missing()
 		}
 		return failed(err)
 	}
+	if eid != id {
+		return failed(fmt.Errorf("bad id in %s", fileName))
+	}
+
+	if err = c.used(fileName); err != nil {
+		return failed(errors.Wrapf(err, "failed to mark %s as used", fileName))
+	}
+
+	return entry, nil
+}
+
+// readEntry reads the action entry file, without marking it as used.
+func readEntry(fileName string) (ActionID, Entry, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return ActionID{}, Entry{}, err
+	}
 	defer f.Close()
 	entry := make([]byte, entrySize+1) // +1 to detect whether f is too long
 	if n, readErr := io.ReadFull(f, entry); n != entrySize || readErr != io.ErrUnexpectedEOF {
-		return failed(fmt.Errorf("read %d/%d bytes from %s with error %s", n, entrySize, fileName, readErr))
+		return ActionID{}, Entry{}, fmt.Errorf("read %d/%d bytes from %s with error %s", n, entrySize, fileName, readErr)
 	}
 	if entry[0] != 'v' || entry[1] != '1' || entry[2] != ' ' || entry[3+hexSize] != ' ' || entry[3+hexSize+1+hexSize] != ' ' || entry[3+hexSize+1+hexSize+1+20] != ' ' || entry[entrySize-1] != '\n' {
-		return failed(fmt.Errorf("bad data in %s", fileName))
+		return ActionID{}, Entry{}, fmt.Errorf("bad data in %s", fileName)
 	}
 	eid, entry := entry[3:3+hexSize], entry[3+hexSize:]
 	eout, entry := entry[1:1+hexSize], entry[1+hexSize:]
 	esize, entry := entry[1:1+20], entry[1+20:]
 	etime := entry[1 : 1+20]
-	var buf [HashSize]byte
-	if _, err = hex.Decode(buf[:], eid); err != nil || buf != id {
-		return failed(errors.Wrapf(err, "failed to hex decode eid data in %s", fileName))
+	var id ActionID
+	if _, err = hex.Decode(id[:], eid); err != nil {
+		return ActionID{}, Entry{}, errors.Wrapf(err, "failed to hex decode eid data in %s", fileName)
 	}
-	if _, err = hex.Decode(buf[:], eout); err != nil {
-		return failed(errors.Wrapf(err, "failed to hex decode eout data in %s", fileName))
+	var out OutputID
+	if _, err = hex.Decode(out[:], eout); err != nil {
+		return ActionID{}, Entry{}, errors.Wrapf(err, "failed to hex decode eout data in %s", fileName)
 	}
 	i := 0
 	for i < len(esize) && esize[i] == ' ' {
@@ -181,7 +199,7 @@ func (c *Cache) get(id ActionID) (Entry, error) {
 	}
 	size, err := strconv.ParseInt(string(esize[i:]), 10, 64)
 	if err != nil || size < 0 {
-		return failed(fmt.Errorf("failed to parse esize int from %s with error %s", fileName, err))
+		return ActionID{}, Entry{}, fmt.Errorf("failed to parse esize int from %s with error %s", fileName, err)
 	}
 	i = 0
 	for i < len(etime) && etime[i] == ' ' {
@@ -189,14 +207,10 @@ func (c *Cache) get(id ActionID) (Entry, error) {
 	}
 	tm, err := strconv.ParseInt(string(etime[i:]), 10, 64)
 	if err != nil || tm < 0 {
-		return failed(fmt.Errorf("failed to parse etime int from %s with error %s", fileName, err))
-	}
-
-	if err = c.used(fileName); err != nil {
-		return failed(errors.Wrapf(err, "failed to mark %s as used", fileName))
+		return ActionID{}, Entry{}, fmt.Errorf("failed to parse etime int from %s with error %s", fileName, err)
 	}
 
-	return Entry{buf, size, time.Unix(0, tm)}, nil
+	return id, Entry{out, size, time.Unix(0, tm)}, nil
 }
 
 // GetBytes looks up the action ID in the cache and returns
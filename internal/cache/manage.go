@@ -0,0 +1,272 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golangci/golangci-lint/internal/renameio"
+)
+
+// lastRunFile is the file of the cache directory storing the statistics of the last run.
+const lastRunFile = "last-run.json"
+
+// Stats are the statistics of the cache directory.
+type Stats struct {
+	// Entries are the action entries, Outputs the stored outputs.
+	Entries int
+	Outputs int
+	// Size is the total size of the entries and the outputs, in bytes.
+	Size int64
+
+	// Oldest and Newest are the times of the least and the most recently used files, zero without files.
+	Oldest time.Time
+	Newest time.Time
+
+	// LastTrim is the time of the last automatic trim, zero if the cache was never trimmed.
+	LastTrim time.Time
+}
+
+// LastRun are the hits and the misses of the packages cache during the last run.
+type LastRun struct {
+	Time   time.Time
+	Hits   int64
+	Misses int64
+}
+
+// cacheFile is an entry or an output file of the cache.
+type cacheFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+func (f cacheFile) isEntry() bool {
+	return strings.HasSuffix(f.path, "-a")
+}
+
+// files lists the entry (xxxx-a) and the output (xxxx-d) files of the cache.
+func (c *Cache) files() ([]cacheFile, error) {
+	var files []cacheFile
+
+	for i := 0; i < 256; i++ {
+		subdir := filepath.Join(c.dir, fmt.Sprintf("%02x", i))
+
+		entries, err := os.ReadDir(subdir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, e := range entries {
+			name := e.Name()
+			if !strings.HasSuffix(name, "-a") && !strings.HasSuffix(name, "-d") {
+				continue
+			}
+
+			info, err := e.Info()
+			if err != nil {
+				continue // removed concurrently
+			}
+
+			files = append(files, cacheFile{path: filepath.Join(subdir, name), size: info.Size(), modTime: info.ModTime()})
+		}
+	}
+
+	return files, nil
+}
+
+// Stats computes the statistics of the cache directory.
+func (c *Cache) Stats() (Stats, error) {
+	files, err := c.files()
+	if err != nil {
+		return Stats{}, err
+	}
+
+	var s Stats
+	for _, f := range files {
+		if f.isEntry() {
+			s.Entries++
+		} else {
+			s.Outputs++
+		}
+		s.Size += f.size
+
+		if s.Oldest.IsZero() || f.modTime.Before(s.Oldest) {
+			s.Oldest = f.modTime
+		}
+		if f.modTime.After(s.Newest) {
+			s.Newest = f.modTime
+		}
+	}
+
+	data, _ := renameio.ReadFile(filepath.Join(c.dir, "trim.txt"))
+	if t, err := parseUnix(string(data)); err == nil {
+		s.LastTrim = t
+	}
+
+	return s, nil
+}
+
+// TrimToSize removes the least recently used files until the size of the cache is at most maxSize bytes.
+// It returns the count of the removed files and their size.
+func (c *Cache) TrimToSize(maxSize int64) (removed int, freed int64, err error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var size int64
+	for _, f := range files {
+		size += f.size
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	for _, f := range files {
+		if size <= maxSize {
+			break
+		}
+
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return removed, freed, err
+		}
+
+		size -= f.size
+		removed++
+		freed += f.size
+	}
+
+	return removed, freed, nil
+}
+
+// Corruption is a corrupted file of the cache.
+type Corruption struct {
+	Path   string
+	Reason string
+}
+
+// Verify checks the files of the cache: the action entries must be readable and point to a valid output,
+// and the content of the outputs must match their hash.
+// The corrupted files are removed if purge is true.
+func (c *Cache) Verify(purge bool) (checked int, corrupted []Corruption, err error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	for _, f := range files {
+		checked++
+
+		var reason string
+		if f.isEntry() {
+			reason = c.verifyEntry(f.path)
+		} else {
+			reason = verifyOutput(f.path)
+		}
+		if reason == "" {
+			continue
+		}
+
+		corrupted = append(corrupted, Corruption{Path: f.path, Reason: reason})
+
+		if purge {
+			if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+				return checked, corrupted, err
+			}
+		}
+	}
+
+	return checked, corrupted, nil
+}
+
+// verifyEntry returns the reason of the corruption of the action entry, empty if it's valid.
+func (c *Cache) verifyEntry(path string) string {
+	id, entry, err := readEntry(path)
+	if err != nil {
+		return err.Error()
+	}
+
+	if filepath.Base(path) != fmt.Sprintf("%x-a", id) {
+		return "the action ID doesn't match the file name"
+	}
+
+	info, err := os.Stat(c.fileName(entry.OutputID, "d"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "missing output"
+		}
+		return err.Error()
+	}
+	if info.Size() != entry.Size {
+		return fmt.Sprintf("the output size is %d, %d expected", info.Size(), entry.Size)
+	}
+
+	return ""
+}
+
+// verifyOutput returns the reason of the corruption of the output, empty if it's valid.
+func verifyOutput(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return err.Error()
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err.Error()
+	}
+
+	if filepath.Base(path) != hex.EncodeToString(h.Sum(nil))+"-d" {
+		return "the content doesn't match the output ID"
+	}
+
+	return ""
+}
+
+// WriteLastRun stores the statistics of the last run in the cache directory.
+func (c *Cache) WriteLastRun(run LastRun) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+	return renameio.WriteFile(filepath.Join(c.dir, lastRunFile), data, 0666)
+}
+
+// ReadLastRun reads the statistics of the last run, nil without run.
+func (c *Cache) ReadLastRun() (*LastRun, error) {
+	data, err := renameio.ReadFile(filepath.Join(c.dir, lastRunFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var run LastRun
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func parseUnix(s string) (time.Time, error) {
+	t, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(t, 0), nil
+}
@@ -0,0 +1,123 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	c := openTestCache(t)
+
+	if err := c.PutBytes(dummyID(1), []byte("abc")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.PutBytes(dummyID(2), []byte("abc")); err != nil { // same output
+		t.Fatal(err)
+	}
+
+	s, err := c.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Entries != 2 || s.Outputs != 1 || s.Size != 2*entrySize+3 {
+		t.Fatalf("Stats() = %d entries, %d outputs, %d bytes, want 2, 1, %d", s.Entries, s.Outputs, s.Size, 2*entrySize+3)
+	}
+	if s.Oldest.IsZero() || s.Newest.Before(s.Oldest) {
+		t.Fatalf("Stats() = oldest %v, newest %v", s.Oldest, s.Newest)
+	}
+}
+
+func TestTrimToSize(t *testing.T) {
+	c := openTestCache(t)
+
+	start := time.Now().Add(-time.Hour)
+	for i := 1; i <= 3; i++ {
+		c.now = func() time.Time { return start.Add(time.Duration(i) * time.Minute) }
+		if err := c.PutBytes(dummyID(i), []byte(fmt.Sprintf("data %d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Keeps only the entry and the output of the most recent action.
+	removed, freed, err := c.TrimToSize(entrySize + 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 4 || freed != 2*(entrySize+6) {
+		t.Fatalf("TrimToSize() = %d, %d, want 4, %d", removed, freed, 2*(entrySize+6))
+	}
+
+	for i := 1; i <= 2; i++ {
+		if _, _, err := c.GetBytes(dummyID(i)); !IsErrMissing(err) {
+			t.Fatalf("GetBytes(%d) = %v, want missing", i, err)
+		}
+	}
+	if data, _, err := c.GetBytes(dummyID(3)); err != nil || string(data) != "data 3" {
+		t.Fatalf("GetBytes(3) = %q, %v", data, err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	c := openTestCache(t)
+
+	for i := 1; i <= 3; i++ {
+		if err := c.PutBytes(dummyID(i), []byte(fmt.Sprintf("data %d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entry, err := c.get(dummyID(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(c.fileName(entry.OutputID, "d"), []byte("corrupted"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(c.fileName(dummyID(3), "a"), []byte("v1 truncated"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	checked, corrupted, err := c.Verify(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The output of 2 doesn't match its ID and has the wrong size, the entry of 3 can't be read.
+	if checked != 6 || len(corrupted) != 3 {
+		t.Fatalf("Verify(false) = %d, %v, want 6 checked, 3 corrupted", checked, corrupted)
+	}
+
+	if _, corrupted, err = c.Verify(true); err != nil || len(corrupted) != 3 {
+		t.Fatalf("Verify(true) = %v, %v, want 3 corrupted", corrupted, err)
+	}
+
+	// The output of 3 isn't corrupted, it's only unused.
+	checked, corrupted, err = c.Verify(false)
+	if err != nil || checked != 3 || len(corrupted) != 0 {
+		t.Fatalf("Verify(false) after purge = %d, %v, %v, want 3 checked, no corruption", checked, corrupted, err)
+	}
+
+	if data, _, err := c.GetBytes(dummyID(1)); err != nil || string(data) != "data 1" {
+		t.Fatalf("GetBytes(1) = %q, %v", data, err)
+	}
+}
+
+func TestLastRun(t *testing.T) {
+	c := openTestCache(t)
+
+	run, err := c.ReadLastRun()
+	if err != nil || run != nil {
+		t.Fatalf("ReadLastRun() = %v, %v, want nil", run, err)
+	}
+
+	want := LastRun{Time: time.Unix(1700000000, 0).UTC(), Hits: 12, Misses: 3}
+	if err = c.WriteLastRun(want); err != nil {
+		t.Fatal(err)
+	}
+
+	run, err = c.ReadLastRun()
+	if err != nil || run == nil || *run != want {
+		t.Fatalf("ReadLastRun() = %v, %v, want %v", run, err, want)
+	}
+}
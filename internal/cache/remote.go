@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+const defaultRemoteTimeout = 10 * time.Second
+
+// RemoteOptions configures a Remote cache.
+type RemoteOptions struct {
+	// URL is the base URL: entries are stored at `<URL>/<hex action id>`.
+	URL string
+	// ReadOnly disables uploads: useful for untrusted runners (e.g. pull requests from forks).
+	ReadOnly bool
+	// Timeout of a single request.
+	Timeout time.Duration
+	// Headers are added to every request, e.g. for authentication.
+	Headers map[string]string
+}
+
+// Remote is a read-through/write-through cache over HTTP in front of a local cache:
+// entries missing locally are downloaded, new entries are uploaded.
+// Any HTTP endpoint supporting GET and PUT can be used: a Bazel-like cache server,
+// an S3 or GCS bucket with its HTTP API, etc.
+// Remote failures are never fatal: the local cache is used alone.
+type Remote struct {
+	local  *Cache
+	opts   RemoteOptions
+	client *http.Client
+	debugf logutils.DebugFunc
+}
+
+func NewRemote(local *Cache, opts RemoteOptions) *Remote {
+	if opts.Timeout == 0 {
+		opts.Timeout = defaultRemoteTimeout
+	}
+	opts.URL = strings.TrimSuffix(opts.URL, "/")
+
+	return &Remote{
+		local:  local,
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout},
+		debugf: logutils.Debug("cache_remote"),
+	}
+}
+
+func (r *Remote) GetBytes(id ActionID) ([]byte, Entry, error) {
+	data, entry, err := r.local.GetBytes(id)
+	if err == nil || !IsErrMissing(err) {
+		return data, entry, err
+	}
+
+	data, err = r.download(id)
+	if err != nil {
+		if !IsErrMissing(err) {
+			r.debugf("Failed to download %x: %s", id, err)
+		}
+		return nil, Entry{}, errMissing
+	}
+
+	if err := r.local.PutBytes(id, data); err != nil {
+		r.debugf("Failed to store downloaded %x locally: %s", id, err)
+	}
+
+	return data, Entry{OutputID: sha256.Sum256(data), Size: int64(len(data)), Time: time.Now()}, nil
+}
+
+func (r *Remote) PutBytes(id ActionID, data []byte) error {
+	if err := r.local.PutBytes(id, data); err != nil {
+		return err
+	}
+
+	if r.opts.ReadOnly {
+		return nil
+	}
+
+	if err := r.upload(id, data); err != nil {
+		r.debugf("Failed to upload %x: %s", id, err)
+	}
+
+	return nil
+}
+
+func (r *Remote) Trim() {
+	r.local.Trim()
+}
+
+func (r *Remote) download(id ActionID) ([]byte, error) {
+	resp, err := r.do(http.MethodGet, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, errMissing
+	default:
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+}
+
+func (r *Remote) upload(id ActionID, data []byte) error {
+	resp, err := r.do(http.MethodPut, id, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (r *Remote) do(method string, id ActionID, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(context.Background(), method,
+		r.opts.URL+"/"+hex.EncodeToString(id[:]), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range r.opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	return r.client.Do(req)
+}
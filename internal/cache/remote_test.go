@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type memoryServer struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func (s *memoryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	switch r.Method {
+	case http.MethodGet:
+		data, ok := s.entries[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		s.entries[key] = data
+	}
+}
+
+func openTestCache(t *testing.T) *Cache {
+	t.Helper()
+
+	c, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	return c
+}
+
+func TestRemote(t *testing.T) {
+	srv := &memoryServer{entries: map[string][]byte{}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	opts := RemoteOptions{URL: ts.URL + "/", Headers: map[string]string{"Authorization": "Bearer token"}}
+
+	// First runner: computes and uploads.
+	r1 := NewRemote(openTestCache(t), opts)
+	if _, _, err := r1.GetBytes(dummyID(1)); !IsErrMissing(err) {
+		t.Fatalf("GetBytes(1) = %v, want missing", err)
+	}
+	if err := r1.PutBytes(dummyID(1), []byte("data")); err != nil {
+		t.Fatalf("PutBytes(1): %v", err)
+	}
+	if len(srv.entries) != 1 {
+		t.Fatalf("got %d remote entries, want 1", len(srv.entries))
+	}
+
+	// Second runner with an empty local cache: downloads.
+	local := openTestCache(t)
+	r2 := NewRemote(local, RemoteOptions{URL: opts.URL, Headers: opts.Headers, ReadOnly: true})
+	data, entry, err := r2.GetBytes(dummyID(1))
+	if err != nil || string(data) != "data" || entry.Size != 4 {
+		t.Fatalf("GetBytes(1) = %q, %d, %v, want %q, 4, nil", data, entry.Size, err, "data")
+	}
+	if data, _, err = local.GetBytes(dummyID(1)); err != nil || string(data) != "data" {
+		t.Fatalf("local.GetBytes(1) = %q, %v, want downloaded entry", data, err)
+	}
+
+	// Read-only runner doesn't upload.
+	if err := r2.PutBytes(dummyID(2), []byte("other")); err != nil {
+		t.Fatalf("PutBytes(2): %v", err)
+	}
+	if len(srv.entries) != 1 {
+		t.Fatalf("got %d remote entries, want 1", len(srv.entries))
+	}
+}
+
+func TestRemoteUnavailable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	r := NewRemote(openTestCache(t), RemoteOptions{URL: ts.URL})
+	if err := r.PutBytes(dummyID(1), []byte("data")); err != nil {
+		t.Fatalf("PutBytes(1): %v", err)
+	}
+	if data, _, err := r.GetBytes(dummyID(1)); err != nil || string(data) != "data" {
+		t.Fatalf("GetBytes(1) = %q, %v, want local entry", data, err)
+	}
+	if _, _, err := r.GetBytes(dummyID(2)); !IsErrMissing(err) {
+		t.Fatalf("GetBytes(2) = %v, want missing", err)
+	}
+}
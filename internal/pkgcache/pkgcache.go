@@ -2,17 +2,20 @@ package pkgcache
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/gob"
 	"encoding/hex"
 	"fmt"
 	"runtime"
 	"sort"
 	"sync"
+	"sync/atomic"
 
 	"github.com/pkg/errors"
 	"golang.org/x/tools/go/packages"
 
 	"github.com/golangci/golangci-lint/internal/cache"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/timeutils"
 )
@@ -28,20 +31,28 @@ const (
 // Cache is a per-package data cache. A cached data is invalidated when
 // package, or it's dependencies change.
 type Cache struct {
-	lowLevelCache *cache.Cache
+	lowLevelCache cache.Backend
 	pkgHashes     sync.Map
 	sw            *timeutils.Stopwatch
 	log           logutils.Log  // not used now, but may be needed for future debugging purposes
 	ioSem         chan struct{} // semaphore limiting parallel IO
+
+	hits, misses int64 // accessed atomically
 }
 
-func NewCache(sw *timeutils.Stopwatch, log logutils.Log) (*Cache, error) {
-	c, err := cache.Default()
-	if err != nil {
-		return nil, err
+// NewCache creates a packages cache over the low-level cache backend:
+// the default local cache is used if it's nil.
+func NewCache(backend cache.Backend, sw *timeutils.Stopwatch, log logutils.Log) (*Cache, error) {
+	if backend == nil {
+		c, err := cache.Default()
+		if err != nil {
+			return nil, err
+		}
+		backend = c
 	}
+
 	return &Cache{
-		lowLevelCache: c,
+		lowLevelCache: backend,
 		sw:            sw,
 		log:           log,
 		ioSem:         make(chan struct{}, runtime.GOMAXPROCS(-1)),
@@ -118,6 +129,7 @@ func (c *Cache) Get(pkg *packages.Package, mode HashMode, key string, data inter
 	<-c.ioSem
 	if err != nil {
 		if cache.IsErrMissing(err) {
+			atomic.AddInt64(&c.misses, 1)
 			return ErrMissing
 		}
 		return errors.Wrapf(err, "failed to get data from low-level cache by key %s for package %s", key, pkg.Name)
@@ -130,9 +142,15 @@ func (c *Cache) Get(pkg *packages.Package, mode HashMode, key string, data inter
 		return errors.Wrap(err, "failed to gob decode")
 	}
 
+	atomic.AddInt64(&c.hits, 1)
 	return nil
 }
 
+// Stats returns the counts of the data found and missing in the cache.
+func (c *Cache) Stats() (hits, misses int64) {
+	return atomic.LoadInt64(&c.hits), atomic.LoadInt64(&c.misses)
+}
+
 func (c *Cache) pkgActionID(pkg *packages.Package, mode HashMode) (cache.ActionID, error) {
 	hash, err := c.packageHash(pkg, mode)
 	if err != nil {
@@ -172,11 +190,17 @@ func (c *Cache) packageHash(pkg *packages.Package, mode HashMode) (string, error
 
 	fmt.Fprintf(key, "pkgpath %s\n", pkg.PkgPath)
 	for _, f := range pkg.CompiledGoFiles {
-		c.ioSem <- struct{}{}
-		h, fErr := cache.FileHash(f)
-		<-c.ioSem
-		if fErr != nil {
-			return "", errors.Wrapf(fErr, "failed to calculate file %s hash", f)
+		var h [cache.HashSize]byte
+		if content, ok := fsutils.OverlayContent(f); ok {
+			h = sha256.Sum256(content) // the file is replaced, e.g. by the standard input
+		} else {
+			var fErr error
+			c.ioSem <- struct{}{}
+			h, fErr = cache.FileHash(f)
+			<-c.ioSem
+			if fErr != nil {
+				return "", errors.Wrapf(fErr, "failed to calculate file %s hash", f)
+			}
 		}
 		fmt.Fprintf(key, "file %s %x\n", f, h)
 	}
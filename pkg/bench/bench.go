@@ -0,0 +1,267 @@
+// Package bench measures the performance of the linters: each linter is run alone several times over a corpus,
+// and the durations, the allocations and the cache usage of the runs are summarized to compare the versions.
+package bench
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/sliceutil"
+)
+
+const (
+	CacheCold = "cold"
+	CacheWarm = "warm"
+)
+
+// Options are the settings of a benchmark.
+type Options struct {
+	// Exe is the golangci-lint binary running the linters.
+	Exe string
+	// Dir is the directory of the corpus, the runs are started in it.
+	Dir string
+	// Corpus describes the corpus in the result.
+	Corpus string
+	// Args are the arguments of the runs: flags of the run command and packages.
+	Args []string
+	// Linters are benchmarked one at a time: the runs enable only the benchmarked linter.
+	Linters []string
+	// Runs is the count of measured runs of each linter.
+	Runs int
+	// Cache is CacheCold to start each run with an empty cache,
+	// or CacheWarm to fill the cache with a run which isn't measured first.
+	Cache string
+}
+
+// Result is the performance of the linters over a corpus.
+type Result struct {
+	Version   string
+	GoVersion string
+	Corpus    string
+	Cache     string
+	Runs      int
+	Linters   []LinterResult
+}
+
+// LinterResult is the performance of the runs of a linter.
+type LinterResult struct {
+	Name string
+	// DurationMs is the duration of the linter, without the loading of the packages.
+	DurationMs Stats
+	// LoadDurationMs is the duration of the loading of the packages for the linter.
+	LoadDurationMs Stats
+	// AllocatedMB is the memory allocated during the run of the linter.
+	AllocatedMB Stats
+	// HeapMB is the memory of the heap at the end of the run of the linter.
+	HeapMB Stats
+	// CacheHitRatio is the ratio of the data of the packages found in the cache, in all the runs.
+	CacheHitRatio float64
+	// Issues is the count of issues found by the linter, before the processing.
+	Issues int
+}
+
+// Stats summarize the samples of the runs.
+type Stats struct {
+	Mean   float64
+	Median float64
+	Min    float64
+	Max    float64
+	Stddev float64
+}
+
+// NewStats summarizes the samples.
+func NewStats(samples []float64) Stats {
+	if len(samples) == 0 {
+		return Stats{}
+	}
+
+	sorted := append([]float64{}, samples...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := sum / float64(len(sorted))
+
+	var variance float64
+	for _, v := range sorted {
+		variance += (v - mean) * (v - mean)
+	}
+
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + median) / 2
+	}
+
+	return Stats{
+		Mean:   mean,
+		Median: median,
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		Stddev: math.Sqrt(variance / float64(len(sorted))),
+	}
+}
+
+// Run benchmarks the linters.
+func Run(ctx context.Context, opts *Options, log logutils.Log) (*Result, error) {
+	if opts.Runs < 1 {
+		return nil, fmt.Errorf("invalid count of runs %d: must be at least 1", opts.Runs)
+	}
+	if opts.Cache != CacheCold && opts.Cache != CacheWarm {
+		return nil, fmt.Errorf("invalid cache mode %q: must be %s or %s", opts.Cache, CacheCold, CacheWarm)
+	}
+
+	tmp, err := os.MkdirTemp("", "golangci-lint-bench-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	res := &Result{
+		GoVersion: runtime.Version(),
+		Corpus:    opts.Corpus,
+		Cache:     opts.Cache,
+		Runs:      opts.Runs,
+	}
+
+	for _, name := range opts.Linters {
+		reports, err := runLinter(ctx, opts, filepath.Join(tmp, name), name, log)
+		if err != nil {
+			return nil, fmt.Errorf("can't benchmark %s: %w", name, err)
+		}
+
+		res.Version = reports[0].Version
+		res.Linters = append(res.Linters, Summarize(name, reports))
+	}
+
+	return res, nil
+}
+
+// runLinter runs the linter alone, and returns the reports of the measured runs.
+func runLinter(ctx context.Context, opts *Options, dir, name string, log logutils.Log) ([]report.RunReport, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	cacheDir := filepath.Join(dir, "cache")
+	if opts.Cache == CacheWarm {
+		log.Infof("Warming up the cache for %s", name)
+		if _, err := runOnce(ctx, opts, name, cacheDir, filepath.Join(dir, "warmup.json")); err != nil {
+			return nil, err
+		}
+	}
+
+	var reports []report.RunReport
+	for i := 0; i < opts.Runs; i++ {
+		if opts.Cache == CacheCold {
+			if err := os.RemoveAll(cacheDir); err != nil {
+				return nil, err
+			}
+		}
+
+		log.Infof("Running %s: %d/%d", name, i+1, opts.Runs)
+		rr, err := runOnce(ctx, opts, name, cacheDir, filepath.Join(dir, fmt.Sprintf("run-%d.json", i)))
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, *rr)
+	}
+
+	return reports, nil
+}
+
+// maxErrorOutput is the size of the end of the output of a failed run kept in its error.
+const maxErrorOutput = 1024
+
+// runOnce runs golangci-lint with only the linter enabled and the cache directory, and reads its run report.
+func runOnce(ctx context.Context, opts *Options, name, cacheDir, reportPath string) (*report.RunReport, error) {
+	args := append([]string{"run", "--disable-all", "--enable=" + name, "--issues-exit-code=0", "--report-file=" + reportPath},
+		opts.Args...)
+
+	cmd := exec.CommandContext(ctx, opts.Exe, args...)
+	cmd.Dir = opts.Dir
+	cmd.Env = append(os.Environ(), "GOLANGCI_LINT_CACHE="+cacheDir)
+	cmd.Stdout = io.Discard
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s %s: %w: %s", filepath.Base(opts.Exe), strings.Join(args, " "), err,
+			tail(strings.TrimSpace(stderr.String()), maxErrorOutput))
+	}
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		return nil, fmt.Errorf("can't read the run report: %w", err)
+	}
+
+	var rr report.RunReport
+	if err := json.Unmarshal(data, &rr); err != nil {
+		return nil, fmt.Errorf("can't parse the run report %s: %w", reportPath, err)
+	}
+
+	return &rr, nil
+}
+
+// Summarize summarizes the reports of the runs of the linter.
+// The runs of the linter are counted, including the runs combining it with other go/analysis linters.
+func Summarize(name string, reports []report.RunReport) LinterResult {
+	lr := LinterResult{Name: name}
+
+	var durations, loads, allocated, heaps []float64
+	var hits, misses int64
+	for i := range reports {
+		rr := &reports[i]
+
+		var duration, alloc, heap float64
+		issues := 0
+		for _, l := range rr.Linters {
+			if l.Name != name && !sliceutil.Contains(l.Linters, name) {
+				continue
+			}
+			duration += float64(l.DurationMs)
+			alloc += float64(l.AllocatedMB)
+			heap = math.Max(heap, float64(l.HeapMB))
+			issues += l.Issues
+		}
+
+		durations = append(durations, duration)
+		loads = append(loads, float64(rr.LoadDurationMs))
+		allocated = append(allocated, alloc)
+		heaps = append(heaps, heap)
+		hits += rr.Cache.Hits
+		misses += rr.Cache.Misses
+		lr.Issues = issues
+	}
+
+	lr.DurationMs = NewStats(durations)
+	lr.LoadDurationMs = NewStats(loads)
+	lr.AllocatedMB = NewStats(allocated)
+	lr.HeapMB = NewStats(heaps)
+	if hits+misses != 0 {
+		lr.CacheHitRatio = float64(hits) / float64(hits+misses)
+	}
+
+	return lr
+}
+
+func tail(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return "..." + s[len(s)-n:]
+}
@@ -0,0 +1,121 @@
+package bench
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/report"
+)
+
+func TestNewStats(t *testing.T) {
+	assert.Equal(t, Stats{}, NewStats(nil))
+	assert.Equal(t, Stats{Mean: 3, Median: 3, Min: 3, Max: 3}, NewStats([]float64{3}))
+
+	s := NewStats([]float64{4, 1, 3, 2})
+	assert.Equal(t, 2.5, s.Mean)
+	assert.Equal(t, 2.5, s.Median)
+	assert.Equal(t, 1.0, s.Min)
+	assert.Equal(t, 4.0, s.Max)
+	assert.InDelta(t, 1.118, s.Stddev, 0.001)
+
+	assert.Equal(t, 2.0, NewStats([]float64{10, 2, 1}).Median)
+}
+
+func TestSummarize(t *testing.T) {
+	reports := []report.RunReport{
+		{
+			LoadDurationMs: 100,
+			Cache:          report.CacheReport{Hits: 1, Misses: 3},
+			Linters: []report.LinterRunReport{
+				{Name: "goanalysis_metalinter", Linters: []string{"govet"}, DurationMs: 20, AllocatedMB: 5, HeapMB: 30, Issues: 2},
+				{Name: "dogsled", DurationMs: 1000},
+			},
+		},
+		{
+			LoadDurationMs: 80,
+			Cache:          report.CacheReport{Hits: 4},
+			Linters: []report.LinterRunReport{
+				{Name: "goanalysis_metalinter", Linters: []string{"govet"}, DurationMs: 10, AllocatedMB: 3, HeapMB: 20, Issues: 2},
+				// An inline run of the linter.
+				{Name: "goanalysis_metalinter", Linters: []string{"govet"}, DurationMs: 5, AllocatedMB: 1, HeapMB: 25},
+			},
+		},
+	}
+
+	lr := Summarize("govet", reports)
+	assert.Equal(t, "govet", lr.Name)
+	assert.Equal(t, Stats{Mean: 17.5, Median: 17.5, Min: 15, Max: 20, Stddev: 2.5}, lr.DurationMs)
+	assert.Equal(t, 90.0, lr.LoadDurationMs.Median)
+	assert.Equal(t, 4.5, lr.AllocatedMB.Median)
+	assert.Equal(t, 30.0, lr.HeapMB.Max)
+	assert.Equal(t, 0.625, lr.CacheHitRatio)
+	assert.Equal(t, 2, lr.Issues)
+}
+
+func newTestResult(durations map[string]float64, allocated float64) *Result {
+	res := &Result{}
+	for name, ms := range durations {
+		res.Linters = append(res.Linters, LinterResult{
+			Name:        name,
+			DurationMs:  Stats{Median: ms},
+			AllocatedMB: Stats{Median: allocated},
+		})
+	}
+	return res
+}
+
+func TestCompare(t *testing.T) {
+	old := newTestResult(map[string]float64{"govet": 100, "errcheck": 100, "dogsled": 2, "lll": 50}, 10)
+	current := newTestResult(map[string]float64{"govet": 150, "errcheck": 105, "dogsled": 4, "gosec": 300}, 10)
+
+	c := Compare(old, current, 0.1)
+	require.Len(t, c.Changes, 3)
+
+	assert.Equal(t, Change{Linter: "dogsled", OldMs: 2, NewMs: 4, Delta: 1, OldAllocatedMB: 10, NewAllocatedMB: 10}, c.Changes[0],
+		"too fast to regress")
+	assert.Equal(t, Change{Linter: "govet", OldMs: 100, NewMs: 150, Delta: 0.5, OldAllocatedMB: 10, NewAllocatedMB: 10, Regression: true},
+		c.Changes[1])
+	assert.Equal(t, "errcheck", c.Changes[2].Linter)
+	assert.False(t, c.Changes[2].Regression, "below the threshold")
+
+	assert.Equal(t, []string{"gosec"}, c.Added)
+	assert.Equal(t, []string{"lll"}, c.Removed)
+	assert.True(t, c.HasRegressions())
+
+	c = Compare(old, newTestResult(map[string]float64{"lll": 50}, 20), 0.1)
+	require.Len(t, c.Changes, 1)
+	assert.True(t, c.Changes[0].Regression, "allocations")
+}
+
+func TestGenerateCorpus(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, GenerateCorpus(dir, 3, 2))
+
+	files, err := filepath.Glob(filepath.Join(dir, "p*", "*.go"))
+	require.NoError(t, err)
+	assert.Len(t, files, 6)
+
+	fset := token.NewFileSet()
+	for _, f := range files {
+		_, err := parser.ParseFile(fset, f, nil, parser.AllErrors)
+		assert.NoError(t, err, f)
+	}
+
+	first, err := os.ReadFile(filepath.Join(dir, "p001", "f000.go"))
+	require.NoError(t, err)
+
+	other := t.TempDir()
+	require.NoError(t, GenerateCorpus(other, 3, 2))
+	second, err := os.ReadFile(filepath.Join(other, "p001", "f000.go"))
+	require.NoError(t, err)
+	assert.Equal(t, string(first), string(second), "reproducible")
+	assert.Contains(t, string(first), `"example.com/golangci-lint-bench/p000"`)
+
+	assert.Error(t, GenerateCorpus(t.TempDir(), 0, 1))
+}
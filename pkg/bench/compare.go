@@ -0,0 +1,102 @@
+package bench
+
+import "sort"
+
+// minDeltaMs is the increase of the duration below which a change isn't a regression:
+// the durations of the fastest linters fluctuate more than their relative change.
+const minDeltaMs = 10
+
+// Change is the change of the performance of a linter between two results.
+type Change struct {
+	Linter string
+	// OldMs and NewMs are the median durations.
+	OldMs float64
+	NewMs float64
+	// Delta is the relative change of the median duration: 0.25 is 25% slower.
+	Delta float64
+	// OldAllocatedMB and NewAllocatedMB are the median allocations.
+	OldAllocatedMB float64
+	NewAllocatedMB float64
+	// AllocatedDelta is the relative change of the median allocations.
+	AllocatedDelta float64
+	// Regression is set if the duration or the allocations increased more than the threshold.
+	Regression bool
+}
+
+// Comparison is the change of the performance of the linters of two results.
+type Comparison struct {
+	Changes []Change
+	// Added and Removed are the linters of only one result.
+	Added   []string `json:",omitempty"`
+	Removed []string `json:",omitempty"`
+}
+
+// HasRegressions checks if a linter regressed.
+func (c *Comparison) HasRegressions() bool {
+	for _, ch := range c.Changes {
+		if ch.Regression {
+			return true
+		}
+	}
+	return false
+}
+
+// Compare compares the medians of the linters of both results:
+// a relative increase of the duration or of the allocations above the threshold (0.1 for 10%) is a regression.
+func Compare(old, current *Result, threshold float64) Comparison {
+	oldByName := map[string]*LinterResult{}
+	for i := range old.Linters {
+		oldByName[old.Linters[i].Name] = &old.Linters[i]
+	}
+
+	var c Comparison
+	seen := map[string]bool{}
+	for i := range current.Linters {
+		cur := &current.Linters[i]
+		seen[cur.Name] = true
+
+		prev, ok := oldByName[cur.Name]
+		if !ok {
+			c.Added = append(c.Added, cur.Name)
+			continue
+		}
+
+		ch := Change{
+			Linter:         cur.Name,
+			OldMs:          prev.DurationMs.Median,
+			NewMs:          cur.DurationMs.Median,
+			Delta:          relativeDelta(prev.DurationMs.Median, cur.DurationMs.Median),
+			OldAllocatedMB: prev.AllocatedMB.Median,
+			NewAllocatedMB: cur.AllocatedMB.Median,
+			AllocatedDelta: relativeDelta(prev.AllocatedMB.Median, cur.AllocatedMB.Median),
+		}
+		ch.Regression = (ch.Delta > threshold && ch.NewMs-ch.OldMs >= minDeltaMs) ||
+			(ch.AllocatedDelta > threshold && ch.NewAllocatedMB-ch.OldAllocatedMB >= 1)
+
+		c.Changes = append(c.Changes, ch)
+	}
+
+	for _, lr := range old.Linters {
+		if !seen[lr.Name] {
+			c.Removed = append(c.Removed, lr.Name)
+		}
+	}
+
+	sort.Slice(c.Changes, func(i, j int) bool {
+		return c.Changes[i].Delta > c.Changes[j].Delta
+	})
+	sort.Strings(c.Added)
+	sort.Strings(c.Removed)
+
+	return c
+}
+
+func relativeDelta(old, current float64) float64 {
+	if old == 0 {
+		if current == 0 {
+			return 0
+		}
+		return 1
+	}
+	return (current - old) / old
+}
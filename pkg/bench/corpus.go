@@ -0,0 +1,143 @@
+package bench
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// syntheticModule is the module path of the synthetic corpus.
+const syntheticModule = "example.com/golangci-lint-bench"
+
+// GenerateCorpus writes a synthetic module of the packages and the files per package in the directory.
+// The corpus is always the same for the same counts: its results are comparable across versions.
+// The packages import the previous one, and the files contain the common constructs analyzed by the linters:
+// errors, loops, closures, methods, conversions and some issues.
+func GenerateCorpus(dir string, packages, files int) error {
+	if packages < 1 || files < 1 {
+		return fmt.Errorf("invalid synthetic corpus %dx%d: must have at least 1 package and 1 file", packages, files)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module "+syntheticModule+"\n\ngo 1.18\n"), 0o600); err != nil {
+		return err
+	}
+
+	for p := 0; p < packages; p++ {
+		name := fmt.Sprintf("p%03d", p)
+		pkgDir := filepath.Join(dir, name)
+		if err := os.MkdirAll(pkgDir, 0o700); err != nil {
+			return err
+		}
+
+		for f := 0; f < files; f++ {
+			content := syntheticFile(name, p, f)
+			if err := os.WriteFile(filepath.Join(pkgDir, fmt.Sprintf("f%03d.go", f)), []byte(content), 0o600); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func syntheticFile(pkg string, p, f int) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "package %s\n\nimport (\n\t\"errors\"\n\t\"fmt\"\n\t\"os\"\n\t\"strconv\"\n\t\"strings\"\n", pkg)
+	if p > 0 && f == 0 {
+		fmt.Fprintf(&b, "\n\tprev %q\n", fmt.Sprintf("%s/p%03d", syntheticModule, p-1))
+	}
+	b.WriteString(")\n\n")
+
+	if p > 0 && f == 0 {
+		b.WriteString("var _ = prev.Parse0\n\n")
+	}
+
+	fmt.Fprintf(&b, `var ErrEmpty%[1]d = errors.New("empty input")
+
+type Item%[1]d struct {
+	Name  string
+	Count int
+	Tags  []string
+}
+
+func (i *Item%[1]d) String() string {
+	return fmt.Sprintf("%%s (%%d): %%s", i.Name, i.Count, strings.Join(i.Tags, ","))
+}
+
+func (i Item%[1]d) Valid() bool {
+	return i.Name != "" && i.Count >= 0
+}
+
+func Parse%[1]d(s string) ([]Item%[1]d, error) {
+	if s == "" {
+		return nil, ErrEmpty%[1]d
+	}
+
+	var items []Item%[1]d
+	for _, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		count, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid count %%q: %%w", fields[1], err)
+		}
+
+		items = append(items, Item%[1]d{Name: fields[0], Count: count, Tags: fields[2:]})
+	}
+
+	return items, nil
+}
+
+func Total%[1]d(items []Item%[1]d) (total int) {
+	for i := 0; i < len(items); i++ {
+		if !items[i].Valid() {
+			continue
+		}
+		total += items[i].Count
+	}
+	return
+}
+
+func Filter%[1]d(items []Item%[1]d, keep func(Item%[1]d) bool) []Item%[1]d {
+	var ret []Item%[1]d
+	for _, item := range items {
+		item := item
+		if keep(item) {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
+
+func Write%[1]d(path string, items []Item%[1]d) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	for i := range items {
+		fmt.Fprintln(file, items[i].String())
+	}
+
+	return nil
+}
+
+func Lookup%[1]d(items map[string]Item%[1]d, name string) int {
+	value := 0
+	value = len(name)
+	if item, ok := items[name]; ok {
+		value = item.Count
+	}
+	_ = strconv.Itoa(value)
+	return int(int64(value))
+}
+`, f)
+
+	return b.String()
+}
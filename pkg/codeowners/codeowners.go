@@ -0,0 +1,142 @@
+// Package codeowners resolves the owners of files from a GitHub/GitLab CODEOWNERS file.
+package codeowners
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Locations are the paths of the CODEOWNERS file relative to the repository root, by priority.
+var Locations = []string{
+	"CODEOWNERS",
+	filepath.Join(".github", "CODEOWNERS"),
+	filepath.Join("docs", "CODEOWNERS"),
+}
+
+type rule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// Owners maps files to their owners: the last matching rule wins.
+type Owners struct {
+	rules []rule
+}
+
+// Find reads the first CODEOWNERS file found in the root directory, nil is returned if there is none.
+func Find(root string) (*Owners, error) {
+	for _, loc := range Locations {
+		f, err := os.Open(filepath.Join(root, loc))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		o, err := Parse(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("can't parse %s: %w", loc, err)
+		}
+
+		return o, nil
+	}
+
+	return nil, nil
+}
+
+// Parse reads the rules of a CODEOWNERS file.
+func Parse(r io.Reader) (*Owners, error) {
+	o := &Owners{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") {
+			continue // empty line, comment or GitLab section
+		}
+
+		fields := strings.Fields(line)
+
+		re, err := patternToRegexp(fields[0])
+		if err != nil {
+			return nil, err
+		}
+
+		var owners []string
+		for _, f := range fields[1:] {
+			if strings.HasPrefix(f, "#") {
+				break // trailing comment
+			}
+			owners = append(owners, f)
+		}
+
+		o.rules = append(o.rules, rule{pattern: re, owners: owners})
+	}
+
+	return o, scanner.Err()
+}
+
+// Of returns the owners of the file: the path is relative to the repository root.
+func (o *Owners) Of(path string) []string {
+	if o == nil {
+		return nil
+	}
+
+	path = strings.TrimPrefix(filepath.ToSlash(path), "./")
+
+	for i := len(o.rules) - 1; i >= 0; i-- {
+		if o.rules[i].pattern.MatchString(path) {
+			return o.rules[i].owners
+		}
+	}
+
+	return nil
+}
+
+// patternToRegexp converts a gitignore-like pattern of CODEOWNERS.
+func patternToRegexp(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("(^|/)")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '*' && i+1 < len(pattern) && pattern[i+1] == '*':
+			b.WriteString(".*")
+			i++
+			if i+1 < len(pattern) && pattern[i+1] == '/' {
+				i++ // `**/` matches zero or more directories
+				b.WriteString("/?")
+			}
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	// A directory matches all its files, a file matches itself.
+	if strings.HasSuffix(pattern, "/") {
+		b.WriteString(".*")
+	} else {
+		b.WriteString("(/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
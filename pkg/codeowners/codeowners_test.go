@@ -0,0 +1,63 @@
+package codeowners
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCodeowners = `# comment
+*                 @org/everyone
+*.md              @org/docs
+/pkg/             @org/backend
+/pkg/printers/    @org/output @alice # trailing comment
+docs/**/api.go    @bob
+internal          @org/internal
+`
+
+func TestOwners_Of(t *testing.T) {
+	o, err := Parse(strings.NewReader(testCodeowners))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		path     string
+		expected []string
+	}{
+		{path: "main.go", expected: []string{"@org/everyone"}},
+		{path: "README.md", expected: []string{"@org/docs"}},
+		{path: "pkg/config/config.go", expected: []string{"@org/backend"}},
+		{path: "./pkg/printers/text.go", expected: []string{"@org/output", "@alice"}},
+		{path: "docs/api.go", expected: []string{"@bob"}},
+		{path: "docs/v1/v2/api.go", expected: []string{"@bob"}},
+		{path: "cmd/internal/x.go", expected: []string{"@org/internal"}},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.path, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, o.Of(test.path))
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+
+	o, err := Find(root)
+	require.NoError(t, err)
+	assert.Nil(t, o)
+	assert.Nil(t, o.Of("main.go"))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".github"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".github", "CODEOWNERS"), []byte("* @alice\n"), 0o600))
+
+	o, err = Find(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@alice"}, o.Of("main.go"))
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const (
+	// autoAdoptIssuesThreshold is the count of issues from which the first run offers to create a baseline.
+	autoAdoptIssuesThreshold = 500
+
+	starterConfigPath   = ".golangci.yml"
+	starterBaselinePath = ".golangci-baseline.json"
+)
+
+const starterConfig = `# Starter configuration generated by golangci-lint.
+# Issues existing at the moment of adoption are recorded in the baseline:
+# only new issues are reported. Fix the old ones progressively and remove them from the baseline.
+# All options: https://golangci-lint.run/usage/configuration/
+issues:
+  baseline: ` + starterBaselinePath + `
+`
+
+// needAutoAdopt checks if the run is the first one in a repository without config
+// and a starter config with a baseline should be created.
+func (e *Executor) needAutoAdopt() bool {
+	if e.cfg.Run.NoConfig || e.getUsedConfig() != "" || e.baseline == nil || e.baseline.Unknown() == 0 {
+		return false
+	}
+
+	if e.cfg.Run.AutoAdopt {
+		return true
+	}
+
+	if e.baseline.Unknown() < autoAdoptIssuesThreshold || !isInteractive() {
+		return false
+	}
+
+	fmt.Fprintf(logutils.StdErr, "Found %d issues and no config file.\n"+
+		"Create %s and record the existing issues in %s to only report new ones? [y/N] ",
+		e.baseline.Unknown(), starterConfigPath, starterBaselinePath)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// autoAdopt writes the starter config and the baseline: the issues of the current run are hidden.
+func (e *Executor) autoAdopt(issues []result.Issue) ([]result.Issue, error) {
+	for _, path := range []string{starterConfigPath, starterBaselinePath} {
+		if _, err := os.Stat(path); err == nil {
+			return nil, fmt.Errorf("can't auto adopt: %s already exists", path)
+		}
+	}
+
+	if err := e.baseline.Save(starterBaselinePath); err != nil {
+		return nil, fmt.Errorf("can't write baseline: %w", err)
+	}
+
+	if err := os.WriteFile(starterConfigPath, []byte(starterConfig), defaultFileMode); err != nil {
+		return nil, fmt.Errorf("can't write starter config: %w", err)
+	}
+
+	fmt.Fprintf(logutils.StdErr, "Created %s and recorded %d existing issues in %s: 0 new issues to fix\n",
+		starterConfigPath, e.baseline.Unknown(), starterBaselinePath)
+
+	return issues[:0], nil
+}
+
+func isInteractive() bool {
+	fi, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode()&os.ModeCharDevice != 0
+}
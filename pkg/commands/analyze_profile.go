@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/profile"
+)
+
+func (e *Executor) initAnalyzeProfile() {
+	var top int
+	cmd := &cobra.Command{
+		Use:   "analyze-profile DIR",
+		Short: "Summarize the biggest costs of a run profiled with --profile-dir",
+		Long: `Print the durations of the loading of the packages, of the go commands, of the linters,
+of the analyzers and of the processors of a run profiled with --profile-dir, and the statistics of the caches.
+The CPU and heap profiles of the directory can be analyzed with 'go tool pprof', the trace with 'go tool trace'.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			e.executeAnalyzeProfile(args[0], top)
+		},
+	}
+	e.rootCmd.AddCommand(cmd)
+	cmd.Flags().IntVar(&top, "top", 10, wh("Count of the biggest costs printed by kind"))
+}
+
+// executeAnalyzeProfile runs the 'analyze-profile' CLI command.
+func (e *Executor) executeAnalyzeProfile(dir string, top int) {
+	t, err := profile.Read(dir)
+	if err != nil {
+		e.log.Fatalf("Can't read the profiling bundle: %s", err)
+	}
+
+	t.PrintSummary(logutils.StdOut, top)
+}
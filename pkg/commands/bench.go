@@ -0,0 +1,251 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/bench"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+type benchOptions struct {
+	linters   []string
+	runs      int
+	cache     string
+	synthetic string
+	format    string
+}
+
+func (e *Executor) initBench() {
+	var opts benchOptions
+	benchCmd := &cobra.Command{
+		Use:   "bench [-- run flags and packages]",
+		Short: "Benchmark the linters: run each linter alone several times, and report its durations, allocations and cache usage",
+		Long: `Benchmark the linters: run each linter alone several times over the packages (./... by default),
+and report the median, the mean, the min and the max of its duration, its allocations and the cache hit ratio of its runs.
+The arguments after "--" are passed to the runs, e.g. "-- --build-tags=integration ./pkg/...".
+
+With --cache=cold each run starts with an empty cache, with --cache=warm (default) the cache is filled by a first run which isn't measured.
+--synthetic=PACKAGESxFILES benchmarks a generated corpus instead, the same for the same counts, e.g. 50x10.
+Use --format=json to save the result, and 'golangci-lint bench compare' to compare the results of two versions.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e.executeBench(args, &opts)
+		},
+	}
+	fs := benchCmd.Flags()
+	fs.StringSliceVar(&opts.linters, "linters", nil, wh("Linters to benchmark (default the enabled linters)"))
+	fs.IntVar(&opts.runs, "runs", 5, wh("Count of measured runs of each linter"))
+	fs.StringVar(&opts.cache, "cache", bench.CacheWarm, wh("Cache of the runs: warm|cold"))
+	fs.StringVar(&opts.synthetic, "synthetic", "", wh("Benchmark a generated corpus of PACKAGESxFILES, e.g. 50x10"))
+	fs.StringVar(&opts.format, "format", "text", wh("Output format: text|json"))
+	e.rootCmd.AddCommand(benchCmd)
+
+	var threshold float64
+	var format string
+	compareCmd := &cobra.Command{
+		Use:   "compare <old.json> <new.json>",
+		Short: "Compare the results of two benchmarks, e.g. before and after an upgrade of the linters",
+		Long: `Compare the results of two benchmarks saved with --format=json: the median durations and allocations of the linters.
+An increase above the threshold is a regression (and an increase of at least 10ms for the durations, 1MB for the allocations):
+the exit code is 1 if a linter regressed.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e.executeBenchCompare(args, threshold, format)
+		},
+	}
+	compareCmd.Flags().Float64Var(&threshold, "threshold", 0.1, wh("Relative increase of a regression: 0.1 for 10%"))
+	compareCmd.Flags().StringVar(&format, "format", "text", wh("Output format: text|json"))
+	benchCmd.AddCommand(compareCmd)
+}
+
+// executeBench runs the 'bench' CLI command.
+func (e *Executor) executeBench(args []string, opts *benchOptions) {
+	if opts.format != "text" && opts.format != "json" {
+		e.log.Fatalf("Unknown format %q: must be text or json", opts.format)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		e.log.Fatalf("Can't find the golangci-lint binary: %s", err)
+	}
+
+	linters := opts.linters
+	if len(linters) == 0 {
+		linters, err = e.benchDefaultLinters()
+		if err != nil {
+			e.log.Fatalf("Can't get the enabled linters: %s", err)
+		}
+	}
+
+	if len(args) == 0 {
+		args = []string{"./..."}
+	}
+
+	bo := &bench.Options{
+		Exe:     exe,
+		Dir:     ".",
+		Corpus:  strings.Join(args, " "),
+		Args:    args,
+		Linters: linters,
+		Runs:    opts.runs,
+		Cache:   opts.cache,
+	}
+
+	if opts.synthetic != "" {
+		var packages, files int
+		if _, err := fmt.Sscanf(opts.synthetic, "%dx%d", &packages, &files); err != nil {
+			e.log.Fatalf("Invalid synthetic corpus %q: must be PACKAGESxFILES, e.g. 50x10", opts.synthetic)
+		}
+
+		dir, err := os.MkdirTemp("", "golangci-lint-bench-corpus-")
+		if err != nil {
+			e.log.Fatalf("Can't create the synthetic corpus: %s", err)
+		}
+		defer os.RemoveAll(dir)
+
+		if err := bench.GenerateCorpus(dir, packages, files); err != nil {
+			e.log.Fatalf("Can't create the synthetic corpus: %s", err)
+		}
+
+		bo.Dir = dir
+		bo.Corpus = "synthetic " + opts.synthetic
+	}
+
+	res, err := bench.Run(context.Background(), bo, e.log.Child("bench"))
+	if err != nil {
+		e.log.Fatalf("Benchmark failed: %s", err)
+	}
+
+	if opts.format == "json" {
+		if err := json.NewEncoder(logutils.StdOut).Encode(res); err != nil {
+			e.log.Fatalf("Can't print the benchmark: %s", err)
+		}
+		return
+	}
+
+	printBench(res)
+}
+
+// benchDefaultLinters returns the enabled linters, without the pseudo-linter of the type errors.
+func (e *Executor) benchDefaultLinters() ([]string, error) {
+	enabled, err := e.EnabledLintersSet.GetEnabledLintersMap()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name := range enabled {
+		if name != "typecheck" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func printBench(res *bench.Result) {
+	fmt.Fprintf(logutils.StdOut, "%s, %s, %d runs per linter, %s cache, corpus: %s\n\n",
+		res.Version, res.GoVersion, res.Runs, res.Cache, filepath.ToSlash(res.Corpus))
+
+	w := tabwriter.NewWriter(logutils.StdOut, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "Linter\tMedian\tMean\tMin\tMax\tAlloc MB\tHeap MB\tLoad\tCache hits\tIssues\t")
+	for i := range res.Linters {
+		lr := &res.Linters[i]
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%.0f\t%.0f\t%s\t%.0f%%\t%d\t\n",
+			lr.Name, formatMs(lr.DurationMs.Median), formatMs(lr.DurationMs.Mean),
+			formatMs(lr.DurationMs.Min), formatMs(lr.DurationMs.Max),
+			lr.AllocatedMB.Median, lr.HeapMB.Max, formatMs(lr.LoadDurationMs.Median), lr.CacheHitRatio*100, lr.Issues)
+	}
+	_ = w.Flush()
+}
+
+func formatMs(ms float64) string {
+	return fmt.Sprintf("%.0fms", ms)
+}
+
+// executeBenchCompare runs the 'bench compare' CLI command.
+func (e *Executor) executeBenchCompare(args []string, threshold float64, format string) {
+	if len(args) != 2 {
+		e.log.Fatalf("Usage: golangci-lint bench compare <old.json> <new.json>")
+	}
+	if format != "text" && format != "json" {
+		e.log.Fatalf("Unknown format %q: must be text or json", format)
+	}
+
+	old, err := readBenchResult(args[0])
+	if err != nil {
+		e.log.Fatalf("Can't read benchmark %s: %s", args[0], err)
+	}
+
+	current, err := readBenchResult(args[1])
+	if err != nil {
+		e.log.Fatalf("Can't read benchmark %s: %s", args[1], err)
+	}
+
+	if old.Corpus != current.Corpus || old.Cache != current.Cache {
+		e.log.Warnf("The benchmarks don't have the same corpus or cache: %q (%s cache) and %q (%s cache)",
+			old.Corpus, old.Cache, current.Corpus, current.Cache)
+	}
+
+	c := bench.Compare(old, current, threshold)
+
+	if format == "json" {
+		if err := json.NewEncoder(logutils.StdOut).Encode(c); err != nil {
+			e.log.Fatalf("Can't print the comparison: %s", err)
+		}
+	} else {
+		printBenchComparison(old, current, &c)
+	}
+
+	if c.HasRegressions() {
+		os.Exit(exitcodes.IssuesFound)
+	}
+	os.Exit(exitcodes.Success)
+}
+
+func readBenchResult(path string) (*bench.Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var res bench.Result
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func printBenchComparison(old, current *bench.Result, c *bench.Comparison) {
+	fmt.Fprintf(logutils.StdOut, "%s -> %s\n\n", old.Version, current.Version)
+
+	w := tabwriter.NewWriter(logutils.StdOut, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Linter\tMedian\tDelta\tAlloc MB\tDelta\t")
+	for _, ch := range c.Changes {
+		status := ""
+		if ch.Regression {
+			status = "REGRESSION"
+		}
+		fmt.Fprintf(w, "%s\t%s -> %s\t%+.1f%%\t%.0f -> %.0f\t%+.1f%%\t%s\n",
+			ch.Linter, formatMs(ch.OldMs), formatMs(ch.NewMs), ch.Delta*100,
+			ch.OldAllocatedMB, ch.NewAllocatedMB, ch.AllocatedDelta*100, status)
+	}
+	_ = w.Flush()
+
+	for _, name := range c.Added {
+		fmt.Fprintf(logutils.StdOut, "+ %s: only in the new benchmark\n", name)
+	}
+	for _, name := range c.Removed {
+		fmt.Fprintf(logutils.StdOut, "- %s: only in the old benchmark\n", name)
+	}
+}
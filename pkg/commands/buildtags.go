@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// defaultVariant is the name of the empty build tag set: only the tags of run.build-tags.
+const defaultVariant = "default"
+
+// runBuildTagSets analyzes the packages once per build tag set of run.build-tag-sets,
+// and merges the issues of the variants: the issues not reported by all the variants are labeled with their variants.
+func (e *Executor) runBuildTagSets(ctx context.Context, args []string) ([]result.Issue, error) {
+	if e.cfg.Issues.ChangedOnly {
+		return nil, errors.New("can't combine option run.build-tag-sets and --changed-only")
+	}
+
+	baseTags := e.cfg.Run.BuildTags
+	defer func() { e.cfg.Run.BuildTags = baseTags }()
+
+	// The linters run once per variant: the baseline, the limits, the fixes and the run report
+	// are applied once to the merged issues.
+	return e.runMergedAnalyses(ctx, args, func(analyze analyzeFunc) ([]result.Issue, error) {
+		variants := make([]string, 0, len(e.cfg.Run.BuildTagSets))
+		runs := make([][]result.Issue, 0, len(e.cfg.Run.BuildTagSets))
+
+		for _, set := range e.cfg.Run.BuildTagSets {
+			variant := variantName(set)
+			e.log.Infof("Analyzing the build tags variant %s", variant)
+
+			e.cfg.Run.BuildTags = append(append([]string{}, baseTags...), set...)
+
+			issues, err := analyze(args)
+			if err != nil {
+				return nil, fmt.Errorf("build tags variant %s: %w", variant, err)
+			}
+
+			variants = append(variants, variant)
+			runs = append(runs, issues)
+		}
+
+		return report.MergeVariants(variants, runs), nil
+	})
+}
+
+func variantName(set []string) string {
+	if len(set) == 0 {
+		return defaultVariant
+	}
+	return strings.Join(set, ",")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -38,8 +39,27 @@ func (e *Executor) initCache() {
 		Short: "Show cache status",
 		Run:   e.executeCacheStatus,
 	})
+	cacheCmd.AddCommand(&cobra.Command{
+		Use:   "stats",
+		Short: "Show cache statistics: size, entries, and hits and misses of the last run",
+		Run:   e.executeCacheStats,
+	})
+
+	trimCmd := &cobra.Command{
+		Use:   "trim",
+		Short: "Remove the least recently used cache entries down to a maximum size",
+		Run:   e.executeCacheTrim,
+	}
+	trimCmd.Flags().String("max-size", "", wh("Maximum size of the cache, e.g. 500MB or 2GiB"))
+	cacheCmd.AddCommand(trimCmd)
 
-	// TODO: add trim command?
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Detect and remove the corrupted cache entries",
+		Run:   e.executeCacheVerify,
+	}
+	verifyCmd.Flags().Bool("dry-run", false, wh("Only report the corrupted cache entries, without removing them"))
+	cacheCmd.AddCommand(verifyCmd)
 }
 
 func (e *Executor) executeCleanCache(_ *cobra.Command, args []string) {
@@ -70,11 +90,138 @@ func (e *Executor) executeCacheStatus(_ *cobra.Command, args []string) {
 	os.Exit(exitcodes.Success)
 }
 
+func (e *Executor) executeCacheStats(_ *cobra.Command, args []string) {
+	if len(args) != 0 {
+		e.log.Fatalf("Usage: golangci-lint cache stats")
+	}
+
+	c := e.openCache()
+
+	stats, err := c.Stats()
+	if err != nil {
+		e.log.Fatalf("Can't compute cache statistics: %s", err)
+	}
+
+	fmt.Fprintf(logutils.StdOut, "Dir: %s\n", cache.DefaultDir())
+	fmt.Fprintf(logutils.StdOut, "Size: %s\n", fsutils.PrettifyBytesCount(stats.Size))
+	fmt.Fprintf(logutils.StdOut, "Entries: %d\n", stats.Entries)
+	fmt.Fprintf(logutils.StdOut, "Outputs: %d\n", stats.Outputs)
+	if !stats.Oldest.IsZero() {
+		fmt.Fprintf(logutils.StdOut, "Used: from %s to %s\n",
+			stats.Oldest.Format(time.RFC3339), stats.Newest.Format(time.RFC3339))
+	}
+	if !stats.LastTrim.IsZero() {
+		fmt.Fprintf(logutils.StdOut, "Last trim: %s\n", stats.LastTrim.Format(time.RFC3339))
+	}
+
+	run, err := c.ReadLastRun()
+	if err != nil {
+		e.log.Warnf("Can't read the statistics of the last run: %s", err)
+	}
+	if run != nil {
+		ratio := 0.0
+		if total := run.Hits + run.Misses; total != 0 {
+			ratio = float64(run.Hits) / float64(total) * 100
+		}
+		fmt.Fprintf(logutils.StdOut, "Last run: %s, %d hits, %d misses (%.1f%% hit ratio)\n",
+			run.Time.Format(time.RFC3339), run.Hits, run.Misses, ratio)
+	} else {
+		fmt.Fprintln(logutils.StdOut, "Last run: none")
+	}
+
+	os.Exit(exitcodes.Success)
+}
+
+func (e *Executor) executeCacheTrim(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		e.log.Fatalf("Usage: golangci-lint cache trim --max-size SIZE")
+	}
+
+	maxSizeFlag, err := cmd.Flags().GetString("max-size")
+	if err != nil {
+		e.log.Fatalf("Can't get max-size flag: %s", err)
+	}
+	if maxSizeFlag == "" {
+		e.log.Fatalf("The maximum size is required: golangci-lint cache trim --max-size SIZE")
+	}
+
+	maxSize, err := fsutils.ParseBytesCount(maxSizeFlag)
+	if err != nil {
+		e.log.Fatalf("Invalid --max-size: %s", err)
+	}
+
+	removed, freed, err := e.openCache().TrimToSize(maxSize)
+	if err != nil {
+		e.log.Fatalf("Failed to trim cache: %s", err)
+	}
+
+	fmt.Fprintf(logutils.StdOut, "Removed %d file(s), %s freed\n", removed, fsutils.PrettifyBytesCount(freed))
+
+	os.Exit(exitcodes.Success)
+}
+
+func (e *Executor) executeCacheVerify(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		e.log.Fatalf("Usage: golangci-lint cache verify")
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		e.log.Fatalf("Can't get dry-run flag: %s", err)
+	}
+
+	checked, corrupted, err := e.openCache().Verify(!dryRun)
+	if err != nil {
+		e.log.Fatalf("Failed to verify cache: %s", err)
+	}
+
+	for _, c := range corrupted {
+		fmt.Fprintf(logutils.StdOut, "%s: %s\n", c.Path, c.Reason)
+	}
+
+	action := "removed"
+	if dryRun {
+		action = "found"
+	}
+	fmt.Fprintf(logutils.StdOut, "Checked %d file(s), %d corrupted file(s) %s\n", checked, len(corrupted), action)
+
+	if dryRun && len(corrupted) != 0 {
+		os.Exit(exitcodes.Failure)
+	}
+	os.Exit(exitcodes.Success)
+}
+
+func (e *Executor) openCache() *cache.Cache {
+	c, err := cache.Default()
+	if err != nil {
+		e.log.Fatalf("Failed to open cache: %s", err)
+	}
+	return c
+}
+
+// storeLastRun stores the hits and the misses of the packages cache, for the cache stats command.
+func (e *Executor) storeLastRun() {
+	hits, misses := e.pkgCache.Stats()
+	if hits+misses == 0 {
+		return
+	}
+
+	c, err := cache.Default()
+	if err != nil {
+		e.log.Infof("Failed to open cache: %s", err)
+		return
+	}
+
+	if err := c.WriteLastRun(cache.LastRun{Time: time.Now(), Hits: hits, Misses: misses}); err != nil {
+		e.log.Infof("Failed to store the cache statistics of the run: %s", err)
+	}
+}
+
 func dirSizeBytes(path string) (int64, error) {
 	var size int64
 	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
 		if err == nil && !info.IsDir() {
-			size = info.Size()
+			size += info.Size()
 		}
 		return err
 	})
@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/golangci/golangci-lint/internal/pkgcache"
+	"github.com/golangci/golangci-lint/pkg/commands/flagsets"
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// canary runs the linters with a proposed config, on the packages loaded for the current config.
+type canary struct {
+	cfg       *config.Config
+	dbManager *lintersdb.Manager
+	es        *lintersdb.EnabledSet
+	linters   []*linter.Config
+}
+
+// newCanary reads the proposed config like the current one: the command-line options have priority.
+func (e *Executor) newCanary(path string) (*canary, error) {
+	if e.cfg.Issues.NeedFix || e.cfg.Issues.UnsafeFix {
+		return nil, fmt.Errorf("can't combine option --canary-config and --fix")
+	}
+
+	commandLineCfg, err := e.getConfigForCommandLine()
+	if err != nil {
+		return nil, err
+	}
+	commandLineCfg.Run.Config = path
+	commandLineCfg.Run.NoConfig = false
+
+	// The flags set the default values, the config overwrites them, then the command-line overwrites the config.
+	cfg := config.NewDefault()
+	fs := pflag.NewFlagSet("canary flag set", pflag.ContinueOnError)
+	flagsets.AddRun(fs, cfg, e.DBManager, false)
+	flagsets.AddRoot(fs, cfg, true)
+
+	// The options of the current config are kept by the global viper.
+	r := config.NewFileReader(cfg, commandLineCfg, e.log.Child("canary_config_reader")).WithViper(viper.New())
+	if err = r.Read(); err != nil {
+		return nil, fmt.Errorf("can't read canary config %s: %w", path, err)
+	}
+
+	fixSlicesFlags(fs)
+	fs.Usage = func() {}
+	fs.ParseErrorsWhitelist.UnknownFlags = true
+	if err = fs.Parse(os.Args); err != nil {
+		return nil, fmt.Errorf("can't parse args: %s", err)
+	}
+
+	cfg.Run.Args = e.cfg.Run.Args
+	// The diff of the standard input is read once, by the current run.
+	cfg.Issues.DiffFile = e.cfg.Issues.DiffFile
+	if cfg.Run.Go == "" {
+		cfg.Run.Go = e.cfg.Run.Go
+	}
+
+	cfg.LintersSettings.Gocritic.InferEnabledChecks(e.log)
+	if err = cfg.LintersSettings.Gocritic.Validate(e.log); err != nil {
+		return nil, fmt.Errorf("invalid gocritic settings of canary config: %w", err)
+	}
+
+	c := &canary{cfg: cfg, dbManager: lintersdb.NewManager(cfg, e.log).WithCustomLinters()}
+	c.es = lintersdb.NewEnabledSet(c.dbManager, lintersdb.NewValidator(c.dbManager), e.log.Child("canary_lintersdb"), cfg)
+
+	c.linters, err = c.es.GetOptimizedLinters()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// runCanary runs the linters of the canary config: it returns the issues found only with the canary config,
+// and records the issues found only with the current config.
+// The issues are compared before the limiting processors: the issues hidden by the limits are found.
+// The packages are shared with the current run, but the results are cached with the salt of the canary config.
+func (e *Executor) runCanary(ctx context.Context, c *canary, lintCtx *linter.Context) ([]result.Issue, error) {
+	if err := lint.InitHashSalt(e.version, c.cfg); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := lint.InitHashSalt(e.version, e.cfg); err != nil {
+			e.log.Warnf("Failed to restore hash salt: %s", err)
+		}
+	}()
+
+	pkgCache, err := pkgcache.NewCache(e.newCacheBackend(), e.sw, e.log.Child("canary_pkgcache"))
+	if err != nil {
+		return nil, err
+	}
+
+	canaryCtx := *lintCtx
+	canaryCtx.Cfg = c.cfg
+	canaryCtx.PkgCache = pkgCache
+	canaryCtx.Log = e.log.Child("canary linters context")
+
+	runner, err := lint.NewRunner(c.cfg, e.log.Child("canary_runner"),
+		e.goenv, c.es, e.lineCache, c.dbManager, lintCtx.Packages)
+	if err != nil {
+		return nil, err
+	}
+
+	// The issues are processed at once: the runner doesn't stream them.
+	runner.OnLimiting = func(issues []result.Issue) []result.Issue {
+		var added []result.Issue
+		added, e.reportData.RemovedByCanary = report.CanaryDelta(e.unlimitedIssues, issues)
+		return added
+	}
+
+	return runner.Run(ctx, c.linters, &canaryCtx)
+}
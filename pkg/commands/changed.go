@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/internal/cache"
+	"github.com/golangci/golangci-lint/pkg/incremental"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/vcs"
+)
+
+// runChangedOnly analyzes only the packages affected by the changes since the revision of new-from-rev,
+// and reuses the issues of the previous run for the other packages.
+func (e *Executor) runChangedOnly(ctx context.Context, args []string) ([]result.Issue, error) {
+	rev := e.cfg.Issues.DiffFromRevision
+	if rev == "" {
+		return nil, errors.New("changed-only mode requires new-from-rev")
+	}
+
+	// All the issues of the analyzed packages are reported, not only the new ones.
+	e.cfg.Issues.DiffFromRevision = ""
+	e.cfg.Issues.Diff = false
+
+	pkgs, err := incremental.ListPackages(ctx, args, e.cfg.Run.BuildTags)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list packages")
+	}
+
+	repo, err := vcs.Detect(".")
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := repo.ChangedFiles(ctx, rev)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the files changed since %s", rev)
+	}
+
+	hashes, err := incremental.Hashes(pkgs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hash packages")
+	}
+
+	c, err := cache.Default()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open cache")
+	}
+
+	id, err := e.changedOnlyRunID(args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute previous run key")
+	}
+
+	previous := e.loadChangedOnlyState(c, id)
+
+	affected := incremental.Affected(pkgs, changed, hashes, previous)
+	e.log.Infof("Changed-only mode: analyzing %d/%d packages affected by the changes since %s",
+		len(affected), len(pkgs), rev)
+
+	// The issues of the packages are stored before the baseline and the limits:
+	// they're applied to the merged issues of all the packages.
+	return e.runMergedAnalyses(ctx, args, func(analyze analyzeFunc) ([]result.Issue, error) {
+		var issues []result.Issue
+		if len(affected) != 0 {
+			issues, err = analyze(incremental.Dirs(affected))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		merged, next := incremental.Merge(pkgs, affected, hashes, previous, issues)
+
+		data, err := json.Marshal(next)
+		if err != nil {
+			e.log.Warnf("Failed to encode run state: %s", err)
+		} else if err = c.PutBytes(id, data); err != nil {
+			e.log.Infof("Failed to store run state: %s", err)
+		}
+
+		return merged, nil
+	})
+}
+
+func (e *Executor) loadChangedOnlyState(c *cache.Cache, id cache.ActionID) *incremental.State {
+	data, _, err := c.GetBytes(id)
+	if err != nil {
+		if !cache.IsErrMissing(err) {
+			e.log.Infof("Failed to read previous run state: %s", err)
+		}
+		return nil
+	}
+
+	var state incremental.State
+	if err = json.Unmarshal(data, &state); err != nil {
+		e.log.Infof("Failed to decode previous run state: %s", err)
+		return nil
+	}
+
+	return &state
+}
+
+// changedOnlyRunID matches the runs by the working directory, the arguments,
+// the enabled linters, the issues and severity configuration, and the configuration (cache salt).
+func (e *Executor) changedOnlyRunID(args []string) (cache.ActionID, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return cache.ActionID{}, err
+	}
+
+	enabledLinters, err := e.EnabledLintersSet.GetEnabledLintersMap()
+	if err != nil {
+		return cache.ActionID{}, err
+	}
+
+	names := make([]string, 0, len(enabledLinters))
+	for name := range enabledLinters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	issuesConfig, err := json.Marshal([]interface{}{e.cfg.Issues, e.cfg.Severity})
+	if err != nil {
+		return cache.ActionID{}, err
+	}
+
+	h, err := cache.NewHash("changed-only run: unmerged issues")
+	if err != nil {
+		return cache.ActionID{}, err
+	}
+
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00", wd, strings.Join(args, "\x00"), strings.Join(names, ","))
+	h.Write(issuesConfig)
+
+	return h.Sum(), nil
+}
@@ -1,14 +1,22 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 
+	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/exitcodes"
 	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
 )
 
 func (e *Executor) initConfig() {
@@ -33,6 +41,33 @@ func (e *Executor) initConfig() {
 	}
 	e.initRunConfiguration(pathCmd) // allow --config
 	cmd.AddCommand(pathCmd)
+
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the used config file against the config schema",
+		Run:   e.executeValidateCmd,
+	}
+	e.initRunConfiguration(validateCmd) // allow --config
+	cmd.AddCommand(validateCmd)
+
+	var effectiveFormat string
+	effectiveCmd := &cobra.Command{
+		Use:   "effective",
+		Short: "Print the configuration resolved from the defaults, the environment, the config file and the flags, with the sources",
+		Run: func(cmd *cobra.Command, args []string) {
+			e.executeEffectiveCmd(cmd, args, effectiveFormat)
+		},
+	}
+	e.initRunConfiguration(effectiveCmd) // allow --config and the flags of run
+	effectiveCmd.Flags().StringVar(&effectiveFormat, "format", "yaml", wh("Output format: yaml|json"))
+	cmd.AddCommand(effectiveCmd)
+
+	schemaCmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of the config file",
+		Run:   e.executeSchemaCmd,
+	}
+	cmd.AddCommand(schemaCmd)
 }
 
 // getUsedConfig returns the resolved path to the golangci config file, or the empty string
@@ -66,3 +101,170 @@ func (e *Executor) executePathCmd(_ *cobra.Command, args []string) {
 	fmt.Println(usedConfigFile)
 	os.Exit(exitcodes.Success)
 }
+
+func (e *Executor) executeValidateCmd(_ *cobra.Command, args []string) {
+	if len(args) != 0 {
+		e.log.Fatalf("Usage: golangci-lint config validate")
+	}
+
+	usedConfigFile := e.getUsedConfig()
+	if usedConfigFile == "" {
+		e.log.Warnf("No config file detected")
+		os.Exit(exitcodes.NoConfigFileDetected)
+	}
+
+	if ext := filepath.Ext(usedConfigFile); ext == ".toml" {
+		e.log.Fatalf("Validation of %s config files is not supported", ext)
+	}
+
+	data, err := os.ReadFile(usedConfigFile)
+	if err != nil {
+		e.log.Fatalf("Can't read config file: %s", err)
+	}
+
+	enabledLinters, err := e.EnabledLintersSet.GetEnabledLintersMap()
+	if err != nil {
+		e.log.Fatalf("Can't get enabled linters: %s", err)
+	}
+
+	isLinterDisabled := func(name string) bool {
+		return e.DBManager.GetLinterConfigs(name) != nil && enabledLinters[name] == nil
+	}
+
+	schemaErrors, err := config.NewSchema().ValidateYAML(data, isLinterDisabled)
+	if err != nil {
+		e.log.Fatalf("Can't parse config file %s: %s", usedConfigFile, err)
+	}
+
+	for _, schemaErr := range schemaErrors {
+		fmt.Fprintf(logutils.StdOut, "%s:%s\n", usedConfigFile, schemaErr)
+	}
+
+	// The decoding errors are usually reported with their positions by the schema validation.
+	if len(schemaErrors) == 0 && e.configErr != nil {
+		fmt.Fprintf(logutils.StdOut, "%s: %s\n", usedConfigFile, e.configErr)
+	}
+
+	if len(schemaErrors) != 0 || e.configErr != nil {
+		os.Exit(exitcodes.Failure)
+	}
+
+	os.Exit(exitcodes.Success)
+}
+
+func (e *Executor) executeSchemaCmd(_ *cobra.Command, args []string) {
+	if len(args) != 0 {
+		e.log.Fatalf("Usage: golangci-lint config schema")
+	}
+
+	data, err := json.MarshalIndent(config.NewSchema(), "", "  ")
+	if err != nil {
+		e.log.Fatalf("Can't marshal config schema: %s", err)
+	}
+
+	fmt.Fprintln(logutils.StdOut, string(data))
+	os.Exit(exitcodes.Success)
+}
+
+// isConfigValidateCmd checks if the command is `config validate`:
+// the errors of the config file are reported by the command itself.
+func isConfigValidateCmd(args []string) bool {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == "config" && args[i+1] == "validate" {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Executor) executeEffectiveCmd(cmd *cobra.Command, args []string, format string) {
+	if len(args) != 0 {
+		e.log.Fatalf("Usage: golangci-lint config effective")
+	}
+
+	reasons, err := e.EnabledLintersSet.GetEnabledLintersReasons()
+	if err != nil {
+		e.log.Fatalf("Can't get enabled linters: %s", err)
+	}
+
+	values := e.cfg.Values()
+
+	effective := map[string]interface{}{
+		"config":  config.NestValues(values),
+		"sources": e.configSources(cmd, values),
+		"linters": reasons,
+	}
+
+	switch format {
+	case "yaml":
+		enc := yaml.NewEncoder(logutils.StdOut)
+		enc.SetIndent(2)
+		err = enc.Encode(effective)
+	case "json":
+		enc := json.NewEncoder(logutils.StdOut)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(effective)
+	default:
+		e.log.Fatalf("Unknown format %q: must be yaml or json", format)
+	}
+	if err != nil {
+		e.log.Fatalf("Can't print effective config: %s", err)
+	}
+
+	os.Exit(exitcodes.Success)
+}
+
+// configSources returns the source (`file`, `flag`, `env` or `go.mod`) of the values not set by default.
+func (e *Executor) configSources(cmd *cobra.Command, values []config.Value) map[string]string {
+	fileKeys := viper.AllKeys()
+
+	flagAddrs := map[uintptr]bool{}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if addr := flagValueAddr(f); addr != 0 {
+			flagAddrs[addr] = true
+		}
+	})
+
+	sources := map[string]string{}
+	for _, v := range values {
+		if flagAddrs[v.Addr] {
+			sources[v.Key] = "flag"
+			continue
+		}
+
+		for _, k := range fileKeys {
+			if k == v.Key || strings.HasPrefix(k, v.Key+".") {
+				sources[v.Key] = "file"
+				break
+			}
+		}
+
+		if _, ok := sources[v.Key]; !ok && e.derivedSources[v.Key] != "" {
+			sources[v.Key] = e.derivedSources[v.Key]
+		}
+	}
+
+	return sources
+}
+
+// flagValueAddr returns the address of the variable bound to the flag:
+// the pflag values are pointers to the variable, or structs holding it for slices.
+func flagValueAddr(f *pflag.Flag) uintptr {
+	v := reflect.ValueOf(f.Value)
+	if v.Kind() != reflect.Ptr {
+		return 0
+	}
+
+	if v.Elem().Kind() != reflect.Struct {
+		return v.Pointer()
+	}
+
+	sv := v.Elem()
+	for i := 0; i < sv.NumField(); i++ {
+		if sv.Field(i).Kind() == reflect.Ptr {
+			return sv.Field(i).Pointer()
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/plugins"
+)
+
+const defaultCustomBinary = "custom-gcl"
+
+func (e *Executor) initCustom() {
+	var opts plugins.BuildOptions
+
+	customCmd := &cobra.Command{
+		Use:   "custom",
+		Short: "Build a golangci-lint binary embedding the module-based custom linters from the config",
+		Run: func(cmd *cobra.Command, args []string) {
+			e.executeCustom(cmd.Flags(), args, opts)
+		},
+	}
+
+	fs := customCmd.Flags()
+	fs.SortFlags = false // sort them as they are defined here
+	fs.StringVarP(&opts.Output, "output", "o", defaultCustomBinary, wh("Path of the built binary"))
+	fs.StringVar(&opts.Version, "golangci-lint-version", e.version,
+		wh("Version of golangci-lint to build against (a release, a branch or a commit), the version of this binary by default"))
+	fs.StringVar(&opts.LocalDir, "golangci-lint-dir", "",
+		wh("Path to a local golangci-lint checkout to build against instead of a released version"))
+
+	e.rootCmd.AddCommand(customCmd)
+}
+
+func (e *Executor) executeCustom(fs *pflag.FlagSet, args []string, opts plugins.BuildOptions) {
+	if len(args) != 0 {
+		e.log.Fatalf("Usage: golangci-lint custom")
+	}
+
+	if !fs.Changed("golangci-lint-version") {
+		switch {
+		case opts.LocalDir != "":
+			opts.Version = "" // the version of the checkout isn't known
+		case !plugins.IsRelease(e.version):
+			// A development build can't be fetched: its version isn't a tag of the module.
+			e.log.Fatalf("The version %q of golangci-lint isn't a release: set --golangci-lint-version or --golangci-lint-dir", e.version)
+		default:
+			opts.Commit, opts.Date = e.commit, e.date
+		}
+	}
+
+	b := plugins.NewBuilder(e.log.Child("custom"), opts, e.cfg.LintersSettings.Custom)
+	if err := b.Build(context.Background()); err != nil {
+		e.log.Fatalf("Can't build custom binary: %s", err)
+	}
+
+	os.Exit(exitcodes.Success)
+}
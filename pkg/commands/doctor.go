@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/credentials"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+const doctorTimeout = 30 * time.Second
+
+func (e *Executor) initDoctor() {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the credentials of the integrations",
+		Long: `Check that the credentials of the configuration can be resolved,
+and that the values referencing credentials use defined ones.
+The values of the credentials are never printed.`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               e.executeDoctor,
+	}
+	e.rootCmd.AddCommand(cmd)
+
+	cmd.SetOut(logutils.StdOut) // use custom output to properly color it in Windows terminals
+	cmd.SetErr(logutils.StdErr)
+}
+
+// executeDoctor runs the 'doctor' CLI command.
+func (e *Executor) executeDoctor(_ *cobra.Command, _ []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	ok := e.checkCredentials(ctx)
+	cancel()
+
+	if !ok {
+		os.Exit(exitcodes.Failure)
+	}
+
+	os.Exit(exitcodes.Success)
+}
+
+// checkCredentials prints the result of the checks of the credentials and of their references.
+func (e *Executor) checkCredentials(ctx context.Context) bool {
+	failed := false
+
+	names := e.credentials.Names()
+	if len(names) == 0 {
+		fmt.Fprintln(logutils.StdOut, "No credentials are configured")
+	}
+
+	for _, name := range names {
+		value, err := e.credentials.Resolve(ctx, name)
+		if err != nil {
+			failed = true
+			fmt.Fprintf(logutils.StdOut, "FAIL credential %s: %s\n", name, err)
+			continue
+		}
+
+		fmt.Fprintf(logutils.StdOut, "OK   credential %s (%s, %d characters)\n", name, e.credentials.Source(name), len(value))
+	}
+
+	defined := map[string]bool{}
+	for _, name := range names {
+		defined[name] = true
+	}
+
+	for _, ref := range e.credentialReferences() {
+		for _, name := range credentials.References(ref.value) {
+			if !defined[name] {
+				failed = true
+				fmt.Fprintf(logutils.StdOut, "FAIL %s: credential %q is not defined\n", ref.option, name)
+			}
+		}
+	}
+
+	return !failed
+}
+
+type credentialReference struct {
+	option string
+	value  string
+}
+
+// credentialReferences returns the configuration values which can reference credentials.
+func (e *Executor) credentialReferences() []credentialReference {
+	var refs []credentialReference
+	for k, v := range e.cfg.Cache.Remote.Headers {
+		refs = append(refs, credentialReference{option: "cache.remote.headers." + k, value: v})
+	}
+
+	for i, s := range e.cfg.Report.Sinks {
+		for k, v := range s.Headers {
+			refs = append(refs, credentialReference{option: fmt.Sprintf("report.sinks[%d].headers.%s", i, k), value: v})
+		}
+	}
+
+	for k, v := range e.cfg.Issues.Suppressions.Headers {
+		refs = append(refs, credentialReference{option: "issues.suppressions.headers." + k, value: v})
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].option < refs[j].option
+	})
+
+	return refs
+}
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/envreport"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/goutil"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+const goEnvTimeout = 10 * time.Second
+
+// envReportGoKeys are the variables of 'go env' in the environment report.
+var envReportGoKeys = []goutil.EnvKey{
+	goutil.EnvGoVersion, goutil.EnvGoOS, goutil.EnvGoArch, goutil.EnvGoRoot,
+	goutil.EnvGoFlags, goutil.EnvGoPath, goutil.EnvGoCache, goutil.EnvGoModCache,
+	goutil.EnvGo111Module, goutil.EnvGoProxy, goutil.EnvGoPrivate, goutil.EnvGoWork,
+	goutil.EnvGoToolchain, goutil.EnvCgoEnabled,
+}
+
+func (e *Executor) initEnv() {
+	var format string
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Print the environment of golangci-lint to attach to a bug report",
+		Long: `Print the version and the build of golangci-lint, the Go environment, the system,
+the hash of the effective configuration and the enabled linters.
+The home directory and the credentials of the URLs are redacted.`,
+		Aliases:           []string{"bugreport"},
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run: func(_ *cobra.Command, _ []string) {
+			e.executeEnv(format)
+		},
+	}
+	e.rootCmd.AddCommand(cmd)
+
+	e.initRunConfiguration(cmd) // allow --config and the flags of run
+	cmd.Flags().StringVar(&format, "format", "markdown", wh("Output format: markdown|json"))
+}
+
+// executeEnv runs the 'env' CLI command.
+func (e *Executor) executeEnv(format string) {
+	r := &envreport.Report{
+		Version: e.version,
+		Commit:  e.commit,
+		Date:    e.date,
+		Build:   envreport.NewBuild(),
+		Go:      map[string]string{},
+		OS:      envreport.NewOS(),
+		Config:  envreport.Config{Path: e.getUsedConfig()},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), goEnvTimeout)
+	if err := e.goenv.DiscoverKeys(ctx, envReportGoKeys...); err != nil {
+		e.log.Warnf("Can't read the Go environment: %s", err)
+	}
+	cancel()
+	for _, k := range envReportGoKeys {
+		r.Go[string(k)] = e.goenv.Get(k)
+	}
+
+	hash, err := envreport.ConfigHash(config.NestValues(e.cfg.Values()))
+	if err != nil {
+		e.log.Fatalf("Can't hash the config: %s", err)
+	}
+	r.Config.Hash = hash
+
+	enabled, err := e.EnabledLintersSet.GetEnabledLintersMap()
+	if err != nil {
+		e.log.Fatalf("Can't get enabled linters: %s", err)
+	}
+	for name := range enabled {
+		r.Linters = append(r.Linters, name)
+	}
+	sort.Strings(r.Linters)
+
+	home, _ := os.UserHomeDir()
+	r.Redact(home, logutils.Redact)
+
+	switch format {
+	case "markdown":
+		err = r.WriteMarkdown(logutils.StdOut)
+	case "json":
+		err = r.WriteJSON(logutils.StdOut)
+	default:
+		e.log.Fatalf("Unknown format %q: must be markdown or json", format)
+	}
+	if err != nil {
+		e.log.Fatalf("Can't print the environment: %s", err)
+	}
+
+	os.Exit(exitcodes.Success)
+}
@@ -1,32 +1,31 @@
 package commands
 
 import (
-	"bytes"
 	"context"
-	"crypto/sha256"
-	"io"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/gofrs/flock"
-	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
-	"gopkg.in/yaml.v3"
 
 	"github.com/golangci/golangci-lint/internal/cache"
 	"github.com/golangci/golangci-lint/internal/pkgcache"
 	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/credentials"
 	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis/load"
 	"github.com/golangci/golangci-lint/pkg/goutil"
 	"github.com/golangci/golangci-lint/pkg/lint"
 	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
 	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/profile"
+	"github.com/golangci/golangci-lint/pkg/progress"
 	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
 	"github.com/golangci/golangci-lint/pkg/timeutils"
 )
 
@@ -36,6 +35,8 @@ type Executor struct {
 	lintersCmd *cobra.Command
 
 	exitCode              int
+	configErr             error
+	derivedSources        map[string]string // sources of the config values detected from the environment, by key
 	version, commit, date string
 
 	cfg               *config.Config // cfg is the unmarshaled data from the golangci config file.
@@ -50,6 +51,16 @@ type Executor struct {
 	pkgCache          *pkgcache.Cache
 	debugf            logutils.DebugFunc
 	sw                *timeutils.Stopwatch
+	baseline          *processors.Baseline
+	// unlimitedIssues are the issues of the run before the limiting processors, e.g. for the fixed issues.
+	unlimitedIssues []result.Issue
+	credentials     *credentials.Resolver
+	profile         *profile.Bundle
+	progress        *progress.Display // nil if the progress isn't shown
+
+	// streamed are the outputs printed as the issues are found, issuesStream prints the issues to them.
+	streamed     []*streamedOutput
+	issuesStream func(issues []result.Issue)
 
 	loadGuard *load.Guard
 	flock     *flock.Flock
@@ -77,7 +88,13 @@ func NewExecutor(version, commit, date string) *Executor {
 		e.log.Fatalf("Can't get config for command line: %s", err)
 	}
 	if commandLineCfg != nil {
-		logutils.SetupVerboseLog(e.log, commandLineCfg.Run.IsVerbose)
+		rc := &commandLineCfg.Run
+		if err = logutils.SetupLogLevel(e.log, rc.LogLevel, rc.IsVerbose, rc.LogDebug); err != nil {
+			e.log.Fatalf("%s", err)
+		}
+		if err = logutils.SetupLogFormat(commandLineCfg.Run.LogFormat); err != nil {
+			e.log.Fatalf("%s", err)
+		}
 
 		switch commandLineCfg.Output.Color {
 		case "always":
@@ -100,6 +117,24 @@ func NewExecutor(version, commit, date string) *Executor {
 	e.initConfig()
 	e.initVersion()
 	e.initCache()
+	e.initCustom()
+	e.initCheckSnippet()
+	e.initNolints()
+	e.initReport()
+	e.initFixServer()
+	e.initDoctor()
+	e.initStats()
+	e.initScore()
+	e.initExplain()
+	e.initMigrate()
+	e.initAnalyzeProfile()
+	e.initPolicy()
+	e.initIssues()
+	e.initMergeResults()
+	e.initEnv()
+	e.initHooks()
+	e.initSuppress()
+	e.initBench()
 
 	// init e.cfg by values from config: flags parse will see these values
 	// like the default ones. It will overwrite them only if the same option
@@ -107,11 +142,18 @@ func NewExecutor(version, commit, date string) *Executor {
 
 	r := config.NewFileReader(e.cfg, commandLineCfg, e.log.Child("config_reader"))
 	if err = r.Read(); err != nil {
-		e.log.Fatalf("Can't read config: %s", err)
+		if !isConfigValidateCmd(os.Args[1:]) {
+			e.log.Fatalf("Can't read config: %s", err)
+		}
+		e.configErr = err
 	}
 
 	if (commandLineCfg == nil || commandLineCfg.Run.Go == "") && e.cfg != nil && e.cfg.Run.Go == "" {
-		e.cfg.Run.Go = config.DetectGoVersion()
+		var source string
+		e.cfg.Run.Go, source = config.DetectGoVersionSource()
+		if source != "" {
+			e.derivedSources = map[string]string{"run.go": source}
+		}
 	}
 
 	// recreate after getting config
@@ -132,15 +174,17 @@ func NewExecutor(version, commit, date string) *Executor {
 	e.fileCache = fsutils.NewFileCache()
 	e.lineCache = fsutils.NewLineCache(e.fileCache)
 
+	e.credentials = credentials.NewResolver(e.cfg.Credentials)
+
 	e.sw = timeutils.NewStopwatch("pkgcache", e.log.Child("stopwatch"))
-	e.pkgCache, err = pkgcache.NewCache(e.sw, e.log.Child("pkgcache"))
+	e.pkgCache, err = pkgcache.NewCache(e.newCacheBackend(), e.sw, e.log.Child("pkgcache"))
 	if err != nil {
 		e.log.Fatalf("Failed to build packages cache: %s", err)
 	}
 	e.loadGuard = load.NewGuard()
 	e.contextLoader = lint.NewContextLoader(e.cfg, e.log.Child("loader"), e.goenv,
 		e.lineCache, e.fileCache, e.pkgCache, e.loadGuard)
-	if err = e.initHashSalt(version); err != nil {
+	if err = lint.InitHashSalt(version, e.cfg); err != nil {
 		e.log.Fatalf("Failed to init hash salt: %s", err)
 	}
 	e.debugf("Initialized executor in %s", time.Since(startedAt))
@@ -151,68 +195,13 @@ func (e *Executor) Execute() error {
 	return e.rootCmd.Execute()
 }
 
-func (e *Executor) initHashSalt(version string) error {
-	binSalt, err := computeBinarySalt(version)
+// newCacheBackend returns the remote cache backend if it's configured, nil otherwise.
+func (e *Executor) newCacheBackend() cache.Backend {
+	backend, err := lint.NewCacheBackend(e.cfg, e.credentials, e.log)
 	if err != nil {
-		return errors.Wrap(err, "failed to calculate binary salt")
-	}
-
-	configSalt, err := computeConfigSalt(e.cfg)
-	if err != nil {
-		return errors.Wrap(err, "failed to calculate config salt")
-	}
-
-	var b bytes.Buffer
-	b.Write(binSalt)
-	b.Write(configSalt)
-	cache.SetSalt(b.Bytes())
-	return nil
-}
-
-func computeBinarySalt(version string) ([]byte, error) {
-	if version != "" && version != "(devel)" {
-		return []byte(version), nil
-	}
-
-	if logutils.HaveDebugTag("bin_salt") {
-		return []byte("debug"), nil
-	}
-
-	p, err := os.Executable()
-	if err != nil {
-		return nil, err
-	}
-	f, err := os.Open(p)
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-	h := sha256.New()
-	if _, err := io.Copy(h, f); err != nil {
-		return nil, err
-	}
-	return h.Sum(nil), nil
-}
-
-func computeConfigSalt(cfg *config.Config) ([]byte, error) {
-	// We don't hash all config fields to reduce meaningless cache
-	// invalidations. At least, it has a huge impact on tests speed.
-
-	lintersSettingsBytes, err := yaml.Marshal(cfg.LintersSettings)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to json marshal config linter settings")
-	}
-
-	var configData bytes.Buffer
-	configData.WriteString("linters-settings=")
-	configData.Write(lintersSettingsBytes)
-	configData.WriteString("\nbuild-tags=%s" + strings.Join(cfg.Run.BuildTags, ","))
-
-	h := sha256.New()
-	if _, err := h.Write(configData.Bytes()); err != nil {
-		return nil, err
+		e.log.Fatalf("%s", err)
 	}
-	return h.Sum(nil), nil
+	return backend
 }
 
 func (e *Executor) acquireFileLock() bool {
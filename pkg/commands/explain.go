@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func (e *Executor) initExplain() {
+	cmd := &cobra.Command{
+		Use:   "explain <linter>[:<rule>]",
+		Short: "Explain a linter or one of its rules",
+		Long: `Print the documentation of a linter or of one of its rules (e.g. govet:printf, staticcheck:SA4006):
+examples of reported and fixed code, and the options of the settings of the linter.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run:               e.executeExplain,
+	}
+	e.rootCmd.AddCommand(cmd)
+
+	cmd.SetOut(logutils.StdOut) // use custom output to properly color it in Windows terminals
+	cmd.SetErr(logutils.StdErr)
+}
+
+// executeExplain runs the 'explain' CLI command.
+func (e *Executor) executeExplain(_ *cobra.Command, args []string) {
+	name, ruleName, _ := strings.Cut(args[0], ":")
+
+	lcs := e.DBManager.GetLinterConfigs(name)
+	if len(lcs) == 0 {
+		e.log.Fatalf("Unknown linter %q: see 'golangci-lint help linters'", name)
+	}
+
+	lc := lcs[0]
+	doc := lintersdb.GetLinterDoc(lc)
+
+	if ruleName != "" {
+		fullName, rule, ok := doc.FindRule(ruleName)
+		if !ok {
+			if len(doc.Rules) == 0 {
+				e.log.Fatalf("No documented rules for linter %s: see %s", lc.Name(), lc.OriginalURL)
+			}
+			e.log.Fatalf("Unknown rule %q of linter %s: must be one of %s", ruleName, lc.Name(), strings.Join(doc.RuleNames(), ", "))
+		}
+
+		printRuleDoc(logutils.StdOut, lc, fullName, rule)
+		os.Exit(exitcodes.Success)
+	}
+
+	printLinterDoc(logutils.StdOut, lc, doc)
+	os.Exit(exitcodes.Success)
+}
+
+func printLinterDoc(w io.Writer, lc *linter.Config, doc lintersdb.LinterDoc) {
+	fmt.Fprintf(w, "%s: %s\n", color.YellowString(lc.Name()), lc.Linter.Desc())
+	if lc.IsDeprecated() {
+		fmt.Fprintf(w, "%s since %s: %s", color.RedString("Deprecated"), lc.Deprecation.Since, lc.Deprecation.Message)
+		if lc.Deprecation.Replacement != "" {
+			fmt.Fprintf(w, " Replaced by %s.", lc.Deprecation.Replacement)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w)
+	if lc.OriginalURL != "" {
+		fmt.Fprintf(w, "URL: %s\n", lc.OriginalURL)
+	}
+	if len(lc.InPresets) != 0 {
+		fmt.Fprintf(w, "Presets: %s\n", strings.Join(lc.InPresets, ", "))
+	}
+	if lc.Since != "" {
+		fmt.Fprintf(w, "Since: %s\n", lc.Since)
+	}
+	fmt.Fprintf(w, "Enabled by default: %t, fast: %t, auto-fix: %t\n", lc.EnabledByDefault, !lc.IsSlowLinter(), lc.CanAutoFix)
+
+	printDocDetails(w, doc.Details, doc.Bad, doc.Good)
+
+	if len(doc.Rules) != 0 {
+		fmt.Fprintf(w, "\n%s\n", color.GreenString("Rules:"))
+		for _, name := range doc.RuleNames() {
+			fmt.Fprintf(w, "  %s: %s\n", color.YellowString(name), doc.Rules[name].Desc)
+		}
+	}
+
+	if settings := linterSettingsSchema(lc.Name()); settings != nil {
+		fmt.Fprintf(w, "\n%s\n", color.GreenString("Settings (linters-settings.%s):", lc.Name()))
+		printSchemaProperties(w, settings, "  ")
+	}
+}
+
+func printRuleDoc(w io.Writer, lc *linter.Config, name string, rule lintersdb.RuleDoc) {
+	fmt.Fprintf(w, "%s:%s: %s\n", color.YellowString(lc.Name()), color.YellowString(name), rule.Desc)
+
+	printDocDetails(w, rule.Details, rule.Bad, rule.Good)
+}
+
+func printDocDetails(w io.Writer, details, bad, good string) {
+	if details != "" {
+		fmt.Fprintf(w, "\n%s\n", details)
+	}
+	if bad != "" {
+		fmt.Fprintf(w, "\n%s\n%s\n", color.RedString("Reported:"), indent(bad, "    "))
+	}
+	if good != "" {
+		fmt.Fprintf(w, "\n%s\n%s\n", color.GreenString("Fixed:"), indent(good, "    "))
+	}
+}
+
+// linterSettingsSchema returns the schema of the settings of the linter, nil if it has no settings.
+func linterSettingsSchema(name string) *config.Schema {
+	settings := config.NewSchema().Properties["linters-settings"].Properties[strings.ToLower(name)]
+	if settings == nil || len(settings.Properties) == 0 {
+		return nil
+	}
+	return settings
+}
+
+func printSchemaProperties(w io.Writer, s *config.Schema, prefix string) {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		p := s.Properties[name]
+		fmt.Fprintf(w, "%s%s: %s\n", prefix, name, schemaTypeName(p))
+
+		if len(p.Properties) != 0 {
+			printSchemaProperties(w, p, prefix+"  ")
+		} else if p.Items != nil && len(p.Items.Properties) != 0 {
+			printSchemaProperties(w, p.Items, prefix+"  - ")
+		}
+	}
+}
+
+func schemaTypeName(s *config.Schema) string {
+	switch {
+	case len(s.Type) == 0:
+		return "any"
+	case s.Format != "":
+		return s.Format
+	case s.Items != nil:
+		return "list of " + schemaTypeName(s.Items)
+	case len(s.Type) == 1 && s.Type[0] == "object" && s.Properties == nil:
+		if values, ok := s.AdditionalProperties.(*config.Schema); ok {
+			return "map of " + schemaTypeName(values)
+		}
+	}
+	return strings.Join(s.Type, "|")
+}
+
+func indent(s, prefix string) string {
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golangci/golangci-lint/internal/cache"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// fixedSincePreviousRun returns the issues of the previous run absent from the current one,
+// and stores the current issues for the next run: the issues are the ones before the limiting processors.
+// The runs are matched by the working directory, the arguments and the configuration (cache salt).
+func (e *Executor) fixedSincePreviousRun(issues []result.Issue) []report.IssueRef {
+	c, err := cache.Default()
+	if err != nil {
+		e.log.Warnf("Failed to open cache: %s", err)
+		return nil
+	}
+
+	id, err := previousRunID(e.cfg.Run.Args)
+	if err != nil {
+		e.log.Warnf("Failed to compute previous run key: %s", err)
+		return nil
+	}
+
+	current := report.NewIssueRefs(issues)
+
+	var fixed []report.IssueRef
+
+	data, _, err := c.GetBytes(id)
+	switch {
+	case err == nil:
+		var previous []report.IssueRef
+		if err = json.Unmarshal(data, &previous); err != nil {
+			e.log.Infof("Failed to decode previous run: %s", err)
+			break
+		}
+		fixed = report.Fixed(previous, current)
+	case !cache.IsErrMissing(err):
+		e.log.Infof("Failed to read previous run: %s", err)
+	}
+
+	data, err = json.Marshal(current)
+	if err != nil {
+		e.log.Warnf("Failed to encode run issues: %s", err)
+		return fixed
+	}
+
+	if err = c.PutBytes(id, data); err != nil {
+		e.log.Infof("Failed to store run issues: %s", err)
+	}
+
+	return fixed
+}
+
+func previousRunID(args []string) (cache.ActionID, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return cache.ActionID{}, err
+	}
+
+	h, err := cache.NewHash("previous run")
+	if err != nil {
+		return cache.ActionID{}, err
+	}
+
+	fmt.Fprintf(h, "%s\x00%s", wd, strings.Join(args, "\x00"))
+
+	return h.Sum(), nil
+}
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/fixpreview"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const fixServerReadTimeout = 10 * time.Second
+
+func (e *Executor) initFixServer() {
+	var addr string
+	cmd := &cobra.Command{
+		Use:   "fix-server",
+		Short: "Run the linters and serve the fixes of the issues over HTTP",
+		Long: `Run the linters once and serve the issues and their fixes over HTTP,
+for the editors to preview and apply the fixes without running the analysis:
+
+  GET /issues                  the issues with their fingerprints
+  GET /fix?fingerprint=<hash>  the fix of an issue: a text edit and a unified diff
+
+A fix is refused with 409 if its line changed since the analysis: restart the server to analyze the changes.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e.executeFixServer(args, addr)
+		},
+	}
+	e.rootCmd.AddCommand(cmd)
+
+	cmd.SetOut(logutils.StdOut) // use custom output to properly color it in Windows terminals
+	cmd.SetErr(logutils.StdErr)
+
+	e.initRunConfiguration(cmd)
+	cmd.Flags().StringVar(&addr, "addr", "127.0.0.1:7878", wh("Address to listen on"))
+}
+
+// executeFixServer runs the 'fix-server' CLI command.
+func (e *Executor) executeFixServer(args []string, addr string) {
+	// the fixes are served, not applied
+	e.cfg.Issues.NeedFix = false
+	// the source lines detect the files changed since the analysis
+	e.cfg.Output.PrintIssuedLine = true
+
+	e.setTimeoutToDeadlineIfOnlyDeadlineIsSet()
+	ctx, cancel := context.WithTimeout(context.Background(), e.cfg.Run.Timeout)
+
+	issues, err := e.runFixServerAnalysis(ctx, args)
+	cancel()
+	if err != nil {
+		e.log.Fatalf("Running error: %s", err)
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		e.log.Fatalf("Can't listen on %s: %s", addr, err)
+	}
+
+	server := &http.Server{
+		Handler:           fixpreview.NewServer(issues),
+		ReadHeaderTimeout: fixServerReadTimeout,
+	}
+
+	go func() {
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+		<-stop
+		_ = server.Close()
+	}()
+
+	e.log.Infof("Serving the fixes of %d issues on http://%s", len(issues), listener.Addr())
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		e.log.Fatalf("Can't serve: %s", err)
+	}
+
+	os.Exit(exitcodes.Success)
+}
+
+func (e *Executor) runFixServerAnalysis(ctx context.Context, args []string) ([]result.Issue, error) {
+	if err := e.goenv.Discover(ctx); err != nil {
+		e.log.Warnf("Failed to discover go env: %s", err)
+	}
+
+	if !logutils.HaveDebugTag("linters_output") {
+		// Don't allow linters and loader to print anything
+		log.SetOutput(io.Discard)
+		savedStdout, savedStderr := e.setOutputToDevNull()
+		defer func() {
+			os.Stdout, os.Stderr = savedStdout, savedStderr
+		}()
+	}
+
+	return e.runAnalysis(ctx, args)
+}
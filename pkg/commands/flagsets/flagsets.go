@@ -0,0 +1,359 @@
+// Package flagsets defines the flags of the commands, without the commands:
+// the default values of the flags are the default values of the config.
+package flagsets
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/pflag"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/packages"
+)
+
+func getDefaultIssueExcludeHelp() string {
+	parts := []string{"Use or not use default excludes:"}
+	for _, ep := range config.DefaultExcludePatterns {
+		parts = append(parts,
+			fmt.Sprintf("  # %s %s: %s", ep.ID, ep.Linter, ep.Why),
+			fmt.Sprintf("  - %s", color.YellowString(ep.Pattern)),
+			"",
+		)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func getDefaultDirectoryExcludeHelp() string {
+	parts := []string{"Use or not use default excluded directories:"}
+	for _, dir := range packages.StdExcludeDirRegexps {
+		parts = append(parts, fmt.Sprintf("  - %s", color.YellowString(dir)))
+	}
+	parts = append(parts, "")
+	return strings.Join(parts, "\n")
+}
+
+func wh(text string) string {
+	return color.GreenString(text)
+}
+
+// DefaultTimeout is the default of the timeout of the run.
+const DefaultTimeout = time.Minute
+
+// AddRun adds the flags of the run command to the flag set, their default values are set in the config.
+// The deprecation messages are set only if isFinalInit: the flags are added to several flag sets.
+//
+//nolint:funlen,gomnd
+func AddRun(fs *pflag.FlagSet, cfg *config.Config, m *lintersdb.Manager, isFinalInit bool) {
+	hideFlag := func(name string) {
+		if err := fs.MarkHidden(name); err != nil {
+			panic(err)
+		}
+
+		// we run AddRun multiple times, but we wouldn't like to see deprecation message multiple times
+		if isFinalInit {
+			const deprecateMessage = "flag will be removed soon, please, use .golangci.yml config"
+			if err := fs.MarkDeprecated(name, deprecateMessage); err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	// Output config
+	oc := &cfg.Output
+	fs.StringVar(&oc.Format, "out-format",
+		config.OutFormatColoredLineNumber,
+		wh(fmt.Sprintf("Format of output: %s", strings.Join(config.OutFormats, "|"))))
+	fs.BoolVar(&oc.PrintIssuedLine, "print-issued-lines", true, wh("Print lines of code with issue"))
+	fs.IntVar(&oc.PrintIssuedLinesContext, "print-issued-lines-context", 0,
+		wh("Count of source lines before and after each issue in the JSON output"))
+	fs.BoolVar(&oc.PrintLinterName, "print-linter-name", true, wh("Print linter name in issue line"))
+	fs.BoolVar(&oc.PrintRuleID, "print-rule-id", false, wh("Print the rule after the linter name in issue line, e.g. govet:printf"))
+	fs.BoolVar(&oc.UniqByLine, "uniq-by-line", true, wh("Make issues output unique by line"))
+	fs.BoolVar(&oc.SortResults, "sort-results", false, wh("Sort linter results"))
+	fs.StringSliceVar(&oc.SortOrder, "sort-order", nil,
+		wh(fmt.Sprintf("Keys of the sorting of the results with --sort-results: %s", strings.Join(config.SortOrders, "|"))))
+	fs.StringVar(&oc.GroupBy, "group-by", "",
+		wh(fmt.Sprintf("Group the issues of the text output with a summary per group: %s", strings.Join(config.GroupBys, "|"))))
+	fs.BoolVar(&oc.PrintWelcomeMessage, "print-welcome", false, wh("Print welcome message"))
+	fs.StringVar(&oc.PathPrefix, "path-prefix", "", wh("Path prefix to add to output"))
+	fs.BoolVar(&oc.ShowFixed, "show-fixed", false, wh("Show the issues fixed since the previous run"))
+	fs.BoolVar(&oc.Stream, "stream", false,
+		wh("Print the issues of each linter as soon as it finishes, in the line-based formats (json-stream is always streamed)"))
+	fs.BoolVar(&oc.NoProgress, "no-progress", false,
+		wh("Don't show the live status of the stages and the linters when the standard error is a terminal"))
+	fs.BoolVar(&oc.JUnitStrict, "junit-strict", false,
+		wh("Print only the elements and attributes of the JUnit schema in the junit-xml format, for the strict parsers"))
+	fs.StringVar(&oc.AnalyticsPath, "analytics-path", "",
+		wh("Write the counts of issues per linter and the durations, without source data, to this file"))
+	fs.StringVar(&oc.StatsHistory, "stats-history", "",
+		wh("Add the counts of issues per linter, severity and package to this history file, see the stats command"))
+	fs.StringVar(&oc.ReportFile, "report-file", "",
+		wh("Write the durations and the memory of the linters, the cache usage and the count of packages to this JSON file"))
+	fs.StringVar(&oc.PostProcess, "post-process", "",
+		wh("Command replacing the issues before they're printed: it reads and prints the issues in the json format"))
+	hideFlag("print-welcome") // no longer used
+
+	fs.BoolVar(&cfg.InternalCmdTest, "internal-cmd-test", false, wh("Option is used only for testing golangci-lint command, don't use it"))
+	if err := fs.MarkHidden("internal-cmd-test"); err != nil {
+		panic(err)
+	}
+
+	// Run config
+	rc := &cfg.Run
+	fs.StringVar(&rc.ModulesDownloadMode, "modules-download-mode", "",
+		"Modules download mode. If not empty, passed as -mod=<mode> to go tools")
+	fs.IntVar(&rc.ExitCodeIfIssuesFound, "issues-exit-code",
+		exitcodes.IssuesFound, wh("Exit code when issues were found"))
+	fs.StringVar(&rc.Go, "go", "", wh("Targeted Go version"))
+	fs.StringSliceVar(&rc.BuildTags, "build-tags", nil, wh("Build tags"))
+
+	fs.DurationVar(&rc.Timeout, "deadline", DefaultTimeout, wh("Deadline for total work"))
+	if err := fs.MarkHidden("deadline"); err != nil {
+		panic(err)
+	}
+	fs.DurationVar(&rc.Timeout, "timeout", DefaultTimeout, wh("Timeout for total work"))
+	fs.IntVar(&rc.MaxMemory, "max-memory", 0,
+		wh("Memory budget in MiB: once exceeded, the remaining linters run one at a time (0 means no budget)"))
+
+	fs.BoolVar(&rc.AnalyzeTests, "tests", true, wh("Analyze tests (*_test.go)"))
+	fs.BoolVar(&rc.PrintResourcesUsage, "print-resources-usage", false,
+		wh("Print avg and max memory usage of golangci-lint and total time"))
+	fs.StringVarP(&rc.Config, "config", "c", "", wh("Read config from file path `PATH`"))
+	fs.BoolVar(&rc.NoConfig, "no-config", false, wh("Don't read config"))
+	fs.StringVar(&rc.CanaryConfig, "canary-config", "",
+		wh("Run the linters with this proposed config too, and report only the issues added and removed by it"))
+	fs.BoolVar(&rc.Interactive, "interactive", false,
+		wh("Browse the issues in the terminal to fix them or suppress them with nolint directives, instead of printing them"))
+	fs.BoolVar(&rc.FastLintersFirst, "fast-linters-first", false,
+		wh("Run the fast linters before the other ones: their issues are streamed earlier"))
+	fs.BoolVar(&rc.SuppressNew, "suppress-new", false,
+		wh("Insert a //nolint directive at the line of each issue instead of printing the issues"))
+	fs.StringVar(&rc.SuppressReason, "suppress-reason", "",
+		wh("Explanation of the directives inserted by --suppress-new, {linter} and {date} are replaced by the linters and the day"))
+	fs.StringSliceVar(&rc.SkipDirs, "skip-dirs", nil, wh("Regexps of directories to skip"))
+	fs.BoolVar(&rc.UseDefaultSkipDirs, "skip-dirs-use-default", true, getDefaultDirectoryExcludeHelp())
+	fs.StringSliceVar(&rc.SkipFiles, "skip-files", nil, wh("Regexps of files to skip"))
+
+	const allowParallelDesc = "Allow multiple parallel golangci-lint instances running. " +
+		"If false (default) - golangci-lint acquires file lock on start."
+	fs.BoolVar(&rc.AllowParallelRunners, "allow-parallel-runners", false, wh(allowParallelDesc))
+	const allowSerialDesc = "Allow multiple golangci-lint instances running, but serialize them	around a lock. " +
+		"If false (default) - golangci-lint exits with an error if it fails to acquire file lock on start."
+	fs.BoolVar(&rc.AllowSerialRunners, "allow-serial-runners", false, wh(allowSerialDesc))
+	fs.BoolVar(&rc.AutoAdopt, "auto-adopt", false,
+		wh("If no config is found, create a starter config and a baseline of the existing issues"))
+	fs.StringVar(&rc.Shard, "shard", "",
+		wh("Analyze only the slice `i/n` of the packages, e.g. 2/4: the outputs of the shards are combined by merge-results"))
+	fs.BoolVar(&rc.ReverseDeps, "rdeps", false,
+		wh("Analyze also the packages importing the packages of the arguments, directly or not, in their modules"))
+	fs.BoolVar(&rc.Strict, "strict", false,
+		wh("Fail the run when a linter panics, instead of reporting the panic as a warning and running the other linters"))
+	fs.BoolVar(&rc.Stdin, "stdin", false,
+		wh("Lint the standard input as the content of the file of --stdin-filename, in its package"))
+	fs.StringVar(&rc.StdinFilename, "stdin-filename", "", wh("Path of the Go file of the standard input of --stdin"))
+
+	// Linters settings config
+	lsc := &cfg.LintersSettings
+
+	// Hide all linters settings flags: they were initially visible,
+	// but when number of linters started to grow it became obvious that
+	// we can't fill 90% of flags by linters settings: common flags became hard to find.
+	// New linters settings should be done only through config file.
+	fs.BoolVar(&lsc.Errcheck.CheckTypeAssertions, "errcheck.check-type-assertions",
+		false, "Errcheck: check for ignored type assertion results")
+	hideFlag("errcheck.check-type-assertions")
+	fs.BoolVar(&lsc.Errcheck.CheckAssignToBlank, "errcheck.check-blank", false,
+		"Errcheck: check for errors assigned to blank identifier: _ = errFunc()")
+	hideFlag("errcheck.check-blank")
+	fs.StringVar(&lsc.Errcheck.Exclude, "errcheck.exclude", "",
+		"Path to a file containing a list of functions to exclude from checking")
+	hideFlag("errcheck.exclude")
+	fs.StringVar(&lsc.Errcheck.Ignore, "errcheck.ignore", "fmt:.*",
+		`Comma-separated list of pairs of the form pkg:regex. The regex is used to ignore names within pkg`)
+	hideFlag("errcheck.ignore")
+
+	fs.BoolVar(&lsc.Govet.CheckShadowing, "govet.check-shadowing", false,
+		"Govet: check for shadowed variables")
+	hideFlag("govet.check-shadowing")
+
+	fs.Float64Var(&lsc.Golint.MinConfidence, "golint.min-confidence", 0.8,
+		"Golint: minimum confidence of a problem to print it")
+	hideFlag("golint.min-confidence")
+
+	fs.BoolVar(&lsc.Gofmt.Simplify, "gofmt.simplify", true, "Gofmt: simplify code")
+	hideFlag("gofmt.simplify")
+
+	fs.IntVar(&lsc.Gocyclo.MinComplexity, "gocyclo.min-complexity",
+		30, "Minimal complexity of function to report it")
+	hideFlag("gocyclo.min-complexity")
+
+	fs.BoolVar(&lsc.Maligned.SuggestNewOrder, "maligned.suggest-new", false,
+		"Maligned: print suggested more optimal struct fields ordering")
+	hideFlag("maligned.suggest-new")
+
+	fs.IntVar(&lsc.Dupl.Threshold, "dupl.threshold",
+		150, "Dupl: Minimal threshold to detect copy-paste")
+	hideFlag("dupl.threshold")
+
+	fs.BoolVar(&lsc.Goconst.MatchWithConstants, "goconst.match-constant",
+		true, "Goconst: look for existing constants matching the values")
+	hideFlag("goconst.match-constant")
+	fs.IntVar(&lsc.Goconst.MinStringLen, "goconst.min-len",
+		3, "Goconst: minimum constant string length")
+	hideFlag("goconst.min-len")
+	fs.IntVar(&lsc.Goconst.MinOccurrencesCount, "goconst.min-occurrences",
+		3, "Goconst: minimum occurrences of constant string count to trigger issue")
+	hideFlag("goconst.min-occurrences")
+	fs.BoolVar(&lsc.Goconst.ParseNumbers, "goconst.numbers",
+		false, "Goconst: search also for duplicated numbers")
+	hideFlag("goconst.numbers")
+	fs.IntVar(&lsc.Goconst.NumberMin, "goconst.min",
+		3, "minimum value, only works with goconst.numbers")
+	hideFlag("goconst.min")
+	fs.IntVar(&lsc.Goconst.NumberMax, "goconst.max",
+		3, "maximum value, only works with goconst.numbers")
+	hideFlag("goconst.max")
+	fs.BoolVar(&lsc.Goconst.IgnoreCalls, "goconst.ignore-calls",
+		true, "Goconst: ignore when constant is not used as function argument")
+	hideFlag("goconst.ignore-calls")
+
+	// (@dixonwille) These flag is only used for testing purposes.
+	fs.StringSliceVar(&lsc.Depguard.Packages, "depguard.packages", nil,
+		"Depguard: packages to add to the list")
+	hideFlag("depguard.packages")
+
+	fs.BoolVar(&lsc.Depguard.IncludeGoRoot, "depguard.include-go-root", false,
+		"Depguard: check list against standard lib")
+	hideFlag("depguard.include-go-root")
+
+	fs.IntVar(&lsc.Lll.TabWidth, "lll.tab-width", 1,
+		"Lll: tab width in spaces")
+	hideFlag("lll.tab-width")
+
+	// Linters config
+	lc := &cfg.Linters
+	fs.StringSliceVarP(&lc.Enable, "enable", "E", nil, wh("Enable specific linter"))
+	fs.StringSliceVarP(&lc.Disable, "disable", "D", nil, wh("Disable specific linter"))
+	fs.BoolVar(&lc.EnableAll, "enable-all", false, wh("Enable all linters"))
+
+	fs.BoolVar(&lc.DisableAll, "disable-all", false, wh("Disable all linters"))
+	fs.StringSliceVarP(&lc.Presets, "presets", "p", nil,
+		wh(fmt.Sprintf("Enable presets (%s) of linters. Run 'golangci-lint linters' to see "+
+			"them. This option implies option --disable-all", strings.Join(m.AllPresets(), "|"))))
+	fs.BoolVar(&lc.Fast, "fast", false, wh("Run only fast linters from enabled linters set (first run won't be fast)"))
+
+	// Issues config
+	ic := &cfg.Issues
+	fs.StringSliceVarP(&ic.ExcludePatterns, "exclude", "e", nil, wh("Exclude issue by regexp"))
+	fs.BoolVar(&ic.UseDefaultExcludes, "exclude-use-default", true, getDefaultIssueExcludeHelp())
+	fs.BoolVar(&ic.ExcludeCaseSensitive, "exclude-case-sensitive", false, wh("If set to true exclude "+
+		"and exclude rules regular expressions are case sensitive"))
+
+	fs.IntVar(&ic.MaxIssuesPerLinter, "max-issues-per-linter", 50,
+		wh("Maximum issues count per one linter. Set to 0 to disable"))
+	fs.IntVar(&ic.MaxSameIssues, "max-same-issues", 3,
+		wh("Maximum count of issues with the same text. Set to 0 to disable"))
+
+	fs.BoolVarP(&ic.Diff, "new", "n", false,
+		wh("Show only new issues: if there are unstaged changes or untracked files, only those changes "+
+			"are analyzed, else only changes in HEAD~ are analyzed.\nIt's a super-useful option for integration "+
+			"of golangci-lint into existing large codebase.\nIt's not practical to fix all existing issues at "+
+			"the moment of integration: much better to not allow issues in new code.\nFor CI setups, prefer "+
+			"--new-from-rev=HEAD~, as --new can skip linting the current patch if any scripts generate "+
+			"unstaged files before golangci-lint runs."))
+	fs.StringVar(&ic.DiffFromRevision, "new-from-rev", "",
+		wh("Show only new issues created after revision `REV` (git, Mercurial or Jujutsu)"))
+	fs.StringVar(&ic.DiffPatchFilePath, "new-from-patch", "",
+		wh("Show only new issues created in git patch with file path `PATH`"))
+	fs.BoolVar(&ic.DiffFromStdin, "diff", false,
+		wh("Show only the issues on the lines added or modified by the unified diff read from the standard input"))
+	fs.StringVar(&ic.DiffFile, "diff-file", "",
+		wh("Show only the issues on the lines added or modified by the unified diff of the file `PATH`, without git"))
+	fs.BoolVar(&ic.WholeFiles, "whole-files", false,
+		wh("Show issues in any part of update files (requires new-from-rev, new-from-patch, diff or diff-file)"))
+	fs.BoolVar(&ic.ChangedOnly, "changed-only", false,
+		wh("Analyze only the packages affected by the changes since new-from-rev, "+
+			"and reuse the issues of the previous run for the other packages (requires new-from-rev)"))
+	fs.BoolVar(&ic.NeedFix, "fix", false, "Fix found issues (if it's supported by the linter)")
+	fs.BoolVar(&ic.UnsafeFix, "unsafe-fix", false,
+		wh("Apply the unsafe fixes with --fix: the renames of identifiers are applied to all their references"))
+	fs.StringVar(&ic.Coverage.Profile, "coverage-profile", "",
+		wh("Annotate issues with the coverage of their lines in the coverage profile with path `PATH`"))
+	fs.BoolVar(&ic.Coverage.UncoveredOnly, "uncovered-only", false,
+		wh("Show only issues on lines not covered by the tests (requires coverage-profile)"))
+	fs.BoolVar(&ic.Coverage.UncoveredFirst, "uncovered-first", false,
+		wh("Sort issues on lines not covered by the tests first (requires coverage-profile)"))
+	fs.BoolVar(&ic.CodeOwners.Annotate, "code-owners", false,
+		wh("Annotate issues with the owners of their files in the CODEOWNERS file"))
+	fs.StringVar(&ic.CodeOwners.File, "code-owners-file", "",
+		wh("Path of the CODEOWNERS file: CODEOWNERS, .github/CODEOWNERS or docs/CODEOWNERS by default"))
+	fs.StringSliceVar(&ic.CodeOwners.Owners, "filter-owner", nil,
+		wh("Show only issues in the files owned by one of these owners of the CODEOWNERS file, e.g. @org/team"))
+	fs.StringVar(&ic.Baseline, "baseline", "",
+		wh("Hide issues recorded in the baseline file with path `PATH`"))
+	fs.BoolVar(&ic.ReportUnusedNolintDirectives, "report-unused-nolint-directives", false,
+		wh("Report the //nolint directives which didn't suppress any issue (they are deleted with --fix)"))
+
+	// Severity config
+	fs.StringVar(&cfg.Severity.MaxSeverityToPass, "max-severity-to-pass", "",
+		wh("Report the issues with this `SEVERITY` or a lower one without failing the run"))
+}
+
+// DefaultConcurrency is the default of the concurrency of the run.
+func DefaultConcurrency() int {
+	if os.Getenv("HELP_RUN") == "1" {
+		// Make stable concurrency for README help generating builds.
+		const prettyConcurrency = 8
+		return prettyConcurrency
+	}
+
+	return runtime.NumCPU()
+}
+
+// AddRoot adds the flags of all the commands to the flag set, their default values are set in the config.
+func AddRoot(fs *pflag.FlagSet, cfg *config.Config, needVersionOption bool) {
+	fs.BoolVarP(&cfg.Run.IsVerbose, "verbose", "v", false, wh("verbose output"))
+	fs.StringVar(&cfg.Run.LogFormat, "log-format", logutils.LogFormatText,
+		wh(fmt.Sprintf("Format of the logs: %s", strings.Join(logutils.LogFormats, "|"))))
+	fs.StringVar(&cfg.Run.LogLevel, "log-level", "",
+		wh(fmt.Sprintf("Minimum level of the logs: %s (default warn, info with --verbose)", strings.Join(logutils.LogLevels, "|"))))
+	fs.StringSliceVar(&cfg.Run.LogDebug, "log-debug", nil,
+		wh("Tags of the debug logs to print, e.g. loader,nolint (like GL_DEBUG): all the tags with --log-level=debug by default"))
+
+	var silent bool
+	fs.BoolVarP(&silent, "silent", "s", false, wh("disables congrats outputs"))
+	if err := fs.MarkHidden("silent"); err != nil {
+		panic(err)
+	}
+	err := fs.MarkDeprecated("silent",
+		"now golangci-lint by default is silent: it doesn't print Congrats message")
+	if err != nil {
+		panic(err)
+	}
+
+	fs.StringVar(&cfg.Run.CPUProfilePath, "cpu-profile-path", "", wh("Path to CPU profile output file"))
+	fs.StringVar(&cfg.Run.MemProfilePath, "mem-profile-path", "", wh("Path to memory profile output file"))
+	fs.StringVar(&cfg.Run.TracePath, "trace-path", "", wh("Path to trace output file"))
+	fs.StringVar(&cfg.Run.ProfileDir, "profile-dir", "",
+		wh("Directory to write the CPU and heap profiles, the trace, the run report and the timings of the run to"))
+	fs.IntVarP(&cfg.Run.Concurrency, "concurrency", "j", DefaultConcurrency(), wh("Concurrency (default NumCPU)"))
+	fs.IntVar(&cfg.Run.LoadConcurrency, "load-concurrency", 0,
+		wh("Count of the packages loaded in parallel by the go/analysis linters, e.g. on a network filesystem (default --concurrency)"))
+	fs.IntVar(&cfg.Run.AnalysisConcurrency, "analysis-concurrency", 0,
+		wh("Count of the packages analyzed in parallel by the go/analysis linters (default --concurrency)"))
+	fs.BoolVar(&cfg.Run.AutoTune, "auto-tune", true,
+		wh("Adapt the concurrency, the memory limit and GOGC of the Go runtime to the container limits"))
+	if needVersionOption {
+		fs.BoolVar(&cfg.Run.PrintVersion, "version", false, wh("Print version"))
+	}
+
+	fs.StringVar(&cfg.Output.Color, "color", "auto", wh("Use color when printing; can be 'always', 'auto', or 'never'"))
+}
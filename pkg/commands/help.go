@@ -84,7 +84,7 @@ func (e *Executor) executeLintersHelp(_ *cobra.Command, args []string) {
 	printLinterConfigs(disabledLCs)
 
 	color.Green("\nLinters presets:")
-	for _, p := range e.DBManager.AllPresets() {
+	for _, p := range append(e.DBManager.AllPresets(), e.DBManager.CustomPresets()...) {
 		linters := e.DBManager.GetAllLinterConfigsForPreset(p)
 		linterNames := make([]string, 0, len(linters))
 		for _, lc := range linters {
@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/hooks"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func (e *Executor) initHooks() {
+	hooksCmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "Install and run the git hooks linting the commits",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 0 {
+				e.log.Fatalf("Usage: golangci-lint hooks")
+			}
+			if err := cmd.Help(); err != nil {
+				e.log.Fatalf("Can't run hooks: %s", err)
+			}
+		},
+	}
+	e.rootCmd.AddCommand(hooksCmd)
+
+	installCmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install the git hook running golangci-lint on the staged files (pre-commit), or on the pushed changes (pre-push)",
+		Run:   e.executeHooksInstall,
+	}
+	installCmd.Flags().String("hook", hooks.PreCommit, wh("Hook to install: pre-commit or pre-push"))
+	installCmd.Flags().String("binary", "golangci-lint", wh("Command of golangci-lint run by the hook"))
+	installCmd.Flags().Bool("force", false, wh("Replace an existing hook not installed by golangci-lint"))
+	hooksCmd.AddCommand(installCmd)
+
+	uninstallCmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove the git hook installed by golangci-lint",
+		Run:   e.executeHooksUninstall,
+	}
+	uninstallCmd.Flags().String("hook", hooks.PreCommit, wh("Hook to remove: pre-commit or pre-push"))
+	hooksCmd.AddCommand(uninstallCmd)
+
+	runCmd := &cobra.Command{
+		Use:   "run [-- run flags]",
+		Short: "Run the hook: lint and fix the staged content of the Go files (pre-commit), or the pushed changes (pre-push)",
+		Run:   e.executeHooksRun,
+	}
+	runCmd.Flags().String("hook", hooks.PreCommit, wh("Hook to run: pre-commit or pre-push"))
+	hooksCmd.AddCommand(runCmd)
+}
+
+func (e *Executor) executeHooksInstall(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		e.log.Fatalf("Usage: golangci-lint hooks install [--hook HOOK]")
+	}
+
+	hook, _ := cmd.Flags().GetString("hook")
+	binary, _ := cmd.Flags().GetString("binary")
+	force, _ := cmd.Flags().GetBool("force")
+
+	ctx := context.Background()
+
+	repo, err := hooks.Open(ctx, ".")
+	if err != nil {
+		e.log.Fatalf("Can't find the git repository: %s", err)
+	}
+
+	path, err := repo.Install(ctx, hook, binary, force)
+	if err != nil {
+		e.log.Fatalf("Can't install the %s hook: %s", hook, err)
+	}
+
+	fmt.Fprintf(logutils.StdOut, "Installed the %s hook %s\n", hook, path)
+	os.Exit(exitcodes.Success)
+}
+
+func (e *Executor) executeHooksUninstall(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		e.log.Fatalf("Usage: golangci-lint hooks uninstall [--hook HOOK]")
+	}
+
+	hook, _ := cmd.Flags().GetString("hook")
+
+	ctx := context.Background()
+
+	repo, err := hooks.Open(ctx, ".")
+	if err != nil {
+		e.log.Fatalf("Can't find the git repository: %s", err)
+	}
+
+	path, err := repo.Uninstall(ctx, hook)
+	if err != nil {
+		e.log.Fatalf("Can't remove the %s hook: %s", hook, err)
+	}
+
+	fmt.Fprintf(logutils.StdOut, "Removed the %s hook %s\n", hook, path)
+	os.Exit(exitcodes.Success)
+}
+
+// executeHooksRun runs the hook: the arguments are flags of the run command.
+func (e *Executor) executeHooksRun(cmd *cobra.Command, args []string) {
+	hook, _ := cmd.Flags().GetString("hook")
+
+	ctx := context.Background()
+
+	repo, err := hooks.Open(ctx, ".")
+	if err != nil {
+		e.log.Fatalf("Can't find the git repository: %s", err)
+	}
+
+	var code int
+	switch hook {
+	case hooks.PreCommit:
+		code, err = e.runPreCommit(ctx, repo, args)
+	case hooks.PrePush:
+		code, err = e.runPrePush(ctx, repo, args)
+	default:
+		err = fmt.Errorf("unsupported hook %q: must be %s or %s", hook, hooks.PreCommit, hooks.PrePush)
+	}
+	if err != nil {
+		e.log.Fatalf("Can't run the %s hook: %s", hook, err)
+	}
+
+	os.Exit(code)
+}
+
+// runPreCommit lints and fixes the staged content of the packages of the staged Go files in a checkout of the index:
+// only the issues of the staged changes are reported, and the fixes are staged.
+func (e *Executor) runPreCommit(ctx context.Context, repo *hooks.Repo, args []string) (int, error) {
+	checkout, err := repo.CheckoutIndex(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if checkout == nil {
+		e.log.Infof("No staged Go file")
+		return exitcodes.Success, nil
+	}
+	defer func() {
+		if err := checkout.Remove(); err != nil {
+			e.log.Warnf("Can't remove the checkout of the index %s: %s", checkout.Dir, err)
+		}
+	}()
+
+	patch, err := checkout.Patch(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// The patch is outside the checkout: it isn't linted.
+	patchFile, err := os.CreateTemp("", "golangci-lint-staged-*.patch")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(patchFile.Name())
+
+	_, err = patchFile.Write(patch)
+	if closeErr := patchFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	runArgs := append([]string{"run", "--fix", "--new-from-patch=" + patchFile.Name()}, args...)
+	code, err := e.runSelf(ctx, checkout.Dir, append(runArgs, checkout.Packages()...))
+	if err != nil {
+		return 0, err
+	}
+
+	fixed, conflicts, err := checkout.Stage(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("can't stage the fixes: %w", err)
+	}
+	for _, f := range fixed {
+		e.log.Infof("Staged the fixes of %s", f)
+	}
+	for _, f := range conflicts {
+		e.log.Warnf("The fixes of %s conflict with its unstaged changes: they are staged, the working tree file is kept", f)
+	}
+
+	return code, nil
+}
+
+// runPrePush lints the changes of the pushed commits in the working tree: the references are read from the standard input.
+func (e *Executor) runPrePush(ctx context.Context, repo *hooks.Repo, args []string) (int, error) {
+	rev, err := repo.PushBase(ctx, os.Stdin)
+	if err != nil {
+		return 0, err
+	}
+
+	runArgs := []string{"run"}
+	switch rev {
+	case "":
+		e.log.Infof("No pushed commit")
+		return exitcodes.Success, nil
+	case hooks.RootRevision:
+		e.log.Infof("The pushed commits have no base: all the issues are reported")
+	default:
+		runArgs = append(runArgs, "--new-from-rev="+rev)
+	}
+
+	return e.runSelf(ctx, repo.Root, append(runArgs, args...))
+}
+
+// runSelf runs golangci-lint with the arguments in the directory, and returns its exit code.
+func (e *Executor) runSelf(ctx context.Context, dir string, args []string) (int, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return 0, err
+	}
+
+	e.log.Infof("Running %s %s in %s", filepath.Base(exe), strings.Join(args, " "), dir)
+
+	cmd := exec.CommandContext(ctx, exe, args...)
+	cmd.Dir = dir
+	cmd.Stdout = logutils.StdOut
+	cmd.Stderr = logutils.StdErr
+
+	if err = cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), nil
+		}
+		return 0, err
+	}
+
+	return exitcodes.Success, nil
+}
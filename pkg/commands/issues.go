@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func (e *Executor) initIssues() {
+	cmd := &cobra.Command{
+		Use:   "issues",
+		Short: "Compare the issues of the reports of the json output format",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 0 {
+				e.log.Fatalf("Usage: golangci-lint issues")
+			}
+			if err := cmd.Help(); err != nil {
+				e.log.Fatalf("Can't run help: %s", err)
+			}
+		},
+	}
+	e.rootCmd.AddCommand(cmd)
+
+	var format string
+	diffCmd := &cobra.Command{
+		Use:   "diff <old.json> <new.json>",
+		Short: "Print the issues introduced, fixed and moved between two reports",
+		Long: `Print the issues introduced, fixed and moved between two reports generated with --out-format=json,
+e.g. the reports of two branches. "-" reads a report from the standard input.
+
+The issues are matched by linter and fingerprint (the file, the text and the first source line of the issue):
+an issue on another line of the same file is moved, not introduced.
+The exit code is 1 if new issues are introduced.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e.executeIssuesDiff(args, format)
+		},
+	}
+	diffCmd.Flags().StringVar(&format, "format", "text", wh("Output format: text|json"))
+	cmd.AddCommand(diffCmd)
+}
+
+// executeIssuesDiff runs the 'issues diff' CLI command.
+func (e *Executor) executeIssuesDiff(args []string, format string) {
+	if len(args) != 2 {
+		e.log.Fatalf("Usage: golangci-lint issues diff <old.json> <new.json>")
+	}
+	if args[0] == "-" && args[1] == "-" {
+		e.log.Fatalf("Only one report can be read from the standard input")
+	}
+
+	if format != "text" && format != "json" {
+		e.log.Fatalf("Unknown format %q: must be text or json", format)
+	}
+
+	old, err := readJSONReport(args[0])
+	if err != nil {
+		e.log.Fatalf("Can't read report %s: %s", args[0], err)
+	}
+
+	current, err := readJSONReport(args[1])
+	if err != nil {
+		e.log.Fatalf("Can't read report %s: %s", args[1], err)
+	}
+
+	d := report.DiffIssues(old.Issues, current.Issues)
+
+	if format == "json" {
+		if err := json.NewEncoder(logutils.StdOut).Encode(d); err != nil {
+			e.log.Fatalf("Can't print diff: %s", err)
+		}
+	} else {
+		printIssuesDiff(&d)
+	}
+
+	if len(d.Introduced) != 0 {
+		os.Exit(exitcodes.IssuesFound)
+	}
+	os.Exit(exitcodes.Success)
+}
+
+func printIssuesDiff(d *report.IssuesDiff) {
+	describe := func(i *result.Issue) string {
+		return fmt.Sprintf("%s (%s)", i.Text, i.FromLinter)
+	}
+
+	for i := range d.Introduced {
+		issue := &d.Introduced[i]
+		fmt.Fprintf(logutils.StdOut, "+ %s:%d: %s\n", issue.FilePath(), issue.Line(), describe(issue))
+	}
+	for i := range d.Fixed {
+		issue := &d.Fixed[i]
+		fmt.Fprintf(logutils.StdOut, "- %s:%d: %s\n", issue.FilePath(), issue.Line(), describe(issue))
+	}
+	for i := range d.Moved {
+		m := &d.Moved[i]
+		fmt.Fprintf(logutils.StdOut, "~ %s:%d -> %d: %s\n", m.Issue.FilePath(), m.FromLine, m.Issue.Line(), describe(&m.Issue))
+	}
+
+	fmt.Fprintf(logutils.StdOut, "%d introduced, %d fixed, %d moved, %d unchanged\n",
+		len(d.Introduced), len(d.Fixed), len(d.Moved), d.Unchanged)
+}
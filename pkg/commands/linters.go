@@ -1,28 +1,55 @@
 package commands
 
 import (
+	"encoding/json"
 	"log"
 	"os"
+	"sort"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/exitcodes"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/logutils"
 )
 
+// jsonLinter is a linter in the output of 'linters --json'.
+type jsonLinter struct {
+	Name             string              `json:"name"`
+	AlternativeNames []string            `json:"alternativeNames,omitempty"`
+	Description      string              `json:"description"`
+	Enabled          bool                `json:"enabled"`
+	EnabledByDefault bool                `json:"enabledByDefault"`
+	Presets          []string            `json:"presets"`
+	Fast             bool                `json:"fast"`
+	AutoFix          bool                `json:"autoFix"`
+	Since            string              `json:"since,omitempty"`
+	Deprecation      *linter.Deprecation `json:"deprecation,omitempty"`
+	URL              string              `json:"url,omitempty"`
+	Upstream         *linter.Upstream    `json:"upstream,omitempty"`
+	// Settings is the schema of the linters-settings of the linter, if it has settings.
+	Settings *config.Schema `json:"settings,omitempty"`
+}
+
 func (e *Executor) initLinters() {
+	var jsonOutput bool
 	e.lintersCmd = &cobra.Command{
 		Use:   "linters",
 		Short: "List current linters configuration",
-		Run:   e.executeLinters,
+		Run: func(cmd *cobra.Command, args []string) {
+			e.executeLinters(cmd, args, jsonOutput)
+		},
 	}
 	e.rootCmd.AddCommand(e.lintersCmd)
 	e.initRunConfiguration(e.lintersCmd)
+	e.lintersCmd.Flags().BoolVar(&jsonOutput, "json", false,
+		wh("Print all the linters as JSON: their state, presets, capabilities, deprecation and settings schema"))
 }
 
 // executeLinters runs the 'linters' CLI command, which displays the supported linters.
-func (e *Executor) executeLinters(_ *cobra.Command, args []string) {
+func (e *Executor) executeLinters(_ *cobra.Command, args []string, jsonOutput bool) {
 	if len(args) != 0 {
 		e.log.Fatalf("Usage: golangci-lint linters")
 	}
@@ -32,6 +59,16 @@ func (e *Executor) executeLinters(_ *cobra.Command, args []string) {
 		log.Fatalf("Can't get enabled linters: %s", err)
 	}
 
+	if jsonOutput {
+		enc := json.NewEncoder(logutils.StdOut)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(e.jsonLinters(enabledLintersMap)); err != nil {
+			e.log.Fatalf("Can't print linters: %s", err)
+		}
+
+		os.Exit(exitcodes.Success)
+	}
+
 	color.Green("Enabled by your configuration linters:\n")
 	enabledLinters := make([]*linter.Config, 0, len(enabledLintersMap))
 	for _, linter := range enabledLintersMap {
@@ -51,3 +88,36 @@ func (e *Executor) executeLinters(_ *cobra.Command, args []string) {
 
 	os.Exit(exitcodes.Success)
 }
+
+// jsonLinters describes all the known linters, sorted by name.
+func (e *Executor) jsonLinters(enabledLintersMap map[string]*linter.Config) []jsonLinter {
+	var linters []jsonLinter
+	for _, lc := range e.DBManager.GetAllSupportedLinterConfigs() {
+		presets := lc.InPresets
+		if presets == nil {
+			presets = []string{}
+		}
+
+		linters = append(linters, jsonLinter{
+			Name:             lc.Name(),
+			AlternativeNames: lc.AlternativeNames,
+			Description:      lc.Linter.Desc(),
+			Enabled:          enabledLintersMap[lc.Name()] != nil,
+			EnabledByDefault: lc.EnabledByDefault,
+			Presets:          presets,
+			Fast:             !lc.IsSlowLinter(),
+			AutoFix:          lc.CanAutoFix,
+			Since:            lc.Since,
+			Deprecation:      lc.Deprecation,
+			URL:              lc.OriginalURL,
+			Upstream:         lc.Upstream(),
+			Settings:         linterSettingsSchema(lc.Name()),
+		})
+	}
+
+	sort.Slice(linters, func(i, j int) bool {
+		return linters[i].Name < linters[j].Name
+	})
+
+	return linters
+}
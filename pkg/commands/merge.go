@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
+)
+
+func (e *Executor) initMergeResults() {
+	cmd := &cobra.Command{
+		Use:   "merge-results <shard.json>...",
+		Short: "Combine the reports of the shards of a run",
+		Long: `Combine the reports generated with --shard=i/n --out-format=json by the shards of a run,
+e.g. on several CI machines, and print their issues like a single run.
+
+The processors of the issues of several packages are run again on the issues of all the shards:
+the deduplication of the issues, --max-issues-per-linter and --max-same-issues
+(the limits aren't applied by the runs of the shards).
+The exit code is the exit code of a run with the issues.`,
+		Run: e.executeMergeResults,
+	}
+	e.rootCmd.AddCommand(cmd)
+
+	fs := cmd.Flags()
+	fs.StringVar(&e.cfg.Output.Format, "out-format", config.OutFormatColoredLineNumber,
+		wh(fmt.Sprintf("Format of output: %s", strings.Join(config.OutFormats, "|"))))
+	fs.IntVar(&e.cfg.Issues.MaxIssuesPerLinter, "max-issues-per-linter", 50,
+		wh("Maximum issues count per one linter. Set to 0 to disable"))
+	fs.IntVar(&e.cfg.Issues.MaxSameIssues, "max-same-issues", 3,
+		wh("Maximum count of issues with the same text. Set to 0 to disable"))
+	fs.IntVar(&e.cfg.Run.ExitCodeIfIssuesFound, "issues-exit-code", exitcodes.IssuesFound,
+		wh("Exit code when issues were found"))
+}
+
+// executeMergeResults runs the 'merge-results' CLI command.
+func (e *Executor) executeMergeResults(_ *cobra.Command, args []string) {
+	if len(args) == 0 {
+		e.log.Fatalf("Usage: golangci-lint merge-results <shard.json>...")
+	}
+
+	var issues []result.Issue
+	for _, path := range args {
+		res, err := readJSONReport(path)
+		if err != nil {
+			e.log.Fatalf("Can't read report %s: %s", path, err)
+		}
+
+		issues = append(issues, res.Issues...)
+		if res.Report != nil {
+			e.reportData.Merge(res.Report)
+		}
+	}
+
+	if err := e.cfg.Severity.Validate(); err != nil {
+		e.log.Fatalf("Error in severity config: %s", err)
+	}
+
+	issues, err := e.processMergedIssues(issues)
+	if err != nil {
+		e.log.Fatalf("Can't process issues: %s", err)
+	}
+
+	if err = e.cfg.Output.Validate(); err != nil {
+		e.log.Fatalf("Error in output config: %s", err)
+	}
+	if err = e.printAllReports(context.Background(), issues); err != nil {
+		e.log.Fatalf("Can't print issues: %s", err)
+	}
+
+	if err = e.setExitCodeIfIssuesFound(issues); err != nil {
+		e.log.Fatalf("Can't set the exit code: %s", err)
+	}
+	os.Exit(e.exitCode)
+}
+
+// processMergedIssues runs the processors of the issues of several packages on the issues of the shards.
+func (e *Executor) processMergedIssues(issues []result.Issue) ([]result.Issue, error) {
+	ps := []processors.Processor{
+		processors.NewDedup(&e.cfg.Issues.Dedup, e.log.Child("dedup")),
+		processors.NewUniqByLine(e.cfg),
+		processors.NewMaxSameIssues(e.cfg.Issues.MaxSameIssues, e.log.Child("max_same_issues"), e.cfg),
+		processors.NewMaxFromLinter(e.cfg.Issues.MaxIssuesPerLinter, e.log.Child("max_from_linter"), e.cfg),
+		processors.NewSortResults(e.cfg),
+	}
+
+	for _, p := range ps {
+		var err error
+		issues, err = p.Process(issues)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Name(), err)
+		}
+		p.Finish()
+	}
+
+	return issues, nil
+}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func (e *Executor) initMigrate() {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Rewrite the config file without its deprecated linters and options",
+		Long: `Rewrite the used config file in place: the deprecated linters are renamed to their replacements,
+the deprecated options are moved to their replacements and the historical options are converted.
+The changes to do by hand are printed too. Only the YAML config files are supported.`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run: func(_ *cobra.Command, _ []string) {
+			e.executeMigrate(dryRun)
+		},
+	}
+	e.rootCmd.AddCommand(cmd)
+	e.initRunConfiguration(cmd) // allow --config
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, wh("Print the migrated config instead of rewriting the config file"))
+}
+
+// executeMigrate runs the 'migrate' CLI command.
+func (e *Executor) executeMigrate(dryRun bool) {
+	path := e.getUsedConfig()
+	if path == "" {
+		e.log.Fatalf("No config file is used")
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+	default:
+		e.log.Fatalf("Can't migrate %s: only the YAML config files are supported", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		e.log.Fatalf("Can't read config file: %s", err)
+	}
+
+	m := config.NewMigrator(e.linterMigrations())
+	migrated, err := m.Migrate(data)
+	if err != nil {
+		e.log.Fatalf("Can't migrate %s: %s", path, err)
+	}
+
+	if dryRun {
+		fmt.Fprint(logutils.StdOut, string(migrated))
+	} else if len(m.Changes) != 0 {
+		info, err := os.Stat(path)
+		if err != nil {
+			e.log.Fatalf("Can't read config file: %s", err)
+		}
+		if err := os.WriteFile(path, migrated, info.Mode().Perm()); err != nil {
+			e.log.Fatalf("Can't write config file: %s", err)
+		}
+	}
+
+	w := logutils.StdOut
+	if dryRun {
+		w = logutils.StdErr // keep the migrated config alone on the standard output
+	}
+
+	if len(m.Changes) == 0 && len(m.Manual) == 0 {
+		fmt.Fprintf(w, "%s is up to date\n", path)
+	}
+	if len(m.Changes) != 0 {
+		fmt.Fprintf(w, "%s\n", color.GreenString("Migrated %s: %d change(s)", path, len(m.Changes)))
+		for _, change := range m.Changes {
+			fmt.Fprintf(w, "  %s\n", change)
+		}
+	}
+	if len(m.Manual) != 0 {
+		fmt.Fprintf(w, "%s\n", color.YellowString("To migrate by hand: %d change(s)", len(m.Manual)))
+		for _, change := range m.Manual {
+			fmt.Fprintf(w, "  %s\n", change)
+		}
+	}
+
+	os.Exit(exitcodes.Success)
+}
+
+// linterMigrations returns the replacements of the deprecated linters, by name and alternative name.
+// A replacement like "govet 'fieldalignment'" is an analyzer of govet.
+func (e *Executor) linterMigrations() map[string]config.LinterMigration {
+	migrations := map[string]config.LinterMigration{}
+	for _, lc := range e.DBManager.GetAllSupportedLinterConfigs() {
+		if !lc.IsDeprecated() {
+			continue
+		}
+
+		var lm config.LinterMigration
+		if fields := strings.Fields(lc.Deprecation.Replacement); len(fields) != 0 {
+			lm.Replacement = fields[0]
+			if len(fields) > 1 && lm.Replacement == "govet" {
+				lm.Analyzer = strings.Trim(fields[1], "'")
+			}
+		}
+
+		for _, name := range append([]string{lc.Name()}, lc.AlternativeNames...) {
+			migrations[strings.ToLower(name)] = lm
+		}
+	}
+	return migrations
+}
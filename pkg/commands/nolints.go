@@ -0,0 +1,210 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/packages"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
+)
+
+const hoursPerDay = 24
+
+func (e *Executor) initNolints() {
+	cmd := &cobra.Command{
+		Use:   "nolints [paths...]",
+		Short: "List the //nolint directives with their reasons, expiration dates and ages",
+		Run:   e.executeNolints,
+	}
+	e.rootCmd.AddCommand(cmd)
+}
+
+// executeNolints runs the 'nolints' CLI command.
+func (e *Executor) executeNolints(_ *cobra.Command, args []string) {
+	if len(args) == 0 {
+		args = []string{"./..."}
+	}
+
+	files, err := goFiles(args)
+	if err != nil {
+		e.log.Fatalf("Can't list files: %s", err)
+	}
+
+	now := time.Now()
+
+	w := tabwriter.NewWriter(logutils.StdOut, 0, 0, 2, ' ', 0)
+	for _, file := range files {
+		directives := fileNolintDirectives(file)
+		if len(directives) == 0 {
+			continue
+		}
+
+		authorTimes := gitAuthorTimes(file)
+
+		for _, fd := range directives {
+			linters := "all"
+			if len(fd.Linters) != 0 {
+				linters = strings.Join(fd.Linters, ",")
+			}
+
+			age := "-"
+			if t, ok := authorTimes[fd.line]; ok {
+				age = fmt.Sprintf("%dd", int(now.Sub(t).Hours()/hoursPerDay))
+			}
+
+			expires := "-"
+			switch {
+			case fd.InvalidExpires != "":
+				expires = fmt.Sprintf("%s (invalid)", fd.InvalidExpires)
+			case !fd.Expires.IsZero():
+				expires = fd.Expires.Format("2006-01-02")
+				if fd.IsExpired(now) {
+					expires += " (expired)"
+				}
+			}
+
+			reason := "-"
+			if fd.Reason != "" {
+				reason = strconv.Quote(fd.Reason)
+			}
+
+			fmt.Fprintf(w, "%s:%d\t%s\t%s\t%s\t%s\n", file, fd.line, linters, age, expires, reason)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		e.log.Fatalf("Can't print nolint directives: %s", err)
+	}
+
+	os.Exit(exitcodes.Success)
+}
+
+type fileNolintDirective struct {
+	*processors.NolintDirective
+	line int
+}
+
+func fileNolintDirectives(file string) []fileNolintDirective {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+	if err != nil {
+		return nil
+	}
+
+	var ret []fileNolintDirective
+	for _, g := range f.Comments {
+		for _, c := range g.List {
+			if d := processors.ParseNolintDirective(c.Text); d != nil {
+				ret = append(ret, fileNolintDirective{NolintDirective: d, line: fset.Position(c.Pos()).Line})
+			}
+		}
+	}
+
+	return ret
+}
+
+// goFiles lists the Go files of the paths: `dir/...` is recursive, the standard excluded dirs are skipped.
+func goFiles(paths []string) ([]string, error) {
+	var skipDirs []*regexp.Regexp
+	for _, re := range packages.StdExcludeDirRegexps {
+		skipDirs = append(skipDirs, regexp.MustCompile(re))
+	}
+
+	var files []string
+	for _, path := range paths {
+		recursive := strings.HasSuffix(path, "/...")
+		root := strings.TrimSuffix(path, "/...")
+		if root == "" {
+			root = "."
+		}
+
+		err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() {
+				if p == root {
+					return nil
+				}
+				if !recursive || strings.HasPrefix(d.Name(), ".") || matchAny(skipDirs, p) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if strings.HasSuffix(p, ".go") {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+func matchAny(res []*regexp.Regexp, s string) bool {
+	for _, re := range res {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// gitAuthorTimes returns the time of the last change of each line of the file, it's empty outside a git repository.
+func gitAuthorTimes(file string) map[int]time.Time {
+	out, err := exec.Command("git", "blame", "--porcelain", "--", file).Output()
+	if err != nil {
+		return nil
+	}
+
+	commitTimes := map[string]time.Time{}
+	lineTimes := map[int]time.Time{}
+
+	var commit string
+	var line int
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		text := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(text, "\t"):
+			lineTimes[line] = commitTimes[commit]
+		case strings.HasPrefix(text, "author-time "):
+			sec, err := strconv.ParseInt(strings.TrimPrefix(text, "author-time "), 10, 64)
+			if err == nil {
+				commitTimes[commit] = time.Unix(sec, 0)
+			}
+		default:
+			// header of a line: <sha> <original line> <final line> [<lines count>]
+			fields := strings.Fields(text)
+			if len(fields) >= 3 && len(fields[0]) == 40 {
+				commit = fields[0]
+				line, _ = strconv.Atoi(fields[2])
+			}
+		}
+	}
+
+	return lineTimes
+}
@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/policy"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
+)
+
+func (e *Executor) initPolicy() {
+	policyCmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Policy enforced by the config",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	e.rootCmd.AddCommand(policyCmd)
+
+	checkCmd := &cobra.Command{
+		Use:   "check [paths...]",
+		Short: "Check the config and the //nolint directives of the files against the policy, without running the linters",
+		Run:   e.executePolicyCheck,
+	}
+	policyCmd.AddCommand(checkCmd)
+	e.initRunConfiguration(checkCmd)
+}
+
+// executePolicyCheck runs the 'policy check' CLI command.
+func (e *Executor) executePolicyCheck(_ *cobra.Command, args []string) {
+	if len(args) == 0 {
+		args = []string{"./..."}
+	}
+
+	if e.cfg.Policy.IsEmpty() {
+		e.log.Infof("No policy in the config")
+		os.Exit(exitcodes.Success)
+	}
+
+	p, violations, err := e.checkPolicyConfig()
+	if err != nil {
+		e.log.Fatalf("Can't check the policy: %s", err)
+	}
+
+	files, err := goFiles(args)
+	if err != nil {
+		e.log.Fatalf("Can't list files: %s", err)
+	}
+
+	for _, file := range files {
+		violations = append(violations, nolintViolations(p, file)...)
+	}
+
+	for _, v := range violations {
+		fmt.Fprintln(logutils.StdOut, v)
+	}
+
+	if len(violations) != 0 {
+		e.log.Errorf("Found %d policy violations", len(violations))
+		os.Exit(exitcodes.Failure)
+	}
+
+	os.Exit(exitcodes.Success)
+}
+
+// checkPolicy fails the run if the config violates the policy.
+func (e *Executor) checkPolicy() error {
+	if e.cfg.Policy.IsEmpty() {
+		return nil
+	}
+
+	_, violations, err := e.checkPolicyConfig()
+	if err != nil {
+		return err
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	for _, v := range violations {
+		e.log.Errorf("Policy violation: %s", v)
+	}
+
+	return &exitcodes.ExitError{
+		Message: fmt.Sprintf("the config violates the policy (%d violations)", len(violations)),
+		Code:    exitcodes.Failure,
+	}
+}
+
+// checkPolicyConfig creates the policy of the config, and checks the config.
+func (e *Executor) checkPolicyConfig() (*policy.Policy, []policy.Violation, error) {
+	p, err := policy.New(e.cfg, e.DBManager)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	enabledLintersMap, err := e.EnabledLintersSet.GetEnabledLintersMap()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return p, p.CheckConfig(enabledLintersMap), nil
+}
+
+// nolintViolations returns the //nolint directives of the file without the reason required by the policy.
+func nolintViolations(p *policy.Policy, file string) []policy.Violation {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+	if err != nil {
+		return nil
+	}
+
+	var ret []policy.Violation
+	for _, g := range f.Comments {
+		for _, c := range g.List {
+			d := processors.ParseNolintDirective(c.Text)
+			if d == nil {
+				continue
+			}
+
+			if v := p.CheckNolint(fset.Position(c.Pos()), d); v != nil {
+				ret = append(ret, *v)
+			}
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/mattn/go-isatty"
+
+	"github.com/golangci/golangci-lint/pkg/lint"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/progress"
+)
+
+// progressDisabledBy returns the option disabling the progress display, empty if it's shown.
+func (e *Executor) progressDisabledBy(stderrIsTerminal bool) string {
+	switch {
+	case e.cfg.Output.NoProgress:
+		return "--no-progress"
+	case !stderrIsTerminal:
+		return "a non-terminal standard error"
+	case e.cfg.Run.IsVerbose:
+		return "--verbose"
+	case e.cfg.Run.LogFormat == logutils.LogFormatJSON:
+		return "--log-format=json"
+	case e.cfg.Run.Interactive:
+		return "--interactive"
+	case len(e.streamed) != 0:
+		return "streamed outputs"
+	}
+	return ""
+}
+
+// startProgress shows the live status of the run on the terminal: the logs are printed above it.
+func (e *Executor) startProgress(stderrIsTerminal bool) {
+	if opt := e.progressDisabledBy(stderrIsTerminal); opt != "" {
+		e.debugf("The progress isn't shown: disabled by %s", opt)
+		return
+	}
+
+	e.progress = progress.New(logutils.StdErr)
+	logutils.SetLogOutput(e.progress.Writer())
+	e.progress.Start()
+}
+
+// stopProgress erases the status and prints the durations of the stages.
+func (e *Executor) stopProgress() {
+	if e.progress == nil {
+		return
+	}
+
+	e.progress.Stop()
+	logutils.SetLogOutput(nil)
+	e.progress = nil
+}
+
+func (e *Executor) progressStage(name string) {
+	if e.progress != nil {
+		e.progress.StartStage(name)
+	}
+}
+
+// trackProgress reports the progress of the linters of the runner and of their packages.
+func (e *Executor) trackProgress(runner *lint.Runner, lintCtx *linter.Context) {
+	if e.progress == nil {
+		return
+	}
+
+	d := e.progress
+	d.PackagesLoaded(len(lintCtx.Packages))
+	d.StartStage(progress.StageAnalyze)
+
+	runner.OnLinterStart = d.LinterStarted
+	runner.OnLinterDone = d.LinterDone
+	runner.OnProcessing = func() { d.StartStage(progress.StageProcess) }
+	lintCtx.OnPackageAnalyzed = d.PackageAnalyzed
+}
+
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
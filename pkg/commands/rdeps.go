@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/incremental"
+)
+
+// expandReverseDeps replaces the arguments by the directories of their packages and of the packages importing them,
+// directly or not: the packages whose issues may change with the packages of the arguments.
+// The importing packages are looked for in the modules of the packages of the arguments.
+func (e *Executor) expandReverseDeps(ctx context.Context, args []string) ([]string, error) {
+	if !e.cfg.Run.ReverseDeps {
+		return args, nil
+	}
+	if e.cfg.Run.Stdin {
+		return nil, errors.New("can't combine options --rdeps and --stdin")
+	}
+
+	targets, err := incremental.ListPackages(ctx, args, e.cfg.Run.BuildTags)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list packages")
+	}
+
+	var patterns []string
+	seen := map[string]bool{}
+	for _, p := range targets {
+		if p.GoMod == "" || seen[p.GoMod] {
+			continue
+		}
+		seen[p.GoMod] = true
+		patterns = append(patterns, filepath.Join(filepath.Dir(p.GoMod), "..."))
+	}
+
+	if len(patterns) == 0 {
+		e.log.Warnf("The packages of the arguments aren't in modules: their reverse dependencies aren't analyzed")
+		return args, nil
+	}
+
+	pkgs, err := incremental.ListPackages(ctx, patterns, e.cfg.Run.BuildTags)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the packages of the modules")
+	}
+
+	rdeps := incremental.ReverseDependencies(pkgs, targets)
+	e.log.Infof("Reverse dependencies: analyzing %d packages, the %d packages of the arguments and the packages importing them",
+		len(rdeps), len(targets))
+
+	return incremental.Dirs(rdeps), nil
+}
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/codeowners"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/printers"
+	"github.com/golangci/golangci-lint/pkg/report"
+)
+
+func (e *Executor) initReport() {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Work with the reports of the json output format",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 0 {
+				e.log.Fatalf("Usage: golangci-lint report")
+			}
+			if err := cmd.Help(); err != nil {
+				e.log.Fatalf("Can't run help: %s", err)
+			}
+		},
+	}
+	e.rootCmd.AddCommand(cmd)
+
+	var format string
+	queryCmd := &cobra.Command{
+		Use:   "query <expr> [report.json|-]",
+		Short: "Filter, group and count the issues of a report",
+		Long: `Filter, group and count the issues of a report generated with --out-format=json.
+
+Filters are separated by spaces and must all match:
+  <field>=<values>   one of the comma-separated values (glob patterns for path)
+  <field>!=<values>  none of the comma-separated values
+  <field>~<regexp>   matches the regular expression
+Fields: linter, severity, path, text, owner (from the CODEOWNERS file).
+
+Stages follow the filters after '|':
+  group-by <field>[,<field>]  count the issues per values of the fields
+  count                       count the issues
+
+Example: golangci-lint report query 'linter=errcheck path=pkg/** | group-by owner' report.json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e.executeReportQuery(args, format)
+		},
+	}
+	queryCmd.Flags().StringVar(&format, "format", "text", wh("Output format: text|json"))
+	cmd.AddCommand(queryCmd)
+}
+
+// executeReportQuery runs the 'report query' CLI command.
+func (e *Executor) executeReportQuery(args []string, format string) {
+	if len(args) == 0 || len(args) > 2 {
+		e.log.Fatalf("Usage: golangci-lint report query <expr> [report.json|-]")
+	}
+
+	if format != "text" && format != "json" {
+		e.log.Fatalf("Unknown format %q: must be text or json", format)
+	}
+
+	q, err := report.ParseQuery(args[0])
+	if err != nil {
+		e.log.Fatalf("Invalid query: %s", err)
+	}
+
+	path := "-"
+	if len(args) == 2 {
+		path = args[1]
+	}
+
+	res, err := readJSONReport(path)
+	if err != nil {
+		e.log.Fatalf("Can't read report %s: %s", path, err)
+	}
+
+	owners, err := codeowners.Find(".")
+	if err != nil {
+		e.log.Warnf("Can't read CODEOWNERS: %s", err)
+	}
+
+	qr := q.Run(res.Issues, owners.Of)
+
+	if format == "json" {
+		if err := json.NewEncoder(logutils.StdOut).Encode(qr); err != nil {
+			e.log.Fatalf("Can't print query result: %s", err)
+		}
+		os.Exit(exitcodes.Success)
+	}
+
+	switch {
+	case q.Grouped():
+		for _, g := range qr.Groups {
+			keys := make([]string, 0, len(g.Values))
+			for k := range g.Values {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			var values []string
+			for _, k := range keys {
+				values = append(values, fmt.Sprintf("%s=%s", k, g.Values[k]))
+			}
+			fmt.Fprintf(logutils.StdOut, "%d\t%s\n", g.Count, strings.Join(values, " "))
+		}
+	case !q.Counted():
+		for i := range qr.Issues {
+			issue := &qr.Issues[i]
+			fmt.Fprintf(logutils.StdOut, "%s: %s (%s)\n", issue.Pos, issue.Text, issue.FromLinter)
+		}
+	default:
+		fmt.Fprintln(logutils.StdOut, qr.Count)
+	}
+
+	os.Exit(exitcodes.Success)
+}
+
+func readJSONReport(path string) (*printers.JSONResult, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var res printers.JSONResult
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	hcversion "github.com/hashicorp/go-version"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/goutil"
+)
+
+// checkRequirements checks the requirements of the config before the run: the version of golangci-lint,
+// the version of the Go toolchain and the custom linters, whose differences change the results.
+func (e *Executor) checkRequirements(ctx context.Context) error {
+	var failures []string
+
+	if constraint := e.cfg.Service.GolangciLintVersion; constraint != "" {
+		ok, err := e.matchVersion(constraint, e.version)
+		if err != nil {
+			return fmt.Errorf("invalid service.golangci-lint-version: %w", err)
+		}
+		if !ok {
+			failures = append(failures, fmt.Sprintf("golangci-lint %s doesn't match the version %q required by service.golangci-lint-version:"+
+				" install a matching version, see https://golangci-lint.run/usage/install/", e.version, constraint))
+		}
+	}
+
+	if constraint := e.cfg.Run.GoVersion; constraint != "" {
+		if err := e.goenv.DiscoverKeys(ctx, goutil.EnvGoVersion); err != nil {
+			e.log.Warnf("Failed to discover the Go version: %s", err)
+		}
+
+		goVersion := e.goenv.Get(goutil.EnvGoVersion)
+		ok, err := e.matchVersion(constraint, config.GoReleaseVersion(goVersion))
+		if err != nil {
+			return fmt.Errorf("invalid run.go-version: %w", err)
+		}
+		if !ok {
+			failures = append(failures, fmt.Sprintf("the Go toolchain %s doesn't match the version %q required by run.go-version:"+
+				" install a matching version, see https://go.dev/dl/", goVersion, constraint))
+		}
+	}
+
+	for _, name := range e.cfg.Service.RequiredCustomLinters {
+		if !e.DBManager.IsCustomLinterLoaded(name) {
+			failures = append(failures, fmt.Sprintf("the custom linter %s required by service.required-custom-linters isn't loaded:"+
+				" fix its settings or build a binary with it with 'golangci-lint custom'", name))
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	for _, f := range failures {
+		e.log.Errorf("%s", f)
+	}
+
+	return &exitcodes.ExitError{
+		Message: fmt.Sprintf("the requirements of the config aren't met (%d failures)", len(failures)),
+		Code:    exitcodes.Failure,
+	}
+}
+
+// matchVersion checks if the version matches the constraint: the unknown versions, e.g. of the development builds,
+// match with a warning.
+func (e *Executor) matchVersion(constraint, version string) (bool, error) {
+	c, err := config.ParseVersionConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+
+	// The development builds have no version, e.g. devel go1.22-abcdef, or a pseudo-version v0.0.0-20231001-abcdef.
+	var v *hcversion.Version
+	if fields := strings.Fields(version); len(fields) != 0 {
+		v, err = hcversion.NewVersion(fields[0])
+	}
+	if v == nil || err != nil || strings.HasPrefix(v.String(), "0.0.0-") {
+		e.log.Warnf("Can't check the version constraint %q: unknown version %q", constraint, version)
+		return true, nil
+	}
+
+	return c.Check(v), nil
+}
@@ -3,7 +3,9 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
+	"runtime/debug"
 	"runtime/pprof"
 	"runtime/trace"
 	"strconv"
@@ -11,17 +13,20 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
-	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/commands/flagsets"
 	"github.com/golangci/golangci-lint/pkg/exitcodes"
 	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/profile"
+	"github.com/golangci/golangci-lint/pkg/resources"
 )
 
-func (e *Executor) persistentPreRun(_ *cobra.Command, _ []string) {
+func (e *Executor) persistentPreRun(cmd *cobra.Command, _ []string) {
 	if e.cfg.Run.PrintVersion {
 		fmt.Fprintf(logutils.StdOut, "golangci-lint has version %s built from %s on %s\n", e.version, e.commit, e.date)
 		os.Exit(exitcodes.Success)
 	}
 
+	e.tuneResources(cmd.Flags())
 	runtime.GOMAXPROCS(e.cfg.Run.Concurrency)
 
 	if e.cfg.Run.CPUProfilePath != "" {
@@ -49,6 +54,62 @@ func (e *Executor) persistentPreRun(_ *cobra.Command, _ []string) {
 			e.log.Fatalf("Can't start tracing: %s", err)
 		}
 	}
+
+	if e.cfg.Run.ProfileDir != "" {
+		e.startProfileBundle()
+	}
+}
+
+// startProfileBundle starts the profiling bundle: the CPU profile, the trace, the run report and the timings of the run.
+func (e *Executor) startProfileBundle() {
+	rc := &e.cfg.Run
+	if rc.CPUProfilePath != "" || rc.MemProfilePath != "" || rc.TracePath != "" {
+		e.log.Fatalf("Can't combine option --profile-dir and options --cpu-profile-path, --mem-profile-path or --trace-path")
+	}
+
+	bundle, err := profile.Start(rc.ProfileDir)
+	if err != nil {
+		e.log.Fatalf("Can't start profiling: %s", err)
+	}
+	e.profile = bundle
+
+	if e.cfg.Output.ReportFile == "" {
+		e.cfg.Output.ReportFile = filepath.Join(rc.ProfileDir, profile.ReportFile)
+	}
+}
+
+// stopProfileBundle writes the profiles and the timings of the run.
+func (e *Executor) stopProfileBundle() {
+	err := e.profile.Stop(func(t *profile.Timings) {
+		t.Version = e.version
+		t.Args = os.Args[1:]
+		t.Concurrency = e.cfg.Run.Concurrency
+
+		for _, ld := range e.reportData.Linters {
+			if ld.Enabled && ld.DurationMs != 0 {
+				t.Linters = append(t.Linters, profile.Timing{Name: ld.Name, DurationMs: float64(ld.DurationMs)})
+			}
+		}
+
+		if e.fileCache != nil {
+			t.Cache.FileEntries, t.Cache.FileBytes = e.fileCache.Stats()
+		}
+		if e.pkgCache != nil {
+			t.Cache.PackageHits, t.Cache.PackageMisses = e.pkgCache.Stats()
+		}
+		if e.sw != nil {
+			for name, d := range e.sw.Stages() {
+				t.Cache.PackageStages = append(t.Cache.PackageStages,
+					profile.Timing{Name: name, DurationMs: logutils.DurationField(d)})
+			}
+		}
+	})
+	if err != nil {
+		e.log.Errorf("Can't write the profiling bundle to %s: %s", e.cfg.Run.ProfileDir, err)
+		return
+	}
+
+	e.log.Infof("Profiling bundle written to %s", e.cfg.Run.ProfileDir)
 }
 
 func (e *Executor) persistentPostRun(_ *cobra.Command, _ []string) {
@@ -73,6 +134,9 @@ func (e *Executor) persistentPostRun(_ *cobra.Command, _ []string) {
 	if e.cfg.Run.TracePath != "" {
 		trace.Stop()
 	}
+	if e.profile != nil {
+		e.stopProfileBundle()
+	}
 
 	os.Exit(e.exitCode)
 }
@@ -105,14 +169,39 @@ func formatMemory(memBytes uint64) string {
 	return fmt.Sprintf("%dmb", memBytes/Mb)
 }
 
-func getDefaultConcurrency() int {
-	if os.Getenv("HELP_RUN") == "1" {
-		// Make stable concurrency for README help generating builds.
-		const prettyConcurrency = 8
-		return prettyConcurrency
+// tuneResources adapts the runtime to the limits of the container:
+// the values set explicitly by the user (`--concurrency`, `GOMAXPROCS`, `GOMEMLIMIT`, `GOGC`) are kept.
+func (e *Executor) tuneResources(fs *pflag.FlagSet) {
+	if !e.cfg.Run.AutoTune {
+		return
+	}
+
+	limits := resources.Detect()
+
+	procs := limits.Procs()
+	if procs != 0 && procs < e.cfg.Run.Concurrency &&
+		!fs.Changed("concurrency") && os.Getenv("GOMAXPROCS") == "" {
+		e.log.Infof("CPU quota is %.2f: set concurrency to %d", limits.CPU, procs)
+		e.cfg.Run.Concurrency = procs
 	}
 
-	return runtime.NumCPU()
+	if limits.Memory != 0 && os.Getenv("GOMEMLIMIT") == "" {
+		// Keep a margin for the memory not managed by the Go runtime.
+		const memoryLimitPercent = 90
+
+		limit := limits.Memory / 100 * memoryLimitPercent
+		if resources.SetMemoryLimit(limit) {
+			e.log.Infof("Memory limit is %s: set GOMEMLIMIT to %s",
+				formatMemory(uint64(limits.Memory)), formatMemory(uint64(limit)))
+
+			// The memory limit triggers the collections near the limit: below it, collect less often to save CPU.
+			const gcPercent = 200
+			if os.Getenv("GOGC") == "" {
+				debug.SetGCPercent(gcPercent)
+				e.log.Infof("Set GOGC to %d", gcPercent)
+			}
+		}
+	}
 }
 
 func (e *Executor) initRoot() {
@@ -132,35 +221,10 @@ func (e *Executor) initRoot() {
 		PersistentPostRun: e.persistentPostRun,
 	}
 
-	initRootFlagSet(rootCmd.PersistentFlags(), e.cfg, e.needVersionOption())
+	flagsets.AddRoot(rootCmd.PersistentFlags(), e.cfg, e.needVersionOption())
 	e.rootCmd = rootCmd
 }
 
 func (e *Executor) needVersionOption() bool {
 	return e.date != ""
 }
-
-func initRootFlagSet(fs *pflag.FlagSet, cfg *config.Config, needVersionOption bool) {
-	fs.BoolVarP(&cfg.Run.IsVerbose, "verbose", "v", false, wh("verbose output"))
-
-	var silent bool
-	fs.BoolVarP(&silent, "silent", "s", false, wh("disables congrats outputs"))
-	if err := fs.MarkHidden("silent"); err != nil {
-		panic(err)
-	}
-	err := fs.MarkDeprecated("silent",
-		"now golangci-lint by default is silent: it doesn't print Congrats message")
-	if err != nil {
-		panic(err)
-	}
-
-	fs.StringVar(&cfg.Run.CPUProfilePath, "cpu-profile-path", "", wh("Path to CPU profile output file"))
-	fs.StringVar(&cfg.Run.MemProfilePath, "mem-profile-path", "", wh("Path to memory profile output file"))
-	fs.StringVar(&cfg.Run.TracePath, "trace-path", "", wh("Path to trace output file"))
-	fs.IntVarP(&cfg.Run.Concurrency, "concurrency", "j", getDefaultConcurrency(), wh("Concurrency (default NumCPU)"))
-	if needVersionOption {
-		fs.BoolVar(&cfg.Run.PrintVersion, "version", false, wh("Print version"))
-	}
-
-	fs.StringVar(&cfg.Output.Color, "color", "auto", wh("Use color when printing; can be 'always', 'auto', or 'never'"))
-}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -14,234 +15,34 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	gopackages "golang.org/x/tools/go/packages"
 
+	"github.com/golangci/golangci-lint/pkg/commands/flagsets"
 	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/lint"
-	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/logutils"
-	"github.com/golangci/golangci-lint/pkg/packages"
+	"github.com/golangci/golangci-lint/pkg/postprocess"
 	"github.com/golangci/golangci-lint/pkg/printers"
+	"github.com/golangci/golangci-lint/pkg/printers/sink"
+	"github.com/golangci/golangci-lint/pkg/progress"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/resources"
 	"github.com/golangci/golangci-lint/pkg/result"
-	"github.com/golangci/golangci-lint/pkg/result/processors"
 )
 
 const defaultFileMode = 0644
 
-func getDefaultIssueExcludeHelp() string {
-	parts := []string{"Use or not use default excludes:"}
-	for _, ep := range config.DefaultExcludePatterns {
-		parts = append(parts,
-			fmt.Sprintf("  # %s %s: %s", ep.ID, ep.Linter, ep.Why),
-			fmt.Sprintf("  - %s", color.YellowString(ep.Pattern)),
-			"",
-		)
-	}
-	return strings.Join(parts, "\n")
-}
-
-func getDefaultDirectoryExcludeHelp() string {
-	parts := []string{"Use or not use default excluded directories:"}
-	for _, dir := range packages.StdExcludeDirRegexps {
-		parts = append(parts, fmt.Sprintf("  - %s", color.YellowString(dir)))
-	}
-	parts = append(parts, "")
-	return strings.Join(parts, "\n")
-}
-
 func wh(text string) string {
 	return color.GreenString(text)
 }
 
-const defaultTimeout = time.Minute
-
-//nolint:funlen,gomnd
-func initFlagSet(fs *pflag.FlagSet, cfg *config.Config, m *lintersdb.Manager, isFinalInit bool) {
-	hideFlag := func(name string) {
-		if err := fs.MarkHidden(name); err != nil {
-			panic(err)
-		}
-
-		// we run initFlagSet multiple times, but we wouldn't like to see deprecation message multiple times
-		if isFinalInit {
-			const deprecateMessage = "flag will be removed soon, please, use .golangci.yml config"
-			if err := fs.MarkDeprecated(name, deprecateMessage); err != nil {
-				panic(err)
-			}
-		}
-	}
-
-	// Output config
-	oc := &cfg.Output
-	fs.StringVar(&oc.Format, "out-format",
-		config.OutFormatColoredLineNumber,
-		wh(fmt.Sprintf("Format of output: %s", strings.Join(config.OutFormats, "|"))))
-	fs.BoolVar(&oc.PrintIssuedLine, "print-issued-lines", true, wh("Print lines of code with issue"))
-	fs.BoolVar(&oc.PrintLinterName, "print-linter-name", true, wh("Print linter name in issue line"))
-	fs.BoolVar(&oc.UniqByLine, "uniq-by-line", true, wh("Make issues output unique by line"))
-	fs.BoolVar(&oc.SortResults, "sort-results", false, wh("Sort linter results"))
-	fs.BoolVar(&oc.PrintWelcomeMessage, "print-welcome", false, wh("Print welcome message"))
-	fs.StringVar(&oc.PathPrefix, "path-prefix", "", wh("Path prefix to add to output"))
-	hideFlag("print-welcome") // no longer used
-
-	fs.BoolVar(&cfg.InternalCmdTest, "internal-cmd-test", false, wh("Option is used only for testing golangci-lint command, don't use it"))
-	if err := fs.MarkHidden("internal-cmd-test"); err != nil {
-		panic(err)
-	}
-
-	// Run config
-	rc := &cfg.Run
-	fs.StringVar(&rc.ModulesDownloadMode, "modules-download-mode", "",
-		"Modules download mode. If not empty, passed as -mod=<mode> to go tools")
-	fs.IntVar(&rc.ExitCodeIfIssuesFound, "issues-exit-code",
-		exitcodes.IssuesFound, wh("Exit code when issues were found"))
-	fs.StringVar(&rc.Go, "go", "", wh("Targeted Go version"))
-	fs.StringSliceVar(&rc.BuildTags, "build-tags", nil, wh("Build tags"))
-
-	fs.DurationVar(&rc.Timeout, "deadline", defaultTimeout, wh("Deadline for total work"))
-	if err := fs.MarkHidden("deadline"); err != nil {
-		panic(err)
-	}
-	fs.DurationVar(&rc.Timeout, "timeout", defaultTimeout, wh("Timeout for total work"))
-
-	fs.BoolVar(&rc.AnalyzeTests, "tests", true, wh("Analyze tests (*_test.go)"))
-	fs.BoolVar(&rc.PrintResourcesUsage, "print-resources-usage", false,
-		wh("Print avg and max memory usage of golangci-lint and total time"))
-	fs.StringVarP(&rc.Config, "config", "c", "", wh("Read config from file path `PATH`"))
-	fs.BoolVar(&rc.NoConfig, "no-config", false, wh("Don't read config"))
-	fs.StringSliceVar(&rc.SkipDirs, "skip-dirs", nil, wh("Regexps of directories to skip"))
-	fs.BoolVar(&rc.UseDefaultSkipDirs, "skip-dirs-use-default", true, getDefaultDirectoryExcludeHelp())
-	fs.StringSliceVar(&rc.SkipFiles, "skip-files", nil, wh("Regexps of files to skip"))
-
-	const allowParallelDesc = "Allow multiple parallel golangci-lint instances running. " +
-		"If false (default) - golangci-lint acquires file lock on start."
-	fs.BoolVar(&rc.AllowParallelRunners, "allow-parallel-runners", false, wh(allowParallelDesc))
-	const allowSerialDesc = "Allow multiple golangci-lint instances running, but serialize them	around a lock. " +
-		"If false (default) - golangci-lint exits with an error if it fails to acquire file lock on start."
-	fs.BoolVar(&rc.AllowSerialRunners, "allow-serial-runners", false, wh(allowSerialDesc))
-
-	// Linters settings config
-	lsc := &cfg.LintersSettings
-
-	// Hide all linters settings flags: they were initially visible,
-	// but when number of linters started to grow it became obvious that
-	// we can't fill 90% of flags by linters settings: common flags became hard to find.
-	// New linters settings should be done only through config file.
-	fs.BoolVar(&lsc.Errcheck.CheckTypeAssertions, "errcheck.check-type-assertions",
-		false, "Errcheck: check for ignored type assertion results")
-	hideFlag("errcheck.check-type-assertions")
-	fs.BoolVar(&lsc.Errcheck.CheckAssignToBlank, "errcheck.check-blank", false,
-		"Errcheck: check for errors assigned to blank identifier: _ = errFunc()")
-	hideFlag("errcheck.check-blank")
-	fs.StringVar(&lsc.Errcheck.Exclude, "errcheck.exclude", "",
-		"Path to a file containing a list of functions to exclude from checking")
-	hideFlag("errcheck.exclude")
-	fs.StringVar(&lsc.Errcheck.Ignore, "errcheck.ignore", "fmt:.*",
-		`Comma-separated list of pairs of the form pkg:regex. The regex is used to ignore names within pkg`)
-	hideFlag("errcheck.ignore")
-
-	fs.BoolVar(&lsc.Govet.CheckShadowing, "govet.check-shadowing", false,
-		"Govet: check for shadowed variables")
-	hideFlag("govet.check-shadowing")
-
-	fs.Float64Var(&lsc.Golint.MinConfidence, "golint.min-confidence", 0.8,
-		"Golint: minimum confidence of a problem to print it")
-	hideFlag("golint.min-confidence")
-
-	fs.BoolVar(&lsc.Gofmt.Simplify, "gofmt.simplify", true, "Gofmt: simplify code")
-	hideFlag("gofmt.simplify")
-
-	fs.IntVar(&lsc.Gocyclo.MinComplexity, "gocyclo.min-complexity",
-		30, "Minimal complexity of function to report it")
-	hideFlag("gocyclo.min-complexity")
-
-	fs.BoolVar(&lsc.Maligned.SuggestNewOrder, "maligned.suggest-new", false,
-		"Maligned: print suggested more optimal struct fields ordering")
-	hideFlag("maligned.suggest-new")
-
-	fs.IntVar(&lsc.Dupl.Threshold, "dupl.threshold",
-		150, "Dupl: Minimal threshold to detect copy-paste")
-	hideFlag("dupl.threshold")
-
-	fs.BoolVar(&lsc.Goconst.MatchWithConstants, "goconst.match-constant",
-		true, "Goconst: look for existing constants matching the values")
-	hideFlag("goconst.match-constant")
-	fs.IntVar(&lsc.Goconst.MinStringLen, "goconst.min-len",
-		3, "Goconst: minimum constant string length")
-	hideFlag("goconst.min-len")
-	fs.IntVar(&lsc.Goconst.MinOccurrencesCount, "goconst.min-occurrences",
-		3, "Goconst: minimum occurrences of constant string count to trigger issue")
-	hideFlag("goconst.min-occurrences")
-	fs.BoolVar(&lsc.Goconst.ParseNumbers, "goconst.numbers",
-		false, "Goconst: search also for duplicated numbers")
-	hideFlag("goconst.numbers")
-	fs.IntVar(&lsc.Goconst.NumberMin, "goconst.min",
-		3, "minimum value, only works with goconst.numbers")
-	hideFlag("goconst.min")
-	fs.IntVar(&lsc.Goconst.NumberMax, "goconst.max",
-		3, "maximum value, only works with goconst.numbers")
-	hideFlag("goconst.max")
-	fs.BoolVar(&lsc.Goconst.IgnoreCalls, "goconst.ignore-calls",
-		true, "Goconst: ignore when constant is not used as function argument")
-	hideFlag("goconst.ignore-calls")
-
-	// (@dixonwille) These flag is only used for testing purposes.
-	fs.StringSliceVar(&lsc.Depguard.Packages, "depguard.packages", nil,
-		"Depguard: packages to add to the list")
-	hideFlag("depguard.packages")
-
-	fs.BoolVar(&lsc.Depguard.IncludeGoRoot, "depguard.include-go-root", false,
-		"Depguard: check list against standard lib")
-	hideFlag("depguard.include-go-root")
-
-	fs.IntVar(&lsc.Lll.TabWidth, "lll.tab-width", 1,
-		"Lll: tab width in spaces")
-	hideFlag("lll.tab-width")
-
-	// Linters config
-	lc := &cfg.Linters
-	fs.StringSliceVarP(&lc.Enable, "enable", "E", nil, wh("Enable specific linter"))
-	fs.StringSliceVarP(&lc.Disable, "disable", "D", nil, wh("Disable specific linter"))
-	fs.BoolVar(&lc.EnableAll, "enable-all", false, wh("Enable all linters"))
-
-	fs.BoolVar(&lc.DisableAll, "disable-all", false, wh("Disable all linters"))
-	fs.StringSliceVarP(&lc.Presets, "presets", "p", nil,
-		wh(fmt.Sprintf("Enable presets (%s) of linters. Run 'golangci-lint linters' to see "+
-			"them. This option implies option --disable-all", strings.Join(m.AllPresets(), "|"))))
-	fs.BoolVar(&lc.Fast, "fast", false, wh("Run only fast linters from enabled linters set (first run won't be fast)"))
-
-	// Issues config
-	ic := &cfg.Issues
-	fs.StringSliceVarP(&ic.ExcludePatterns, "exclude", "e", nil, wh("Exclude issue by regexp"))
-	fs.BoolVar(&ic.UseDefaultExcludes, "exclude-use-default", true, getDefaultIssueExcludeHelp())
-	fs.BoolVar(&ic.ExcludeCaseSensitive, "exclude-case-sensitive", false, wh("If set to true exclude "+
-		"and exclude rules regular expressions are case sensitive"))
-
-	fs.IntVar(&ic.MaxIssuesPerLinter, "max-issues-per-linter", 50,
-		wh("Maximum issues count per one linter. Set to 0 to disable"))
-	fs.IntVar(&ic.MaxSameIssues, "max-same-issues", 3,
-		wh("Maximum count of issues with the same text. Set to 0 to disable"))
-
-	fs.BoolVarP(&ic.Diff, "new", "n", false,
-		wh("Show only new issues: if there are unstaged changes or untracked files, only those changes "+
-			"are analyzed, else only changes in HEAD~ are analyzed.\nIt's a super-useful option for integration "+
-			"of golangci-lint into existing large codebase.\nIt's not practical to fix all existing issues at "+
-			"the moment of integration: much better to not allow issues in new code.\nFor CI setups, prefer "+
-			"--new-from-rev=HEAD~, as --new can skip linting the current patch if any scripts generate "+
-			"unstaged files before golangci-lint runs."))
-	fs.StringVar(&ic.DiffFromRevision, "new-from-rev", "",
-		wh("Show only new issues created after git revision `REV`"))
-	fs.StringVar(&ic.DiffPatchFilePath, "new-from-patch", "",
-		wh("Show only new issues created in git patch with file path `PATH`"))
-	fs.BoolVar(&ic.WholeFiles, "whole-files", false,
-		wh("Show issues in any part of update files (requires new-from-rev or new-from-patch)"))
-	fs.BoolVar(&ic.NeedFix, "fix", false, "Fix found issues (if it's supported by the linter)")
-}
-
 func (e *Executor) initRunConfiguration(cmd *cobra.Command) {
 	fs := cmd.Flags()
 	fs.SortFlags = false // sort them as they are defined here
-	initFlagSet(fs, e.cfg, e.DBManager, true)
+	flagsets.AddRun(fs, e.cfg, e.DBManager, true)
 }
 
 func (e *Executor) getConfigForCommandLine() (*config.Config, error) {
@@ -254,15 +55,17 @@ func (e *Executor) getConfigForCommandLine() (*config.Config, error) {
 	// `changed` variable inside string slice vars will be shared.
 	// Use another config variable here, not e.cfg, to not
 	// affect main parsing by this parsing of only config option.
-	initFlagSet(fs, &cfg, e.DBManager, false)
+	flagsets.AddRun(fs, &cfg, e.DBManager, false)
 	initVersionFlagSet(fs, &cfg)
 
 	// Parse max options, even force version option: don't want
 	// to get access to Executor here: it's error-prone to use
 	// cfg vs e.cfg.
-	initRootFlagSet(fs, &cfg, true)
+	flagsets.AddRoot(fs, &cfg, true)
 
 	fs.Usage = func() {} // otherwise, help text will be printed twice
+	// The flags of the other commands are parsed by cobra.
+	fs.ParseErrorsWhitelist.UnknownFlags = true
 	if err := fs.Parse(os.Args); err != nil {
 		if err == pflag.ErrHelp {
 			return nil, err
@@ -276,7 +79,7 @@ func (e *Executor) getConfigForCommandLine() (*config.Config, error) {
 
 func (e *Executor) initRun() {
 	e.runCmd = &cobra.Command{
-		Use:   "run",
+		Use:   "run [recipe] [paths...]",
 		Short: "Run the linters",
 		Run:   e.executeRun,
 		PreRun: func(_ *cobra.Command, _ []string) {
@@ -326,44 +129,177 @@ func fixSlicesFlags(fs *pflag.FlagSet) {
 	})
 }
 
+// analyzeFunc runs the linters on the packages of the arguments, the issues are processed until the baseline.
+type analyzeFunc func(args []string) ([]result.Issue, error)
+
+// mergeFunc runs the analyses of a run with analyze, and merges their issues.
+type mergeFunc func(analyze analyzeFunc) ([]result.Issue, error)
+
 // runAnalysis executes the linters that have been enabled in the configuration.
 func (e *Executor) runAnalysis(ctx context.Context, args []string) ([]result.Issue, error) {
+	return e.runMergedAnalyses(ctx, args, nil)
+}
+
+// runMergedAnalyses executes the linters like runAnalysis if merge is nil, otherwise merge runs the analyses:
+// their merged issues are processed once from the baseline (limits, severities, sorting) and fixed.
+func (e *Executor) runMergedAnalyses(ctx context.Context, args []string, merge mergeFunc) ([]result.Issue, error) {
 	e.cfg.Run.Args = args
 
-	lintersToRun, err := e.EnabledLintersSet.GetOptimizedLinters()
-	if err != nil {
-		return nil, err
+	var runReport *report.RunReport
+	if e.cfg.Output.ReportFile != "" {
+		runReport = report.NewRunReport(e.version, time.Now())
+	}
+
+	analysis := &lint.Analysis{
+		Cfg:               e.cfg,
+		Log:               e.log,
+		GoEnv:             e.goenv,
+		DBManager:         e.DBManager,
+		EnabledLintersSet: e.EnabledLintersSet,
+		ContextLoader:     e.contextLoader,
+		FileCache:         e.fileCache,
+		LineCache:         e.lineCache,
+		Credentials:       e.credentials,
+		Unmerged:          merge != nil,
+	}
+
+	var c *canary
+	if e.cfg.Run.CanaryConfig != "" {
+		var err error
+		c, err = e.newCanary(e.cfg.Run.CanaryConfig)
+		if err != nil {
+			return nil, err
+		}
+		analysis.ExtraLinters = c.linters
+	}
+
+	// The analytics and the run report cover the merged analyses.
+	var (
+		startedAt time.Time
+		analytics *report.Analytics
+	)
+	analysis.OnLoad = func(enabledLintersMap map[string]*linter.Config) {
+		if analytics == nil && e.cfg.Output.AnalyticsPath != "" {
+			enabledLinters := make([]string, 0, len(enabledLintersMap))
+			for name := range enabledLintersMap {
+				enabledLinters = append(enabledLinters, name)
+			}
+			analytics = report.NewAnalytics(e.version, enabledLinters)
+		}
+
+		if e.reportData.Linters == nil { // once for the analyses of the build tag sets
+			for _, lc := range e.DBManager.GetAllSupportedLinterConfigs() {
+				isEnabled := enabledLintersMap[lc.Name()] != nil
+				e.reportData.AddLinter(lc.Name(), isEnabled, lc.EnabledByDefault)
+			}
+		}
+
+		e.progressStage(progress.StageLoad)
 	}
+	analysis.OnLoaded = func(lintCtx *linter.Context) {
+		if runReport != nil {
+			runReport.LoadDurationMs = time.Since(runReport.StartedAt).Milliseconds()
+			runReport.Packages = packagesReport(lintCtx)
+		}
+	}
+	analysis.OnRunner = func(runner *lint.Runner, lintCtx *linter.Context) {
+		runner.ReportData = &e.reportData
+		runner.RunReport = runReport
+		runner.OnIssues = e.issuesStream
+		runner.Analytics = analytics
+		runner.OnLimiting = func(issues []result.Issue) []result.Issue {
+			e.unlimitedIssues = append(e.unlimitedIssues, issues...)
+			return issues
+		}
+		e.trackProgress(runner, lintCtx)
 
-	enabledLintersMap, err := e.EnabledLintersSet.GetEnabledLintersMap()
+		if startedAt.IsZero() {
+			startedAt = time.Now()
+		}
+	}
+
+	res, err := e.analyze(ctx, analysis, args, merge)
 	if err != nil {
 		return nil, err
 	}
+	e.baseline = res.Runner.Baseline
+	issues, lintCtx, runner := res.Issues, res.LintCtx, res.Runner
 
-	for _, lc := range e.DBManager.GetAllSupportedLinterConfigs() {
-		isEnabled := enabledLintersMap[lc.Name()] != nil
-		e.reportData.AddLinter(lc.Name(), isEnabled, lc.EnabledByDefault)
+	if c != nil {
+		added, err := e.runCanary(ctx, c, lintCtx)
+		if err != nil {
+			return nil, errors.Wrap(err, "canary run failed")
+		}
+
+		e.log.Infof("Canary config %s: %d issue(s) added, %d removed",
+			e.cfg.Run.CanaryConfig, len(added), len(e.reportData.RemovedByCanary))
+		issues = added
 	}
 
-	lintCtx, err := e.contextLoader.Load(ctx, lintersToRun)
-	if err != nil {
-		return nil, errors.Wrap(err, "context loading failed")
+	if runner.Analytics != nil {
+		runner.Analytics.SetReported(issues)
+		runner.Analytics.DurationMs = time.Since(startedAt).Milliseconds()
+		if err := writeJSONFile(e.cfg.Output.AnalyticsPath, runner.Analytics); err != nil {
+			e.log.Warnf("Can't write analytics to %s: %s", e.cfg.Output.AnalyticsPath, err)
+		}
+	}
+
+	if runReport != nil {
+		runReport.Issues = len(issues)
+		runReport.DurationMs = time.Since(runReport.StartedAt).Milliseconds()
+		runReport.SetCache(e.pkgCache.Stats())
+		if err := writeJSONFile(e.cfg.Output.ReportFile, runReport); err != nil {
+			e.log.Warnf("Can't write run report to %s: %s", e.cfg.Output.ReportFile, err)
+		}
+	}
+
+	e.storeLastRun()
+
+	return issues, nil
+}
+
+// analyze runs the analysis, or the analyses of merge with their issues merged.
+func (e *Executor) analyze(ctx context.Context, analysis *lint.Analysis, args []string,
+	merge mergeFunc) (*lint.AnalysisResult, error) {
+	if merge == nil {
+		return analysis.Run(ctx)
 	}
-	lintCtx.Log = e.log.Child("linters context")
 
-	runner, err := lint.NewRunner(e.cfg, e.log.Child("runner"),
-		e.goenv, e.EnabledLintersSet, e.lineCache, e.DBManager, lintCtx.Packages)
+	var last *lint.AnalysisResult
+	issues, err := merge(func(analyzedArgs []string) ([]result.Issue, error) {
+		e.cfg.Run.Args = analyzedArgs
+
+		res, err := analysis.Run(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		last = res
+		return res.Issues, nil
+	})
+	e.cfg.Run.Args = args
 	if err != nil {
 		return nil, err
 	}
 
-	issues, err := runner.Run(ctx, lintersToRun, lintCtx)
+	return analysis.Finish(ctx, last, issues)
+}
+
+// packagesReport counts the analyzed packages and their dependencies.
+func packagesReport(lintCtx *linter.Context) report.PackagesReport {
+	total := 0
+	gopackages.Visit(lintCtx.OriginalPackages, nil, func(*gopackages.Package) { total++ })
+
+	return report.PackagesReport{Analyzed: len(lintCtx.OriginalPackages), Total: total}
+}
+
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	fixer := processors.NewFixer(e.cfg, e.log, e.fileCache)
-	return fixer.Process(issues), nil
+	return os.WriteFile(path, append(data, '\n'), defaultFileMode)
 }
 
 func (e *Executor) setOutputToDevNull() (savedStdout, savedStderr *os.File) {
@@ -378,17 +314,62 @@ func (e *Executor) setOutputToDevNull() (savedStdout, savedStderr *os.File) {
 	return
 }
 
-func (e *Executor) setExitCodeIfIssuesFound(issues []result.Issue) {
-	if len(issues) != 0 {
-		e.exitCode = e.cfg.Run.ExitCodeIfIssuesFound
+func (e *Executor) setExitCodeIfIssuesFound(issues []result.Issue) error {
+	failing, warnOnly := 0, 0
+	hasTypecheck, hasError := false, false
+	for i := range issues {
+		if issues[i].WarnOnly {
+			warnOnly++
+			continue
+		}
+
+		isFailing, err := e.cfg.Severity.IsFailing(issues[i].Severity)
+		if err != nil {
+			return fmt.Errorf("error in severity config: %w", err)
+		}
+		if isFailing {
+			failing++
+			hasTypecheck = hasTypecheck || issues[i].FromLinter == "typecheck"
+			hasError = hasError || e.cfg.Severity.IsError(issues[i].Severity)
+		}
+	}
+
+	if warnOnly != 0 {
+		e.log.Infof("%d issues are reported by warn-only linters", warnOnly)
 	}
+	if below := len(issues) - failing - warnOnly; below != 0 {
+		e.log.Infof("%d issues are below the failure severity threshold", below)
+	}
+
+	// The panics of the linters don't stop the run: their issues are incomplete.
+	if code := e.cfg.Run.ExitCodeMap.LinterFailure; code != 0 && len(e.reportData.RunWarnings) != 0 {
+		e.log.Infof("%d linter panics were recovered", len(e.reportData.RunWarnings))
+		e.exitCode = code
+		return nil
+	}
+
+	if failing != 0 {
+		e.exitCode = e.cfg.Run.ExitCodeMap.IssuesExitCode(e.cfg.Run.ExitCodeIfIssuesFound, hasTypecheck, hasError)
+	}
+
+	return nil
 }
 
 func (e *Executor) runAndPrint(ctx context.Context, args []string) error {
+	// The options may come from the flags, without a configuration file.
+	if err := e.cfg.Output.Validate(); err != nil {
+		return fmt.Errorf("error in output config: %w", err)
+	}
+	if err := e.cfg.Severity.Validate(); err != nil {
+		return fmt.Errorf("error in severity config: %w", err)
+	}
+
 	if err := e.goenv.Discover(ctx); err != nil {
 		e.log.Warnf("Failed to discover go env: %s", err)
 	}
 
+	stderrIsTerminal := isTerminal(os.Stderr)
+
 	if !logutils.HaveDebugTag("linters_output") {
 		// Don't allow linters and loader to print anything
 		log.SetOutput(io.Discard)
@@ -398,31 +379,243 @@ func (e *Executor) runAndPrint(ctx context.Context, args []string) error {
 		}()
 	}
 
-	issues, err := e.runAnalysis(ctx, args)
+	if err := e.checkPolicy(); err != nil {
+		return err
+	}
+
+	if err := e.checkRequirements(ctx); err != nil {
+		return err
+	}
+
+	if e.cfg.Run.Interactive {
+		if err := e.prepareTriage(); err != nil {
+			return err
+		}
+	}
+
+	if e.cfg.Run.SuppressNew {
+		if err := e.prepareSuppressNew(); err != nil {
+			return err
+		}
+	}
+
+	args, err := e.prepareStdin(args)
+	if err != nil {
+		return err
+	}
+
+	args, err = e.expandReverseDeps(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	cleanup, err := e.prepareDiffFile()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if err = e.startStreaming(ctx); err != nil {
+		return err
+	}
+	defer e.stopStreaming()
+
+	e.startProgress(stderrIsTerminal)
+	defer e.stopProgress()
+
+	var issues []result.Issue
+	switch {
+	case len(e.cfg.Run.BuildTagSets) != 0:
+		issues, err = e.runBuildTagSets(ctx, args)
+	case e.cfg.Issues.ChangedOnly:
+		issues, err = e.runChangedOnly(ctx, args)
+	default:
+		issues, err = e.runAnalysis(ctx, args)
+	}
 	if err != nil {
 		return err // XXX: don't loose type
 	}
 
-	formats := strings.Split(e.cfg.Output.Format, ",")
-	for _, format := range formats {
-		out := strings.SplitN(format, ":", 2)
-		if len(out) < 2 {
-			out = append(out, "")
+	if e.cfg.Run.Interactive {
+		issues, err = e.triage(issues)
+		if err != nil {
+			return err
 		}
 
-		err := e.printReports(ctx, issues, out[1], out[0])
+		return e.setExitCodeIfIssuesFound(issues)
+	}
+
+	if e.cfg.Run.SuppressNew {
+		issues, err = e.suppressNew(issues)
 		if err != nil {
 			return err
 		}
 	}
 
-	e.setExitCodeIfIssuesFound(issues)
+	if e.cfg.Output.PostProcess != "" {
+		issues, err = postprocess.Run(ctx, e.cfg.Output.PostProcess, issues, &e.reportData, logutils.StdErr)
+		if err != nil {
+			return fmt.Errorf("can't post-process issues: %w", err)
+		}
+	}
+
+	if e.needAutoAdopt() {
+		issues, err = e.autoAdopt(issues)
+		if err != nil {
+			return err
+		}
+	}
+
+	if e.cfg.Output.ShowFixed {
+		e.reportData.Fixed = e.fixedSincePreviousRun(e.unlimitedIssues)
+	}
+
+	e.progressStage(progress.StagePrint)
+	e.stopProgress()
+
+	if err = e.printAllReports(ctx, issues); err != nil {
+		return err
+	}
+	e.finishStreaming()
+
+	e.sendToSinks(ctx, issues)
+
+	if e.cfg.Output.StatsHistory != "" {
+		if err = recordStats(ctx, e.cfg.Output.StatsHistory, issues); err != nil {
+			e.log.Warnf("Can't record stats to %s: %s", e.cfg.Output.StatsHistory, err)
+		}
+	}
+
+	if err = e.setExitCodeIfIssuesFound(issues); err != nil {
+		return err
+	}
 
 	e.fileCache.PrintStats(e.log)
 
 	return nil
 }
 
+// prepareDiffFile checks the options of the diff, and saves the diff of the standard input to a temporary file:
+// the runners of the canary config read it too.
+func (e *Executor) prepareDiffFile() (func(), error) {
+	ic := &e.cfg.Issues
+	if ic.DiffFromStdin {
+		ic.DiffFile = "-"
+	}
+
+	if ic.DiffFile == "" {
+		return func() {}, nil
+	}
+
+	if ic.Diff || ic.DiffFromRevision != "" || ic.DiffPatchFilePath != "" || ic.ChangedOnly {
+		return nil, errors.New("can't combine options --diff or --diff-file and --new, --new-from-rev, --new-from-patch or --changed-only")
+	}
+
+	if ic.DiffFile != "-" {
+		return func() {}, nil
+	}
+
+	if e.cfg.Run.Interactive {
+		return nil, errors.New("can't combine options --diff and --interactive: both read the standard input")
+	}
+
+	f, err := os.CreateTemp("", "golangci-lint-diff-*.patch")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = io.Copy(f, os.Stdin)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return nil, fmt.Errorf("can't read diff from standard input: %w", err)
+	}
+
+	ic.DiffFile = f.Name()
+	return func() { _ = os.Remove(f.Name()) }, nil
+}
+
+// prepareStdin checks the options of the standard input, and replaces the content of the file by the standard input:
+// the file is the only argument, its package is loaded with the content of the standard input.
+func (e *Executor) prepareStdin(args []string) ([]string, error) {
+	rc := &e.cfg.Run
+	if !rc.Stdin {
+		return args, nil
+	}
+
+	if !strings.HasSuffix(rc.StdinFilename, ".go") {
+		return nil, errors.New("option --stdin requires the path of a Go file in --stdin-filename")
+	}
+	if len(args) != 0 {
+		return nil, errors.New("can't combine option --stdin and arguments")
+	}
+	if e.cfg.Issues.DiffFile == "-" || e.cfg.Issues.DiffFromStdin || rc.Interactive {
+		return nil, errors.New("can't combine option --stdin and options --diff or --interactive: they read the standard input")
+	}
+
+	content, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("can't read standard input: %w", err)
+	}
+
+	if err := fsutils.SetOverlay(rc.StdinFilename, content); err != nil {
+		return nil, err
+	}
+
+	if e.cfg.Issues.NeedFix {
+		e.log.Warnf("The fixes can't be applied to the standard input: --fix is ignored")
+		e.cfg.Issues.NeedFix = false
+	}
+
+	return []string{rc.StdinFilename}, nil
+}
+
+// printAllReports prints the issues in every format of the comma-separated output format option.
+func (e *Executor) printAllReports(ctx context.Context, issues []result.Issue) error {
+	for _, out := range e.cfg.Output.OutTargets() {
+		if e.isStreamed(out.Format, out.Path) {
+			continue
+		}
+
+		err := e.printReports(ctx, issues, out.Path, out.Format)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sendToSinks sends the issues to the HTTP endpoints of the report sinks: the failures are only warnings.
+func (e *Executor) sendToSinks(ctx context.Context, issues []result.Issue) {
+	for i := range e.cfg.Report.Sinks {
+		cfg := &e.cfg.Report.Sinks[i]
+
+		headers := make(map[string]string, len(cfg.Headers))
+		for k, v := range cfg.Headers {
+			value, err := e.credentials.Expand(ctx, v)
+			if err != nil {
+				e.log.Warnf("Can't send issues to %s: header %s: %s", cfg.URL, k, err)
+				headers = nil
+				break
+			}
+			headers[k] = value
+		}
+		if headers == nil {
+			continue
+		}
+
+		if err := sink.New(&e.reportData, cfg, headers).Print(ctx, issues); err != nil {
+			e.log.Warnf("Can't send issues to %s: %s", cfg.URL, err)
+			continue
+		}
+
+		e.log.Infof("Sent %d issues to %s", len(issues), cfg.URL)
+	}
+}
+
 func (e *Executor) printReports(ctx context.Context, issues []result.Issue, path, format string) error {
 	w, shouldClose, err := e.createWriter(path)
 	if err != nil {
@@ -444,6 +637,12 @@ func (e *Executor) printReports(ctx context.Context, issues []result.Issue, path
 		return fmt.Errorf("can't print %d issues: %s", len(issues), err)
 	}
 
+	if text, ok := p.(*printers.Text); ok {
+		text.PrintFixed(e.reportData.Fixed)
+		text.PrintRemovedByCanary(e.reportData.RemovedByCanary)
+		text.PrintRunWarnings(e.reportData.RunWarnings)
+	}
+
 	if file, ok := w.(io.Closer); shouldClose && ok {
 		_ = file.Close()
 	}
@@ -465,27 +664,42 @@ func (e *Executor) createWriter(path string) (io.Writer, bool, error) {
 	return f, true, nil
 }
 
+// linterPresets returns the presets of the linters by name.
+func (e *Executor) linterPresets() map[string][]string {
+	presets := map[string][]string{}
+	for _, lc := range e.DBManager.GetAllSupportedLinterConfigs() {
+		presets[lc.Name()] = lc.InPresets
+	}
+	return presets
+}
+
 func (e *Executor) createPrinter(format string, w io.Writer) (printers.Printer, error) {
 	var p printers.Printer
 	switch format {
 	case config.OutFormatJSON:
 		p = printers.NewJSON(&e.reportData, w)
+	case config.OutFormatJSONStream:
+		p = printers.NewJSONStream(w)
 	case config.OutFormatColoredLineNumber, config.OutFormatLineNumber:
 		p = printers.NewText(e.cfg.Output.PrintIssuedLine,
 			format == config.OutFormatColoredLineNumber, e.cfg.Output.PrintLinterName,
-			e.log.Child("text_printer"), w)
+			e.log.Child("text_printer"), w).WithRuleID(e.cfg.Output.PrintRuleID).WithGroupBy(e.cfg.Output.GroupBy)
 	case config.OutFormatTab:
 		p = printers.NewTab(e.cfg.Output.PrintLinterName, e.log.Child("tab_printer"), w)
 	case config.OutFormatCheckstyle:
 		p = printers.NewCheckstyle(w)
 	case config.OutFormatCodeClimate:
-		p = printers.NewCodeClimate(w)
+		p = printers.NewCodeClimate(e.linterPresets(), w)
 	case config.OutFormatHTML:
-		p = printers.NewHTML(w)
+		p = printers.NewHTML(e.lineCache, w)
 	case config.OutFormatJunitXML:
-		p = printers.NewJunitXML(w)
+		p = printers.NewJunitXML(&e.reportData, e.cfg.Output.JUnitStrict, w)
 	case config.OutFormatGithubActions:
 		p = printers.NewGithub(w)
+	case config.OutFormatTeamCity:
+		p = printers.NewTeamCity(w)
+	case config.OutFormatSarif:
+		p = printers.NewSarif(w)
 	default:
 		return nil, fmt.Errorf("unknown output format %s", format)
 	}
@@ -495,7 +709,12 @@ func (e *Executor) createPrinter(format string, w io.Writer) (printers.Printer,
 
 // executeRun executes the 'run' CLI command, which runs the linters.
 func (e *Executor) executeRun(_ *cobra.Command, args []string) {
-	needTrackResources := e.cfg.Run.IsVerbose || e.cfg.Run.PrintResourcesUsage
+	if len(args) != 0 && e.cfg.IsRecipe(args[0]) {
+		e.log.Infof("Using recipe %s", args[0])
+		args = e.cfg.ApplyRecipe(args[0], args[1:])
+	}
+
+	needTrackResources := e.cfg.Run.IsVerbose || e.cfg.Run.PrintResourcesUsage || e.cfg.Run.ProfileDir != ""
 	trackResourcesEndCh := make(chan struct{})
 	defer func() { // XXX: this defer must be before ctx.cancel defer
 		if needTrackResources { // wait until resource tracking finished to print properly
@@ -514,21 +733,39 @@ func (e *Executor) executeRun(_ *cobra.Command, args []string) {
 	if err := e.runAndPrint(ctx, args); err != nil {
 		e.log.Errorf("Running error: %s", err)
 		if e.exitCode == exitcodes.Success {
-			if exitErr, ok := errors.Cause(err).(*exitcodes.ExitError); ok {
-				e.exitCode = exitErr.Code
-			} else {
-				e.exitCode = exitcodes.Failure
-			}
+			e.exitCode = e.failureExitCode(err)
 		}
 	}
 
 	e.setupExitCode(ctx)
 }
 
+// failureExitCode returns the exit code of the failure of the run: the code of an ExitError,
+// or the code mapped to the kind of the failure by run.exit-code-map.
+func (e *Executor) failureExitCode(err error) int {
+	if exitErr, ok := errors.Cause(err).(*exitcodes.ExitError); ok {
+		return exitErr.Code
+	}
+
+	codes := e.cfg.Run.ExitCodeMap
+
+	var linterErr *lint.LinterError
+	if codes.LinterFailure != 0 && errors.As(err, &linterErr) {
+		return codes.LinterFailure
+	}
+
+	var typecheckErr *lint.TypecheckError
+	if codes.Typecheck != 0 && errors.As(err, &typecheckErr) {
+		return codes.Typecheck
+	}
+
+	return exitcodes.Failure
+}
+
 // to be removed when deadline is finally decommissioned
 func (e *Executor) setTimeoutToDeadlineIfOnlyDeadlineIsSet() {
 	deadlineValue := e.cfg.Run.Deadline
-	if deadlineValue != 0 && e.cfg.Run.Timeout == defaultTimeout {
+	if deadlineValue != 0 && e.cfg.Run.Timeout == flagsets.DefaultTimeout {
 		e.cfg.Run.Timeout = deadlineValue
 	}
 }
@@ -569,7 +806,6 @@ func watchResources(ctx context.Context, done chan struct{}, logger logutils.Log
 	defer ticker.Stop()
 
 	logEveryRecord := os.Getenv("GL_MEM_LOG_EVERY") == "1"
-	const MB = 1024 * 1024
 
 	track := func() {
 		var m runtime.MemStats
@@ -580,7 +816,7 @@ func watchResources(ctx context.Context, done chan struct{}, logger logutils.Log
 			printMemStats(&m, logger)
 		}
 
-		rssMB := float64(m.Sys) / MB
+		rssMB := float64(m.Sys) / resources.BytesPerMB
 		if rssMB > maxRSSMB {
 			maxRSSMB = rssMB
 		}
@@ -607,8 +843,10 @@ func watchResources(ctx context.Context, done chan struct{}, logger logutils.Log
 
 	avgRSSMB := totalRSSMB / float64(iterationsCount)
 
-	logger.Infof("Memory: %d samples, avg is %.1fMB, max is %.1fMB",
-		iterationsCount, avgRSSMB, maxRSSMB)
-	logger.Infof("Execution took %s", time.Since(startedAt))
+	logutils.InfoEvent(logger, "memory", logutils.Fields{"samples": iterationsCount, "avg_mb": avgRSSMB, "max_mb": maxRSSMB},
+		"Memory: %d samples, avg is %.1fMB, max is %.1fMB", iterationsCount, avgRSSMB, maxRSSMB)
+	took := time.Since(startedAt)
+	logutils.InfoEvent(logger, "execution", logutils.Fields{"duration_ms": logutils.DurationField(took)},
+		"Execution took %s", took)
 	close(done)
 }
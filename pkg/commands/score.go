@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/report"
+)
+
+type scoreOptions struct {
+	historyPath string
+	format      string
+	minGrade    string
+	sources     []string
+}
+
+func (e *Executor) initScore() {
+	var opts scoreOptions
+
+	cmd := &cobra.Command{
+		Use:   "score [report.json|-]",
+		Short: "Grade the health of the repository from a report of the json output format",
+		Long: `Grade the health of the repository from a report generated with --out-format=json:
+the density of the lint debt (issues weighted by severity) per thousand lines of Go code,
+the density of the //nolint directives, and the trend of the counts of issues of the stats history.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			e.executeScore(args, &opts)
+		},
+	}
+
+	fs := cmd.Flags()
+	fs.StringVar(&opts.historyPath, "history", "",
+		wh(fmt.Sprintf("Stats history file for the trend (default: output.stats-history or %s)", defaultStatsHistory)))
+	fs.StringVar(&opts.format, "format", "text", wh("Output format: text|json"))
+	fs.StringVar(&opts.minGrade, "min-grade", "",
+		wh(fmt.Sprintf("Exit with code %d if the grade is worse: %s", exitcodes.IssuesFound, strings.Join(report.Grades, "|"))))
+	fs.StringSliceVar(&opts.sources, "sources", []string{"./..."}, wh("Paths of the Go files to count the lines and the nolint directives"))
+
+	e.rootCmd.AddCommand(cmd)
+}
+
+// executeScore runs the 'score' CLI command.
+func (e *Executor) executeScore(args []string, opts *scoreOptions) {
+	if opts.format != "text" && opts.format != "json" {
+		e.log.Fatalf("Unknown format %q: must be text or json", opts.format)
+	}
+
+	if opts.minGrade != "" && !report.IsGrade(opts.minGrade) {
+		e.log.Fatalf("Unknown grade %q: must be one of %s", opts.minGrade, strings.Join(report.Grades, ", "))
+	}
+
+	path := "-"
+	if len(args) == 1 {
+		path = args[0]
+	}
+
+	res, err := readJSONReport(path)
+	if err != nil {
+		e.log.Fatalf("Can't read report %s: %s", path, err)
+	}
+
+	files, err := goFiles(opts.sources)
+	if err != nil {
+		e.log.Fatalf("Can't list files: %s", err)
+	}
+
+	in := &report.ScoreInput{Issues: res.Issues, History: e.readStats(opts.historyPath)}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			e.log.Fatalf("Can't read %s: %s", file, err)
+		}
+
+		in.Lines += bytes.Count(data, []byte("\n"))
+		in.Suppressions += len(fileNolintDirectives(file))
+	}
+
+	s := report.NewScore(in)
+
+	if opts.format == "json" {
+		if err := json.NewEncoder(logutils.StdOut).Encode(s); err != nil {
+			e.log.Fatalf("Can't print score: %s", err)
+		}
+	} else {
+		printScore(s)
+	}
+
+	if opts.minGrade != "" && !report.MeetsGrade(s.Grade, opts.minGrade) {
+		e.log.Errorf("Grade %s is worse than the minimal grade %s", s.Grade, strings.ToUpper(opts.minGrade))
+		os.Exit(exitcodes.IssuesFound)
+	}
+
+	os.Exit(exitcodes.Success)
+}
+
+func printScore(s *report.Score) {
+	fmt.Fprintf(logutils.StdOut, "Grade: %s (%.0f/100)\n\n", s.Grade, s.Score)
+
+	w := tabwriter.NewWriter(logutils.StdOut, 0, 0, 2, ' ', 0)
+	for _, c := range s.Components {
+		fmt.Fprintf(w, "%s\t%.0f\t%s\n", c.Name, c.Score, c.Detail)
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const (
+	snippetFile           = "snippet.go"
+	snippetDefaultVersion = "1.18"
+)
+
+// snippet is a piece of code wrapped into a compilable file.
+type snippet struct {
+	src string
+	// offset is the count of lines added before the code of the user.
+	offset int
+	// lines is the count of lines of the code of the user.
+	lines int
+}
+
+func (e *Executor) initCheckSnippet() {
+	cmd := &cobra.Command{
+		Use:   "check-snippet [code]",
+		Short: "Run the linters on a code snippet read from the argument or the standard input",
+		Long: `Run the linters on a code snippet read from the argument or the standard input.
+The snippet can be a whole file, declarations without the package clause or statements:
+it's wrapped into a synthetic package, imports must be declared explicitly.`,
+		Run: e.executeCheckSnippet,
+	}
+	e.rootCmd.AddCommand(cmd)
+
+	cmd.SetOut(logutils.StdOut) // use custom output to properly color it in Windows terminals
+	cmd.SetErr(logutils.StdErr)
+
+	e.initRunConfiguration(cmd)
+}
+
+// executeCheckSnippet runs the 'check-snippet' CLI command.
+func (e *Executor) executeCheckSnippet(_ *cobra.Command, args []string) {
+	e.setTimeoutToDeadlineIfOnlyDeadlineIsSet()
+	ctx, cancel := context.WithTimeout(context.Background(), e.cfg.Run.Timeout)
+	defer cancel()
+
+	if err := e.checkSnippet(ctx, args); err != nil {
+		e.log.Errorf("Running error: %s", err)
+		if e.exitCode == exitcodes.Success {
+			e.exitCode = exitcodes.Failure
+		}
+	}
+
+	e.setupExitCode(ctx)
+}
+
+func (e *Executor) checkSnippet(ctx context.Context, args []string) error {
+	code, err := readSnippet(args)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(code) == "" {
+		return fmt.Errorf("empty snippet")
+	}
+
+	s := wrapSnippet(code)
+
+	dir, err := os.MkdirTemp("", "golangci-lint-snippet")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	goVersion := e.cfg.Run.Go
+	if goVersion == "" {
+		goVersion = snippetDefaultVersion
+	}
+
+	goMod := fmt.Sprintf("module snippet\n\ngo %s\n", goVersion)
+	if err = os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), defaultFileMode); err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, snippetFile), []byte(s.src), defaultFileMode); err != nil {
+		return err
+	}
+
+	// The options related to the VCS or to the files of the project make no sense for a snippet.
+	e.cfg.Issues.Diff = false
+	e.cfg.Issues.NeedFix = false
+	e.cfg.Issues.DiffFromRevision = ""
+	e.cfg.Issues.DiffPatchFilePath = ""
+	e.cfg.Issues.DiffFile = ""
+	e.cfg.Issues.Baseline = ""
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	// The packages are loaded from the synthetic module.
+	if err = os.Chdir(dir); err != nil {
+		return err
+	}
+	defer func() {
+		if errChdir := os.Chdir(wd); errChdir != nil {
+			e.log.Warnf("Can't restore working directory %s: %s", wd, errChdir)
+		}
+	}()
+
+	if err = e.goenv.Discover(ctx); err != nil {
+		e.log.Warnf("Failed to discover go env: %s", err)
+	}
+
+	issues, err := e.runSnippetAnalysis(ctx)
+	if err != nil {
+		return err
+	}
+
+	issues = s.unwrap(issues)
+
+	if err = e.printAllReports(ctx, issues); err != nil {
+		return err
+	}
+
+	e.setExitCodeIfIssuesFound(issues)
+
+	return nil
+}
+
+func (e *Executor) runSnippetAnalysis(ctx context.Context) ([]result.Issue, error) {
+	if !logutils.HaveDebugTag("linters_output") {
+		// Don't allow linters and loader to print anything
+		log.SetOutput(io.Discard)
+		savedStdout, savedStderr := e.setOutputToDevNull()
+		defer func() {
+			os.Stdout, os.Stderr = savedStdout, savedStderr
+		}()
+	}
+
+	return e.runAnalysis(ctx, []string{"./..."})
+}
+
+func readSnippet(args []string) (string, error) {
+	if len(args) != 0 && !(len(args) == 1 && args[0] == "-") {
+		return strings.Join(args, " "), nil
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("can't read snippet from stdin: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// wrapSnippet returns the smallest wrapping of the code making it a valid Go file:
+// the code as is, with a package clause, or inside a function.
+func wrapSnippet(code string) snippet {
+	lines := strings.Count(strings.TrimRight(code, "\n"), "\n") + 1
+
+	candidates := []snippet{
+		{src: code, lines: lines},
+		{src: "package snippet\n" + code, offset: 1, lines: lines},
+		{src: "package snippet\n\nfunc _() {\n" + code + "\n}\n", offset: 3, lines: lines},
+	}
+
+	for _, c := range candidates {
+		if _, err := parser.ParseFile(token.NewFileSet(), snippetFile, c.src, parser.AllErrors); err == nil {
+			return c
+		}
+	}
+
+	// Let the typecheck linter report the syntax errors of the code as is.
+	return candidates[0]
+}
+
+// unwrap maps the positions of the issues back to the lines of the snippet
+// and drops the issues about the synthetic code.
+func (s snippet) unwrap(issues []result.Issue) []result.Issue {
+	ret := issues[:0]
+	for i := range issues {
+		issue := issues[i]
+		if issue.Pos.Line <= s.offset || issue.Pos.Line > s.offset+s.lines {
+			continue
+		}
+
+		issue.Pos.Line -= s.offset
+		if issue.LineRange != nil {
+			issue.LineRange = &result.Range{From: issue.LineRange.From - s.offset, To: issue.LineRange.To - s.offset}
+		}
+
+		ret = append(ret, issue)
+	}
+
+	return ret
+}
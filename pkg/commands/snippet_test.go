@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestWrapSnippet(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		code   string
+		offset int
+		lines  int
+	}{
+		{
+			desc:  "file",
+			code:  "package main\n\nfunc main() {}\n",
+			lines: 3,
+		},
+		{
+			desc:   "declarations",
+			code:   "import \"fmt\"\n\nfunc f() { fmt.Println() }",
+			offset: 1,
+			lines:  3,
+		},
+		{
+			desc:   "statements",
+			code:   "a, b := 1, 2\n_ = a + b",
+			offset: 3,
+			lines:  2,
+		},
+		{
+			desc:  "syntax error",
+			code:  "func (",
+			lines: 1,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			s := wrapSnippet(test.code)
+			assert.Equal(t, test.offset, s.offset)
+			assert.Equal(t, test.lines, s.lines)
+			assert.Contains(t, s.src, test.code)
+		})
+	}
+}
+
+func TestSnippet_unwrap(t *testing.T) {
+	s := wrapSnippet("a, b := 1, 2\n_ = a + b")
+	require.Equal(t, 3, s.offset)
+
+	newIssue := func(line int) result.Issue {
+		return result.Issue{Text: "issue", Pos: token.Position{Filename: snippetFile, Line: line}}
+	}
+
+	rangeIssue := newIssue(4)
+	rangeIssue.LineRange = &result.Range{From: 4, To: 5}
+
+	issues := s.unwrap([]result.Issue{
+		newIssue(3), // the func line of the wrapping
+		rangeIssue,  // the first line of the snippet
+		newIssue(5), // the last line of the snippet
+		newIssue(6), // the closing brace of the wrapping
+		newIssue(0),
+	})
+
+	require.Len(t, issues, 2)
+	assert.Equal(t, 1, issues[0].Line())
+	assert.Equal(t, &result.Range{From: 1, To: 2}, issues[0].LineRange)
+	assert.Equal(t, 2, issues[1].Line())
+	assert.Nil(t, issues[1].LineRange)
+}
+
+func TestReadSnippet_args(t *testing.T) {
+	code, err := readSnippet([]string{"var", "x = 1"})
+	require.NoError(t, err)
+	assert.Equal(t, "var x = 1", code)
+}
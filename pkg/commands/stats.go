@@ -0,0 +1,202 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/vcs"
+)
+
+const defaultStatsHistory = ".golangci-stats.json"
+
+func (e *Executor) initStats() {
+	var historyPath string
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Print the history of the counts of issues of the runs",
+		Long: `Print the history of the counts of issues of the runs, recorded with 'run --stats-history'
+or with 'stats record'.`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run: func(_ *cobra.Command, _ []string) {
+			e.executeStats(e.readStats(historyPath))
+		},
+	}
+	cmd.PersistentFlags().StringVar(&historyPath, "history", "",
+		wh(fmt.Sprintf("History file (default: output.stats-history or %s)", defaultStatsHistory)))
+	e.rootCmd.AddCommand(cmd)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "record [report.json|-]",
+		Short: "Add the counts of issues of a report of the json output format to the history",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			e.executeStatsRecord(e.statsHistoryPath(historyPath), args)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "diff [from] [to]",
+		Short: "Print the changes of the counts of issues between two runs",
+		Long: `Print the changes of the counts of issues per linter, severity and package between two runs.
+The runs are the numbers printed by 'stats', negative numbers count from the last run:
+by default, the previous run is compared to the last one.`,
+		Args:              cobra.MaximumNArgs(2),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run: func(_ *cobra.Command, args []string) {
+			e.executeStatsDiff(e.readStats(historyPath), args)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:               "export",
+		Short:             "Print the history as CSV",
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run: func(_ *cobra.Command, _ []string) {
+			if err := e.readStats(historyPath).WriteCSV(logutils.StdOut); err != nil {
+				e.log.Fatalf("Can't write CSV: %s", err)
+			}
+			os.Exit(exitcodes.Success)
+		},
+	})
+}
+
+func (e *Executor) statsHistoryPath(path string) string {
+	switch {
+	case path != "":
+		return path
+	case e.cfg.Output.StatsHistory != "":
+		return e.cfg.Output.StatsHistory
+	default:
+		return defaultStatsHistory
+	}
+}
+
+func (e *Executor) readStats(path string) *report.Stats {
+	path = e.statsHistoryPath(path)
+
+	s, err := report.ReadStats(path)
+	if err != nil {
+		e.log.Fatalf("Can't read stats history %s: %s", path, err)
+	}
+
+	return s
+}
+
+// executeStats runs the 'stats' CLI command.
+func (e *Executor) executeStats(s *report.Stats) {
+	if len(s.Runs) == 0 {
+		fmt.Fprintln(logutils.StdOut, "No runs are recorded")
+		os.Exit(exitcodes.Success)
+	}
+
+	for i := range s.Runs {
+		rs := &s.Runs[i]
+
+		delta := ""
+		if i > 0 {
+			delta = fmt.Sprintf("%+d", rs.Total-s.Runs[i-1].Total)
+		}
+
+		fmt.Fprintf(logutils.StdOut, "%d\t%s\t%s\t%d\t%s\n",
+			i+1, rs.Time.Local().Format(time.RFC3339), rs.Revision, rs.Total, delta)
+	}
+
+	os.Exit(exitcodes.Success)
+}
+
+// executeStatsRecord runs the 'stats record' CLI command.
+func (e *Executor) executeStatsRecord(historyPath string, args []string) {
+	path := "-"
+	if len(args) == 1 {
+		path = args[0]
+	}
+
+	res, err := readJSONReport(path)
+	if err != nil {
+		e.log.Fatalf("Can't read report %s: %s", path, err)
+	}
+
+	if err := recordStats(context.Background(), historyPath, res.Issues); err != nil {
+		e.log.Fatalf("Can't record stats to %s: %s", historyPath, err)
+	}
+
+	os.Exit(exitcodes.Success)
+}
+
+// executeStatsDiff runs the 'stats diff' CLI command.
+func (e *Executor) executeStatsDiff(s *report.Stats, args []string) {
+	refs := []string{"-2", "-1"}
+	copy(refs, args)
+
+	var runs [2]*report.RunStats
+	for i, ref := range refs {
+		rs, err := findRunStats(s, ref)
+		if err != nil {
+			e.log.Fatalf("Invalid run %q: %s", ref, err)
+		}
+		runs[i] = rs
+	}
+
+	fmt.Fprintf(logutils.StdOut, "total\t%d -> %d (%+d)\n", runs[0].Total, runs[1].Total, runs[1].Total-runs[0].Total)
+	for _, d := range report.DiffStats(runs[0], runs[1]) {
+		fmt.Fprintf(logutils.StdOut, "%s %s\t%d -> %d (%+d)\n", d.Dimension, d.Value, d.From, d.To, d.Delta())
+	}
+
+	os.Exit(exitcodes.Success)
+}
+
+// findRunStats returns the run of the number, 1 for the first run: the negative numbers count from the last run.
+func findRunStats(s *report.Stats, ref string) (*report.RunStats, error) {
+	n, err := strconv.Atoi(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if n < 0 {
+		n += len(s.Runs) + 1
+	}
+
+	if n < 1 || n > len(s.Runs) {
+		return nil, fmt.Errorf("%d runs are recorded", len(s.Runs))
+	}
+
+	return &s.Runs[n-1], nil
+}
+
+// recordStats adds the counts of the issues to the history file, with the current git commit if any.
+func recordStats(ctx context.Context, path string, issues []result.Issue) error {
+	s, err := report.ReadStats(path)
+	if err != nil {
+		return err
+	}
+
+	s.Runs = append(s.Runs, report.NewRunStats(time.Now(), revision(ctx), issues))
+
+	return report.WriteStats(path, s)
+}
+
+func revision(ctx context.Context) string {
+	repo, err := vcs.Detect(".")
+	if err != nil {
+		return ""
+	}
+
+	rev, err := repo.Revision(ctx)
+	if err != nil {
+		return ""
+	}
+
+	return rev
+}
@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"context"
+	"io"
+
+	"github.com/golangci/golangci-lint/pkg/printers"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// streamedOutput is an output printing the issues of each linter as soon as it finishes.
+type streamedOutput struct {
+	format, path string
+
+	printer printers.Printer
+	w       io.Writer
+	close   bool
+}
+
+// streamingDisabledBy returns the option changing the issues after the run, which disables the streaming output.
+func (e *Executor) streamingDisabledBy() string {
+	switch {
+	case e.cfg.Run.Interactive:
+		return "--interactive"
+	case e.cfg.Run.SuppressNew:
+		return "--suppress-new"
+	case e.cfg.Output.PostProcess != "":
+		return "--post-process"
+	case e.cfg.Run.CanaryConfig != "":
+		return "--canary-config"
+	case e.cfg.Issues.NeedFix:
+		return "--fix"
+	case e.cfg.Issues.ChangedOnly:
+		return "--changed-only"
+	case e.cfg.Run.AutoAdopt:
+		return "--auto-adopt"
+	case len(e.cfg.Run.BuildTagSets) != 0:
+		return "run.build-tag-sets"
+	default:
+		return ""
+	}
+}
+
+// startStreaming creates the printers of the streamed outputs: the issues are printed by the runner as they're found.
+func (e *Executor) startStreaming(ctx context.Context) error {
+	var outputs []*streamedOutput
+	for _, out := range e.cfg.Output.OutTargets() {
+		if !e.cfg.Output.IsStreamed(out.Format) {
+			continue
+		}
+
+		if opt := e.streamingDisabledBy(); opt != "" {
+			e.log.Infof("The issues are printed at the end of the run: streaming isn't supported with %s", opt)
+			e.stopStreaming()
+			return nil
+		}
+
+		so := &streamedOutput{format: out.Format, path: out.Path}
+		e.streamed = append(e.streamed, so)
+
+		w, shouldClose, err := e.createWriter(so.path)
+		if err != nil {
+			e.stopStreaming()
+			return err
+		}
+		so.w, so.close = w, shouldClose
+
+		so.printer, err = e.createPrinter(so.format, w)
+		if err != nil {
+			e.stopStreaming()
+			return err
+		}
+
+		outputs = append(outputs, so)
+	}
+
+	if len(outputs) == 0 {
+		return nil
+	}
+
+	e.issuesStream = func(issues []result.Issue) {
+		for _, so := range outputs {
+			if err := so.printer.Print(ctx, issues); err != nil {
+				e.log.Warnf("Can't print %d issues: %s", len(issues), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// stopStreaming closes the files of the streamed outputs.
+func (e *Executor) stopStreaming() {
+	for _, so := range e.streamed {
+		if file, ok := so.w.(io.Closer); so.close && ok {
+			_ = file.Close()
+		}
+	}
+
+	e.streamed = nil
+	e.issuesStream = nil
+}
+
+// isStreamed checks if the output was already printed by the streaming.
+func (e *Executor) isStreamed(format, path string) bool {
+	for _, so := range e.streamed {
+		if so.format == format && so.path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// finishStreaming prints the end of the streamed text outputs.
+func (e *Executor) finishStreaming() {
+	for _, so := range e.streamed {
+		if text, ok := so.printer.(*printers.Text); ok {
+			text.PrintFixed(e.reportData.Fixed)
+			text.PrintRunWarnings(e.reportData.RunWarnings)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/suppress"
+)
+
+// prepareSuppressNew checks that the directives of the issues can be inserted in their files.
+func (e *Executor) prepareSuppressNew() error {
+	switch {
+	case e.cfg.Issues.NeedFix || e.cfg.Issues.UnsafeFix:
+		return errors.New("can't combine options --suppress-new and --fix: the fixes change the lines of the issues")
+	case e.cfg.Run.Interactive:
+		return errors.New("can't combine options --suppress-new and --interactive")
+	case e.cfg.Run.Stdin:
+		return errors.New("can't combine options --suppress-new and --stdin: the directives can't be inserted in the standard input")
+	case strings.ContainsAny(e.cfg.Run.SuppressReason, "\r\n"):
+		return errors.New("option --suppress-reason can't contain a line break")
+	}
+
+	// The source lines detect the files changed since the analysis.
+	e.cfg.Output.PrintIssuedLine = true
+
+	return nil
+}
+
+// suppressNew inserts the directives of the issues, it returns the issues which can't be suppressed.
+func (e *Executor) suppressNew(issues []result.Issue) ([]result.Issue, error) {
+	sum, err := suppress.New(e.cfg.Run.SuppressReason).Run(issues)
+	if err != nil {
+		return nil, fmt.Errorf("can't suppress issues: %w", err)
+	}
+
+	e.log.Infof("Suppressed %d issues in %d files, %d remaining", sum.Suppressed, sum.Files, len(sum.Remaining))
+	if len(sum.Remaining) != 0 {
+		e.log.Warnf("%d issues can't be suppressed: their lines changed since the analysis, "+
+			"they are type errors, or they are inside a multi-line string or comment", len(sum.Remaining))
+	}
+
+	return sum.Remaining, nil
+}
@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/codeowners"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/suppressions"
+)
+
+func (e *Executor) initSuppress() {
+	cmd := &cobra.Command{
+		Use:   "suppress",
+		Short: "Manage the suppressions of issues of the store of issues.suppressions",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 0 {
+				e.log.Fatalf("Usage: golangci-lint suppress")
+			}
+			if err := cmd.Help(); err != nil {
+				e.log.Fatalf("Can't run help: %s", err)
+			}
+		},
+	}
+	e.rootCmd.AddCommand(cmd)
+
+	// The config is read before the commands: the flag documents it.
+	cmd.PersistentFlags().StringVarP(&e.cfg.Run.Config, "config", "c", "", wh("Read config from file path `PATH`"))
+
+	var reason, author string
+	addCmd := &cobra.Command{
+		Use:   "add <expr> [report.json|-]",
+		Short: "Suppress the issues of a report matching a query",
+		Long: `Suppress the issues of a report generated with --out-format=json matching a query of 'golangci-lint report query',
+e.g. 'linter=gosec path=internal/legacy/**'. "-" (default) reads the report from the standard input.
+
+The suppressed issues are hidden by the next runs: they're matched by fingerprint
+(the linter, the file, the text and the source line of the issue), not by line number.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			e.executeSuppressAdd(args, reason, author)
+		},
+	}
+	addCmd.Flags().StringVar(&reason, "reason", "", wh("Reason of the suppressions (required)"))
+	addCmd.Flags().StringVar(&author, "author", currentUser(), wh("Author of the suppressions"))
+	cmd.AddCommand(addCmd)
+
+	removeCmd := &cobra.Command{
+		Use:   "remove <fingerprint>...",
+		Short: "Remove suppressions: the issues are reported again",
+		Run:   e.executeSuppressRemove,
+	}
+	cmd.AddCommand(removeCmd)
+
+	var format string
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the suppressions",
+		Run: func(cmd *cobra.Command, args []string) {
+			e.executeSuppressList(args, format)
+		},
+	}
+	listCmd.Flags().StringVar(&format, "format", "text", wh("Output format: text|json"))
+	cmd.AddCommand(listCmd)
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// executeSuppressAdd runs the 'suppress add' CLI command.
+func (e *Executor) executeSuppressAdd(args []string, reason, author string) {
+	if len(args) == 0 || len(args) > 2 {
+		e.log.Fatalf("Usage: golangci-lint suppress add <expr> [report.json|-] --reason REASON")
+	}
+	if reason == "" {
+		e.log.Fatalf("The reason of the suppressions is required: use --reason")
+	}
+
+	q, err := report.ParseQuery(args[0])
+	if err != nil {
+		e.log.Fatalf("Invalid query: %s", err)
+	}
+	if q.Grouped() || q.Counted() {
+		e.log.Fatalf("Invalid query: the stages aren't supported, only the filters")
+	}
+
+	path := "-"
+	if len(args) == 2 {
+		path = args[1]
+	}
+
+	res, err := readJSONReport(path)
+	if err != nil {
+		e.log.Fatalf("Can't read report %s: %s", path, err)
+	}
+
+	owners, err := codeowners.Find(".")
+	if err != nil {
+		e.log.Warnf("Can't read CODEOWNERS: %s", err)
+	}
+
+	issues := q.Run(res.Issues, owners.Of).Issues
+
+	ctx := context.Background()
+	store := e.suppressionStore(ctx)
+
+	list := make([]suppressions.Suppression, 0, len(issues))
+	for i := range issues {
+		issue := e.unprefixedIssue(issues[i])
+		list = append(list, suppressions.New(&issue, e.issueLine(&issue), reason, author))
+	}
+
+	if len(list) != 0 {
+		if err := store.Add(ctx, list); err != nil {
+			e.log.Fatalf("Can't add the suppressions to %s: %s", store, err)
+		}
+	}
+
+	fmt.Fprintf(logutils.StdOut, "Suppressed %d issues in %s\n", len(list), store)
+	os.Exit(exitcodes.Success)
+}
+
+// unprefixedIssue returns the issue of a report without the output.path-prefix of its path:
+// the suppressions are matched before the prefix is added.
+func (e *Executor) unprefixedIssue(issue result.Issue) result.Issue {
+	if e.cfg.Output.PathPrefix == "" {
+		return issue
+	}
+
+	issue.Pos.Filename = strings.TrimPrefix(filepath.ToSlash(issue.Pos.Filename), path.Clean(e.cfg.Output.PathPrefix)+"/")
+	return issue
+}
+
+// issueLine returns the source line of the issue of a report: the first source line of the report,
+// or the line of the file if the report has no source lines.
+func (e *Executor) issueLine(issue *result.Issue) string {
+	if len(issue.SourceLines) != 0 {
+		return issue.SourceLines[0]
+	}
+
+	line, err := e.lineCache.GetLine(issue.FilePath(), issue.Line())
+	if err != nil {
+		e.log.Warnf("Can't read the source line of the issue %s:%d: %s", issue.FilePath(), issue.Line(), err)
+	}
+	return line
+}
+
+// executeSuppressRemove runs the 'suppress remove' CLI command.
+func (e *Executor) executeSuppressRemove(_ *cobra.Command, args []string) {
+	if len(args) == 0 {
+		e.log.Fatalf("Usage: golangci-lint suppress remove <fingerprint>...")
+	}
+
+	ctx := context.Background()
+	store := e.suppressionStore(ctx)
+
+	removed, err := store.Remove(ctx, args)
+	if err != nil {
+		e.log.Fatalf("Can't remove the suppressions from %s: %s", store, err)
+	}
+
+	done := map[string]bool{}
+	for _, fp := range removed {
+		done[fp] = true
+	}
+	for _, fp := range args {
+		if !done[fp] {
+			e.log.Warnf("No suppression %s in %s", fp, store)
+		}
+	}
+
+	fmt.Fprintf(logutils.StdOut, "Removed %d suppressions from %s\n", len(removed), store)
+	os.Exit(exitcodes.Success)
+}
+
+// executeSuppressList runs the 'suppress list' CLI command.
+func (e *Executor) executeSuppressList(args []string, format string) {
+	if len(args) != 0 {
+		e.log.Fatalf("Usage: golangci-lint suppress list")
+	}
+	if format != "text" && format != "json" {
+		e.log.Fatalf("Unknown format %q: must be text or json", format)
+	}
+
+	ctx := context.Background()
+	store := e.suppressionStore(ctx)
+
+	list, err := store.List(ctx)
+	if err != nil {
+		e.log.Fatalf("Can't list the suppressions of %s: %s", store, err)
+	}
+
+	if format == "json" {
+		if list == nil {
+			list = []suppressions.Suppression{}
+		}
+		if err := json.NewEncoder(logutils.StdOut).Encode(list); err != nil {
+			e.log.Fatalf("Can't print suppressions: %s", err)
+		}
+		os.Exit(exitcodes.Success)
+	}
+
+	for _, s := range list {
+		fmt.Fprintf(logutils.StdOut, "%s %s: %s (%s)\n", s.Fingerprint, s.Path, s.Text, s.Linter)
+		fmt.Fprintf(logutils.StdOut, "  %s [%s, %s]\n", s.Reason, s.Author, s.Created.Format(time.RFC3339))
+	}
+	os.Exit(exitcodes.Success)
+}
+
+// suppressionStore returns the store of issues.suppressions, it exits if none is configured.
+func (e *Executor) suppressionStore(ctx context.Context) suppressions.Store {
+	store, err := suppressions.NewStore(ctx, &e.cfg.Issues.Suppressions, e.credentials)
+	if err != nil {
+		e.log.Fatalf("Can't open the suppressions store: %s", err)
+	}
+	if store == nil {
+		e.log.Fatalf("No suppressions store: set issues.suppressions.file or issues.suppressions.url in the config")
+	}
+	return store
+}
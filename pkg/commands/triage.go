@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/triage"
+)
+
+// prepareTriage checks that the issues can be triaged interactively.
+func (e *Executor) prepareTriage() error {
+	if !isInteractive() {
+		return errors.New("option --interactive requires a terminal")
+	}
+
+	if e.cfg.Issues.NeedFix || e.cfg.Issues.UnsafeFix {
+		return errors.New("can't combine option --interactive and --fix: the fixes are applied one by one")
+	}
+
+	// The source lines detect the files changed since the analysis.
+	e.cfg.Output.PrintIssuedLine = true
+
+	return nil
+}
+
+// triage browses the issues in the terminal, it returns the issues neither fixed nor suppressed.
+func (e *Executor) triage(issues []result.Issue) ([]result.Issue, error) {
+	sum, err := triage.NewSession(issues, os.Stdin, logutils.StdOut).Run()
+	if err != nil {
+		return nil, fmt.Errorf("can't triage issues: %w", err)
+	}
+
+	e.log.Infof("Triaged issues: %d fixed, %d suppressed, %d remaining", sum.Fixed, sum.Suppressed, len(sum.Remaining))
+
+	return sum.Remaining, nil
+}
@@ -9,12 +9,15 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
 )
 
 type jsonVersion struct {
 	Version string `json:"version"`
 	Commit  string `json:"commit"`
 	Date    string `json:"date"`
+	// Linters are the bundled modules implementing the linters, by linter name.
+	Linters map[string]*linter.Upstream `json:"linters,omitempty"`
 }
 
 func (e *Executor) initVersionConfiguration(cmd *cobra.Command) {
@@ -42,6 +45,12 @@ func (e *Executor) initVersion() {
 					Version: e.version,
 					Commit:  e.commit,
 					Date:    e.date,
+					Linters: map[string]*linter.Upstream{},
+				}
+				for _, lc := range e.DBManager.GetAllSupportedLinterConfigs() {
+					if upstream := lc.Upstream(); upstream != nil {
+						ver.Linters[lc.Name()] = upstream
+					}
 				}
 				data, err := json.Marshal(&ver)
 				if err != nil {
@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+// Cache encapsulates the config options of the analysis cache.
+type Cache struct {
+	Remote RemoteCache
+}
+
+// RemoteCache describes a shared cache over HTTP used in addition to the local cache.
+type RemoteCache struct {
+	URL      string `mapstructure:"url"`
+	ReadOnly bool   `mapstructure:"read-only"`
+	Timeout  time.Duration
+	// Headers added to every request, environment variables and credentials are expanded in values.
+	Headers map[string]string
+}
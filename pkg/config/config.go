@@ -11,15 +11,31 @@ import (
 // Config encapsulates the config data specified in the golangci yaml config file.
 type Config struct {
 	cfgDir string // The directory containing the golangci config file.
-	Run    Run
+
+	// Extends is the parent config, overridden by this config: a URL,
+	// a git repository `[host/]org/repo[/path]@ref` or a file path relative to this config.
+	Extends string
+	// ExtendsChecksum pins the checksum of the parent config: `sha256:<hex>`.
+	ExtendsChecksum string `mapstructure:"extends-checksum"`
+
+	Run Run
 
 	Output Output
 
 	LintersSettings LintersSettings `mapstructure:"linters-settings"`
 	Linters         Linters
+	Presets         Presets
 	Issues          Issues
 	Severity        Severity
 	Version         Version
+	Recipes         map[string]Recipe
+	Cache           Cache
+	Credentials     map[string]Credential
+	Report          Report
+	Overrides       []Override
+	Policy          Policy
+	Typecheck       Typecheck
+	Service         Service
 
 	InternalCmdTest bool `mapstructure:"internal-cmd-test"` // Option is used only for testing golangci-lint command, don't use it
 	InternalTest    bool // Option is used only for testing golangci-lint code, don't use it
@@ -55,16 +71,23 @@ func IsGreaterThanOrEqualGo118(v string) bool {
 }
 
 func DetectGoVersion() string {
+	v, _ := DetectGoVersionSource()
+	return v
+}
+
+// DetectGoVersionSource is DetectGoVersion returning the source of the version too:
+// `go.mod`, `env` for the GOVERSION environment variable, or empty for the default version.
+func DetectGoVersionSource() (version, source string) {
 	file, _ := gomoddirectives.GetModuleFile()
 
 	if file != nil && file.Go != nil && file.Go.Version != "" {
-		return file.Go.Version
+		return file.Go.Version, "go.mod"
 	}
 
 	v := os.Getenv("GOVERSION")
 	if v != "" {
-		return v
+		return v, "env"
 	}
 
-	return "1.17"
+	return "1.17", ""
 }
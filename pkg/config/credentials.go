@@ -0,0 +1,46 @@
+package config
+
+import "errors"
+
+// Credential is a secret of the integrations, resolved when it's used: exactly one source must be set.
+// It's referenced as `${credentials.NAME}` in the values supporting it.
+type Credential struct {
+	// Env is the name of the environment variable holding the secret.
+	Env string `mapstructure:"env"`
+	// File is the path of the file holding the secret, the trailing whitespaces are trimmed.
+	File string `mapstructure:"file"`
+	// Keychain is the item of the OS keychain holding the secret.
+	Keychain KeychainItem `mapstructure:"keychain"`
+	// OIDCAudience is the audience of the OIDC token requested to the CI.
+	OIDCAudience string `mapstructure:"oidc-audience"`
+	// OIDCExchangeURL is the endpoint exchanging the OIDC token for an access token (RFC 8693), optional.
+	OIDCExchangeURL string `mapstructure:"oidc-exchange-url"`
+}
+
+// KeychainItem is a generic password of the macOS keychain or of the Secret Service on Linux.
+type KeychainItem struct {
+	Service string `mapstructure:"service"`
+	Account string `mapstructure:"account"`
+}
+
+func (c Credential) Validate() error {
+	count := 0
+	for _, set := range []bool{c.Env != "", c.File != "", c.Keychain.Service != "", c.OIDCAudience != ""} {
+		if set {
+			count++
+		}
+	}
+
+	switch {
+	case count == 0:
+		return errors.New("no source: one of env, file, keychain or oidc-audience must be set")
+	case count > 1:
+		return errors.New("only one of env, file, keychain or oidc-audience can be set")
+	case c.Keychain.Account != "" && c.Keychain.Service == "":
+		return errors.New("keychain service must be set")
+	case c.OIDCExchangeURL != "" && c.OIDCAudience == "":
+		return errors.New("oidc-exchange-url requires oidc-audience")
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,213 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SettingDeprecation is a deprecated option of the settings of the linters, relative to `linters-settings`:
+// its value is converted to the replacement option if Convert is set, and if the replacement isn't set.
+type SettingDeprecation struct {
+	Option      string
+	Replacement string
+	Message     string
+
+	// Convert returns the value of the replacement option, false to ignore the value.
+	Convert func(value interface{}) (interface{}, bool)
+	// Overrides keeps the priority of the deprecated option over its replacement, as before its deprecation.
+	Overrides bool
+	// Entries is set for a deprecated map whose entries are next to the options of the linter, e.g. the aliases of importas:
+	// the keys which aren't options are deprecated, Convert receives their map.
+	Entries bool
+}
+
+func (d SettingDeprecation) String() string {
+	msg := fmt.Sprintf("The option linters-settings.%s is deprecated", d.Option)
+	if d.Entries {
+		msg = fmt.Sprintf("The map of linters-settings.%s is deprecated", d.Option)
+	}
+	if d.Replacement != "" {
+		msg += fmt.Sprintf(": use %s", d.Replacement)
+		if d.Convert != nil {
+			msg += ", its value is converted"
+		}
+	}
+	if d.Message != "" {
+		msg += ". " + d.Message
+	}
+	return msg
+}
+
+func sameValue(value interface{}) (interface{}, bool) {
+	return value, true
+}
+
+// entries returns the entries of the deprecated map of the value: the keys which aren't options of the linter.
+func (d SettingDeprecation) entries(value interface{}) map[string]interface{} {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	options := NewSchema().Properties["linters-settings"]
+	for _, key := range strings.Split(d.Option, ".") {
+		if options = options.Properties[key]; options == nil {
+			return nil
+		}
+	}
+
+	entries := map[string]interface{}{}
+	for key, v := range m {
+		if _, ok := options.Properties[strings.ToLower(key)]; !ok {
+			entries[key] = v
+		}
+	}
+	return entries
+}
+
+// importasAliases converts the map of the packages by alias of importas to the alias entries.
+func importasAliases(value interface{}) (interface{}, bool) {
+	m, _ := value.(map[string]interface{})
+
+	aliases := make([]string, 0, len(m))
+	for alias := range m {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	var entries []interface{}
+	for _, alias := range aliases {
+		if pkg, ok := m[alias].(string); ok {
+			entries = append(entries, map[string]interface{}{"pkg": pkg, "alias": alias})
+		}
+	}
+	return entries, len(entries) != 0
+}
+
+// goVersionMessage is the message of the go versions of the linters:
+// run.go is the version of all the linters, the version of one linter can't be moved to it without changing the others.
+const goVersionMessage = "The version applies to all the linters, it must be set by hand"
+
+var settingDeprecations = []SettingDeprecation{
+	{Option: "errcheck.exclude", Replacement: "linters-settings.errcheck.exclude-functions",
+		Message: "The functions of the file must be moved to the list"},
+	{Option: "gci.local-prefixes", Replacement: "linters-settings.gci.sections",
+		Convert: func(value interface{}) (interface{}, bool) {
+			prefix, ok := value.(string)
+			if !ok || prefix == "" {
+				return nil, false
+			}
+			return []interface{}{"standard", "default", fmt.Sprintf("prefix(%s)", prefix)}, true
+		},
+		Overrides: true},
+	{Option: "godot.check-all", Replacement: "linters-settings.godot.scope",
+		Convert: func(value interface{}) (interface{}, bool) {
+			if checkAll, ok := value.(bool); !ok || !checkAll {
+				return nil, false
+			}
+			return "all", true
+		}},
+	{Option: "gofumpt.lang-version", Replacement: "run.go", Message: goVersionMessage},
+	{Option: "importas", Replacement: "linters-settings.importas.alias", Entries: true, Convert: importasAliases,
+		Message: "The packages by alias are converted to alias entries"},
+	{Option: "gomnd.settings.mnd.checks", Replacement: "linters-settings.gomnd.checks", Convert: sameValue},
+	{Option: "gomnd.settings.mnd.ignored-numbers", Replacement: "linters-settings.gomnd.ignored-numbers", Convert: sameValue},
+	{Option: "gomnd.settings.mnd.ignored-files", Replacement: "linters-settings.gomnd.ignored-files", Convert: sameValue},
+	{Option: "gomnd.settings.mnd.ignored-functions", Replacement: "linters-settings.gomnd.ignored-functions", Convert: sameValue},
+	{Option: "gosimple.go", Replacement: "run.go", Message: goVersionMessage},
+	{Option: "staticcheck.go", Replacement: "run.go", Message: goVersionMessage},
+	{Option: "stylecheck.go", Replacement: "run.go", Message: goVersionMessage},
+}
+
+func findSettingDeprecation(option string) *SettingDeprecation {
+	for i := range settingDeprecations {
+		d := &settingDeprecations[i]
+		if d.Option == option || d.Entries && strings.HasPrefix(option, d.Option+".") {
+			return d
+		}
+	}
+	return nil
+}
+
+// convertDeprecatedSettings converts the deprecated options of the settings of the linters:
+// it returns the used deprecated options, in the order of the table.
+func convertDeprecatedSettings(get func(key string) (interface{}, bool), set func(key string, value interface{})) []SettingDeprecation {
+	var used []SettingDeprecation
+	for _, d := range settingDeprecations {
+		value, ok := get("linters-settings." + d.Option)
+		if ok && d.Entries {
+			value = d.entries(value)
+			ok = len(value.(map[string]interface{})) != 0
+		}
+		if !ok {
+			continue
+		}
+		used = append(used, d)
+
+		if d.Convert == nil {
+			continue
+		}
+		if _, isSet := get(d.Replacement); isSet && !d.Overrides {
+			continue // the replacement has priority.
+		}
+		if converted, ok := d.Convert(value); ok {
+			set(d.Replacement, converted)
+		}
+	}
+
+	return used
+}
+
+// UnknownLintersSettings returns the sorted keys of the settings of the linters which aren't options,
+// and which aren't deprecated options: they would be silently ignored.
+func (s *Schema) UnknownLintersSettings(settings map[string]interface{}) []string {
+	var unknown []string
+	s.Properties["linters-settings"].collectUnknownKeys(settings, "", &unknown)
+	sort.Strings(unknown)
+	return unknown
+}
+
+func (s *Schema) collectUnknownKeys(value interface{}, path string, unknown *[]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			key = strings.ToLower(key)
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+
+			if prop, ok := s.Properties[key]; ok {
+				prop.collectUnknownKeys(item, keyPath, unknown)
+				continue
+			}
+
+			switch additional := s.AdditionalProperties.(type) {
+			case *Schema:
+				additional.collectUnknownKeys(item, keyPath, unknown)
+			case bool:
+				if !additional && !isDeprecatedPrefix(keyPath) {
+					*unknown = append(*unknown, "linters-settings."+keyPath)
+				}
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for _, item := range v {
+				s.Items.collectUnknownKeys(item, path, unknown)
+			}
+		}
+	}
+}
+
+// isDeprecatedPrefix checks if the option is a deprecated option or one of its parents.
+func isDeprecatedPrefix(option string) bool {
+	for _, d := range settingDeprecations {
+		if d.Option == option || strings.HasPrefix(d.Option, option+".") ||
+			d.Entries && strings.HasPrefix(option, d.Option+".") {
+			return true
+		}
+	}
+	return false
+}
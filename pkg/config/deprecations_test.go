@@ -0,0 +1,125 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertDeprecatedSettings(t *testing.T) {
+	values := map[string]interface{}{
+		"linters-settings.gci.local-prefixes":        "github.com/org/project",
+		"linters-settings.godot.check-all":           true,
+		"linters-settings.gomnd.settings.mnd.checks": []interface{}{"argument"},
+		"linters-settings.gomnd.checks":              []interface{}{"case"},
+		"linters-settings.staticcheck.go":            "1.15",
+	}
+
+	get := func(key string) (interface{}, bool) {
+		v, ok := values[key]
+		return v, ok
+	}
+	set := func(key string, value interface{}) {
+		values[key] = value
+	}
+
+	used := convertDeprecatedSettings(get, set)
+
+	var options []string
+	for _, d := range used {
+		options = append(options, d.Option)
+	}
+	assert.Equal(t, []string{"gci.local-prefixes", "godot.check-all", "gomnd.settings.mnd.checks", "staticcheck.go"}, options)
+
+	assert.Equal(t, []interface{}{"standard", "default", "prefix(github.com/org/project)"}, values["linters-settings.gci.sections"])
+	assert.Equal(t, "all", values["linters-settings.godot.scope"])
+	assert.Equal(t, []interface{}{"case"}, values["linters-settings.gomnd.checks"], "the replacement has priority")
+	assert.NotContains(t, values, "run.go")
+
+	assert.Equal(t, "The option linters-settings.godot.check-all is deprecated: use linters-settings.godot.scope, its value is converted",
+		used[1].String())
+	assert.Equal(t, "The option linters-settings.staticcheck.go is deprecated: use run.go. "+
+		"The version applies to all the linters, it must be set by hand", used[3].String())
+}
+
+func TestConvertDeprecatedSettings_overrides(t *testing.T) {
+	values := map[string]interface{}{
+		"linters-settings.gci.local-prefixes": "github.com/org/project",
+		"linters-settings.gci.sections":       []interface{}{"standard"},
+	}
+
+	get := func(key string) (interface{}, bool) {
+		v, ok := values[key]
+		return v, ok
+	}
+	set := func(key string, value interface{}) {
+		values[key] = value
+	}
+
+	convertDeprecatedSettings(get, set)
+
+	assert.Equal(t, []interface{}{"standard", "default", "prefix(github.com/org/project)"}, values["linters-settings.gci.sections"],
+		"local-prefixes has priority over sections")
+}
+
+func TestConvertDeprecatedSettings_entries(t *testing.T) {
+	values := map[string]interface{}{
+		"linters-settings.importas": map[string]interface{}{"no-unaliased": true, "std_os": "os", "fff": "fmt"},
+	}
+
+	get := func(key string) (interface{}, bool) {
+		v, ok := values[key]
+		return v, ok
+	}
+	set := func(key string, value interface{}) {
+		values[key] = value
+	}
+
+	used := convertDeprecatedSettings(get, set)
+	require.Len(t, used, 1)
+	assert.Equal(t, "The map of linters-settings.importas is deprecated: use linters-settings.importas.alias, its value is converted. "+
+		"The packages by alias are converted to alias entries", used[0].String())
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"pkg": "fmt", "alias": "fff"},
+		map[string]interface{}{"pkg": "os", "alias": "std_os"},
+	}, values["linters-settings.importas.alias"])
+
+	values = map[string]interface{}{
+		"linters-settings.importas": map[string]interface{}{"no-unaliased": true},
+	}
+	assert.Empty(t, convertDeprecatedSettings(get, set), "only options")
+}
+
+func TestSchema_UnknownLintersSettings(t *testing.T) {
+	unknown := NewSchema().UnknownLintersSettings(map[string]interface{}{
+		"errcheck": map[string]interface{}{"check-blank": true, "ignre": "fmt:.*"},
+		"gomnd":    map[string]interface{}{"settings": map[string]interface{}{"mnd": map[string]interface{}{"checks": "case"}}},
+		"gocritic": map[string]interface{}{"settings": map[string]interface{}{"captLocal": map[string]interface{}{"paramsOnly": true}}},
+		"revive":   map[string]interface{}{"rules": []interface{}{map[string]interface{}{"name": "exported", "argument": 1}}},
+		"unknown":  map[string]interface{}{"key": 1},
+		"importas": map[string]interface{}{"std_os": "os"},
+	})
+
+	assert.Equal(t, []string{
+		"linters-settings.errcheck.ignre",
+		"linters-settings.revive.rules.argument",
+		"linters-settings.unknown",
+	}, unknown)
+}
+
+func TestSchema_UnknownLintersSettings_reference(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", ".golangci.reference.yml"))
+	require.NoError(t, err)
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(strings.NewReader(string(data))))
+
+	assert.Empty(t, NewSchema().UnknownLintersSettings(v.GetStringMap("linters-settings")))
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Value is a leaf of the configuration: structs are expanded, maps and slices are not.
+type Value struct {
+	// Key is the dotted path of the value, as named in the config file.
+	Key   string
+	Value interface{}
+	// Addr is the address of the field, it allows to match the flags bound to the config.
+	Addr uintptr
+}
+
+// Values returns the leaves of the configuration ordered as the fields of the structs.
+func (c *Config) Values() []Value {
+	var values []Value
+	collectValues(reflect.ValueOf(c).Elem(), "", &values)
+	return values
+}
+
+func collectValues(v reflect.Value, prefix string, values *[]Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+
+		name, opts := parseMapstructureTag(f)
+		if name == "-" {
+			continue
+		}
+
+		fv := v.Field(i)
+		if opts == "squash" {
+			collectValues(fv, prefix, values)
+			continue
+		}
+
+		key := joinPath(prefix, name)
+		if fv.Kind() == reflect.Struct {
+			collectValues(fv, key, values)
+			continue
+		}
+
+		*values = append(*values, Value{Key: key, Value: exportValue(fv), Addr: fv.Addr().Pointer()})
+	}
+}
+
+// exportValue converts the value to its config file representation.
+func exportValue(v reflect.Value) interface{} {
+	if d, ok := v.Interface().(time.Duration); ok {
+		return d.String()
+	}
+
+	if v.Kind() == reflect.Slice && v.IsNil() {
+		return reflect.MakeSlice(v.Type(), 0, 0).Interface()
+	}
+
+	return v.Interface()
+}
+
+// NestValues converts the dotted keys to nested maps.
+func NestValues(values []Value) map[string]interface{} {
+	root := map[string]interface{}{}
+	for _, v := range values {
+		parts := strings.Split(v.Key, ".")
+
+		m := root
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := m[part].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				m[part] = child
+			}
+			m = child
+		}
+
+		m[parts[len(parts)-1]] = v.Value
+	}
+
+	return root
+}
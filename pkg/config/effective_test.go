@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValues(t *testing.T) {
+	cfg := NewDefault()
+	cfg.Run.Timeout = time.Minute
+	cfg.Linters.Enable = []string{"misspell"}
+	cfg.Severity.Rules = []SeverityRule{{Severity: "info"}}
+
+	values := map[string]Value{}
+	for _, v := range cfg.Values() {
+		values[v.Key] = v
+	}
+
+	assert.Equal(t, "1m0s", values["run.timeout"].Value)
+	assert.Equal(t, []string{"misspell"}, values["linters.enable"].Value)
+	assert.Equal(t, []string{}, values["linters.disable"].Value)
+	assert.Equal(t, 120, values["linters-settings.lll.line-length"].Value)
+	assert.Len(t, values["severity.rules"].Value, 1)
+
+	require.Contains(t, values, "run.concurrency")
+	assert.NotZero(t, values["run.concurrency"].Addr)
+}
+
+func TestNestValues(t *testing.T) {
+	values := []Value{
+		{Key: "run.timeout", Value: "1m0s"},
+		{Key: "run.tests", Value: true},
+		{Key: "linters.enable", Value: []string{"misspell"}},
+	}
+
+	expected := map[string]interface{}{
+		"run": map[string]interface{}{
+			"timeout": "1m0s",
+			"tests":   true,
+		},
+		"linters": map[string]interface{}{
+			"enable": []string{"misspell"},
+		},
+	}
+
+	assert.Equal(t, expected, NestValues(values))
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// The kinds of the declarations and the expressions of the scopes.
+const (
+	ScopeKindFunc  = "func"
+	ScopeKindVar   = "var"
+	ScopeKindConst = "const"
+	ScopeKindType  = "type"
+	ScopeKindCall  = "call"
+)
+
+// ExcludeScope excludes the issues inside the declarations or the calls matching its scope, e.g.
+//
+//	func (*suite) Test*
+//	var _ = Describe
+//	call Describe
+type ExcludeScope struct {
+	Linter string `mapstructure:"linter"`
+	Path   string `mapstructure:"path"`
+	Scope  string `mapstructure:"scope"`
+}
+
+func (s ExcludeScope) Validate() error {
+	if err := validateOptionalRegex(s.Path); err != nil {
+		return fmt.Errorf("invalid path regex: %v", err)
+	}
+	if _, err := ParseScopePattern(s.Scope); err != nil {
+		return fmt.Errorf("invalid scope %q: %v", s.Scope, err)
+	}
+	return nil
+}
+
+// ScopePattern is a parsed scope: the names are globs (`*` and `?`).
+type ScopePattern struct {
+	Kind string
+	// Name is the name of the declaration, or the function of the call.
+	Name string
+	// Receiver is the type of the receiver of the methods, empty for the functions: without `*`,
+	// it matches the pointer receivers too.
+	Receiver string
+	// Call is the function called by the value of the variables, if set.
+	Call string
+}
+
+var scopeFuncRe = regexp.MustCompile(`^func\s*(?:\(\s*([^)]*?)\s*\))?\s*(\S+)$`)
+
+// ParseScopePattern parses a scope:
+//
+//	func <name>               the functions
+//	func (<receiver>) <name>  the methods, the name of the receiver is optional: (*suite) or (s *suite)
+//	var <name> [= <call>]     the variables, the value of the variable calls the function
+//	const <name>              the constants
+//	type <name>               the types
+//	call <function>           the calls of the function, the package is optional: Describe or ginkgo.Describe
+func ParseScopePattern(scope string) (ScopePattern, error) {
+	scope = strings.TrimSpace(scope)
+	kind, rest, _ := strings.Cut(scope, " ")
+	rest = strings.TrimSpace(rest)
+
+	var p ScopePattern
+	switch {
+	case kind == ScopeKindFunc || strings.HasPrefix(scope, ScopeKindFunc+"("):
+		m := scopeFuncRe.FindStringSubmatch(scope)
+		if m == nil {
+			return p, errors.New("the functions should be matched as func <name> or func (<receiver>) <name>")
+		}
+		p = ScopePattern{Kind: ScopeKindFunc, Name: m[2]}
+		if strings.Contains(scope, "(") {
+			fields := strings.Fields(m[1])
+			if len(fields) == 0 || len(fields) > 2 {
+				return p, errors.New("the receiver should be (<type>) or (<name> <type>)")
+			}
+			p.Receiver = fields[len(fields)-1]
+			if len(fields) == 2 && strings.HasPrefix(fields[0], "*") && !strings.HasPrefix(p.Receiver, "*") {
+				p.Receiver = "*" + p.Receiver // (*_ suite) is read as (_ *suite)
+			}
+		}
+
+	case kind == ScopeKindVar:
+		name, call, hasCall := strings.Cut(rest, "=")
+		p = ScopePattern{Kind: ScopeKindVar, Name: strings.TrimSpace(name), Call: strings.TrimSpace(call)}
+		if hasCall && p.Call == "" {
+			return p, errors.New("the called function should be set after =")
+		}
+
+	case kind == ScopeKindConst, kind == ScopeKindType, kind == ScopeKindCall:
+		p = ScopePattern{Kind: kind, Name: rest}
+
+	default:
+		return p, fmt.Errorf("unknown kind %q: must be %s, %s, %s, %s or %s",
+			kind, ScopeKindFunc, ScopeKindVar, ScopeKindConst, ScopeKindType, ScopeKindCall)
+	}
+
+	for _, glob := range []string{p.Name, p.Receiver, p.Call} {
+		if strings.ContainsAny(glob, " \t") {
+			return p, fmt.Errorf("invalid name %q", glob)
+		}
+		if _, err := path.Match(glob, ""); err != nil {
+			return p, fmt.Errorf("invalid glob %q: %v", glob, err)
+		}
+	}
+	if p.Name == "" {
+		return p, errors.New("the name should be set")
+	}
+
+	return p, nil
+}
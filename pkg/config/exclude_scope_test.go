@@ -0,0 +1,68 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScopePattern(t *testing.T) {
+	testCases := []struct {
+		scope    string
+		expected ScopePattern
+	}{
+		{scope: "func Test*", expected: ScopePattern{Kind: ScopeKindFunc, Name: "Test*"}},
+		{scope: "func (*suite) Test*", expected: ScopePattern{Kind: ScopeKindFunc, Receiver: "*suite", Name: "Test*"}},
+		{scope: "func (s suite) Test*", expected: ScopePattern{Kind: ScopeKindFunc, Receiver: "suite", Name: "Test*"}},
+		{scope: "func (*_ suite) Test*", expected: ScopePattern{Kind: ScopeKindFunc, Receiver: "*suite", Name: "Test*"}},
+		{scope: "func(*suite)Setup", expected: ScopePattern{Kind: ScopeKindFunc, Receiver: "*suite", Name: "Setup"}},
+		{scope: "var _ = Describe", expected: ScopePattern{Kind: ScopeKindVar, Name: "_", Call: "Describe"}},
+		{scope: "var err*", expected: ScopePattern{Kind: ScopeKindVar, Name: "err*"}},
+		{scope: "const Default*", expected: ScopePattern{Kind: ScopeKindConst, Name: "Default*"}},
+		{scope: "type *Mock", expected: ScopePattern{Kind: ScopeKindType, Name: "*Mock"}},
+		{scope: " call ginkgo.It ", expected: ScopePattern{Kind: ScopeKindCall, Name: "ginkgo.It"}},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.scope, func(t *testing.T) {
+			p, err := ParseScopePattern(test.scope)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, p)
+		})
+	}
+}
+
+func TestParseScopePattern_error(t *testing.T) {
+	testCases := []struct {
+		scope string
+		err   string
+	}{
+		{scope: "", err: `unknown kind "": must be func, var, const, type or call`},
+		{scope: "method Test*", err: `unknown kind "method": must be func, var, const, type or call`},
+		{scope: "func", err: "the functions should be matched as func <name> or func (<receiver>) <name>"},
+		{scope: "func () Test", err: "the receiver should be (<type>) or (<name> <type>)"},
+		{scope: "func Test Suite", err: "the functions should be matched as func <name> or func (<receiver>) <name>"},
+		{scope: "var _ =", err: "the called function should be set after ="},
+		{scope: "type", err: "the name should be set"},
+		{scope: "call [a", err: `invalid glob "[a": syntax error in pattern`},
+		{scope: "const a b", err: `invalid name "a b"`},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.scope, func(t *testing.T) {
+			_, err := ParseScopePattern(test.scope)
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestExcludeScope_Validate(t *testing.T) {
+	assert.NoError(t, ExcludeScope{Linter: "dupl", Path: `_test\.go`, Scope: "func (*suite) Test*"}.Validate())
+	assert.EqualError(t, ExcludeScope{Path: "(", Scope: "func Test*"}.Validate(),
+		"invalid path regex: error parsing regexp: missing closing ): `(`")
+	assert.EqualError(t, ExcludeScope{Scope: "func"}.Validate(),
+		`invalid scope "func": the functions should be matched as func <name> or func (<receiver>) <name>`)
+}
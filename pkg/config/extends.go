@@ -0,0 +1,414 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/golangci/golangci-lint/internal/cache"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+const (
+	maxExtendsDepth = 10
+	maxExtendsSize  = 10 << 20
+
+	extendsTimeout = 30 * time.Second
+
+	checksumPrefix = "sha256:"
+)
+
+// extendsSource is the parent config of the extends option:
+// a URL, a git repository `[host/]org/repo[/path]@ref` (GitHub by default, `.golangci.yml` by default), or a file.
+type extendsSource struct {
+	url string
+
+	repo    string
+	repoRef string
+
+	path string
+}
+
+// isLocalExtendsPath checks if the reference is a local file: a path relative to "." or "..", an absolute path,
+// or an existing file of the directory of the config.
+func isLocalExtendsPath(ref, dir string) bool {
+	if ref == "." || ref == ".." || strings.HasPrefix(ref, "./") || strings.HasPrefix(ref, "../") ||
+		strings.HasPrefix(ref, "."+string(filepath.Separator)) || strings.HasPrefix(ref, ".."+string(filepath.Separator)) ||
+		filepath.IsAbs(ref) {
+		return true
+	}
+
+	if dir == "" {
+		return false
+	}
+
+	fi, err := os.Stat(filepath.Join(dir, ref))
+	return err == nil && !fi.IsDir()
+}
+
+func parseExtendsSource(ref, dir string) (*extendsSource, error) {
+	if strings.HasPrefix(ref, "https://") || strings.HasPrefix(ref, "http://") {
+		return &extendsSource{url: ref}, nil
+	}
+
+	// The local paths can contain @: only the other references are git repositories.
+	at := strings.LastIndex(ref, "@")
+	if at == -1 || isLocalExtendsPath(ref, dir) {
+		if dir == "" {
+			return nil, fmt.Errorf("a remote config can't extend the local file %s", ref)
+		}
+		if !filepath.IsAbs(ref) {
+			ref = filepath.Join(dir, ref)
+		}
+		return &extendsSource{path: ref}, nil
+	}
+
+	parts := strings.Split(ref[:at], "/")
+	host := "github.com"
+	if strings.Contains(parts[0], ".") {
+		host, parts = parts[0], parts[1:]
+	}
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" || at == len(ref)-1 {
+		return nil, fmt.Errorf("invalid git repository %q: [host/]org/repo[/path]@ref is expected", ref)
+	}
+
+	src := &extendsSource{
+		repo:    "https://" + path.Join(host, parts[0], parts[1]),
+		repoRef: ref[at+1:],
+		path:    path.Join(parts[2:]...),
+	}
+	if src.path == "" {
+		src.path = ".golangci.yml"
+	}
+
+	return src, nil
+}
+
+func (s *extendsSource) isRemote() bool {
+	return s.url != "" || s.repo != ""
+}
+
+// configType is the format of the config, from its extension: YAML by default.
+func (s *extendsSource) configType() string {
+	p := s.path
+	if s.url != "" {
+		p, _, _ = strings.Cut(s.url, "?")
+	}
+
+	switch ext := strings.TrimPrefix(path.Ext(p), "."); ext {
+	case "json", "toml":
+		return ext
+	default:
+		return "yaml"
+	}
+}
+
+func (s *extendsSource) String() string {
+	switch {
+	case s.url != "":
+		return s.url
+	case s.repo != "":
+		return fmt.Sprintf("%s@%s:%s", s.repo, s.repoRef, s.path)
+	default:
+		return s.path
+	}
+}
+
+// extendsLoader reads the parent configs: the remote configs are cached,
+// to be read without network if their checksum is pinned, or if they can't be fetched.
+type extendsLoader struct {
+	log    logutils.Log
+	cache  *cache.Cache // nil without cache.
+	client *http.Client
+}
+
+func newExtendsLoader(log logutils.Log) *extendsLoader {
+	l := &extendsLoader{log: log, client: &http.Client{Timeout: extendsTimeout}}
+
+	c, err := cache.Default()
+	if err != nil {
+		log.Warnf("Can't open the cache of the extended configs: %s", err)
+	} else {
+		l.cache = c
+	}
+
+	return l
+}
+
+func (l *extendsLoader) load(src *extendsSource, checksum string) ([]byte, error) {
+	if checksum != "" && !strings.HasPrefix(checksum, checksumPrefix) {
+		return nil, fmt.Errorf("invalid checksum %q: %s<hex> is expected", checksum, checksumPrefix)
+	}
+
+	if !src.isRemote() {
+		data, err := os.ReadFile(src.path)
+		if err != nil {
+			return nil, err
+		}
+		return data, verifyChecksum(data, checksum)
+	}
+
+	id, err := extendsCacheID(src)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := l.getCached(id)
+	if checksum != "" && cached != nil && verifyChecksum(cached, checksum) == nil {
+		return cached, nil
+	}
+
+	data, err := l.fetch(src)
+	if err != nil {
+		if cached == nil || checksum != "" {
+			return nil, err
+		}
+
+		l.log.Warnf("Can't fetch the extended config %s, the cached copy is used: %s", src, err)
+		return cached, nil
+	}
+
+	if err := verifyChecksum(data, checksum); err != nil {
+		return nil, err
+	}
+
+	if l.cache != nil {
+		if err := l.cache.PutBytes(id, data); err != nil {
+			l.log.Warnf("Can't cache the extended config %s: %s", src, err)
+		}
+	}
+
+	return data, nil
+}
+
+func (l *extendsLoader) getCached(id cache.ActionID) []byte {
+	if l.cache == nil {
+		return nil
+	}
+
+	data, _, err := l.cache.GetBytes(id)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
+
+func (l *extendsLoader) fetch(src *extendsSource) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), extendsTimeout)
+	defer cancel()
+
+	if src.repo != "" {
+		return fetchGitFile(ctx, src.repo, src.repoRef, src.path)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", src.url, resp.Status)
+	}
+
+	return readLimited(resp.Body)
+}
+
+// fetchGitFile reads the file at the ref (a branch, a tag or a commit) of the repository, with a shallow fetch.
+func fetchGitFile(ctx context.Context, repo, ref, file string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "golangci-lint-extends")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	git := func(args ...string) ([]byte, error) {
+		cmd := exec.CommandContext(ctx, "git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+		}
+		return out, nil
+	}
+
+	if _, err := git("init", "--quiet"); err != nil {
+		return nil, err
+	}
+	if _, err := git("fetch", "--quiet", "--depth=1", repo, ref); err != nil {
+		return nil, err
+	}
+
+	data, err := git("show", "FETCH_HEAD:"+file)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxExtendsSize {
+		return nil, fmt.Errorf("the config is larger than %d bytes", maxExtendsSize)
+	}
+
+	return data, nil
+}
+
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxExtendsSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxExtendsSize {
+		return nil, fmt.Errorf("the config is larger than %d bytes", maxExtendsSize)
+	}
+	return data, nil
+}
+
+func extendsCacheID(src *extendsSource) (cache.ActionID, error) {
+	h, err := cache.NewHash("config extends")
+	if err != nil {
+		return cache.ActionID{}, err
+	}
+
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s", src.url, src.repo, src.repoRef, src.path)
+
+	return h.Sum(), nil
+}
+
+func verifyChecksum(data []byte, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+
+	sum := sha256.Sum256(data)
+	if got := checksumPrefix + hex.EncodeToString(sum[:]); got != checksum {
+		return fmt.Errorf("checksum mismatch: %s is pinned, but the config has %s", checksum, got)
+	}
+
+	return nil
+}
+
+// readExtends merges the parent configs of the extends option below the config read by viper:
+// the sections are merged, the other values of a config override the values of its parents.
+func (r *FileReader) readExtends() error {
+	ref := r.v.GetString("extends")
+	if ref == "" {
+		return nil
+	}
+
+	loader := newExtendsLoader(r.log)
+
+	layers := []map[string]interface{}{r.v.AllSettings()}
+	checksum := r.v.GetString("extends-checksum")
+	dir := r.cfg.cfgDir
+	seen := map[string]bool{}
+
+	for ref != "" {
+		src, err := parseExtendsSource(ref, dir)
+		if err != nil {
+			return err
+		}
+
+		if seen[src.String()] {
+			return fmt.Errorf("the config %s extends itself", src)
+		}
+		seen[src.String()] = true
+		if len(seen) > maxExtendsDepth {
+			return errors.New("too many extended configs")
+		}
+
+		data, err := loader.load(src, checksum)
+		if err != nil {
+			return fmt.Errorf("can't read the extended config %s: %w", src, err)
+		}
+
+		v := viper.New()
+		v.SetConfigType(src.configType())
+		if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("can't parse the extended config %s: %w", src, err)
+		}
+		r.log.Infof("Used extended config %s", src)
+
+		layers = append(layers, v.AllSettings())
+
+		ref, checksum = v.GetString("extends"), v.GetString("extends-checksum")
+		dir = ""
+		if !src.isRemote() {
+			dir = filepath.Dir(src.path)
+		}
+	}
+
+	merged := map[string]interface{}{}
+	for i := len(layers) - 1; i >= 0; i-- {
+		mergeSettings(merged, layers[i])
+	}
+
+	if policy := mergePolicies(layers); len(policy) != 0 {
+		merged["policy"] = policy
+	}
+
+	return r.v.MergeConfigMap(merged)
+}
+
+// mergePolicies joins the lists of the policies of the configs: a config can't weaken the policy of its parents.
+func mergePolicies(layers []map[string]interface{}) map[string]interface{} {
+	policy := map[string]interface{}{}
+	for i := len(layers) - 1; i >= 0; i-- {
+		layer, ok := layers[i]["policy"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for k, v := range layer {
+			values, ok := v.([]interface{})
+			if !ok {
+				policy[k] = v
+				continue
+			}
+
+			joined, _ := policy[k].([]interface{})
+			policy[k] = append(append([]interface{}{}, joined...), values...)
+		}
+	}
+	return policy
+}
+
+// mergeSettings merges the settings into dst: the maps are merged, the other values replace the values of dst.
+func mergeSettings(dst, src map[string]interface{}) {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]interface{})
+		dstMap, dstIsMap := dst[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeSettings(dstMap, srcMap)
+			continue
+		}
+
+		if srcIsMap {
+			copied := map[string]interface{}{}
+			mergeSettings(copied, srcMap)
+			v = copied
+		}
+		dst[k] = v
+	}
+}
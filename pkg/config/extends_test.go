@@ -0,0 +1,165 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/internal/cache"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func TestParseExtendsSource(t *testing.T) {
+	dir := filepath.FromSlash("/project")
+
+	testCases := []struct {
+		ref      string
+		expected extendsSource
+	}{
+		{
+			ref:      "https://example.com/org-golangci.yml",
+			expected: extendsSource{url: "https://example.com/org-golangci.yml"},
+		},
+		{
+			ref:      "org/lint@v1",
+			expected: extendsSource{repo: "https://github.com/org/lint", repoRef: "v1", path: ".golangci.yml"},
+		},
+		{
+			ref:      "gitlab.example.com/org/lint/configs/strict.yml@main",
+			expected: extendsSource{repo: "https://gitlab.example.com/org/lint", repoRef: "main", path: "configs/strict.yml"},
+		},
+		{
+			ref:      "../base.yml",
+			expected: extendsSource{path: filepath.Join(dir, "..", "base.yml")},
+		},
+		{
+			ref:      "./configs/base@v2.yml",
+			expected: extendsSource{path: filepath.Join(dir, "configs", "base@v2.yml")},
+		},
+		{
+			ref:      filepath.Join(dir, "team@example", "base.yml"),
+			expected: extendsSource{path: filepath.Join(dir, "team@example", "base.yml")},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.ref, func(t *testing.T) {
+			src, err := parseExtendsSource(test.ref, dir)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, *src)
+		})
+	}
+
+	_, err := parseExtendsSource("org@v1", dir)
+	assert.Error(t, err)
+
+	_, err = parseExtendsSource("base.yml", "")
+	assert.EqualError(t, err, "a remote config can't extend the local file base.yml")
+
+	_, err = parseExtendsSource("./base@v2.yml", "")
+	assert.EqualError(t, err, "a remote config can't extend the local file ./base@v2.yml")
+}
+
+func TestParseExtendsSource_existingFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "configs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "configs", "base@v2.yml"), nil, 0o600))
+
+	src, err := parseExtendsSource("configs/base@v2.yml", dir)
+	require.NoError(t, err)
+	assert.Equal(t, extendsSource{path: filepath.Join(dir, "configs", "base@v2.yml")}, *src)
+
+	src, err = parseExtendsSource("org/lint@v1", dir)
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/org/lint", src.repo)
+}
+
+func TestMergeSettings(t *testing.T) {
+	merged := map[string]interface{}{}
+	mergeSettings(merged, map[string]interface{}{
+		"linters": map[string]interface{}{"enable": []interface{}{"gosec"}, "fast": true},
+		"run":     map[string]interface{}{"timeout": "5m"},
+	})
+	mergeSettings(merged, map[string]interface{}{
+		"linters": map[string]interface{}{"enable": []interface{}{"errcheck"}},
+		"output":  map[string]interface{}{"format": "json"},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"linters": map[string]interface{}{"enable": []interface{}{"errcheck"}, "fast": true},
+		"run":     map[string]interface{}{"timeout": "5m"},
+		"output":  map[string]interface{}{"format": "json"},
+	}, merged)
+}
+
+func TestMergePolicies(t *testing.T) {
+	layers := []map[string]interface{}{
+		{"policy": map[string]interface{}{"required-linters": []interface{}{"errcheck"}}},
+		{"linters": map[string]interface{}{"enable": []interface{}{"gosec"}}},
+		{"policy": map[string]interface{}{
+			"required-linters":   []interface{}{"govet", "gosec"},
+			"forbidden-excludes": []interface{}{"_test"},
+		}},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"required-linters":   []interface{}{"govet", "gosec", "errcheck"},
+		"forbidden-excludes": []interface{}{"_test"},
+	}, mergePolicies(layers))
+
+	assert.Empty(t, mergePolicies(layers[1:2]))
+}
+
+func TestExtendsLoader_load(t *testing.T) {
+	content := "linters:\n  enable: [gosec]\n"
+	sum := sha256.Sum256([]byte(content))
+	checksum := checksumPrefix + hex.EncodeToString(sum[:])
+
+	up := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer srv.Close()
+
+	c, err := cache.Open(t.TempDir())
+	require.NoError(t, err)
+
+	l := &extendsLoader{log: logutils.NewStderrLog(""), cache: c, client: srv.Client()}
+	src := &extendsSource{url: srv.URL + "/org-golangci.yml"}
+
+	data, err := l.load(src, checksum)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+
+	_, err = l.load(src, "sha256:0000")
+	assert.Contains(t, err.Error(), "checksum mismatch")
+
+	_, err = l.load(src, "md5:0000")
+	assert.Contains(t, err.Error(), "invalid checksum")
+
+	// The cached copy is used when the server is down.
+	up = false
+
+	data, err = l.load(src, checksum)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+
+	data, err = l.load(src, "")
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+
+	_, err = l.load(&extendsSource{url: srv.URL + "/other.yml"}, "")
+	assert.Contains(t, err.Error(), "503")
+}
@@ -0,0 +1,222 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"gopkg.in/yaml.v3"
+)
+
+// InlineDirectivePrefix is the prefix of the inline directives of the files:
+//
+//	//golangci:disable staticcheck,gosec
+//	//golangci:config errcheck.check-blank=true
+const InlineDirectivePrefix = "//golangci:"
+
+// InlineConfig is the configuration of a file set by its inline directives.
+type InlineConfig struct {
+	File string
+	// Disable are the linters disabled for the file.
+	Disable []string
+	// Settings are the options of the linters settings changed for the file.
+	Settings []InlineSetting
+}
+
+// InlineSetting is an option of the linters settings: the path of the option starts with the name of the linter,
+// e.g. errcheck.check-blank.
+type InlineSetting struct {
+	Path  string
+	Value string
+}
+
+// Linter returns the name of the linter of the option.
+func (s InlineSetting) Linter() string {
+	name, _, _ := strings.Cut(s.Path, ".")
+	return name
+}
+
+func (s InlineSetting) String() string {
+	return s.Path + "=" + s.Value
+}
+
+// ParseDirective parses the inline directive of the comment: it returns false if the comment isn't a directive.
+func (c *InlineConfig) ParseDirective(comment string) (bool, error) {
+	if !strings.HasPrefix(comment, InlineDirectivePrefix) {
+		return false, nil
+	}
+
+	name, args, _ := strings.Cut(strings.TrimPrefix(comment, InlineDirectivePrefix), " ")
+	args = strings.TrimSpace(args)
+
+	switch name {
+	case "disable":
+		var linters []string
+		for _, linter := range strings.Split(args, ",") {
+			if linter = strings.TrimSpace(linter); linter != "" {
+				linters = append(linters, linter)
+			}
+		}
+		if len(linters) == 0 {
+			return true, fmt.Errorf("%q: no linters to disable", comment)
+		}
+		c.Disable = append(c.Disable, linters...)
+
+	case "config":
+		path, value, ok := strings.Cut(args, "=")
+		path = strings.TrimSpace(path)
+		if !ok || !strings.Contains(path, ".") {
+			return true, fmt.Errorf("%q: the option should be set as linter.option=value", comment)
+		}
+		c.Settings = append(c.Settings, InlineSetting{Path: path, Value: strings.TrimSpace(value)})
+
+	default:
+		return true, fmt.Errorf("%q: unknown directive %q", comment, name)
+	}
+
+	return true, nil
+}
+
+// WithInlineSettings returns a copy of the linters settings with the options of the inline settings.
+// The values are parsed like the YAML values of the config file, and the lists can be separated by commas.
+func (s LintersSettings) WithInlineSettings(settings []InlineSetting) (LintersSettings, error) {
+	v := reflect.ValueOf(&s).Elem()
+	for _, setting := range settings {
+		if err := setOption(v, strings.Split(setting.Path, "."), optionText(setting.Value)); err != nil {
+			return s, fmt.Errorf("invalid option %s: %w", setting.Path, err)
+		}
+	}
+	return s, nil
+}
+
+var errUnknownOption = errors.New("unknown option")
+
+// optionText is the text of the value of an option, parsed as YAML.
+type optionText string
+
+// setOption sets the option at the path of keys: the maps and the pointers of the path are copied,
+// the settings of the original value are unchanged.
+// The value is a value of the config file, or the optionText of an inline directive.
+func setOption(v reflect.Value, keys []string, value interface{}) error {
+	if len(keys) == 0 {
+		return decodeOption(v, value)
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		f, ok := structFieldByKey(v, keys[0])
+		if !ok {
+			return errUnknownOption
+		}
+		return setOption(f, keys[1:], value)
+
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		if !v.IsNil() {
+			elem.Elem().Set(v.Elem())
+		}
+		if err := setOption(elem.Elem(), keys, value); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return errUnknownOption
+		}
+
+		// The keys of the maps are lowercased like the keys of the config file.
+		key := reflect.ValueOf(strings.ToLower(keys[0])).Convert(v.Type().Key())
+
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if old := v.MapIndex(key); old.IsValid() {
+			elem.Set(old)
+		}
+		if err := setOption(elem, keys[1:], value); err != nil {
+			return err
+		}
+
+		m := reflect.MakeMapWithSize(v.Type(), v.Len()+1)
+		iter := v.MapRange()
+		for iter.Next() {
+			m.SetMapIndex(iter.Key(), iter.Value())
+		}
+		m.SetMapIndex(key, elem)
+		v.Set(m)
+		return nil
+
+	case reflect.Interface:
+		// A nested map of a free-form section.
+		m := reflect.New(reflect.TypeOf(map[string]interface{}{})).Elem()
+		if old, ok := v.Interface().(map[string]interface{}); ok {
+			m.Set(reflect.ValueOf(old))
+		}
+		if err := setOption(m, keys, value); err != nil {
+			return err
+		}
+		v.Set(m)
+		return nil
+
+	default:
+		return errUnknownOption
+	}
+}
+
+// structFieldByKey returns the field of the struct with the key of the config file, searched in the squashed structs.
+func structFieldByKey(v reflect.Value, key string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+
+		name, opts := parseMapstructureTag(f)
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "squash") && f.Type.Kind() == reflect.Struct {
+			if fv, ok := structFieldByKey(v.Field(i), key); ok {
+				return fv, true
+			}
+			continue
+		}
+
+		if strings.EqualFold(name, key) {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// decodeOption sets the value of the option like viper decodes the values of the config file.
+func decodeOption(v reflect.Value, value interface{}) error {
+	parsed := value
+	if text, ok := value.(optionText); ok {
+		if err := yaml.Unmarshal([]byte(text), &parsed); err != nil || parsed == nil {
+			parsed = string(text)
+		}
+	}
+
+	decoded := reflect.New(v.Type())
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToSliceHookFunc(","),
+		),
+		WeaklyTypedInput: true,
+		Result:           decoded.Interface(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := decoder.Decode(parsed); err != nil {
+		return err
+	}
+
+	v.Set(decoded.Elem())
+	return nil
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInlineConfig_ParseDirective(t *testing.T) {
+	var c InlineConfig
+
+	ok, err := c.ParseDirective("// a comment")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	for _, comment := range []string{
+		"//golangci:disable staticcheck, gosec",
+		"//golangci:disable lll",
+		"//golangci:config errcheck.check-blank=true",
+		"//golangci:config govet.settings.printf.funcs = Logf,Errorf",
+	} {
+		ok, err = c.ParseDirective(comment)
+		require.NoError(t, err, comment)
+		assert.True(t, ok, comment)
+	}
+
+	assert.Equal(t, []string{"staticcheck", "gosec", "lll"}, c.Disable)
+	assert.Equal(t, []InlineSetting{
+		{Path: "errcheck.check-blank", Value: "true"},
+		{Path: "govet.settings.printf.funcs", Value: "Logf,Errorf"},
+	}, c.Settings)
+	assert.Equal(t, "govet", c.Settings[1].Linter())
+
+	for _, comment := range []string{
+		"//golangci:disable",
+		"//golangci:config errcheck",
+		"//golangci:config check-blank=true",
+		"//golangci:enable gosec",
+	} {
+		ok, err = c.ParseDirective(comment)
+		assert.True(t, ok, comment)
+		assert.Error(t, err, comment)
+	}
+}
+
+func TestLintersSettings_WithInlineSettings(t *testing.T) {
+	settings := LintersSettings{
+		Errcheck: ErrcheckSettings{ExcludeFunctions: []string{"fmt.Print"}},
+		Govet: GovetSettings{
+			Settings: map[string]map[string]interface{}{
+				"shadow": {"strict": true},
+			},
+		},
+	}
+
+	changed, err := settings.WithInlineSettings([]InlineSetting{
+		{Path: "errcheck.check-blank", Value: "true"},
+		{Path: "errcheck.exclude-functions", Value: "io.Copy,os.Remove"},
+		{Path: "govet.settings.printf.funcs", Value: "[Logf, Errorf]"},
+		{Path: "dogsled.max-blank-identifiers", Value: "3"},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, changed.Errcheck.CheckAssignToBlank)
+	assert.Equal(t, []string{"io.Copy", "os.Remove"}, changed.Errcheck.ExcludeFunctions)
+	assert.Equal(t, 3, changed.Dogsled.MaxBlankIdentifiers)
+	assert.Equal(t, map[string]map[string]interface{}{
+		"shadow": {"strict": true},
+		"printf": {"funcs": []interface{}{"Logf", "Errorf"}},
+	}, changed.Govet.Settings)
+
+	// The original settings are unchanged.
+	assert.False(t, settings.Errcheck.CheckAssignToBlank)
+	assert.Equal(t, []string{"fmt.Print"}, settings.Errcheck.ExcludeFunctions)
+	assert.Len(t, settings.Govet.Settings, 1)
+
+	_, err = settings.WithInlineSettings([]InlineSetting{{Path: "errcheck.check-blnk", Value: "true"}})
+	assert.EqualError(t, err, "invalid option errcheck.check-blnk: unknown option")
+
+	_, err = settings.WithInlineSettings([]InlineSetting{{Path: "dogsled.max-blank-identifiers", Value: "many"}})
+	assert.Error(t, err)
+}
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"regexp"
+	"strings"
+	"time"
 )
 
 const excludeRuleMinConditionsCount = 2
@@ -110,6 +114,23 @@ type Issues struct {
 	ExcludeRules           []ExcludeRule `mapstructure:"exclude-rules"`
 	UseDefaultExcludes     bool          `mapstructure:"exclude-use-default"`
 
+	// IncludeRules restrict their linters to the matching issues, e.g. to run a linter only on some paths.
+	IncludeRules []IncludeRule `mapstructure:"include-rules"`
+
+	// ExcludeScopes exclude the issues inside the declarations or the calls matching their scopes.
+	ExcludeScopes []ExcludeScope `mapstructure:"exclude-scopes"`
+
+	// ExcludeGenerated is the detection of the generated files, whose issues are excluded:
+	// ExcludeGeneratedLax (by default), ExcludeGeneratedStrict or ExcludeGeneratedDisable.
+	ExcludeGenerated string `mapstructure:"exclude-generated"`
+	// ExcludeGeneratedMarkers are more markers of the generated files, in their comments before the package clause.
+	ExcludeGeneratedMarkers []string `mapstructure:"exclude-generated-markers"`
+	// ExcludeGeneratedPaths are the globs of the paths of more generated files.
+	ExcludeGeneratedPaths []string `mapstructure:"exclude-generated-paths"`
+
+	// ReportUnusedNolintDirectives reports the //nolint directives which didn't suppress any issue.
+	ReportUnusedNolintDirectives bool `mapstructure:"report-unused-nolint-directives"`
+
 	MaxIssuesPerLinter int `mapstructure:"max-issues-per-linter"`
 	MaxSameIssues      int `mapstructure:"max-same-issues"`
 
@@ -118,7 +139,207 @@ type Issues struct {
 	WholeFiles        bool   `mapstructure:"whole-files"`
 	Diff              bool   `mapstructure:"new"`
 
+	// DiffFile is the path to a unified diff, "-" for the standard input:
+	// only the issues on the lines added or modified by the diff are reported, without git.
+	DiffFile string `mapstructure:"diff-file"`
+	// DiffFromStdin reads the diff from the standard input, see DiffFile.
+	DiffFromStdin bool `mapstructure:"-"`
+
+	// ChangedOnly analyzes only the packages affected by the changes since DiffFromRevision.
+	ChangedOnly bool `mapstructure:"changed-only"`
+
 	NeedFix bool `mapstructure:"fix"`
+	// UnsafeFix enables the fixes which can change the behavior or break the build, e.g. the renames of identifiers:
+	// they are applied to all the references of the analyzed packages.
+	UnsafeFix bool `mapstructure:"unsafe-fix"`
+
+	Baseline string `mapstructure:"baseline"`
+
+	Normalize NormalizeSettings `mapstructure:"normalize"`
+
+	Coverage CoverageSettings `mapstructure:"coverage"`
+
+	CodeOwners CodeOwnersSettings `mapstructure:"code-owners"`
+
+	Suppressions SuppressionsSettings `mapstructure:"suppressions"`
+
+	Dedup DedupSettings `mapstructure:"dedup"`
+}
+
+// DedupSettings reports once the same problem found by several linters:
+// the issues of equivalent checks on the same line are duplicates, only the issue of the canonical check is kept.
+type DedupSettings struct {
+	Enable bool `mapstructure:"enable"`
+	// Equivalences are added to DefaultDedupEquivalences, their checks are removed from the default equivalences.
+	Equivalences []DedupEquivalence `mapstructure:"equivalences"`
+}
+
+// DedupEquivalence is a set of checks reporting the same problem.
+type DedupEquivalence struct {
+	// Checks are the linters and the rules of linter ("linter:rule"), the first one is the canonical check.
+	Checks []string `mapstructure:"checks"`
+}
+
+// DefaultDedupEquivalences are the checks of the linters known to report the same problems.
+var DefaultDedupEquivalences = []DedupEquivalence{
+	{Checks: []string{"errcheck", "gosec:G104", "revive:unhandled-error"}},
+	{Checks: []string{"ineffassign", "staticcheck:SA4006"}},
+	{Checks: []string{"unused", "deadcode", "varcheck", "structcheck"}},
+	{Checks: []string{"predeclared", "gocritic:builtinShadow", "revive:redefines-builtin-id"}},
+	{Checks: []string{"gocritic:importShadow", "revive:import-shadowing"}},
+	{Checks: []string{"govet:unusedresult", "staticcheck:SA4017"}},
+	{Checks: []string{"revive:exported", "golint"}},
+	{Checks: []string{"stylecheck:ST1005", "revive:error-strings"}},
+	{Checks: []string{"stylecheck:ST1003", "revive:var-naming"}},
+	{Checks: []string{"stylecheck:ST1006", "revive:receiver-naming"}},
+	{Checks: []string{"gocyclo", "cyclop", "revive:cyclomatic"}},
+	{Checks: []string{"funlen", "revive:function-length"}},
+	{Checks: []string{"lll", "revive:line-length-limit"}},
+}
+
+func (d *DedupSettings) Validate() error {
+	seen := map[string]bool{}
+	for i, e := range d.Equivalences {
+		if len(e.Checks) < 2 {
+			return fmt.Errorf("equivalence #%d must have at least 2 checks", i)
+		}
+		for _, check := range e.Checks {
+			if check == "" || strings.HasPrefix(check, ":") || strings.HasSuffix(check, ":") {
+				return fmt.Errorf("equivalence #%d: invalid check %q: must be a linter or linter:rule", i, check)
+			}
+			if seen[check] {
+				return fmt.Errorf("equivalence #%d: check %q is in several equivalences", i, check)
+			}
+			seen[check] = true
+		}
+	}
+	return nil
+}
+
+// CoverageSettings annotates the issues with the coverage of their lines by the tests, to prioritize them.
+type CoverageSettings struct {
+	// Profile is the coverage profile written by `go test -coverprofile`.
+	Profile string `mapstructure:"profile"`
+	// UncoveredOnly hides the issues on the lines covered by the tests.
+	UncoveredOnly bool `mapstructure:"uncovered-only"`
+	// UncoveredFirst sorts the issues on the lines not covered by the tests first.
+	UncoveredFirst bool `mapstructure:"uncovered-first"`
+}
+
+// CodeOwnersSettings annotate the issues with the owners of their files, from a GitHub/GitLab CODEOWNERS file.
+type CodeOwnersSettings struct {
+	// Annotate sets the owners of the issues.
+	Annotate bool `mapstructure:"annotate"`
+	// File is the path of the CODEOWNERS file: by default, CODEOWNERS, .github/CODEOWNERS or docs/CODEOWNERS.
+	File string `mapstructure:"file"`
+	// Owners shows only the issues of the files owned by one of the owners, e.g. @org/team-payments.
+	// They imply Annotate.
+	Owners []string `mapstructure:"owners"`
+}
+
+// SuppressionsSettings is the store of the suppressions of issues managed by `golangci-lint suppress`:
+// a JSON file or an HTTP service.
+type SuppressionsSettings struct {
+	// File is the path of the JSON file of the suppressions.
+	File string `mapstructure:"file"`
+	// URL is the endpoint of the HTTP service of the suppressions.
+	URL string `mapstructure:"url"`
+	// Headers added to the requests, environment variables and credentials are expanded in values.
+	Headers map[string]string `mapstructure:"headers"`
+	Timeout time.Duration     `mapstructure:"timeout"`
+}
+
+func (s *SuppressionsSettings) Validate() error {
+	if s.File != "" && s.URL != "" {
+		return errors.New("file and url can't be combined")
+	}
+
+	if s.URL != "" {
+		u, err := url.Parse(s.URL)
+		if err != nil {
+			return fmt.Errorf("invalid url: %v", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.New("url must be an http or https URL")
+		}
+	} else if len(s.Headers) != 0 {
+		return errors.New("headers require an url")
+	}
+
+	if s.Timeout < 0 {
+		return errors.New("timeout can't be negative")
+	}
+
+	return nil
+}
+
+const (
+	// ExcludeGeneratedLax detects the generated files by markers like "code generated" or "do not edit" in their comments.
+	ExcludeGeneratedLax = "lax"
+	// ExcludeGeneratedStrict detects the generated files by the comment of https://golang.org/s/generatedcode.
+	ExcludeGeneratedStrict = "strict"
+	// ExcludeGeneratedDisable reports the issues of the generated files.
+	ExcludeGeneratedDisable = "disable"
+)
+
+// ValidateExcludeGenerated checks the mode of the detection of the generated files.
+func (i *Issues) ValidateExcludeGenerated() error {
+	switch i.ExcludeGenerated {
+	case "", ExcludeGeneratedLax, ExcludeGeneratedStrict, ExcludeGeneratedDisable:
+		return nil
+	default:
+		return fmt.Errorf("invalid exclude-generated %q: must be %q, %q or %q",
+			i.ExcludeGenerated, ExcludeGeneratedLax, ExcludeGeneratedStrict, ExcludeGeneratedDisable)
+	}
+}
+
+const (
+	NormalizeCaseKeep  = ""
+	NormalizeCaseLower = "lower"
+	NormalizeCaseUpper = "upper"
+)
+
+// NormalizeSettings describes how issue messages are rewritten before exclusion
+// so that reports mixing many linters read coherently.
+type NormalizeSettings struct {
+	Enable              bool                `mapstructure:"enable"`
+	Case                string              `mapstructure:"case"`
+	StripTrailingPeriod bool                `mapstructure:"strip-trailing-period"`
+	Templates           []NormalizeTemplate `mapstructure:"templates"`
+}
+
+func (n NormalizeSettings) Validate() error {
+	switch n.Case {
+	case NormalizeCaseKeep, NormalizeCaseLower, NormalizeCaseUpper:
+	default:
+		return fmt.Errorf("invalid case %q: must be %q or %q", n.Case, NormalizeCaseLower, NormalizeCaseUpper)
+	}
+
+	for i, t := range n.Templates {
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("error in template #%d: %v", i, err)
+		}
+	}
+
+	return nil
+}
+
+// NormalizeTemplate rewrites the messages of a linter matching Pattern into Replacement.
+// Replacement can reference capture groups of Pattern (`${1}`).
+type NormalizeTemplate struct {
+	Linter      string `mapstructure:"linter"`
+	Pattern     string `mapstructure:"pattern"`
+	Replacement string `mapstructure:"replacement"`
+}
+
+func (t NormalizeTemplate) Validate() error {
+	if t.Pattern == "" {
+		return errors.New("pattern should be set")
+	}
+	if _, err := regexp.Compile(t.Pattern); err != nil {
+		return fmt.Errorf("invalid pattern regex: %v", err)
+	}
+	return nil
 }
 
 type ExcludeRule struct {
@@ -129,6 +350,18 @@ func (e ExcludeRule) Validate() error {
 	return e.BaseRule.Validate(excludeRuleMinConditionsCount)
 }
 
+// IncludeRule keeps the issues of its linters only if they match it or another include rule of the linter.
+type IncludeRule struct {
+	BaseRule `mapstructure:",squash"`
+}
+
+func (e IncludeRule) Validate() error {
+	if len(e.Linters) == 0 {
+		return errors.New("linters should be set")
+	}
+	return e.BaseRule.Validate(excludeRuleMinConditionsCount)
+}
+
 type BaseRule struct {
 	Linters []string
 	Path    string
@@ -146,6 +379,14 @@ func (b BaseRule) Validate(minConditionsCount int) error {
 	if err := validateOptionalRegex(b.Source); err != nil {
 		return fmt.Errorf("invalid source regex: %v", err)
 	}
+	if b.conditionsCount() < minConditionsCount {
+		return fmt.Errorf("at least %d of (text, source, path, linters) should be set", minConditionsCount)
+	}
+	return nil
+}
+
+// conditionsCount returns the count of the conditions set.
+func (b BaseRule) conditionsCount() int {
 	nonBlank := 0
 	if len(b.Linters) > 0 {
 		nonBlank++
@@ -159,10 +400,7 @@ func (b BaseRule) Validate(minConditionsCount int) error {
 	if b.Source != "" {
 		nonBlank++
 	}
-	if nonBlank < minConditionsCount {
-		return fmt.Errorf("at least %d of (text, source, path, linters) should be set", minConditionsCount)
-	}
-	return nil
+	return nonBlank
 }
 
 func validateOptionalRegex(value string) error {
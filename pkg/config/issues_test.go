@@ -34,3 +34,24 @@ func TestGetExcludePatterns(t *testing.T) {
 		assert.True(t, inDefaultExc, fmt.Sprintf("%s must appear inside DefaultExcludePatterns.", p.ID))
 	}
 }
+
+func TestSuppressionsSettings_Validate(t *testing.T) {
+	valid := []SuppressionsSettings{
+		{},
+		{File: ".golangci-suppressions.json"},
+		{URL: "https://suppressions.example.com/api", Headers: map[string]string{"Authorization": "Bearer x"}},
+	}
+	for _, s := range valid {
+		assert.NoError(t, s.Validate(), "%+v", s)
+	}
+
+	invalid := []SuppressionsSettings{
+		{File: "s.json", URL: "https://suppressions.example.com/api"},
+		{URL: "ftp://suppressions.example.com"},
+		{File: "s.json", Headers: map[string]string{"Authorization": "Bearer x"}},
+		{URL: "https://suppressions.example.com/api", Timeout: -1},
+	}
+	for _, s := range invalid {
+		assert.Error(t, s.Validate(), "%+v", s)
+	}
+}
@@ -8,4 +8,12 @@ type Linters struct {
 	Fast       bool
 
 	Presets []string
+
+	// Versions are the pinned versions of the modules implementing the linters, by linter name:
+	// the run fails if the bundled versions don't match.
+	Versions map[string]string
+
+	// WarnOnly are the linters reporting their issues as warnings:
+	// the issues are reported with the warning severity, but don't fail the run.
+	WarnOnly []string `mapstructure:"warn-only"`
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"runtime"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -186,6 +187,9 @@ type LintersSettings struct {
 	WSL              WSLSettings
 
 	Custom map[string]CustomLinterSettings
+
+	// Timeouts are the durations after which the linters are cancelled, from the keys `<linter>.timeout`.
+	Timeouts map[string]time.Duration `mapstructure:"-"`
 }
 
 type AsasalintSettings struct {
@@ -285,7 +289,6 @@ type FunlenSettings struct {
 }
 
 type GciSettings struct {
-	LocalPrefixes string   `mapstructure:"local-prefixes"` // Deprecated
 	Sections      []string `mapstructure:"sections"`
 	SkipGenerated bool     `mapstructure:"skip-generated"`
 }
@@ -314,9 +317,6 @@ type GodotSettings struct {
 	Exclude []string `mapstructure:"exclude"`
 	Capital bool     `mapstructure:"capital"`
 	Period  bool     `mapstructure:"period"`
-
-	// Deprecated: use `Scope` instead
-	CheckAll bool `mapstructure:"check-all"`
 }
 
 type GodoxSettings struct {
@@ -350,11 +350,10 @@ type GoLintSettings struct {
 }
 
 type GoMndSettings struct {
-	Settings         map[string]map[string]interface{} // Deprecated
-	Checks           []string                          `mapstructure:"checks"`
-	IgnoredNumbers   []string                          `mapstructure:"ignored-numbers"`
-	IgnoredFiles     []string                          `mapstructure:"ignored-files"`
-	IgnoredFunctions []string                          `mapstructure:"ignored-functions"`
+	Checks           []string `mapstructure:"checks"`
+	IgnoredNumbers   []string `mapstructure:"ignored-numbers"`
+	IgnoredFiles     []string `mapstructure:"ignored-files"`
+	IgnoredFunctions []string `mapstructure:"ignored-functions"`
 }
 
 type GoModDirectivesSettings struct {
@@ -645,9 +644,16 @@ type WSLSettings struct {
 //	     path: /example.so
 //	     description: The description of the linter
 //	     original-url: github.com/golangci/example-linter
+//	   other:
+//	     module: github.com/golangci/other-linter@v1.0.0
+//	     import: github.com/golangci/other-linter/plugin
 type CustomLinterSettings struct {
 	// Path to a plugin *.so file that implements the private linter.
 	Path string
+	// Module (`path@version`) compiled into a custom binary by `golangci-lint custom`.
+	Module string
+	// Import is the package of Module registering the private linter, the module path by default.
+	Import string
 	// Description describes the purpose of the private linter.
 	Description string
 	// The URL containing the source code for the private linter.
@@ -0,0 +1,388 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LinterMigration is the replacement of a deprecated linter.
+type LinterMigration struct {
+	// Replacement is the name of the linter replacing the deprecated linter, empty without replacement.
+	Replacement string
+	// Analyzer is the analyzer of govet replacing the deprecated linter, if the replacement is govet.
+	Analyzer string
+}
+
+// Migrator rewrites a YAML configuration: it renames the deprecated linters to their replacements,
+// moves the deprecated options to their replacements and converts the historical options.
+// The comments of the configuration are kept.
+type Migrator struct {
+	linters map[string]LinterMigration
+
+	// Changes are the applied changes, Manual are the changes to do by hand.
+	Changes []string
+	Manual  []string
+}
+
+func NewMigrator(linters map[string]LinterMigration) *Migrator {
+	return &Migrator{linters: linters}
+}
+
+// Migrate returns the migrated configuration, unchanged if there are no changes.
+func (m *Migrator) Migrate(data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return data, nil
+	}
+	root := doc.Content[0]
+
+	m.migrateRun(root)
+	m.migrateSettings(root)
+	m.migrateLinters(root)
+
+	if len(m.Changes) == 0 {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (m *Migrator) changef(format string, args ...interface{}) {
+	m.Changes = append(m.Changes, fmt.Sprintf(format, args...))
+}
+
+func (m *Migrator) manualf(format string, args ...interface{}) {
+	m.Manual = append(m.Manual, fmt.Sprintf(format, args...))
+}
+
+// migrateRun converts the options of the run kept for historical compatibility.
+func (m *Migrator) migrateRun(root *yaml.Node) {
+	deadline := yamlGet(root, "run.deadline")
+	if deadline == nil {
+		return
+	}
+
+	if yamlGet(root, "run.timeout") != nil {
+		yamlDelete(root, "run.deadline")
+		m.changef("run.deadline: removed, run.timeout is set")
+		return
+	}
+
+	yamlKey(root, "run.deadline").Value = "timeout"
+	m.changef("run.deadline: renamed to run.timeout")
+}
+
+// migrateSettings moves the deprecated options of the settings of the linters to their replacements.
+func (m *Migrator) migrateSettings(root *yaml.Node) {
+	for _, d := range settingDeprecations {
+		path := "linters-settings." + d.Option
+
+		node := yamlGet(root, path)
+		if node == nil {
+			continue
+		}
+
+		if d.Entries {
+			m.migrateEntries(root, node, d)
+			continue
+		}
+
+		convert := d.Convert
+		if convert == nil {
+			m.manualf("%s", d)
+			continue
+		}
+
+		replaced := yamlGet(root, d.Replacement) != nil
+		if replaced && !d.Overrides {
+			yamlDelete(root, path)
+			m.changef("%s: removed, %s is set", path, d.Replacement)
+			continue
+		}
+
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			m.manualf("%s", d)
+			continue
+		}
+
+		converted, ok := convert(value)
+		if ok {
+			replacement := node // the same value keeps its style and its comments
+			if !reflect.DeepEqual(converted, value) {
+				replacement = &yaml.Node{}
+				if err := replacement.Encode(converted); err != nil {
+					m.manualf("%s", d)
+					continue
+				}
+			}
+			yamlSet(root, d.Replacement, replacement)
+		}
+
+		yamlDelete(root, path)
+		switch {
+		case ok && replaced:
+			m.changef("%s: moved to %s, replacing its value: the deprecated option had priority", path, d.Replacement)
+		case ok:
+			m.changef("%s: moved to %s", path, d.Replacement)
+		default:
+			m.changef("%s: removed, its value is the default", path)
+		}
+	}
+}
+
+// migrateEntries moves the entries of a deprecated map to its replacement, next to the options of the linter.
+func (m *Migrator) migrateEntries(root, node *yaml.Node, d SettingDeprecation) {
+	path := "linters-settings." + d.Option
+
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return
+	}
+
+	entries := d.entries(value)
+	if len(entries) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	deleteEntries := func() {
+		for _, key := range keys {
+			yamlDelete(root, path+"."+key)
+		}
+	}
+
+	if yamlGet(root, d.Replacement) != nil {
+		deleteEntries()
+		m.changef("%s: removed the entries %s, %s is set", path, strings.Join(keys, ", "), d.Replacement)
+		return
+	}
+
+	converted, ok := d.Convert(entries)
+	replacement := &yaml.Node{}
+	if !ok || replacement.Encode(converted) != nil {
+		m.manualf("%s", d)
+		return
+	}
+
+	deleteEntries()
+	yamlSet(root, d.Replacement, replacement)
+	m.changef("%s: moved the entries %s to %s", path, strings.Join(keys, ", "), d.Replacement)
+}
+
+// migrateLinters renames the deprecated linters in the lists of linters.
+func (m *Migrator) migrateLinters(root *yaml.Node) {
+	for _, path := range []string{"linters.enable", "linters.disable"} {
+		if list := yamlGet(root, path); list != nil {
+			m.migrateLintersList(root, list, path)
+		}
+	}
+
+	for _, path := range []string{"issues.exclude-rules", "issues.include-rules", "severity.rules"} {
+		rules := yamlGet(root, path)
+		if rules == nil || rules.Kind != yaml.SequenceNode {
+			continue
+		}
+		for i, rule := range rules.Content {
+			if list := yamlGet(rule, "linters"); list != nil {
+				m.migrateLintersList(root, list, fmt.Sprintf("%s[%d].linters", path, i))
+			}
+		}
+	}
+
+	names := make([]string, 0, len(m.linters))
+	for name := range m.linters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		lm := m.linters[name]
+		if yamlGet(root, "linters-settings."+name) != nil {
+			if lm.Replacement != "" {
+				m.manualf("linters-settings.%s: the linter is deprecated, its settings must be converted to the settings of %s",
+					name, lm.Replacement)
+			} else {
+				m.manualf("linters-settings.%s: the linter is deprecated without replacement", name)
+			}
+		}
+	}
+}
+
+func (m *Migrator) migrateLintersList(root, list *yaml.Node, path string) {
+	if list.Kind != yaml.SequenceNode {
+		return
+	}
+
+	seen := map[string]bool{}
+	var content []*yaml.Node
+
+	for _, item := range list.Content {
+		name := strings.ToLower(item.Value)
+
+		lm, ok := m.linters[name]
+		if ok && lm.Replacement == "" {
+			m.manualf("%s: %s is deprecated without replacement", path, item.Value)
+		}
+		if ok && lm.Replacement != "" {
+			m.changef("%s: %s renamed to %s", path, item.Value, lm.Replacement)
+			item.Value = lm.Replacement
+
+			if lm.Analyzer != "" && strings.HasSuffix(path, "linters.enable") {
+				m.enableGovetAnalyzer(root, lm.Analyzer)
+			}
+		}
+
+		if seen[item.Value] {
+			continue // the replacement was already in the list
+		}
+		seen[item.Value] = true
+		content = append(content, item)
+	}
+
+	list.Content = content
+}
+
+func (m *Migrator) enableGovetAnalyzer(root *yaml.Node, analyzer string) {
+	const path = "linters-settings.govet.enable"
+
+	list := yamlGet(root, path)
+	if list == nil {
+		list = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		yamlSet(root, path, list)
+	}
+	if list.Kind != yaml.SequenceNode {
+		return
+	}
+
+	for _, item := range list.Content {
+		if item.Value == analyzer {
+			return
+		}
+	}
+
+	list.Content = append(list.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: analyzer})
+	m.changef("%s: %s added", path, analyzer)
+}
+
+// yamlKey returns the key node of the dotted path in the mapping, nil if it's missing.
+func yamlKey(node *yaml.Node, path string) *yaml.Node {
+	parent, key := yamlParent(node, path)
+	if parent == nil {
+		return nil
+	}
+
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if strings.EqualFold(parent.Content[i].Value, key) {
+			return parent.Content[i]
+		}
+	}
+	return nil
+}
+
+// yamlGet returns the value node of the dotted path in the mapping, nil if it's missing.
+func yamlGet(node *yaml.Node, path string) *yaml.Node {
+	for _, key := range strings.Split(path, ".") {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if strings.EqualFold(node.Content[i].Value, key) {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		node = next
+	}
+	return node
+}
+
+func yamlParent(node *yaml.Node, path string) (*yaml.Node, string) {
+	i := strings.LastIndex(path, ".")
+	if i < 0 {
+		return node, path
+	}
+
+	parent := yamlGet(node, path[:i])
+	if parent == nil || parent.Kind != yaml.MappingNode {
+		return nil, ""
+	}
+	return parent, path[i+1:]
+}
+
+// yamlSet sets the value node of the dotted path, the missing mappings are added.
+func yamlSet(node *yaml.Node, path string, value *yaml.Node) {
+	keys := strings.Split(path, ".")
+	for i, key := range keys {
+		var next *yaml.Node
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if strings.EqualFold(node.Content[j].Value, key) {
+				if i == len(keys)-1 {
+					node.Content[j+1] = value
+					return
+				}
+				next = node.Content[j+1]
+				break
+			}
+		}
+
+		if next == nil {
+			next = value
+			if i != len(keys)-1 {
+				next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, next)
+		}
+		if next.Kind != yaml.MappingNode {
+			return
+		}
+		node = next
+	}
+}
+
+// yamlDelete deletes the dotted path, and the mappings left empty.
+func yamlDelete(node *yaml.Node, path string) {
+	parent, key := yamlParent(node, path)
+	if parent == nil {
+		return
+	}
+
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if strings.EqualFold(parent.Content[i].Value, key) {
+			parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+			break
+		}
+	}
+
+	if len(parent.Content) == 0 && strings.Contains(path, ".") {
+		yamlDelete(node, path[:strings.LastIndex(path, ".")])
+	}
+}
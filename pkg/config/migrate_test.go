@@ -0,0 +1,155 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrator_Migrate(t *testing.T) {
+	data := []byte(`# the config
+run:
+  deadline: 5m # the total
+linters:
+  enable:
+    - golint
+    - revive
+    - maligned
+  disable:
+    - interfacer
+linters-settings:
+  staticcheck:
+    go: "1.17"
+  godot:
+    check-all: false
+  gomnd:
+    settings:
+      mnd:
+        checks: [argument]
+  errcheck:
+    exclude: errcheck.txt
+severity:
+  rules:
+    - linters: [golint]
+      severity: info
+`)
+
+	m := NewMigrator(map[string]LinterMigration{
+		"golint":     {Replacement: "revive"},
+		"maligned":   {Replacement: "govet", Analyzer: "fieldalignment"},
+		"interfacer": {},
+	})
+
+	migrated, err := m.Migrate(data)
+	require.NoError(t, err)
+
+	expected := `# the config
+run:
+  timeout: 5m # the total
+linters:
+  enable:
+    - revive
+    - govet
+  disable:
+    - interfacer
+linters-settings:
+  staticcheck:
+    go: "1.17"
+  gomnd:
+    checks: [argument]
+  errcheck:
+    exclude: errcheck.txt
+  govet:
+    enable:
+      - fieldalignment
+severity:
+  rules:
+    - linters: [revive]
+      severity: info
+`
+	assert.Equal(t, expected, string(migrated))
+
+	assert.Equal(t, []string{
+		"run.deadline: renamed to run.timeout",
+		"linters-settings.godot.check-all: removed, its value is the default",
+		"linters-settings.gomnd.settings.mnd.checks: moved to linters-settings.gomnd.checks",
+		"linters.enable: golint renamed to revive",
+		"linters.enable: maligned renamed to govet",
+		"linters-settings.govet.enable: fieldalignment added",
+		"severity.rules[0].linters: golint renamed to revive",
+	}, m.Changes)
+	assert.Equal(t, []string{
+		"The option linters-settings.errcheck.exclude is deprecated: use linters-settings.errcheck.exclude-functions. " +
+			"The functions of the file must be moved to the list",
+		"The option linters-settings.staticcheck.go is deprecated: use run.go. " +
+			"The version applies to all the linters, it must be set by hand",
+		"linters.disable: interfacer is deprecated without replacement",
+	}, m.Manual)
+}
+
+func TestMigrator_Migrate_overrides(t *testing.T) {
+	data := []byte(`linters-settings:
+  gci:
+    sections: [standard]
+    local-prefixes: github.com/org/project
+`)
+
+	m := NewMigrator(nil)
+
+	migrated, err := m.Migrate(data)
+	require.NoError(t, err)
+
+	expected := `linters-settings:
+  gci:
+    sections:
+      - standard
+      - default
+      - prefix(github.com/org/project)
+`
+	assert.Equal(t, expected, string(migrated))
+	assert.Equal(t, []string{
+		"linters-settings.gci.local-prefixes: moved to linters-settings.gci.sections, replacing its value: the deprecated option had priority",
+	}, m.Changes)
+}
+
+func TestMigrator_Migrate_entries(t *testing.T) {
+	data := []byte(`linters-settings:
+  importas:
+    no-unaliased: true
+    std_os: os
+    fff: fmt
+`)
+
+	m := NewMigrator(nil)
+
+	migrated, err := m.Migrate(data)
+	require.NoError(t, err)
+
+	expected := `linters-settings:
+  importas:
+    no-unaliased: true
+    alias:
+      - alias: fff
+        pkg: fmt
+      - alias: std_os
+        pkg: os
+`
+	assert.Equal(t, expected, string(migrated))
+	assert.Equal(t, []string{
+		"linters-settings.importas: moved the entries fff, std_os to linters-settings.importas.alias",
+	}, m.Changes)
+}
+
+func TestMigrator_Migrate_upToDate(t *testing.T) {
+	data := []byte("run:\n    timeout: 5m\n")
+
+	m := NewMigrator(nil)
+
+	migrated, err := m.Migrate(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(data), string(migrated), "unchanged, with its formatting")
+	assert.Empty(t, m.Changes)
+	assert.Empty(t, m.Manual)
+}
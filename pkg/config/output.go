@@ -1,7 +1,15 @@
 package config
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/sliceutil"
+)
+
 const (
 	OutFormatJSON              = "json"
+	OutFormatJSONStream        = "json-stream"
 	OutFormatLineNumber        = "line-number"
 	OutFormatColoredLineNumber = "colored-line-number"
 	OutFormatTab               = "tab"
@@ -10,18 +18,54 @@ const (
 	OutFormatHTML              = "html"
 	OutFormatJunitXML          = "junit-xml"
 	OutFormatGithubActions     = "github-actions"
+	OutFormatTeamCity          = "teamcity"
+	OutFormatSarif             = "sarif"
 )
 
 var OutFormats = []string{
 	OutFormatColoredLineNumber,
 	OutFormatLineNumber,
 	OutFormatJSON,
+	OutFormatJSONStream,
 	OutFormatTab,
 	OutFormatCheckstyle,
 	OutFormatCodeClimate,
 	OutFormatHTML,
 	OutFormatJunitXML,
 	OutFormatGithubActions,
+	OutFormatTeamCity,
+	OutFormatSarif,
+}
+
+const (
+	// SortOrderFile sorts by file path.
+	SortOrderFile = "file"
+	// SortOrderLine sorts by line and column.
+	SortOrderLine = "line"
+	// SortOrderLinter sorts by linter name.
+	SortOrderLinter = "linter"
+	// SortOrderSeverity sorts the most severe issues first.
+	SortOrderSeverity = "severity"
+)
+
+var SortOrders = []string{SortOrderFile, SortOrderLine, SortOrderLinter, SortOrderSeverity}
+
+const (
+	GroupByFile     = "file"
+	GroupByLinter   = "linter"
+	GroupBySeverity = "severity"
+)
+
+var GroupBys = []string{GroupByFile, GroupByLinter, GroupBySeverity}
+
+// StreamableOutFormats are the formats which can print the issues of each linter as soon as it finishes.
+var StreamableOutFormats = []string{
+	OutFormatColoredLineNumber,
+	OutFormatLineNumber,
+	OutFormatJSONStream,
+	OutFormatTab,
+	OutFormatGithubActions,
+	OutFormatTeamCity,
 }
 
 type Output struct {
@@ -29,8 +73,113 @@ type Output struct {
 	Color               string
 	PrintIssuedLine     bool   `mapstructure:"print-issued-lines"`
 	PrintLinterName     bool   `mapstructure:"print-linter-name"`
+	PrintRuleID         bool   `mapstructure:"print-rule-id"`
 	UniqByLine          bool   `mapstructure:"uniq-by-line"`
 	SortResults         bool   `mapstructure:"sort-results"`
 	PrintWelcomeMessage bool   `mapstructure:"print-welcome"`
 	PathPrefix          string `mapstructure:"path-prefix"`
+	ShowFixed           bool   `mapstructure:"show-fixed"`
+	// SortOrder are the keys of the sort of the issues, if SortResults is set: the file and the line by default.
+	SortOrder []string `mapstructure:"sort-order"`
+	// GroupBy groups the issues of the text output, with a summary per group: GroupByFile, GroupByLinter or GroupBySeverity.
+	GroupBy string `mapstructure:"group-by"`
+	// Stream prints the issues of each linter as soon as it finishes, in the streamable formats.
+	// The json-stream format is always streamed.
+	Stream bool `mapstructure:"stream"`
+	// NoProgress disables the live status of the run shown on terminals.
+	NoProgress bool `mapstructure:"no-progress"`
+	// PrintIssuedLinesContext is the count of source lines before and after each issue in the JSON output.
+	PrintIssuedLinesContext int `mapstructure:"print-issued-lines-context"`
+	// JUnitStrict prints only the elements and attributes of the JUnit schema in the junit-xml format.
+	JUnitStrict bool `mapstructure:"junit-strict"`
+	// AnalyticsPath is the file to write the counts of issues per linter and the durations of the run to.
+	AnalyticsPath string `mapstructure:"analytics-path"`
+	// StatsHistory is the file to add the counts of issues of the run to, see the stats command.
+	StatsHistory string `mapstructure:"stats-history"`
+	// ReportFile is the file to write the durations, the memory and the cache usage of the run to.
+	ReportFile string `mapstructure:"report-file"`
+	// PostProcess is the command, run with the shell, replacing the issues before they're printed:
+	// it reads them in the json format from its standard input and prints the issues replacing them.
+	// It's only set on the command-line: a config can't run commands.
+	PostProcess string `mapstructure:"post-process"`
+}
+
+// OutTarget is an output of the run: a format printed to the standard output, the standard error or a file.
+type OutTarget struct {
+	Format string
+	Path   string
+}
+
+// IsFile checks if the output is written to a file.
+func (t OutTarget) IsFile() bool {
+	return t.Path != "" && t.Path != "stdout" && t.Path != "stderr"
+}
+
+// OutTargets parses the comma-separated outputs of the format option: format[:path], the standard output by default.
+func (o *Output) OutTargets() []OutTarget {
+	var targets []OutTarget
+	for _, out := range strings.Split(o.Format, ",") {
+		format, path, _ := strings.Cut(out, ":")
+		targets = append(targets, OutTarget{Format: format, Path: path})
+	}
+	return targets
+}
+
+// IsStreamed checks if the issues are printed in the format as soon as they're found.
+func (o *Output) IsStreamed(format string) bool {
+	if format == OutFormatJSONStream {
+		return true
+	}
+	return o.Stream && sliceutil.Contains(StreamableOutFormats, format)
+}
+
+func (o *Output) Validate() error {
+	if err := o.validateFormat(); err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	for _, key := range o.SortOrder {
+		if !sliceutil.Contains(SortOrders, key) {
+			return fmt.Errorf("invalid sort-order %q: must be one of %s", key, strings.Join(SortOrders, ", "))
+		}
+		if seen[key] {
+			return fmt.Errorf("sort-order %q is duplicated", key)
+		}
+		seen[key] = true
+	}
+
+	if o.PrintIssuedLinesContext < 0 {
+		return fmt.Errorf("print-issued-lines-context must be non-negative: %d", o.PrintIssuedLinesContext)
+	}
+
+	if o.GroupBy != "" && !sliceutil.Contains(GroupBys, o.GroupBy) {
+		return fmt.Errorf("invalid group-by %q: must be one of %s", o.GroupBy, strings.Join(GroupBys, ", "))
+	}
+
+	return nil
+}
+
+// validateFormat checks the outputs before the run: the formats are known, and the files are written by a single format.
+func (o *Output) validateFormat() error {
+	if o.Format == "" {
+		return nil
+	}
+
+	files := map[string]string{}
+	for _, t := range o.OutTargets() {
+		if !sliceutil.Contains(OutFormats, t.Format) {
+			return fmt.Errorf("invalid format %q: must be one of %s", t.Format, strings.Join(OutFormats, ", "))
+		}
+		if !t.IsFile() {
+			continue
+		}
+
+		if format, ok := files[t.Path]; ok {
+			return fmt.Errorf("the formats %s and %s are written to the same file %s", format, t.Format, t.Path)
+		}
+		files[t.Path] = t.Format
+	}
+
+	return nil
 }
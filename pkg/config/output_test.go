@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutput_IsStreamed(t *testing.T) {
+	o := &Output{}
+	assert.True(t, o.IsStreamed(OutFormatJSONStream))
+	assert.False(t, o.IsStreamed(OutFormatLineNumber))
+
+	o.Stream = true
+	assert.True(t, o.IsStreamed(OutFormatLineNumber))
+	assert.True(t, o.IsStreamed(OutFormatTeamCity))
+	assert.False(t, o.IsStreamed(OutFormatJSON))
+	assert.False(t, o.IsStreamed(OutFormatCheckstyle))
+}
+
+func TestOutput_OutTargets(t *testing.T) {
+	o := &Output{Format: "colored-line-number:stdout,json:report.json,tab,html:C:\\report.html"}
+
+	assert.Equal(t, []OutTarget{
+		{Format: OutFormatColoredLineNumber, Path: "stdout"},
+		{Format: OutFormatJSON, Path: "report.json"},
+		{Format: OutFormatTab},
+		{Format: OutFormatHTML, Path: `C:\report.html`},
+	}, o.OutTargets())
+}
+
+func TestOutput_Validate(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		output Output
+		err    string
+	}{
+		{desc: "empty"},
+		{desc: "valid", output: Output{SortOrder: []string{SortOrderSeverity, SortOrderFile}, GroupBy: GroupByLinter}},
+		{desc: "invalid sort key", output: Output{SortOrder: []string{"column"}}, err: `invalid sort-order "column"`},
+		{desc: "duplicated sort key", output: Output{SortOrder: []string{SortOrderLine, SortOrderLine}}, err: `sort-order "line" is duplicated`},
+		{desc: "invalid group", output: Output{GroupBy: "package"}, err: `invalid group-by "package"`},
+		{desc: "multiple formats", output: Output{Format: "colored-line-number:stdout,json:report.json,sarif:report.sarif,tab"}},
+		{desc: "invalid format", output: Output{Format: "line-number,xml:report.xml"}, err: `invalid format "xml"`},
+		{
+			desc:   "same file",
+			output: Output{Format: "json:report.json,checkstyle:report.json"},
+			err:    "the formats json and checkstyle are written to the same file report.json",
+		},
+		{desc: "same standard output", output: Output{Format: "line-number,github-actions:stdout"}},
+		{desc: "negative context", output: Output{PrintIssuedLinesContext: -1}, err: "print-issued-lines-context must be non-negative"},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := test.output.Validate()
+			if test.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
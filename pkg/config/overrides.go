@@ -0,0 +1,141 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Override changes the enabled linters and the settings of the linters for the files matching Path.
+// Overrides are applied in order: the last matching override wins.
+type Override struct {
+	Path    string
+	Linters OverrideLinters
+	// LintersSettings are the options of the settings of the linters changed for the files, by linter.
+	LintersSettings map[string]interface{} `mapstructure:"linters-settings"`
+}
+
+type OverrideLinters struct {
+	Enable     []string
+	Disable    []string
+	DisableAll bool `mapstructure:"disable-all"`
+
+	// Presets and EnableAll aren't supported: they are rejected instead of being ignored.
+	Presets   []string
+	EnableAll bool `mapstructure:"enable-all"`
+}
+
+func (o OverrideLinters) Validate() error {
+	if len(o.Presets) != 0 {
+		return errors.New("presets aren't supported: enable the linters of the presets")
+	}
+	if o.EnableAll {
+		return errors.New("enable-all isn't supported: enable the linters")
+	}
+	return nil
+}
+
+// TestsOverridePath matches the test files: the path of the override of `run.tests-linters`.
+const TestsOverridePath = `_test\.go$`
+
+// TestsLinters are the linters enabled or disabled for the test files, and the severity of their issues.
+type TestsLinters struct {
+	OverrideLinters `mapstructure:",squash"`
+
+	// Severity of the issues of the test files not matched by the severity rules.
+	Severity string
+}
+
+// AllOverrides returns the override of the test files then the explicit overrides, which have priority.
+func (c *Config) AllOverrides() []Override {
+	t := c.Run.TestsLinters.OverrideLinters
+	if len(t.Enable) == 0 && len(t.Disable) == 0 && !t.DisableAll {
+		return c.Overrides
+	}
+
+	return append([]Override{{Path: TestsOverridePath, Linters: t}}, c.Overrides...)
+}
+
+func (o Override) Validate() error {
+	if o.Path == "" {
+		return errors.New("path should be set")
+	}
+	if err := validateOptionalRegex(o.Path); err != nil {
+		return fmt.Errorf("invalid path regex: %v", err)
+	}
+	return o.validateLinters()
+}
+
+// validateLinters validates the linters and the settings of the linters of the override.
+func (o Override) validateLinters() error {
+	for _, name := range o.SettingsLinters() {
+		if _, ok := structFieldByKey(reflect.ValueOf(&LintersSettings{}).Elem(), name); !ok {
+			return fmt.Errorf("error in linters-settings: unknown linter %q", name)
+		}
+		if _, err := (LintersSettings{}).WithOverrideSettings(name, []Override{o}); err != nil {
+			return fmt.Errorf("error in linters-settings: %v", err)
+		}
+	}
+	if err := o.Linters.Validate(); err != nil {
+		return fmt.Errorf("error in linters: %v", err)
+	}
+	return nil
+}
+
+// SettingsLinters returns the sorted names of the linters whose settings are changed by the override.
+func (o Override) SettingsLinters() []string {
+	names := make([]string, 0, len(o.LintersSettings))
+	for name := range o.LintersSettings {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithOverrideSettings returns the settings with the options of the settings of the linter changed by the overrides,
+// applied in order: the settings of the original value are unchanged.
+func (s LintersSettings) WithOverrideSettings(linter string, overrides []Override) (LintersSettings, error) {
+	v := reflect.ValueOf(&s).Elem()
+	for _, o := range overrides {
+		for name, value := range o.LintersSettings {
+			if !strings.EqualFold(name, linter) {
+				continue
+			}
+
+			err := walkOptions(value, []string{linter}, func(keys []string, value interface{}) error {
+				if err := setOption(v, keys, value); err != nil {
+					return fmt.Errorf("invalid option %s: %w", strings.Join(keys, "."), err)
+				}
+				return nil
+			})
+			if err != nil {
+				return s, err
+			}
+		}
+	}
+	return s, nil
+}
+
+// walkOptions calls fn with the keys and the value of each option of the section, in the order of the keys:
+// the values which aren't sections, like the lists, are options.
+func walkOptions(value interface{}, keys []string, fn func(keys []string, value interface{}) error) error {
+	section, ok := value.(map[string]interface{})
+	if !ok {
+		return fn(keys, value)
+	}
+
+	names := make([]string, 0, len(section))
+	for name := range section {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := walkOptions(section[name], append(keys[:len(keys):len(keys)], name), fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_AllOverrides(t *testing.T) {
+	explicit := Override{Path: `^internal/`, Linters: OverrideLinters{Enable: []string{"gosec"}}}
+
+	cfg := &Config{Overrides: []Override{explicit}}
+	assert.Equal(t, []Override{explicit}, cfg.AllOverrides())
+
+	cfg.Run.TestsLinters = TestsLinters{
+		OverrideLinters: OverrideLinters{Disable: []string{"errcheck"}},
+		Severity:        "info",
+	}
+	assert.Equal(t, []Override{
+		{Path: TestsOverridePath, Linters: OverrideLinters{Disable: []string{"errcheck"}}},
+		explicit,
+	}, cfg.AllOverrides())
+}
+
+func TestLintersSettings_WithOverrideSettings(t *testing.T) {
+	settings := LintersSettings{
+		Errcheck: ErrcheckSettings{ExcludeFunctions: []string{"fmt.Print"}},
+		Govet: GovetSettings{
+			Settings: map[string]map[string]interface{}{
+				"shadow": {"strict": true},
+			},
+		},
+	}
+
+	overrides := []Override{
+		{Path: `^internal/`, LintersSettings: map[string]interface{}{
+			"errcheck": map[string]interface{}{"check-blank": true, "exclude-functions": []interface{}{"io.Copy"}},
+			"govet":    map[string]interface{}{"settings": map[string]interface{}{"printf": map[string]interface{}{"funcs": []interface{}{"Logf"}}}},
+		}},
+		{Path: `^internal/legacy/`, LintersSettings: map[string]interface{}{
+			"errcheck": map[string]interface{}{"check-blank": false},
+		}},
+	}
+
+	changed, err := settings.WithOverrideSettings("errcheck", overrides)
+	require.NoError(t, err)
+	assert.False(t, changed.Errcheck.CheckAssignToBlank, "the last override wins")
+	assert.Equal(t, []string{"io.Copy"}, changed.Errcheck.ExcludeFunctions)
+	assert.Len(t, changed.Govet.Settings, 1, "only the settings of the linter")
+
+	changed, err = settings.WithOverrideSettings("govet", overrides[:1])
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]interface{}{
+		"shadow": {"strict": true},
+		"printf": {"funcs": []interface{}{"Logf"}},
+	}, changed.Govet.Settings)
+
+	// The original settings are unchanged.
+	assert.Equal(t, []string{"fmt.Print"}, settings.Errcheck.ExcludeFunctions)
+	assert.Len(t, settings.Govet.Settings, 1)
+
+	_, err = settings.WithOverrideSettings("errcheck", []Override{
+		{Path: `^a/`, LintersSettings: map[string]interface{}{"errcheck": map[string]interface{}{"check-blnk": true}}},
+	})
+	assert.EqualError(t, err, "invalid option errcheck.check-blnk: unknown option")
+}
+
+func TestOverride_Validate(t *testing.T) {
+	assert.NoError(t, Override{Path: `^a/`, LintersSettings: map[string]interface{}{
+		"dogsled": map[string]interface{}{"max-blank-identifiers": 3},
+	}}.Validate())
+	assert.Error(t, Override{Path: `^a/`, LintersSettings: map[string]interface{}{
+		"dogsled": map[string]interface{}{"max-blank-identifiers": "many"},
+	}}.Validate())
+	assert.Error(t, Override{Path: `^a/`, LintersSettings: map[string]interface{}{"unknown": map[string]interface{}{}}}.Validate())
+	assert.Error(t, Override{Path: `^a/`, Linters: OverrideLinters{Presets: []string{"bugs"}}}.Validate())
+	assert.Error(t, Override{Path: `^a/`, Linters: OverrideLinters{EnableAll: true}}.Validate())
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Policy is the standard enforced by a central config, e.g. a parent config of `extends`:
+// its violations fail the run regardless of the issues found.
+type Policy struct {
+	// RequiredLinters can't be disabled, made warn-only or disabled for some files by the overrides.
+	RequiredLinters []string `mapstructure:"required-linters"`
+	// ForbiddenExcludes are regular expressions matched against the exclude patterns of the config:
+	// issues.exclude, the path, text and source of issues.exclude-rules, run.skip-files and run.skip-dirs.
+	ForbiddenExcludes []string `mapstructure:"forbidden-excludes"`
+	// NolintRequireReason are the linters whose issues are suppressed only by the //nolint directives with a reason.
+	NolintRequireReason []string `mapstructure:"nolint-require-reason"`
+}
+
+// IsEmpty checks if the policy enforces nothing.
+func (p *Policy) IsEmpty() bool {
+	return len(p.RequiredLinters) == 0 && len(p.ForbiddenExcludes) == 0 && len(p.NolintRequireReason) == 0
+}
+
+func (p *Policy) Validate() error {
+	for _, pattern := range p.ForbiddenExcludes {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid forbidden-excludes regex %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package config
+
+// Presets are the presets of linters defined in the config, in addition to the built-in presets.
+type Presets struct {
+	Custom map[string]CustomPreset
+}
+
+// CustomPreset is a named group of linters enabled with `linters.presets` or `--presets`:
+// it includes the linters of the built-in or custom presets it extends.
+type CustomPreset struct {
+	Extends []string
+	Linters []string
+}
@@ -3,15 +3,19 @@ package config
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/viper"
 
 	"github.com/golangci/golangci-lint/pkg/exitcodes"
 	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/goutil"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/sliceutil"
 )
@@ -20,6 +24,7 @@ type FileReader struct {
 	log            logutils.Log
 	cfg            *Config
 	commandLineCfg *Config
+	v              *viper.Viper
 }
 
 func NewFileReader(toCfg, commandLineCfg *Config, log logutils.Log) *FileReader {
@@ -27,9 +32,17 @@ func NewFileReader(toCfg, commandLineCfg *Config, log logutils.Log) *FileReader
 		log:            log,
 		cfg:            toCfg,
 		commandLineCfg: commandLineCfg,
+		v:              viper.GetViper(),
 	}
 }
 
+// WithViper reads the config with the viper instance instead of the global one:
+// the global one is used by the commands reading the options of the config file after the reading.
+func (r *FileReader) WithViper(v *viper.Viper) *FileReader {
+	r.v = v
+	return r
+}
+
 func (r *FileReader) Read() error {
 	// XXX: hack with double parsing for 2 purposes:
 	// 1. to access "config" option here.
@@ -45,21 +58,25 @@ func (r *FileReader) Read() error {
 	}
 
 	if configFile != "" {
-		viper.SetConfigFile(configFile)
+		r.v.SetConfigFile(configFile)
 
 		// Assume YAML if the file has no extension.
 		if filepath.Ext(configFile) == "" {
-			viper.SetConfigType("yaml")
+			r.v.SetConfigType("yaml")
 		}
 	} else {
 		r.setupConfigFileSearch()
 	}
 
-	return r.parseConfig()
+	if err := r.parseConfig(); err != nil {
+		return err
+	}
+
+	return r.readWorkspaceConfigs()
 }
 
 func (r *FileReader) parseConfig() error {
-	if err := viper.ReadInConfig(); err != nil {
+	if err := r.v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
 			return nil
 		}
@@ -67,7 +84,7 @@ func (r *FileReader) parseConfig() error {
 		return fmt.Errorf("can't read viper config: %s", err)
 	}
 
-	usedConfigFile := viper.ConfigFileUsed()
+	usedConfigFile := r.v.ConfigFileUsed()
 	if usedConfigFile == "" {
 		return nil
 	}
@@ -83,10 +100,34 @@ func (r *FileReader) parseConfig() error {
 	}
 	r.cfg.cfgDir = usedConfigDir
 
-	if err := viper.Unmarshal(r.cfg); err != nil {
+	if err := r.readExtends(); err != nil {
+		return fmt.Errorf("error in extends: %s", err)
+	}
+
+	r.convertDeprecatedSettings()
+	// The converted options are in the override layer of viper: all the layers are needed.
+	lintersSettings, _ := r.v.AllSettings()["linters-settings"].(map[string]interface{})
+	for _, key := range NewSchema().UnknownLintersSettings(lintersSettings) {
+		logutils.WarnEvent(r.log, "config_option_unknown", logutils.Fields{"option": key}, "Unknown option %s: it's ignored", key)
+	}
+
+	if err := r.v.Unmarshal(r.cfg); err != nil {
 		return fmt.Errorf("can't unmarshal config by viper: %s", err)
 	}
 
+	if err := r.parseLinterTimeouts(); err != nil {
+		return err
+	}
+
+	if r.cfg.Run.NestedConfigs {
+		nested, err := r.readNestedConfigs(usedConfigDir)
+		if err != nil {
+			return fmt.Errorf("can't read nested configs: %s", err)
+		}
+		// Explicit overrides have priority over nested configs.
+		r.cfg.Overrides = append(nested, r.cfg.Overrides...)
+	}
+
 	if err := r.validateConfig(); err != nil {
 		return fmt.Errorf("can't validate config: %s", err)
 	}
@@ -99,6 +140,66 @@ func (r *FileReader) parseConfig() error {
 	return nil
 }
 
+// convertDeprecatedSettings warns about the deprecated options of the settings of the linters,
+// and converts them to their replacements.
+func (r *FileReader) convertDeprecatedSettings() {
+	get := func(key string) (interface{}, bool) {
+		if !r.v.IsSet(key) {
+			return nil, false
+		}
+		return r.v.Get(key), true
+	}
+
+	// The replacements are read before the conversion.
+	var overridden []SettingDeprecation
+	for _, d := range settingDeprecations {
+		if d.Overrides && r.v.IsSet("linters-settings."+d.Option) && r.v.IsSet(d.Replacement) {
+			overridden = append(overridden, d)
+		}
+	}
+
+	for _, d := range convertDeprecatedSettings(get, r.v.Set) {
+		logutils.WarnEvent(r.log, "config_option_deprecated",
+			logutils.Fields{"option": "linters-settings." + d.Option, "replacement": d.Replacement}, "%s", d)
+	}
+
+	for _, d := range overridden {
+		r.log.Warnf("The options linters-settings.%s and %s are both set: linters-settings.%s has priority",
+			d.Option, d.Replacement, d.Option)
+	}
+}
+
+// parseLinterTimeouts reads the `linters-settings.<linter>.timeout` keys,
+// they aren't fields of the settings of the linters.
+func (r *FileReader) parseLinterTimeouts() error {
+	for _, key := range r.v.AllKeys() {
+		parts := strings.Split(key, ".")
+		if len(parts) != 3 || parts[0] != "linters-settings" || parts[2] != "timeout" {
+			continue
+		}
+
+		raw, ok := r.v.Get(key).(string)
+		if !ok {
+			return fmt.Errorf("error in %s: the timeout must be a duration, e.g. 2m", key)
+		}
+
+		timeout, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("error in %s: %v", key, err)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("error in %s: the timeout must be positive", key)
+		}
+
+		if r.cfg.LintersSettings.Timeouts == nil {
+			r.cfg.LintersSettings.Timeouts = map[string]time.Duration{}
+		}
+		r.cfg.LintersSettings.Timeouts[parts[1]] = timeout
+	}
+
+	return nil
+}
+
 func (r *FileReader) validateConfig() error {
 	c := r.cfg
 	if len(c.Run.Args) != 0 {
@@ -117,14 +218,105 @@ func (r *FileReader) validateConfig() error {
 		return errors.New("option run.tracepath in config isn't allowed")
 	}
 
+	if c.Run.ProfileDir != "" {
+		return errors.New("option run.profiledir in config isn't allowed")
+	}
+
+	if c.Run.CanaryConfig != "" {
+		return errors.New("option run.canaryconfig in config isn't allowed")
+	}
+
+	if c.Run.Interactive {
+		return errors.New("option run.interactive in config isn't allowed")
+	}
+
+	if c.Run.SuppressNew || c.Run.SuppressReason != "" {
+		return errors.New("option run.suppressnew in config isn't allowed")
+	}
+
+	if c.Run.Shard != "" {
+		return errors.New("option run.shard in config isn't allowed")
+	}
+
 	if c.Run.IsVerbose {
 		return errors.New("can't set run.verbose option with config: only on command-line")
 	}
+	if c.Run.LogLevel != "" || len(c.Run.LogDebug) != 0 {
+		// The logs are set up before the config file is read.
+		return errors.New("can't set run.log-level and run.log-debug options with config: only on command-line")
+	}
+	if c.Output.PostProcess != "" {
+		// The command would be run by linting a repository with its config, e.g. the branch of a pull request.
+		return errors.New("can't set output.post-process option with config: only on command-line")
+	}
+	if err := c.Run.ExitCodeMap.Validate(); err != nil {
+		return fmt.Errorf("error in run exit-code-map config: %v", err)
+	}
+	if err := c.Run.AnalyzerQuotas.Validate(); err != nil {
+		return fmt.Errorf("error in run analyzer-quotas config: %v", err)
+	}
+	if c.Run.MaxMemory < 0 {
+		return errors.New("option run.max-memory must be non-negative")
+	}
+	if err := c.Typecheck.Validate(); err != nil {
+		return fmt.Errorf("error in typecheck config: %v", err)
+	}
+	if err := c.Service.Validate(c.LintersSettings.Custom); err != nil {
+		return fmt.Errorf("error in service config: %v", err)
+	}
+	if c.Run.GoVersion != "" {
+		if _, err := ParseVersionConstraint(c.Run.GoVersion); err != nil {
+			return fmt.Errorf("invalid run.go-version: %v", err)
+		}
+	}
 	for i, rule := range c.Issues.ExcludeRules {
 		if err := rule.Validate(); err != nil {
 			return fmt.Errorf("error in exclude rule #%d: %v", i, err)
 		}
 	}
+	for i, rule := range c.Issues.IncludeRules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("error in include rule #%d: %v", i, err)
+		}
+	}
+	for i, scope := range c.Issues.ExcludeScopes {
+		if err := scope.Validate(); err != nil {
+			return fmt.Errorf("error in exclude scope #%d: %v", i, err)
+		}
+	}
+	if err := c.Issues.ValidateExcludeGenerated(); err != nil {
+		return fmt.Errorf("error in issues config: %v", err)
+	}
+	if err := c.Issues.Normalize.Validate(); err != nil {
+		return fmt.Errorf("error in issues normalize config: %v", err)
+	}
+	if err := c.Issues.Dedup.Validate(); err != nil {
+		return fmt.Errorf("error in issues dedup config: %v", err)
+	}
+	if err := c.Issues.Suppressions.Validate(); err != nil {
+		return fmt.Errorf("error in issues suppressions config: %v", err)
+	}
+	if err := c.Output.Validate(); err != nil {
+		return fmt.Errorf("error in output config: %v", err)
+	}
+	for i := range c.Report.Sinks {
+		if err := c.Report.Sinks[i].Validate(); err != nil {
+			return fmt.Errorf("error in report sink #%d: %v", i, err)
+		}
+	}
+	for name, cred := range c.Credentials {
+		if err := cred.Validate(); err != nil {
+			return fmt.Errorf("error in credential %q: %v", name, err)
+		}
+	}
+	for i, o := range c.Overrides {
+		if err := o.Validate(); err != nil {
+			return fmt.Errorf("error in override #%d: %v", i, err)
+		}
+	}
+	if err := c.Run.TestsLinters.Validate(); err != nil {
+		return fmt.Errorf("error in run.tests-linters: %v", err)
+	}
 	if len(c.Severity.Rules) > 0 && c.Severity.Default == "" {
 		return errors.New("can't set severity rule option: no default severity defined")
 	}
@@ -133,12 +325,165 @@ func (r *FileReader) validateConfig() error {
 			return fmt.Errorf("error in severity rule #%d: %v", i, err)
 		}
 	}
+	if err := c.Severity.Validate(); err != nil {
+		return fmt.Errorf("error in severity config: %v", err)
+	}
 	if err := c.LintersSettings.Govet.Validate(); err != nil {
 		return fmt.Errorf("error in govet config: %v", err)
 	}
+	if err := c.Policy.Validate(); err != nil {
+		return fmt.Errorf("error in policy config: %v", err)
+	}
+	return nil
+}
+
+// readNestedConfigs converts the `linters` section of the config files
+// in the subdirectories of root to overrides of these subdirectories.
+// Parent directories are visited first: the deepest config wins.
+func (r *FileReader) readNestedConfigs(root string) ([]Override, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides []Override
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "vendor" || d.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		dir := filepath.Dir(path)
+		if dir == root || (d.Name() != ".golangci.yml" && d.Name() != ".golangci.yaml") {
+			return nil
+		}
+
+		o, err := r.readNestedConfig(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		relDir, err := filepath.Rel(wd, dir)
+		if err != nil {
+			return err
+		}
+		o.Path = "^" + regexp.QuoteMeta(filepath.ToSlash(relDir)) + "/"
+
+		r.log.Infof("Used nested config file %s", path)
+		overrides = append(overrides, *o)
+		return nil
+	})
+
+	return overrides, err
+}
+
+// readWorkspaceConfigs converts the `linters` section of the config files of the modules of the go.work workspace
+// into overrides for their directories, like the nested configs.
+func (r *FileReader) readWorkspaceConfigs() error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	ws, err := goutil.FindWorkspace(wd)
+	if err != nil {
+		r.log.Warnf("Can't read go.work, the configs of its modules aren't used: %s", err)
+		return nil
+	}
+	if ws == nil {
+		return nil
+	}
+
+	modules, err := ws.ModulesIn(wd)
+	if err != nil {
+		return err
+	}
+
+	var overrides []Override
+	for _, relDir := range modules {
+		dir := filepath.Join(wd, relDir)
+
+		if relDir == "." || (r.cfg.Run.NestedConfigs && isInDir(r.cfg.cfgDir, dir)) {
+			continue // the config of the run or an already read nested config
+		}
+
+		path := findModuleConfig(dir)
+		if path == "" || filepath.Dir(path) == r.cfg.cfgDir {
+			continue
+		}
+
+		o, err := r.readNestedConfig(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		o.Path = "^" + regexp.QuoteMeta(filepath.ToSlash(relDir)) + "/"
+
+		r.log.Infof("Used workspace module config file %s", path)
+		overrides = append(overrides, *o)
+	}
+
+	// Explicit overrides have priority over module configs.
+	r.cfg.Overrides = append(overrides, r.cfg.Overrides...)
+
 	return nil
 }
 
+// isInDir checks if the path is the directory or inside it.
+func isInDir(dir, path string) bool {
+	if dir == "" {
+		return false
+	}
+
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func findModuleConfig(dir string) string {
+	for _, name := range []string{".golangci.yml", ".golangci.yaml"} {
+		path := filepath.Join(dir, name)
+		if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+func (r *FileReader) readNestedConfig(path string) (*Override, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	for _, key := range v.AllKeys() {
+		if !strings.HasPrefix(key, "linters.") && !strings.HasPrefix(key, "linters-settings.") {
+			logutils.WarnEvent(r.log, "config_option_ignored", logutils.Fields{"config": path, "option": key},
+				"Nested config %s: only the linters and linters-settings sections are supported, option %s is ignored", path, key)
+		}
+	}
+
+	var nested struct {
+		Linters         OverrideLinters
+		LintersSettings map[string]interface{} `mapstructure:"linters-settings"`
+	}
+	if err := v.Unmarshal(&nested); err != nil {
+		return nil, err
+	}
+
+	o := &Override{Linters: nested.Linters, LintersSettings: nested.LintersSettings}
+	if err := o.validateLinters(); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
 func getFirstPathArg() string {
 	args := os.Args
 
@@ -166,6 +511,9 @@ func getFirstPathArg() string {
 
 func (r *FileReader) setupConfigFileSearch() {
 	firstArg := getFirstPathArg()
+	if r.commandLineCfg != nil && len(r.commandLineCfg.Run.Args) != 0 {
+		firstArg = r.commandLineCfg.Run.Args[0] // the paths of a run without command line
+	}
 	absStartPath, err := filepath.Abs(firstArg)
 	if err != nil {
 		r.log.Warnf("Can't make abs path for %q: %s", firstArg, err)
@@ -200,9 +548,9 @@ func (r *FileReader) setupConfigFileSearch() {
 	}
 
 	r.log.Infof("Config search paths: %s", configSearchPaths)
-	viper.SetConfigName(".golangci")
+	r.v.SetConfigName(".golangci")
 	for _, p := range configSearchPaths {
-		viper.AddConfigPath(p)
+		r.v.AddConfigPath(p)
 	}
 }
 
@@ -0,0 +1,79 @@
+package config
+
+import "time"
+
+// RecipeChangedPaths is the path of a recipe analyzing only the packages affected by the changes
+// since new-from-rev, or the uncommitted changes without it.
+const RecipeChangedPaths = "changed"
+
+// Recipe is a named set of run options invoked as `golangci-lint run <name>`.
+type Recipe struct {
+	Description string
+
+	Enable     []string
+	Disable    []string
+	DisableAll bool `mapstructure:"disable-all"`
+	Fast       bool
+	Presets    []string
+
+	// Paths to analyze when no paths are given on the command line.
+	// The path RecipeChangedPaths analyzes only the packages affected by the changes (see Issues.ChangedOnly).
+	Paths []string
+
+	New              bool
+	DiffFromRevision string `mapstructure:"new-from-rev"`
+
+	Timeout time.Duration
+}
+
+// ApplyRecipe applies the options of the recipe on top of the config
+// and returns the paths to analyze.
+func (c *Config) ApplyRecipe(name string, args []string) []string {
+	r := c.Recipes[name]
+
+	if r.DisableAll {
+		c.Linters.EnableAll = false
+		c.Linters.DisableAll = true
+		c.Linters.Enable = nil
+		c.Linters.Disable = nil
+		c.Linters.Presets = nil
+	}
+	c.Linters.Enable = append(c.Linters.Enable, r.Enable...)
+	c.Linters.Disable = append(c.Linters.Disable, r.Disable...)
+	c.Linters.Presets = append(c.Linters.Presets, r.Presets...)
+	c.Linters.Fast = c.Linters.Fast || r.Fast
+
+	c.Issues.Diff = c.Issues.Diff || r.New
+	if r.DiffFromRevision != "" {
+		c.Issues.DiffFromRevision = r.DiffFromRevision
+	}
+
+	if r.Timeout != 0 {
+		c.Run.Timeout = r.Timeout
+	}
+
+	var paths []string
+	for _, p := range r.Paths {
+		if p != RecipeChangedPaths {
+			paths = append(paths, p)
+			continue
+		}
+
+		c.Issues.ChangedOnly = true
+		if c.Issues.DiffFromRevision == "" {
+			c.Issues.DiffFromRevision = "HEAD"
+		}
+	}
+
+	if len(args) == 0 {
+		return paths
+	}
+
+	return args
+}
+
+// IsRecipe checks if the name is a recipe defined in the config.
+func (c *Config) IsRecipe(name string) bool {
+	_, ok := c.Recipes[name]
+	return ok
+}
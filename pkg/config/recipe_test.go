@@ -0,0 +1,62 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyRecipe(t *testing.T) {
+	cfg := &Config{
+		Linters: Linters{Enable: []string{"gosec"}, EnableAll: true},
+		Run:     Run{Timeout: time.Minute},
+		Recipes: map[string]Recipe{
+			"quick": {
+				DisableAll: true,
+				Enable:     []string{"govet", "errcheck"},
+				Fast:       true,
+				New:        true,
+				Paths:      []string{"./pkg/..."},
+			},
+			"full": {
+				Disable: []string{"lll"},
+				Timeout: 10 * time.Minute,
+			},
+		},
+	}
+
+	assert.True(t, cfg.IsRecipe("quick"))
+	assert.False(t, cfg.IsRecipe("./..."))
+
+	quick := *cfg
+	assert.Equal(t, []string{"./pkg/..."}, quick.ApplyRecipe("quick", nil))
+	assert.Equal(t, Linters{Enable: []string{"govet", "errcheck"}, DisableAll: true, Fast: true}, quick.Linters)
+	assert.True(t, quick.Issues.Diff)
+	assert.Equal(t, time.Minute, quick.Run.Timeout)
+
+	full := *cfg
+	assert.Equal(t, []string{"./cmd/..."}, full.ApplyRecipe("full", []string{"./cmd/..."}))
+	assert.Equal(t, []string{"lll"}, full.Linters.Disable)
+	assert.True(t, full.Linters.EnableAll)
+	assert.Equal(t, 10*time.Minute, full.Run.Timeout)
+}
+
+func TestApplyRecipe_changed(t *testing.T) {
+	cfg := &Config{
+		Recipes: map[string]Recipe{
+			"quick":  {Paths: []string{RecipeChangedPaths}},
+			"review": {DiffFromRevision: "origin/main", Paths: []string{RecipeChangedPaths, "./pkg/..."}},
+		},
+	}
+
+	quick := *cfg
+	assert.Empty(t, quick.ApplyRecipe("quick", nil))
+	assert.True(t, quick.Issues.ChangedOnly)
+	assert.Equal(t, "HEAD", quick.Issues.DiffFromRevision)
+
+	review := *cfg
+	assert.Equal(t, []string{"./pkg/..."}, review.ApplyRecipe("review", nil))
+	assert.True(t, review.Issues.ChangedOnly)
+	assert.Equal(t, "origin/main", review.Issues.DiffFromRevision)
+}
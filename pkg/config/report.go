@@ -0,0 +1,53 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	SinkFormatJSON   = "json"
+	SinkFormatNDJSON = "ndjson"
+)
+
+// Report encapsulates the config options of the issues sent to external systems.
+type Report struct {
+	Sinks []ReportSink `mapstructure:"sinks"`
+}
+
+// ReportSink is an HTTP endpoint receiving the issues of the run with a POST request.
+type ReportSink struct {
+	URL string `mapstructure:"url"`
+	// Format is json (the document of the json output format) or ndjson (an issue by line).
+	Format string `mapstructure:"format"`
+	// Headers added to the request, environment variables and credentials are expanded in values.
+	Headers map[string]string `mapstructure:"headers"`
+	Timeout time.Duration     `mapstructure:"timeout"`
+	// Retries is the count of retries of the failed requests, with an exponential backoff starting at Backoff.
+	Retries int           `mapstructure:"retries"`
+	Backoff time.Duration `mapstructure:"backoff"`
+}
+
+func (s *ReportSink) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("url must be an http or https URL")
+	}
+
+	switch s.Format {
+	case "", SinkFormatJSON, SinkFormatNDJSON:
+	default:
+		return fmt.Errorf("invalid format %q: must be %s or %s", s.Format, SinkFormatJSON, SinkFormatNDJSON)
+	}
+
+	if s.Timeout < 0 || s.Retries < 0 || s.Backoff < 0 {
+		return errors.New("timeout, retries and backoff can't be negative")
+	}
+
+	return nil
+}
@@ -1,29 +1,70 @@
 package config
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Run encapsulates the config options for running the linter analysis.
 type Run struct {
-	IsVerbose           bool `mapstructure:"verbose"`
-	Silent              bool
-	CPUProfilePath      string
-	MemProfilePath      string
-	TracePath           string
-	Concurrency         int
+	IsVerbose      bool   `mapstructure:"verbose"`
+	LogFormat      string `mapstructure:"log-format"`
+	Silent         bool
+	CPUProfilePath string
+	MemProfilePath string
+	TracePath      string
+	// ProfileDir is the directory of the profiling bundle: the profiles, the trace and the timings of the run.
+	ProfileDir  string
+	Concurrency int
+	// LoadConcurrency and AnalysisConcurrency are the counts of the packages loaded (parsed and type-checked)
+	// and analyzed in parallel by the go/analysis linters, Concurrency if 0: the loading overlaps the analysis.
+	LoadConcurrency     int `mapstructure:"load-concurrency"`
+	AnalysisConcurrency int `mapstructure:"analysis-concurrency"`
+	// FastLintersFirst runs the fast linters before the other ones: their issues are streamed earlier.
+	FastLintersFirst    bool `mapstructure:"fast-linters-first"`
+	AutoTune            bool `mapstructure:"auto-tune"`
 	PrintResourcesUsage bool `mapstructure:"print-resources-usage"`
 
 	Config   string // The path to the golangci config file, as specified with the --config argument.
 	NoConfig bool
+	// CanaryConfig is the path to a proposed config: only the difference of its issues is reported.
+	CanaryConfig string
+	// Interactive opens the triage of the issues in the terminal instead of printing them.
+	Interactive bool
+	// SuppressNew inserts the //nolint directives of the issues instead of printing them,
+	// explained by the SuppressReason template.
+	SuppressNew    bool
+	SuppressReason string
 
 	Args []string
 
+	// LogLevel is the minimum level of the logs, LogDebug are the tags of the enabled debug logs, like GL_DEBUG.
+	LogLevel string   `mapstructure:"log-level"`
+	LogDebug []string `mapstructure:"log-debug"`
+
+	// Stdin lints the standard input as the content of the file StdinFilename, e.g. an unsaved buffer of an editor.
+	Stdin         bool
+	StdinFilename string `mapstructure:"stdin-filename"`
+
 	Go string `mapstructure:"go"`
+	// GoVersion is the constraint of the version of the Go toolchain loading the packages, e.g. ">=1.21",
+	// checked before the run: Go is the version of the language targeted by the linters.
+	GoVersion string `mapstructure:"go-version"`
 
-	BuildTags           []string `mapstructure:"build-tags"`
-	ModulesDownloadMode string   `mapstructure:"modules-download-mode"`
+	BuildTags []string `mapstructure:"build-tags"`
+	// BuildTagSets are the variants of build tags analyzed by the run, each in addition to BuildTags:
+	// the packages are loaded and analyzed once per set.
+	BuildTagSets        [][]string `mapstructure:"build-tag-sets"`
+	ModulesDownloadMode string     `mapstructure:"modules-download-mode"`
 
-	ExitCodeIfIssuesFound int  `mapstructure:"issues-exit-code"`
-	AnalyzeTests          bool `mapstructure:"tests"`
+	ExitCodeIfIssuesFound int `mapstructure:"issues-exit-code"`
+	// ExitCodeMap maps the kinds of failures of the run to exit codes, e.g. to tell the type errors from the issues.
+	ExitCodeMap  ExitCodeMap `mapstructure:"exit-code-map"`
+	AnalyzeTests bool        `mapstructure:"tests"`
 
 	// Deprecated: Deadline exists for historical compatibility
 	// and should not be used. To set run timeout use Timeout instead.
@@ -37,4 +78,124 @@ type Run struct {
 
 	AllowParallelRunners bool `mapstructure:"allow-parallel-runners"`
 	AllowSerialRunners   bool `mapstructure:"allow-serial-runners"`
+
+	AutoAdopt bool `mapstructure:"auto-adopt"`
+
+	// ReverseDeps adds to the packages of the arguments the packages of their modules importing them.
+	ReverseDeps bool
+
+	// Shard is the slice i/n of the packages analyzed by the run, to distribute the analysis over several runs.
+	Shard string
+
+	// Strict fails the run when a linter panics, instead of reporting the panic as a warning of the run.
+	Strict bool `mapstructure:"strict"`
+
+	NestedConfigs bool `mapstructure:"nested-configs"`
+
+	// TestsLinters changes the enabled linters and the severity of the issues of the _test.go files.
+	TestsLinters TestsLinters `mapstructure:"tests-linters"`
+
+	AnalyzerQuotas AnalyzerQuotas `mapstructure:"analyzer-quotas"`
+
+	// MaxMemory is the memory budget in MiB: once exceeded, the remaining linters run one at a time.
+	MaxMemory int `mapstructure:"max-memory"`
+}
+
+// ExitCodeMap maps the kinds of failures of the run to exit codes: 0 keeps the default exit code of the kind.
+// The exit code of the most severe kind is used: linter-failure, typecheck, error, then warning.
+type ExitCodeMap struct {
+	// Error is the exit code of the failing issues if one of them has the error severity (or an unknown one).
+	Error int `mapstructure:"error"`
+	// Warning is the exit code of the failing issues if none of them has the error severity.
+	Warning int `mapstructure:"warning"`
+	// Typecheck is the exit code of the type errors: the typecheck issues, or the run stopped by them.
+	Typecheck int `mapstructure:"typecheck"`
+	// LinterFailure is the exit code of a linter which couldn't run or panicked.
+	LinterFailure int `mapstructure:"linter-failure"`
+}
+
+func (m *ExitCodeMap) Validate() error {
+	codes := map[string]int{"error": m.Error, "warning": m.Warning, "typecheck": m.Typecheck, "linter-failure": m.LinterFailure}
+	for _, kind := range []string{"error", "warning", "typecheck", "linter-failure"} {
+		if code := codes[kind]; code < 0 || code > 255 {
+			return fmt.Errorf("%s: exit code %d must be between 0 and 255", kind, code)
+		}
+	}
+	return nil
+}
+
+// IssuesExitCode returns the exit code of the failing issues, defaultCode if their kind isn't mapped.
+func (m *ExitCodeMap) IssuesExitCode(defaultCode int, hasTypecheck, hasError bool) int {
+	switch {
+	case hasTypecheck && m.Typecheck != 0:
+		return m.Typecheck
+	case hasError && m.Error != 0:
+		return m.Error
+	case !hasError && m.Warning != 0:
+		return m.Warning
+	default:
+		return defaultCode
+	}
+}
+
+// AnalyzerQuotas limit the run of each analyzer on each package:
+// on a breach the analyzer is skipped for the package with a warning.
+type AnalyzerQuotas struct {
+	Time time.Duration `mapstructure:"time"`
+	// AllocMB is the limit of the allocated memory in MiB.
+	AllocMB int `mapstructure:"alloc-mb"`
+}
+
+func (q *AnalyzerQuotas) Validate() error {
+	if q.Time < 0 {
+		return errors.New("time must be non-negative")
+	}
+	if q.AllocMB < 0 {
+		return errors.New("alloc-mb must be non-negative")
+	}
+	return nil
+}
+
+// IsSet checks if a quota is set.
+func (q *AnalyzerQuotas) IsSet() bool {
+	return q.Time > 0 || q.AllocMB > 0
+}
+
+// Shard is the slice Index (from 1) of Count of the packages analyzed by a run.
+type Shard struct {
+	Index, Count int
+}
+
+// ParseShard parses the shard i/n of the --shard option.
+func ParseShard(s string) (Shard, error) {
+	index, count, ok := strings.Cut(s, "/")
+	if !ok {
+		return Shard{}, fmt.Errorf("invalid shard %q: must be i/n", s)
+	}
+
+	var shard Shard
+	var err error
+	if shard.Index, err = strconv.Atoi(strings.TrimSpace(index)); err != nil {
+		return Shard{}, fmt.Errorf("invalid shard %q: must be i/n", s)
+	}
+	if shard.Count, err = strconv.Atoi(strings.TrimSpace(count)); err != nil {
+		return Shard{}, fmt.Errorf("invalid shard %q: must be i/n", s)
+	}
+	if shard.Count < 1 || shard.Index < 1 || shard.Index > shard.Count {
+		return Shard{}, fmt.Errorf("invalid shard %q: i must be between 1 and n", s)
+	}
+
+	return shard, nil
+}
+
+// Contains checks if the package is in the shard. The packages are distributed by the hash of their path:
+// the shard of a package doesn't change when other packages are added, and the test packages are in the shard of their package.
+func (s Shard) Contains(pkgPath string) bool {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strings.TrimSuffix(pkgPath, "_test")))
+	return int(h.Sum32()%uint32(s.Count)) == s.Index-1
+}
+
+func (s Shard) String() string {
+	return fmt.Sprintf("%d/%d", s.Index, s.Count)
 }
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseShard(t *testing.T) {
+	shard, err := ParseShard("2/4")
+	require.NoError(t, err)
+	assert.Equal(t, Shard{Index: 2, Count: 4}, shard)
+	assert.Equal(t, "2/4", shard.String())
+
+	for _, s := range []string{"", "2", "a/4", "2/b", "0/4", "5/4", "1/0"} {
+		_, err = ParseShard(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestShard_Contains(t *testing.T) {
+	const count = 3
+
+	inShards := map[int]int{}
+	for i := 0; i < 100; i++ {
+		pkgPath := fmt.Sprintf("example.com/p%d", i)
+
+		var found []int
+		for index := 1; index <= count; index++ {
+			shard := Shard{Index: index, Count: count}
+			if shard.Contains(pkgPath) {
+				found = append(found, index)
+			}
+			assert.Equal(t, shard.Contains(pkgPath), shard.Contains(pkgPath+"_test"), pkgPath)
+		}
+
+		require.Len(t, found, 1, pkgPath)
+		inShards[found[0]]++
+	}
+
+	assert.Len(t, inShards, count)
+}
+
+func TestExitCodeMap_IssuesExitCode(t *testing.T) {
+	m := ExitCodeMap{Error: 10, Warning: 11, Typecheck: 12}
+
+	assert.Equal(t, 12, m.IssuesExitCode(1, true, true))
+	assert.Equal(t, 10, m.IssuesExitCode(1, false, true))
+	assert.Equal(t, 11, m.IssuesExitCode(1, false, false))
+
+	m = ExitCodeMap{Warning: 11}
+	assert.Equal(t, 1, m.IssuesExitCode(1, true, true), "not mapped")
+	assert.Equal(t, 11, m.IssuesExitCode(1, true, false), "typecheck not mapped")
+}
+
+func TestExitCodeMap_Validate(t *testing.T) {
+	assert.NoError(t, (&ExitCodeMap{Error: 1, Warning: 255}).Validate())
+	assert.EqualError(t, (&ExitCodeMap{Typecheck: 256}).Validate(), "typecheck: exit code 256 must be between 0 and 255")
+	assert.Error(t, (&ExitCodeMap{LinterFailure: -1}).Validate())
+}
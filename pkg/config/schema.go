@@ -0,0 +1,338 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const schemaDraft = "http://json-schema.org/draft-07/schema#"
+
+const (
+	schemaTypeObject  = "object"
+	schemaTypeArray   = "array"
+	schemaTypeString  = "string"
+	schemaTypeBoolean = "boolean"
+	schemaTypeInteger = "integer"
+	schemaTypeNumber  = "number"
+
+	schemaFormatDuration = "duration"
+)
+
+// SchemaTypes is the type of a JSON Schema: a single type is marshaled as a string.
+type SchemaTypes []string
+
+func (t SchemaTypes) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+func (t SchemaTypes) has(typ string) bool {
+	for _, v := range t {
+		if v == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// Schema is the subset of JSON Schema describing the configuration.
+type Schema struct {
+	Schema     string             `json:"$schema,omitempty"`
+	Type       SchemaTypes        `json:"type,omitempty"`
+	Format     string             `json:"format,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// AdditionalProperties is false for structs and the schema of the values for maps.
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+	Items                *Schema     `json:"items,omitempty"`
+}
+
+// NewSchema generates the schema of the configuration from the `mapstructure` tags of the Config struct.
+func NewSchema() *Schema {
+	g := schemaGenerator{visiting: map[reflect.Type]bool{}}
+
+	s := g.schemaOf(reflect.TypeOf(Config{}))
+	s.Schema = schemaDraft
+
+	// The settings of all the linters accept a timeout, see LintersSettings.Timeouts.
+	for _, settings := range s.Properties["linters-settings"].Properties {
+		if settings.Properties != nil {
+			settings.Properties["timeout"] = g.schemaOf(reflect.TypeOf(time.Duration(0)))
+		}
+	}
+
+	return s
+}
+
+type schemaGenerator struct {
+	visiting map[reflect.Type]bool
+}
+
+func (g schemaGenerator) schemaOf(t reflect.Type) *Schema {
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return &Schema{Type: SchemaTypes{schemaTypeString, schemaTypeInteger}, Format: schemaFormatDuration}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.schemaOf(t.Elem())
+	case reflect.Struct:
+		if g.visiting[t] {
+			// Recursive type: the nested values are not checked.
+			return &Schema{Type: SchemaTypes{schemaTypeObject}}
+		}
+		g.visiting[t] = true
+		defer delete(g.visiting, t)
+
+		s := &Schema{Type: SchemaTypes{schemaTypeObject}, Properties: map[string]*Schema{}, AdditionalProperties: false}
+		g.addStructProperties(s, t)
+		return s
+	case reflect.Map:
+		return &Schema{Type: SchemaTypes{schemaTypeObject}, AdditionalProperties: g.schemaOf(t.Elem())}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: SchemaTypes{schemaTypeArray}, Items: g.schemaOf(t.Elem())}
+	case reflect.String:
+		return &Schema{Type: SchemaTypes{schemaTypeString}}
+	case reflect.Bool:
+		return &Schema{Type: SchemaTypes{schemaTypeBoolean}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: SchemaTypes{schemaTypeInteger}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: SchemaTypes{schemaTypeNumber}}
+	default:
+		// interface{}: any value.
+		return &Schema{}
+	}
+}
+
+func (g schemaGenerator) addStructProperties(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+
+		name, opts := parseMapstructureTag(f)
+		if name == "-" {
+			continue
+		}
+
+		if opts == "squash" {
+			g.addStructProperties(s, f.Type)
+			continue
+		}
+
+		s.Properties[name] = g.schemaOf(f.Type)
+	}
+}
+
+// parseMapstructureTag returns the key of the field, as matched by viper: case-insensitive.
+func parseMapstructureTag(f reflect.StructField) (name, opts string) {
+	tag := f.Tag.Get("mapstructure")
+	name, opts, _ = strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+
+	return strings.ToLower(name), opts
+}
+
+// SchemaError is a mismatch between the config file and the schema.
+type SchemaError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e SchemaError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+// ValidateYAML checks a YAML (or JSON) config file against the schema.
+// isLinterDisabled reports the settings of linters which are not enabled, it can be nil.
+func (s *Schema) ValidateYAML(data []byte, isLinterDisabled func(name string) bool) ([]SchemaError, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, nil // empty file
+	}
+
+	v := schemaValidator{isLinterDisabled: isLinterDisabled}
+	v.validate(s, doc.Content[0], "")
+
+	sort.SliceStable(v.errors, func(i, j int) bool {
+		if v.errors[i].Line != v.errors[j].Line {
+			return v.errors[i].Line < v.errors[j].Line
+		}
+		return v.errors[i].Column < v.errors[j].Column
+	})
+
+	return v.errors, nil
+}
+
+type schemaValidator struct {
+	isLinterDisabled func(name string) bool
+	errors           []SchemaError
+}
+
+func (v *schemaValidator) addError(node *yaml.Node, format string, args ...interface{}) {
+	v.errors = append(v.errors, SchemaError{Line: node.Line, Column: node.Column, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *schemaValidator) validate(s *Schema, node *yaml.Node, path string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	if len(s.Type) == 0 || node.Tag == "!!null" {
+		return
+	}
+
+	switch {
+	case s.Type.has(schemaTypeObject):
+		v.validateObject(s, node, path)
+	case s.Type.has(schemaTypeArray):
+		v.validateArray(s, node, path)
+	default:
+		v.validateScalar(s, node, path)
+	}
+}
+
+func (v *schemaValidator) validateObject(s *Schema, node *yaml.Node, path string) {
+	if _, isMap := s.AdditionalProperties.(*Schema); isMap && node.Kind == yaml.SequenceNode {
+		// A sequence of mappings is merged into a map.
+		for _, item := range node.Content {
+			v.validateObject(s, item, path)
+		}
+		return
+	}
+
+	if node.Kind != yaml.MappingNode {
+		v.addError(node, "%s: expected a mapping", displayPath(path))
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		if keyNode.Value == "<<" {
+			continue // merge key
+		}
+
+		key := strings.ToLower(keyNode.Value)
+		keyPath := joinPath(path, keyNode.Value)
+
+		if path == "linters-settings" && v.isLinterDisabled != nil && v.isLinterDisabled(key) {
+			v.addError(keyNode, "%s: settings of the linter %s which is not enabled", keyPath, keyNode.Value)
+		}
+
+		if prop, ok := s.Properties[key]; ok {
+			v.validate(prop, valueNode, keyPath)
+			continue
+		}
+
+		switch additional := s.AdditionalProperties.(type) {
+		case *Schema:
+			v.validate(additional, valueNode, keyPath)
+		case bool:
+			if !additional {
+				v.addUnknownKeyError(keyNode, valueNode, keyPath)
+			}
+		}
+	}
+}
+
+// addUnknownKeyError reports an unknown key, or the deprecated options of the settings of the linters.
+func (v *schemaValidator) addUnknownKeyError(keyNode, valueNode *yaml.Node, keyPath string) {
+	if lower := strings.ToLower(keyPath); strings.HasPrefix(lower, "linters-settings.") {
+		option := strings.TrimPrefix(lower, "linters-settings.")
+		if d := findSettingDeprecation(option); d != nil {
+			v.addError(keyNode, "%s", d)
+			return
+		}
+
+		if isDeprecatedPrefix(option) && valueNode.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(valueNode.Content); i += 2 {
+				v.addUnknownKeyError(valueNode.Content[i], valueNode.Content[i+1], joinPath(keyPath, valueNode.Content[i].Value))
+			}
+			return
+		}
+	}
+
+	v.addError(keyNode, "%s: unknown key", keyPath)
+}
+
+func (v *schemaValidator) validateArray(s *Schema, node *yaml.Node, path string) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case yaml.ScalarNode:
+		// A single value (or comma-separated values) is converted to a slice.
+		if s.Items != nil && (s.Items.Type.has(schemaTypeObject) || s.Items.Type.has(schemaTypeArray)) {
+			v.addError(node, "%s: expected a sequence", displayPath(path))
+			return
+		}
+	default:
+		v.addError(node, "%s: expected a sequence", displayPath(path))
+	}
+}
+
+func (v *schemaValidator) validateScalar(s *Schema, node *yaml.Node, path string) {
+	if node.Kind != yaml.ScalarNode {
+		v.addError(node, "%s: expected %s", displayPath(path), strings.Join(s.Type, " or "))
+		return
+	}
+
+	if s.Format == schemaFormatDuration {
+		if _, err := time.ParseDuration(node.Value); err != nil && node.Tag != "!!int" {
+			v.addError(node, "%s: invalid duration %q", displayPath(path), node.Value)
+		}
+		return
+	}
+
+	var valid bool
+	switch {
+	case s.Type.has(schemaTypeString):
+		valid = true
+	case s.Type.has(schemaTypeBoolean):
+		_, err := strconv.ParseBool(node.Value)
+		valid = err == nil
+	case s.Type.has(schemaTypeInteger):
+		_, err := strconv.ParseInt(node.Value, 0, 64)
+		valid = err == nil
+	case s.Type.has(schemaTypeNumber):
+		_, err := strconv.ParseFloat(node.Value, 64)
+		valid = err == nil
+	}
+
+	if !valid {
+		v.addError(node, "%s: expected %s, got %q", displayPath(path), strings.Join(s.Type, " or "), node.Value)
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "root"
+	}
+	return path
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSchema(t *testing.T) {
+	s := NewSchema()
+
+	require.Contains(t, s.Properties, "linters-settings")
+	require.Contains(t, s.Properties, "run")
+
+	run := s.Properties["run"]
+	assert.Equal(t, SchemaTypes{schemaTypeString, schemaTypeInteger}, run.Properties["timeout"].Type)
+	assert.Equal(t, SchemaTypes{schemaTypeArray}, run.Properties["skip-dirs"].Type)
+	assert.Equal(t, false, run.AdditionalProperties)
+
+	staticcheck := s.Properties["linters-settings"].Properties["staticcheck"]
+	assert.Equal(t, schemaFormatDuration, staticcheck.Properties["timeout"].Format)
+
+	// squashed BaseRule
+	rule := s.Properties["issues"].Properties["exclude-rules"].Items
+	assert.Contains(t, rule.Properties, "linters")
+
+	_, err := json.Marshal(s)
+	require.NoError(t, err)
+}
+
+func TestSchemaValidateYAML(t *testing.T) {
+	data := []byte(`
+run:
+  timeout: 5x
+  tests: maybe
+  skip-dirs: vendor
+linters:
+  enable:
+    - govet
+  disabel:
+    - errcheck
+linters-settings:
+  lll:
+    line-length: long
+  misspell:
+    locale: US
+  custom:
+    foo:
+      path: foo.so
+issues:
+  exclude-rules: foo
+`)
+
+	isLinterDisabled := func(name string) bool {
+		return name == "misspell"
+	}
+
+	errs, err := NewSchema().ValidateYAML(data, isLinterDisabled)
+	require.NoError(t, err)
+
+	expected := []SchemaError{
+		{Line: 3, Column: 12, Message: `run.timeout: invalid duration "5x"`},
+		{Line: 4, Column: 10, Message: `run.tests: expected boolean, got "maybe"`},
+		{Line: 9, Column: 3, Message: `linters.disabel: unknown key`},
+		{Line: 13, Column: 18, Message: `linters-settings.lll.line-length: expected integer, got "long"`},
+		{Line: 14, Column: 3, Message: `linters-settings.misspell: settings of the linter misspell which is not enabled`},
+		{Line: 20, Column: 18, Message: `issues.exclude-rules: expected a sequence`},
+	}
+	assert.Equal(t, expected, errs)
+}
+
+func TestSchemaValidateYAML_invalid(t *testing.T) {
+	_, err := NewSchema().ValidateYAML([]byte("run: [\n"), nil)
+	assert.Error(t, err)
+}
+
+func TestSchemaValidateYAML_reference(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", ".golangci.reference.yml"))
+	require.NoError(t, err)
+
+	errs, err := NewSchema().ValidateYAML(data, nil)
+	require.NoError(t, err)
+	assert.Empty(t, errs)
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	hcversion "github.com/hashicorp/go-version"
+)
+
+// Service is the requirements of the config on the binary running it, checked before the run.
+type Service struct {
+	// GolangciLintVersion is the constraint of the version of golangci-lint, e.g. ">=1.55 <2" or "1.55.x".
+	GolangciLintVersion string `mapstructure:"golangci-lint-version"`
+	// RequiredCustomLinters are the custom linters of linters-settings.custom which must be loaded by the binary.
+	RequiredCustomLinters []string `mapstructure:"required-custom-linters"`
+}
+
+func (s *Service) Validate(custom map[string]CustomLinterSettings) error {
+	if s.GolangciLintVersion != "" {
+		if _, err := ParseVersionConstraint(s.GolangciLintVersion); err != nil {
+			return fmt.Errorf("invalid golangci-lint-version: %v", err)
+		}
+	}
+
+	for _, name := range s.RequiredCustomLinters {
+		if _, ok := custom[name]; !ok {
+			return fmt.Errorf("required custom linter %q isn't configured in linters-settings.custom", name)
+		}
+	}
+
+	return nil
+}
+
+// GoReleaseVersion returns the release of the version of the Go toolchain, without its prerelease:
+// go1.22rc1 is 1.22, so it matches >=1.21. The versions of the development builds are returned unchanged.
+func GoReleaseVersion(version string) string {
+	version = strings.TrimPrefix(version, "go")
+
+	end := strings.IndexFunc(version, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if end <= 0 {
+		return version
+	}
+
+	return strings.TrimSuffix(version[:end], ".")
+}
+
+// ParseVersionConstraint parses a version constraint: the comparisons are separated by spaces or commas,
+// and the x of a version matches any version, e.g. ">=1.55 <2", ">= 1.55, < 2" or "1.55.x".
+func ParseVersionConstraint(constraint string) (hcversion.Constraints, error) {
+	fields := strings.FieldsFunc(constraint, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ','
+	})
+
+	var parts []string
+	var operator string
+	for _, field := range fields {
+		if strings.Trim(field, "<>=!~") == "" {
+			operator += field // the version follows the operator: >= 1.55
+			continue
+		}
+
+		if operator == "" && strings.HasSuffix(field, ".x") {
+			// 1.55.x is ~> 1.55.0: >= 1.55.0, < 1.56.0
+			field = "~>" + strings.TrimSuffix(field, "x") + "0"
+		}
+		parts = append(parts, operator+field)
+		operator = ""
+	}
+
+	if operator != "" {
+		return nil, fmt.Errorf("constraint %q: no version after %s", constraint, operator)
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("constraint %q is empty", constraint)
+	}
+
+	c, err := hcversion.NewConstraint(strings.Join(parts, ","))
+	if err != nil {
+		return nil, fmt.Errorf("constraint %q: must be comparisons of versions, e.g. >=1.55 <2", constraint)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"testing"
+
+	hcversion "github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersionConstraint(t *testing.T) {
+	testCases := []struct {
+		constraint string
+		matching   []string
+		other      []string
+	}{
+		{constraint: ">=1.55 <2", matching: []string{"1.55.0", "1.59.1"}, other: []string{"1.54.2", "2.0.0"}},
+		{constraint: ">= 1.55, < 2", matching: []string{"1.55.0"}, other: []string{"2.1.0"}},
+		{constraint: "1.55.x", matching: []string{"1.55.0", "1.55.2"}, other: []string{"1.54.0", "1.56.0"}},
+		{constraint: "1.55.2", matching: []string{"1.55.2"}, other: []string{"1.55.1"}},
+		{constraint: "!=1.55.1 >1.54", matching: []string{"1.55.0"}, other: []string{"1.55.1", "1.54.0"}},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.constraint, func(t *testing.T) {
+			c, err := ParseVersionConstraint(test.constraint)
+			require.NoError(t, err)
+
+			for _, v := range test.matching {
+				assert.True(t, c.Check(hcversion.Must(hcversion.NewVersion(v))), v)
+			}
+			for _, v := range test.other {
+				assert.False(t, c.Check(hcversion.Must(hcversion.NewVersion(v))), v)
+			}
+		})
+	}
+}
+
+func TestParseVersionConstraint_error(t *testing.T) {
+	testCases := []struct {
+		constraint string
+		err        string
+	}{
+		{constraint: " ", err: `constraint " " is empty`},
+		{constraint: ">=1.55 <", err: `constraint ">=1.55 <": no version after <`},
+		{constraint: "latest", err: `constraint "latest": must be comparisons of versions, e.g. >=1.55 <2`},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.constraint, func(t *testing.T) {
+			_, err := ParseVersionConstraint(test.constraint)
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestGoReleaseVersion(t *testing.T) {
+	assert.Equal(t, "1.21.5", GoReleaseVersion("go1.21.5"))
+	assert.Equal(t, "1.22", GoReleaseVersion("go1.22rc1"))
+	assert.Equal(t, "1.21", GoReleaseVersion("go1.21beta2"))
+	assert.Equal(t, "1.22.0", GoReleaseVersion("1.22.0"))
+	assert.Equal(t, "devel go1.22-abcdef", GoReleaseVersion("devel go1.22-abcdef"))
+
+	c, err := ParseVersionConstraint(">=1.21")
+	require.NoError(t, err)
+	assert.True(t, c.Check(hcversion.Must(hcversion.NewVersion(GoReleaseVersion("go1.22rc1")))))
+}
+
+func TestService_Validate(t *testing.T) {
+	custom := map[string]CustomLinterSettings{"example": {Path: "example.so"}}
+
+	testCases := []struct {
+		desc    string
+		service Service
+		err     string
+	}{
+		{desc: "empty"},
+		{desc: "valid", service: Service{GolangciLintVersion: ">=1.55 <2", RequiredCustomLinters: []string{"example"}}},
+		{desc: "invalid version", service: Service{GolangciLintVersion: "~"}, err: "invalid golangci-lint-version"},
+		{
+			desc:    "unknown custom linter",
+			service: Service{RequiredCustomLinters: []string{"other"}},
+			err:     `required custom linter "other" isn't configured in linters-settings.custom`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := test.service.Validate(custom)
+			if test.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
@@ -1,18 +1,177 @@
 package config
 
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gobwas/glob"
+)
+
 const severityRuleMinConditionsCount = 1
 
+// severityLevels orders the severity names of the supported output formats:
+// an issue fails the run if its level reaches the failure threshold.
+var severityLevels = map[string]int{
+	"ignore":   0,
+	"info":     1,
+	"notice":   1,
+	"low":      1,
+	"minor":    2,
+	"warning":  2,
+	"medium":   2,
+	"major":    3,
+	"error":    3,
+	"high":     3,
+	"critical": 4,
+	"blocker":  5,
+}
+
 type Severity struct {
 	Default       string         `mapstructure:"default-severity"`
 	CaseSensitive bool           `mapstructure:"case-sensitive"`
 	Rules         []SeverityRule `mapstructure:"rules"`
+
+	// Match is how the rules are applied: SeverityMatchFirst (by default) or SeverityMatchAll.
+	Match string `mapstructure:"match"`
+	// LintersDefault are the default severities of the issues of the linters, by linter: they have priority over Default.
+	LintersDefault map[string]string `mapstructure:"linters-default-severity"`
+
+	// FailOn is the lowest severity failing the run.
+	FailOn string `mapstructure:"fail-on"`
+	// MaxSeverityToPass is the highest severity not failing the run.
+	MaxSeverityToPass string `mapstructure:"max-severity-to-pass"`
+}
+
+const (
+	// SeverityMatchFirst sets the severity of the first matching rule.
+	SeverityMatchFirst = "first"
+	// SeverityMatchAll applies all the matching rules in order: the severity of the last matching rule is set.
+	SeverityMatchAll = "all"
+)
+
+const (
+	// SeverityScopeTests restricts a severity rule to the test files.
+	SeverityScopeTests = "tests"
+	// SeverityScopeNonTests restricts a severity rule to the files which aren't test files.
+	SeverityScopeNonTests = "non-tests"
+)
+
+func (s *Severity) Validate() error {
+	switch s.Match {
+	case "", SeverityMatchFirst, SeverityMatchAll:
+	default:
+		return fmt.Errorf("invalid match %q: must be %q or %q", s.Match, SeverityMatchFirst, SeverityMatchAll)
+	}
+
+	for linterName, severity := range s.LintersDefault {
+		if _, ok := SeverityLevel(severity); !ok {
+			return fmt.Errorf("unknown severity %q of linter %s", severity, linterName)
+		}
+	}
+
+	if s.FailOn != "" && s.MaxSeverityToPass != "" {
+		return errors.New("fail-on and max-severity-to-pass can't be combined")
+	}
+
+	for _, name := range []string{s.FailOn, s.MaxSeverityToPass} {
+		if _, ok := SeverityLevel(name); name != "" && !ok {
+			return fmt.Errorf("unknown severity %q", name)
+		}
+	}
+
+	return nil
+}
+
+// IsFailing checks if an issue with the given severity fails the run.
+// Without threshold, or if the severity is unknown, all issues fail the run.
+// An error is returned if the threshold is unknown.
+func (s *Severity) IsFailing(severity string) (bool, error) {
+	if severity == "" {
+		severity = s.Default
+	}
+
+	level, ok := SeverityLevel(severity)
+	if !ok {
+		return true, nil
+	}
+
+	if s.FailOn != "" {
+		failOn, ok := SeverityLevel(s.FailOn)
+		if !ok {
+			return false, fmt.Errorf("unknown severity %q of fail-on", s.FailOn)
+		}
+		return level >= failOn, nil
+	}
+
+	if s.MaxSeverityToPass != "" {
+		maxToPass, ok := SeverityLevel(s.MaxSeverityToPass)
+		if !ok {
+			return false, fmt.Errorf("unknown severity %q of max-severity-to-pass", s.MaxSeverityToPass)
+		}
+		return level > maxToPass, nil
+	}
+
+	return true, nil
+}
+
+// IsError checks if an issue with the given severity has the level of the error severity:
+// as for the failure threshold, an unknown severity is an error.
+func (s *Severity) IsError(severity string) bool {
+	if severity == "" {
+		severity = s.Default
+	}
+
+	level, ok := SeverityLevel(severity)
+	return !ok || level >= severityLevels["error"]
+}
+
+// SeverityLevel returns the level of the severity name (case-insensitive), false if the severity is unknown.
+func SeverityLevel(name string) (int, bool) {
+	level, ok := severityLevels[strings.ToLower(name)]
+	return level, ok
 }
 
 type SeverityRule struct {
 	BaseRule `mapstructure:",squash"`
 	Severity string
+
+	// RuleIDs are the identifiers of the rules of the linters, e.g. G404 for gosec.
+	RuleIDs []string `mapstructure:"rule-ids"`
+	// PathGlob matches the path of the issues, `**` matches any directories.
+	PathGlob string `mapstructure:"path-glob"`
+	// Scope restricts the rule to the test files (SeverityScopeTests) or to the other files (SeverityScopeNonTests).
+	Scope string `mapstructure:"scope"`
 }
 
 func (s *SeverityRule) Validate() error {
-	return s.BaseRule.Validate(severityRuleMinConditionsCount)
+	if err := s.BaseRule.Validate(0); err != nil {
+		return err
+	}
+
+	if s.PathGlob != "" {
+		if _, err := glob.Compile(filepath.ToSlash(s.PathGlob), '/'); err != nil {
+			return fmt.Errorf("invalid path glob: %v", err)
+		}
+	}
+
+	switch s.Scope {
+	case "", SeverityScopeTests, SeverityScopeNonTests:
+	default:
+		return fmt.Errorf("invalid scope %q: must be %q or %q", s.Scope, SeverityScopeTests, SeverityScopeNonTests)
+	}
+
+	conditions := s.BaseRule.conditionsCount()
+	for _, set := range []bool{len(s.RuleIDs) != 0, s.PathGlob != "", s.Scope != ""} {
+		if set {
+			conditions++
+		}
+	}
+	if conditions < severityRuleMinConditionsCount {
+		return fmt.Errorf("at least %d of (text, source, path, path-glob, linters, rule-ids, scope) should be set",
+			severityRuleMinConditionsCount)
+	}
+
+	return nil
 }
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeverityIsFailing(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		cfg      Severity
+		severity string
+		expected bool
+	}{
+		{desc: "no threshold", cfg: Severity{}, severity: "info", expected: true},
+		{desc: "fail-on below", cfg: Severity{FailOn: "error"}, severity: "warning", expected: false},
+		{desc: "fail-on equal", cfg: Severity{FailOn: "error"}, severity: "error", expected: true},
+		{desc: "fail-on above", cfg: Severity{FailOn: "warning"}, severity: "critical", expected: true},
+		{desc: "fail-on case", cfg: Severity{FailOn: "Error"}, severity: "WARNING", expected: false},
+		{desc: "max-to-pass equal", cfg: Severity{MaxSeverityToPass: "warning"}, severity: "warning", expected: false},
+		{desc: "max-to-pass above", cfg: Severity{MaxSeverityToPass: "warning"}, severity: "error", expected: true},
+		{desc: "default severity", cfg: Severity{Default: "info", FailOn: "error"}, severity: "", expected: false},
+		{desc: "empty severity", cfg: Severity{FailOn: "error"}, severity: "", expected: true},
+		{desc: "unknown severity", cfg: Severity{FailOn: "error"}, severity: "foo", expected: true},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			failing, err := test.cfg.IsFailing(test.severity)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, failing)
+		})
+	}
+}
+
+func TestSeverityIsFailing_unknownThreshold(t *testing.T) {
+	_, err := (&Severity{FailOn: "foo"}).IsFailing("error")
+	assert.Error(t, err)
+
+	_, err = (&Severity{MaxSeverityToPass: "foo"}).IsFailing("error")
+	assert.Error(t, err)
+}
+
+func TestSeverityIsError(t *testing.T) {
+	cfg := Severity{Default: "warning"}
+
+	assert.True(t, cfg.IsError("error"))
+	assert.True(t, cfg.IsError("Critical"))
+	assert.False(t, cfg.IsError("warning"))
+	assert.False(t, cfg.IsError(""), "default severity")
+	assert.True(t, cfg.IsError("foo"), "unknown severity")
+	assert.True(t, (&Severity{}).IsError(""), "no default severity")
+}
+
+func TestSeverityValidate(t *testing.T) {
+	assert.NoError(t, (&Severity{FailOn: "warning"}).Validate())
+	assert.Error(t, (&Severity{FailOn: "foo"}).Validate())
+	assert.Error(t, (&Severity{FailOn: "error", MaxSeverityToPass: "warning"}).Validate())
+	assert.NoError(t, (&Severity{LintersDefault: map[string]string{"gosec": "Warning"}}).Validate())
+	assert.Error(t, (&Severity{LintersDefault: map[string]string{"gosec": "warn"}}).Validate())
+}
+
+func TestSeverityValidate_match(t *testing.T) {
+	assert.NoError(t, (&Severity{Match: SeverityMatchAll}).Validate())
+	assert.Error(t, (&Severity{Match: "last"}).Validate())
+}
+
+func TestSeverityRuleValidate(t *testing.T) {
+	assert.NoError(t, (&SeverityRule{RuleIDs: []string{"G404"}}).Validate())
+	assert.NoError(t, (&SeverityRule{Scope: SeverityScopeTests}).Validate())
+	assert.NoError(t, (&SeverityRule{PathGlob: "pkg/**/*.go"}).Validate())
+	assert.Error(t, (&SeverityRule{Severity: "info"}).Validate(), "no condition")
+	assert.Error(t, (&SeverityRule{Scope: "test"}).Validate())
+	assert.Error(t, (&SeverityRule{PathGlob: "pkg/[a"}).Validate())
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// The modes of the type errors of the packages.
+const (
+	// TypecheckModeReport reports the type errors as issues of typecheck:
+	// the linters needing the types don't analyze the packages with type errors.
+	TypecheckModeReport = "report"
+	// TypecheckModeReportAndContinue reports the type errors like TypecheckModeReport,
+	// and the linters needing only the syntax analyze the packages with type errors.
+	TypecheckModeReportAndContinue = "report-and-continue"
+	// TypecheckModeFailFast stops the run at the first type error.
+	TypecheckModeFailFast = "fail-fast"
+)
+
+// Typecheck is the policy of the type errors of the analyzed packages.
+type Typecheck struct {
+	Mode string `mapstructure:"mode"`
+	// IgnorePaths are regular expressions of the paths of the files whose type errors are ignored,
+	// e.g. the intentionally broken vendored code: the packages are still skipped by the linters needing the types.
+	IgnorePaths []string `mapstructure:"ignore-paths"`
+}
+
+func (t *Typecheck) Validate() error {
+	switch t.Mode {
+	case "", TypecheckModeReport, TypecheckModeReportAndContinue, TypecheckModeFailFast:
+	default:
+		return fmt.Errorf("invalid mode %q: must be %s, %s or %s", t.Mode,
+			TypecheckModeReport, TypecheckModeReportAndContinue, TypecheckModeFailFast)
+	}
+
+	for _, pattern := range t.IgnorePaths {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid ignore-paths regex %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypecheck_Validate(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		typecheck Typecheck
+		err       string
+	}{
+		{desc: "empty"},
+		{desc: "valid", typecheck: Typecheck{Mode: TypecheckModeFailFast, IgnorePaths: []string{"^vendor/broken/"}}},
+		{desc: "invalid mode", typecheck: Typecheck{Mode: "ignore"}, err: `invalid mode "ignore"`},
+		{desc: "invalid path", typecheck: Typecheck{IgnorePaths: []string{"("}}, err: `invalid ignore-paths regex "("`},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := test.typecheck.Validate()
+			if test.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
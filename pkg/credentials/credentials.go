@@ -0,0 +1,170 @@
+// Package credentials resolves the secrets of the integrations (remote cache, reporters...)
+// from the environment, files, the OS keychain or the OIDC token of the CI.
+// The resolved secrets are redacted from the logs.
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+// oidcTimeout is the timeout of the requests of the OIDC tokens and of their exchange.
+const oidcTimeout = 10 * time.Second
+
+// RefPrefix is the prefix of the references to the credentials in the values: `${credentials.NAME}`.
+const RefPrefix = "credentials."
+
+// Resolver resolves the credentials of the configuration, once per run.
+type Resolver struct {
+	creds map[string]config.Credential
+
+	mu     sync.Mutex
+	values map[string]string
+
+	getenv     func(string) string
+	readFile   func(string) ([]byte, error)
+	keychain   func(ctx context.Context, item config.KeychainItem) (string, error)
+	httpClient *http.Client
+}
+
+func NewResolver(creds map[string]config.Credential) *Resolver {
+	return &Resolver{
+		creds:      creds,
+		values:     map[string]string{},
+		getenv:     os.Getenv,
+		readFile:   os.ReadFile,
+		keychain:   readKeychain,
+		httpClient: &http.Client{Timeout: oidcTimeout},
+	}
+}
+
+// Names returns the sorted names of the credentials.
+func (r *Resolver) Names() []string {
+	names := make([]string, 0, len(r.creds))
+	for name := range r.creds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Source describes the source of the credential, without its value.
+func (r *Resolver) Source(name string) string {
+	c, ok := r.creds[name]
+	if !ok {
+		return "undefined"
+	}
+
+	switch {
+	case c.Env != "":
+		return "env " + c.Env
+	case c.File != "":
+		return "file " + c.File
+	case c.Keychain.Service != "":
+		if c.Keychain.Account != "" {
+			return fmt.Sprintf("keychain %s/%s", c.Keychain.Service, c.Keychain.Account)
+		}
+		return "keychain " + c.Keychain.Service
+	default:
+		return "oidc " + c.OIDCAudience
+	}
+}
+
+// Resolve returns the value of the credential.
+func (r *Resolver) Resolve(ctx context.Context, name string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if v, ok := r.values[name]; ok {
+		return v, nil
+	}
+
+	c, ok := r.creds[name]
+	if !ok {
+		return "", fmt.Errorf("credential %q is not defined", name)
+	}
+
+	v, err := r.resolve(ctx, c)
+	if err != nil {
+		return "", fmt.Errorf("credential %q (%s): %w", name, r.Source(name), err)
+	}
+	if v == "" {
+		return "", fmt.Errorf("credential %q (%s) is empty", name, r.Source(name))
+	}
+
+	logutils.AddSecret(v)
+	r.values[name] = v
+
+	return v, nil
+}
+
+func (r *Resolver) resolve(ctx context.Context, c config.Credential) (string, error) {
+	switch {
+	case c.Env != "":
+		v := r.getenv(c.Env)
+		if v == "" {
+			return "", fmt.Errorf("environment variable %s is not set", c.Env)
+		}
+		return v, nil
+	case c.File != "":
+		data, err := r.readFile(c.File)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(data), " \t\r\n"), nil
+	case c.Keychain.Service != "":
+		return r.keychain(ctx, c.Keychain)
+	case c.OIDCAudience != "":
+		token, err := r.oidcToken(ctx, c.OIDCAudience)
+		if err != nil || c.OIDCExchangeURL == "" {
+			return token, err
+		}
+		return r.exchangeToken(ctx, c.OIDCExchangeURL, token)
+	default:
+		return "", errors.New("no source")
+	}
+}
+
+// Expand replaces the references to the credentials `${credentials.NAME}` and the environment variables in the value.
+func (r *Resolver) Expand(ctx context.Context, value string) (string, error) {
+	var err error
+	expanded := os.Expand(value, func(key string) string {
+		name := strings.TrimPrefix(key, RefPrefix)
+		if name == key {
+			return r.getenv(key)
+		}
+
+		v, errResolve := r.Resolve(ctx, name)
+		if errResolve != nil && err == nil {
+			err = errResolve
+		}
+		return v
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return expanded, nil
+}
+
+// References returns the names of the credentials referenced in the value.
+func References(value string) []string {
+	var names []string
+	os.Expand(value, func(key string) string {
+		if name := strings.TrimPrefix(key, RefPrefix); name != key {
+			names = append(names, name)
+		}
+		return ""
+	})
+	return names
+}
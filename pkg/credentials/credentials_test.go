@@ -0,0 +1,139 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func newTestResolver(creds map[string]config.Credential, env map[string]string) *Resolver {
+	r := NewResolver(creds)
+	r.getenv = func(key string) string { return env[key] }
+	r.keychain = func(_ context.Context, item config.KeychainItem) (string, error) {
+		if item.Service == "golangci" && item.Account == "bot" {
+			return "keychain-secret", nil
+		}
+		return "", errors.New("item not found")
+	}
+	return r
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(file, []byte("file-secret\n"), 0o600))
+
+	creds := map[string]config.Credential{
+		"env":           {Env: "TOKEN"},
+		"env-missing":   {Env: "MISSING"},
+		"file":          {File: file},
+		"file-missing":  {File: filepath.Join(t.TempDir(), "missing")},
+		"keychain":      {Keychain: config.KeychainItem{Service: "golangci", Account: "bot"}},
+		"keychain-none": {Keychain: config.KeychainItem{Service: "other"}},
+	}
+
+	r := newTestResolver(creds, map[string]string{"TOKEN": "env-secret"})
+
+	testCases := []struct {
+		name     string
+		expected string
+		errMsg   string
+	}{
+		{name: "env", expected: "env-secret"},
+		{name: "env-missing", errMsg: `credential "env-missing" (env MISSING): environment variable MISSING is not set`},
+		{name: "file", expected: "file-secret"},
+		{name: "file-missing", errMsg: `credential "file-missing" (file `},
+		{name: "keychain", expected: "keychain-secret"},
+		{name: "keychain-none", errMsg: `credential "keychain-none" (keychain other): item not found`},
+		{name: "undefined", errMsg: `credential "undefined" is not defined`},
+	}
+
+	for _, test := range testCases {
+		v, err := r.Resolve(context.Background(), test.name)
+		if test.errMsg != "" {
+			require.Error(t, err, test.name)
+			assert.Contains(t, err.Error(), test.errMsg, test.name)
+			continue
+		}
+
+		require.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, v, test.name)
+	}
+
+	assert.Equal(t, "[REDACTED] and [REDACTED]", logutils.Redact("env-secret and file-secret"))
+}
+
+func TestResolver_Expand(t *testing.T) {
+	r := newTestResolver(map[string]config.Credential{"cache": {Env: "CACHE_TOKEN"}},
+		map[string]string{"CACHE_TOKEN": "s3cr3t$", "USER": "bot"})
+
+	v, err := r.Expand(context.Background(), "Bearer ${credentials.cache} for ${USER}")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer s3cr3t$ for bot", v)
+
+	_, err = r.Expand(context.Background(), "Bearer ${credentials.other}")
+	require.EqualError(t, err, `credential "other" is not defined`)
+
+	// the braces are required: the name of a variable stops at the dot
+	assert.Equal(t, []string{"cache"}, References("${credentials.cache} ${HOME} $credentials.other"))
+}
+
+func TestResolver_OIDC(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/token":
+			if req.Header.Get("Authorization") != "Bearer request-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"value": "id-token-` + req.URL.Query().Get("audience") + `"}`))
+		case "/exchange":
+			if err := req.ParseForm(); err != nil || req.PostForm.Get("subject_token") != "id-token-golangci" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token": "access-token"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	env := map[string]string{
+		githubOIDCRequestURL:   server.URL + "/token?api-version=2.0",
+		githubOIDCRequestToken: "request-token",
+	}
+
+	creds := map[string]config.Credential{
+		"id":       {OIDCAudience: "golangci"},
+		"access":   {OIDCAudience: "golangci", OIDCExchangeURL: server.URL + "/exchange"},
+		"rejected": {OIDCAudience: "other", OIDCExchangeURL: server.URL + "/exchange"},
+	}
+
+	r := newTestResolver(creds, env)
+
+	v, err := r.Resolve(context.Background(), "id")
+	require.NoError(t, err)
+	assert.Equal(t, "id-token-golangci", v)
+
+	v, err = r.Resolve(context.Background(), "access")
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", v)
+
+	_, err = r.Resolve(context.Background(), "rejected")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "token exchange: 403 Forbidden")
+
+	_, err = newTestResolver(creds, nil).Resolve(context.Background(), "id")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no CI OIDC provider")
+}
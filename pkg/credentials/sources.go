@@ -0,0 +1,141 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+)
+
+const (
+	githubOIDCRequestURL   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	githubOIDCRequestToken = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+)
+
+// readKeychain reads a generic password with the keychain CLI of the OS.
+func readKeychain(ctx context.Context, item config.KeychainItem) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		args := []string{"find-generic-password", "-w", "-s", item.Service}
+		if item.Account != "" {
+			args = append(args, "-a", item.Account)
+		}
+		cmd = exec.CommandContext(ctx, "security", args...)
+	case "linux", "freebsd", "openbsd":
+		args := []string{"lookup", "service", item.Service}
+		if item.Account != "" {
+			args = append(args, "account", item.Account)
+		}
+		cmd = exec.CommandContext(ctx, "secret-tool", args...)
+	default:
+		return "", fmt.Errorf("the keychain isn't supported on %s", runtime.GOOS)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w: %s", cmd.Args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+// oidcToken requests an OIDC token for the audience to the CI: only GitHub Actions is supported,
+// the tokens of GitLab CI (id_tokens) are environment variables.
+func (r *Resolver) oidcToken(ctx context.Context, audience string) (string, error) {
+	requestURL, requestToken := r.getenv(githubOIDCRequestURL), r.getenv(githubOIDCRequestToken)
+	if requestURL == "" || requestToken == "" {
+		return "", fmt.Errorf("no CI OIDC provider: %s and %s must be set (GitHub Actions with the id-token: write permission)",
+			githubOIDCRequestURL, githubOIDCRequestToken)
+	}
+
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", githubOIDCRequestURL, err)
+	}
+
+	q := u.Query()
+	q.Set("audience", audience)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+requestToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("OIDC token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("OIDC token request: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var token struct {
+		Value string `json:"value"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("can't decode OIDC token response: %w", err)
+	}
+	if token.Value == "" {
+		return "", errors.New("empty OIDC token")
+	}
+
+	return token.Value, nil
+}
+
+// exchangeToken exchanges the OIDC token for an access token with the OAuth 2.0 token exchange (RFC 8693).
+func (r *Resolver) exchangeToken(ctx context.Context, exchangeURL, subjectToken string) (string, error) {
+	form := url.Values{
+		"grant_type":         {"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"subject_token":      {subjectToken},
+		"subject_token_type": {"urn:ietf:params:oauth:token-type:id_token"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, exchangeURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token exchange: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("token exchange: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("can't decode token exchange response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("empty access token")
+	}
+
+	return token.AccessToken, nil
+}
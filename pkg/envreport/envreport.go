@@ -0,0 +1,204 @@
+// Package envreport builds the report of the environment of golangci-lint attached to the bug reports.
+package envreport
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+// Report is the environment of golangci-lint.
+type Report struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+
+	Build Build `json:"build"`
+	// Go are the values of 'go env', by variable.
+	Go map[string]string `json:"go"`
+	OS OS                `json:"os"`
+
+	Config Config `json:"config"`
+	// Linters are the enabled linters.
+	Linters []string `json:"linters"`
+}
+
+// Build is the build of the binary.
+type Build struct {
+	GoVersion string `json:"goVersion"`
+	Module    string `json:"module,omitempty"`
+	// Settings are the build settings, e.g. -tags, CGO_ENABLED or vcs.revision.
+	Settings map[string]string `json:"settings,omitempty"`
+}
+
+// OS is the system running the binary.
+type OS struct {
+	OS     string `json:"os"`
+	Arch   string `json:"arch"`
+	NumCPU int    `json:"numCPU"`
+	// Release is the name of the distribution on Linux.
+	Release string `json:"release,omitempty"`
+}
+
+// Config is the configuration of the run: its values are not reported, only their hash.
+type Config struct {
+	Path string `json:"path,omitempty"`
+	// Hash is the hash of the effective configuration: two reports with the same hash have the same configuration.
+	Hash string `json:"hash"`
+}
+
+// NewBuild returns the build of the running binary.
+func NewBuild() Build {
+	b := Build{GoVersion: runtime.Version()}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return b
+	}
+
+	b.Module = info.Main.Path + "@" + info.Main.Version
+	for _, s := range info.Settings {
+		// The default GODEBUG of the Go version is long and not relevant.
+		if s.Value == "" || s.Key == "DefaultGODEBUG" {
+			continue
+		}
+		if b.Settings == nil {
+			b.Settings = map[string]string{}
+		}
+		b.Settings[s.Key] = s.Value
+	}
+
+	return b
+}
+
+// NewOS returns the system running the binary.
+func NewOS() OS {
+	return OS{
+		OS:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+		NumCPU:  runtime.NumCPU(),
+		Release: osRelease("/etc/os-release"),
+	}
+}
+
+// osRelease returns the pretty name of the os-release file, empty if it can't be read.
+func osRelease(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if strings.HasPrefix(sc.Text(), "PRETTY_NAME=") {
+			return strings.Trim(strings.TrimPrefix(sc.Text(), "PRETTY_NAME="), `"'`)
+		}
+	}
+
+	return ""
+}
+
+// ConfigHash returns the hash of the values of the configuration.
+func ConfigHash(values interface{}) (string, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	const hashLen = 16
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:hashLen], nil
+}
+
+const redacted = "[REDACTED]"
+
+// urlCredentialsRe matches the credentials of the URLs, e.g. of a GOPROXY.
+var urlCredentialsRe = regexp.MustCompile(`://[^/@\s]+@`)
+
+// Redact removes the private data from the values of the report: the home directory is replaced by ~,
+// the credentials of the URLs are removed, and the values are redacted by the redact function.
+func (r *Report) Redact(home string, redact func(string) string) {
+	clean := func(s string) string {
+		if home != "" && home != "/" {
+			s = strings.ReplaceAll(s, home, "~")
+		}
+		s = urlCredentialsRe.ReplaceAllString(s, "://"+redacted+"@")
+		return redact(s)
+	}
+
+	for k, v := range r.Go {
+		r.Go[k] = clean(v)
+	}
+	for k, v := range r.Build.Settings {
+		r.Build.Settings[k] = clean(v)
+	}
+	r.Config.Path = clean(r.Config.Path)
+}
+
+// WriteJSON writes the report in JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteMarkdown writes the report in Markdown, to be pasted in an issue.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	b.WriteString("### golangci-lint env\n\n")
+	fmt.Fprintf(&b, "- Version: %s built from %s on %s\n", r.Version, r.Commit, r.Date)
+	fmt.Fprintf(&b, "- Built with: %s", r.Build.GoVersion)
+	if r.Build.Module != "" {
+		fmt.Fprintf(&b, " (%s)", r.Build.Module)
+	}
+	b.WriteString("\n")
+	if v := r.Go["GOVERSION"]; v != "" {
+		fmt.Fprintf(&b, "- Go: %s\n", v)
+	}
+	fmt.Fprintf(&b, "- OS: %s/%s, %d CPUs", r.OS.OS, r.OS.Arch, r.OS.NumCPU)
+	if r.OS.Release != "" {
+		fmt.Fprintf(&b, ", %s", r.OS.Release)
+	}
+	b.WriteString("\n")
+
+	config := "no config file"
+	if r.Config.Path != "" {
+		config = "`" + r.Config.Path + "`"
+	}
+	fmt.Fprintf(&b, "- Config: %s, hash %s\n", config, r.Config.Hash)
+	fmt.Fprintf(&b, "- Enabled linters (%d): %s\n", len(r.Linters), strings.Join(r.Linters, ", "))
+
+	writeDetails(&b, "go env", r.Go)
+	if len(r.Build.Settings) != 0 {
+		writeDetails(&b, "build settings", r.Build.Settings)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeDetails(b *strings.Builder, summary string, values map[string]string) {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(b, "\n<details><summary>%s</summary>\n\n```\n", summary)
+	for _, k := range keys {
+		fmt.Fprintf(b, "%s=%s\n", k, values[k])
+	}
+	b.WriteString("```\n\n</details>\n")
+}
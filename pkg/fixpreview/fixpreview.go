@@ -0,0 +1,142 @@
+// Package fixpreview describes the fixes of the issues as text edits and diffs,
+// for the editors to preview and apply them without running the analysis.
+package fixpreview
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const diffContextLines = 3
+
+// Edit replaces the text between the start (included) and the end (excluded) positions by NewText.
+// Lines are 1-based, columns are 0-based byte offsets.
+type Edit struct {
+	Path      string
+	StartLine int
+	StartCol  int
+	EndLine   int
+	EndCol    int
+	NewText   string
+}
+
+// Preview is the fix of an issue.
+type Preview struct {
+	Fingerprint string
+	Linter      string
+	Text        string
+	Edit        Edit
+	// Diff is the unified diff of the fix.
+	Diff string
+}
+
+var ErrNoFix = errors.New("the issue has no fix")
+
+// NewPreview returns the fix of the issue: lines are the lines of the file of the issue.
+func NewPreview(issue *result.Issue, lines []string) (*Preview, error) {
+	r := issue.Replacement
+	if r == nil {
+		return nil, ErrNoFix
+	}
+
+	lineRange := issue.GetLineRange()
+	if lineRange.From < 1 || lineRange.To > len(lines) || lineRange.From > lineRange.To {
+		return nil, fmt.Errorf("invalid lines %d-%d: the file has %d lines", lineRange.From, lineRange.To, len(lines))
+	}
+
+	oldLines := lines[lineRange.From-1 : lineRange.To]
+
+	var edit Edit
+	var newLines []string
+
+	switch {
+	case r.Inline != nil:
+		if issue.Line() < 1 || issue.Line() > len(lines) {
+			return nil, fmt.Errorf("invalid line %d: the file has %d lines", issue.Line(), len(lines))
+		}
+
+		line := lines[issue.Line()-1]
+		fix := r.Inline
+		if fix.StartCol < 0 || fix.Length < 0 || fix.StartCol+fix.Length > len(line) {
+			return nil, fmt.Errorf("invalid inline fix %+v for the line %q", *fix, line)
+		}
+
+		edit = Edit{
+			StartLine: issue.Line(),
+			StartCol:  fix.StartCol,
+			EndLine:   issue.Line(),
+			EndCol:    fix.StartCol + fix.Length,
+			NewText:   fix.NewString,
+		}
+
+		oldLines = []string{line}
+		lineRange = result.Range{From: issue.Line(), To: issue.Line()}
+		newLines = []string{line[:fix.StartCol] + fix.NewString + line[fix.StartCol+fix.Length:]}
+	case r.NeedOnlyDelete:
+		edit = Edit{StartLine: lineRange.From, EndLine: lineRange.To + 1}
+	default:
+		edit = Edit{StartLine: lineRange.From, EndLine: lineRange.To + 1, NewText: strings.Join(r.NewLines, "\n") + "\n"}
+		newLines = r.NewLines
+	}
+
+	edit.Path = issue.FilePath()
+
+	return &Preview{
+		Fingerprint: issue.Fingerprint(),
+		Linter:      issue.FromLinter,
+		Text:        issue.Text,
+		Edit:        edit,
+		Diff:        unifiedDiff(issue.FilePath(), lines, lineRange, oldLines, newLines),
+	}, nil
+}
+
+// unifiedDiff renders the replacement of the lines of the range by newLines, with context lines.
+func unifiedDiff(path string, lines []string, lineRange result.Range, oldLines, newLines []string) string {
+	before := lines[max(0, lineRange.From-1-diffContextLines) : lineRange.From-1]
+	after := lines[lineRange.To:min(len(lines), lineRange.To+diffContextLines)]
+
+	oldStart := lineRange.From - len(before)
+	oldCount := len(before) + len(oldLines) + len(after)
+	newCount := len(before) + len(newLines) + len(after)
+
+	newStart := oldStart
+	if newCount == 0 {
+		newStart-- // by convention, an empty range starts at the line before
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", path, path)
+	fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+
+	for _, l := range before {
+		fmt.Fprintf(&b, " %s\n", l)
+	}
+	for _, l := range oldLines {
+		fmt.Fprintf(&b, "-%s\n", l)
+	}
+	for _, l := range newLines {
+		fmt.Fprintf(&b, "+%s\n", l)
+	}
+	for _, l := range after {
+		fmt.Fprintf(&b, " %s\n", l)
+	}
+
+	return b.String()
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
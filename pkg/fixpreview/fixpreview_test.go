@@ -0,0 +1,117 @@
+package fixpreview
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+var testLines = []string{
+	"package p",
+	"",
+	"func f() {",
+	"	_ = 1",
+	"	println(\"becouse\")",
+	"}",
+}
+
+func TestNewPreview(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		issue        result.Issue
+		expectedEdit Edit
+		expectedDiff string
+	}{
+		{
+			desc: "inline",
+			issue: result.Issue{
+				Pos:         token.Position{Filename: "p.go", Line: 5},
+				Replacement: &result.Replacement{Inline: &result.InlineFix{StartCol: 10, Length: 7, NewString: "because"}},
+			},
+			expectedEdit: Edit{Path: "p.go", StartLine: 5, StartCol: 10, EndLine: 5, EndCol: 17, NewText: "because"},
+			expectedDiff: `--- a/p.go
++++ b/p.go
+@@ -2,5 +2,5 @@
+ 
+ func f() {
+ 	_ = 1
+-	println("becouse")
++	println("because")
+ }
+`,
+		},
+		{
+			desc: "delete",
+			issue: result.Issue{
+				Pos:         token.Position{Filename: "p.go", Line: 4},
+				Replacement: &result.Replacement{NeedOnlyDelete: true},
+			},
+			expectedEdit: Edit{Path: "p.go", StartLine: 4, EndLine: 5},
+			expectedDiff: `--- a/p.go
++++ b/p.go
+@@ -1,6 +1,5 @@
+ package p
+ 
+ func f() {
+-	_ = 1
+ 	println("becouse")
+ }
+`,
+		},
+		{
+			desc: "lines",
+			issue: result.Issue{
+				Pos:         token.Position{Filename: "p.go", Line: 3},
+				LineRange:   &result.Range{From: 3, To: 6},
+				Replacement: &result.Replacement{NewLines: []string{"func f() {}"}},
+			},
+			expectedEdit: Edit{Path: "p.go", StartLine: 3, EndLine: 7, NewText: "func f() {}\n"},
+			expectedDiff: `--- a/p.go
++++ b/p.go
+@@ -1,6 +1,3 @@
+ package p
+ 
+-func f() {
+-	_ = 1
+-	println("becouse")
+-}
++func f() {}
+`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := NewPreview(&test.issue, testLines)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedEdit, p.Edit)
+			assert.Equal(t, test.expectedDiff, p.Diff)
+			assert.Equal(t, test.issue.Fingerprint(), p.Fingerprint)
+		})
+	}
+}
+
+func TestNewPreview_errors(t *testing.T) {
+	_, err := NewPreview(&result.Issue{Pos: token.Position{Line: 1}}, testLines)
+	assert.ErrorIs(t, err, ErrNoFix)
+
+	_, err = NewPreview(&result.Issue{
+		Pos:         token.Position{Line: 10},
+		Replacement: &result.Replacement{NeedOnlyDelete: true},
+	}, testLines)
+	assert.Error(t, err)
+
+	_, err = NewPreview(&result.Issue{
+		Pos:         token.Position{Line: 1},
+		Replacement: &result.Replacement{Inline: &result.InlineFix{StartCol: 5, Length: 20}},
+	}, testLines)
+	assert.Error(t, err)
+}
@@ -0,0 +1,130 @@
+package fixpreview
+
+import (
+	"encoding/json"
+	"errors"
+	"go/token"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// IssueRef is an issue listed by the server.
+type IssueRef struct {
+	Fingerprint string
+	Linter      string
+	Text        string
+	Pos         token.Position
+	HasFix      bool
+}
+
+// Server serves the issues of a run and their fixes:
+//
+//	GET /issues                  the issues
+//	GET /fix?fingerprint=<hash>  the fix of an issue as a Preview
+type Server struct {
+	issues map[string]result.Issue
+	refs   []IssueRef
+	mux    *http.ServeMux
+
+	readFile func(path string) ([]byte, error)
+}
+
+// NewServer creates a server for the issues: the files are read on each request, to detect the files changed since the run.
+func NewServer(issues []result.Issue) *Server {
+	s := &Server{
+		issues:   map[string]result.Issue{},
+		mux:      http.NewServeMux(),
+		readFile: os.ReadFile,
+	}
+
+	for i := range issues {
+		issue := &issues[i]
+		fp := issue.Fingerprint()
+		if _, ok := s.issues[fp]; ok {
+			continue // same file, text and source line
+		}
+
+		s.issues[fp] = *issue
+		s.refs = append(s.refs, IssueRef{
+			Fingerprint: fp,
+			Linter:      issue.FromLinter,
+			Text:        issue.Text,
+			Pos:         issue.Pos,
+			HasFix:      issue.Replacement != nil,
+		})
+	}
+
+	s.mux.HandleFunc("/issues", s.handleIssues)
+	s.mux.HandleFunc("/fix", s.handleFix)
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleIssues(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	refs := s.refs
+	if refs == nil {
+		refs = []IssueRef{}
+	}
+
+	writeJSON(w, refs)
+}
+
+func (s *Server) handleFix(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	issue, ok := s.issues[r.URL.Query().Get("fingerprint")]
+	if !ok {
+		http.Error(w, "unknown issue fingerprint", http.StatusNotFound)
+		return
+	}
+
+	data, err := s.readFile(issue.FilePath())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	lines := strings.Split(string(data), "\n")
+
+	if len(issue.SourceLines) != 0 && !hasLine(lines, issue.Line(), issue.SourceLines[0]) {
+		http.Error(w, "the file changed since the analysis", http.StatusConflict)
+		return
+	}
+
+	preview, err := NewPreview(&issue, lines)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrNoFix) {
+			status = http.StatusUnprocessableEntity
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	writeJSON(w, preview)
+}
+
+// hasLine checks if the 1-based line of the file is still the analyzed one.
+func hasLine(lines []string, line int, analyzed string) bool {
+	return line >= 1 && line <= len(lines) && lines[line-1] == analyzed
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,59 @@
+package fixpreview
+
+import (
+	"encoding/json"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestServer(t *testing.T) {
+	fixable := result.Issue{
+		FromLinter:  "misspell",
+		Text:        "`becouse` is a misspelling of `because`",
+		Pos:         token.Position{Filename: "p.go", Line: 5},
+		SourceLines: []string{testLines[4]},
+		Replacement: &result.Replacement{Inline: &result.InlineFix{StartCol: 10, Length: 7, NewString: "because"}},
+	}
+	notFixable := result.Issue{FromLinter: "govet", Text: "unreachable code", Pos: token.Position{Filename: "p.go", Line: 4}}
+
+	s := NewServer([]result.Issue{fixable, notFixable})
+
+	content := strings.Join(testLines, "\n")
+	s.readFile = func(string) ([]byte, error) { return []byte(content), nil }
+
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, http.NoBody))
+		return w
+	}
+
+	w := get("/issues")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var refs []IssueRef
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &refs))
+	require.Len(t, refs, 2)
+	assert.True(t, refs[0].HasFix)
+	assert.False(t, refs[1].HasFix)
+
+	w = get("/fix?fingerprint=" + fixable.Fingerprint())
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var preview Preview
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &preview))
+	assert.Equal(t, "because", preview.Edit.NewText)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, get("/fix?fingerprint="+notFixable.Fingerprint()).Code)
+	assert.Equal(t, http.StatusNotFound, get("/fix?fingerprint=foo").Code)
+
+	content = strings.Replace(content, "becouse", "because", 1)
+	assert.Equal(t, http.StatusConflict, get("/fix?fingerprint="+fixable.Fingerprint()).Code)
+}
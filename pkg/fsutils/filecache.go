@@ -2,7 +2,10 @@ package fsutils
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -24,6 +27,11 @@ func (fc *FileCache) GetFileBytes(filePath string) ([]byte, error) {
 		return cachedBytes.([]byte), nil
 	}
 
+	if content, ok := OverlayContent(filePath); ok {
+		fc.files.Store(filePath, content)
+		return content, nil
+	}
+
 	fileBytes, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, errors.Wrapf(err, "can't read file %s", filePath)
@@ -53,15 +61,51 @@ func PrettifyBytesCount(n int64) string {
 	return fmt.Sprintf("%dB", n)
 }
 
-func (fc *FileCache) PrintStats(log logutils.Log) {
-	var size int64
-	var mapLen int
+// ParseBytesCount parses a count of bytes with an optional unit: 512, 100KB, 1.5GiB.
+// The units are multiples of 1024, KB and KiB are the same.
+func ParseBytesCount(s string) (int64, error) {
+	units := []struct {
+		suffix string
+		size   float64
+	}{
+		{"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10},
+		{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10},
+		{"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10},
+		{"B", 1},
+	}
+
+	value, multiplier := strings.TrimSpace(s), 1.0
+	for _, u := range units {
+		if len(value) > len(u.suffix) && strings.EqualFold(value[len(value)-len(u.suffix):], u.suffix) {
+			value, multiplier = strings.TrimSpace(value[:len(value)-len(u.suffix)]), u.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 || math.IsNaN(n) {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	if n*multiplier >= math.MaxInt64 {
+		return 0, fmt.Errorf("size %q is too large", s)
+	}
+	return int64(n * multiplier), nil
+}
+
+// Stats returns the count of the cached files and their total size.
+func (fc *FileCache) Stats() (entries int, size int64) {
 	fc.files.Range(func(_, fileBytes interface{}) bool {
-		mapLen++
+		entries++
 		size += int64(len(fileBytes.([]byte)))
 
 		return true
 	})
 
+	return entries, size
+}
+
+func (fc *FileCache) PrintStats(log logutils.Log) {
+	mapLen, size := fc.Stats()
+
 	log.Infof("File cache stats: %d entries of total size %s", mapLen, PrettifyBytesCount(size))
 }
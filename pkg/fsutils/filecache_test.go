@@ -0,0 +1,52 @@
+package fsutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBytesCount(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected int64
+	}{
+		{value: "0", expected: 0},
+		{value: "512", expected: 512},
+		{value: "512B", expected: 512},
+		{value: "100K", expected: 100 << 10},
+		{value: "100KB", expected: 100 << 10},
+		{value: "100KiB", expected: 100 << 10},
+		{value: "100kib", expected: 100 << 10},
+		{value: "20M", expected: 20 << 20},
+		{value: "20mb", expected: 20 << 20},
+		{value: "20MiB", expected: 20 << 20},
+		{value: "1.5GiB", expected: 3 << 29},
+		{value: "2g", expected: 2 << 30},
+		{value: " 3 MB ", expected: 3 << 20},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.value, func(t *testing.T) {
+			t.Parallel()
+
+			n, err := ParseBytesCount(test.value)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, n)
+		})
+	}
+}
+
+func TestParseBytesCount_invalid(t *testing.T) {
+	for _, value := range []string{"", "MB", "-1", "-1KB", "1TB", "1 2", "ten", "NaN", "Inf", "1e30", "9000000000GiB"} {
+		value := value
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseBytesCount(value)
+			assert.Error(t, err)
+		})
+	}
+}
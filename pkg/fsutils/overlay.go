@@ -0,0 +1,92 @@
+package fsutils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// overlay are the contents replacing the contents of files on disk, by absolute path, e.g. an unsaved buffer of an editor.
+var overlay sync.Map
+
+// SetOverlay replaces the content of the file for the loading of the packages, the linters and the processors.
+func SetOverlay(path string, content []byte) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	overlay.Store(abs, content)
+	return nil
+}
+
+// OverlayContent returns the content replacing the file, false if the file isn't replaced.
+func OverlayContent(path string) ([]byte, bool) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, false
+	}
+
+	content, ok := overlay.Load(abs)
+	if !ok {
+		return nil, false
+	}
+	return content.([]byte), true
+}
+
+// ReadFile reads the file, or returns its replaced content.
+func ReadFile(path string) ([]byte, error) {
+	if content, ok := OverlayContent(path); ok {
+		return content, nil
+	}
+	return os.ReadFile(path)
+}
+
+// OverlayFiles returns the replaced files, for the overlay of go/packages.
+func OverlayFiles() map[string][]byte {
+	files := map[string][]byte{}
+	overlay.Range(func(key, value interface{}) bool {
+		files[key.(string)] = value.([]byte)
+		return true
+	})
+
+	if len(files) == 0 {
+		return nil
+	}
+	return files
+}
+
+// ParserSource returns the source of the file for go/parser: its replaced content, or nil to read the file.
+func ParserSource(path string) interface{} {
+	if content, ok := OverlayContent(path); ok {
+		return content
+	}
+	return nil
+}
+
+// OverlaidPath returns the position in the replaced file of a position in the copy of its content made by go/packages,
+// e.g. in the errors of the compiler, the other positions are returned unchanged.
+// The copies are named "<random>-<path without the separators>".
+func OverlaidPath(pos string) string {
+	end := strings.Index(pos, ".go:")
+	if end < 0 {
+		return pos
+	}
+	end += len(".go")
+
+	base := filepath.Base(pos[:end])
+
+	res := pos
+	overlay.Range(func(key, _ interface{}) bool {
+		path := key.(string)
+		if !strings.HasSuffix(base, "-"+strings.ReplaceAll(filepath.ToSlash(path), "/", "")) {
+			return true
+		}
+
+		res = path + pos[end:]
+		return false
+	})
+
+	return res
+}
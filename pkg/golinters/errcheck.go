@@ -79,6 +79,8 @@ func runErrCheck(lintCtx *linter.Context, pass *analysis.Pass, checker *errcheck
 		TypesInfo: pass.TypesInfo,
 	}
 
+	// errcheck reads the lines of the unchecked errors from disk, they aren't reported:
+	// the issues are only positioned in the syntax of the package, which is loaded with the overlay of the files.
 	lintIssues := checker.CheckPackage(pkg).Unique()
 	if len(lintIssues.UncheckedErrors) == 0 {
 		return nil
@@ -34,19 +34,7 @@ func NewGci(settings *config.GciSettings) *goanalysis.Linter {
 
 	var cfg *gcicfg.Config
 	if settings != nil {
-		rawCfg := gcicfg.YamlConfig{
-			Cfg: gcicfg.BoolConfig{
-				SkipGenerated: settings.SkipGenerated,
-			},
-			SectionStrings: settings.Sections,
-		}
-
-		if settings.LocalPrefixes != "" {
-			prefix := []string{"standard", "default", fmt.Sprintf("prefix(%s)", settings.LocalPrefixes)}
-			rawCfg.SectionStrings = prefix
-		}
-
-		cfg, _ = rawCfg.Parse()
+		cfg, _ = GciConfig(settings)
 	}
 
 	var lock sync.Mutex
@@ -78,6 +66,36 @@ func NewGci(settings *config.GciSettings) *goanalysis.Linter {
 	}).WithLoadMode(goanalysis.LoadModeSyntax)
 }
 
+// GciConfig returns the config of gci, the linter and the formatter of the fixed files.
+func GciConfig(settings *config.GciSettings) (*gcicfg.Config, error) {
+	rawCfg := gcicfg.YamlConfig{
+		Cfg: gcicfg.BoolConfig{
+			SkipGenerated: settings.SkipGenerated,
+		},
+		SectionStrings: settings.Sections,
+	}
+
+	return rawCfg.Parse()
+}
+
+// GciSource formats the source of the file with gci.
+func GciSource(path string, src []byte, cfg *gcicfg.Config) ([]byte, error) {
+	log.InitLogger()
+	defer func() { _ = log.L().Sync() }()
+
+	_, formatted, err := gci.LoadFormatGoFile(gciSource{path: path, src: src}, *cfg)
+	return formatted, err
+}
+
+// gciSource is a file of gci read from memory.
+type gciSource struct {
+	path string
+	src  []byte
+}
+
+func (s gciSource) Load() ([]byte, error) { return s.src, nil }
+func (s gciSource) Path() string          { return s.path }
+
 func runGci(pass *analysis.Pass, lintCtx *linter.Context, cfg *gcicfg.Config, lock *sync.Mutex) ([]goanalysis.Issue, error) {
 	var fileNames []string
 	for _, f := range pass.Files {
@@ -22,6 +22,8 @@ func NewIssue(i *result.Issue, pass *analysis.Pass) Issue {
 
 type EncodingIssue struct {
 	FromLinter           string
+	RuleID               string
+	DocumentationURL     string
 	Text                 string
 	Pos                  token.Position
 	LineRange            *result.Range
@@ -47,6 +47,7 @@ type Linter struct {
 	cfg                     map[string]map[string]interface{}
 	issuesReporter          func(*linter.Context) []Issue
 	contextSetter           func(*linter.Context)
+	documentationURL        func(ruleID string) string
 	loadMode                LoadMode
 	needUseOriginalPackages bool
 }
@@ -55,12 +56,12 @@ func NewLinter(name, desc string, analyzers []*analysis.Analyzer, cfg map[string
 	return &Linter{name: name, desc: desc, analyzers: analyzers, cfg: cfg}
 }
 
-func (lnt *Linter) Run(_ context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
+func (lnt *Linter) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
 	if err := lnt.preRun(lintCtx); err != nil {
 		return nil, err
 	}
 
-	return runAnalyzers(lnt, lintCtx)
+	return runAnalyzers(ctx, lnt, lintCtx)
 }
 
 func (lnt *Linter) UseOriginalPackages() {
@@ -86,6 +87,12 @@ func (lnt *Linter) WithContextSetter(cs func(*linter.Context)) *Linter {
 	return lnt
 }
 
+// WithDocumentationURL sets the builder of the URLs of the documentation of the rules.
+func (lnt *Linter) WithDocumentationURL(u func(ruleID string) string) *Linter {
+	lnt.documentationURL = u
+	return lnt
+}
+
 func (lnt *Linter) Name() string {
 	return lnt.name
 }
@@ -94,6 +101,11 @@ func (lnt *Linter) Desc() string {
 	return lnt.desc
 }
 
+// Analyzers returns the analyzers of the linter.
+func (lnt *Linter) Analyzers() []*analysis.Analyzer {
+	return lnt.analyzers
+}
+
 func (lnt *Linter) allAnalyzerNames() []string {
 	var ret []string
 	for _, a := range lnt.analyzers {
@@ -160,6 +172,13 @@ func (lnt *Linter) preRun(lintCtx *linter.Context) error {
 	return nil
 }
 
+func (lnt *Linter) getSyntaxAnalyzers() []*analysis.Analyzer {
+	if lnt.loadMode > LoadModeSyntax {
+		return nil
+	}
+	return lnt.analyzers
+}
+
 func (lnt *Linter) getName() string {
 	return lnt.name
 }
@@ -168,6 +187,13 @@ func (lnt *Linter) getLinterNameForDiagnostic(*Diagnostic) string {
 	return lnt.name
 }
 
+func (lnt *Linter) getDocumentationURL(_, ruleID string) string {
+	if lnt.documentationURL == nil {
+		return ""
+	}
+	return lnt.documentationURL(ruleID)
+}
+
 func (lnt *Linter) getAnalyzers() []*analysis.Analyzer {
 	return lnt.analyzers
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -46,3 +47,26 @@ func TestParseError(t *testing.T) {
 		assert.Equal(t, "msg", i.Text)
 	}
 }
+
+func TestBuildIssuesRuleID(t *testing.T) {
+	mnd := &analysis.Analyzer{Name: "mnd"}
+	printf := &analysis.Analyzer{Name: "printf"}
+	shadow := &analysis.Analyzer{Name: "shadow"}
+
+	ml := NewMetaLinter([]*Linter{
+		NewLinter("gomnd", "", []*analysis.Analyzer{mnd}, nil),
+		NewLinter("govet", "", []*analysis.Analyzer{printf, shadow}, nil),
+	})
+
+	issues := buildIssues([]Diagnostic{
+		{Diagnostic: analysis.Diagnostic{Message: "magic number"}, Analyzer: mnd},
+		{Diagnostic: analysis.Diagnostic{Message: "wrong format"}, Analyzer: printf},
+	}, ml)
+
+	assert.Len(t, issues, 2)
+	assert.Equal(t, "gomnd", issues[0].FromLinter)
+	assert.Empty(t, issues[0].RuleID)
+	assert.Equal(t, "mnd: magic number", issues[0].Text)
+	assert.Equal(t, "govet", issues[1].FromLinter)
+	assert.Equal(t, "printf", issues[1].RuleID)
+}
@@ -11,28 +11,43 @@ import (
 )
 
 type MetaLinter struct {
+	name                 string
 	linters              []*Linter
 	analyzerToLinterName map[*analysis.Analyzer]string
 }
 
 func NewMetaLinter(linters []*Linter) *MetaLinter {
-	ml := &MetaLinter{linters: linters}
+	return newMetaLinter("goanalysis_metalinter", linters)
+}
+
+// NewFastMetaLinter returns the metalinter of the fast linters, run before the other ones.
+func NewFastMetaLinter(linters []*Linter) *MetaLinter {
+	return newMetaLinter("goanalysis_metalinter_fast", linters)
+}
+
+func newMetaLinter(name string, linters []*Linter) *MetaLinter {
+	ml := &MetaLinter{name: name, linters: linters}
 	ml.analyzerToLinterName = ml.getAnalyzerToLinterNameMapping()
 	return ml
 }
 
-func (ml MetaLinter) Run(_ context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
+func (ml MetaLinter) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
 	for _, l := range ml.linters {
 		if err := l.preRun(lintCtx); err != nil {
 			return nil, errors.Wrapf(err, "failed to pre-run %s", l.Name())
 		}
 	}
 
-	return runAnalyzers(ml, lintCtx)
+	return runAnalyzers(ctx, ml, lintCtx)
+}
+
+// Linters returns the combined linters.
+func (ml MetaLinter) Linters() []*Linter {
+	return ml.linters
 }
 
 func (ml MetaLinter) Name() string {
-	return "goanalysis_metalinter"
+	return ml.name
 }
 
 func (ml MetaLinter) Desc() string {
@@ -57,6 +72,14 @@ func (ml MetaLinter) getAnalyzers() []*analysis.Analyzer {
 	return allAnalyzers
 }
 
+func (ml MetaLinter) getSyntaxAnalyzers() []*analysis.Analyzer {
+	var analyzers []*analysis.Analyzer
+	for _, l := range ml.linters {
+		analyzers = append(analyzers, l.getSyntaxAnalyzers()...)
+	}
+	return analyzers
+}
+
 func (ml MetaLinter) getName() string {
 	return "metalinter"
 }
@@ -79,6 +102,15 @@ func (ml MetaLinter) getLinterNameForDiagnostic(diag *Diagnostic) string {
 	return ml.analyzerToLinterName[diag.Analyzer]
 }
 
+func (ml MetaLinter) getDocumentationURL(linterName, ruleID string) string {
+	for _, l := range ml.linters {
+		if l.Name() == linterName {
+			return l.getDocumentationURL(linterName, ruleID)
+		}
+	}
+	return ""
+}
+
 func (ml MetaLinter) getAnalyzerToLinterNameMapping() map[*analysis.Analyzer]string {
 	analyzerToLinterName := map[*analysis.Analyzer]string{}
 	for _, l := range ml.linters {
@@ -10,6 +10,7 @@
 package goanalysis
 
 import (
+	"context"
 	"encoding/gob"
 	"go/token"
 	"runtime"
@@ -22,8 +23,10 @@ import (
 
 	"github.com/golangci/golangci-lint/internal/errorutil"
 	"github.com/golangci/golangci-lint/internal/pkgcache"
+	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis/load"
 	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/resources"
 	"github.com/golangci/golangci-lint/pkg/timeutils"
 )
 
@@ -57,11 +60,30 @@ type runner struct {
 	passToPkg      map[*analysis.Pass]*packages.Package
 	passToPkgGuard sync.Mutex
 	sw             *timeutils.Stopwatch
+
+	// ctx cancels the analyzers which aren't run yet, and abandons the running ones.
+	ctx    context.Context
+	quotas config.AnalyzerQuotas
+	// loadConcurrency and analysisConcurrency limit the packages loaded and analyzed in parallel, GOMAXPROCS if 0.
+	loadConcurrency     int
+	analysisConcurrency int
+	// memoryBudget reduces the analysis to one package at a time once exceeded.
+	memoryBudget *resources.MemoryBudget
+	// skipped is set if an analyzer was skipped for a package, by a quota or a cancellation: the results are incomplete.
+	skipped int32
+	// despiteTypeErrors are the analyzers analyzing the packages with type errors.
+	despiteTypeErrors map[*analysis.Analyzer]bool
+	// onPanic receives the recovered panics of the analyzers, if not nil: the panicked actions are skipped.
+	// The panics are propagated if nil.
+	onPanic func(act *action, pe *errorutil.PanicError)
+	// onPackageAnalyzed is called when the analysis of an initial package finishes, if not nil.
+	onPackageAnalyzed func(total int)
 }
 
-func newRunner(prefix string, logger logutils.Log, pkgCache *pkgcache.Cache, loadGuard *load.Guard,
-	loadMode LoadMode, sw *timeutils.Stopwatch) *runner {
+func newRunner(ctx context.Context, prefix string, logger logutils.Log, pkgCache *pkgcache.Cache,
+	loadGuard *load.Guard, loadMode LoadMode, sw *timeutils.Stopwatch) *runner {
 	return &runner{
+		ctx:       ctx,
 		prefix:    prefix,
 		log:       logger,
 		pkgCache:  pkgCache,
@@ -85,7 +107,7 @@ func (r *runner) run(analyzers []*analysis.Analyzer, initialPackages []*packages
 
 	roots := r.analyze(initialPackages, analyzers)
 
-	diags, errs := extractDiagnostics(roots)
+	diags, errs := extractDiagnostics(roots, r.onPanic)
 
 	return diags, errs, r.passToPkg
 }
@@ -102,7 +124,7 @@ func (r *runner) markAllActions(a *analysis.Analyzer, pkg *packages.Package, mar
 	}
 
 	for _, req := range a.Requires {
-		r.markAllActions(req, pkg, markedActions)
+		r.markAllActions(sharedAnalyzer(req), pkg, markedActions)
 	}
 
 	if len(a.FactTypes) != 0 {
@@ -136,9 +158,9 @@ func (r *runner) makeAction(a *analysis.Analyzer, pkg *packages.Package,
 	}
 	act.deps = make([]*action, 0, depsCount)
 
-	// Add a dependency on each required analyzers.
+	// Add a dependency on each required analyzers: the shared analyzers replace their copies.
 	for _, req := range a.Requires {
-		act.deps = append(act.deps, r.makeAction(req, pkg, initialPkgs, actions, actAlloc))
+		act.deps = append(act.deps, r.makeAction(sharedAnalyzer(req), pkg, initialPkgs, actions, actAlloc))
 	}
 
 	r.buildActionFactDeps(act, a, pkg, initialPkgs, actions, actAlloc)
@@ -261,9 +283,19 @@ func (r *runner) analyze(pkgs []*packages.Package, analyzers []*analysis.Analyze
 	}
 
 	// Limit memory and IO usage.
-	gomaxprocs := runtime.GOMAXPROCS(-1)
-	debugf("Analyzing at most %d packages in parallel", gomaxprocs)
-	loadSem := make(chan struct{}, gomaxprocs)
+	loadConcurrency, analysisConcurrency := r.loadConcurrency, r.analysisConcurrency
+	if loadConcurrency <= 0 {
+		loadConcurrency = runtime.GOMAXPROCS(-1)
+	}
+	if analysisConcurrency <= 0 {
+		analysisConcurrency = runtime.GOMAXPROCS(-1)
+	}
+	debugf("Loading at most %d and analyzing at most %d packages in parallel", loadConcurrency, analysisConcurrency)
+	r.disableConcurrentAllocQuota(analysisConcurrency)
+	sched := newScheduler(loadConcurrency, analysisConcurrency)
+
+	stopThrottle := r.throttleOnMemoryBudget(sched.inFlight)
+	defer stopThrottle()
 
 	var wg sync.WaitGroup
 	debugf("There are %d initial and %d total packages", len(initialPkgs), len(loadingPackages))
@@ -271,7 +303,10 @@ func (r *runner) analyze(pkgs []*packages.Package, analyzers []*analysis.Analyze
 		if lp.isInitial {
 			wg.Add(1)
 			go func(lp *loadingPackage) {
-				lp.analyzeRecursive(r.loadMode, loadSem)
+				lp.analyzeRecursive(r.loadMode, sched)
+				if r.onPackageAnalyzed != nil {
+					r.onPackageAnalyzed(len(initialPkgs))
+				}
 				wg.Done()
 			}(lp)
 		}
@@ -281,8 +316,37 @@ func (r *runner) analyze(pkgs []*packages.Package, analyzers []*analysis.Analyze
 	return rootActions
 }
 
+// throttleOnMemoryBudget takes all but one slot of the semaphore once the memory budget is exceeded:
+// the remaining packages are analyzed one at a time.
+func (r *runner) throttleOnMemoryBudget(sem chan struct{}) (stop func()) {
+	exceeded := r.memoryBudget.Exceeded()
+	if exceeded == nil {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-exceeded:
+		case <-done:
+			return
+		}
+
+		for i := 1; i < cap(sem); i++ {
+			select {
+			case sem <- struct{}{}:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
 //nolint:nakedret
-func extractDiagnostics(roots []*action) (retDiags []Diagnostic, retErrors []error) {
+func extractDiagnostics(roots []*action, onPanic func(act *action, pe *errorutil.PanicError)) (retDiags []Diagnostic,
+	retErrors []error) {
 	extracted := make(map[*action]bool)
 	var extract func(*action)
 	var visitAll func(actions []*action)
@@ -309,7 +373,14 @@ func extractDiagnostics(roots []*action) (retDiags []Diagnostic, retErrors []err
 	extract = func(act *action) {
 		if act.err != nil {
 			if pe, ok := act.err.(*errorutil.PanicError); ok {
-				panic(pe)
+				if onPanic == nil {
+					panic(pe)
+				}
+				onPanic(act, pe)
+				return
+			}
+			if isSkipped(act.err) || errors.Is(act.err, errPrerequisitePanicked) {
+				return // already reported as a warning
 			}
 			retErrors = append(retErrors, errors.Wrap(act.err, act.a.Name))
 			return
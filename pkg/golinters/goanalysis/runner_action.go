@@ -5,6 +5,8 @@ import (
 	"go/types"
 	"reflect"
 	"runtime/debug"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -59,6 +61,10 @@ type action struct {
 	isroot              bool
 	isInitialPkg        bool
 	needAnalyzeSource   bool
+
+	// abandoned is set when the action exceeds a quota or is cancelled, guardMu serializes it with the reports.
+	abandoned int32
+	guardMu   sync.Mutex
 }
 
 func (act *action) String() string {
@@ -94,6 +100,20 @@ func (act *action) waitUntilDependingAnalyzersWorked() {
 	}
 }
 
+// runsDespiteTypeErrors checks if the analyzer analyzes the package despite its type errors:
+// the analyzers needing only the syntax of the files, with the typecheck.mode report-and-continue.
+func (act *action) runsDespiteTypeErrors() bool {
+	return act.r.despiteTypeErrors[act.a] && len(act.pkg.Syntax) != 0
+}
+
+// errPrerequisitePanicked skips the actions requiring a panicked action of their package, when the panics are recovered.
+var errPrerequisitePanicked = errors.New("a required analyzer panicked")
+
+func isPanicked(err error) bool {
+	var pe *errorutil.PanicError
+	return errors.As(err, &pe) || errors.Is(err, errPrerequisitePanicked)
+}
+
 func (act *action) analyzeSafe() {
 	defer func() {
 		if p := recover(); p != nil {
@@ -124,6 +144,22 @@ func (act *action) analyze() {
 			continue
 		}
 
+		if isSkipped(dep.err) {
+			if dep.pkg == act.pkg {
+				act.err = dep.err // the required analyzer was skipped: skip this one too
+				return
+			}
+			continue // only the facts of the dependency are missing
+		}
+
+		if act.r.onPanic != nil && isPanicked(dep.err) {
+			if dep.pkg == act.pkg {
+				act.err = errPrerequisitePanicked // the panic is reported once, by the panicked action
+				return
+			}
+			continue
+		}
+
 		depErrors = multierror.Append(depErrors, errors.Cause(dep.err))
 	}
 	if depErrors != nil {
@@ -135,6 +171,12 @@ func (act *action) analyze() {
 		return
 	}
 
+	if err := act.r.ctx.Err(); err != nil {
+		act.err = err // cancelled, e.g. at the timeout of the linter: skip the remaining analyzers
+		atomic.StoreInt32(&act.r.skipped, 1)
+		return
+	}
+
 	// Plumb the output values of the dependencies
 	// into the inputs of this action.  Also facts.
 	inputs := make(map[*analysis.Analyzer]interface{})
@@ -152,16 +194,28 @@ func (act *action) analyze() {
 			inheritFacts(act, dep)
 		}
 	}
+	// The analyzer reads the results of the copies of the shared analyzers by its own requirements.
+	for _, req := range act.a.Requires {
+		if shared := sharedAnalyzer(req); shared != req {
+			inputs[req] = inputs[shared]
+		}
+	}
 	factsDebugf("%s: Inherited facts in %s", act, time.Since(startedAt))
 
+	pkgTypes, typesInfo := act.pkg.Types, act.pkg.TypesInfo
+	if pkgTypes == nil && act.pkg.IllTyped && act.runsDespiteTypeErrors() {
+		// The package failed to compile before its type-checking: only its syntax is analyzed.
+		pkgTypes, typesInfo = types.NewPackage(act.pkg.PkgPath, act.pkg.Name), newTypesInfo()
+	}
+
 	// Run the analysis.
 	pass := &analysis.Pass{
 		Analyzer:          act.a,
 		Fset:              act.pkg.Fset,
 		Files:             act.pkg.Syntax,
 		OtherFiles:        act.pkg.OtherFiles,
-		Pkg:               act.pkg.Types,
-		TypesInfo:         act.pkg.TypesInfo,
+		Pkg:               pkgTypes,
+		TypesInfo:         typesInfo,
 		TypesSizes:        act.pkg.TypesSizes,
 		ResultOf:          inputs,
 		Report:            func(d analysis.Diagnostic) { act.diagnostics = append(act.diagnostics, d) },
@@ -177,20 +231,24 @@ func (act *action) analyze() {
 	act.r.passToPkg[pass] = act.pkg
 	act.r.passToPkgGuard.Unlock()
 
-	if act.pkg.IllTyped {
+	if act.pkg.IllTyped && !act.runsDespiteTypeErrors() {
 		// It looks like there should be !pass.Analyzer.RunDespiteErrors
 		// but govet's cgocall crashes on it. Govet itself contains !pass.Analyzer.RunDespiteErrors condition here,
 		// but it exits before it if packages.Load have failed.
 		act.err = errors.Wrap(&IllTypedError{Pkg: act.pkg}, "analysis skipped")
 	} else {
 		startedAt = time.Now()
-		act.result, act.err = pass.Analyzer.Run(pass)
+		act.result, act.err = act.runWithQuotas(pass)
 		analyzedIn := time.Since(startedAt)
 		if analyzedIn > time.Millisecond*10 {
 			debugf("%s: run analyzer in %s", act, analyzedIn)
 		}
 	}
 
+	if act.isAbandoned() {
+		return // the analyzer still runs: don't touch the pass or persist incomplete facts
+	}
+
 	// disallow calls after Run
 	pass.ExportObjectFact = nil
 	pass.ExportPackageFact = nil
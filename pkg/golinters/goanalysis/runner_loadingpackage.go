@@ -15,6 +15,7 @@ import (
 	"golang.org/x/tools/go/gcexportdata"
 	"golang.org/x/tools/go/packages"
 
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis/load"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 )
@@ -33,32 +34,27 @@ type loadingPackage struct {
 	decUseMutex sync.Mutex
 }
 
-func (lp *loadingPackage) analyzeRecursive(loadMode LoadMode, loadSem chan struct{}) {
+func (lp *loadingPackage) analyzeRecursive(loadMode LoadMode, sched *scheduler) {
 	lp.analyzeOnce.Do(func() {
 		// Load the direct dependencies, in parallel.
 		var wg sync.WaitGroup
 		wg.Add(len(lp.imports))
 		for _, imp := range lp.imports {
 			go func(imp *loadingPackage) {
-				imp.analyzeRecursive(loadMode, loadSem)
+				imp.analyzeRecursive(loadMode, sched)
 				wg.Done()
 			}(imp)
 		}
 		wg.Wait()
-		lp.analyze(loadMode, loadSem)
+		lp.analyze(loadMode, sched)
 	})
 }
 
-func (lp *loadingPackage) analyze(loadMode LoadMode, loadSem chan struct{}) {
-	loadSem <- struct{}{}
-	defer func() {
-		<-loadSem
-	}()
-
+func (lp *loadingPackage) analyze(loadMode LoadMode, sched *scheduler) {
 	// Save memory on unused more fields.
 	defer lp.decUse(loadMode < LoadModeWholeProgram)
 
-	if err := lp.loadWithFacts(loadMode); err != nil {
+	if err := sched.loadPackage(func() error { return lp.loadWithFacts(loadMode) }); err != nil {
 		werr := errors.Wrapf(err, "failed to load package %s", lp.pkg.Name)
 		// Don't need to write error to errCh, it will be extracted and reported on another layer.
 		// Unblock depending on actions and propagate error.
@@ -69,18 +65,20 @@ func (lp *loadingPackage) analyze(loadMode LoadMode, loadSem chan struct{}) {
 		return
 	}
 
-	var actsWg sync.WaitGroup
-	actsWg.Add(len(lp.actions))
-	for _, act := range lp.actions {
-		go func(act *action) {
-			defer actsWg.Done()
+	sched.analyzePackage(func() {
+		var actsWg sync.WaitGroup
+		actsWg.Add(len(lp.actions))
+		for _, act := range lp.actions {
+			go func(act *action) {
+				defer actsWg.Done()
 
-			act.waitUntilDependingAnalyzersWorked()
+				act.waitUntilDependingAnalyzersWorked()
 
-			act.analyzeSafe()
-		}(act)
-	}
-	actsWg.Wait()
+				act.analyzeSafe()
+			}(act)
+		}
+		actsWg.Wait()
+	})
 }
 
 func (lp *loadingPackage) loadFromSource(loadMode LoadMode) error {
@@ -93,7 +91,7 @@ func (lp *loadingPackage) loadFromSource(loadMode LoadMode) error {
 	// bookkeeping and potentially false sharing of cache lines.
 	pkg.Syntax = make([]*ast.File, 0, len(pkg.CompiledGoFiles))
 	for _, file := range pkg.CompiledGoFiles {
-		f, err := parser.ParseFile(pkg.Fset, file, nil, parser.ParseComments)
+		f, err := parser.ParseFile(pkg.Fset, file, fsutils.ParserSource(file), parser.ParseComments)
 		if err != nil {
 			pkg.Errors = append(pkg.Errors, lp.convertError(err)...)
 			continue
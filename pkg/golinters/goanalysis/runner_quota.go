@@ -0,0 +1,170 @@
+package goanalysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/types"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/golangci/golangci-lint/internal/errorutil"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/resources"
+)
+
+const allocCheckInterval = 50 * time.Millisecond
+
+// QuotaError is the breach of a quota by an analyzer on a package: the analyzer is skipped for the package.
+type QuotaError struct {
+	Analyzer string
+	Pkg      string
+	Resource string
+	Limit    string
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("analyzer %s exceeded the %s quota (%s) on package %s", e.Analyzer, e.Resource, e.Limit, e.Pkg)
+}
+
+type runResult struct {
+	result interface{}
+	err    error
+}
+
+// runWithQuotas runs the analyzer until it finishes, exceeds a quota or the context of the runner is done.
+// The run can't be interrupted: on a breach, the analyzer continues in background,
+// but its diagnostics and facts are dropped.
+func (act *action) runWithQuotas(pass *analysis.Pass) (interface{}, error) {
+	quotas := act.r.quotas
+	if !quotas.IsSet() && act.r.ctx.Done() == nil {
+		return pass.Analyzer.Run(pass)
+	}
+
+	act.guardPass(pass)
+
+	done := make(chan runResult, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- runResult{err: errorutil.NewPanicError(fmt.Sprintf("%s: package %q: %s",
+					act.a.Name, act.pkg.Name, p), debug.Stack())}
+			}
+		}()
+
+		res, err := pass.Analyzer.Run(pass)
+		done <- runResult{result: res, err: err}
+	}()
+
+	var timeout <-chan time.Time
+	if quotas.Time > 0 {
+		timer := time.NewTimer(quotas.Time)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var allocCheck <-chan time.Time
+	startAllocs := resources.HeapAllocs()
+	if quotas.AllocMB > 0 {
+		ticker := time.NewTicker(allocCheckInterval)
+		defer ticker.Stop()
+		allocCheck = ticker.C
+	}
+
+	startedAt := time.Now()
+	for {
+		select {
+		case r := <-done:
+			return r.result, r.err
+		case <-act.r.ctx.Done():
+			act.abandon()
+			return nil, act.r.ctx.Err()
+		case <-timeout:
+			return nil, act.exceedQuota("time", quotas.Time.String(), logutils.Fields{
+				"duration_ms": logutils.DurationField(time.Since(startedAt)),
+			})
+		case <-allocCheck:
+			allocated := resources.HeapAllocs() - startAllocs
+			if allocated > uint64(quotas.AllocMB)*resources.BytesPerMB {
+				return nil, act.exceedQuota("allocations", fmt.Sprintf("%dMiB", quotas.AllocMB), logutils.Fields{
+					"allocated_mb": allocated / resources.BytesPerMB,
+				})
+			}
+		}
+	}
+}
+
+// disableConcurrentAllocQuota disables the allocations quota if the packages are analyzed in parallel:
+// the allocations are measured for the whole process, they can't be attributed to a single analyzer.
+func (r *runner) disableConcurrentAllocQuota(analysisConcurrency int) {
+	if r.quotas.AllocMB <= 0 || analysisConcurrency <= 1 {
+		return
+	}
+
+	r.log.Warnf("The allocations quota of the analyzers is disabled: it requires run.analysis-concurrency 1, not %d",
+		analysisConcurrency)
+	r.quotas.AllocMB = 0
+}
+
+// guardPass drops the diagnostics and facts reported once the action is abandoned.
+func (act *action) guardPass(pass *analysis.Pass) {
+	report, exportObjectFact, exportPackageFact := pass.Report, pass.ExportObjectFact, pass.ExportPackageFact
+
+	pass.Report = func(d analysis.Diagnostic) {
+		act.guardMu.Lock()
+		defer act.guardMu.Unlock()
+		if !act.isAbandoned() {
+			report(d)
+		}
+	}
+	pass.ExportObjectFact = func(obj types.Object, fact analysis.Fact) {
+		act.guardMu.Lock()
+		defer act.guardMu.Unlock()
+		if !act.isAbandoned() {
+			exportObjectFact(obj, fact)
+		}
+	}
+	pass.ExportPackageFact = func(fact analysis.Fact) {
+		act.guardMu.Lock()
+		defer act.guardMu.Unlock()
+		if !act.isAbandoned() {
+			exportPackageFact(fact)
+		}
+	}
+}
+
+func (act *action) exceedQuota(resource, limit string, fields logutils.Fields) error {
+	act.abandon()
+
+	err := &QuotaError{Analyzer: act.a.Name, Pkg: act.pkg.PkgPath, Resource: resource, Limit: limit}
+
+	fields["analyzer"] = act.a.Name
+	fields["package"] = act.pkg.PkgPath
+	fields["resource"] = resource
+	fields["limit"] = limit
+	logutils.WarnEvent(act.r.log, "analyzer_quota_exceeded", fields, "%s: the analyzer is skipped for the package", err)
+
+	return err
+}
+
+// abandon drops the results of the action: the analyzer is skipped for the package.
+func (act *action) abandon() {
+	act.guardMu.Lock()
+	atomic.StoreInt32(&act.abandoned, 1)
+	act.guardMu.Unlock()
+
+	atomic.StoreInt32(&act.r.skipped, 1)
+}
+
+// isSkipped checks if the action was skipped by a quota or a cancellation, it isn't a failure of the analyzer.
+func isSkipped(err error) bool {
+	var quotaErr *QuotaError
+	return errors.As(err, &quotaErr) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func (act *action) isAbandoned() bool {
+	return atomic.LoadInt32(&act.abandoned) == 1
+}
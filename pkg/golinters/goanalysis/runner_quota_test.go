@@ -0,0 +1,146 @@
+package goanalysis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/resources"
+)
+
+func newQuotaTestAction(quotas config.AnalyzerQuotas, run func(*analysis.Pass) (interface{}, error)) (*action, *analysis.Pass) {
+	log := logutils.NewMockLog()
+	log.On("Warnf", mock.Anything, mock.Anything).Maybe()
+
+	act := &action{
+		a:   &analysis.Analyzer{Name: "slow", Run: run},
+		pkg: &packages.Package{PkgPath: "example.com/p"},
+		r:   &runner{ctx: context.Background(), log: log, quotas: quotas},
+	}
+
+	pass := &analysis.Pass{
+		Analyzer:          act.a,
+		Report:            func(d analysis.Diagnostic) { act.diagnostics = append(act.diagnostics, d) },
+		ExportPackageFact: func(analysis.Fact) {},
+	}
+
+	return act, pass
+}
+
+func TestRunWithQuotas_time(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	act, pass := newQuotaTestAction(config.AnalyzerQuotas{Time: 10 * time.Millisecond}, func(pass *analysis.Pass) (interface{}, error) {
+		<-release
+		pass.Report(analysis.Diagnostic{Message: "too late"})
+		return nil, nil
+	})
+
+	_, err := act.runWithQuotas(pass)
+
+	var quotaErr *QuotaError
+	require.True(t, errors.As(err, &quotaErr))
+	assert.Equal(t, "time", quotaErr.Resource)
+	assert.Equal(t, "example.com/p", quotaErr.Pkg)
+	assert.True(t, act.isAbandoned())
+	assert.Equal(t, int32(1), act.r.skipped)
+
+	pass.Report(analysis.Diagnostic{Message: "dropped"})
+	assert.Empty(t, act.diagnostics)
+}
+
+func TestRunWithQuotas_withinQuotas(t *testing.T) {
+	act, pass := newQuotaTestAction(config.AnalyzerQuotas{Time: time.Minute, AllocMB: 1024}, func(pass *analysis.Pass) (interface{}, error) {
+		pass.Report(analysis.Diagnostic{Message: "found"})
+		return "result", nil
+	})
+
+	res, err := act.runWithQuotas(pass)
+	require.NoError(t, err)
+	assert.Equal(t, "result", res)
+	assert.Len(t, act.diagnostics, 1)
+	assert.False(t, act.isAbandoned())
+}
+
+func TestRunWithQuotas_cancelled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	act, pass := newQuotaTestAction(config.AnalyzerQuotas{}, func(pass *analysis.Pass) (interface{}, error) {
+		<-release
+		pass.Report(analysis.Diagnostic{Message: "too late"})
+		return nil, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	act.r.ctx = ctx
+
+	_, err := act.runWithQuotas(pass)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, isSkipped(err))
+	assert.True(t, act.isAbandoned())
+	assert.Equal(t, int32(1), act.r.skipped)
+
+	pass.Report(analysis.Diagnostic{Message: "dropped"})
+	assert.Empty(t, act.diagnostics)
+}
+
+func TestRunWithQuotas_panic(t *testing.T) {
+	act, pass := newQuotaTestAction(config.AnalyzerQuotas{Time: time.Minute}, func(*analysis.Pass) (interface{}, error) {
+		panic("boom")
+	})
+
+	_, err := act.runWithQuotas(pass)
+	assert.ErrorContains(t, err, "boom")
+}
+
+var allocSink []byte
+
+func TestRunWithQuotas_allocations(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	act, pass := newQuotaTestAction(config.AnalyzerQuotas{AllocMB: 1}, func(*analysis.Pass) (interface{}, error) {
+		for {
+			select {
+			case <-release:
+				return nil, nil
+			default:
+				allocSink = make([]byte, 4*resources.BytesPerMB)
+				time.Sleep(time.Millisecond)
+			}
+		}
+	})
+
+	_, err := act.runWithQuotas(pass)
+
+	var quotaErr *QuotaError
+	require.True(t, errors.As(err, &quotaErr))
+	assert.Equal(t, "allocations", quotaErr.Resource)
+}
+
+func TestDisableConcurrentAllocQuota(t *testing.T) {
+	log := logutils.NewMockLog()
+	log.On("Warnf", mock.Anything, mock.Anything).Once()
+
+	r := &runner{log: log, quotas: config.AnalyzerQuotas{Time: time.Minute, AllocMB: 1024}}
+
+	r.disableConcurrentAllocQuota(1)
+	assert.Equal(t, 1024, r.quotas.AllocMB)
+
+	r.disableConcurrentAllocQuota(4)
+	assert.Equal(t, config.AnalyzerQuotas{Time: time.Minute}, r.quotas)
+	log.AssertExpectations(t)
+}
@@ -0,0 +1,42 @@
+package goanalysis
+
+// scheduler limits the packages loaded and analyzed in parallel: the loading of the packages (the parsing and the
+// type-checking, often bound by the IO) overlaps the analysis of the loaded ones (bound by the CPU).
+type scheduler struct {
+	load     chan struct{}
+	analysis chan struct{}
+	// inFlight limits the packages loaded and not analyzed yet: they are kept in memory.
+	inFlight chan struct{}
+}
+
+func newScheduler(loadConcurrency, analysisConcurrency int) *scheduler {
+	return &scheduler{
+		load:     make(chan struct{}, loadConcurrency),
+		analysis: make(chan struct{}, analysisConcurrency),
+		inFlight: make(chan struct{}, loadConcurrency+analysisConcurrency),
+	}
+}
+
+// loadPackage runs the loading of a package: if it succeeds, the package must be analyzed by analyzePackage.
+func (s *scheduler) loadPackage(load func() error) error {
+	s.inFlight <- struct{}{}
+	s.load <- struct{}{}
+	err := load()
+	<-s.load
+
+	if err != nil {
+		<-s.inFlight
+	}
+	return err
+}
+
+// analyzePackage runs the analysis of a loaded package.
+func (s *scheduler) analyzePackage(analyze func()) {
+	s.analysis <- struct{}{}
+	defer func() {
+		<-s.analysis
+		<-s.inFlight
+	}()
+
+	analyze()
+}
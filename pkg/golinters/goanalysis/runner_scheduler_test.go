@@ -0,0 +1,66 @@
+package goanalysis
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler(t *testing.T) {
+	sched := newScheduler(3, 2)
+
+	var loading, analyzing, inFlight, maxLoading, maxAnalyzing, maxInFlight int32
+	setMax := func(max *int32, v int32) {
+		for {
+			old := atomic.LoadInt32(max)
+			if v <= old || atomic.CompareAndSwapInt32(max, old, v) {
+				return
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			err := sched.loadPackage(func() error {
+				setMax(&maxInFlight, atomic.AddInt32(&inFlight, 1))
+				setMax(&maxLoading, atomic.AddInt32(&loading, 1))
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&loading, -1)
+
+				if i%5 == 0 {
+					atomic.AddInt32(&inFlight, -1)
+					return errors.New("can't load")
+				}
+				return nil
+			})
+			if err != nil {
+				return
+			}
+
+			sched.analyzePackage(func() {
+				setMax(&maxAnalyzing, atomic.AddInt32(&analyzing, 1))
+				time.Sleep(2 * time.Millisecond)
+				atomic.AddInt32(&analyzing, -1)
+				atomic.AddInt32(&inFlight, -1)
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, maxLoading, int32(3))
+	assert.LessOrEqual(t, maxAnalyzing, int32(2))
+	assert.LessOrEqual(t, maxInFlight, int32(5))
+
+	// All the slots are released.
+	assert.Empty(t, sched.load)
+	assert.Empty(t, sched.analysis)
+	assert.Empty(t, sched.inFlight)
+}
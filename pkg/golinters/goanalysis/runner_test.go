@@ -0,0 +1,50 @@
+package goanalysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/internal/errorutil"
+)
+
+func TestExtractDiagnostics_panics(t *testing.T) {
+	pkg := &packages.Package{PkgPath: "example.com/p"}
+	pe := errorutil.NewPanicError("boom", []byte("stack"))
+
+	panicked := &action{a: &analysis.Analyzer{Name: "panicking"}, pkg: pkg, err: pe}
+	dependent := &action{a: &analysis.Analyzer{Name: "dependent"}, pkg: pkg, err: errPrerequisitePanicked,
+		deps: []*action{panicked}, isroot: true}
+
+	var recovered []*action
+	diags, errs := extractDiagnostics([]*action{dependent}, func(act *action, err *errorutil.PanicError) {
+		assert.Equal(t, pe, err)
+		recovered = append(recovered, act)
+	})
+	assert.Empty(t, diags)
+	assert.Empty(t, errs)
+	assert.Equal(t, []*action{panicked}, recovered)
+
+	assert.PanicsWithValue(t, pe, func() {
+		extractDiagnostics([]*action{dependent}, nil)
+	})
+}
+
+func TestWithRequiredAnalyzers(t *testing.T) {
+	inspect := &analysis.Analyzer{Name: "inspect_for_test"}
+	syntax := &analysis.Analyzer{Name: "syntax", Requires: []*analysis.Analyzer{inspect}}
+	other := &analysis.Analyzer{Name: "other", Requires: []*analysis.Analyzer{inspect}}
+
+	set := withRequiredAnalyzers([]*analysis.Analyzer{syntax})
+	assert.True(t, set[syntax])
+	assert.True(t, set[inspect])
+	assert.False(t, set[other])
+}
+
+func TestIsPanicked(t *testing.T) {
+	assert.True(t, isPanicked(errorutil.NewPanicError("boom", nil)))
+	assert.True(t, isPanicked(errPrerequisitePanicked))
+	assert.False(t, isPanicked(&QuotaError{Resource: "time"}))
+}
@@ -1,6 +1,7 @@
 package goanalysis
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"sort"
@@ -12,7 +13,9 @@ import (
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/packages"
 
+	"github.com/golangci/golangci-lint/internal/errorutil"
 	"github.com/golangci/golangci-lint/internal/pkgcache"
+	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/result"
 	"github.com/golangci/golangci-lint/pkg/timeutils"
@@ -21,20 +24,50 @@ import (
 type runAnalyzersConfig interface {
 	getName() string
 	getLinterNameForDiagnostic(*Diagnostic) string
+	getDocumentationURL(linterName, ruleID string) string
 	getAnalyzers() []*analysis.Analyzer
 	useOriginalPackages() bool
 	reportIssues(*linter.Context) []Issue
 	getLoadMode() LoadMode
+	getSyntaxAnalyzers() []*analysis.Analyzer
 }
 
-func runAnalyzers(cfg runAnalyzersConfig, lintCtx *linter.Context) ([]result.Issue, error) {
+func runAnalyzers(ctx context.Context, cfg runAnalyzersConfig, lintCtx *linter.Context) ([]result.Issue, error) {
 	log := lintCtx.Log.Child("goanalysis")
 	sw := timeutils.NewStopwatch("analyzers", log)
 
 	const stagesToPrint = 10
 	defer sw.PrintTopStages(stagesToPrint)
 
-	runner := newRunner(cfg.getName(), log, lintCtx.PkgCache, lintCtx.LoadGuard, cfg.getLoadMode(), sw)
+	runner := newRunner(ctx, cfg.getName(), log, lintCtx.PkgCache, lintCtx.LoadGuard, cfg.getLoadMode(), sw)
+	if lintCtx.Cfg != nil {
+		runner.quotas = lintCtx.Cfg.Run.AnalyzerQuotas
+		runner.loadConcurrency = lintCtx.Cfg.Run.LoadConcurrency
+		runner.analysisConcurrency = lintCtx.Cfg.Run.AnalysisConcurrency
+	}
+	runner.memoryBudget = lintCtx.MemoryBudget
+	runner.onPackageAnalyzed = lintCtx.OnPackageAnalyzed
+	if lintCtx.Cfg != nil && lintCtx.Cfg.Typecheck.Mode == config.TypecheckModeReportAndContinue {
+		runner.despiteTypeErrors = withRequiredAnalyzers(cfg.getSyntaxAnalyzers())
+	}
+
+	panicked := false
+	if lintCtx.OnPanic != nil {
+		runner.onPanic = func(act *action, pe *errorutil.PanicError) {
+			panicked = true
+
+			name := cfg.getLinterNameForDiagnostic(&Diagnostic{Analyzer: act.a})
+			if name == "" {
+				name = cfg.getName() // an analyzer shared by the linters of the metalinter
+			}
+			lintCtx.OnPanic(linter.Panic{
+				Linter:  name,
+				Package: act.pkg.PkgPath,
+				Message: pe.Error(),
+				Stack:   pe.Stack(),
+			})
+		}
+	}
 
 	pkgs := lintCtx.Packages
 	if cfg.useOriginalPackages() {
@@ -52,7 +85,7 @@ func runAnalyzers(cfg runAnalyzersConfig, lintCtx *linter.Context) ([]result.Iss
 	diags, errs, passToPkg := runner.run(cfg.getAnalyzers(), pkgsToAnalyze)
 
 	defer func() {
-		if len(errs) == 0 {
+		if len(errs) == 0 && atomic.LoadInt32(&runner.skipped) == 0 && !panicked {
 			// If we try to save to cache even if we have compilation errors
 			// we won't see them on repeated runs.
 			saveIssuesToCache(pkgs, pkgsFromCache, issues, lintCtx, cfg.getAnalyzers())
@@ -69,7 +102,7 @@ func runAnalyzers(cfg runAnalyzersConfig, lintCtx *linter.Context) ([]result.Iss
 			}
 			retIssues = append(retIssues, *issue)
 		}
-		retIssues = append(retIssues, buildIssues(diags, cfg.getLinterNameForDiagnostic)...)
+		retIssues = append(retIssues, buildIssues(diags, cfg)...)
 		return retIssues
 	}
 
@@ -84,11 +117,45 @@ func runAnalyzers(cfg runAnalyzersConfig, lintCtx *linter.Context) ([]result.Iss
 	return issues, nil
 }
 
-func buildIssues(diags []Diagnostic, linterNameBuilder func(diag *Diagnostic) string) []result.Issue {
+// withRequiredAnalyzers returns the set of the analyzers and of the analyzers they require.
+func withRequiredAnalyzers(analyzers []*analysis.Analyzer) map[*analysis.Analyzer]bool {
+	set := map[*analysis.Analyzer]bool{}
+	var add func(a *analysis.Analyzer)
+	add = func(a *analysis.Analyzer) {
+		if set[a] {
+			return
+		}
+		set[a] = true
+		set[sharedAnalyzer(a)] = true
+		for _, req := range a.Requires {
+			add(req)
+		}
+	}
+
+	for _, a := range analyzers {
+		add(a)
+	}
+	return set
+}
+
+func buildIssues(diags []Diagnostic, cfg runAnalyzersConfig) []result.Issue {
+	analyzersCount := map[string]int{}
+	for _, a := range cfg.getAnalyzers() {
+		analyzersCount[cfg.getLinterNameForDiagnostic(&Diagnostic{Analyzer: a})]++
+	}
+
 	var issues []result.Issue
 	for i := range diags {
 		diag := &diags[i]
-		linterName := linterNameBuilder(diag)
+		linterName := cfg.getLinterNameForDiagnostic(diag)
+
+		// The analyzers of the linters made of several analyzers are their rules,
+		// the linters made of one analyzer have no rules.
+		var ruleID string
+		if analyzersCount[linterName] > 1 {
+			ruleID = diag.Analyzer.Name
+		}
+		docURL := cfg.getDocumentationURL(linterName, ruleID)
 
 		var text string
 		if diag.Analyzer.Name == linterName {
@@ -98,19 +165,23 @@ func buildIssues(diags []Diagnostic, linterNameBuilder func(diag *Diagnostic) st
 		}
 
 		issues = append(issues, result.Issue{
-			FromLinter: linterName,
-			Text:       text,
-			Pos:        diag.Position,
-			Pkg:        diag.Pkg,
+			FromLinter:       linterName,
+			RuleID:           ruleID,
+			DocumentationURL: docURL,
+			Text:             text,
+			Pos:              diag.Position,
+			Pkg:              diag.Pkg,
 		})
 
 		if len(diag.Related) > 0 {
 			for _, info := range diag.Related {
 				issues = append(issues, result.Issue{
-					FromLinter: linterName,
-					Text:       fmt.Sprintf("%s(related information): %s", diag.Analyzer.Name, info.Message),
-					Pos:        diag.Pkg.Fset.Position(info.Pos),
-					Pkg:        diag.Pkg,
+					FromLinter:       linterName,
+					RuleID:           ruleID,
+					DocumentationURL: docURL,
+					Text:             fmt.Sprintf("%s(related information): %s", diag.Analyzer.Name, info.Message),
+					Pos:              diag.Pkg.Fset.Position(info.Pos),
+					Pkg:              diag.Pkg,
 				})
 			}
 		}
@@ -118,8 +189,8 @@ func buildIssues(diags []Diagnostic, linterNameBuilder func(diag *Diagnostic) st
 	return issues
 }
 
-func getIssuesCacheKey(analyzers []*analysis.Analyzer) string {
-	return "lint/result:" + analyzersHashID(analyzers)
+func getIssuesCacheKey(analyzers []*analysis.Analyzer, salt string) string {
+	return "lint/result/v2:" + analyzersHashID(analyzers) + salt
 }
 
 func saveIssuesToCache(allPkgs []*packages.Package, pkgsFromCache map[*packages.Package]bool,
@@ -132,7 +203,7 @@ func saveIssuesToCache(allPkgs []*packages.Package, pkgsFromCache map[*packages.
 	}
 
 	savedIssuesCount := int32(0)
-	lintResKey := getIssuesCacheKey(analyzers)
+	lintResKey := getIssuesCacheKey(analyzers, lintCtx.CacheSalt)
 
 	workerCount := runtime.GOMAXPROCS(-1)
 	var wg sync.WaitGroup
@@ -149,6 +220,8 @@ func saveIssuesToCache(allPkgs []*packages.Package, pkgsFromCache map[*packages.
 					i := &pkgIssues[ind]
 					encodedIssues = append(encodedIssues, EncodingIssue{
 						FromLinter:           i.FromLinter,
+						RuleID:               i.RuleID,
+						DocumentationURL:     i.DocumentationURL,
 						Text:                 i.Text,
 						Pos:                  i.Pos,
 						LineRange:            i.LineRange,
@@ -186,7 +259,7 @@ func loadIssuesFromCache(pkgs []*packages.Package, lintCtx *linter.Context,
 	analyzers []*analysis.Analyzer) ([]result.Issue, map[*packages.Package]bool) {
 	startedAt := time.Now()
 
-	lintResKey := getIssuesCacheKey(analyzers)
+	lintResKey := getIssuesCacheKey(analyzers, lintCtx.CacheSalt)
 	type cacheRes struct {
 		issues  []result.Issue
 		loadErr error
@@ -220,6 +293,8 @@ func loadIssuesFromCache(pkgs []*packages.Package, lintCtx *linter.Context,
 				for _, i := range pkgIssues {
 					issues = append(issues, result.Issue{
 						FromLinter:           i.FromLinter,
+						RuleID:               i.RuleID,
+						DocumentationURL:     i.DocumentationURL,
 						Text:                 i.Text,
 						Pos:                  i.Pos,
 						LineRange:            i.LineRange,
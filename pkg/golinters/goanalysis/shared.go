@@ -0,0 +1,57 @@
+package goanalysis
+
+import (
+	"reflect"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+)
+
+// The shared analyzers are the expensive dependencies of the analyzers, like the SSA form of the packages:
+// in an analysis pass, each one runs once per package, and its result is shared by all the analyzers requiring it.
+//
+// The analyzers requiring the same analyzer share its action, so the wrappers of the linters should
+// require these analyzers (e.g. buildssa.Analyzer) instead of building the same results in their Run.
+// A linter requiring an exact copy of a shared analyzer (an analyzer with the same name, the same Run function,
+// the same result type and no facts, e.g. a vendored copy of the struct) gets the result of the shared analyzer too:
+// an analyzer wrapping or forking a shared analyzer under its name keeps its own result.
+// The wrappers requiring other expensive dependencies register them with RegisterSharedAnalyzer.
+var (
+	sharedAnalyzers = map[string]*analysis.Analyzer{
+		buildssa.Analyzer.Name: buildssa.Analyzer,
+		ctrlflow.Analyzer.Name: ctrlflow.Analyzer,
+		inspect.Analyzer.Name:  inspect.Analyzer,
+	}
+	sharedAnalyzersMu sync.RWMutex
+)
+
+// RegisterSharedAnalyzer registers a dependency of the analyzers whose copies must be replaced by it.
+// It must be called before the analysis, e.g. in the constructor of the linter.
+func RegisterSharedAnalyzer(a *analysis.Analyzer) {
+	sharedAnalyzersMu.Lock()
+	defer sharedAnalyzersMu.Unlock()
+
+	sharedAnalyzers[a.Name] = a
+}
+
+// sharedAnalyzer returns the shared analyzer equivalent to the required analyzer, or the required analyzer.
+func sharedAnalyzer(a *analysis.Analyzer) *analysis.Analyzer {
+	sharedAnalyzersMu.RLock()
+	shared := sharedAnalyzers[a.Name]
+	sharedAnalyzersMu.RUnlock()
+
+	if shared == nil || shared == a {
+		return a
+	}
+
+	// The results must be the same.
+	if shared.ResultType != a.ResultType || len(shared.FactTypes) != 0 || len(a.FactTypes) != 0 ||
+		reflect.ValueOf(shared.Run).Pointer() != reflect.ValueOf(a.Run).Pointer() {
+		return a
+	}
+
+	return shared
+}
@@ -0,0 +1,91 @@
+package goanalysis
+
+import (
+	"context"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/timeutils"
+)
+
+func TestRunner_sharedAnalyzers(t *testing.T) {
+	var builds int32
+	shared := &analysis.Analyzer{
+		Name: "testshared",
+		Run: func(*analysis.Pass) (interface{}, error) {
+			atomic.AddInt32(&builds, 1)
+			return "built", nil
+		},
+		ResultType: reflect.TypeOf(""),
+	}
+	RegisterSharedAnalyzer(shared)
+	defer func() {
+		sharedAnalyzersMu.Lock()
+		delete(sharedAnalyzers, shared.Name)
+		sharedAnalyzersMu.Unlock()
+	}()
+
+	sharedCopy := *shared
+
+	var (
+		results   []interface{}
+		resultsMu sync.Mutex
+	)
+	newAnalyzer := func(name string, req *analysis.Analyzer) *analysis.Analyzer {
+		return &analysis.Analyzer{
+			Name:     name,
+			Requires: []*analysis.Analyzer{req},
+			Run: func(pass *analysis.Pass) (interface{}, error) {
+				resultsMu.Lock()
+				results = append(results, pass.ResultOf[req])
+				resultsMu.Unlock()
+				return nil, nil
+			},
+		}
+	}
+
+	file := filepath.Join(t.TempDir(), "p.go")
+	require.NoError(t, os.WriteFile(file, []byte("package p\n"), 0o600))
+	pkg := &packages.Package{
+		ID: "example.com/p", Name: "p", PkgPath: "example.com/p",
+		Fset: token.NewFileSet(), CompiledGoFiles: []string{file},
+	}
+
+	r := newRunner(context.Background(), "test", logutils.NewStderrLog(""), nil, nil, LoadModeSyntax,
+		timeutils.NewStopwatch("test", logutils.NewStderrLog("")))
+	r.analyze([]*packages.Package{pkg}, []*analysis.Analyzer{newAnalyzer("a", shared), newAnalyzer("b", &sharedCopy)})
+
+	assert.Equal(t, int32(1), builds)
+	assert.Equal(t, []interface{}{"built", "built"}, results)
+}
+
+func TestSharedAnalyzer(t *testing.T) {
+	shared := sharedAnalyzers["buildssa"]
+
+	sameCopy := *shared
+	assert.Same(t, shared, sharedAnalyzer(&sameCopy))
+
+	otherResult := sameCopy
+	otherResult.ResultType = reflect.TypeOf(0)
+	assert.Same(t, &otherResult, sharedAnalyzer(&otherResult))
+
+	wrapper := sameCopy
+	wrapper.Run = func(pass *analysis.Pass) (interface{}, error) {
+		return shared.Run(pass)
+	}
+	assert.Same(t, &wrapper, sharedAnalyzer(&wrapper), "a wrapper can build another result")
+
+	other := &analysis.Analyzer{Name: "other"}
+	assert.Same(t, other, sharedAnalyzer(other))
+}
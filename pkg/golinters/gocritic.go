@@ -141,6 +141,7 @@ func runGocriticOnFile(linterCtx *gocriticlinter.Context, f *ast.File, checkers
 				Pos:        pos,
 				Text:       fmt.Sprintf("%s: %s", c.Info.Name, warn.Text),
 				FromLinter: gocriticName,
+				RuleID:     c.Info.Name,
 			}
 
 			if warn.HasQuickFix() {
@@ -27,12 +27,6 @@ func NewGodot(settings *config.GodotSettings) *goanalysis.Linter {
 			Period:  settings.Period,
 			Capital: settings.Capital,
 		}
-
-		// Convert deprecated setting
-		// todo(butuzov): remove on v2 release
-		if settings.CheckAll { // nolint:staticcheck // Keep for retro-compatibility.
-			dotSettings.Scope = godot.AllScope
-		}
 	}
 
 	if dotSettings.Scope == "" {
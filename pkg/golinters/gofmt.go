@@ -1,6 +1,8 @@
 package golinters
 
 import (
+	"bytes"
+	"os"
 	"sync"
 
 	gofmtAPI "github.com/golangci/gofmt/gofmt"
@@ -8,6 +10,7 @@ import (
 	"golang.org/x/tools/go/analysis"
 
 	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 )
@@ -62,7 +65,7 @@ func runGofmt(lintCtx *linter.Context, pass *analysis.Pass, settings *config.GoF
 	var issues []goanalysis.Issue
 
 	for _, f := range fileNames {
-		diff, err := gofmtAPI.Run(f, settings.Simplify)
+		diff, err := runGofmtOnFile(f, settings.Simplify)
 		if err != nil { // TODO: skip
 			return nil, err
 		}
@@ -82,3 +85,51 @@ func runGofmt(lintCtx *linter.Context, pass *analysis.Pass, settings *config.GoF
 
 	return issues, nil
 }
+
+// runGofmtOnFile returns the diff of the formatting of the file, or of the content replacing it.
+func runGofmtOnFile(path string, simplify bool) ([]byte, error) {
+	content, ok := fsutils.OverlayContent(path)
+	if !ok {
+		return gofmtAPI.Run(path, simplify)
+	}
+
+	return runGofmtOnContent(path, content, simplify)
+}
+
+// GofmtSource formats the source of the file like the linter gofmt, simplified if simplify is set.
+func GofmtSource(path string, src []byte, simplify bool) ([]byte, error) {
+	diff, err := runGofmtOnContent(path, src, simplify)
+	if err != nil {
+		return nil, err
+	}
+	if diff == nil {
+		return src, nil
+	}
+
+	return applyPatch(src, diff)
+}
+
+// runGofmtOnContent returns the diff of the formatting of the content of the file:
+// gofmt reads the files from disk, the content is formatted in a temporary file.
+func runGofmtOnContent(path string, content []byte, simplify bool) ([]byte, error) {
+	tmp, err := os.CreateTemp("", "gofmt-*.go")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := gofmtAPI.Run(tmp.Name(), simplify)
+	if err != nil || diff == nil {
+		return nil, err
+	}
+
+	return bytes.ReplaceAll(diff, []byte(tmp.Name()), []byte(path)), nil
+}
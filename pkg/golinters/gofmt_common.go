@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/shazow/go-diff/difflib"
 	diffpkg "github.com/sourcegraph/go-diff/diff"
 
 	"github.com/golangci/golangci-lint/pkg/config"
@@ -276,3 +277,79 @@ func extractIssuesFromPatch(patch string, lintCtx *linter.Context, linterName st
 
 	return issues, nil
 }
+
+// applyPatch applies the unified diff of the formatting of a file to its content.
+// The formatted files end with a newline: the lines without newline of the diff are ignored.
+func applyPatch(src, patch []byte) ([]byte, error) {
+	diffs, err := diffpkg.ParseMultiFileDiff(patch)
+	if err != nil {
+		return nil, err
+	}
+	if len(diffs) != 1 {
+		return nil, fmt.Errorf("got %d file diffs, expected one", len(diffs))
+	}
+
+	lines := strings.SplitAfter(string(src), "\n")
+	next := 0 // the index of the next original line
+
+	var out bytes.Buffer
+	for _, h := range diffs[0].Hunks {
+		start := int(h.OrigStartLine) - 1
+		if h.OrigLines == 0 {
+			start++ // the lines are added after the start line
+		}
+		if start < next || start > len(lines) {
+			return nil, fmt.Errorf("invalid hunk at line %d", h.OrigStartLine)
+		}
+		for ; next < start; next++ {
+			out.WriteString(lines[next])
+		}
+
+		for _, line := range strings.SplitAfter(string(h.Body), "\n") {
+			if line == "" {
+				continue
+			}
+
+			switch line[0] {
+			case ' ', '-':
+				if next >= len(lines) {
+					return nil, fmt.Errorf("invalid hunk at line %d", h.OrigStartLine)
+				}
+				if line[0] == ' ' {
+					out.WriteString(lines[next])
+				}
+				next++
+			case '+':
+				out.WriteString(line[1:])
+			}
+		}
+	}
+
+	for ; next < len(lines); next++ {
+		out.WriteString(lines[next])
+	}
+
+	return out.Bytes(), nil
+}
+
+// diffOverlay returns the unified diff of the formatting of the content replacing the file, nil if it's formatted:
+// the formatters reading the files from disk would ignore the overlay, e.g. of the standard input.
+func diffOverlay(path string, content []byte, format func(src []byte) ([]byte, error)) ([]byte, error) {
+	output, err := format(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(content, output) {
+		return nil, nil
+	}
+
+	out := bytes.Buffer{}
+	out.WriteString(fmt.Sprintf("--- %[1]s\n+++ %[1]s\n", path))
+
+	if err := difflib.New().Diff(&out, bytes.NewReader(content), bytes.NewReader(output)); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
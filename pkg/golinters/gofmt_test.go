@@ -1,12 +1,16 @@
 package golinters
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	diffpkg "github.com/sourcegraph/go-diff/diff"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
@@ -348,3 +352,54 @@ index 2c9f78d..c0d5791 100644
 		},
 	})
 }
+
+func TestRunGofmtOnFile_overlay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "p.go")
+	require.NoError(t, os.WriteFile(path, []byte("package p\n\nfunc f() {}\n"), 0o600))
+
+	diff, err := runGofmtOnFile(path, true)
+	require.NoError(t, err)
+	assert.Nil(t, diff, "formatted on disk")
+
+	require.NoError(t, fsutils.SetOverlay(path, []byte("package p\n\nfunc f()  {}\n")))
+
+	diff, err = runGofmtOnFile(path, true)
+	require.NoError(t, err)
+	assert.Contains(t, string(diff), "+++ "+path)
+	assert.Contains(t, string(diff), "+func f() {}")
+
+	diff, err = runGoimportsOnFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(diff), "+++ "+path)
+	assert.Contains(t, string(diff), "+func f() {}")
+
+	issues, err := getLLLIssuesForFile(path, 11, " ")
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, 3, issues[0].Line())
+}
+
+func TestGofmtSource(t *testing.T) {
+	src := []byte("package p\n\nvar s = []struct{ a int }{struct{ a int }{1}}\n\nfunc f()  {}\n\nfunc g() {}\n")
+
+	formatted, err := GofmtSource("p.go", src, false)
+	require.NoError(t, err)
+	assert.Equal(t, "package p\n\nvar s = []struct{ a int }{struct{ a int }{1}}\n\nfunc f() {}\n\nfunc g() {}\n", string(formatted))
+
+	formatted, err = GofmtSource("p.go", src, true)
+	require.NoError(t, err)
+	assert.Equal(t, "package p\n\nvar s = []struct{ a int }{{1}}\n\nfunc f() {}\n\nfunc g() {}\n", string(formatted))
+
+	formatted, err = GofmtSource("p.go", formatted, true)
+	require.NoError(t, err)
+	assert.Equal(t, "package p\n\nvar s = []struct{ a int }{{1}}\n\nfunc f() {}\n\nfunc g() {}\n", string(formatted), "formatted")
+}
+
+func TestApplyPatch(t *testing.T) {
+	src := "a\nb\nc\nd\n"
+	patch := "--- p.go\n+++ p.go\n@@ -0,0 +1 @@\n+start\n@@ -2,2 +3,2 @@\n-b\n+B\n c\n@@ -4,0 +6 @@\n+end\n"
+
+	out, err := applyPatch([]byte(src), []byte(patch))
+	require.NoError(t, err)
+	assert.Equal(t, "start\na\nB\nc\nd\nend\n", string(out))
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"os"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -13,6 +12,7 @@ import (
 	"mvdan.cc/gofumpt/format"
 
 	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 )
@@ -32,11 +32,7 @@ func NewGofumpt(settings *config.GofumptSettings) *goanalysis.Linter {
 	var options format.Options
 
 	if settings != nil {
-		options = format.Options{
-			LangVersion: getLangVersion(settings),
-			ModulePath:  settings.ModulePath,
-			ExtraRules:  settings.ExtraRules,
-		}
+		options = GofumptOptions(settings)
 	}
 
 	analyzer := &analysis.Analyzer{
@@ -82,7 +78,7 @@ func runGofumpt(lintCtx *linter.Context, pass *analysis.Pass, diff differ, optio
 	var issues []goanalysis.Issue
 
 	for _, f := range fileNames {
-		input, err := os.ReadFile(f)
+		input, err := fsutils.ReadFile(f)
 		if err != nil {
 			return nil, fmt.Errorf("unable to open file %s: %w", f, err)
 		}
@@ -119,6 +115,15 @@ func runGofumpt(lintCtx *linter.Context, pass *analysis.Pass, diff differ, optio
 	return issues, nil
 }
 
+// GofumptOptions returns the options of gofumpt, the linter and the formatter of the fixed files.
+func GofumptOptions(settings *config.GofumptSettings) format.Options {
+	return format.Options{
+		LangVersion: getLangVersion(settings),
+		ModulePath:  settings.ModulePath,
+		ExtraRules:  settings.ExtraRules,
+	}
+}
+
 func getLangVersion(settings *config.GofumptSettings) string {
 	if settings == nil || settings.LangVersion == "" {
 		// TODO: defaults to "1.15", in the future (v2) must be set by using build.Default.ReleaseTags like staticcheck.
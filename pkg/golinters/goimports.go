@@ -9,6 +9,7 @@ import (
 	"golang.org/x/tools/imports"
 
 	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 )
@@ -64,7 +65,7 @@ func runGoiImports(lintCtx *linter.Context, pass *analysis.Pass) ([]goanalysis.I
 	var issues []goanalysis.Issue
 
 	for _, f := range fileNames {
-		diff, err := goimportsAPI.Run(f)
+		diff, err := runGoimportsOnFile(f)
 		if err != nil { // TODO: skip
 			return nil, err
 		}
@@ -84,3 +85,24 @@ func runGoiImports(lintCtx *linter.Context, pass *analysis.Pass) ([]goanalysis.I
 
 	return issues, nil
 }
+
+// runGoimportsOnFile returns the diff of the formatting of the file, or of the content replacing it.
+func runGoimportsOnFile(path string) ([]byte, error) {
+	content, ok := fsutils.OverlayContent(path)
+	if !ok {
+		return goimportsAPI.Run(path)
+	}
+
+	return diffOverlay(path, content, func(src []byte) ([]byte, error) {
+		return imports.Process(path, src, goimportsOptions)
+	})
+}
+
+// goimportsOptions are the options of goimportsAPI.Run.
+var goimportsOptions = &imports.Options{TabWidth: 8, TabIndent: true, Comments: true, Fragment: true}
+
+// GoimportsSource formats the source of the file with goimports, the local prefixes are the ones of the settings.
+func GoimportsSource(path string, src []byte, settings *config.GoImportsSettings) ([]byte, error) {
+	imports.LocalPrefix = settings.LocalPrefixes
+	return imports.Process(path, src, goimportsOptions)
+}
@@ -12,27 +12,23 @@ func NewGoMND(settings *config.GoMndSettings) *goanalysis.Linter {
 	var linterCfg map[string]map[string]interface{}
 
 	if settings != nil {
-		// TODO(ldez) For compatibility only, must be drop in v2.
-		if len(settings.Settings) > 0 {
-			linterCfg = settings.Settings
-		} else {
-			cfg := make(map[string]interface{})
-			if len(settings.Checks) > 0 {
-				cfg["checks"] = settings.Checks
-			}
-			if len(settings.IgnoredNumbers) > 0 {
-				cfg["ignored-numbers"] = settings.IgnoredNumbers
-			}
-			if len(settings.IgnoredFiles) > 0 {
-				cfg["ignored-files"] = settings.IgnoredFiles
-			}
-			if len(settings.IgnoredFunctions) > 0 {
-				cfg["ignored-functions"] = settings.IgnoredFunctions
-			}
+		// The deprecated settings.mnd options are converted by the config reader.
+		cfg := make(map[string]interface{})
+		if len(settings.Checks) > 0 {
+			cfg["checks"] = settings.Checks
+		}
+		if len(settings.IgnoredNumbers) > 0 {
+			cfg["ignored-numbers"] = settings.IgnoredNumbers
+		}
+		if len(settings.IgnoredFiles) > 0 {
+			cfg["ignored-files"] = settings.IgnoredFiles
+		}
+		if len(settings.IgnoredFunctions) > 0 {
+			cfg["ignored-functions"] = settings.IgnoredFunctions
+		}
 
-			linterCfg = map[string]map[string]interface{}{
-				"mnd": cfg,
-			}
+		linterCfg = map[string]map[string]interface{}{
+			"mnd": cfg,
 		}
 	}
 
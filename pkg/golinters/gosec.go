@@ -135,6 +135,7 @@ func runGoSec(lintCtx *linter.Context, pass *analysis.Pass, settings *config.GoS
 			Text:       text,
 			LineRange:  r,
 			FromLinter: gosecName,
+			RuleID:     i.RuleID,
 		}, pass))
 	}
 
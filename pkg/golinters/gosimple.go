@@ -17,5 +17,6 @@ func NewGosimple(settings *config.StaticCheckSettings) *goanalysis.Linter {
 		"Linter for Go source code that specializes in simplifying code",
 		analyzers,
 		nil,
-	).WithLoadMode(goanalysis.LoadModeTypesInfo)
+	).WithLoadMode(goanalysis.LoadModeTypesInfo).
+		WithDocumentationURL(staticCheckDocumentationURL)
 }
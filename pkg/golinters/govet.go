@@ -131,7 +131,10 @@ func NewGovet(settings *config.GovetSettings) *goanalysis.Linter {
 			"such as Printf calls whose arguments do not align with the format string",
 		analyzersFromConfig(settings),
 		conf,
-	).WithLoadMode(goanalysis.LoadModeTypesInfo)
+	).WithLoadMode(goanalysis.LoadModeTypesInfo).
+		WithDocumentationURL(func(ruleID string) string {
+			return "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/" + ruleID
+		})
 }
 
 func analyzersFromConfig(settings *config.GovetSettings) []*analysis.Analyzer {
@@ -2,9 +2,9 @@ package golinters
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"go/token"
-	"os"
 	"strings"
 	"sync"
 	"unicode/utf8"
@@ -12,6 +12,7 @@ import (
 	"golang.org/x/tools/go/analysis"
 
 	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/result"
@@ -82,14 +83,13 @@ func runLll(pass *analysis.Pass, settings *config.LllSettings) ([]goanalysis.Iss
 func getLLLIssuesForFile(filename string, maxLineLen int, tabSpaces string) ([]result.Issue, error) {
 	var res []result.Issue
 
-	f, err := os.Open(filename)
+	content, err := fsutils.ReadFile(filename)
 	if err != nil {
 		return nil, fmt.Errorf("can't open file %s: %s", filename, err)
 	}
-	defer f.Close()
 
 	lineNumber := 1
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
 	for scanner.Scan() {
 		line := scanner.Text()
 		line = strings.Replace(line, "\t", tabSpaces, -1)
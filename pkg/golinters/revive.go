@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"go/token"
-	"os"
 	"reflect"
 	"strings"
 	"sync"
@@ -18,6 +17,7 @@ import (
 	"golang.org/x/tools/go/analysis"
 
 	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/logutils"
@@ -91,7 +91,7 @@ func runRevive(lintCtx *linter.Context, pass *analysis.Pass, settings *config.Re
 		return nil, err
 	}
 
-	revive := lint.New(os.ReadFile, settings.MaxOpenFiles)
+	revive := lint.New(fsutils.ReadFile, settings.MaxOpenFiles)
 
 	lintingRules, err := reviveConfig.GetLintingRules(conf, []lint.Rule{})
 	if err != nil {
@@ -159,7 +159,9 @@ func reviveToIssue(pass *analysis.Pass, object *jsonObject) goanalysis.Issue {
 			From: object.Position.Start.Line,
 			To:   lineRangeTo,
 		},
-		FromLinter: reviveName,
+		FromLinter:       reviveName,
+		RuleID:           object.RuleName,
+		DocumentationURL: "https://github.com/mgechev/revive/blob/master/RULES_DESCRIPTIONS.md#" + object.RuleName,
 	}, pass)
 }
 
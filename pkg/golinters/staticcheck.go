@@ -17,5 +17,6 @@ func NewStaticcheck(settings *config.StaticCheckSettings) *goanalysis.Linter {
 			" The author of staticcheck doesn't support or approve the use of staticcheck as a library inside golangci-lint.",
 		analyzers,
 		nil,
-	).WithLoadMode(goanalysis.LoadModeTypesInfo)
+	).WithLoadMode(goanalysis.LoadModeTypesInfo).
+		WithDocumentationURL(staticCheckDocumentationURL)
 }
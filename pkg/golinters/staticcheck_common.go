@@ -27,6 +27,10 @@ func getGoVersion(settings *config.StaticCheckSettings) string {
 	return "1.17"
 }
 
+func staticCheckDocumentationURL(ruleID string) string {
+	return "https://staticcheck.io/docs/checks/#" + ruleID
+}
+
 func setupStaticCheckAnalyzers(src []*lint.Analyzer, goVersion string, checks []string) []*analysis.Analyzer {
 	var names []string
 	for _, a := range src {
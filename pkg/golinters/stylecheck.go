@@ -26,5 +26,6 @@ func NewStylecheck(settings *config.StaticCheckSettings) *goanalysis.Linter {
 		"Stylecheck is a replacement for golint",
 		analyzers,
 		nil,
-	).WithLoadMode(goanalysis.LoadModeTypesInfo)
+	).WithLoadMode(goanalysis.LoadModeTypesInfo).
+		WithDocumentationURL(staticCheckDocumentationURL)
 }
@@ -18,6 +18,19 @@ type EnvKey string
 const (
 	EnvGoCache EnvKey = "GOCACHE"
 	EnvGoRoot  EnvKey = "GOROOT"
+
+	EnvGoVersion   EnvKey = "GOVERSION"
+	EnvGoOS        EnvKey = "GOOS"
+	EnvGoArch      EnvKey = "GOARCH"
+	EnvGoFlags     EnvKey = "GOFLAGS"
+	EnvGoPath      EnvKey = "GOPATH"
+	EnvGoModCache  EnvKey = "GOMODCACHE"
+	EnvGo111Module EnvKey = "GO111MODULE"
+	EnvGoProxy     EnvKey = "GOPROXY"
+	EnvGoPrivate   EnvKey = "GOPRIVATE"
+	EnvGoWork      EnvKey = "GOWORK"
+	EnvGoToolchain EnvKey = "GOTOOLCHAIN"
+	EnvCgoEnabled  EnvKey = "CGO_ENABLED"
 )
 
 type Env struct {
@@ -35,9 +48,16 @@ func NewEnv(log logutils.Log) *Env {
 }
 
 func (e *Env) Discover(ctx context.Context) error {
+	return e.DiscoverKeys(ctx, EnvGoCache, EnvGoRoot)
+}
+
+// DiscoverKeys reads the values of the variables with 'go env'.
+func (e *Env) DiscoverKeys(ctx context.Context, keys ...EnvKey) error {
 	startedAt := time.Now()
 	args := []string{"env", "-json"}
-	args = append(args, string(EnvGoCache), string(EnvGoRoot))
+	for _, k := range keys {
+		args = append(args, string(k))
+	}
 	out, err := exec.CommandContext(ctx, "go", args...).Output()
 	if err != nil {
 		return errors.Wrap(err, "failed to run 'go env'")
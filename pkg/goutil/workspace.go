@@ -0,0 +1,157 @@
+package goutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+const goWorkFile = "go.work"
+
+// Workspace is a go.work workspace.
+type Workspace struct {
+	// Path is the absolute path of the go.work file.
+	Path string
+	// Modules are the absolute directories of the modules used by the workspace.
+	Modules []string
+}
+
+// FindWorkspace returns the workspace of the directory like the go command:
+// GOWORK is the path of the go.work file or "off", else the file is searched in the directory and its parents.
+// nil is returned outside a workspace.
+func FindWorkspace(dir string) (*Workspace, error) {
+	switch gowork := os.Getenv("GOWORK"); gowork {
+	case "off":
+		return nil, nil
+	case "":
+	default:
+		return ReadWorkspace(gowork)
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		path := filepath.Join(dir, goWorkFile)
+		if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
+			return ReadWorkspace(path)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// ReadWorkspace reads the go.work file.
+func ReadWorkspace(path string) (*Workspace, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	wf, err := modfile.ParseWork(path, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := &Workspace{Path: path}
+	for _, use := range wf.Use {
+		dir := filepath.FromSlash(use.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(path), dir)
+		}
+		ws.Modules = append(ws.Modules, filepath.Clean(dir))
+	}
+
+	if len(ws.Modules) == 0 {
+		return nil, fmt.Errorf("%s: no module is used", path)
+	}
+
+	return ws, nil
+}
+
+// ModulesIn returns the directories of the modules of the workspace inside the directory, relative to it:
+// "." is the directory itself.
+func (ws *Workspace) ModulesIn(dir string) ([]string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []string
+	for _, m := range ws.Modules {
+		rel, err := filepath.Rel(dir, m)
+		if err != nil {
+			return nil, err
+		}
+
+		if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		ret = append(ret, rel)
+	}
+
+	return ret, nil
+}
+
+// Patterns returns the patterns matching the packages of the modules of the workspace inside the directory:
+// "./..." doesn't match the modules in the subdirectories of a workspace.
+// The patterns are relative to the directory, nil is returned if no module is inside it.
+func (ws *Workspace) Patterns(dir string) ([]string, error) {
+	modules, err := ws.ModulesIn(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []string
+	for _, rel := range modules {
+		if rel == "." {
+			ret = append(ret, "./...")
+		} else {
+			ret = append(ret, "."+string(filepath.Separator)+filepath.Join(rel, "..."))
+		}
+	}
+
+	return ret, nil
+}
+
+// ExpandPatterns returns the patterns of the packages to load in the working directory, like the go command:
+// the default pattern is "./...", expanded to the modules of the workspace ws if not nil,
+// and the relative directories are prefixed by "./".
+func ExpandPatterns(ws *Workspace, patterns []string) []string {
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	ret := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		if ws != nil && (p == "./..." || p == "...") {
+			if wsPatterns, err := ws.Patterns("."); err == nil && len(wsPatterns) != 0 {
+				ret = append(ret, wsPatterns...)
+				continue
+			}
+		}
+
+		if strings.HasPrefix(p, ".") || filepath.IsAbs(p) {
+			ret = append(ret, p)
+		} else {
+			// go/packages doesn't work well without the prefix ./ for the local packages
+			ret = append(ret, "."+string(filepath.Separator)+p)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,106 @@
+package goutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGoWork = `go 1.18
+
+use (
+	./a
+	./tools/b
+	../c
+)
+`
+
+func writeTestWorkspace(t *testing.T) string {
+	t.Helper()
+
+	root := filepath.Join(t.TempDir(), "ws")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "a", "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.work"), []byte(testGoWork), 0o600))
+
+	return root
+}
+
+func TestFindWorkspace(t *testing.T) {
+	t.Setenv("GOWORK", "")
+	root := writeTestWorkspace(t)
+
+	ws, err := FindWorkspace(filepath.Join(root, "a", "sub"))
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+
+	assert.Equal(t, filepath.Join(root, "go.work"), ws.Path)
+	assert.Equal(t, []string{
+		filepath.Join(root, "a"),
+		filepath.Join(root, "tools", "b"),
+		filepath.Join(filepath.Dir(root), "c"),
+	}, ws.Modules)
+
+	ws, err = FindWorkspace(filepath.Dir(root))
+	require.NoError(t, err)
+	assert.Nil(t, ws)
+}
+
+func TestFindWorkspace_env(t *testing.T) {
+	root := writeTestWorkspace(t)
+
+	t.Setenv("GOWORK", "off")
+	ws, err := FindWorkspace(root)
+	require.NoError(t, err)
+	assert.Nil(t, ws)
+
+	t.Setenv("GOWORK", filepath.Join(root, "go.work"))
+	ws, err = FindWorkspace(t.TempDir())
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Len(t, ws.Modules, 3)
+}
+
+func TestWorkspace_Patterns(t *testing.T) {
+	root := filepath.FromSlash("/src/ws")
+	ws := &Workspace{Modules: []string{
+		filepath.Join(root, "a"),
+		filepath.Join(root, "tools", "b"),
+		filepath.FromSlash("/src/c"),
+	}}
+
+	patterns, err := ws.Patterns(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"." + string(filepath.Separator) + filepath.Join("a", "..."),
+		"." + string(filepath.Separator) + filepath.Join("tools", "b", "..."),
+	}, patterns)
+
+	patterns, err = ws.Patterns(filepath.Join(root, "a"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"./..."}, patterns)
+
+	patterns, err = ws.Patterns(filepath.Join(root, "a", "sub"))
+	require.NoError(t, err)
+	assert.Empty(t, patterns)
+}
+
+func TestExpandPatterns(t *testing.T) {
+	sep := string(filepath.Separator)
+
+	assert.Equal(t, []string{"./..."}, ExpandPatterns(nil, nil))
+	assert.Equal(t, []string{"." + sep + "pkg", "./cmd/...", filepath.FromSlash("/src/x")},
+		ExpandPatterns(nil, []string{"pkg", "./cmd/...", filepath.FromSlash("/src/x")}))
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	ws := &Workspace{Modules: []string{filepath.Join(wd, "a"), filepath.Join(filepath.Dir(wd), "c")}}
+	assert.Equal(t, []string{"." + sep + filepath.Join("a", "..."), "." + sep + "pkg"},
+		ExpandPatterns(ws, []string{"...", "pkg"}))
+
+	ws = &Workspace{Modules: []string{filepath.Join(filepath.Dir(wd), "c")}}
+	assert.Equal(t, []string{"./..."}, ExpandPatterns(ws, nil), "no module in the directory")
+}
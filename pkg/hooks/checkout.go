@@ -0,0 +1,221 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Checkout is the content of the index checked out in a temporary directory:
+// the staged content of the files is linted and fixed without the unstaged changes of the working tree.
+type Checkout struct {
+	// Dir is the root of the checkout.
+	Dir string
+	// Files are the staged Go files, relative to the root, with slashes.
+	Files []string
+
+	repo *Repo
+	// staged are the contents of the staged files before the fixes.
+	staged map[string][]byte
+}
+
+// CheckoutIndex checks out the index in a temporary directory. It returns nil if no Go file is staged.
+func (r *Repo) CheckoutIndex(ctx context.Context) (*Checkout, error) {
+	out, err := r.git(ctx, nil, "diff", "--cached", "--name-only", "-z", "--diff-filter=ACMR", "--", "*.go")
+	if err != nil {
+		return nil, err
+	}
+
+	files := splitNul(out)
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	dir, err := os.MkdirTemp("", "golangci-lint-index-")
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Checkout{Dir: dir, Files: files, repo: r, staged: map[string][]byte{}}
+
+	if _, err = r.git(ctx, nil, "checkout-index", "--all", "--prefix="+dir+string(filepath.Separator)); err != nil {
+		_ = c.Remove()
+		return nil, err
+	}
+
+	for _, f := range files {
+		content, err := os.ReadFile(c.path(f))
+		if err != nil {
+			_ = c.Remove()
+			return nil, err
+		}
+		c.staged[f] = content
+	}
+
+	return c, nil
+}
+
+// Remove removes the checkout.
+func (c *Checkout) Remove() error {
+	return os.RemoveAll(c.Dir)
+}
+
+func (c *Checkout) path(file string) string {
+	return filepath.Join(c.Dir, filepath.FromSlash(file))
+}
+
+// Patch returns the diff of the staged changes, relative to the root: the changes of the commit.
+func (c *Checkout) Patch(ctx context.Context) ([]byte, error) {
+	return c.repo.git(ctx, nil, "diff", "--cached", "--no-color", "--no-ext-diff", "--src-prefix=a/", "--dst-prefix=b/")
+}
+
+// Packages returns the directories of the packages of the staged files, as patterns relative to the root.
+func (c *Checkout) Packages() []string {
+	seen := map[string]bool{}
+	var dirs []string
+	for _, f := range c.Files {
+		dir := path.Dir(f)
+		if dir != "." {
+			dir = "./" + dir
+		}
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, filepath.FromSlash(dir))
+		}
+	}
+
+	sort.Strings(dirs)
+	return dirs
+}
+
+// Stage stages the fixes of the staged files, and applies them to the working tree.
+// The fixes of a partially staged file are merged with its unstaged changes:
+// on a conflict, they are only staged, and the file is returned in conflicts.
+func (c *Checkout) Stage(ctx context.Context) (fixed, conflicts []string, err error) {
+	for _, f := range c.Files {
+		content, err := os.ReadFile(c.path(f))
+		if err != nil {
+			return fixed, conflicts, err
+		}
+		if bytes.Equal(content, c.staged[f]) {
+			continue
+		}
+
+		if err = c.stageFile(ctx, f, content); err != nil {
+			return fixed, conflicts, err
+		}
+		fixed = append(fixed, f)
+
+		ok, err := c.updateWorkingTree(ctx, f, content)
+		if err != nil {
+			return fixed, conflicts, err
+		}
+		if !ok {
+			conflicts = append(conflicts, f)
+		}
+	}
+
+	return fixed, conflicts, nil
+}
+
+// stageFile replaces the content of the file in the index, with its mode.
+func (c *Checkout) stageFile(ctx context.Context, file string, content []byte) error {
+	out, err := c.repo.git(ctx, nil, "ls-files", "--stage", "-z", "--", file)
+	if err != nil {
+		return err
+	}
+
+	fields := strings.Fields(string(out))
+	if len(fields) < 2 {
+		return fmt.Errorf("%s isn't in the index", file)
+	}
+	mode := fields[0]
+
+	out, err = c.repo.git(ctx, content, "hash-object", "-w", "--stdin", "--path", file)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.repo.git(ctx, nil, "update-index", "--cacheinfo", mode+","+strings.TrimSpace(string(out))+","+file)
+	return err
+}
+
+// updateWorkingTree applies the fixes to the file of the working tree:
+// the file is replaced if it has no unstaged changes, otherwise the fixes are merged with them.
+// It returns false if the fixes conflict with the unstaged changes: the file is kept.
+func (c *Checkout) updateWorkingTree(ctx context.Context, file string, fixed []byte) (bool, error) {
+	wtPath := filepath.Join(c.repo.Root, filepath.FromSlash(file))
+
+	current, err := os.ReadFile(wtPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return true, nil // deleted in the working tree
+		}
+		return false, err
+	}
+
+	content := fixed
+	if !bytes.Equal(current, c.staged[file]) {
+		var ok bool
+		content, ok, err = c.merge(ctx, wtPath, file, fixed)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	info, err := os.Stat(wtPath)
+	if err != nil {
+		return false, err
+	}
+
+	return true, os.WriteFile(wtPath, content, info.Mode().Perm())
+}
+
+// merge merges the unstaged changes of the file of the working tree and the fixes with a three-way merge.
+func (c *Checkout) merge(ctx context.Context, wtPath, file string, fixed []byte) ([]byte, bool, error) {
+	dir, err := os.MkdirTemp("", "golangci-lint-merge-")
+	if err != nil {
+		return nil, false, err
+	}
+	defer os.RemoveAll(dir)
+
+	base, other := filepath.Join(dir, "base"), filepath.Join(dir, "fixed")
+	if err = os.WriteFile(base, c.staged[file], 0o600); err != nil {
+		return nil, false, err
+	}
+	if err = os.WriteFile(other, fixed, 0o600); err != nil {
+		return nil, false, err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "merge-file", "-p", "-q", wtPath, base, other)
+	cmd.Dir = c.repo.Root
+
+	out, err := cmd.Output()
+	if err != nil {
+		// The exit code is the count of the conflicts, negative on an error.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("git merge-file: %w", err)
+	}
+
+	return out, true, nil
+}
+
+func splitNul(out []byte) []string {
+	var ret []string
+	for _, s := range strings.Split(string(out), "\x00") {
+		if s != "" {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,224 @@
+// Package hooks installs the git hooks running golangci-lint,
+// and checks out the staged content of a commit to lint it and stage its fixes.
+package hooks
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	PreCommit = "pre-commit"
+	PrePush   = "pre-push"
+)
+
+// marker is the line identifying the hooks managed by golangci-lint.
+const marker = "# Managed by golangci-lint: 'golangci-lint hooks uninstall' removes it."
+
+var ErrNotManaged = errors.New("the hook isn't managed by golangci-lint")
+
+// Repo is a git repository.
+type Repo struct {
+	// Root is the root directory of the working tree.
+	Root string
+}
+
+// Open returns the git repository containing the directory.
+func Open(ctx context.Context, dir string) (*Repo, error) {
+	out, err := (&Repo{Root: dir}).git(ctx, nil, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repo{Root: filepath.FromSlash(strings.TrimSpace(string(out)))}, nil
+}
+
+// git runs the git command in the root of the repository.
+func (r *Repo) git(ctx context.Context, stdin []byte, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = r.Root
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return out, nil
+}
+
+// Script returns the script of the hook, running the binary of golangci-lint.
+func Script(hook, binary string) string {
+	return fmt.Sprintf("#!/bin/sh\n%s\nexec %s hooks run --hook %s\n", marker, shellQuote(binary), hook)
+}
+
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// hookPath returns the path of the hook, in the hooks directory of the repository (core.hooksPath, if set).
+func (r *Repo) hookPath(ctx context.Context, hook string) (string, error) {
+	if hook != PreCommit && hook != PrePush {
+		return "", fmt.Errorf("unsupported hook %q: must be %s or %s", hook, PreCommit, PrePush)
+	}
+
+	out, err := r.git(ctx, nil, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.FromSlash(strings.TrimSpace(string(out)))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(r.Root, dir)
+	}
+
+	return filepath.Join(dir, hook), nil
+}
+
+// Install writes the hook running the binary. An existing hook not managed by golangci-lint is replaced only with force.
+func (r *Repo) Install(ctx context.Context, hook, binary string, force bool) (string, error) {
+	path, err := r.hookPath(ctx, hook)
+	if err != nil {
+		return "", err
+	}
+
+	if !force {
+		if err := checkManaged(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("%s: %w: use --force to replace it", path, err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(path, []byte(Script(hook, binary)), 0o755); err != nil { //nolint:gosec // the hook is executable
+		return "", err
+	}
+
+	// The mode of an existing file isn't changed by WriteFile.
+	return path, os.Chmod(path, 0o755)
+}
+
+// Uninstall removes the hook, if it's managed by golangci-lint.
+func (r *Repo) Uninstall(ctx context.Context, hook string) (string, error) {
+	path, err := r.hookPath(ctx, hook)
+	if err != nil {
+		return "", err
+	}
+
+	if err := checkManaged(path); err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+
+	return path, os.Remove(path)
+}
+
+func checkManaged(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Contains(content, []byte(marker)) {
+		return ErrNotManaged
+	}
+
+	return nil
+}
+
+// RootRevision is the base of the pushed commits without parent: all their content is new.
+const RootRevision = "root"
+
+const zeroSHA = "0000000000000000000000000000000000000000"
+
+// PushBase returns the revision the pushed commits are based on, from the references of the pre-push hook:
+// the remote commit of an updated branch, or the parent of the oldest commit not on a remote for a new branch.
+// When several references are pushed, it returns the common ancestor of their bases: the commits of all of them are new.
+// It returns an empty revision if no commit is pushed.
+func (r *Repo) PushBase(ctx context.Context, refs io.Reader) (string, error) {
+	var bases []string
+	seen := map[string]bool{}
+
+	scanner := bufio.NewScanner(refs)
+	for scanner.Scan() {
+		// <local ref> <local sha> <remote ref> <remote sha>
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 4 || fields[1] == zeroSHA {
+			continue // a deleted reference
+		}
+
+		base, err := r.refBase(ctx, fields[1], fields[3])
+		if err != nil {
+			return "", err
+		}
+
+		if base == RootRevision {
+			return RootRevision, nil
+		}
+		if base != "" && !seen[base] {
+			seen[base] = true
+			bases = append(bases, base)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	switch len(bases) {
+	case 0:
+		return "", nil
+	case 1:
+		return bases[0], nil
+	}
+
+	out, err := r.git(ctx, nil, append([]string{"merge-base", "--octopus"}, bases...)...)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// refBase returns the base of the commits pushed from the local commit to the remote one,
+// or an empty revision if they're already on a remote.
+func (r *Repo) refBase(ctx context.Context, local, remote string) (string, error) {
+	if remote != zeroSHA {
+		return remote, nil
+	}
+
+	out, err := r.git(ctx, nil, "rev-list", "--reverse", local, "--not", "--remotes")
+	if err != nil {
+		return "", err
+	}
+
+	oldest, _, _ := strings.Cut(string(out), "\n")
+	if oldest == "" {
+		return "", nil // already on a remote
+	}
+
+	parent, err := r.git(ctx, nil, "rev-list", "--parents", "-n", "1", oldest)
+	if err != nil {
+		return "", err
+	}
+	if fields := strings.Fields(string(parent)); len(fields) > 1 {
+		return fields[1], nil
+	}
+	return RootRevision, nil
+}
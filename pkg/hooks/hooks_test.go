@@ -0,0 +1,217 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRepo(t *testing.T) *Repo {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+
+	r := &Repo{Root: t.TempDir()}
+	r.run(t, "init", "-q")
+	r.run(t, "config", "user.email", "test@example.com")
+	r.run(t, "config", "user.name", "test")
+	return r
+}
+
+func (r *Repo) run(t *testing.T, args ...string) string {
+	t.Helper()
+
+	out, err := r.git(context.Background(), nil, args...)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func (r *Repo) write(t *testing.T, file, content string) {
+	t.Helper()
+
+	path := filepath.Join(r.Root, filepath.FromSlash(file))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestScript(t *testing.T) {
+	assert.Equal(t, "#!/bin/sh\n"+marker+"\nexec golangci-lint hooks run --hook pre-commit\n", Script(PreCommit, "golangci-lint"))
+	assert.Contains(t, Script(PrePush, "/opt/my tools/golangci-lint"), "exec '/opt/my tools/golangci-lint' hooks run --hook pre-push\n")
+}
+
+func TestRepo_Install(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	path, err := r.Install(ctx, PreCommit, "golangci-lint", false)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(r.Root, ".git", "hooks", PreCommit), path)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode().Perm()&0o100, "executable")
+
+	_, err = r.Install(ctx, PreCommit, "golangci-lint", false)
+	require.NoError(t, err, "managed hook")
+
+	_, err = r.Install(ctx, "post-commit", "golangci-lint", false)
+	require.Error(t, err)
+
+	_, err = r.Uninstall(ctx, PreCommit)
+	require.NoError(t, err)
+	assert.NoFileExists(t, path)
+
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\nmake lint\n"), 0o600))
+
+	_, err = r.Install(ctx, PreCommit, "golangci-lint", false)
+	require.ErrorIs(t, err, ErrNotManaged)
+	_, err = r.Uninstall(ctx, PreCommit)
+	require.ErrorIs(t, err, ErrNotManaged)
+
+	_, err = r.Install(ctx, PreCommit, "golangci-lint", true)
+	require.NoError(t, err)
+}
+
+func TestRepo_CheckoutIndex(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	r.write(t, "go.mod", "module m\n")
+	r.write(t, "a.go", "package m\n\nvar a = 1\n")
+	r.write(t, "p/b.go", "package p\n\nvar b = 1\n")
+	r.run(t, "add", "-A")
+	r.run(t, "commit", "-q", "-m", "init")
+
+	c, err := r.CheckoutIndex(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, c, "no staged file")
+
+	// a.go is staged, then changed again; p/b.go is only staged.
+	r.write(t, "a.go", "package m\n\nvar a = 2\n\nvar c = 1\n")
+	r.write(t, "p/b.go", "package p\n\nvar b = 2\n")
+	r.run(t, "add", "-A")
+	r.write(t, "a.go", "package m\n\nvar a = 2\n\nvar c = 1\n\n// unstaged\n")
+
+	c, err = r.CheckoutIndex(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	defer func() { require.NoError(t, c.Remove()) }()
+
+	assert.Equal(t, []string{"a.go", "p/b.go"}, c.Files)
+	assert.Equal(t, []string{".", filepath.FromSlash("./p")}, c.Packages())
+	assert.FileExists(t, filepath.Join(c.Dir, "go.mod"))
+
+	content, err := os.ReadFile(filepath.Join(c.Dir, "a.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package m\n\nvar a = 2\n\nvar c = 1\n", string(content), "without the unstaged changes")
+
+	patch, err := c.Patch(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, string(patch), "+++ b/p/b.go\n")
+	assert.NotContains(t, string(patch), "unstaged")
+
+	// The fixes of the checkout.
+	require.NoError(t, os.WriteFile(filepath.Join(c.Dir, "a.go"), []byte("package m\n\nvar a = 3\n\nvar c = 1\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(c.Dir, "p", "b.go"), []byte("package p\n\nvar b = 3\n"), 0o600))
+
+	fixed, conflicts, err := c.Stage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go", "p/b.go"}, fixed)
+	assert.Empty(t, conflicts)
+
+	assert.Equal(t, "package m\n\nvar a = 3\n\nvar c = 1\n", r.run(t, "show", ":a.go"))
+	assert.Equal(t, "package p\n\nvar b = 3\n", r.run(t, "show", ":p/b.go"))
+
+	content, err = os.ReadFile(filepath.Join(r.Root, "a.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package m\n\nvar a = 3\n\nvar c = 1\n\n// unstaged\n", string(content), "merged with the unstaged changes")
+}
+
+func TestRepo_CheckoutIndex_conflict(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	r.write(t, "a.go", "package m\n\nvar a = 1\n")
+	r.run(t, "add", "-A")
+	r.write(t, "a.go", "package m\n\nvar a = 2\n")
+
+	c, err := r.CheckoutIndex(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	defer func() { require.NoError(t, c.Remove()) }()
+
+	require.NoError(t, os.WriteFile(filepath.Join(c.Dir, "a.go"), []byte("package m\n\nvar a = 3\n"), 0o600))
+
+	fixed, conflicts, err := c.Stage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go"}, fixed)
+	assert.Equal(t, []string{"a.go"}, conflicts)
+
+	assert.Equal(t, "package m\n\nvar a = 3\n", r.run(t, "show", ":a.go"))
+
+	content, err := os.ReadFile(filepath.Join(r.Root, "a.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package m\n\nvar a = 2\n", string(content), "kept")
+}
+
+func TestRepo_PushBase(t *testing.T) {
+	r := newTestRepo(t)
+	ctx := context.Background()
+
+	r.write(t, "a.go", "package m\n")
+	r.run(t, "add", "-A")
+	r.run(t, "commit", "-q", "-m", "first")
+	first := strings.TrimSpace(r.run(t, "rev-parse", "HEAD"))
+
+	r.write(t, "b.go", "package m\n")
+	r.run(t, "add", "-A")
+	r.run(t, "commit", "-q", "-m", "second")
+	second := strings.TrimSpace(r.run(t, "rev-parse", "HEAD"))
+
+	testCases := []struct {
+		desc     string
+		refs     string
+		expected string
+	}{
+		{desc: "updated branch", refs: "refs/heads/main " + second + " refs/heads/main " + first + "\n", expected: first},
+		{desc: "new branch", refs: "refs/heads/main " + second + " refs/heads/main " + zeroSHA + "\n", expected: RootRevision},
+		{desc: "deleted branch", refs: "(delete) " + zeroSHA + " refs/heads/main " + first + "\n"},
+		{desc: "nothing pushed"},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			rev, err := r.PushBase(ctx, strings.NewReader(test.refs))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, rev)
+		})
+	}
+
+	// The commits on a remote aren't pushed again.
+	r.run(t, "update-ref", "refs/remotes/origin/main", first)
+
+	rev, err := r.PushBase(ctx, strings.NewReader("refs/heads/topic "+second+" refs/heads/topic "+zeroSHA+"\n"))
+	require.NoError(t, err)
+	assert.Equal(t, first, rev)
+
+	r.write(t, "c.go", "package m\n")
+	r.run(t, "add", "-A")
+	r.run(t, "commit", "-q", "-m", "third")
+	third := strings.TrimSpace(r.run(t, "rev-parse", "HEAD"))
+
+	// The oldest base of the references.
+	rev, err = r.PushBase(ctx, strings.NewReader(
+		"refs/heads/main "+third+" refs/heads/main "+second+"\n"+
+			"refs/heads/topic "+second+" refs/heads/topic "+first+"\n"))
+	require.NoError(t, err)
+	assert.Equal(t, first, rev)
+}
@@ -0,0 +1,313 @@
+// Package incremental selects the packages affected by the changes of the working tree,
+// to analyze only them and reuse the issues of the previous run for the others.
+package incremental
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/goutil"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// Package is a package of the analyzed patterns.
+type Package struct {
+	ImportPath string
+	Dir        string
+	// Files are the absolute paths of the Go files of the package, including the test files.
+	Files []string
+	// Imports are the import paths of the package and of its tests.
+	Imports []string
+	// GoMod is the go.mod file of the module of the package, if any.
+	GoMod string
+}
+
+type listedPackage struct {
+	ImportPath   string
+	Dir          string
+	GoFiles      []string
+	CgoFiles     []string
+	TestGoFiles  []string
+	XTestGoFiles []string
+	Imports      []string
+	TestImports  []string
+	XTestImports []string
+	Module       *struct {
+		GoMod string
+	}
+}
+
+// ListPackages lists the packages matched by the patterns with "go list".
+func ListPackages(ctx context.Context, patterns, buildTags []string) ([]Package, error) {
+	args := []string{"list", "-e", "-json"}
+	if len(buildTags) != 0 {
+		args = append(args, "-tags", strings.Join(buildTags, " "))
+	}
+	args = append(args, "--")
+	// A malformed go.work is reported by the packages loader.
+	ws, _ := goutil.FindWorkspace(".")
+	args = append(args, goutil.ExpandPatterns(ws, patterns)...)
+
+	cmd := exec.CommandContext(ctx, "go", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseListOutput(out)
+}
+
+func parseListOutput(out []byte) ([]Package, error) {
+	var pkgs []Package
+
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var lp listedPackage
+		if err := dec.Decode(&lp); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("can't decode go list output: %w", err)
+		}
+
+		p := Package{ImportPath: lp.ImportPath, Dir: lp.Dir}
+		for _, files := range [][]string{lp.GoFiles, lp.CgoFiles, lp.TestGoFiles, lp.XTestGoFiles} {
+			for _, f := range files {
+				p.Files = append(p.Files, filepath.Join(lp.Dir, f))
+			}
+		}
+		p.Imports = append(p.Imports, lp.Imports...)
+		p.Imports = append(p.Imports, lp.TestImports...)
+		p.Imports = append(p.Imports, lp.XTestImports...)
+		if lp.Module != nil {
+			p.GoMod = lp.Module.GoMod
+		}
+
+		pkgs = append(pkgs, p)
+	}
+
+	return pkgs, nil
+}
+
+// Hashes returns the hashes of the packages by import path:
+// they change with the files of the packages and the go.mod and go.sum files of their modules.
+func Hashes(pkgs []Package) (map[string]string, error) {
+	moduleHashes := map[string][]byte{}
+
+	ret := make(map[string]string, len(pkgs))
+	for _, p := range pkgs {
+		h := sha256.New()
+		fmt.Fprintf(h, "%s\x00", p.ImportPath)
+
+		if p.GoMod != "" {
+			mh, ok := moduleHashes[p.GoMod]
+			if !ok {
+				var err error
+				mh, err = hashFiles(p.GoMod, filepath.Join(filepath.Dir(p.GoMod), "go.sum"))
+				if err != nil {
+					return nil, err
+				}
+				moduleHashes[p.GoMod] = mh
+			}
+			h.Write(mh)
+		}
+
+		fh, err := hashFiles(p.Files...)
+		if err != nil {
+			return nil, err
+		}
+		h.Write(fh)
+
+		ret[p.ImportPath] = hex.EncodeToString(h.Sum(nil))
+	}
+
+	return ret, nil
+}
+
+// hashFiles hashes the names and the contents of the files, the missing files are skipped.
+func hashFiles(files ...string) ([]byte, error) {
+	h := sha256.New()
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		fmt.Fprintf(h, "%s\x00%d\x00", filepath.Base(file), len(data))
+		h.Write(data)
+	}
+
+	return h.Sum(nil), nil
+}
+
+// State is the result of a run, to reuse the issues of the unaffected packages in the next run.
+type State struct {
+	Packages map[string]PackageState
+}
+
+// PackageState is the result of the analysis of a package.
+type PackageState struct {
+	Hash   string
+	Issues []result.Issue
+}
+
+// Affected returns the packages to analyze:
+// the packages with a changed file, the packages modified since the previous run, and their reverse dependencies.
+// All the packages are affected without a previous run.
+func Affected(pkgs []Package, changedFiles []string, hashes map[string]string, previous *State) []Package {
+	if previous == nil {
+		return pkgs
+	}
+
+	changedDirs := map[string]bool{}
+	changedModules := map[string]bool{}
+	for _, f := range changedFiles {
+		dir := filepath.Dir(f)
+		changedDirs[dir] = true
+
+		switch filepath.Base(f) {
+		case "go.mod", "go.sum":
+			changedModules[filepath.Join(dir, "go.mod")] = true
+		case "go.work", "go.work.sum":
+			return pkgs
+		}
+	}
+
+	var modified []string
+	for _, p := range pkgs {
+		prev, ok := previous.Packages[p.ImportPath]
+		if changedDirs[p.Dir] || changedModules[p.GoMod] || !ok || prev.Hash != hashes[p.ImportPath] {
+			modified = append(modified, p.ImportPath)
+		}
+	}
+
+	return withDependents(pkgs, modified)
+}
+
+// ReverseDependencies returns the packages of pkgs importing the targets, directly or not, with the targets:
+// the packages whose analysis may change with the targets.
+func ReverseDependencies(pkgs, targets []Package) []Package {
+	paths := make([]string, 0, len(targets))
+	for _, t := range targets {
+		paths = append(paths, t.ImportPath)
+	}
+
+	return withDependents(pkgs, paths)
+}
+
+// withDependents returns the packages of pkgs of the import paths, and the packages importing them, directly or not.
+func withDependents(pkgs []Package, paths []string) []Package {
+	affected := map[string]bool{}
+	var queue []string
+	mark := func(path string) {
+		if !affected[path] {
+			affected[path] = true
+			queue = append(queue, path)
+		}
+	}
+
+	for _, path := range paths {
+		mark(path)
+	}
+
+	dependents := map[string][]string{}
+	for _, p := range pkgs {
+		for _, imp := range p.Imports {
+			dependents[imp] = append(dependents[imp], p.ImportPath)
+		}
+	}
+
+	for len(queue) != 0 {
+		path := queue[0]
+		queue = queue[1:]
+
+		for _, dep := range dependents[path] {
+			mark(dep)
+		}
+	}
+
+	var ret []Package
+	for _, p := range pkgs {
+		if affected[p.ImportPath] {
+			ret = append(ret, p)
+		}
+	}
+
+	return ret
+}
+
+// Dirs returns the directories of the packages, to use them as patterns.
+func Dirs(pkgs []Package) []string {
+	ret := make([]string, 0, len(pkgs))
+	for _, p := range pkgs {
+		ret = append(ret, p.Dir)
+	}
+	return ret
+}
+
+// Merge returns the issues of the analysis of the affected packages with the previous issues of the other packages,
+// and the state of the run for the next one.
+func Merge(pkgs, affected []Package, hashes map[string]string, previous *State, issues []result.Issue) ([]result.Issue, *State) {
+	byDir := map[string]string{}
+	for _, p := range pkgs {
+		byDir[p.Dir] = p.ImportPath
+	}
+
+	fresh := map[string][]result.Issue{}
+	var merged []result.Issue
+	for i := range issues {
+		path, ok := byDir[issueDir(&issues[i])]
+		if !ok {
+			// not attached to a package: reported but not reused
+			merged = append(merged, issues[i])
+			continue
+		}
+		fresh[path] = append(fresh[path], issues[i])
+	}
+
+	isAffected := map[string]bool{}
+	for _, p := range affected {
+		isAffected[p.ImportPath] = true
+	}
+
+	next := &State{Packages: make(map[string]PackageState, len(pkgs))}
+	for _, p := range pkgs {
+		pkgIssues := fresh[p.ImportPath]
+		if !isAffected[p.ImportPath] && previous != nil {
+			pkgIssues = previous.Packages[p.ImportPath].Issues
+		}
+
+		next.Packages[p.ImportPath] = PackageState{Hash: hashes[p.ImportPath], Issues: pkgIssues}
+		merged = append(merged, pkgIssues...)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].FilePath() < merged[j].FilePath()
+	})
+
+	return merged, next
+}
+
+func issueDir(issue *result.Issue) string {
+	path := issue.FilePath()
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return filepath.Dir(path)
+}
@@ -0,0 +1,189 @@
+package incremental
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// testPackages are a -> b -> c, and d.
+func testPackages(root string) []Package {
+	return []Package{
+		{ImportPath: "m/a", Dir: filepath.Join(root, "a"), Imports: []string{"m/b", "fmt"}},
+		{ImportPath: "m/b", Dir: filepath.Join(root, "b"), Imports: []string{"m/c"}},
+		{ImportPath: "m/c", Dir: filepath.Join(root, "c")},
+		{ImportPath: "m/d", Dir: filepath.Join(root, "d"), GoMod: filepath.Join(root, "d", "go.mod")},
+	}
+}
+
+func testState(hashes map[string]string) *State {
+	s := &State{Packages: map[string]PackageState{}}
+	for path, h := range hashes {
+		s.Packages[path] = PackageState{Hash: h}
+	}
+	return s
+}
+
+func importPaths(pkgs []Package) []string {
+	var ret []string
+	for _, p := range pkgs {
+		ret = append(ret, p.ImportPath)
+	}
+	return ret
+}
+
+func TestAffected(t *testing.T) {
+	root := filepath.FromSlash("/src")
+	pkgs := testPackages(root)
+	hashes := map[string]string{"m/a": "1", "m/b": "2", "m/c": "3", "m/d": "4"}
+
+	testCases := []struct {
+		desc     string
+		changed  []string
+		previous *State
+		expected []string
+	}{
+		{
+			desc:     "no previous run",
+			previous: nil,
+			expected: []string{"m/a", "m/b", "m/c", "m/d"},
+		},
+		{
+			desc:     "no change",
+			previous: testState(hashes),
+		},
+		{
+			desc:     "reverse dependencies",
+			changed:  []string{filepath.Join(root, "c", "c.go")},
+			previous: testState(hashes),
+			expected: []string{"m/a", "m/b", "m/c"},
+		},
+		{
+			desc:     "deleted file",
+			changed:  []string{filepath.Join(root, "a", "deleted.go")},
+			previous: testState(hashes),
+			expected: []string{"m/a"},
+		},
+		{
+			desc:     "go.sum of module",
+			changed:  []string{filepath.Join(root, "d", "go.sum")},
+			previous: testState(hashes),
+			expected: []string{"m/d"},
+		},
+		{
+			desc:     "modified since previous run",
+			previous: testState(map[string]string{"m/a": "1", "m/b": "old", "m/c": "3", "m/d": "4"}),
+			expected: []string{"m/a", "m/b"},
+		},
+		{
+			desc:     "new package",
+			previous: testState(map[string]string{"m/a": "1", "m/b": "2", "m/c": "3"}),
+			expected: []string{"m/d"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			affected := Affected(pkgs, test.changed, hashes, test.previous)
+			assert.Equal(t, test.expected, importPaths(affected))
+		})
+	}
+}
+
+func TestReverseDependencies(t *testing.T) {
+	pkgs := testPackages(filepath.FromSlash("/src"))
+
+	assert.Equal(t, []string{"m/a", "m/b", "m/c"}, importPaths(ReverseDependencies(pkgs, pkgs[2:3])))
+	assert.Equal(t, []string{"m/a", "m/b", "m/d"}, importPaths(ReverseDependencies(pkgs, []Package{pkgs[1], pkgs[3]})))
+	assert.Empty(t, ReverseDependencies(pkgs, []Package{{ImportPath: "other"}}), "not imported")
+}
+
+func newIssue(file, text string) result.Issue {
+	return result.Issue{FromLinter: "linter", Text: text, Pos: token.Position{Filename: file, Line: 1}}
+}
+
+func TestMerge(t *testing.T) {
+	root := filepath.FromSlash("/src")
+	pkgs := testPackages(root)
+	hashes := map[string]string{"m/a": "1", "m/b": "new", "m/c": "3", "m/d": "4"}
+
+	previous := &State{Packages: map[string]PackageState{
+		"m/a": {Hash: "1", Issues: []result.Issue{newIssue(filepath.Join(root, "a", "a.go"), "cached a")}},
+		"m/b": {Hash: "2", Issues: []result.Issue{newIssue(filepath.Join(root, "b", "b.go"), "fixed b")}},
+		"m/c": {Hash: "3"},
+	}}
+
+	issues := []result.Issue{
+		newIssue(filepath.Join(root, "b", "b.go"), "new b"),
+		newIssue(filepath.Join(root, "other", "x.go"), "outside"),
+	}
+
+	affected := []Package{pkgs[1], pkgs[3]}
+
+	merged, next := Merge(pkgs, affected, hashes, previous, issues)
+
+	var texts []string
+	for _, i := range merged {
+		texts = append(texts, i.Text)
+	}
+	assert.Equal(t, []string{"cached a", "new b", "outside"}, texts)
+
+	require.Len(t, next.Packages, 4)
+	assert.Equal(t, PackageState{Hash: "new", Issues: issues[:1]}, next.Packages["m/b"])
+	assert.Equal(t, previous.Packages["m/a"], next.Packages["m/a"])
+	assert.Equal(t, PackageState{Hash: "4"}, next.Packages["m/d"])
+}
+
+func TestHashes(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.go")
+	goMod := filepath.Join(dir, "go.mod")
+	require.NoError(t, os.WriteFile(file, []byte("package a\n"), 0o600))
+	require.NoError(t, os.WriteFile(goMod, []byte("module m\n"), 0o600))
+
+	pkgs := []Package{{ImportPath: "m", Dir: dir, Files: []string{file}, GoMod: goMod}}
+
+	before, err := Hashes(pkgs)
+	require.NoError(t, err)
+
+	same, err := Hashes(pkgs)
+	require.NoError(t, err)
+	assert.Equal(t, before, same)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.sum"), []byte("x v1.0.0 h1:abc=\n"), 0o600))
+
+	after, err := Hashes(pkgs)
+	require.NoError(t, err)
+	assert.NotEqual(t, before["m"], after["m"])
+}
+
+func TestParseListOutput(t *testing.T) {
+	out := `{"ImportPath": "m/a", "Dir": "/src/a", "GoFiles": ["a.go"], "TestGoFiles": ["a_test.go"],
+"Imports": ["m/b"], "XTestImports": ["m/c"], "Module": {"GoMod": "/src/go.mod"}}
+{"ImportPath": "m/b", "Dir": "/src/b"}
+`
+
+	pkgs, err := parseListOutput([]byte(out))
+	require.NoError(t, err)
+
+	expected := []Package{
+		{
+			ImportPath: "m/a",
+			Dir:        "/src/a",
+			Files:      []string{filepath.Join("/src/a", "a.go"), filepath.Join("/src/a", "a_test.go")},
+			Imports:    []string{"m/b", "m/c"},
+			GoMod:      "/src/go.mod",
+		},
+		{ImportPath: "m/b", Dir: "/src/b"},
+	}
+	assert.Equal(t, expected, pkgs)
+}
@@ -0,0 +1,172 @@
+package lint
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/credentials"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/goutil"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
+	"github.com/golangci/golangci-lint/pkg/suppressions"
+)
+
+// Analysis runs the enabled linters of the config on the packages of run.args, then processes and fixes their issues:
+// it's shared by the run command and pkg/lintapi.
+type Analysis struct {
+	Cfg               *config.Config
+	Log               logutils.Log
+	GoEnv             *goutil.Env
+	DBManager         *lintersdb.Manager
+	EnabledLintersSet *lintersdb.EnabledSet
+	ContextLoader     *ContextLoader
+	FileCache         *fsutils.FileCache
+	LineCache         *fsutils.LineCache
+	Credentials       *credentials.Resolver
+
+	// Unmerged stops the processing of the issues before the baseline, and doesn't fix them:
+	// the issues of several analyses are merged, then processed once by Finish.
+	Unmerged bool
+
+	// ExtraLinters are loaded with the enabled linters, but not run: e.g. the linters of a canary config.
+	ExtraLinters []*linter.Config
+
+	// OnLoad is called before the loading of the packages, with the enabled linters, if not nil.
+	// It's called by Finish without analysis too.
+	OnLoad func(enabledLinters map[string]*linter.Config)
+	// OnLoaded is called when the packages are loaded, if not nil.
+	OnLoaded func(lintCtx *linter.Context)
+	// OnRunner is called before the run of the linters, to set the hooks of the runner, if not nil.
+	// The context is empty for the runner of Finish without analysis.
+	OnRunner func(runner *Runner, lintCtx *linter.Context)
+}
+
+// AnalysisResult is the result of an analysis.
+type AnalysisResult struct {
+	// Issues are the processed issues, fixed with issues.fix, or the partially processed ones of an unmerged analysis.
+	Issues         []result.Issue
+	EnabledLinters map[string]*linter.Config
+	LintCtx        *linter.Context
+	Runner         *Runner
+}
+
+// Run runs the analysis.
+func (a *Analysis) Run(ctx context.Context) (*AnalysisResult, error) {
+	lintersToRun, err := a.EnabledLintersSet.GetOptimizedLinters()
+	if err != nil {
+		return nil, err
+	}
+
+	enabledLintersMap, err := a.EnabledLintersSet.GetEnabledLintersMap()
+	if err != nil {
+		return nil, err
+	}
+
+	if a.OnLoad != nil {
+		a.OnLoad(enabledLintersMap)
+	}
+
+	lintersToLoad := lintersToRun
+	if len(a.ExtraLinters) != 0 {
+		lintersToLoad = append(append([]*linter.Config{}, lintersToRun...), a.ExtraLinters...)
+	}
+
+	lintCtx, err := a.ContextLoader.Load(ctx, lintersToLoad)
+	if err != nil {
+		return nil, errors.Wrap(err, "context loading failed")
+	}
+	lintCtx.Log = a.Log.Child("linters context")
+
+	if a.OnLoaded != nil {
+		a.OnLoaded(lintCtx)
+	}
+
+	runner, err := NewRunner(a.Cfg, a.Log.Child("runner"),
+		a.GoEnv, a.EnabledLintersSet, a.LineCache, a.DBManager, lintCtx.Packages)
+	if err != nil {
+		return nil, err
+	}
+
+	runner.Unmerged = a.Unmerged
+	a.loadSuppressions(ctx, runner)
+
+	if a.OnRunner != nil {
+		a.OnRunner(runner, lintCtx)
+	}
+
+	issues, err := runner.Run(ctx, lintersToRun, lintCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &AnalysisResult{
+		Issues:         issues,
+		EnabledLinters: enabledLintersMap,
+		LintCtx:        lintCtx,
+		Runner:         runner,
+	}
+	if !a.Unmerged {
+		res.Issues = a.fix(res, issues)
+	}
+
+	return res, nil
+}
+
+// Finish processes the merged issues of the unmerged analyses with the runner of the last one, then fixes them.
+// If no analysis was run, last is nil: the issues are processed by a runner without packages.
+func (a *Analysis) Finish(ctx context.Context, last *AnalysisResult, issues []result.Issue) (*AnalysisResult, error) {
+	if last == nil {
+		enabledLintersMap, err := a.EnabledLintersSet.GetEnabledLintersMap()
+		if err != nil {
+			return nil, err
+		}
+
+		if a.OnLoad != nil {
+			a.OnLoad(enabledLintersMap)
+		}
+
+		runner, err := NewRunner(a.Cfg, a.Log.Child("runner"),
+			a.GoEnv, a.EnabledLintersSet, a.LineCache, a.DBManager, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		a.loadSuppressions(ctx, runner)
+
+		lintCtx := &linter.Context{Cfg: a.Cfg, Log: a.Log.Child("linters context")}
+		if a.OnRunner != nil {
+			a.OnRunner(runner, lintCtx)
+		}
+
+		last = &AnalysisResult{EnabledLinters: enabledLintersMap, LintCtx: lintCtx, Runner: runner}
+	}
+
+	res := *last
+	res.Issues = a.fix(&res, res.Runner.Finalize(issues))
+
+	return &res, nil
+}
+
+func (a *Analysis) fix(res *AnalysisResult, issues []result.Issue) []result.Issue {
+	fixer := processors.NewFixer(a.Cfg, a.Log, a.FileCache, res.LintCtx.Packages).WithEnabledLinters(res.EnabledLinters)
+	return fixer.Process(issues)
+}
+
+// loadSuppressions loads the suppressions of the store of issues.suppressions in the runner:
+// if the store can't be read, nothing is suppressed.
+func (a *Analysis) loadSuppressions(ctx context.Context, runner *Runner) {
+	store, err := suppressions.NewStore(ctx, &a.Cfg.Issues.Suppressions, a.Credentials)
+	if err == nil && store != nil {
+		err = runner.Suppressions.Load(ctx, store)
+	}
+	if err != nil {
+		logutils.WarnEvent(a.Log, "suppressions_unavailable", logutils.Fields{"error": err.Error()},
+			"Can't load the suppressions: all the issues are reported: %s", err)
+	}
+}
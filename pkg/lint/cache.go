@@ -0,0 +1,114 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/golangci/golangci-lint/internal/cache"
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/credentials"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+// InitHashSalt sets the salt of the cache keys for the binary and the config.
+func InitHashSalt(version string, cfg *config.Config) error {
+	binSalt, err := computeBinarySalt(version)
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate binary salt")
+	}
+
+	configSalt, err := computeConfigSalt(cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate config salt")
+	}
+
+	var b bytes.Buffer
+	b.Write(binSalt)
+	b.Write(configSalt)
+	cache.SetSalt(b.Bytes())
+	return nil
+}
+
+func computeBinarySalt(version string) ([]byte, error) {
+	if version != "" && version != "(devel)" {
+		return []byte(version), nil
+	}
+
+	if logutils.HaveDebugTag("bin_salt") {
+		return []byte("debug"), nil
+	}
+
+	p, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+func computeConfigSalt(cfg *config.Config) ([]byte, error) {
+	// We don't hash all config fields to reduce meaningless cache
+	// invalidations. At least, it has a huge impact on tests speed.
+
+	lintersSettingsBytes, err := yaml.Marshal(cfg.LintersSettings)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to json marshal config linter settings")
+	}
+
+	var configData bytes.Buffer
+	configData.WriteString("linters-settings=")
+	configData.Write(lintersSettingsBytes)
+	configData.WriteString("\nbuild-tags=%s" + strings.Join(cfg.Run.BuildTags, ","))
+
+	h := sha256.New()
+	if _, err := h.Write(configData.Bytes()); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// NewCacheBackend returns the remote cache backend if it's configured, nil otherwise.
+func NewCacheBackend(cfg *config.Config, resolver *credentials.Resolver, log logutils.Log) (cache.Backend, error) {
+	rc := cfg.Cache.Remote
+	if rc.URL == "" {
+		return nil, nil
+	}
+
+	headers := make(map[string]string, len(rc.Headers))
+	for k, v := range rc.Headers {
+		value, err := resolver.Expand(context.Background(), v)
+		if err != nil {
+			log.Warnf("Remote cache is disabled: header %s: %s", k, err)
+			return nil, nil
+		}
+		headers[k] = value
+	}
+
+	local, err := cache.Default()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open cache")
+	}
+
+	log.Infof("Using remote cache %s", rc.URL)
+	return cache.NewRemote(local, cache.RemoteOptions{
+		URL:      rc.URL,
+		ReadOnly: rc.ReadOnly,
+		Timeout:  rc.Timeout,
+		Headers:  headers,
+	}), nil
+}
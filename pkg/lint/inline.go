@@ -0,0 +1,381 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	gopackages "golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// inlineRun is a run of a linter with the settings of the inline configs or of the overrides of some files:
+// its issues of these files replace the issues of the run with the settings of the config.
+type inlineRun struct {
+	linters []*linter.Config
+	names   map[string]bool
+	files   map[string]bool
+
+	cfg  *config.Config
+	salt string
+}
+
+// context returns the context of the run: the packages of its files, analyzed with its settings.
+func (run *inlineRun) context(lintCtx *linter.Context) *linter.Context {
+	filter := func(pkgs []*gopackages.Package) []*gopackages.Package {
+		var ret []*gopackages.Package
+		for _, p := range pkgs {
+			for _, f := range p.GoFiles {
+				if run.files[f] {
+					ret = append(ret, p)
+					break
+				}
+			}
+		}
+		return ret
+	}
+
+	ret := *lintCtx
+	ret.Packages = filter(lintCtx.Packages)
+	ret.OriginalPackages = filter(lintCtx.OriginalPackages)
+	ret.Cfg = run.cfg
+	ret.CacheSalt = run.salt
+	return &ret
+}
+
+// issues keeps the issues of the linters of the run in its files.
+func (run *inlineRun) issues(issues []result.Issue) []result.Issue {
+	var ret []result.Issue
+	for i := range issues {
+		if run.names[issues[i].FromLinter] && run.files[issues[i].FilePath()] {
+			ret = append(ret, issues[i])
+		}
+	}
+	return ret
+}
+
+// withoutInlineIssues removes the issues replaced by the inline runs.
+func (r Runner) withoutInlineIssues(issues []result.Issue) []result.Issue {
+	if len(r.inlineRuns) == 0 {
+		return issues
+	}
+
+	ret := make([]result.Issue, 0, len(issues))
+	for i := range issues {
+		replaced := false
+		for _, run := range r.inlineRuns {
+			if run.names[issues[i].FromLinter] && run.files[issues[i].FilePath()] {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			ret = append(ret, issues[i])
+		}
+	}
+	return ret
+}
+
+// readInlineConfigs reads the inline directives of the files.
+func readInlineConfigs(files []string) ([]config.InlineConfig, error) {
+	var configs []config.InlineConfig
+	seen := map[string]bool{}
+
+	for _, file := range files {
+		if seen[file] {
+			continue
+		}
+		seen[file] = true
+
+		data, err := fsutils.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Contains(data, []byte(config.InlineDirectivePrefix)) {
+			continue
+		}
+
+		c := config.InlineConfig{File: file}
+		for i, line := range strings.Split(string(data), "\n") {
+			if _, err := c.ParseDirective(strings.TrimSpace(line)); err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid inline directive %s", file, i+1, err)
+			}
+		}
+
+		if len(c.Disable) != 0 || len(c.Settings) != 0 {
+			configs = append(configs, c)
+		}
+	}
+
+	return configs, nil
+}
+
+// getInlineConfigs converts the disabled linters of the inline configs into overrides of their files,
+// and their settings into runs of the linters for their files.
+func getInlineConfigs(cfg *config.Config, configs []config.InlineConfig, dbManager *lintersdb.Manager,
+	enabledLinters map[string]*linter.Config, log logutils.Log) ([]config.Override, []*inlineRun, error) {
+	var overrides []config.Override
+	runs := map[string]*inlineRun{}
+
+	for _, c := range configs {
+		rel, err := fsutils.ShortestRelPath(c.File, "")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(c.Disable) != 0 {
+			for _, name := range c.Disable {
+				if len(dbManager.GetLinterConfigs(name)) == 0 {
+					return nil, nil, fmt.Errorf("%s: unknown linter %q in the inline directives", rel, name)
+				}
+			}
+
+			overrides = append(overrides, config.Override{
+				Path:    "^" + regexp.QuoteMeta(filepath.ToSlash(rel)) + "$",
+				Linters: config.OverrideLinters{Disable: c.Disable},
+			})
+			log.Infof("Inline config of %s: disabled linters %s", rel, strings.Join(c.Disable, ", "))
+		}
+
+		var names []string
+		byLinter := map[string][]config.InlineSetting{}
+		for _, s := range c.Settings {
+			if byLinter[s.Linter()] == nil {
+				names = append(names, s.Linter())
+			}
+			byLinter[s.Linter()] = append(byLinter[s.Linter()], s)
+		}
+
+		for _, name := range names {
+			settings := byLinter[name]
+
+			lcs := dbManager.GetLinterConfigs(name)
+			if len(lcs) == 0 {
+				return nil, nil, fmt.Errorf("%s: unknown linter %q in the inline directives", rel, name)
+			}
+			if enabledLinters[lcs[0].Name()] == nil {
+				logutils.WarnEvent(log, "config_option_ignored", logutils.Fields{"file": rel, "linter": name},
+					"%s: the inline settings of the linter %s are ignored: it's not enabled", rel, name)
+				continue
+			}
+			if lcs[0].DoesChangeTypes {
+				logutils.WarnEvent(log, "config_option_ignored", logutils.Fields{"file": rel, "linter": name},
+					"%s: the inline settings of the linter %s are ignored: it can't be run again", rel, name)
+				continue
+			}
+
+			var parts []string
+			for _, s := range settings {
+				parts = append(parts, s.String())
+			}
+			key := name + " " + strings.Join(parts, " ")
+
+			run := runs[key]
+			if run == nil {
+				run, err = newInlineRun(cfg, name, settings, log)
+				if err != nil {
+					return nil, nil, fmt.Errorf("%s: %w", rel, err)
+				}
+				run.salt = key
+				runs[key] = run
+			}
+			run.files[c.File] = true
+
+			log.Infof("Inline config of %s: %s", rel, strings.Join(parts, ", "))
+		}
+	}
+
+	return overrides, sortedRuns(runs), nil
+}
+
+// sortedRuns returns the runs in the order of their keys.
+func sortedRuns(runs map[string]*inlineRun) []*inlineRun {
+	keys := make([]string, 0, len(runs))
+	for key := range runs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	ret := make([]*inlineRun, 0, len(keys))
+	for _, key := range keys {
+		ret = append(ret, runs[key])
+	}
+	return ret
+}
+
+func newInlineRun(cfg *config.Config, name string, settings []config.InlineSetting, log logutils.Log) (*inlineRun, error) {
+	lintersSettings, err := cfg.LintersSettings.WithInlineSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := newSettingsRun(cfg, name, lintersSettings, log)
+	if err != nil {
+		return nil, fmt.Errorf("the settings of the linter %s can't be set inline", name)
+	}
+	return run, nil
+}
+
+// getOverridesRuns converts the settings of the linters of the overrides into runs of the linters for the files
+// matching the overrides: the settings of all the matching overrides are applied in order.
+// The inline settings of the files have priority.
+func getOverridesRuns(cfg *config.Config, files []string, dbManager *lintersdb.Manager,
+	enabledLinters map[string]*linter.Config, inlineRuns []*inlineRun, log logutils.Log) ([]*inlineRun, error) {
+	overrides := cfg.AllOverrides()
+
+	enabled := map[string]bool{}
+	for name := range enabledLinters {
+		enabled[name] = true
+	}
+	for _, o := range overrides {
+		for name := range dbManager.GetCanonicalNames(o.Linters.Enable) {
+			enabled[name] = true
+		}
+	}
+
+	// The linters of the settings of each override, nil without settings.
+	linters := make([][]string, len(overrides))
+	paths := make([]*regexp.Regexp, len(overrides))
+	for i, o := range overrides {
+		for _, name := range o.SettingsLinters() {
+			lcs := dbManager.GetLinterConfigs(name)
+			switch {
+			case len(lcs) == 0:
+				return nil, fmt.Errorf("override #%d: unknown linter %q in linters-settings", i, name)
+			case !enabled[lcs[0].Name()]:
+				log.Infof("Override of %s: the settings of the linter %s are ignored: it's not enabled", o.Path, name)
+			case lcs[0].DoesChangeTypes:
+				logutils.WarnEvent(log, "config_option_ignored", logutils.Fields{"path": o.Path, "linter": name},
+					"Override of %s: the settings of the linter %s are ignored: it can't be run again", o.Path, name)
+			default:
+				linters[i] = append(linters[i], name)
+			}
+		}
+
+		if len(linters[i]) != 0 {
+			re, err := regexp.Compile(o.Path)
+			if err != nil {
+				return nil, fmt.Errorf("override #%d: can't compile regexp %q: %s", i, o.Path, err)
+			}
+			paths[i] = re
+		}
+	}
+
+	inlineFiles := map[string]bool{}
+	for _, run := range inlineRuns {
+		for name := range run.names {
+			for file := range run.files {
+				inlineFiles[name+" "+file] = true
+			}
+		}
+	}
+
+	runs := map[string]*inlineRun{}
+	seen := map[string]bool{}
+	for _, file := range files {
+		if seen[file] {
+			continue
+		}
+		seen[file] = true
+
+		rel, err := fsutils.ShortestRelPath(file, "")
+		if err != nil {
+			return nil, err
+		}
+
+		// The matching overrides of the file, by linter.
+		matching := map[string][]config.Override{}
+		var names []string
+		for i, o := range overrides {
+			if paths[i] == nil || !paths[i].MatchString(filepath.ToSlash(rel)) {
+				continue
+			}
+			for _, name := range linters[i] {
+				if matching[name] == nil {
+					names = append(names, name)
+				}
+				matching[name] = append(matching[name], o)
+			}
+		}
+
+		for _, name := range names {
+			run, err := getOverridesRun(cfg, name, matching[name], runs, log)
+			if err != nil {
+				return nil, err
+			}
+
+			replaced := false
+			for lcName := range run.names {
+				replaced = replaced || inlineFiles[lcName+" "+file]
+			}
+			if !replaced {
+				run.files[file] = true
+			}
+		}
+	}
+
+	for key, run := range runs {
+		if len(run.files) == 0 {
+			delete(runs, key) // replaced by the inline runs
+		}
+	}
+
+	return sortedRuns(runs), nil
+}
+
+// getOverridesRun returns the run of the linter with the settings of the overrides, created once by settings.
+func getOverridesRun(cfg *config.Config, name string, overrides []config.Override,
+	runs map[string]*inlineRun, log logutils.Log) (*inlineRun, error) {
+	key := name
+	for _, o := range overrides {
+		key += fmt.Sprintf(" %v", o.LintersSettings[name])
+	}
+
+	if run := runs[key]; run != nil {
+		return run, nil
+	}
+
+	lintersSettings, err := cfg.LintersSettings.WithOverrideSettings(name, overrides)
+	if err != nil {
+		return nil, fmt.Errorf("override of %s: %w", overrides[len(overrides)-1].Path, err)
+	}
+
+	run, err := newSettingsRun(cfg, name, lintersSettings, log)
+	if err != nil {
+		return nil, fmt.Errorf("override of %s: %w", overrides[len(overrides)-1].Path, err)
+	}
+
+	run.salt = "override " + key
+	runs[key] = run
+	return run, nil
+}
+
+// newSettingsRun creates the run of the linter with the settings.
+func newSettingsRun(cfg *config.Config, name string, lintersSettings config.LintersSettings, log logutils.Log) (*inlineRun, error) {
+	runCfg := *cfg
+	runCfg.LintersSettings = lintersSettings
+
+	run := &inlineRun{
+		linters: lintersdb.NewManager(&runCfg, log).GetLinterConfigs(name),
+		names:   map[string]bool{},
+		files:   map[string]bool{},
+		cfg:     &runCfg,
+	}
+	if len(run.linters) == 0 {
+		return nil, fmt.Errorf("the settings of the linter %s can't be changed", name)
+	}
+	for _, lc := range run.linters {
+		run.names[lc.Name()] = true
+	}
+
+	return run, nil
+}
@@ -27,9 +27,9 @@ const (
 const LastLinter = "nolintlint"
 
 type Deprecation struct {
-	Since       string
-	Message     string
-	Replacement string
+	Since       string `json:"since"`
+	Message     string `json:"message"`
+	Replacement string `json:"replacement,omitempty"`
 }
 
 type Config struct {
@@ -42,6 +42,7 @@ type Config struct {
 	AlternativeNames []string
 
 	OriginalURL     string // URL of original (not forked) repo, needed for autogenerated README
+	Module          string // Go module implementing the linter, if it doesn't match OriginalURL
 	CanAutoFix      bool
 	IsSlow          bool
 	DoesChangeTypes bool
@@ -81,6 +82,11 @@ func (lc *Config) WithURL(url string) *Config {
 	return lc
 }
 
+func (lc *Config) WithModule(path string) *Config {
+	lc.Module = path
+	return lc
+}
+
 func (lc *Config) WithAlternativeNames(names ...string) *Config {
 	lc.AlternativeNames = names
 	return lc
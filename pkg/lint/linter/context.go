@@ -2,6 +2,7 @@ package linter
 
 import (
 	"go/ast"
+	"strings"
 
 	"golang.org/x/tools/go/packages"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis/load"
 	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/resources"
 )
 
 type Context struct {
@@ -27,12 +29,74 @@ type Context struct {
 
 	PkgCache  *pkgcache.Cache
 	LoadGuard *load.Guard
+
+	// MemoryBudget degrades the analysis to use less memory once exceeded, if not nil.
+	MemoryBudget *resources.MemoryBudget
+
+	// OnPackageAnalyzed is called when a linter finishes the analysis of a package, with the count of packages it analyzes,
+	// for the progress of the run, if not nil.
+	OnPackageAnalyzed func(total int)
+
+	// OnPanic receives the panics of the analyzers recovered on a package, if not nil:
+	// the run of the linter continues without the issues of the package. The panics fail the run if nil.
+	OnPanic func(p Panic)
+
+	// CacheSalt distinguishes the cached issues of the runs of the linters with other settings,
+	// like the settings of the inline configs of the files.
+	CacheSalt string
+}
+
+// Panic is a panic of a linter recovered on a package.
+type Panic struct {
+	Linter  string
+	Package string
+	Message string
+	Stack   []byte
 }
 
 func (c *Context) Settings() *config.LintersSettings {
 	return &c.Cfg.LintersSettings
 }
 
+// WithoutTestPackages returns a copy of the context without the test variants of the packages:
+// the packages with tests are analyzed without their _test.go files.
+func (c *Context) WithoutTestPackages() *Context {
+	var pkgs []*packages.Package
+	for _, p := range c.OriginalPackages {
+		if !IsTestPackage(p) {
+			pkgs = append(pkgs, p)
+		}
+	}
+
+	ret := *c
+	ret.Packages = pkgs
+	ret.OriginalPackages = pkgs
+	return &ret
+}
+
+// WithOnlyTestPackages returns a copy of the context with only the test variants of the packages.
+func (c *Context) WithOnlyTestPackages() *Context {
+	filter := func(pkgs []*packages.Package) []*packages.Package {
+		var ret []*packages.Package
+		for _, p := range pkgs {
+			if IsTestPackage(p) {
+				ret = append(ret, p)
+			}
+		}
+		return ret
+	}
+
+	ret := *c
+	ret.Packages = filter(c.Packages)
+	ret.OriginalPackages = filter(c.OriginalPackages)
+	return &ret
+}
+
+// IsTestPackage checks if the package is the test variant of a package, or its external test package.
+func IsTestPackage(p *packages.Package) bool {
+	return strings.HasSuffix(p.ID, ".test]")
+}
+
 func (c *Context) ClearTypesInPackages() {
 	for _, p := range c.Packages {
 		clearTypes(p)
@@ -0,0 +1,81 @@
+package linter
+
+import (
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// Upstream is the Go module implementing a linter, bundled in the binary.
+type Upstream struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+var (
+	bundledModulesOnce sync.Once
+	bundledModules     map[string]string
+)
+
+// getBundledModules returns the versions of the dependencies of the binary by module path.
+func getBundledModules() map[string]string {
+	bundledModulesOnce.Do(func() {
+		bundledModules = map[string]string{}
+
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+
+		for _, dep := range info.Deps {
+			version := dep.Version
+			if dep.Replace != nil && dep.Replace.Version != "" {
+				version = dep.Replace.Version
+			}
+			bundledModules[dep.Path] = version
+		}
+	})
+
+	return bundledModules
+}
+
+// Upstream returns the module implementing the linter and its version, from the build information of the binary.
+// It's nil for the linters implemented by golangci-lint itself, or if the build information is missing.
+func (lc *Config) Upstream() *Upstream {
+	path := lc.Module
+	if path == "" {
+		path = modulePathFromURL(lc.OriginalURL)
+	}
+	if path == "" {
+		return nil
+	}
+
+	modules := getBundledModules()
+
+	// The linter can be in a sub-directory of its module.
+	for p := path; p != "." && p != ""; p = parentPath(p) {
+		if version, ok := modules[p]; ok {
+			return &Upstream{Path: p, Version: version}
+		}
+	}
+
+	return nil
+}
+
+func modulePathFromURL(url string) string {
+	path := strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+	for _, sep := range []string{"/tree/", "/blob/"} {
+		if i := strings.Index(path, sep); i != -1 {
+			path = path[:i]
+		}
+	}
+	return strings.TrimSuffix(path, "/")
+}
+
+func parentPath(path string) string {
+	i := strings.LastIndex(path, "/")
+	if i == -1 {
+		return ""
+	}
+	return path[:i]
+}
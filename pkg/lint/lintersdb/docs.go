@@ -0,0 +1,265 @@
+package lintersdb
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+)
+
+// LinterDoc is the documentation of a linter, completing its description.
+type LinterDoc struct {
+	Details string
+	Bad     string // Code reported by the linter.
+	Good    string // The fixed code.
+	Rules   map[string]RuleDoc
+}
+
+// RuleDoc is the documentation of a check of a linter.
+type RuleDoc struct {
+	Desc    string
+	Details string
+	Bad     string
+	Good    string
+}
+
+// GetLinterDoc returns the documentation of a linter: the registry completed with the docs of its analyzers.
+func GetLinterDoc(lc *linter.Config) LinterDoc {
+	doc := linterDocs[lc.Name()]
+
+	rules := map[string]RuleDoc{}
+	for name, rule := range analyzerRules(lc) {
+		rules[name] = rule
+	}
+	for name, rule := range doc.Rules {
+		rules[name] = rule
+	}
+
+	if len(rules) != 0 {
+		doc.Rules = rules
+	}
+
+	return doc
+}
+
+// FindRule returns the documentation of a rule, case-insensitively.
+func (d LinterDoc) FindRule(name string) (string, RuleDoc, bool) {
+	if rule, ok := d.Rules[name]; ok {
+		return name, rule, true
+	}
+
+	for n, rule := range d.Rules {
+		if strings.EqualFold(n, name) {
+			return n, rule, true
+		}
+	}
+
+	return "", RuleDoc{}, false
+}
+
+// RuleNames returns the sorted names of the rules.
+func (d LinterDoc) RuleNames() []string {
+	names := make([]string, 0, len(d.Rules))
+	for name := range d.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// analyzerRules returns the analyzers of the linters made of several analyzers (govet, staticcheck...), by name.
+func analyzerRules(lc *linter.Config) map[string]RuleDoc {
+	lnt, ok := lc.Linter.(*goanalysis.Linter)
+	if !ok || len(lnt.Analyzers()) < 2 {
+		return nil
+	}
+
+	rules := map[string]RuleDoc{}
+	for _, a := range lnt.Analyzers() {
+		desc, details, _ := strings.Cut(strings.TrimSpace(a.Doc), "\n")
+		rules[a.Name] = RuleDoc{Desc: desc, Details: strings.TrimSpace(details)}
+	}
+
+	return rules
+}
+
+// linterDocs is the registry of the documentation of the linters.
+var linterDocs = map[string]LinterDoc{
+	"errcheck": {
+		Details: `Unchecked errors hide failures: a failed write or close can lose data silently.
+The functions of 'exclude-functions' and, with 'check-blank', the errors assigned to '_' are reported or not.`,
+		Bad: `os.Remove(path)`,
+		Good: `if err := os.Remove(path); err != nil {
+	return err
+}`,
+	},
+	"govet": {
+		Details: `The analyzers of 'go vet', and optional ones like 'shadow' or 'fieldalignment'.
+The analyzers are enabled with 'enable', 'enable-all', 'disable' and 'disable-all'.`,
+		Rules: map[string]RuleDoc{
+			"printf": {
+				Desc: "check consistency of Printf format strings and arguments",
+				Bad:  `fmt.Printf("%d items", "3")`,
+				Good: `fmt.Printf("%d items", 3)`,
+			},
+			"copylocks": {
+				Desc: "check for locks erroneously passed by value",
+				Details: `A copy of a sync.Mutex is a new mutex: the copy doesn't protect the original data.
+Pass and receive the values holding a lock by pointer.`,
+				Bad:  `func (c Counter) Inc() { c.mu.Lock(); c.n++; c.mu.Unlock() }`,
+				Good: `func (c *Counter) Inc() { c.mu.Lock(); c.n++; c.mu.Unlock() }`,
+			},
+			"shadow": {
+				Desc:    "check for possible unintended shadowing of variables",
+				Details: `Disabled by default: enable it with 'enable: [shadow]', and 'settings.shadow.strict' to report all the shadowings.`,
+				Bad: `x, err := f()
+if x > 0 {
+	y, err := g(x) // The outer err isn't set.
+	use(y)
+}
+return err`,
+				Good: `x, err := f()
+if x > 0 {
+	var y int
+	y, err = g(x)
+	use(y)
+}
+return err`,
+			},
+		},
+	},
+	"staticcheck": {
+		Details: `The SA checks of staticcheck: bugs and performance issues.
+The checks are selected with 'checks', e.g. ["all", "-SA1019"].`,
+		Rules: map[string]RuleDoc{
+			"SA1019": {
+				Desc: "Using a deprecated function, variable, constant or field",
+				Bad:  `data, err := ioutil.ReadAll(r)`,
+				Good: `data, err := io.ReadAll(r)`,
+			},
+			"SA4006": {
+				Desc: "A value assigned to a variable is never read before being overwritten",
+				Bad: `v, err := f()
+v, err = g()`,
+				Good: `v, err := f()
+if err != nil {
+	return err
+}
+v, err = g()`,
+			},
+		},
+	},
+	"gosimple": {
+		Details: `The S checks of staticcheck: simplifications of the code.`,
+		Rules: map[string]RuleDoc{
+			"S1002": {
+				Desc: "Omit comparison with boolean constant",
+				Bad:  `if ok == true {}`,
+				Good: `if ok {}`,
+			},
+		},
+	},
+	"ineffassign": {
+		Details: `An assignment not read before the next one or the end of the function, usually a forgotten error check.`,
+		Bad: `err := f()
+err = g()
+return err`,
+		Good: `if err := f(); err != nil {
+	return err
+}
+return g()`,
+	},
+	"unused": {
+		Details: `Constants, variables, functions, types and fields not used: the exported identifiers of the packages main
+and of the internal packages are checked too.`,
+		Bad:  `func helper() {} // Never called.`,
+		Good: `// The function is removed.`,
+	},
+	"gocritic": {
+		Details: `The checks are selected with 'enabled-checks', 'disabled-checks' and 'enabled-tags',
+and configured with 'settings'. See 'golangci-lint explain gocritic:<check>'.`,
+		Rules: map[string]RuleDoc{
+			"rangeValCopy": {
+				Desc:    "Detects loops that copy big objects during each iteration",
+				Details: `The size of the reported values is set with 'settings.rangeValCopy.sizeThreshold'.`,
+				Bad:     `for _, v := range bigStructs { use(v) }`,
+				Good:    `for i := range bigStructs { use(&bigStructs[i]) }`,
+			},
+			"ifElseChain": {
+				Desc: "Detects repeated if-else statements and suggests to replace them with switch statement",
+				Bad: `if x == 1 {
+	a()
+} else if x == 2 {
+	b()
+} else {
+	c()
+}`,
+				Good: `switch x {
+case 1:
+	a()
+case 2:
+	b()
+default:
+	c()
+}`,
+			},
+		},
+	},
+	"gosec": {
+		Details: `The rules are selected with 'includes' and 'excludes', and configured with 'config'.`,
+		Rules: map[string]RuleDoc{
+			"G101": {
+				Desc: "Look for hard coded credentials",
+				Bad:  `const password = "s3cr3t"`,
+				Good: `password := os.Getenv("PASSWORD")`,
+			},
+			"G304": {
+				Desc: "File path provided as taint input",
+				Bad:  `data, err := os.ReadFile(r.URL.Query().Get("file"))`,
+				Good: `data, err := os.ReadFile(filepath.Join(root, filepath.Base(r.URL.Query().Get("file"))))`,
+			},
+		},
+	},
+	"errorlint": {
+		Details: `The wrapped errors aren't matched by == and type assertions, and aren't wrapped by the verb %v.`,
+		Rules: map[string]RuleDoc{
+			"comparison": {
+				Desc: "Comparisons of errors not using errors.Is",
+				Bad:  `if err == io.EOF {}`,
+				Good: `if errors.Is(err, io.EOF) {}`,
+			},
+			"asserts": {
+				Desc: "Type assertions on errors not using errors.As",
+				Bad:  `if e, ok := err.(*os.PathError); ok {}`,
+				Good: `var e *os.PathError
+if errors.As(err, &e) {}`,
+			},
+			"errorf": {
+				Desc: "Errors formatted by fmt.Errorf without the verb %w",
+				Bad:  `return fmt.Errorf("can't open: %v", err)`,
+				Good: `return fmt.Errorf("can't open: %w", err)`,
+			},
+		},
+	},
+	"bodyclose": {
+		Details: `An HTTP response body not closed leaks the connection.`,
+		Bad: `resp, err := http.Get(url)
+if err != nil {
+	return err
+}
+return decode(resp.Body)`,
+		Good: `resp, err := http.Get(url)
+if err != nil {
+	return err
+}
+defer resp.Body.Close()
+return decode(resp.Body)`,
+	},
+	"nolintlint": {
+		Details: `With 'require-explanation' and 'require-specific', the directives must name the linters and explain the reason.
+With 'allow-unused: false', the directives not suppressing any issue are reported.`,
+		Bad:  `//nolint`,
+		Good: `//nolint:errcheck // The error is always nil.`,
+	},
+}
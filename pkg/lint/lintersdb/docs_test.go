@@ -0,0 +1,43 @@
+package lintersdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+)
+
+func TestLinterDocs(t *testing.T) {
+	m := NewManager(config.NewDefault(), nil)
+
+	for name, doc := range linterDocs {
+		assert.NotEmpty(t, m.GetLinterConfigs(name), "documented linter %s doesn't exist", name)
+		assert.NotEmpty(t, doc.Details, name)
+
+		for ruleName, rule := range doc.Rules {
+			assert.NotEmpty(t, rule.Desc, "%s:%s", name, ruleName)
+		}
+	}
+}
+
+func TestGetLinterDoc(t *testing.T) {
+	m := NewManager(config.NewDefault(), nil)
+
+	doc := GetLinterDoc(m.GetLinterConfigs("staticcheck")[0])
+
+	// Documented by the registry.
+	name, rule, ok := doc.FindRule("sa4006")
+	require.True(t, ok)
+	assert.Equal(t, "SA4006", name)
+	assert.NotEmpty(t, rule.Bad)
+
+	// Documented by the analyzer only.
+	_, rule, ok = doc.FindRule("SA1000")
+	require.True(t, ok)
+	assert.NotEmpty(t, rule.Desc)
+
+	_, _, ok = doc.FindRule("SA0000")
+	assert.False(t, ok)
+}
@@ -90,6 +90,47 @@ func (es EnabledSet) GetEnabledLintersMap() (map[string]*linter.Config, error) {
 	return enabledLinters, nil
 }
 
+// GetEnabledLintersReasons explains why each enabled linter is enabled:
+// `enable`, `preset <name>`, `enable-all` or `default`.
+func (es EnabledSet) GetEnabledLintersReasons() (map[string]string, error) {
+	enabledLinters, err := es.GetEnabledLintersMap()
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := make(map[string]string, len(enabledLinters))
+	for name := range enabledLinters {
+		reasons[name] = es.enabledReason(&es.cfg.Linters, name)
+	}
+
+	return reasons, nil
+}
+
+// enabledReason follows the priorities of build.
+func (es EnabledSet) enabledReason(lcfg *config.Linters, name string) string {
+	for _, n := range lcfg.Enable {
+		for _, lc := range es.m.GetLinterConfigs(n) {
+			if lc.Name() == name {
+				return "enable"
+			}
+		}
+	}
+
+	for _, p := range lcfg.Presets {
+		for _, lc := range es.m.GetAllLinterConfigsForPreset(p) {
+			if lc.Name() == name {
+				return "preset " + p
+			}
+		}
+	}
+
+	if lcfg.EnableAll {
+		return "enable-all"
+	}
+
+	return "default"
+}
+
 // GetOptimizedLinters returns enabled linters after optimization (merging) of multiple linters
 // into a fewer number of linters. E.g. some go/analysis linters can be optimized into
 // one metalinter for data reuse and speed up.
@@ -98,7 +139,12 @@ func (es EnabledSet) GetOptimizedLinters() ([]*linter.Config, error) {
 		return nil, err
 	}
 
+	if err := es.v.validateOverridesLintersNames(es.cfg.AllOverrides()); err != nil {
+		return nil, err
+	}
+
 	resultLintersSet := es.build(&es.cfg.Linters, es.m.GetAllEnabledByDefaultLinters())
+	es.addOverridesLinters(resultLintersSet)
 	es.verbosePrintLintersStatus(resultLintersSet)
 	es.combineGoAnalysisLinters(resultLintersSet)
 
@@ -119,6 +165,10 @@ func (es EnabledSet) GetOptimizedLinters() ([]*linter.Config, error) {
 			return false
 		}
 
+		if es.cfg.Run.FastLintersFirst && a.IsSlowLinter() != b.IsSlowLinter() {
+			return b.IsSlowLinter()
+		}
+
 		if a.DoesChangeTypes != b.DoesChangeTypes {
 			return b.DoesChangeTypes // move type-changing linters to the end to optimize speed
 		}
@@ -128,11 +178,22 @@ func (es EnabledSet) GetOptimizedLinters() ([]*linter.Config, error) {
 	return resultLinters, nil
 }
 
+// addOverridesLinters adds the linters enabled only for some paths:
+// they run on the whole codebase, their issues are filtered by the overrides processor.
+func (es EnabledSet) addOverridesLinters(linters map[string]*linter.Config) {
+	for _, o := range es.cfg.AllOverrides() {
+		for _, name := range o.Linters.Enable {
+			for _, lc := range es.m.GetLinterConfigs(name) {
+				linters[lc.Name()] = lc
+			}
+		}
+	}
+}
+
 func (es EnabledSet) combineGoAnalysisLinters(linters map[string]*linter.Config) {
-	var goanalysisLinters []*goanalysis.Linter
-	goanalysisPresets := map[string]bool{}
-	for _, linter := range linters {
-		lnt, ok := linter.Linter.(*goanalysis.Linter)
+	var slowLinters, fastLinters []*linter.Config
+	for _, lc := range linters {
+		lnt, ok := lc.Linter.(*goanalysis.Linter)
 		if !ok {
 			continue
 		}
@@ -140,19 +201,42 @@ func (es EnabledSet) combineGoAnalysisLinters(linters map[string]*linter.Config)
 			// It's ineffective by CPU and memory to run whole-program and incremental analyzers at once.
 			continue
 		}
-		goanalysisLinters = append(goanalysisLinters, lnt)
-		for _, p := range linter.InPresets {
-			goanalysisPresets[p] = true
+		if es.cfg.LintersSettings.Timeouts[lnt.Name()] > 0 {
+			// The linter runs alone to be cancelled without the other ones at its timeout.
+			continue
+		}
+
+		// The fast linters run first in their own metalinter: their issues are streamed without waiting for the others.
+		if es.cfg.Run.FastLintersFirst && !lc.IsSlowLinter() && lc.Name() != linter.LastLinter {
+			fastLinters = append(fastLinters, lc)
+		} else {
+			slowLinters = append(slowLinters, lc)
 		}
 	}
 
-	if len(goanalysisLinters) <= 1 {
-		es.debugf("Didn't combine go/analysis linters: got only %d linters", len(goanalysisLinters))
+	es.combine(linters, slowLinters, goanalysis.NewMetaLinter)
+	es.combine(linters, fastLinters, goanalysis.NewFastMetaLinter)
+}
+
+// combine replaces the go/analysis linters by a metalinter running them at once.
+func (es EnabledSet) combine(linters map[string]*linter.Config, lcs []*linter.Config,
+	newMetaLinter func([]*goanalysis.Linter) *goanalysis.MetaLinter) {
+	if len(lcs) <= 1 {
+		es.debugf("Didn't combine go/analysis linters: got only %d linters", len(lcs))
 		return
 	}
 
-	for _, lnt := range goanalysisLinters {
-		delete(linters, lnt.Name())
+	var goanalysisLinters []*goanalysis.Linter
+	goanalysisPresets := map[string]bool{}
+	isSlow := false
+	for _, lc := range lcs {
+		goanalysisLinters = append(goanalysisLinters, lc.Linter.(*goanalysis.Linter))
+		for _, p := range lc.InPresets {
+			goanalysisPresets[p] = true
+		}
+		isSlow = isSlow || lc.IsSlowLinter()
+
+		delete(linters, lc.Name())
 	}
 
 	// Make order of execution of go/analysis analyzers stable.
@@ -170,7 +254,7 @@ func (es EnabledSet) combineGoAnalysisLinters(linters map[string]*linter.Config)
 		return a.Name() <= b.Name()
 	})
 
-	ml := goanalysis.NewMetaLinter(goanalysisLinters)
+	ml := newMetaLinter(goanalysisLinters)
 
 	var presets []string
 	for p := range goanalysisPresets {
@@ -185,10 +269,14 @@ func (es EnabledSet) combineGoAnalysisLinters(linters map[string]*linter.Config)
 		OriginalURL:      "",
 	}
 
-	mlConfig = mlConfig.WithLoadForGoAnalysis()
+	if isSlow {
+		mlConfig = mlConfig.WithLoadForGoAnalysis()
+	} else {
+		mlConfig = mlConfig.WithLoadFiles()
+	}
 
 	linters[ml.Name()] = mlConfig
-	es.debugf("Combined %d go/analysis linters into one metalinter", len(goanalysisLinters))
+	es.debugf("Combined %d go/analysis linters into the metalinter %s", len(goanalysisLinters), ml.Name())
 }
 
 func (es EnabledSet) verbosePrintLintersStatus(lcs map[string]*linter.Config) {
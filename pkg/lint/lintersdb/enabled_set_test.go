@@ -5,9 +5,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/logutils"
 )
 
 //nolint:funlen
@@ -117,3 +120,96 @@ func TestGetEnabledLintersSet(t *testing.T) {
 		})
 	}
 }
+
+func TestEnabledReason(t *testing.T) {
+	m := NewManager(nil, nil)
+	es := NewEnabledSet(m, NewValidator(m), nil, nil)
+
+	lcfg := &config.Linters{
+		Enable:  []string{"gas"},
+		Presets: []string{"style"},
+	}
+
+	assert.Equal(t, "enable", es.enabledReason(lcfg, "gosec"))
+	assert.Equal(t, "preset style", es.enabledReason(lcfg, "stylecheck"))
+	assert.Equal(t, "default", es.enabledReason(&config.Linters{}, "govet"))
+	assert.Equal(t, "enable-all", es.enabledReason(&config.Linters{EnableAll: true}, "govet"))
+}
+
+func TestGetEnabledLintersSet_customPresets(t *testing.T) {
+	cfg := &config.Config{Presets: config.Presets{Custom: map[string]config.CustomPreset{
+		"security":        {Linters: []string{"gosec", "errcheck"}},
+		"security-strict": {Extends: []string{"security", "sql"}, Linters: []string{"gas", "bodyclose"}},
+	}}}
+
+	m := NewManager(cfg, nil)
+	es := NewEnabledSet(m, NewValidator(m), nil, cfg)
+
+	els := es.build(&config.Linters{Presets: []string{"security-strict"}}, m.GetAllEnabledByDefaultLinters())
+
+	var enabledLinters []string
+	for ln := range els {
+		enabledLinters = append(enabledLinters, ln)
+	}
+	sort.Strings(enabledLinters)
+
+	assert.Equal(t, []string{"bodyclose", "errcheck", "execinquery", "gosec", "rowserrcheck", "sqlclosecheck"}, enabledLinters)
+	assert.Equal(t, "preset security-strict", es.enabledReason(&config.Linters{Presets: []string{"security-strict"}}, "gosec"))
+}
+
+func TestGetEnabledLintersSet_customPresetsCase(t *testing.T) {
+	// The keys of the config files are lowercased by viper, not the values.
+	cfg := &config.Config{Presets: config.Presets{Custom: map[string]config.CustomPreset{
+		"security":        {Linters: []string{"gosec"}},
+		"security-strict": {Extends: []string{"Security"}, Linters: []string{"bodyclose"}},
+	}}}
+
+	m := NewManager(cfg, nil)
+	require.NoError(t, NewValidator(m).validatePresets(&config.Linters{Presets: []string{"Security-Strict"}}))
+
+	es := NewEnabledSet(m, NewValidator(m), nil, cfg)
+	els := es.build(&config.Linters{DisableAll: true, Presets: []string{"Security-Strict"}}, nil)
+
+	var enabledLinters []string
+	for ln := range els {
+		enabledLinters = append(enabledLinters, ln)
+	}
+	sort.Strings(enabledLinters)
+
+	assert.Equal(t, []string{"bodyclose", "gosec"}, enabledLinters)
+}
+
+func TestGetOptimizedLinters_fastLintersFirst(t *testing.T) {
+	cfg := &config.Config{
+		Run: config.Run{FastLintersFirst: true},
+		Linters: config.Linters{
+			DisableAll: true,
+			Enable:     []string{"govet", "errcheck", "dogsled", "lll", "nolintlint"},
+		},
+	}
+
+	m := NewManager(cfg, nil)
+	es := NewEnabledSet(m, NewValidator(m), logutils.NewStderrLog("test"), cfg)
+
+	lcs, err := es.GetOptimizedLinters()
+	require.NoError(t, err)
+
+	var names [][]string
+	for _, lc := range lcs {
+		ml, ok := lc.Linter.(*goanalysis.MetaLinter)
+		require.True(t, ok, lc.Name())
+
+		var linters []string
+		for _, l := range ml.Linters() {
+			linters = append(linters, l.Name())
+		}
+		names = append(names, append([]string{lc.Name()}, linters...))
+	}
+
+	assert.Equal(t, [][]string{
+		{"goanalysis_metalinter_fast", "dogsled", "lll"},
+		{"goanalysis_metalinter", "errcheck", "govet", "nolintlint"},
+	}, names)
+	assert.False(t, lcs[0].IsSlowLinter())
+	assert.True(t, lcs[1].IsSlowLinter())
+}
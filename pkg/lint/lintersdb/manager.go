@@ -1,18 +1,15 @@
 package lintersdb
 
 import (
-	"fmt"
-	"path/filepath"
-	"plugin"
-
-	"github.com/spf13/viper"
-	"golang.org/x/tools/go/analysis"
+	"sort"
+	"strings"
 
 	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/golinters"
 	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/plugins"
 	"github.com/golangci/golangci-lint/pkg/report"
 )
 
@@ -20,6 +17,9 @@ type Manager struct {
 	nameToLCs map[string][]*linter.Config
 	cfg       *config.Config
 	log       logutils.Log
+
+	// loadedCustomLinters are the names of the custom linters loaded by WithCustomLinters.
+	loadedCustomLinters map[string]bool
 }
 
 func NewManager(cfg *config.Config, log logutils.Log) *Manager {
@@ -40,6 +40,7 @@ func (m *Manager) WithCustomLinters() *Manager {
 	if m.log == nil {
 		m.log = report.NewLogWrapper(logutils.NewStderrLog(""), &report.Data{})
 	}
+	m.loadedCustomLinters = map[string]bool{}
 	if m.cfg != nil {
 		for name, settings := range m.cfg.LintersSettings.Custom {
 			lc, err := m.loadCustomLinterConfig(name, settings)
@@ -51,12 +52,18 @@ func (m *Manager) WithCustomLinters() *Manager {
 					err)
 			} else {
 				m.nameToLCs[name] = append(m.nameToLCs[name], lc)
+				m.loadedCustomLinters[name] = true
 			}
 		}
 	}
 	return m
 }
 
+// IsCustomLinterLoaded checks if the custom linter of linters-settings.custom was loaded by WithCustomLinters.
+func (m Manager) IsCustomLinterLoaded(name string) bool {
+	return m.loadedCustomLinters[name]
+}
+
 func (Manager) AllPresets() []string {
 	return []string{
 		linter.PresetBugs,
@@ -75,11 +82,46 @@ func (Manager) AllPresets() []string {
 	}
 }
 
+// CustomPresets returns the names of the presets defined in the config.
+func (m Manager) CustomPresets() []string {
+	presets := m.customPresets()
+
+	ret := make([]string, 0, len(presets))
+	for name := range presets {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+
+	return ret
+}
+
+// customPresets returns the presets defined in the config by lowercase name, with the lowercase names of the presets they extend:
+// viper lowercases the keys of the config files, the names of the presets are case-insensitive.
+func (m Manager) customPresets() map[string]config.CustomPreset {
+	if m.cfg == nil {
+		return nil
+	}
+
+	ret := make(map[string]config.CustomPreset, len(m.cfg.Presets.Custom))
+	for name, p := range m.cfg.Presets.Custom {
+		extends := make([]string, 0, len(p.Extends))
+		for _, parent := range p.Extends {
+			extends = append(extends, strings.ToLower(parent))
+		}
+		ret[strings.ToLower(name)] = config.CustomPreset{Extends: extends, Linters: p.Linters}
+	}
+
+	return ret
+}
+
 func (m Manager) allPresetsSet() map[string]bool {
 	ret := map[string]bool{}
 	for _, p := range m.AllPresets() {
 		ret[p] = true
 	}
+	for _, p := range m.CustomPresets() {
+		ret[p] = true
+	}
 	return ret
 }
 
@@ -87,6 +129,17 @@ func (m Manager) GetLinterConfigs(name string) []*linter.Config {
 	return m.nameToLCs[name]
 }
 
+// GetCanonicalNames resolves the names and the aliases of linters to their canonical names: unknown names are ignored.
+func (m Manager) GetCanonicalNames(names []string) map[string]bool {
+	ret := map[string]bool{}
+	for _, name := range names {
+		for _, lc := range m.GetLinterConfigs(name) {
+			ret[lc.Name()] = true
+		}
+	}
+	return ret
+}
+
 func enableLinterConfigs(lcs []*linter.Config, isEnabled func(lc *linter.Config) bool) []*linter.Config {
 	var ret []*linter.Config
 	for _, lc := range lcs {
@@ -318,7 +371,8 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 			WithSince("v1.0.0").
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetUnused).
-			WithURL("https://github.com/remyoudompheng/go-misc/tree/master/deadcode"),
+			WithURL("https://github.com/remyoudompheng/go-misc/tree/master/deadcode").
+			WithModule("github.com/golangci/go-misc"),
 
 		linter.NewConfig(golinters.NewDepguard(depGuardCfg)).
 			WithSince("v1.4.0").
@@ -333,7 +387,8 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 		linter.NewConfig(golinters.NewDupl(duplCfg)).
 			WithSince("v1.0.0").
 			WithPresets(linter.PresetStyle).
-			WithURL("https://github.com/mibk/dupl"),
+			WithURL("https://github.com/mibk/dupl").
+			WithModule("github.com/golangci/dupl"),
 
 		linter.NewConfig(golinters.NewDurationCheck()).
 			WithSince("v1.37.0").
@@ -419,7 +474,8 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 		linter.NewConfig(golinters.NewGochecknoglobals()).
 			WithSince("v1.12.0").
 			WithPresets(linter.PresetStyle).
-			WithURL("https://github.com/leighmcculloch/gochecknoglobals"),
+			WithURL("https://github.com/leighmcculloch/gochecknoglobals").
+			WithModule("4d63.com/gochecknoglobals"),
 
 		linter.NewConfig(golinters.NewGochecknoinits()).
 			WithSince("v1.12.0").
@@ -474,7 +530,8 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 			WithSince("v1.28.0").
 			WithPresets(linter.PresetFormatting).
 			WithAutoFix().
-			WithURL("https://github.com/mvdan/gofumpt"),
+			WithURL("https://github.com/mvdan/gofumpt").
+			WithModule("mvdan.cc/gofumpt"),
 
 		linter.NewConfig(golinters.NewGoHeader(goheaderCfg)).
 			WithSince("v1.28.0").
@@ -485,13 +542,15 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 			WithSince("v1.20.0").
 			WithPresets(linter.PresetFormatting, linter.PresetImport).
 			WithAutoFix().
-			WithURL("https://godoc.org/golang.org/x/tools/cmd/goimports"),
+			WithURL("https://godoc.org/golang.org/x/tools/cmd/goimports").
+			WithModule("golang.org/x/tools"),
 
 		linter.NewConfig(golinters.NewGolint(golintCfg)).
 			WithSince("v1.0.0").
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetStyle).
 			WithURL("https://github.com/golang/lint").
+			WithModule("github.com/golangci/lint-1").
 			Deprecated("The repository of the linter has been archived by the owner.", "v1.41.0", "revive"),
 
 		linter.NewConfig(golinters.NewGoMND(goMndCfg)).
@@ -526,14 +585,16 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetStyle).
 			WithAlternativeNames(megacheckName).
-			WithURL("https://github.com/dominikh/go-tools/tree/master/simple"),
+			WithURL("https://github.com/dominikh/go-tools/tree/master/simple").
+			WithModule("honnef.co/go/tools"),
 
 		linter.NewConfig(golinters.NewGovet(govetCfg)).
 			WithSince("v1.0.0").
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetBugs, linter.PresetMetaLinter).
 			WithAlternativeNames("vet", "vetshadow").
-			WithURL("https://golang.org/cmd/vet/"),
+			WithURL("https://golang.org/cmd/vet/").
+			WithModule("golang.org/x/tools"),
 
 		linter.NewConfig(golinters.NewGrouper(grouperCfg)).
 			WithSince("v1.44.0").
@@ -561,6 +622,7 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetStyle).
 			WithURL("https://github.com/mvdan/interfacer").
+			WithModule("mvdan.cc/interfacer").
 			Deprecated("The repository of the linter has been archived by the owner.", "v1.38.0", "").
 			WithNoopFallback(m.cfg),
 
@@ -590,13 +652,15 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetPerformance).
 			WithURL("https://github.com/mdempsky/maligned").
+			WithModule("github.com/golangci/maligned").
 			Deprecated("The repository of the linter has been archived by the owner.", "v1.38.0", "govet 'fieldalignment'"),
 
 		linter.NewConfig(golinters.NewMisspell(misspellCfg)).
 			WithSince("v1.8.0").
 			WithPresets(linter.PresetStyle, linter.PresetComment).
 			WithAutoFix().
-			WithURL("https://github.com/client9/misspell"),
+			WithURL("https://github.com/client9/misspell").
+			WithModule("github.com/golangci/misspell"),
 
 		linter.NewConfig(golinters.NewNakedret(nakedretCfg)).
 			WithSince("v1.19.0").
@@ -701,20 +765,23 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetBugs, linter.PresetMetaLinter).
 			WithAlternativeNames(megacheckName).
-			WithURL("https://staticcheck.io/"),
+			WithURL("https://staticcheck.io/").
+			WithModule("honnef.co/go/tools"),
 
 		linter.NewConfig(golinters.NewStructcheck(structcheckCfg)).
 			WithSince("v1.0.0").
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetUnused).
 			WithURL("https://github.com/opennota/check").
+			WithModule("github.com/golangci/check").
 			WithNoopFallback(m.cfg),
 
 		linter.NewConfig(golinters.NewStylecheck(stylecheckCfg)).
 			WithSince("v1.20.0").
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetStyle).
-			WithURL("https://github.com/dominikh/go-tools/tree/master/stylecheck"),
+			WithURL("https://github.com/dominikh/go-tools/tree/master/stylecheck").
+			WithModule("honnef.co/go/tools"),
 
 		linter.NewConfig(golinters.NewTagliatelle(tagliatelleCfg)).
 			WithSince("v1.40.0").
@@ -755,13 +822,15 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 			WithSince("v1.0.0").
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetStyle).
-			WithURL("https://github.com/mdempsky/unconvert"),
+			WithURL("https://github.com/mdempsky/unconvert").
+			WithModule("github.com/golangci/unconvert"),
 
 		linter.NewConfig(golinters.NewUnparam(unparamCfg)).
 			WithSince("v1.9.0").
 			WithPresets(linter.PresetUnused).
 			WithLoadForGoAnalysis().
-			WithURL("https://github.com/mvdan/unparam"),
+			WithURL("https://github.com/mvdan/unparam").
+			WithModule("mvdan.cc/unparam"),
 
 		linter.NewConfig(golinters.NewUnused(unusedCfg)).
 			WithSince("v1.20.0").
@@ -770,13 +839,15 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 			WithAlternativeNames(megacheckName).
 			ConsiderSlow().
 			WithChangeTypes().
-			WithURL("https://github.com/dominikh/go-tools/tree/master/unused"),
+			WithURL("https://github.com/dominikh/go-tools/tree/master/unused").
+			WithModule("honnef.co/go/tools"),
 
 		linter.NewConfig(golinters.NewVarcheck(varcheckCfg)).
 			WithSince("v1.0.0").
 			WithLoadForGoAnalysis().
 			WithPresets(linter.PresetUnused).
-			WithURL("https://github.com/opennota/check"),
+			WithURL("https://github.com/opennota/check").
+			WithModule("github.com/golangci/check"),
 
 		linter.NewConfig(golinters.NewVarnamelen(varnamelenCfg)).
 			WithSince("v1.43.0").
@@ -854,6 +925,13 @@ func linterConfigsToMap(lcs []*linter.Config) map[string]*linter.Config {
 }
 
 func (m Manager) GetAllLinterConfigsForPreset(p string) []*linter.Config {
+	p = strings.ToLower(p)
+	if _, ok := m.customPresets()[p]; ok {
+		var ret []*linter.Config
+		m.addCustomPresetLinterConfigs(p, map[string]bool{}, map[string]bool{}, &ret)
+		return ret
+	}
+
 	var ret []*linter.Config
 	for _, lc := range m.GetAllSupportedLinterConfigs() {
 		for _, ip := range lc.InPresets {
@@ -867,14 +945,49 @@ func (m Manager) GetAllLinterConfigsForPreset(p string) []*linter.Config {
 	return ret
 }
 
+// addCustomPresetLinterConfigs adds the linters of the presets extended by the custom preset, then its own linters.
+// The presets already visited are skipped: the cycles are reported by the validator.
+func (m Manager) addCustomPresetLinterConfigs(p string, visited, added map[string]bool, ret *[]*linter.Config) {
+	if visited[p] {
+		return
+	}
+	visited[p] = true
+
+	add := func(lcs []*linter.Config) {
+		for _, lc := range lcs {
+			if !added[lc.Name()] {
+				added[lc.Name()] = true
+				*ret = append(*ret, lc)
+			}
+		}
+	}
+
+	preset := m.customPresets()[p]
+	for _, parent := range preset.Extends {
+		if _, ok := m.customPresets()[parent]; ok {
+			m.addCustomPresetLinterConfigs(parent, visited, added, ret)
+		} else {
+			add(m.GetAllLinterConfigsForPreset(parent))
+		}
+	}
+
+	for _, name := range preset.Linters {
+		add(m.GetLinterConfigs(name))
+	}
+}
+
 // loadCustomLinterConfig loads the configuration of private linters.
 // Private linters are dynamically loaded from .so plugin files.
 func (m Manager) loadCustomLinterConfig(name string, settings config.CustomLinterSettings) (*linter.Config, error) {
-	analyzer, err := m.getAnalyzerPlugin(settings.Path)
+	analyzer, err := plugins.Load(name, settings, m.cfg.GetConfigDir())
 	if err != nil {
 		return nil, err
 	}
-	m.log.Infof("Loaded %s: %s", settings.Path, name)
+	source := settings.Path
+	if source == "" {
+		source = settings.Module
+	}
+	m.log.Infof("Loaded %s: %s", source, name)
 	customLinter := goanalysis.NewLinter(
 		name,
 		settings.Description,
@@ -886,41 +999,3 @@ func (m Manager) loadCustomLinterConfig(name string, settings config.CustomLinte
 	linterConfig.WithURL(settings.OriginalURL)
 	return linterConfig, nil
 }
-
-type AnalyzerPlugin interface {
-	GetAnalyzers() []*analysis.Analyzer
-}
-
-// getAnalyzerPlugin loads a private linter as specified in the config file,
-// loads the plugin from a .so file, and returns the 'AnalyzerPlugin' interface
-// implemented by the private plugin.
-// An error is returned if the private linter cannot be loaded or the linter
-// does not implement the AnalyzerPlugin interface.
-func (m Manager) getAnalyzerPlugin(path string) (AnalyzerPlugin, error) {
-	if !filepath.IsAbs(path) {
-		// resolve non-absolute paths relative to config file's directory
-		configFilePath := viper.ConfigFileUsed()
-		absConfigFilePath, err := filepath.Abs(configFilePath)
-		if err != nil {
-			return nil, fmt.Errorf("could not get absolute representation of config file path %q: %v", configFilePath, err)
-		}
-		path = filepath.Join(filepath.Dir(absConfigFilePath), path)
-	}
-
-	plug, err := plugin.Open(path)
-	if err != nil {
-		return nil, err
-	}
-
-	symbol, err := plug.Lookup("AnalyzerPlugin")
-	if err != nil {
-		return nil, err
-	}
-
-	analyzerPlugin, ok := symbol.(AnalyzerPlugin)
-	if !ok {
-		return nil, fmt.Errorf("plugin %s does not abide by 'AnalyzerPlugin' interface", path)
-	}
-
-	return analyzerPlugin, nil
-}
@@ -2,8 +2,11 @@ package lintersdb
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
+	"golang.org/x/mod/semver"
+
 	"github.com/golangci/golangci-lint/pkg/config"
 )
 
@@ -20,6 +23,7 @@ func NewValidator(m *Manager) *Validator {
 func (v Validator) validateLintersNames(cfg *config.Linters) error {
 	allNames := append([]string{}, cfg.Enable...)
 	allNames = append(allNames, cfg.Disable...)
+	allNames = append(allNames, cfg.WarnOnly...)
 
 	var unknownNames []string
 
@@ -37,12 +41,27 @@ func (v Validator) validateLintersNames(cfg *config.Linters) error {
 	return nil
 }
 
+func (v Validator) validateOverridesLintersNames(overrides []config.Override) error {
+	for i, o := range overrides {
+		err := v.validateLintersNames(&config.Linters{Enable: o.Linters.Enable, Disable: o.Linters.Disable})
+		if err != nil {
+			return fmt.Errorf("override #%d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
 func (v Validator) validatePresets(cfg *config.Linters) error {
+	if err := v.validateCustomPresets(); err != nil {
+		return err
+	}
+
 	allPresets := v.m.allPresetsSet()
 	for _, p := range cfg.Presets {
-		if !allPresets[p] {
+		if !allPresets[strings.ToLower(p)] {
 			return fmt.Errorf("no such preset %q: only next presets exist: (%s)",
-				p, strings.Join(v.m.AllPresets(), "|"))
+				p, strings.Join(append(v.m.AllPresets(), v.m.CustomPresets()...), "|"))
 		}
 	}
 
@@ -53,6 +72,63 @@ func (v Validator) validatePresets(cfg *config.Linters) error {
 	return nil
 }
 
+// validateCustomPresets checks the names of the custom presets, their linters and the presets they extend,
+// and that they don't extend themselves.
+func (v Validator) validateCustomPresets() error {
+	builtin := map[string]bool{}
+	for _, p := range v.m.AllPresets() {
+		builtin[p] = true
+	}
+
+	custom := v.m.customPresets()
+	for _, name := range v.m.CustomPresets() {
+		if builtin[name] {
+			return fmt.Errorf("custom preset %q: the name of a built-in preset can't be reused", name)
+		}
+
+		for _, parent := range custom[name].Extends {
+			if _, ok := custom[parent]; !ok && !builtin[parent] {
+				return fmt.Errorf("custom preset %q: no such preset %q to extend", name, parent)
+			}
+		}
+
+		for _, linterName := range custom[name].Linters {
+			if v.m.GetLinterConfigs(linterName) == nil {
+				return fmt.Errorf("custom preset %q: unknown linter %q", name, linterName)
+			}
+		}
+	}
+
+	for _, name := range v.m.CustomPresets() {
+		if cycle := customPresetCycle(custom, name, nil); cycle != nil {
+			return fmt.Errorf("custom preset %q extends itself: %s", name, strings.Join(cycle, " -> "))
+		}
+	}
+
+	return nil
+}
+
+// customPresetCycle returns the chain of the presets extended from the path back to its first preset, if any.
+func customPresetCycle(custom map[string]config.CustomPreset, name string, path []string) []string {
+	if len(path) != 0 && name == path[0] {
+		return append(path, name)
+	}
+	for _, p := range path {
+		if p == name {
+			return nil // a cycle not containing the first preset, reported for its own presets.
+		}
+	}
+
+	path = append(path, name)
+	for _, parent := range custom[name].Extends {
+		if cycle := customPresetCycle(custom, parent, path); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
 func (v Validator) validateAllDisableEnableOptions(cfg *config.Linters) error {
 	if cfg.EnableAll && cfg.DisableAll {
 		return fmt.Errorf("--enable-all and --disable-all options must not be combined")
@@ -90,12 +166,60 @@ func (v Validator) validateDisabledAndEnabledAtOneMoment(cfg *config.Linters) er
 	return nil
 }
 
+// validatePinnedVersions checks that the bundled versions of the linters match the pinned ones:
+// "v1" and "v1.2" pin the major and the minor versions, "v1.2.3" pins the exact version.
+func (v Validator) validatePinnedVersions(cfg *config.Linters) error {
+	names := make([]string, 0, len(cfg.Versions))
+	for name := range cfg.Versions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		pinned := cfg.Versions[name]
+		if !semver.IsValid(pinned) {
+			return fmt.Errorf("linter %s: invalid pinned version %q", name, pinned)
+		}
+
+		lcs := v.m.GetLinterConfigs(name)
+		if lcs == nil {
+			return fmt.Errorf("can't pin the version of the unknown linter %q", name)
+		}
+
+		for _, lc := range lcs {
+			upstream := lc.Upstream()
+			if upstream == nil {
+				return fmt.Errorf("linter %s: the bundled version is unknown, it can't be pinned", name)
+			}
+
+			if !matchVersion(upstream.Version, pinned) {
+				return fmt.Errorf("linter %s: %s %s is bundled, but %s is pinned: "+
+					"check the changes of the linter, then update the pinned version", name, upstream.Path, upstream.Version, pinned)
+			}
+		}
+	}
+
+	return nil
+}
+
+func matchVersion(version, pinned string) bool {
+	switch strings.Count(strings.SplitN(pinned, "-", 2)[0], ".") {
+	case 0:
+		return semver.Major(version) == semver.Major(pinned)
+	case 1:
+		return semver.MajorMinor(version) == semver.MajorMinor(pinned)
+	default:
+		return semver.Compare(version, pinned) == 0
+	}
+}
+
 func (v Validator) validateEnabledDisabledLintersConfig(cfg *config.Linters) error {
 	validators := []func(cfg *config.Linters) error{
 		v.validateLintersNames,
 		v.validatePresets,
 		v.validateAllDisableEnableOptions,
 		v.validateDisabledAndEnabledAtOneMoment,
+		v.validatePinnedVersions,
 	}
 	for _, v := range validators {
 		if err := v(cfg); err != nil {
@@ -0,0 +1,105 @@
+package lintersdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+)
+
+func TestValidator_validatePinnedVersions(t *testing.T) {
+	m := NewManager(nil, nil)
+	v := NewValidator(m)
+
+	upstream := m.GetLinterConfigs("staticcheck")[0].Upstream()
+	require.NotNil(t, upstream)
+	assert.Equal(t, "honnef.co/go/tools", upstream.Path)
+
+	require.NoError(t, v.validatePinnedVersions(&config.Linters{Versions: map[string]string{
+		"staticcheck": upstream.Version,
+		"gosimple":    "v0",
+	}}))
+
+	testCases := []struct {
+		desc     string
+		versions map[string]string
+		expected string
+	}{
+		{desc: "other version", versions: map[string]string{"staticcheck": "v99.1"}, expected: "is pinned"},
+		{desc: "invalid version", versions: map[string]string{"staticcheck": "latest"}, expected: "invalid pinned version"},
+		{desc: "unknown linter", versions: map[string]string{"unknown": "v1"}, expected: "unknown linter"},
+		{desc: "in-tree linter", versions: map[string]string{"nolintlint": "v1"}, expected: "the bundled version is unknown"},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			err := v.validatePinnedVersions(&config.Linters{Versions: test.versions})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expected)
+		})
+	}
+}
+
+func TestMatchVersion(t *testing.T) {
+	assert.True(t, matchVersion("v0.3.2", "v0"))
+	assert.True(t, matchVersion("v0.3.2", "v0.3"))
+	assert.True(t, matchVersion("v0.3.2", "v0.3.2"))
+	assert.False(t, matchVersion("v0.3.2", "v0.4"))
+	assert.False(t, matchVersion("v0.3.2", "v0.3.1"))
+	assert.True(t, matchVersion("v0.0.0-20180506172741-cfe4005ccda2", "v0.0.0-20180506172741-cfe4005ccda2"))
+}
+
+func TestValidator_validateCustomPresets(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		presets  map[string]config.CustomPreset
+		expected string
+	}{
+		{
+			desc:    "valid",
+			presets: map[string]config.CustomPreset{"a": {Extends: []string{"bugs"}, Linters: []string{"gosec"}}, "b": {Extends: []string{"a"}}},
+		},
+		{
+			desc:     "built-in name",
+			presets:  map[string]config.CustomPreset{"bugs": {Linters: []string{"gosec"}}},
+			expected: `custom preset "bugs": the name of a built-in preset can't be reused`,
+		},
+		{
+			desc:     "unknown linter",
+			presets:  map[string]config.CustomPreset{"a": {Linters: []string{"unknown"}}},
+			expected: `custom preset "a": unknown linter "unknown"`,
+		},
+		{
+			desc:     "unknown preset",
+			presets:  map[string]config.CustomPreset{"a": {Extends: []string{"unknown"}}},
+			expected: `custom preset "a": no such preset "unknown" to extend`,
+		},
+		{
+			desc: "cycle",
+			presets: map[string]config.CustomPreset{
+				"a": {Extends: []string{"b"}},
+				"b": {Extends: []string{"c"}},
+				"c": {Extends: []string{"a"}},
+			},
+			expected: `custom preset "a" extends itself: a -> b -> c -> a`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			m := NewManager(&config.Config{Presets: config.Presets{Custom: test.presets}}, nil)
+
+			err := NewValidator(m).validatePresets(&config.Linters{})
+			if test.expected == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/golangci/golangci-lint/pkg/goutil"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/resources"
 )
 
 type ContextLoader struct {
@@ -73,26 +74,45 @@ func (cl *ContextLoader) findLoadMode(linters []*linter.Config) packages.LoadMod
 	return loadMode
 }
 
-func (cl *ContextLoader) buildArgs() []string {
-	args := cl.cfg.Run.Args
-	if len(args) == 0 {
-		return []string{"./..."}
+// buildArgs returns the patterns of the packages to load, ws is the workspace of the working directory if any.
+func (cl *ContextLoader) buildArgs(ws *goutil.Workspace) []string {
+	if ws != nil {
+		cl.log.Infof("Using the modules of the workspace %s", ws.Path)
 	}
 
 	var retArgs []string
-	for _, arg := range args {
-		if strings.HasPrefix(arg, ".") || filepath.IsAbs(arg) {
-			retArgs = append(retArgs, arg)
-		} else {
-			// go/packages doesn't work well if we don't have the prefix ./ for local packages
-			retArgs = append(retArgs, fmt.Sprintf(".%c%s", filepath.Separator, arg))
+	for _, arg := range goutil.ExpandPatterns(ws, cl.cfg.Run.Args) {
+		if isEnclosedFile(arg) {
+			// the package of the file is loaded, its other files are needed to type-check it
+			abs, err := filepath.Abs(arg)
+			if err == nil {
+				retArgs = append(retArgs, "file="+abs)
+				continue
+			}
 		}
+
+		retArgs = append(retArgs, arg)
 	}
 
 	return retArgs
 }
 
-func (cl *ContextLoader) makeBuildFlags() ([]string, error) {
+// isEnclosedFile returns true if the argument is a Go file of a package.
+// The files of the testdata directories aren't parts of packages: they are loaded alone, as by the go command.
+func isEnclosedFile(arg string) bool {
+	if !strings.HasSuffix(arg, ".go") {
+		return false
+	}
+
+	for _, elem := range strings.Split(filepath.ToSlash(filepath.Dir(arg)), "/") {
+		if elem == "testdata" {
+			return false
+		}
+	}
+	return true
+}
+
+func (cl *ContextLoader) makeBuildFlags(ws *goutil.Workspace) ([]string, error) {
 	var buildFlags []string
 
 	if len(cl.cfg.Run.BuildTags) != 0 {
@@ -116,6 +136,12 @@ func (cl *ContextLoader) makeBuildFlags() ([]string, error) {
 			return nil, fmt.Errorf("invalid modules download path %s, only (%s) allowed", mod, strings.Join(allowedMods, "|"))
 		}
 
+		if ws != nil && mod == "mod" {
+			// the go command refuses -mod=mod in workspace mode
+			cl.log.Warnf("Modules download mode %q isn't supported in the workspace %s: it's ignored", mod, ws.Path)
+			return buildFlags, nil
+		}
+
 		buildFlags = append(buildFlags, fmt.Sprintf("-mod=%s", cl.cfg.Run.ModulesDownloadMode))
 	}
 
@@ -146,6 +172,7 @@ func stringifyLoadMode(mode packages.LoadMode) string {
 	return fmt.Sprintf("%d (%s)", mode, strings.Join(flags, "|"))
 }
 
+// logf prints the debug logs of go/packages, and records the durations of the go commands.
 func (cl *ContextLoader) debugPrintLoadedPackages(pkgs []*packages.Package) {
 	cl.debugf("loaded %d pkgs", len(pkgs))
 	for i, pkg := range pkgs {
@@ -176,12 +203,20 @@ func (cl *ContextLoader) parseLoadedPackagesErrors(pkgs []*packages.Package) err
 
 func (cl *ContextLoader) loadPackages(ctx context.Context, loadMode packages.LoadMode) ([]*packages.Package, error) {
 	defer func(startedAt time.Time) {
-		cl.log.Infof("Go packages loading at mode %s took %s", stringifyLoadMode(loadMode), time.Since(startedAt))
+		took := time.Since(startedAt)
+		logutils.InfoEvent(cl.log, "packages_loading",
+			logutils.Fields{"mode": stringifyLoadMode(loadMode), "duration_ms": logutils.DurationField(took)},
+			"Go packages loading at mode %s took %s", stringifyLoadMode(loadMode), took)
 	}(time.Now())
 
 	cl.prepareBuildContext()
 
-	buildFlags, err := cl.makeBuildFlags()
+	ws, err := goutil.FindWorkspace(".")
+	if err != nil {
+		cl.log.Warnf("Can't read go.work: %s", err)
+	}
+
+	buildFlags, err := cl.makeBuildFlags(ws)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to make build flags for go list")
 	}
@@ -192,12 +227,18 @@ func (cl *ContextLoader) loadPackages(ctx context.Context, loadMode packages.Loa
 		Context:    ctx,
 		BuildFlags: buildFlags,
 		Logf:       cl.debugf,
-		// TODO: use fset, parsefile, overlay
+		Overlay:    fsutils.OverlayFiles(),
+		// TODO: use fset, parsefile
 	}
 
-	args := cl.buildArgs()
+	args := cl.buildArgs(ws)
 	cl.debugf("Built loader args are %s", args)
+	loadStartedAt := time.Now()
 	pkgs, err := packages.Load(conf, args...)
+	logutils.RecordEvent("go_command", logutils.Fields{
+		"command":     "packages.Load " + strings.Join(args, " "),
+		"duration_ms": logutils.DurationField(time.Since(loadStartedAt)),
+	})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load with go/packages")
 	}
@@ -251,6 +292,24 @@ func (cl *ContextLoader) filterTestMainPackages(pkgs []*packages.Package) []*pac
 	return retPkgs
 }
 
+// filterShardPackages keeps the packages of the shard of the run: a shard can be empty.
+func (cl *ContextLoader) filterShardPackages(pkgs []*packages.Package) ([]*packages.Package, error) {
+	shard, err := config.ParseShard(cl.cfg.Run.Shard)
+	if err != nil {
+		return nil, err
+	}
+
+	var retPkgs []*packages.Package
+	for _, pkg := range pkgs {
+		if shard.Contains(pkg.PkgPath) {
+			retPkgs = append(retPkgs, pkg)
+		}
+	}
+
+	cl.log.Infof("Shard %s: analyzing %d/%d packages", shard, len(retPkgs), len(pkgs))
+	return retPkgs, nil
+}
+
 func (cl *ContextLoader) filterDuplicatePackages(pkgs []*packages.Package) []*packages.Package {
 	packagesWithTests := map[string]bool{}
 	for _, pkg := range pkgs {
@@ -290,9 +349,20 @@ func (cl *ContextLoader) Load(ctx context.Context, linters []*linter.Config) (*l
 		return nil, errors.Wrap(err, "failed to load packages")
 	}
 
+	if len(pkgs) == 0 {
+		return nil, exitcodes.ErrNoGoFiles
+	}
+
+	if cl.cfg.Run.Shard != "" {
+		pkgs, err = cl.filterShardPackages(pkgs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	deduplicatedPkgs := cl.filterDuplicatePackages(pkgs)
 
-	if len(deduplicatedPkgs) == 0 {
+	if len(deduplicatedPkgs) == 0 && cl.cfg.Run.Shard == "" {
 		return nil, exitcodes.ErrNoGoFiles
 	}
 
@@ -309,6 +379,8 @@ func (cl *ContextLoader) Load(ctx context.Context, linters []*linter.Config) (*l
 		LineCache: cl.lineCache,
 		PkgCache:  cl.pkgCache,
 		LoadGuard: cl.loadGuard,
+
+		MemoryBudget: resources.NewMemoryBudget(cl.cfg.Run.MaxMemory),
 	}
 
 	return ret, nil
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
@@ -13,11 +14,14 @@ import (
 	"github.com/golangci/golangci-lint/internal/errorutil"
 	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis"
 	"github.com/golangci/golangci-lint/pkg/goutil"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/packages"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/resources"
 	"github.com/golangci/golangci-lint/pkg/result"
 	"github.com/golangci/golangci-lint/pkg/result/processors"
 	"github.com/golangci/golangci-lint/pkg/timeutils"
@@ -25,12 +29,69 @@ import (
 
 type Runner struct {
 	Processors []processors.Processor
+	Baseline   *processors.Baseline
 	Log        logutils.Log
+
+	// Suppressions hides the issues suppressed in the store of issues.suppressions, once loaded.
+	Suppressions *processors.Suppressions
+
+	// Analytics aggregates the counts of issues and the durations, if not nil.
+	Analytics *report.Analytics
+
+	// Timeouts are the durations after which the linters are cancelled, by linter name.
+	Timeouts map[string]time.Duration
+	// ReportData records the linters which timed out, their durations and their excluded issues, if not nil.
+	ReportData *report.Data
+	// RunReport records the duration and the memory of the runs of the linters, if not nil.
+	RunReport *report.RunReport
+	// OnIssues receives the processed issues of each linter as soon as it finishes, for the streaming output, if not nil.
+	// The issues added by the processors of the issues of the run, like the unused nolint directives, are received last.
+	OnIssues func(issues []result.Issue)
+	// OnLinterStart and OnLinterDone are called when a linter starts and finishes, with the names of its linters:
+	// a go/analysis linter combines several linters.
+	OnLinterStart func(linters []string)
+	OnLinterDone  func(linters []string)
+	// OnProcessing is called when the linters are finished, before the processing of their issues.
+	OnProcessing func()
+	// OnLimiting receives the issues before the limiting processors, from the uniq by line, and returns the issues to limit,
+	// if not nil: the issues hidden by the limits are still found, e.g. they aren't fixed.
+	// It receives the batches of the streaming, or all the issues at once.
+	OnLimiting func(issues []result.Issue) []result.Issue
+
+	// Unmerged stops the processing of the issues of Run before the baseline:
+	// the issues of several runs are merged, then processed once by Finalize.
+	Unmerged bool
+	// mergeAt is the index of the processors of the merged issues, from the baseline.
+	mergeAt int
+	// sortResults sorts the issues of the streamed batches at the end of the run.
+	sortResults *processors.SortResults
+	// limiting is the first limiting processor, the processor of OnLimiting.
+	limiting processors.Processor
+
+	// linterURLs are the URLs of the enabled linters, by name: the documentation of the issues without rule URLs.
+	linterURLs map[string]string
+	// testsScopes are the linters analyzing only the test packages, or none of them, by name.
+	testsScopes map[string]testsScope
+	// inlineRuns are the runs of the linters with the settings of the inline configs of the files.
+	inlineRuns []*inlineRun
+	// typecheck is the policy of the type errors: the ignored paths and the fail-fast mode.
+	typecheck typecheckPolicy
+	// strict fails the run on the panics of the linters, instead of recording them as warnings of the run.
+	strict bool
 }
 
+// testsScope selects the test packages analyzed by a linter, for `run.tests-linters`.
+type testsScope int
+
+const (
+	allPackages testsScope = iota
+	withoutTestPackages
+	onlyTestPackages
+)
+
 func NewRunner(cfg *config.Config, log logutils.Log, goenv *goutil.Env, es *lintersdb.EnabledSet,
 	lineCache *fsutils.LineCache, dbManager *lintersdb.Manager, pkgs []*gopackages.Package) (*Runner, error) {
-	skipFilesProcessor, err := processors.NewSkipFiles(cfg.Run.SkipFiles)
+	skipFilesProcessor, err := processors.NewSkipFiles(cfg.Run.SkipFiles, log.Child("skip files"))
 	if err != nil {
 		return nil, err
 	}
@@ -44,11 +105,84 @@ func NewRunner(cfg *config.Config, log logutils.Log, goenv *goutil.Env, es *lint
 		return nil, err
 	}
 
+	selectedFilesProcessor, err := processors.NewSelectedFiles(cfg.Run.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	baselineProcessor, err := processors.NewBaseline(cfg.Issues.Baseline, lineCache, log.Child("baseline"))
+	if err != nil {
+		return nil, err
+	}
+
+	suppressionsProcessor := processors.NewSuppressions(lineCache, log.Child("suppressions"))
+
+	patchProcessor, err := processors.NewPatch(cfg.Issues.DiffFile, cfg.Issues.WholeFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	excludeScopesProcessor, err := processors.NewExcludeScopes(cfg.Issues.ExcludeScopes)
+	if err != nil {
+		return nil, err
+	}
+
+	coverageProcessor, err := processors.NewCoverage(&cfg.Issues.Coverage)
+	if err != nil {
+		return nil, err
+	}
+
+	codeOwnersProcessor, err := processors.NewCodeOwners(&cfg.Issues.CodeOwners, log.Child("code_owners"))
+	if err != nil {
+		return nil, err
+	}
+
 	enabledLinters, err := es.GetEnabledLintersMap()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get enabled linters")
 	}
 
+	linterURLs := map[string]string{}
+	for name, lc := range enabledLinters {
+		linterURLs[name] = lc.OriginalURL
+	}
+
+	inlineConfigs, err := readInlineConfigs(packagesGoFiles(pkgs))
+	if err != nil {
+		return nil, err
+	}
+	inlineOverrides, inlineRuns, err := getInlineConfigs(cfg, inlineConfigs, dbManager, enabledLinters,
+		log.Child("inline_config"))
+	if err != nil {
+		return nil, err
+	}
+
+	overridesRuns, err := getOverridesRuns(cfg, packagesGoFiles(pkgs), dbManager, enabledLinters, inlineRuns,
+		log.Child("overrides"))
+	if err != nil {
+		return nil, err
+	}
+	inlineRuns = append(inlineRuns, overridesRuns...)
+
+	// The inline configs of the files have priority over the overrides of the config.
+	overrides := append(append([]config.Override{}, cfg.AllOverrides()...), inlineOverrides...)
+	overridesProcessor, err := getOverridesProcessor(overrides, dbManager, enabledLinters)
+	if err != nil {
+		return nil, err
+	}
+
+	autogeneratedExcludeProcessor, err := processors.NewAutogeneratedExclude(cfg.Issues.ExcludeGenerated,
+		cfg.Issues.ExcludeGeneratedMarkers, cfg.Issues.ExcludeGeneratedPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	nolintProcessor := processors.NewNolint(log.Child("nolint"), dbManager, enabledLinters).
+		WithReasonRequired(dbManager.GetCanonicalNames(cfg.Policy.NolintRequireReason))
+	unusedNolintProcessor := processors.NewUnusedNolint(cfg.Issues.ReportUnusedNolintDirectives, nolintProcessor,
+		packagesGoFiles(pkgs), []processors.Processor{skipFilesProcessor, skipDirsProcessor, autogeneratedExcludeProcessor},
+		lineCache, log.Child("unused_nolint"))
+
 	// print deprecated messages
 	if !cfg.InternalCmdTest {
 		for name, lc := range enabledLinters {
@@ -61,50 +195,200 @@ func NewRunner(cfg *config.Config, log logutils.Log, goenv *goutil.Env, es *lint
 				extra = fmt.Sprintf(" Replaced by %s.", lc.Deprecation.Replacement)
 			}
 
-			log.Warnf("The linter '%s' is deprecated (since %s) due to: %s %s", name, lc.Deprecation.Since, lc.Deprecation.Message, extra)
+			logutils.WarnEvent(log, "linter_deprecated",
+				logutils.Fields{"linter": name, "since": lc.Deprecation.Since, "replacement": lc.Deprecation.Replacement},
+				"The linter '%s' is deprecated (since %s) due to: %s %s", name, lc.Deprecation.Since, lc.Deprecation.Message, extra)
 		}
 	}
 
-	return &Runner{
+	typecheck, err := newTypecheckPolicy(&cfg.Typecheck)
+	if err != nil {
+		return nil, err
+	}
+
+	// The limits of the issues of the run are applied to the issues of all the shards by merge-results.
+	maxSameIssues, maxIssuesPerLinter := cfg.Issues.MaxSameIssues, cfg.Issues.MaxIssuesPerLinter
+	if cfg.Run.Shard != "" {
+		maxSameIssues, maxIssuesPerLinter = 0, 0
+	}
+
+	sortResultsProcessor := processors.NewSortResults(cfg)
+	uniqByLineProcessor := processors.NewUniqByLine(cfg)
+
+	r := &Runner{
 		Processors: []processors.Processor{
 			processors.NewCgo(goenv),
 
 			// Must go after Cgo.
 			processors.NewFilenameUnadjuster(pkgs, log.Child("filename_unadjuster")),
 
+			// Must go after FilenameUnadjuster: the paths are compared to the arguments.
+			selectedFilesProcessor,
+
 			// Must be before diff, nolint and exclude autogenerated processor at least.
 			processors.NewPathPrettifier(),
 			skipFilesProcessor,
 			skipDirsProcessor, // must be after path prettifier
+			overridesProcessor,
 
-			processors.NewAutogeneratedExclude(),
+			autogeneratedExcludeProcessor,
 
 			// Must be before exclude because users see already marked output and configure excluding by it.
 			processors.NewIdentifierMarker(),
 
 			getExcludeProcessor(&cfg.Issues),
 			getExcludeRulesProcessor(&cfg.Issues, log, lineCache),
-			processors.NewNolint(log.Child("nolint"), dbManager, enabledLinters),
 
-			processors.NewUniqByLine(cfg),
+			// Must be after exclude: the default and the user exclusions match the messages of the linters.
+			processors.NewMessageNormalizer(&cfg.Issues.Normalize),
+
+			excludeScopesProcessor,
+			nolintProcessor,
+			unusedNolintProcessor, // must be after nolint
+
+			// Must be before limiting processors to record all issues.
+			baselineProcessor,
+
+			// Must be before limiting processors to hide the suppressed issues first.
+			suppressionsProcessor,
+
+			// Must be before limiting processors to hide the covered issues first.
+			coverageProcessor,
+
+			// Must be before limiting processors to keep the issues of the owners.
+			codeOwnersProcessor,
+
+			// Must be before uniq by line to keep the issue of the canonical check.
+			processors.NewDedup(&cfg.Issues.Dedup, log.Child("dedup")),
+
+			uniqByLineProcessor,
 			processors.NewDiff(cfg.Issues.Diff, cfg.Issues.DiffFromRevision, cfg.Issues.DiffPatchFilePath, cfg.Issues.WholeFiles),
+			patchProcessor,
 			processors.NewMaxPerFileFromLinter(cfg),
-			processors.NewMaxSameIssues(cfg.Issues.MaxSameIssues, log.Child("max_same_issues"), cfg),
-			processors.NewMaxFromLinter(cfg.Issues.MaxIssuesPerLinter, log.Child("max_from_linter"), cfg),
-			processors.NewSourceCode(lineCache, log.Child("source_code")),
+			processors.NewMaxSameIssues(maxSameIssues, log.Child("max_same_issues"), cfg),
+			processors.NewMaxFromLinter(maxIssuesPerLinter, log.Child("max_from_linter"), cfg),
+			processors.NewSourceCode(lineCache, cfg.Output.PrintIssuedLinesContext, log.Child("source_code")),
 			processors.NewPathShortener(),
-			getSeverityRulesProcessor(&cfg.Severity, log, lineCache),
+			getSeverityRulesProcessor(&cfg.Severity, cfg.Run.TestsLinters.Severity, log, lineCache),
+			processors.NewWarnOnly(dbManager.GetCanonicalNames(cfg.Linters.WarnOnly)), // must be after severity rules
 			processors.NewPathPrefixer(cfg.Output.PathPrefix),
-			processors.NewSortResults(cfg),
+			sortResultsProcessor,
 		},
-		Log: log,
-	}, nil
+		Baseline:     baselineProcessor,
+		Suppressions: suppressionsProcessor,
+		Log:          log,
+		Timeouts:     cfg.LintersSettings.Timeouts,
+
+		linterURLs:  linterURLs,
+		testsScopes: getTestsScopes(cfg, dbManager, enabledLinters),
+		inlineRuns:  inlineRuns,
+		typecheck:   typecheck,
+		strict:      cfg.Run.Strict,
+
+		sortResults: sortResultsProcessor,
+		limiting:    uniqByLineProcessor,
+	}
+
+	for i, p := range r.Processors {
+		if p == baselineProcessor {
+			r.mergeAt = i
+		}
+	}
+
+	return r, nil
+}
+
+// getTestsScopes returns the linters disabled for all the test files, and the linters enabled only for them,
+// by `run.tests-linters`: the linters enabled by the explicit overrides analyze all the packages.
+func getTestsScopes(cfg *config.Config, dbManager *lintersdb.Manager,
+	enabledLinters map[string]*linter.Config) map[string]testsScope {
+	scopes := map[string]testsScope{}
+	if !cfg.Run.AnalyzeTests {
+		return scopes
+	}
+
+	explicit := map[string]bool{}
+	for _, o := range cfg.Overrides {
+		for name := range dbManager.GetCanonicalNames(o.Linters.Enable) {
+			explicit[name] = true
+		}
+	}
+
+	t := cfg.Run.TestsLinters
+	enable, disable := dbManager.GetCanonicalNames(t.Enable), dbManager.GetCanonicalNames(t.Disable)
+
+	for name := range enable {
+		if enabledLinters[name] == nil && !explicit[name] {
+			scopes[name] = onlyTestPackages
+		}
+	}
+
+	for name := range enabledLinters {
+		if !explicit[name] && (disable[name] || (t.DisableAll && !enable[name])) {
+			scopes[name] = withoutTestPackages
+		}
+	}
+
+	return scopes
+}
+
+// packagesContext returns the context with the packages analyzed by the linter:
+// all the linters of a metalinter must have the same tests scope to restrict its packages.
+func (r *Runner) packagesContext(lintCtx *linter.Context, lc *linter.Config) *linter.Context {
+	names := linterNames(lc)
+
+	scope := r.testsScopes[names[0]]
+	for _, name := range names[1:] {
+		if r.testsScopes[name] != scope {
+			return lintCtx
+		}
+	}
+
+	switch scope {
+	case withoutTestPackages:
+		return lintCtx.WithoutTestPackages()
+	case onlyTestPackages:
+		return lintCtx.WithOnlyTestPackages()
+	default:
+		return lintCtx
+	}
+}
+
+// packagesGoFiles returns the Go files of the packages.
+func packagesGoFiles(pkgs []*gopackages.Package) []string {
+	var files []string
+	for _, pkg := range pkgs {
+		files = append(files, pkg.GoFiles...)
+	}
+	return files
+}
+
+// linterContext returns the context of the linter, cancelled at its timeout.
+// The other linters continue after it.
+// Without timeout, the context of the run is kept: the analyzers run without a watcher if the run has no deadline.
+func (r Runner) linterContext(ctx context.Context, lc *linter.Config) (context.Context, context.CancelFunc) {
+	timeout := r.Timeouts[lc.Name()]
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
 }
 
 func (r *Runner) runLinterSafe(ctx context.Context, lintCtx *linter.Context,
 	lc *linter.Config) (ret []result.Issue, err error) {
 	defer func() {
 		if panicData := recover(); panicData != nil {
+			if !r.strict {
+				p := linter.Panic{Linter: lc.Name(), Message: fmt.Sprint(panicData), Stack: debug.Stack()}
+				if pe, ok := panicData.(*errorutil.PanicError); ok {
+					p.Message, p.Stack = pe.Error(), pe.Stack()
+				}
+				r.recordPanic(p)
+				ret, err = nil, nil
+				return
+			}
+
 			if pe, ok := panicData.(*errorutil.PanicError); ok {
 				err = fmt.Errorf("%s: %w", lc.Name(), pe)
 
@@ -117,7 +401,7 @@ func (r *Runner) runLinterSafe(ctx context.Context, lintCtx *linter.Context,
 		}
 	}()
 
-	issues, err := lc.Linter.Run(ctx, lintCtx)
+	issues, err := lc.Linter.Run(ctx, r.packagesContext(lintCtx, lc))
 
 	if lc.DoesChangeTypes {
 		// Packages in lintCtx might be dirty due to the last analysis,
@@ -136,6 +420,12 @@ func (r *Runner) runLinterSafe(ctx context.Context, lintCtx *linter.Context,
 		if issues[i].FromLinter == "" {
 			issues[i].FromLinter = lc.Name()
 		}
+		if issues[i].RuleID == "" {
+			issues[i].RuleID = issues[i].FromLinter
+		}
+		if issues[i].DocumentationURL == "" {
+			issues[i].DocumentationURL = r.linterURLs[issues[i].FromLinter]
+		}
 	}
 
 	return issues, nil
@@ -147,48 +437,114 @@ type processorStat struct {
 }
 
 func (r Runner) processLintResults(inIssues []result.Issue) []result.Issue {
-	sw := timeutils.NewStopwatch("processing", r.Log)
-
-	var issuesBefore, issuesAfter int
-	statPerProcessor := map[string]processorStat{}
+	p := r.newIssuesProcessing()
+	if r.Unmerged {
+		p.processors = r.Processors[:r.mergeAt]
+	}
 
 	var outIssues []result.Issue
 	if len(inIssues) != 0 {
-		issuesBefore += len(inIssues)
-		outIssues = r.processIssues(inIssues, sw, statPerProcessor)
-		issuesAfter += len(outIssues)
+		outIssues = p.process(inIssues, true)
+	}
+
+	p.finish()
+
+	return outIssues
+}
+
+// Finalize processes the merged issues of the runs of Unmerged runners: from the baseline to the sorting.
+func (r Runner) Finalize(issues []result.Issue) []result.Issue {
+	p := r.newIssuesProcessing()
+	p.processors = r.Processors[r.mergeAt:]
+
+	outIssues := p.process(issues, true)
+	p.finish()
+
+	return outIssues
+}
+
+// issuesProcessing processes the issues of the linters at once, or in batches for the streaming output.
+type issuesProcessing struct {
+	r  Runner
+	sw *timeutils.Stopwatch
+	// processors are the processors of the issues: a range of the processors of the runner.
+	processors []processors.Processor
+
+	issuesBefore, issuesAfter int
+	statPerProcessor          map[string]processorStat
+}
+
+func (r Runner) newIssuesProcessing() *issuesProcessing {
+	return &issuesProcessing{
+		r:                r,
+		sw:               timeutils.NewStopwatch("processing", r.Log),
+		processors:       r.Processors,
+		statPerProcessor: map[string]processorStat{},
 	}
+}
+
+// process processes a batch of issues: the processors of the issues of the run only process the last batch.
+func (p *issuesProcessing) process(issues []result.Issue, last bool) []result.Issue {
+	p.issuesBefore += len(issues)
+	outIssues := p.r.processIssues(p.processors, issues, p.sw, p.statPerProcessor, last)
+	p.issuesAfter += len(outIssues)
+
+	return outIssues
+}
+
+func (p *issuesProcessing) finish() {
+	r := p.r
 
 	// finalize processors: logging, clearing, no heavy work here
 
-	for _, p := range r.Processors {
-		p := p
-		sw.TrackStage(p.Name(), func() {
-			p.Finish()
+	for _, proc := range p.processors {
+		proc := proc
+		p.sw.TrackStage(proc.Name(), func() {
+			proc.Finish()
 		})
 	}
 
-	if issuesBefore != issuesAfter {
-		r.Log.Infof("Issues before processing: %d, after processing: %d", issuesBefore, issuesAfter)
+	if p.issuesBefore != p.issuesAfter {
+		logutils.InfoEvent(r.Log, "issues_processed", logutils.Fields{"before": p.issuesBefore, "after": p.issuesAfter},
+			"Issues before processing: %d, after processing: %d", p.issuesBefore, p.issuesAfter)
 	}
-	r.printPerProcessorStat(statPerProcessor)
-	sw.PrintStages()
+	r.printPerProcessorStat(p.statPerProcessor)
+	p.sw.PrintStages()
 
-	return outIssues
+	if r.Analytics != nil {
+		r.Analytics.AddStages(p.sw.Stages())
+	}
 }
 
 func (r Runner) printPerProcessorStat(stat map[string]processorStat) {
 	parts := make([]string, 0, len(stat))
+	fields := logutils.Fields{}
 	for name, ps := range stat {
 		if ps.inCount != 0 {
 			parts = append(parts, fmt.Sprintf("%s: %d/%d", name, ps.outCount, ps.inCount))
+			fields[name] = map[string]int{"in": ps.inCount, "out": ps.outCount}
 		}
 	}
 	if len(parts) != 0 {
-		r.Log.Infof("Processors filtering stat (out/in): %s", strings.Join(parts, ", "))
+		logutils.InfoEvent(r.Log, "processors_stat", logutils.Fields{"processors": fields},
+			"Processors filtering stat (out/in): %s", strings.Join(parts, ", "))
 	}
 }
 
+// LinterError is the failure of a linter which couldn't run.
+type LinterError struct {
+	Linter string
+	Err    error
+}
+
+func (e *LinterError) Error() string {
+	return fmt.Sprintf("can't run linter %s: %s", e.Linter, e.Err)
+}
+
+func (e *LinterError) Unwrap() error {
+	return e.Err
+}
+
 func (r Runner) Run(ctx context.Context, linters []*linter.Config, lintCtx *linter.Context) ([]result.Issue, error) {
 	sw := timeutils.NewStopwatch("linters", r.Log)
 	defer sw.Print()
@@ -196,27 +552,265 @@ func (r Runner) Run(ctx context.Context, linters []*linter.Config, lintCtx *lint
 	var (
 		lintErrors *multierror.Error
 		issues     []result.Issue
+		timedOut   []string
+		degraded   []string
+		// stopped stops the run of the remaining linters at the first type error of the fail-fast mode.
+		stopped bool
 	)
 
-	for _, lc := range linters {
-		lc := lc
+	var (
+		streaming    *issuesProcessing
+		streamIssues []result.Issue
+	)
+	if r.OnIssues != nil {
+		streaming = r.newIssuesProcessing()
+	}
+
+	if !r.strict {
+		withPanics := *lintCtx
+		withPanics.OnPanic = r.recordPanic
+		lintCtx = &withPanics
+	}
+
+	budget := lintCtx.MemoryBudget
+	if budget != nil {
+		monitorCtx, stopMonitor := context.WithCancel(ctx)
+		defer stopMonitor()
+
+		go r.monitorMemory(monitorCtx, budget)
+	}
+
+	// runLinter runs the linter with the settings of the config, or with the settings of the inline run if not nil.
+	runLinter := func(lc *linter.Config, inline *inlineRun) {
+		if r.OnLinterStart != nil && inline == nil {
+			r.OnLinterStart(linterNames(lc))
+		}
+		if r.OnLinterDone != nil && inline == nil {
+			defer r.OnLinterDone(linterNames(lc))
+		}
+
+		runCtx := lintCtx
+		if inline != nil {
+			runCtx = inline.context(lintCtx)
+		}
+
 		sw.TrackStage(lc.Name(), func() {
-			linterIssues, err := r.runLinterSafe(ctx, lintCtx, lc)
+			linterCtx, cancel := r.linterContext(ctx, lc)
+			defer cancel()
+
+			startedAt, allocatedMB := time.Now(), resources.AllocatedMB()
+
+			linterIssues, err := r.runLinterSafe(linterCtx, runCtx, lc)
+			if inline != nil {
+				linterIssues = inline.issues(linterIssues)
+			} else {
+				linterIssues = r.withoutInlineIssues(linterIssues)
+			}
+			isDegraded := budget.IsExceeded()
+			if isDegraded {
+				degraded = append(degraded, linterNames(lc)...)
+			}
+			isTimedOut := err == nil && ctx.Err() == nil && linterCtx.Err() != nil
+
+			duration := time.Since(startedAt)
+
+			if r.RunReport != nil && inline == nil {
+				lr := report.LinterRunReport{
+					Name:        lc.Name(),
+					DurationMs:  duration.Milliseconds(),
+					AllocatedMB: resources.AllocatedMB() - allocatedMB,
+					HeapMB:      resources.HeapMB(),
+					TimedOut:    isTimedOut,
+					Degraded:    isDegraded,
+				}
+				if _, ok := lc.Linter.(*goanalysis.MetaLinter); ok {
+					lr.Linters = linterNames(lc)
+					lr.LinterDurationsMs = map[string]int64{}
+					for _, name := range lr.Linters {
+						lr.LinterDurationsMs[name] = shareDuration(duration, len(lr.Linters)).Milliseconds()
+					}
+				}
+				r.RunReport.AddLinter(lr, linterIssues)
+			}
+
+			if r.ReportData != nil && inline == nil {
+				names := linterNames(lc)
+				for _, name := range names {
+					r.ReportData.AddDuration(name, shareDuration(duration, len(names)))
+				}
+			}
+
 			if err != nil {
-				lintErrors = multierror.Append(lintErrors, fmt.Errorf("can't run linter %s: %w", lc.Linter.Name(), err))
+				lintErrors = multierror.Append(lintErrors, &LinterError{Linter: lc.Linter.Name(), Err: err})
 				r.Log.Warnf("Can't run linter %s: %v", lc.Linter.Name(), err)
 
 				return
 			}
+
+			linterIssues, err = r.typecheck.apply(linterIssues)
+			if err != nil {
+				lintErrors = multierror.Append(lintErrors, err)
+				stopped = true
+				return
+			}
+
+			if isTimedOut {
+				timedOut = append(timedOut, lc.Name())
+				logutils.InfoEvent(r.Log, "linter_timeout",
+					logutils.Fields{"linter": lc.Name(), "timeout_ms": logutils.DurationField(r.Timeouts[lc.Name()])},
+					"Linter %s was cancelled at its timeout %s", lc.Name(), r.Timeouts[lc.Name()])
+
+				if r.ReportData != nil {
+					r.ReportData.SetTimedOut(lc.Name())
+				}
+			}
+
+			if r.Analytics != nil {
+				r.Analytics.AddFound(linterIssues)
+			}
+
+			if streaming != nil {
+				processed := streaming.process(linterIssues, false)
+				streamIssues = append(streamIssues, processed...)
+				r.OnIssues(processed)
+				return
+			}
+
 			issues = append(issues, linterIssues...)
 		})
 	}
 
+	for _, lc := range linters {
+		if stopped {
+			break
+		}
+		if ml, ok := lc.Linter.(*goanalysis.MetaLinter); ok && budget.IsExceeded() {
+			// Run the combined linters one at a time: the results of fewer analyzers are kept in memory.
+			// Only the linter is swapped: the config of the metalinter is kept, e.g. its load mode.
+			for _, l := range ml.Linters() {
+				single := *lc
+				single.Linter = l
+				runLinter(&single, nil)
+			}
+			continue
+		}
+
+		runLinter(lc, nil)
+	}
+
+	for _, run := range r.inlineRuns {
+		for _, lc := range run.linters {
+			if !stopped {
+				runLinter(lc, run)
+			}
+		}
+	}
+
+	if len(timedOut) != 0 {
+		logutils.WarnEvent(r.Log, "linters_timed_out", logutils.Fields{"linters": timedOut},
+			"Linters timed out, their issues are incomplete: %s", strings.Join(timedOut, ", "))
+	}
+
+	if len(degraded) != 0 {
+		if r.ReportData != nil {
+			for _, name := range degraded {
+				r.ReportData.SetDegraded(name)
+			}
+		}
+
+		logutils.WarnEvent(r.Log, "linters_degraded", logutils.Fields{"linters": degraded},
+			"Linters run in degraded mode over the memory budget: %s", strings.Join(degraded, ", "))
+	}
+
+	if r.Analytics != nil {
+		r.Analytics.AddStages(sw.Stages())
+	}
+
+	if r.OnProcessing != nil {
+		r.OnProcessing()
+	}
+
+	if streaming != nil {
+		if streaming.issuesBefore != 0 {
+			processed := streaming.process([]result.Issue{}, true)
+			streamIssues = append(streamIssues, processed...)
+			r.OnIssues(processed)
+		}
+		streaming.finish()
+
+		// The batches are sorted one by one: the issues of the run are sorted for the outputs printed at the end.
+		if sorted, err := r.sortResults.Process(streamIssues); err == nil {
+			streamIssues = sorted
+		}
+
+		return streamIssues, lintErrors.ErrorOrNil()
+	}
+
 	return r.processLintResults(issues), lintErrors.ErrorOrNil()
 }
 
-func (r *Runner) processIssues(issues []result.Issue, sw *timeutils.Stopwatch, statPerProcessor map[string]processorStat) []result.Issue {
-	for _, p := range r.Processors {
+// recordPanic records the panic of a linter as a warning of the run: the run continues without its issues.
+func (r Runner) recordPanic(p linter.Panic) {
+	where := p.Linter
+	if p.Package != "" {
+		where += " on " + p.Package
+	}
+	logutils.WarnEvent(r.Log, "linter_panic", logutils.Fields{"linter": p.Linter, "package": p.Package},
+		"Panic of %s, its issues are incomplete: %s", where, p.Message)
+	r.Log.Infof("Panic stack trace: %s", p.Stack)
+
+	if r.ReportData != nil {
+		r.ReportData.AddRunWarning(report.RunWarning{
+			Linter:  p.Linter,
+			Package: p.Package,
+			Message: p.Message,
+			Stack:   string(p.Stack),
+		})
+	}
+}
+
+// monitorMemory warns when the memory budget is exceeded.
+func (r Runner) monitorMemory(ctx context.Context, budget *resources.MemoryBudget) {
+	const checkInterval = 100 * time.Millisecond
+
+	if budget.Monitor(ctx, checkInterval) {
+		logutils.WarnEvent(r.Log, "memory_budget_exceeded",
+			logutils.Fields{"used_mb": budget.UsedMB(), "limit_mb": budget.LimitMB()},
+			"Memory budget exceeded (%dMiB > %dMiB): the remaining packages and linters are analyzed one at a time",
+			budget.UsedMB(), budget.LimitMB())
+	}
+}
+
+// shareDuration returns the share of the duration of a run of count linters:
+// the linters combined in one analysis can't be timed one by one, they share its duration evenly.
+func shareDuration(duration time.Duration, count int) time.Duration {
+	return duration / time.Duration(count)
+}
+
+// linterNames returns the name of the linter, or the names of the linters combined by the go/analysis metalinter.
+func linterNames(lc *linter.Config) []string {
+	ml, ok := lc.Linter.(*goanalysis.MetaLinter)
+	if !ok {
+		return []string{lc.Name()}
+	}
+
+	var names []string
+	for _, l := range ml.Linters() {
+		names = append(names, l.Name())
+	}
+	return names
+}
+
+func (r *Runner) processIssues(procs []processors.Processor, issues []result.Issue, sw *timeutils.Stopwatch,
+	statPerProcessor map[string]processorStat, last bool) []result.Issue {
+	for _, p := range procs {
+		if _, ok := p.(processors.RunIssuesProcessor); ok && !last {
+			continue
+		}
+		if p == r.limiting && r.OnLimiting != nil {
+			issues = r.OnLimiting(issues)
+		}
+
 		var newIssues []result.Issue
 		var err error
 		p := p
@@ -231,6 +825,13 @@ func (r *Runner) processIssues(issues []result.Issue, sw *timeutils.Stopwatch, s
 			stat.inCount += len(issues)
 			stat.outCount += len(newIssues)
 			statPerProcessor[p.Name()] = stat
+
+			if r.Analytics != nil {
+				r.Analytics.AddProcessed(p.Name(), issues, newIssues)
+			}
+			if r.ReportData != nil && isExclusion(p) {
+				r.ReportData.AddExcluded(issues, newIssues)
+			}
 			issues = newIssues
 		}
 
@@ -242,6 +843,18 @@ func (r *Runner) processIssues(issues []result.Issue, sw *timeutils.Stopwatch, s
 	return issues
 }
 
+// isExclusion checks if the processor excludes issues on purpose: exclude, exclude-rules and nolint.
+// The issues removed by the other processors, like the limits or the diff, aren't excluded.
+func isExclusion(p processors.Processor) bool {
+	switch p.(type) {
+	case *processors.Exclude, *processors.ExcludeCaseSensitive,
+		*processors.ExcludeRules, *processors.ExcludeRulesCaseSensitive, *processors.Nolint:
+		return true
+	default:
+		return false
+	}
+}
+
 func getExcludeProcessor(cfg *config.Issues) processors.Processor {
 	var excludeTotalPattern string
 
@@ -283,16 +896,30 @@ func getExcludeRulesProcessor(cfg *config.Issues, log logutils.Log, lineCache *f
 		}
 	}
 
+	var includeRules []processors.IncludeRule
+	for _, r := range cfg.IncludeRules {
+		includeRules = append(includeRules, processors.IncludeRule{
+			BaseRule: processors.BaseRule{
+				Text:    r.Text,
+				Source:  r.Source,
+				Path:    r.Path,
+				Linters: r.Linters,
+			},
+		})
+	}
+
 	var excludeRulesProcessor processors.Processor
 	if cfg.ExcludeCaseSensitive {
 		excludeRulesProcessor = processors.NewExcludeRulesCaseSensitive(
 			excludeRules,
+			includeRules,
 			lineCache,
 			log.Child("exclude_rules"),
 		)
 	} else {
 		excludeRulesProcessor = processors.NewExcludeRules(
 			excludeRules,
+			includeRules,
 			lineCache,
 			log.Child("exclude_rules"),
 		)
@@ -301,7 +928,8 @@ func getExcludeRulesProcessor(cfg *config.Issues, log logutils.Log, lineCache *f
 	return excludeRulesProcessor
 }
 
-func getSeverityRulesProcessor(cfg *config.Severity, log logutils.Log, lineCache *fsutils.LineCache) processors.Processor {
+func getSeverityRulesProcessor(cfg *config.Severity, testsSeverity string, log logutils.Log,
+	lineCache *fsutils.LineCache) processors.Processor {
 	var severityRules []processors.SeverityRule
 	for _, r := range cfg.Rules {
 		severityRules = append(severityRules, processors.SeverityRule{
@@ -312,25 +940,61 @@ func getSeverityRulesProcessor(cfg *config.Severity, log logutils.Log, lineCache
 				Path:    r.Path,
 				Linters: r.Linters,
 			},
+			RuleIDs:  r.RuleIDs,
+			PathGlob: r.PathGlob,
+			Scope:    r.Scope,
 		})
 	}
 
-	var severityRulesProcessor processors.Processor
+	// The explicit rules have priority: the first matching rule is applied,
+	// or the last one when all the matching rules are applied.
+	if testsSeverity != "" {
+		testsRule := processors.SeverityRule{
+			Severity: testsSeverity,
+			BaseRule: processors.BaseRule{Path: config.TestsOverridePath},
+		}
+		if cfg.Match == config.SeverityMatchAll {
+			severityRules = append([]processors.SeverityRule{testsRule}, severityRules...)
+		} else {
+			severityRules = append(severityRules, testsRule)
+		}
+	}
+
 	if cfg.CaseSensitive {
-		severityRulesProcessor = processors.NewSeverityRulesCaseSensitive(
-			cfg.Default,
-			severityRules,
-			lineCache,
-			log.Child("severity_rules"),
-		)
-	} else {
-		severityRulesProcessor = processors.NewSeverityRules(
-			cfg.Default,
-			severityRules,
-			lineCache,
-			log.Child("severity_rules"),
-		)
+		p := processors.NewSeverityRulesCaseSensitive(cfg.Default, severityRules, lineCache, log.Child("severity_rules"))
+		p.WithDefaults(cfg.Match, cfg.LintersDefault)
+		return p
+	}
+
+	p := processors.NewSeverityRules(cfg.Default, severityRules, lineCache, log.Child("severity_rules"))
+	p.WithDefaults(cfg.Match, cfg.LintersDefault)
+	return p
+}
+
+func getOverridesProcessor(overrides []config.Override, dbManager *lintersdb.Manager,
+	enabledLinters map[string]*linter.Config) (processors.Processor, error) {
+	canonicalNames := func(names []string) []string {
+		var ret []string
+		for name := range dbManager.GetCanonicalNames(names) {
+			ret = append(ret, name)
+		}
+		return ret
+	}
+
+	var lintersOverrides []processors.LintersOverride
+	for _, o := range overrides {
+		lintersOverrides = append(lintersOverrides, processors.LintersOverride{
+			Path:       o.Path,
+			Enable:     canonicalNames(o.Linters.Enable),
+			Disable:    canonicalNames(o.Linters.Disable),
+			DisableAll: o.Linters.DisableAll,
+		})
+	}
+
+	var enabled []string
+	for name := range enabledLinters {
+		enabled = append(enabled, name)
 	}
 
-	return severityRulesProcessor
+	return processors.NewOverrides(lintersOverrides, enabled)
 }
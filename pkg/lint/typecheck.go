@@ -0,0 +1,99 @@
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const typecheckLinterName = "typecheck"
+
+// maxTypeErrors is the count of the type errors printed by the fail-fast mode.
+const maxTypeErrors = 10
+
+// TypecheckError is the failure of the run stopped by the type errors of the packages in the fail-fast mode.
+type TypecheckError struct {
+	Count  int
+	Errors []string
+}
+
+func (e *TypecheckError) Error() string {
+	return fmt.Sprintf("the packages have %d type error(s) (typecheck mode %s):\n\t%s",
+		e.Count, config.TypecheckModeFailFast, strings.Join(e.Errors, "\n\t"))
+}
+
+// typecheckPolicy applies the typecheck section of the config to the type errors reported by the linters.
+type typecheckPolicy struct {
+	failFast    bool
+	ignorePaths []*regexp.Regexp
+}
+
+func newTypecheckPolicy(cfg *config.Typecheck) (typecheckPolicy, error) {
+	p := typecheckPolicy{failFast: cfg.Mode == config.TypecheckModeFailFast}
+	for _, pattern := range cfg.IgnorePaths {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return p, fmt.Errorf("invalid typecheck ignore-paths regex %q: %w", pattern, err)
+		}
+		p.ignorePaths = append(p.ignorePaths, re)
+	}
+	return p, nil
+}
+
+// apply removes the type errors of the ignored paths: in the fail-fast mode, the other type errors fail the run.
+func (p typecheckPolicy) apply(issues []result.Issue) ([]result.Issue, error) {
+	if !p.failFast && len(p.ignorePaths) == 0 {
+		return issues, nil
+	}
+
+	ret := make([]result.Issue, 0, len(issues))
+	var typeErrors []string
+	seen := map[string]bool{}
+	for i := range issues {
+		if issues[i].FromLinter != typecheckLinterName {
+			ret = append(ret, issues[i])
+			continue
+		}
+
+		path := issues[i].FilePath()
+		if rel, err := fsutils.ShortestRelPath(path, ""); err == nil {
+			path = rel
+		}
+		if p.isIgnored(filepath.ToSlash(path)) {
+			continue
+		}
+
+		ret = append(ret, issues[i])
+
+		// The type errors of a package are reported by each linter analyzing it.
+		typeError := fmt.Sprintf("%s:%d:%d: %s", path, issues[i].Line(), issues[i].Column(), issues[i].Text)
+		if !seen[typeError] {
+			seen[typeError] = true
+			typeErrors = append(typeErrors, typeError)
+		}
+	}
+
+	if p.failFast && len(typeErrors) != 0 {
+		count := len(typeErrors)
+		if count > maxTypeErrors {
+			typeErrors = append(typeErrors[:maxTypeErrors], fmt.Sprintf("and %d more", count-maxTypeErrors))
+		}
+		return nil, &TypecheckError{Count: count, Errors: typeErrors}
+	}
+
+	return ret, nil
+}
+
+func (p typecheckPolicy) isIgnored(path string) bool {
+	for _, re := range p.ignorePaths {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
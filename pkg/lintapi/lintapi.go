@@ -0,0 +1,428 @@
+// Package lintapi runs golangci-lint as a library.
+//
+// The types of the package are stable: they don't expose the internal packages of golangci-lint,
+// which change between the releases.
+package lintapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/golangci/golangci-lint/internal/pkgcache"
+	"github.com/golangci/golangci-lint/pkg/commands/flagsets"
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/credentials"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis/load"
+	"github.com/golangci/golangci-lint/pkg/goutil"
+	"github.com/golangci/golangci-lint/pkg/lint"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/timeutils"
+)
+
+// Options are the options of a run.
+// The config file is read like by the run command, the options overwrite it like the command-line flags.
+type Options struct {
+	// Paths are the packages to analyze, relative to the current directory: ./... by default.
+	Paths []string
+
+	// Config is the path of the config file, searched from the first path if empty.
+	Config string
+	// NoConfig disables the config file.
+	NoConfig bool
+
+	// Enable and Disable are the linters enabled and disabled in addition to the ones of the config.
+	Enable  []string
+	Disable []string
+	// DisableAll disables all the linters, except the ones of Enable.
+	DisableAll bool
+
+	// BuildTags are the build tags of the packages.
+	BuildTags []string
+	// Timeout is the timeout of the run, the one of the config if zero.
+	Timeout time.Duration
+	// Fix applies the fixes of the issues to the files.
+	Fix bool
+
+	// Version is the version of the embedding tool, a part of the cache keys.
+	Version string
+
+	// Progress receives the progress of the run, if not nil.
+	Progress func(p Progress)
+	// Logger receives the logs of the run, if not nil.
+	Logger Logger
+}
+
+// Stage is a stage of a run.
+type Stage string
+
+const (
+	// StageLoading is the loading of the packages.
+	StageLoading Stage = "loading"
+	// StageLinting is the run of the linters: a progress is sent when each linter finishes.
+	StageLinting Stage = "linting"
+	// StageDone is the end of the run.
+	StageDone Stage = "done"
+)
+
+// Progress is the progress of a run.
+type Progress struct {
+	Stage Stage
+	// Packages is the count of the analyzed packages, once loaded.
+	Packages int
+	// Linters are the names of the linters which just finished, in the linting stage.
+	Linters []string
+	// Done and Total are the counts of the finished and enabled linters.
+	Done, Total int
+}
+
+// Level is the level of a log message.
+type Level int
+
+const (
+	LevelInfo Level = iota
+	LevelWarning
+	LevelError
+)
+
+// Logger receives the log messages of a run.
+type Logger interface {
+	Log(level Level, message string)
+}
+
+// Position is the position of an issue.
+type Position struct {
+	Filename string
+	Line     int
+	Column   int // 0 if unknown
+}
+
+// Issue is an issue found by a linter.
+type Issue struct {
+	Linter string
+	// Rule is the identifier of the check of the linter, e.g. SA4006 for staticcheck.
+	Rule             string
+	Text             string
+	Severity         string
+	DocumentationURL string
+	Position         Position
+	// SourceLines are the lines of code of the issue.
+	SourceLines []string
+	// Fixable tells if the issue has a fix, applied with Options.Fix.
+	Fixable bool
+}
+
+// Result is the result of a run.
+type Result struct {
+	Issues []Issue
+	// Linters are the names of the enabled linters.
+	Linters []string
+	// Warnings are the warnings logged during the run.
+	Warnings []string
+}
+
+// mu serializes the runs: golangci-lint keeps a state in the process (the salt of the cache keys).
+var mu sync.Mutex
+
+// Run runs the linters on the packages of the options.
+// The runs are serialized.
+func Run(ctx context.Context, opts Options) (res *Result, err error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	log := newLogger(opts.Logger, "")
+	defer func() {
+		// The code after a fatal error isn't expected to run: it can panic.
+		if r := recover(); r != nil {
+			if fatal := log.fatalError(); fatal != nil {
+				res, err = nil, fatal
+				return
+			}
+			panic(r)
+		}
+	}()
+
+	res, err = run(ctx, &opts, log)
+	if fatal := log.fatalError(); fatal != nil {
+		return nil, fatal
+	}
+
+	return res, err
+}
+
+func run(ctx context.Context, opts *Options, log *logger) (*Result, error) {
+	cfg, err := readConfig(opts, log)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Run.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Run.Timeout)
+		defer cancel()
+	}
+
+	analysis, err := newAnalysis(ctx, cfg, opts.Version, log)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		linters     []string
+		total, done int
+	)
+	analysis.OnLoad = func(enabledLinters map[string]*linter.Config) {
+		for name := range enabledLinters {
+			linters = append(linters, name)
+		}
+		sort.Strings(linters)
+
+		if opts.Progress != nil {
+			opts.Progress(Progress{Stage: StageLoading})
+		}
+	}
+	if opts.Progress != nil {
+		analysis.OnRunner = func(runner *lint.Runner, lintCtx *linter.Context) {
+			total = len(linters)
+			opts.Progress(Progress{Stage: StageLinting, Packages: len(lintCtx.OriginalPackages), Total: total})
+
+			runner.OnLinterDone = func(linters []string) {
+				done += len(linters)
+				opts.Progress(Progress{Stage: StageLinting, Linters: linters, Done: done, Total: total})
+			}
+		}
+	}
+
+	ar, err := analysis.Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Progress != nil {
+		opts.Progress(Progress{Stage: StageDone, Done: total, Total: total})
+	}
+
+	res := &Result{Linters: linters, Warnings: log.warnings()}
+	for i := range ar.Issues {
+		res.Issues = append(res.Issues, newIssue(&ar.Issues[i]))
+	}
+
+	return res, nil
+}
+
+// newAnalysis prepares the analysis of the config like the run command.
+// The file lock of the run command isn't acquired.
+func newAnalysis(ctx context.Context, cfg *config.Config, version string, log logutils.Log) (*lint.Analysis, error) {
+	cfg.LintersSettings.Gocritic.InferEnabledChecks(log)
+	if err := cfg.LintersSettings.Gocritic.Validate(log); err != nil {
+		return nil, fmt.Errorf("invalid gocritic settings: %w", err)
+	}
+
+	dbManager := lintersdb.NewManager(cfg, log).WithCustomLinters()
+	es := lintersdb.NewEnabledSet(dbManager, lintersdb.NewValidator(dbManager), log.Child("lintersdb"), cfg)
+
+	goenv := goutil.NewEnv(log.Child("goenv"))
+	if err := goenv.Discover(ctx); err != nil {
+		log.Warnf("Failed to discover go env: %s", err)
+	}
+
+	fileCache := fsutils.NewFileCache()
+	lineCache := fsutils.NewLineCache(fileCache)
+	resolver := credentials.NewResolver(cfg.Credentials)
+
+	backend, err := lint.NewCacheBackend(cfg, resolver, log)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgCache, err := pkgcache.NewCache(backend, timeutils.NewStopwatch("pkgcache", log.Child("stopwatch")), log.Child("pkgcache"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build packages cache: %w", err)
+	}
+
+	if err = lint.InitHashSalt(version, cfg); err != nil {
+		return nil, fmt.Errorf("failed to init hash salt: %w", err)
+	}
+
+	return &lint.Analysis{
+		Cfg:               cfg,
+		Log:               log,
+		GoEnv:             goenv,
+		DBManager:         dbManager,
+		EnabledLintersSet: es,
+		ContextLoader:     lint.NewContextLoader(cfg, log.Child("loader"), goenv, lineCache, fileCache, pkgCache, load.NewGuard()),
+		FileCache:         fileCache,
+		LineCache:         lineCache,
+		Credentials:       resolver,
+	}, nil
+}
+
+// readConfig reads the config like the run command: the default values are the ones of its flags,
+// the config file overwrites them, and the options overwrite the config file.
+func readConfig(opts *Options, log logutils.Log) (*config.Config, error) {
+	paths := opts.Paths
+	if len(paths) == 0 {
+		paths = []string{"./..."}
+	}
+
+	commandLineCfg := &config.Config{}
+	commandLineCfg.Run.Config = opts.Config
+	commandLineCfg.Run.NoConfig = opts.NoConfig
+	commandLineCfg.Run.Args = paths
+
+	cfg := config.NewDefault()
+
+	fs := pflag.NewFlagSet("lintapi flag set", pflag.ContinueOnError)
+	flagsets.AddRun(fs, cfg, lintersdb.NewManager(nil, nil), false)
+	flagsets.AddRoot(fs, cfg, true)
+
+	// A viper per run: the global one is the one of the command line of the embedding tool, if any.
+	r := config.NewFileReader(cfg, commandLineCfg, log.Child("config_reader")).WithViper(viper.New())
+	if err := r.Read(); err != nil {
+		return nil, fmt.Errorf("can't read config: %w", err)
+	}
+
+	cfg.Run.Args = paths
+	if cfg.Run.Go == "" {
+		cfg.Run.Go = config.DetectGoVersion()
+	}
+
+	if opts.DisableAll {
+		cfg.Linters.DisableAll = true
+		cfg.Linters.EnableAll = false
+		cfg.Linters.Enable = nil
+		cfg.Linters.Presets = nil
+	}
+	cfg.Linters.Enable = append(cfg.Linters.Enable, opts.Enable...)
+	cfg.Linters.Disable = append(cfg.Linters.Disable, opts.Disable...)
+
+	cfg.Run.BuildTags = append(cfg.Run.BuildTags, opts.BuildTags...)
+	if opts.Timeout != 0 {
+		cfg.Run.Timeout = opts.Timeout
+	}
+	if opts.Fix {
+		cfg.Issues.NeedFix = true
+	}
+
+	return cfg, nil
+}
+
+func newIssue(i *result.Issue) Issue {
+	return Issue{
+		Linter:           i.FromLinter,
+		Rule:             i.RuleID,
+		Text:             i.Text,
+		Severity:         i.Severity,
+		DocumentationURL: i.DocumentationURL,
+		Position: Position{
+			Filename: i.FilePath(),
+			Line:     i.Line(),
+			Column:   i.Column(),
+		},
+		SourceLines: i.SourceLines,
+		Fixable:     i.Replacement != nil,
+	}
+}
+
+// logger adapts a Logger to the logs of golangci-lint.
+type logger struct {
+	l     Logger
+	name  string
+	level logutils.LogLevel
+
+	mu     *sync.Mutex
+	warned *[]string
+	// fatal is the first fatal error of the run: the run returns it instead of exiting.
+	fatal *error
+}
+
+var _ logutils.Log = (*logger)(nil)
+
+func newLogger(l Logger, name string) *logger {
+	return &logger{l: l, name: name, level: logutils.LogLevelInfo, mu: &sync.Mutex{}, warned: new([]string), fatal: new(error)}
+}
+
+func (l *logger) log(level Level, format string, args ...interface{}) string {
+	message := fmt.Sprintf(format, args...)
+	if l.name != "" {
+		message = fmt.Sprintf("[%s] %s", l.name, message)
+	}
+	if l.l != nil {
+		l.l.Log(level, message)
+	}
+	return message
+}
+
+func (l *logger) Fatalf(format string, args ...interface{}) {
+	l.setFatal(l.log(LevelError, format, args...))
+}
+
+func (l *logger) Panicf(format string, args ...interface{}) {
+	l.setFatal(l.log(LevelError, format, args...))
+}
+
+func (l *logger) setFatal(message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if *l.fatal == nil {
+		*l.fatal = errors.New(message)
+	}
+}
+
+func (l *logger) fatalError() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return *l.fatal
+}
+
+func (l *logger) Errorf(format string, args ...interface{}) {
+	l.log(LevelError, format, args...)
+}
+
+func (l *logger) Warnf(format string, args ...interface{}) {
+	message := l.log(LevelWarning, format, args...)
+
+	l.mu.Lock()
+	*l.warned = append(*l.warned, message)
+	l.mu.Unlock()
+}
+
+func (l *logger) Infof(format string, args ...interface{}) {
+	if l.level > logutils.LogLevelInfo {
+		return
+	}
+	l.log(LevelInfo, format, args...)
+}
+
+func (l *logger) Child(name string) logutils.Log {
+	child := *l
+	if l.name != "" {
+		child.name = l.name + "/" + name
+	} else {
+		child.name = name
+	}
+	return &child
+}
+
+func (l *logger) SetLevel(level logutils.LogLevel) {
+	l.level = level
+}
+
+func (l *logger) warnings() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]string(nil), *l.warned...)
+}
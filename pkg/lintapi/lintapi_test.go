@@ -0,0 +1,63 @@
+package lintapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLogger struct {
+	messages []string
+}
+
+func (l *testLogger) Log(_ Level, message string) {
+	l.messages = append(l.messages, message)
+}
+
+func TestRun(t *testing.T) {
+	var progress []Progress
+	logger := &testLogger{}
+
+	res, err := Run(context.Background(), Options{
+		Paths:      []string{"./testdata/typecheck"},
+		NoConfig:   true,
+		DisableAll: true,
+		Enable:     []string{"govet"},
+		Progress: func(p Progress) {
+			progress = append(progress, p)
+		},
+		Logger: logger,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"govet"}, res.Linters)
+	assert.Equal(t, []Progress{
+		{Stage: StageLoading},
+		{Stage: StageLinting, Packages: 1, Total: 1},
+		{Stage: StageLinting, Linters: []string{"govet"}, Done: 1, Total: 1},
+		{Stage: StageDone, Done: 1, Total: 1},
+	}, progress)
+
+	require.Len(t, res.Issues, 1)
+	issue := res.Issues[0]
+	assert.Equal(t, "typecheck", issue.Linter)
+	assert.Contains(t, issue.Text, "undefinedValue")
+	assert.Equal(t, Position{Filename: "testdata/typecheck/typecheck.go", Line: 4, Column: 9}, issue.Position)
+	assert.NotEmpty(t, logger.messages)
+}
+
+func TestRun_invalidConfig(t *testing.T) {
+	_, err := Run(context.Background(), Options{Config: "testdata/missing.yml", NoConfig: true})
+	assert.EqualError(t, err, "can't read config: can't parse --config option: can't combine option --config and --no-config")
+}
+
+func TestLogger_Fatalf(t *testing.T) {
+	log := newLogger(nil, "")
+
+	log.Child("loader").Fatalf("can't load: %s", "boom")
+	log.Panicf("second error")
+
+	assert.EqualError(t, log.fatalError(), "[loader] can't load: boom")
+}
@@ -0,0 +1,5 @@
+package typecheck
+
+func Undefined() int {
+	return undefinedValue
+}
@@ -0,0 +1,137 @@
+package logutils
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus" //nolint:depguard
+)
+
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+var LogFormats = []string{LogFormatText, LogFormatJSON}
+
+var jsonFormat int32
+
+// SetupLogFormat switches the format of all loggers, including the already created ones.
+func SetupLogFormat(format string) error {
+	switch format {
+	case "", LogFormatText:
+		atomic.StoreInt32(&jsonFormat, 0)
+	case LogFormatJSON:
+		atomic.StoreInt32(&jsonFormat, 1)
+	default:
+		return fmt.Errorf("invalid log format %q: must be one of %s", format, strings.Join(LogFormats, ", "))
+	}
+
+	return nil
+}
+
+func isJSONFormat() bool {
+	return atomic.LoadInt32(&jsonFormat) == 1
+}
+
+// Fields are structured data of a log event.
+type Fields map[string]interface{}
+
+// eventLog is implemented by loggers supporting structured events.
+type eventLog interface {
+	InfoEvent(event string, fields Fields, format string, args ...interface{})
+}
+
+// InfoEvent logs an info message with a stable event name and structured fields.
+// With the JSON log format the event and the fields are separate keys of the entry,
+// with the text format only the message is printed.
+func InfoEvent(log Log, event string, fields Fields, format string, args ...interface{}) {
+	if el, ok := log.(eventLog); ok {
+		el.InfoEvent(event, fields, format, args...)
+		return
+	}
+
+	log.Infof(format, args...)
+}
+
+type warnEventLog interface {
+	WarnEvent(event string, fields Fields, format string, args ...interface{})
+}
+
+// WarnEvent logs a warning with a stable event name and structured fields, like InfoEvent.
+func WarnEvent(log Log, event string, fields Fields, format string, args ...interface{}) {
+	if el, ok := log.(warnEventLog); ok {
+		el.WarnEvent(event, fields, format, args...)
+		return
+	}
+
+	log.Warnf(format, args...)
+}
+
+// EventRecorder receives the structured events of the logs, whatever the log level.
+type EventRecorder interface {
+	RecordEvent(event string, fields Fields)
+}
+
+var (
+	eventRecorderMu sync.RWMutex
+	eventRecorder   EventRecorder
+)
+
+// SetEventRecorder sets the recorder of the structured events, nil to remove it.
+func SetEventRecorder(r EventRecorder) {
+	eventRecorderMu.Lock()
+	eventRecorder = r
+	eventRecorderMu.Unlock()
+}
+
+// RecordEvent passes an event to the recorder of the events without logging it, e.g. the timings of the debug logs.
+func RecordEvent(event string, fields Fields) {
+	eventRecorderMu.RLock()
+	r := eventRecorder
+	eventRecorderMu.RUnlock()
+
+	if r != nil {
+		r.RecordEvent(event, fields)
+	}
+}
+
+// DurationField converts a duration to milliseconds for machine consumption.
+func DurationField(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// switchFormatter delegates to the text or JSON formatter depending on the current log format,
+// and redacts the secrets.
+type switchFormatter struct {
+	text logrus.Formatter
+	json logrus.Formatter
+}
+
+func (f switchFormatter) Format(e *logrus.Entry) ([]byte, error) {
+	formatter := f.text
+	if isJSONFormat() {
+		formatter = f.json
+	}
+
+	b, err := formatter.Format(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return redact(b), nil
+}
+
+func newJSONFormatter() logrus.Formatter {
+	return &logrus.JSONFormatter{
+		TimestampFormat: time.RFC3339Nano,
+		FieldMap: logrus.FieldMap{
+			logrus.FieldKeyTime:  "time",
+			logrus.FieldKeyLevel: "level",
+			logrus.FieldKeyMsg:   "message",
+		},
+	}
+}
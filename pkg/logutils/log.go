@@ -1,5 +1,10 @@
 package logutils
 
+import (
+	"fmt"
+	"strings"
+)
+
 type Log interface {
 	Fatalf(format string, args ...interface{})
 	Panicf(format string, args ...interface{})
@@ -29,3 +34,22 @@ const (
 	// error logging happens in 1-2 places: in the "main" function.
 	LogLevelError LogLevel = 3
 )
+
+// LogLevels are the names of the log levels, from the most verbose one.
+var LogLevels = []string{"debug", "info", "warn", "error"}
+
+// ParseLogLevel parses the name of a log level (case-insensitive): debug, info, warn (or warning) or error.
+func ParseLogLevel(name string) (LogLevel, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return LogLevelDebug, nil
+	case "info":
+		return LogLevelInfo, nil
+	case "warn", "warning":
+		return LogLevelWarn, nil
+	case "error":
+		return LogLevelError, nil
+	default:
+		return LogLevelWarn, fmt.Errorf("invalid log level %q: must be one of %s", name, strings.Join(LogLevels, ", "))
+	}
+}
@@ -3,8 +3,12 @@ package logutils
 import (
 	"os"
 	"strings"
+	"sync/atomic"
 )
 
+// AllDebugTags enables the debug logs of all the tags.
+const AllDebugTags = "*"
+
 func getEnabledDebugs() map[string]bool {
 	ret := map[string]bool{}
 	debugVar := os.Getenv("GL_DEBUG")
@@ -19,27 +23,51 @@ func getEnabledDebugs() map[string]bool {
 	return ret
 }
 
-var enabledDebugs = getEnabledDebugs()
-
-type DebugFunc func(format string, args ...interface{})
+// enabledDebugs is the set of the enabled debug tags: the tags of GL_DEBUG, and the tags enabled by EnableDebugTags.
+var enabledDebugs atomic.Value
 
-func nopDebugf(format string, args ...interface{}) {}
+func init() {
+	enabledDebugs.Store(getEnabledDebugs())
+}
 
-func Debug(tag string) DebugFunc {
-	if !enabledDebugs[tag] {
-		return nopDebugf
+// EnableDebugTags enables the debug logs of the tags, in addition to the tags of GL_DEBUG:
+// AllDebugTags enables all the debug logs, but not the debug tags changing the behavior (HaveDebugTag).
+func EnableDebugTags(tags ...string) {
+	enabled := map[string]bool{}
+	for tag := range enabledDebugs.Load().(map[string]bool) {
+		enabled[tag] = true
+	}
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			enabled[tag] = true
+		}
 	}
 
+	enabledDebugs.Store(enabled)
+}
+
+func isDebugEnabled(tag string) bool {
+	enabled := enabledDebugs.Load().(map[string]bool)
+	return enabled[tag] || enabled[AllDebugTags]
+}
+
+type DebugFunc func(format string, args ...interface{})
+
+// Debug returns the function logging the debug messages of the tag, if the tag is enabled when they are logged:
+// the tags can be enabled after the creation of the functions, e.g. by the command-line options.
+func Debug(tag string) DebugFunc {
 	logger := NewStderrLog(tag)
 	logger.SetLevel(LogLevelDebug)
 
 	return func(format string, args ...interface{}) {
-		logger.Debugf(format, args...)
+		if isDebugEnabled(tag) {
+			logger.Debugf(format, args...)
+		}
 	}
 }
 
 func HaveDebugTag(tag string) bool {
-	return enabledDebugs[tag]
+	return enabledDebugs.Load().(map[string]bool)[tag]
 }
 
 func SetupVerboseLog(log Log, isVerbose bool) {
@@ -47,3 +75,26 @@ func SetupVerboseLog(log Log, isVerbose bool) {
 		log.SetLevel(LogLevelInfo)
 	}
 }
+
+// SetupLogLevel sets the level of the log: the named level, else info if verbose.
+// The debug logs of the debug tags are enabled whatever the level, the debug level without tags enables all of them.
+func SetupLogLevel(log Log, level string, isVerbose bool, debugTags []string) error {
+	if level == "" {
+		SetupVerboseLog(log, isVerbose)
+		EnableDebugTags(debugTags...)
+		return nil
+	}
+
+	l, err := ParseLogLevel(level)
+	if err != nil {
+		return err
+	}
+	log.SetLevel(l)
+
+	if l == LogLevelDebug && len(debugTags) == 0 {
+		debugTags = []string{AllDebugTags}
+	}
+	EnableDebugTags(debugTags...)
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package logutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	for name, expected := range map[string]LogLevel{
+		"debug":   LogLevelDebug,
+		"Info":    LogLevelInfo,
+		"warn":    LogLevelWarn,
+		"warning": LogLevelWarn,
+		"ERROR":   LogLevelError,
+	} {
+		level, err := ParseLogLevel(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, expected, level, name)
+	}
+
+	_, err := ParseLogLevel("verbose")
+	assert.EqualError(t, err, `invalid log level "verbose": must be one of debug, info, warn, error`)
+}
+
+func TestEnableDebugTags(t *testing.T) {
+	saved := enabledDebugs.Load()
+	defer enabledDebugs.Store(saved)
+
+	enabledDebugs.Store(map[string]bool{"env": true})
+
+	EnableDebugTags("loader", " nolint ")
+	assert.True(t, isDebugEnabled("env"), "GL_DEBUG")
+	assert.True(t, isDebugEnabled("loader"))
+	assert.True(t, isDebugEnabled("nolint"))
+	assert.False(t, isDebugEnabled("revive"))
+
+	EnableDebugTags(AllDebugTags)
+	assert.True(t, isDebugEnabled("revive"))
+	assert.False(t, HaveDebugTag("linters_output"), "not a debug log")
+}
+
+func TestSetupLogLevel(t *testing.T) {
+	saved := enabledDebugs.Load()
+	defer enabledDebugs.Store(saved)
+
+	testCases := []struct {
+		desc      string
+		level     string
+		verbose   bool
+		tags      []string
+		expected  LogLevel
+		allDebugs bool
+	}{
+		{desc: "default", expected: LogLevelWarn},
+		{desc: "verbose", verbose: true, expected: LogLevelInfo},
+		{desc: "level", level: "error", verbose: true, expected: LogLevelError},
+		{desc: "debug", level: "debug", expected: LogLevelDebug, allDebugs: true},
+		{desc: "debug tags", level: "debug", tags: []string{"loader"}, expected: LogLevelDebug},
+	}
+
+	for _, test := range testCases {
+		enabledDebugs.Store(map[string]bool{})
+
+		log := NewMockLog()
+		log.On("SetLevel", test.expected).Maybe()
+
+		require.NoError(t, SetupLogLevel(log, test.level, test.verbose, test.tags), test.desc)
+		assert.Equal(t, test.allDebugs, isDebugEnabled("revive"), test.desc)
+
+		for _, tag := range test.tags {
+			assert.True(t, isDebugEnabled(tag), test.desc)
+		}
+	}
+
+	assert.Error(t, SetupLogLevel(NewMockLog(), "loud", false, nil))
+}
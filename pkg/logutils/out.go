@@ -1,9 +1,34 @@
 package logutils
 
 import (
+	"io"
+	"sync/atomic"
+
 	"github.com/fatih/color"
 	colorable "github.com/mattn/go-colorable"
 )
 
 var StdOut = color.Output // https://github.com/golangci/golangci-lint/issues/14
 var StdErr = colorable.NewColorableStderr()
+
+// logOutput is the writer of the logs, StdErr if unset.
+var logOutput atomic.Value
+
+type outputHolder struct {
+	w io.Writer
+}
+
+// SetLogOutput redirects the logs to w, e.g. to print them above a progress display: nil restores StdErr.
+func SetLogOutput(w io.Writer) {
+	logOutput.Store(outputHolder{w: w})
+}
+
+// logWriter writes to the output of the logs.
+type logWriter struct{}
+
+func (logWriter) Write(p []byte) (int, error) {
+	if h, ok := logOutput.Load().(outputHolder); ok && h.w != nil {
+		return h.w.Write(p)
+	}
+	return StdErr.Write(p)
+}
@@ -0,0 +1,52 @@
+package logutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// minSecretLen avoids redacting short common strings.
+const minSecretLen = 4
+
+const redacted = "[REDACTED]"
+
+var secrets = struct {
+	sync.RWMutex
+	values [][]byte
+}{}
+
+// AddSecret registers a secret redacted from the output of all loggers.
+func AddSecret(secret string) {
+	if len(secret) < minSecretLen {
+		return
+	}
+
+	secrets.Lock()
+	defer secrets.Unlock()
+
+	secrets.values = append(secrets.values, []byte(secret))
+
+	// The JSON format escapes some characters.
+	if b, err := json.Marshal(secret); err == nil {
+		if escaped := b[1 : len(b)-1]; !bytes.Equal(escaped, []byte(secret)) {
+			secrets.values = append(secrets.values, escaped)
+		}
+	}
+}
+
+// Redact replaces the registered secrets by a placeholder.
+func Redact(s string) string {
+	return string(redact([]byte(s)))
+}
+
+func redact(b []byte) []byte {
+	secrets.RLock()
+	defer secrets.RUnlock()
+
+	for _, secret := range secrets.values {
+		b = bytes.ReplaceAll(b, secret, []byte(redacted))
+	}
+
+	return b
+}
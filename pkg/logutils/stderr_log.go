@@ -36,7 +36,7 @@ func NewStderrLog(name string) *StderrLog {
 		sl.logger.SetLevel(logrus.DebugLevel)
 	}
 
-	sl.logger.Out = StdErr
+	sl.logger.Out = logWriter{}
 	formatter := &logrus.TextFormatter{
 		DisableTimestamp:          true, // `INFO[0007] msg` -> `INFO msg`
 		EnvironmentOverrideColors: true,
@@ -46,13 +46,17 @@ func NewStderrLog(name string) *StderrLog {
 		formatter.FullTimestamp = true
 		formatter.TimestampFormat = time.StampMilli
 	}
-	sl.logger.Formatter = formatter
+	sl.logger.Formatter = switchFormatter{text: formatter, json: newJSONFormatter()}
 
 	return sl
 }
 
 func (sl StderrLog) prefix() string {
 	prefix := ""
+	if isJSONFormat() {
+		return prefix // the name is the component field
+	}
+
 	if sl.name != "" {
 		prefix = fmt.Sprintf("[%s] ", sl.name)
 	}
@@ -60,8 +64,22 @@ func (sl StderrLog) prefix() string {
 	return prefix
 }
 
+// entry returns the log entry with the component and the fields of the JSON format.
+func (sl StderrLog) entry(fields Fields) *logrus.Entry {
+	e := logrus.NewEntry(sl.logger)
+	if !isJSONFormat() {
+		return e
+	}
+
+	if sl.name != "" {
+		e = e.WithField("component", sl.name)
+	}
+
+	return e.WithFields(logrus.Fields(fields))
+}
+
 func (sl StderrLog) Fatalf(format string, args ...interface{}) {
-	sl.logger.Errorf("%s%s", sl.prefix(), fmt.Sprintf(format, args...))
+	sl.entry(nil).Errorf("%s%s", sl.prefix(), fmt.Sprintf(format, args...))
 	os.Exit(exitcodes.Failure)
 }
 
@@ -75,7 +93,7 @@ func (sl StderrLog) Errorf(format string, args ...interface{}) {
 		return
 	}
 
-	sl.logger.Errorf("%s%s", sl.prefix(), fmt.Sprintf(format, args...))
+	sl.entry(nil).Errorf("%s%s", sl.prefix(), fmt.Sprintf(format, args...))
 	// don't call exitIfTest() because the idea is to
 	// crash on hidden errors (warnings); but Errorf MUST NOT be
 	// called on hidden errors, see log levels comments.
@@ -86,7 +104,22 @@ func (sl StderrLog) Warnf(format string, args ...interface{}) {
 		return
 	}
 
-	sl.logger.Warnf("%s%s", sl.prefix(), fmt.Sprintf(format, args...))
+	sl.entry(nil).Warnf("%s%s", sl.prefix(), fmt.Sprintf(format, args...))
+}
+
+func (sl StderrLog) WarnEvent(event string, fields Fields, format string, args ...interface{}) {
+	RecordEvent(event, fields)
+
+	if sl.level > LogLevelWarn {
+		return
+	}
+
+	e := sl.entry(fields)
+	if isJSONFormat() {
+		e = e.WithField("event", event)
+	}
+
+	e.Warnf("%s%s", sl.prefix(), fmt.Sprintf(format, args...))
 }
 
 func (sl StderrLog) Infof(format string, args ...interface{}) {
@@ -94,7 +127,22 @@ func (sl StderrLog) Infof(format string, args ...interface{}) {
 		return
 	}
 
-	sl.logger.Infof("%s%s", sl.prefix(), fmt.Sprintf(format, args...))
+	sl.entry(nil).Infof("%s%s", sl.prefix(), fmt.Sprintf(format, args...))
+}
+
+func (sl StderrLog) InfoEvent(event string, fields Fields, format string, args ...interface{}) {
+	RecordEvent(event, fields)
+
+	if sl.level > LogLevelInfo {
+		return
+	}
+
+	e := sl.entry(fields)
+	if isJSONFormat() {
+		e = e.WithField("event", event)
+	}
+
+	e.Infof("%s%s", sl.prefix(), fmt.Sprintf(format, args...))
 }
 
 func (sl StderrLog) Debugf(format string, args ...interface{}) {
@@ -102,7 +150,7 @@ func (sl StderrLog) Debugf(format string, args ...interface{}) {
 		return
 	}
 
-	sl.logger.Debugf("%s%s", sl.prefix(), fmt.Sprintf(format, args...))
+	sl.entry(nil).Debugf("%s%s", sl.prefix(), fmt.Sprintf(format, args...))
 }
 
 func (sl StderrLog) Child(name string) Log {
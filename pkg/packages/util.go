@@ -6,12 +6,18 @@ import (
 	"strings"
 
 	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 )
 
 // reFile matches a line who starts with path and position.
 // ex: `/example/main.go:11:17: foobar`
 var reFile = regexp.MustCompile(`^.+\.go:\d+:\d+: .+`)
 
+// reBuildError matches a line of the output of the compiler in an error of the go command.
+// ex: `./main.go:11:17: foobar`
+var reBuildError = regexp.MustCompile(`^(.+\.go:\d+:\d+): (.+)$`)
+
 func ExtractErrors(pkg *packages.Package) []packages.Error {
 	errors := extractErrorsImpl(pkg, map[*packages.Package]bool{})
 	if len(errors) == 0 {
@@ -35,6 +41,8 @@ func ExtractErrors(pkg *packages.Package) []packages.Error {
 		uniqErrors = append(uniqErrors, err)
 	}
 
+	uniqErrors = splitBuildErrors(uniqErrors)
+
 	if len(pkg.GoFiles) != 0 {
 		// errors were extracted from deps and have at least one file in package
 		for i := range uniqErrors {
@@ -59,6 +67,37 @@ func ExtractErrors(pkg *packages.Package) []packages.Error {
 	return uniqErrors
 }
 
+// splitBuildErrors splits the errors of the compilation by the go command without position,
+// e.g. "# pkg\n./main.go:11:17: foobar", into an error per line of the compiler with its position.
+func splitBuildErrors(errs []packages.Error) []packages.Error {
+	var res []packages.Error
+	for _, err := range errs {
+		if _, parseErr := ParseErrorPosition(err.Pos); parseErr == nil {
+			res = append(res, err)
+			continue
+		}
+
+		var split []packages.Error
+		for _, line := range strings.Split(err.Msg, "\n") {
+			m := reBuildError.FindStringSubmatch(strings.TrimSpace(line))
+			if m == nil {
+				continue
+			}
+
+			pos := strings.TrimPrefix(fsutils.OverlaidPath(m[1]), "./")
+			split = append(split, packages.Error{Pos: pos, Msg: m[2], Kind: err.Kind})
+		}
+
+		if len(split) == 0 {
+			res = append(res, err)
+			continue
+		}
+		res = append(res, split...)
+	}
+
+	return res
+}
+
 func extractErrorsImpl(pkg *packages.Package, seenPackages map[*packages.Package]bool) []packages.Error {
 	if seenPackages[pkg] {
 		return nil
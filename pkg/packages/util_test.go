@@ -1,9 +1,16 @@
 package packages
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 )
 
 //nolint:lll
@@ -51,3 +58,24 @@ func Test_stackCrusher(t *testing.T) {
 		})
 	}
 }
+
+func Test_splitBuildErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "b.go")
+	require.NoError(t, fsutils.SetOverlay(path, []byte("package b")))
+
+	copyPath := filepath.Join(os.TempDir(), "gopackages-1", "42-"+strings.ReplaceAll(filepath.ToSlash(path), "/", ""))
+
+	errs := splitBuildErrors([]packages.Error{
+		{Pos: "a.go:1:2", Msg: "positioned"},
+		{Msg: "# x\n./a.go:3:23: undefined: foo\n" + copyPath + ":4:5: undefined: bar", Kind: packages.ListError},
+		{Msg: "no position"},
+	})
+
+	expected := []packages.Error{
+		{Pos: "a.go:1:2", Msg: "positioned"},
+		{Pos: "a.go:3:23", Msg: "undefined: foo", Kind: packages.ListError},
+		{Pos: path + ":4:5", Msg: "undefined: bar", Kind: packages.ListError},
+		{Msg: "no position"},
+	}
+	assert.Equal(t, expected, errs)
+}
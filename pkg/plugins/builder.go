@@ -0,0 +1,213 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+const golangciModule = "github.com/golangci/golangci-lint"
+
+const mainTemplate = `// Code generated by golangci-lint custom. DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/golangci/golangci-lint/pkg/commands"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+{{range .Imports}}
+	_ "{{.}}"{{end}}
+)
+
+func main() {
+	e := commands.NewExecutor({{printf "%q" .Version}}, {{printf "%q" .Commit}}, {{printf "%q" .Date}})
+
+	if err := e.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed executing command with error %v\n", err)
+		os.Exit(exitcodes.Failure)
+	}
+}
+`
+
+// BuildOptions describes a custom golangci-lint binary embedding private linters.
+type BuildOptions struct {
+	// Output is the path of the resulting binary.
+	Output string
+	// Version of golangci-lint to build against.
+	Version string
+	// Commit and Date are the commit and the build date of the version, reported by the built binary.
+	Commit, Date string
+	// LocalDir is a local golangci-lint checkout used instead of Version.
+	LocalDir string
+}
+
+// Builder compiles a golangci-lint binary with module-based private linters.
+type Builder struct {
+	log      logutils.Log
+	opts     BuildOptions
+	settings map[string]config.CustomLinterSettings
+}
+
+func NewBuilder(log logutils.Log, opts BuildOptions, settings map[string]config.CustomLinterSettings) *Builder {
+	return &Builder{
+		log:      log,
+		opts:     opts,
+		settings: settings,
+	}
+}
+
+// Build generates a temporary module importing the private linters and builds it.
+func (b Builder) Build(ctx context.Context) error {
+	modules := b.modules()
+	if len(modules) == 0 {
+		return fmt.Errorf("no module-based custom linters are defined in linters-settings.custom")
+	}
+
+	output, err := filepath.Abs(b.opts.Output)
+	if err != nil {
+		return fmt.Errorf("can't get absolute output path: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "golangci-lint-custom")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	mainSrc, err := generateMain(b.mainData())
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, "main.go"), mainSrc, 0o600); err != nil {
+		return err
+	}
+
+	cmds := [][]string{{"mod", "init", "golangci-lint-custom"}}
+	if b.opts.LocalDir != "" {
+		localDir, errAbs := filepath.Abs(b.opts.LocalDir)
+		if errAbs != nil {
+			return errAbs
+		}
+		cmds = append(cmds, []string{"mod", "edit", "-replace", golangciModule + "=" + localDir})
+	} else {
+		cmds = append(cmds, []string{"get", golangciModule + "@" + ModuleVersion(b.opts.Version)})
+	}
+	for _, m := range modules {
+		cmds = append(cmds, []string{"get", m})
+	}
+	cmds = append(cmds,
+		[]string{"mod", "tidy"},
+		[]string{"build", "-o", output, "."},
+	)
+
+	for _, args := range cmds {
+		if err := b.goCmd(ctx, dir, args...); err != nil {
+			return err
+		}
+	}
+
+	b.log.Infof("Built custom golangci-lint binary %s with %d module(s)", output, len(modules))
+	return nil
+}
+
+func (b Builder) goCmd(ctx context.Context, dir string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("go %s failed: %w: %s", strings.Join(args, " "), err, out)
+	}
+	return nil
+}
+
+// modules returns the sorted list of `module@version` to require.
+func (b Builder) modules() []string {
+	var ret []string
+	for _, s := range b.settings {
+		if s.Module != "" {
+			ret = append(ret, s.Module)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// imports returns the sorted list of packages registering the private linters.
+func (b Builder) imports() []string {
+	var ret []string
+	for _, s := range b.settings {
+		if s.Module == "" {
+			continue
+		}
+
+		imp := s.Import
+		if imp == "" {
+			imp = strings.SplitN(s.Module, "@", 2)[0]
+		}
+		ret = append(ret, imp)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// IsRelease checks if the version of golangci-lint is a release, e.g. 1.50.0:
+// not a development build, e.g. (devel) or a pseudo-version of a modified checkout.
+func IsRelease(version string) bool {
+	v := ModuleVersion(version)
+	return semver.IsValid(v) && semver.Build(v) == "" && !module.IsPseudoVersion(v)
+}
+
+// ModuleVersion returns the version of the golangci-lint module: the releases are tagged with a `v` prefix.
+func ModuleVersion(version string) string {
+	if v := "v" + version; semver.IsValid(v) {
+		return v
+	}
+	return version
+}
+
+type mainData struct {
+	Version, Commit, Date string
+	Imports               []string
+}
+
+func (b Builder) mainData() mainData {
+	data := mainData{Version: b.opts.Version, Commit: b.opts.Commit, Date: b.opts.Date, Imports: b.imports()}
+	if data.Version == "" {
+		data.Version = "(devel)"
+	}
+	if data.Commit == "" {
+		data.Commit = "?"
+	}
+	return data
+}
+
+func generateMain(data mainData) ([]byte, error) {
+	tmpl, err := template.New("main").Parse(mainTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,111 @@
+package plugins
+
+import (
+	"fmt"
+	"path/filepath"
+	"plugin"
+	"sort"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+)
+
+// AnalyzerPlugin is the interface implemented by private linters.
+// It's exposed by a `*.so` plugin as the `AnalyzerPlugin` variable,
+// or registered with Register by a module compiled into a custom binary.
+type AnalyzerPlugin interface {
+	GetAnalyzers() []*analysis.Analyzer
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]AnalyzerPlugin{}
+)
+
+// Register makes a private linter available under the given name.
+// It's intended to be called from the `init` function of a module
+// embedded by the `golangci-lint custom` command.
+func Register(name string, p AnalyzerPlugin) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if p == nil {
+		panic(fmt.Sprintf("plugins: Register plugin %s is nil", name))
+	}
+	if _, dup := registry[name]; dup {
+		panic(fmt.Sprintf("plugins: Register called twice for plugin %s", name))
+	}
+
+	registry[name] = p
+}
+
+// Registered returns the sorted names of the plugins compiled into the binary.
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func getRegistered(name string) (AnalyzerPlugin, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	p, ok := registry[name]
+	return p, ok
+}
+
+// Load returns the private linter described by the settings:
+// a plugin compiled into the binary has priority over a `*.so` file.
+// Relative paths to `*.so` files are resolved from the config directory.
+func Load(name string, settings config.CustomLinterSettings, configDir string) (AnalyzerPlugin, error) {
+	if p, ok := getRegistered(name); ok {
+		return p, nil
+	}
+
+	if settings.Path == "" {
+		if settings.Module != "" {
+			return nil, fmt.Errorf("module %s is not compiled into this binary, run 'golangci-lint custom' to build one",
+				settings.Module)
+		}
+		return nil, fmt.Errorf("path or module should be set")
+	}
+
+	path := settings.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(configDir, path)
+	}
+
+	return open(path)
+}
+
+// open loads the plugin from a .so file, and returns the 'AnalyzerPlugin' interface
+// implemented by the private plugin.
+// An error is returned if the private linter cannot be loaded or the linter
+// does not implement the AnalyzerPlugin interface.
+func open(path string) (AnalyzerPlugin, error) {
+	plug, err := plugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	symbol, err := plug.Lookup("AnalyzerPlugin")
+	if err != nil {
+		return nil, err
+	}
+
+	analyzerPlugin, ok := symbol.(AnalyzerPlugin)
+	if !ok {
+		return nil, fmt.Errorf("plugin %s does not abide by 'AnalyzerPlugin' interface", path)
+	}
+
+	return analyzerPlugin, nil
+}
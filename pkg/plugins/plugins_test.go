@@ -0,0 +1,74 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+type testPlugin struct{}
+
+func (testPlugin) GetAnalyzers() []*analysis.Analyzer {
+	return []*analysis.Analyzer{{Name: "test"}}
+}
+
+func TestLoadRegistered(t *testing.T) {
+	Register("registered", testPlugin{})
+
+	assert.Contains(t, Registered(), "registered")
+	assert.Panics(t, func() { Register("registered", testPlugin{}) })
+
+	p, err := Load("registered", config.CustomLinterSettings{Module: "example.com/registered@v1.0.0"}, "")
+	require.NoError(t, err)
+	assert.Len(t, p.GetAnalyzers(), 1)
+}
+
+func TestLoadNotCompiledModule(t *testing.T) {
+	_, err := Load("missing", config.CustomLinterSettings{Module: "example.com/missing@v1.0.0"}, "")
+	assert.EqualError(t, err,
+		"module example.com/missing@v1.0.0 is not compiled into this binary, run 'golangci-lint custom' to build one")
+
+	_, err = Load("missing", config.CustomLinterSettings{}, "")
+	assert.EqualError(t, err, "path or module should be set")
+}
+
+func TestBuilderImports(t *testing.T) {
+	b := NewBuilder(logutils.NewStderrLog(""), BuildOptions{}, map[string]config.CustomLinterSettings{
+		"b":  {Module: "example.com/b@v1.2.0", Import: "example.com/b/plugin"},
+		"a":  {Module: "example.com/a@v0.1.0"},
+		"so": {Path: "/example.so"},
+	})
+
+	assert.Equal(t, []string{"example.com/a@v0.1.0", "example.com/b@v1.2.0"}, b.modules())
+	assert.Equal(t, []string{"example.com/a", "example.com/b/plugin"}, b.imports())
+
+	src, err := generateMain(b.mainData())
+	require.NoError(t, err)
+	assert.Contains(t, string(src), "\t_ \"example.com/a\"\n\t_ \"example.com/b/plugin\"\n)")
+	assert.Contains(t, string(src), `commands.NewExecutor("(devel)", "?", "")`)
+
+	b.opts = BuildOptions{Version: "1.50.0", Commit: "abc", Date: "2022-10-01"}
+	src, err = generateMain(b.mainData())
+	require.NoError(t, err)
+	assert.Contains(t, string(src), `commands.NewExecutor("1.50.0", "abc", "2022-10-01")`)
+}
+
+func TestIsRelease(t *testing.T) {
+	assert.True(t, IsRelease("1.50.0"))
+	assert.True(t, IsRelease("v1.50.0-rc.1"))
+	assert.False(t, IsRelease(""))
+	assert.False(t, IsRelease("master"))
+	assert.False(t, IsRelease("(devel)"))
+	assert.False(t, IsRelease("v0.0.0-20221014133137-eea887d2116c"))
+	assert.False(t, IsRelease("v1.50.1-0.20221014133137-eea887d2116c"))
+	assert.False(t, IsRelease("v1.50.0+dirty"))
+
+	assert.Equal(t, "v1.50.0", ModuleVersion("1.50.0"))
+	assert.Equal(t, "v1.50.0", ModuleVersion("v1.50.0"))
+	assert.Equal(t, "master", ModuleVersion("master"))
+}
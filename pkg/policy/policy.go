@@ -0,0 +1,192 @@
+// Package policy enforces the policy block of the config: the required linters, the forbidden exclude patterns
+// and the reasons of the //nolint directives.
+package policy
+
+import (
+	"fmt"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
+)
+
+// Violation is a violation of the policy.
+type Violation struct {
+	// Pos is the position of the //nolint directive, it's zero for the violations of the config.
+	Pos     token.Position
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Pos.Filename == "" {
+		return v.Message
+	}
+	return fmt.Sprintf("%s:%d: %s", v.Pos.Filename, v.Pos.Line, v.Message)
+}
+
+// Policy checks the config and the //nolint directives.
+type Policy struct {
+	cfg       *config.Config
+	dbManager *lintersdb.Manager
+
+	required       []string        // canonical names
+	reasonRequired map[string]bool // canonical names
+	forbidden      []*regexp.Regexp
+}
+
+// New creates the policy of the config: the names of the linters are resolved to their canonical names.
+func New(cfg *config.Config, dbManager *lintersdb.Manager) (*Policy, error) {
+	p := &Policy{cfg: cfg, dbManager: dbManager, reasonRequired: map[string]bool{}}
+
+	var unknown []string
+	resolve := func(names []string, add func(name string)) {
+		for _, name := range names {
+			lcs := dbManager.GetLinterConfigs(name)
+			if lcs == nil {
+				unknown = append(unknown, name)
+			}
+			for _, lc := range lcs {
+				add(lc.Name())
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	resolve(cfg.Policy.RequiredLinters, func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			p.required = append(p.required, name)
+		}
+	})
+	resolve(cfg.Policy.NolintRequireReason, func(name string) {
+		p.reasonRequired[name] = true
+	})
+
+	if len(unknown) != 0 {
+		return nil, fmt.Errorf("unknown linters in the policy: '%s', run 'golangci-lint help linters' to see the list of supported linters",
+			strings.Join(unknown, ","))
+	}
+
+	for _, pattern := range cfg.Policy.ForbiddenExcludes {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid forbidden-excludes regex %q: %w", pattern, err)
+		}
+		p.forbidden = append(p.forbidden, re)
+	}
+
+	return p, nil
+}
+
+// CheckConfig checks the config: the enabled linters are the ones of the run, by canonical name.
+func (p *Policy) CheckConfig(enabled map[string]*linter.Config) []Violation {
+	var violations []Violation
+	add := func(format string, args ...interface{}) {
+		violations = append(violations, Violation{Message: fmt.Sprintf(format, args...)})
+	}
+
+	warnOnly := p.dbManager.GetCanonicalNames(p.cfg.Linters.WarnOnly)
+
+	for _, name := range p.required {
+		if enabled[name] == nil {
+			add("the linter %s is required by the policy: it can't be disabled", name)
+			continue
+		}
+
+		if warnOnly[name] {
+			add("the linter %s is required by the policy: it can't be in linters.warn-only", name)
+		}
+
+		for _, o := range p.cfg.AllOverrides() {
+			if p.isDisabledBy(name, o) {
+				add("the linter %s is required by the policy: it can't be disabled for the files matching %q", name, o.Path)
+			}
+		}
+	}
+
+	for _, e := range p.excludes() {
+		for _, re := range p.forbidden {
+			if re.MatchString(e.pattern) {
+				add("the exclude pattern %q of %s is forbidden by the policy (%s)", e.pattern, e.option, re)
+				break
+			}
+		}
+	}
+
+	return violations
+}
+
+// CheckNolint checks that the //nolint directive has a reason if it suppresses the issues of a linter requiring one.
+func (p *Policy) CheckNolint(pos token.Position, d *processors.NolintDirective) *Violation {
+	if d.Reason != "" || len(p.reasonRequired) == 0 {
+		return nil
+	}
+
+	var linters []string
+	if len(d.Linters) == 0 {
+		for name := range p.reasonRequired {
+			linters = append(linters, name)
+		}
+	} else {
+		for name := range p.dbManager.GetCanonicalNames(d.Linters) {
+			if p.reasonRequired[name] {
+				linters = append(linters, name)
+			}
+		}
+	}
+	if len(linters) == 0 {
+		return nil
+	}
+	sort.Strings(linters)
+
+	return &Violation{
+		Pos:     pos,
+		Message: fmt.Sprintf("the //nolint directive requires a reason for %s (//nolint:<linters> // <reason>)", strings.Join(linters, ", ")),
+	}
+}
+
+func (p *Policy) isDisabledBy(name string, o config.Override) bool {
+	if p.dbManager.GetCanonicalNames(o.Linters.Disable)[name] {
+		return true
+	}
+	return o.Linters.DisableAll && !p.dbManager.GetCanonicalNames(o.Linters.Enable)[name]
+}
+
+type exclude struct {
+	option, pattern string
+}
+
+// excludes returns the exclude patterns of the config, with their options.
+func (p *Policy) excludes() []exclude {
+	var ret []exclude
+	addAll := func(option string, patterns []string) {
+		for _, pattern := range patterns {
+			if pattern != "" {
+				ret = append(ret, exclude{option: option, pattern: pattern})
+			}
+		}
+	}
+
+	ic := &p.cfg.Issues
+	if ic.UseDefaultExcludes {
+		for _, e := range config.GetExcludePatterns(ic.IncludeDefaultExcludes) {
+			addAll(fmt.Sprintf("issues.exclude-use-default (%s)", e.ID), []string{e.Pattern})
+		}
+	}
+	addAll("issues.exclude", ic.ExcludePatterns)
+	for i, rule := range ic.ExcludeRules {
+		addAll(fmt.Sprintf("issues.exclude-rules[%d].path", i), []string{rule.Path})
+		addAll(fmt.Sprintf("issues.exclude-rules[%d].text", i), []string{rule.Text})
+		addAll(fmt.Sprintf("issues.exclude-rules[%d].source", i), []string{rule.Source})
+	}
+	addAll("issues.exclude-generated-paths", ic.ExcludeGeneratedPaths)
+	addAll("run.skip-files", p.cfg.Run.SkipFiles)
+	addAll("run.skip-dirs", p.cfg.Run.SkipDirs)
+
+	return ret
+}
@@ -0,0 +1,128 @@
+package policy
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
+)
+
+func newTestPolicy(t *testing.T, cfg *config.Config) (*Policy, *lintersdb.Manager) {
+	t.Helper()
+
+	dbManager := lintersdb.NewManager(cfg, nil)
+	p, err := New(cfg, dbManager)
+	require.NoError(t, err)
+	return p, dbManager
+}
+
+func enabledLinters(dbManager *lintersdb.Manager, names ...string) map[string]*linter.Config {
+	enabled := map[string]*linter.Config{}
+	for _, name := range names {
+		for _, lc := range dbManager.GetLinterConfigs(name) {
+			enabled[lc.Name()] = lc
+		}
+	}
+	return enabled
+}
+
+func messages(violations []Violation) []string {
+	var ret []string
+	for _, v := range violations {
+		ret = append(ret, v.String())
+	}
+	return ret
+}
+
+func TestPolicy_CheckConfig(t *testing.T) {
+	cfg := config.NewDefault()
+	cfg.Policy = config.Policy{
+		RequiredLinters:   []string{"vet", "errcheck", "gosec"},
+		ForbiddenExcludes: []string{`^Error return value`, `_test`},
+	}
+	cfg.Linters.WarnOnly = []string{"gosec"}
+	cfg.Issues.ExcludePatterns = []string{"Error return value of .(os\\.Close)", "should have comment"}
+	cfg.Issues.ExcludeRules = []config.ExcludeRule{
+		{BaseRule: config.BaseRule{Path: `_test\.go`, Linters: []string{"gocyclo"}}},
+	}
+	cfg.Overrides = []config.Override{
+		{Path: "^internal/", Linters: config.OverrideLinters{Disable: []string{"govet"}}},
+		{Path: "^tools/", Linters: config.OverrideLinters{DisableAll: true, Enable: []string{"govet"}}},
+	}
+
+	p, dbManager := newTestPolicy(t, cfg)
+
+	violations := p.CheckConfig(enabledLinters(dbManager, "govet", "gosec"))
+
+	assert.Equal(t, []string{
+		`the linter govet is required by the policy: it can't be disabled for the files matching "^internal/"`,
+		"the linter errcheck is required by the policy: it can't be disabled",
+		"the linter gosec is required by the policy: it can't be in linters.warn-only",
+		`the linter gosec is required by the policy: it can't be disabled for the files matching "^tools/"`,
+		`the exclude pattern "Error return value of .(os\\.Close)" of issues.exclude is forbidden by the policy (^Error return value)`,
+		`the exclude pattern "_test\\.go" of issues.exclude-rules[0].path is forbidden by the policy (_test)`,
+	}, messages(violations))
+}
+
+func TestPolicy_CheckConfig_defaultExcludes(t *testing.T) {
+	cfg := config.NewDefault()
+	cfg.Policy.ForbiddenExcludes = []string{`^Error return value`}
+	cfg.Issues.UseDefaultExcludes = true
+
+	p, dbManager := newTestPolicy(t, cfg)
+
+	violations := p.CheckConfig(enabledLinters(dbManager, "errcheck"))
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "issues.exclude-use-default (EXC0001)")
+
+	// The default exclude is disabled by issues.include.
+	cfg.Issues.IncludeDefaultExcludes = []string{"EXC0001"}
+	assert.Empty(t, p.CheckConfig(enabledLinters(dbManager, "errcheck")))
+}
+
+func TestPolicy_CheckNolint(t *testing.T) {
+	cfg := config.NewDefault()
+	cfg.Policy.NolintRequireReason = []string{"errcheck", "vet"}
+
+	p, _ := newTestPolicy(t, cfg)
+	pos := token.Position{Filename: "a.go", Line: 3}
+
+	const hint = " (//nolint:<linters> // <reason>)"
+
+	testCases := []struct {
+		comment  string
+		expected string
+	}{
+		{comment: "//nolint:errcheck", expected: "a.go:3: the //nolint directive requires a reason for errcheck" + hint},
+		{comment: "//nolint:gofmt"},
+		{comment: "//nolint:errcheck // the error is logged"},
+		{comment: "//nolint:vet,gofmt", expected: "a.go:3: the //nolint directive requires a reason for govet" + hint},
+		{comment: "//nolint", expected: "a.go:3: the //nolint directive requires a reason for errcheck, govet" + hint},
+	}
+
+	for _, test := range testCases {
+		v := p.CheckNolint(pos, processors.ParseNolintDirective(test.comment))
+		if test.expected == "" {
+			assert.Nil(t, v, test.comment)
+			continue
+		}
+
+		require.NotNil(t, v, test.comment)
+		assert.Equal(t, test.expected, v.String(), test.comment)
+	}
+}
+
+func TestNew_unknownLinter(t *testing.T) {
+	cfg := config.NewDefault()
+	cfg.Policy.RequiredLinters = []string{"govet", "foo"}
+
+	_, err := New(cfg, lintersdb.NewManager(cfg, nil))
+	require.EqualError(t, err,
+		"unknown linters in the policy: 'foo', run 'golangci-lint help linters' to see the list of supported linters")
+}
@@ -0,0 +1,55 @@
+// Package postprocess pipes the issues of a run through an external command, e.g. to filter them
+// or to add ticket links, before they're printed.
+package postprocess
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/printers"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// Run runs the command with the shell: it reads the issues from its standard input, in the json output format,
+// and prints the issues replacing them to its standard output in the same format.
+// The report data is passed to the command, its changes are ignored.
+func Run(ctx context.Context, command string, issues []result.Issue, rd *report.Data, stderr io.Writer) ([]result.Issue, error) {
+	if issues == nil {
+		issues = []result.Issue{}
+	}
+
+	in, err := json.Marshal(printers.JSONResult{Issues: issues, Report: rd})
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := shellCommand(ctx, command)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stderr = stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("command %q failed: %w", command, err)
+	}
+
+	var res printers.JSONResult
+	if err := json.Unmarshal(out, &res); err != nil {
+		return nil, fmt.Errorf("invalid output of command %q: %w", command, err)
+	}
+
+	return res.Issues, nil
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", strings.TrimSpace(command))
+}
@@ -0,0 +1,72 @@
+package postprocess
+
+import (
+	"context"
+	"go/token"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the commands of the test need a POSIX shell")
+	}
+
+	issues := []result.Issue{
+		{FromLinter: "govet", Text: "printf: bad verb", Pos: token.Position{Filename: "a.go", Line: 3, Column: 2}},
+	}
+
+	testCases := []struct {
+		desc     string
+		command  string
+		expected []result.Issue
+		err      string
+	}{
+		{
+			desc:     "unchanged issues",
+			command:  "cat",
+			expected: issues,
+		},
+		{
+			desc:     "replaced issues",
+			command:  `cat >/dev/null; echo '{"Issues": [{"FromLinter": "custom", "Text": "ticket #12", "Pos": {"Filename": "b.go", "Line": 1}}]}'`,
+			expected: []result.Issue{{FromLinter: "custom", Text: "ticket #12", Pos: token.Position{Filename: "b.go", Line: 1}}},
+		},
+		{
+			desc:     "no issues",
+			command:  `cat >/dev/null; echo '{"Issues": null}'`,
+			expected: nil,
+		},
+		{
+			desc:    "failed command",
+			command: "cat >/dev/null; exit 3",
+			err:     `command "cat >/dev/null; exit 3" failed: exit status 3`,
+		},
+		{
+			desc:    "invalid output",
+			command: "cat >/dev/null; echo done",
+			err:     `invalid output of command "cat >/dev/null; echo done": invalid character 'd' looking for beginning of value`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			ret, err := Run(context.Background(), test.command, issues, &report.Data{}, io.Discard)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ret)
+		})
+	}
+}
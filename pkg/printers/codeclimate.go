@@ -2,19 +2,46 @@ package printers
 
 import (
 	"context"
+	"crypto/md5" //nolint:gosec
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
+// codeClimateSeverities are the severities of Code Climate, by level of severity.
+var codeClimateSeverities = []string{"info", "info", "minor", "major", "critical", "blocker"}
+
+// codeClimateCategories are the categories of Code Climate of the linters, by preset.
+var codeClimateCategories = map[string]string{
+	linter.PresetBugs:        "Bug Risk",
+	linter.PresetError:       "Bug Risk",
+	linter.PresetSQL:         "Bug Risk",
+	linter.PresetComment:     "Clarity",
+	linter.PresetUnused:      "Clarity",
+	linter.PresetModule:      "Compatibility",
+	linter.PresetComplexity:  "Complexity",
+	linter.PresetPerformance: "Performance",
+	linter.PresetFormatting:  "Style",
+	linter.PresetImport:      "Style",
+	linter.PresetStyle:       "Style",
+	linter.PresetTest:        "Style",
+}
+
 // CodeClimateIssue is a subset of the Code Climate spec - https://github.com/codeclimate/spec/blob/master/SPEC.md#data-types
 // It is just enough to support GitLab CI Code Quality - https://docs.gitlab.com/ee/user/project/merge_requests/code_quality.html
 type CodeClimateIssue struct {
-	Description string `json:"description"`
-	Severity    string `json:"severity,omitempty"`
-	Fingerprint string `json:"fingerprint"`
+	Type        string   `json:"type"`
+	CheckName   string   `json:"check_name"`
+	Description string   `json:"description"`
+	Categories  []string `json:"categories,omitempty"`
+	Severity    string   `json:"severity,omitempty"`
+	Fingerprint string   `json:"fingerprint"`
 	Location    struct {
 		Path  string `json:"path"`
 		Lines struct {
@@ -24,25 +51,38 @@ type CodeClimateIssue struct {
 }
 
 type CodeClimate struct {
-	w io.Writer
+	linterPresets map[string][]string
+	w             io.Writer
 }
 
-func NewCodeClimate(w io.Writer) *CodeClimate {
-	return &CodeClimate{w: w}
+// NewCodeClimate creates a printer of Code Climate issues, the categories of the issues come from the presets of their linters.
+func NewCodeClimate(linterPresets map[string][]string, w io.Writer) *CodeClimate {
+	return &CodeClimate{linterPresets: linterPresets, w: w}
 }
 
 func (p CodeClimate) Print(ctx context.Context, issues []result.Issue) error {
 	codeClimateIssues := make([]CodeClimateIssue, 0, len(issues))
+	fingerprints := map[string]int{}
+
 	for i := range issues {
 		issue := &issues[i]
-		codeClimateIssue := CodeClimateIssue{}
+		codeClimateIssue := CodeClimateIssue{Type: "issue"}
+		codeClimateIssue.CheckName = codeClimateCheckName(issue)
 		codeClimateIssue.Description = issue.Description()
+		codeClimateIssue.Categories = p.categories(issue.FromLinter)
 		codeClimateIssue.Location.Path = issue.Pos.Filename
 		codeClimateIssue.Location.Lines.Begin = issue.Pos.Line
-		codeClimateIssue.Fingerprint = issue.Fingerprint()
+
+		// The same issue in the same code has the same fingerprint: its occurrences in the file are numbered.
+		fingerprint := codeClimateFingerprint(issue)
+		fingerprints[fingerprint]++
+		if n := fingerprints[fingerprint]; n > 1 {
+			fingerprint = codeClimateHash(fingerprint, fmt.Sprint(n))
+		}
+		codeClimateIssue.Fingerprint = fingerprint
 
 		if issue.Severity != "" {
-			codeClimateIssue.Severity = issue.Severity
+			codeClimateIssue.Severity = codeClimateSeverity(issue.Severity)
 		}
 
 		codeClimateIssues = append(codeClimateIssues, codeClimateIssue)
@@ -59,3 +99,63 @@ func (p CodeClimate) Print(ctx context.Context, issues []result.Issue) error {
 	}
 	return nil
 }
+
+func (p CodeClimate) categories(linterName string) []string {
+	seen := map[string]bool{}
+	var categories []string
+
+	for _, preset := range p.linterPresets[linterName] {
+		category, ok := codeClimateCategories[preset]
+		if !ok || seen[category] {
+			continue
+		}
+		seen[category] = true
+		categories = append(categories, category)
+	}
+
+	sort.Strings(categories)
+
+	return categories
+}
+
+func codeClimateCheckName(issue *result.Issue) string {
+	if issue.RuleID != "" {
+		return issue.RuleID
+	}
+	return issue.FromLinter
+}
+
+// codeClimateSeverity maps the severity of the issue (e.g. set by the severity rules) to a severity of Code Climate,
+// the unknown severities are kept.
+func codeClimateSeverity(severity string) string {
+	level, ok := config.SeverityLevel(severity)
+	if !ok || level >= len(codeClimateSeverities) {
+		return severity
+	}
+	return codeClimateSeverities[level]
+}
+
+// codeClimateFingerprint hashes the path, the rule and the source code of the issue, without its line:
+// the fingerprint of an issue doesn't change when the code is moved.
+// The whitespaces of the code are normalized, the text of the issue is used without source code.
+func codeClimateFingerprint(issue *result.Issue) string {
+	lines := make([]string, 0, len(issue.SourceLines))
+	for _, line := range issue.SourceLines {
+		lines = append(lines, strings.Join(strings.Fields(line), " "))
+	}
+
+	snippet := strings.TrimSpace(strings.Join(lines, "\n"))
+	if snippet == "" {
+		snippet = issue.Text
+	}
+
+	return codeClimateHash(issue.FilePath(), issue.FromLinter, codeClimateCheckName(issue), snippet)
+}
+
+func codeClimateHash(parts ...string) string {
+	hash := md5.New() //nolint:gosec
+	for _, part := range parts {
+		_, _ = fmt.Fprintf(hash, "%d:%s", len(part), part)
+	}
+	return fmt.Sprintf("%X", hash.Sum(nil))
+}
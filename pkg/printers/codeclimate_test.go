@@ -4,12 +4,14 @@ package printers
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"go/token"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
@@ -45,13 +47,57 @@ func TestCodeClimate_Print(t *testing.T) {
 	}
 
 	buf := new(bytes.Buffer)
-	printer := NewCodeClimate(buf)
+	presets := map[string][]string{"linter-a": {linter.PresetBugs, linter.PresetError, linter.PresetStyle}}
+	printer := NewCodeClimate(presets, buf)
 
 	err := printer.Print(context.Background(), issues)
 	require.NoError(t, err)
 
 	//nolint:lll
-	expected := `[{"description":"linter-a: some issue","severity":"warning","fingerprint":"BA73C5DF4A6FD8462FFF1D3140235777","location":{"path":"path/to/filea.go","lines":{"begin":10}}},{"description":"linter-b: another issue","severity":"error","fingerprint":"0777B4FE60242BD8B2E9B7E92C4B9521","location":{"path":"path/to/fileb.go","lines":{"begin":300}}}]`
+	expected := `[{"type":"issue","check_name":"linter-a","description":"linter-a: some issue","categories":["Bug Risk","Style"],"severity":"minor","fingerprint":"371BCCCD0D43B65DBC71A00A54A9B1EC","location":{"path":"path/to/filea.go","lines":{"begin":10}}},{"type":"issue","check_name":"linter-b","description":"linter-b: another issue","severity":"major","fingerprint":"045E035C411D8F1FCE1DD8A8F7B7C031","location":{"path":"path/to/fileb.go","lines":{"begin":300}}}]`
 
 	assert.Equal(t, expected, buf.String())
 }
+
+func TestCodeClimate_fingerprint(t *testing.T) {
+	issue := result.Issue{
+		FromLinter:  "errcheck",
+		Text:        "Error return value is not checked",
+		SourceLines: []string{"	f.Close()"},
+		Pos:         token.Position{Filename: "a.go", Line: 10},
+	}
+
+	moved := issue
+	moved.SourceLines = []string{"        f.Close()  "}
+	moved.Pos.Line = 42
+
+	other := issue
+	other.RuleID = "other-rule"
+
+	assert.Equal(t, codeClimateFingerprint(&issue), codeClimateFingerprint(&moved), "moved code")
+	assert.NotEqual(t, codeClimateFingerprint(&issue), codeClimateFingerprint(&other), "other rule")
+
+	buf := new(bytes.Buffer)
+	err := NewCodeClimate(nil, buf).Print(context.Background(), []result.Issue{issue, moved})
+	require.NoError(t, err)
+
+	var printed []CodeClimateIssue
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &printed))
+	require.Len(t, printed, 2)
+	assert.NotEqual(t, printed[0].Fingerprint, printed[1].Fingerprint, "occurrences in the same file")
+}
+
+func TestCodeClimate_severity(t *testing.T) {
+	testCases := map[string]string{
+		"info":     "info",
+		"warning":  "minor",
+		"error":    "major",
+		"Critical": "critical",
+		"blocker":  "blocker",
+		"custom":   "custom",
+	}
+
+	for severity, expected := range testCases {
+		assert.Equal(t, expected, codeClimateSeverity(severity), severity)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
+	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
@@ -14,34 +16,80 @@ type github struct {
 
 const defaultGithubSeverity = "error"
 
+// githubSeverities are the annotation levels of GitHub, by level of severity.
+var githubSeverities = []string{"notice", "notice", "warning", "error", "error", "error"}
+
 // NewGithub output format outputs issues according to GitHub actions format:
-// https://help.github.com/en/actions/reference/workflow-commands-for-github-actions#setting-an-error-message
+// https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions
+// The issues are grouped by linter in collapsible groups of the log.
 func NewGithub(w io.Writer) Printer {
 	return &github{w: w}
 }
 
-// print each line as: ::error file=app.js,line=10,col=15::Something went wrong
+// print each line as: ::error file=app.js,line=10,endLine=12,col=15,title=linter::Something went wrong
 func formatIssueAsGithub(issue *result.Issue) string {
 	severity := defaultGithubSeverity
-	if issue.Severity != "" {
-		severity = issue.Severity
+	if level, ok := config.SeverityLevel(issue.Severity); ok && level < len(githubSeverities) {
+		severity = githubSeverities[level]
+	}
+
+	ret := fmt.Sprintf("::%s file=%s,line=%d", severity, escapeGithubProperty(issue.FilePath()), issue.Line())
+
+	if lineRange := issue.GetLineRange(); lineRange.To > issue.Line() {
+		ret += fmt.Sprintf(",endLine=%d", lineRange.To)
 	}
 
-	ret := fmt.Sprintf("::%s file=%s,line=%d", severity, issue.FilePath(), issue.Line())
 	if issue.Pos.Column != 0 {
 		ret += fmt.Sprintf(",col=%d", issue.Pos.Column)
+
+		if r := issue.Replacement; r != nil && r.Inline != nil && r.Inline.Length > 0 && r.Inline.StartCol+1 == issue.Pos.Column {
+			ret += fmt.Sprintf(",endColumn=%d", issue.Pos.Column+r.Inline.Length)
+		}
 	}
 
-	ret += fmt.Sprintf("::%s (%s)", issue.Text, issue.FromLinter)
+	ret += fmt.Sprintf(",title=%s::%s", escapeGithubProperty(issue.FromLinter), escapeGithubData(issue.Text))
 	return ret
 }
 
 func (p *github) Print(_ context.Context, issues []result.Issue) error {
+	var linters []string
+	issuesPerLinter := map[string][]*result.Issue{}
 	for ind := range issues {
-		_, err := fmt.Fprintln(p.w, formatIssueAsGithub(&issues[ind]))
+		issue := &issues[ind]
+		if _, ok := issuesPerLinter[issue.FromLinter]; !ok {
+			linters = append(linters, issue.FromLinter)
+		}
+		issuesPerLinter[issue.FromLinter] = append(issuesPerLinter[issue.FromLinter], issue)
+	}
+
+	for _, linter := range linters {
+		linterIssues := issuesPerLinter[linter]
+
+		_, err := fmt.Fprintf(p.w, "::group::%s (%d)\n", escapeGithubData(linter), len(linterIssues))
+		if err != nil {
+			return err
+		}
+
+		for _, issue := range linterIssues {
+			_, err = fmt.Fprintln(p.w, formatIssueAsGithub(issue))
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = fmt.Fprintln(p.w, "::endgroup::")
 		if err != nil {
 			return err
 		}
 	}
+
 	return nil
 }
+
+func escapeGithubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeGithubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -41,6 +41,15 @@ func TestGithub_Print(t *testing.T) {
 				Column:   9,
 			},
 		},
+		{
+			FromLinter: "linter-a",
+			Severity:   "info",
+			Text:       "third issue",
+			Pos: token.Position{
+				Filename: "path/to/filec.go",
+				Line:     5,
+			},
+		},
 	}
 
 	buf := new(bytes.Buffer)
@@ -49,8 +58,13 @@ func TestGithub_Print(t *testing.T) {
 	err := printer.Print(context.Background(), issues)
 	require.NoError(t, err)
 
-	expected := `::warning file=path/to/filea.go,line=10,col=4::some issue (linter-a)
-::error file=path/to/fileb.go,line=300,col=9::another issue (linter-b)
+	expected := `::group::linter-a (2)
+::warning file=path/to/filea.go,line=10,col=4,title=linter-a::some issue
+::notice file=path/to/filec.go,line=5,title=linter-a::third issue
+::endgroup::
+::group::linter-b (1)
+::error file=path/to/fileb.go,line=300,col=9,title=linter-b::another issue
+::endgroup::
 `
 
 	assert.Equal(t, expected, buf.String())
@@ -67,8 +81,27 @@ func TestFormatGithubIssue(t *testing.T) {
 			Column:   4,
 		},
 	}
-	require.Equal(t, "::error file=path/to/file.go,line=10,col=4::some issue (sample-linter)", formatIssueAsGithub(&sampleIssue))
+	require.Equal(t, "::error file=path/to/file.go,line=10,col=4,title=sample-linter::some issue", formatIssueAsGithub(&sampleIssue))
 
 	sampleIssue.Pos.Column = 0
-	require.Equal(t, "::error file=path/to/file.go,line=10::some issue (sample-linter)", formatIssueAsGithub(&sampleIssue))
+	require.Equal(t, "::error file=path/to/file.go,line=10,title=sample-linter::some issue", formatIssueAsGithub(&sampleIssue))
+}
+
+func TestFormatGithubIssue_range(t *testing.T) {
+	sampleIssue := result.Issue{
+		FromLinter: "sample-linter",
+		Severity:   "foo",
+		Text:       "100% wrong\nsecond line",
+		LineRange:  &result.Range{From: 10, To: 12},
+		Pos: token.Position{
+			Filename: "path/to/file,a.go",
+			Line:     10,
+			Column:   4,
+		},
+		Replacement: &result.Replacement{Inline: &result.InlineFix{StartCol: 3, Length: 5}},
+	}
+
+	require.Equal(t,
+		"::error file=path/to/file%2Ca.go,line=10,endLine=12,col=4,endColumn=9,title=sample-linter::100%25 wrong%0Asecond line",
+		formatIssueAsGithub(&sampleIssue))
 }
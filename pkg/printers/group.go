@@ -0,0 +1,66 @@
+package printers
+
+import (
+	"sort"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const noSeverityGroup = "(no severity)"
+
+type issuesGroup struct {
+	name   string
+	issues []result.Issue
+}
+
+// groupIssues groups the issues by file, linter or severity, keeping their order in each group:
+// the groups of files and linters are sorted by name, the groups of severities from the most severe.
+func groupIssues(issues []result.Issue, groupBy string) []*issuesGroup {
+	byName := map[string]*issuesGroup{}
+	var groups []*issuesGroup
+
+	for i := range issues {
+		name := groupName(&issues[i], groupBy)
+
+		g := byName[name]
+		if g == nil {
+			g = &issuesGroup{name: name}
+			byName[name] = g
+			groups = append(groups, g)
+		}
+		g.issues = append(g.issues, issues[i])
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groupBy != config.GroupBySeverity {
+			return groups[i].name < groups[j].name
+		}
+
+		li, iok := config.SeverityLevel(groups[i].name)
+		lj, jok := config.SeverityLevel(groups[j].name)
+		if iok != jok {
+			return iok // the unknown severities last
+		}
+		if li != lj {
+			return li > lj
+		}
+		return groups[i].name < groups[j].name
+	})
+
+	return groups
+}
+
+func groupName(issue *result.Issue, groupBy string) string {
+	switch groupBy {
+	case config.GroupByFile:
+		return issue.FilePath()
+	case config.GroupByLinter:
+		return issue.FromLinter
+	default:
+		if issue.Severity == "" {
+			return noSeverityGroup
+		}
+		return issue.Severity
+	}
+}
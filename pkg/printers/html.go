@@ -3,155 +3,308 @@ package printers
 import (
 	"context"
 	"fmt"
+	"go/scanner"
+	"go/token"
 	"html/template"
 	"io"
+	"sort"
 	"strings"
 
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
+// htmlContextLines is the count of lines printed before and after the line of an issue.
+const htmlContextLines = 3
+
+// The report is standalone (no external styles or scripts): it can be attached to the artifacts of a CI job.
 const templateContent = `<!doctype html>
 <html lang="en">
 <head>
     <meta charset="utf-8">
     <title>golangci-lint</title>
-    <link rel="shortcut icon" type="image/png" href="https://golangci-lint.run/favicon-32x32.png">
-    <link rel="stylesheet" href="https://cdnjs.cloudflare.com/ajax/libs/bulma/0.9.2/css/bulma.min.css"
-          integrity="sha512-byErQdWdTqREz6DLAA9pCnLbdoGGhXfU6gm1c8bkf7F51JVmUBlayGe2A31VpXWQP+eiJ3ilTAZHCR3vmMyybA=="
-          crossorigin="anonymous" referrerpolicy="no-referrer"/>
-    <link rel="stylesheet" href="https://cdnjs.cloudflare.com/ajax/libs/highlight.js/10.7.2/styles/default.min.css"
-          integrity="sha512-kZqGbhf9JTB4bVJ0G8HCkqmaPcRgo88F0dneK30yku5Y/dep7CZfCnNml2Je/sY4lBoqoksXz4PtVXS4GHSUzQ=="
-          crossorigin="anonymous" referrerpolicy="no-referrer"/>
-    <script type="text/javascript" src="https://cdnjs.cloudflare.com/ajax/libs/highlight.js/10.7.2/highlight.min.js"
-            integrity="sha512-s+tOYYcC3Jybgr9mVsdAxsRYlGNq4mlAurOrfNuGMQ/SCofNPu92tjE7YRZCsdEtWL1yGkqk15fU/ark206YTg=="
-            crossorigin="anonymous" referrerpolicy="no-referrer"></script>
-    <script type="text/javascript" src="https://cdnjs.cloudflare.com/ajax/libs/highlight.js/10.7.2/languages/go.min.js"
-            integrity="sha512-+UYV2NyyynWEQcZ4sMTKmeppyV331gqvMOGZ61/dqc89Tn1H40lF05ACd03RSD9EWwGutNwKj256mIR8waEJBQ=="
-            crossorigin="anonymous" referrerpolicy="no-referrer"></script>
-    <script type="text/javascript" src="https://cdnjs.cloudflare.com/ajax/libs/react/17.0.2/umd/react.production.min.js"
-            integrity="sha512-qlzIeUtTg7eBpmEaS12NZgxz52YYZVF5myj89mjJEesBd/oE9UPsYOX2QAXzvOAZYEvQohKdcY8zKE02ifXDmA=="
-            crossorigin="anonymous" referrerpolicy="no-referrer"></script>
-    <script type="text/javascript"
-            src="https://cdnjs.cloudflare.com/ajax/libs/react-dom/17.0.2/umd/react-dom.production.min.js"
-            integrity="sha512-9jGNr5Piwe8nzLLYTk8QrEMPfjGU0px80GYzKZUxi7lmCfrBjtyCc1V5kkS5vxVwwIB7Qpzc7UxLiQxfAN30dw=="
-            crossorigin="anonymous" referrerpolicy="no-referrer"></script>
-    <script type="text/javascript" src="https://cdnjs.cloudflare.com/ajax/libs/babel-standalone/6.26.0/babel.min.js"
-            integrity="sha512-kp7YHLxuJDJcOzStgd6vtpxr4ZU9kjn77e6dBsivSz+pUuAuMlE2UTdKB7jjsWT84qbS8kdCWHPETnP/ctrFsA=="
-            crossorigin="anonymous" referrerpolicy="no-referrer"></script>
+    <style>
+        body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; color: #24292f; }
+        header { background: #24292f; color: #fff; padding: 16px 24px; }
+        header h1 { margin: 0; font-size: 20px; }
+        main { padding: 16px 24px; }
+        .tabs { display: flex; flex-wrap: wrap; gap: 4px; margin-bottom: 12px; }
+        .tabs button { border: 1px solid #d0d7de; background: #f6f8fa; border-radius: 6px; padding: 4px 10px; cursor: pointer; }
+        .tabs button.active { background: #0969da; border-color: #0969da; color: #fff; }
+        #filter { width: 100%; box-sizing: border-box; padding: 6px 10px; margin-bottom: 12px; border: 1px solid #d0d7de; border-radius: 6px; }
+        table { width: 100%; border-collapse: collapse; }
+        th, td { text-align: left; vertical-align: top; padding: 6px 8px; border-bottom: 1px solid #d0d7de; }
+        th { background: #f6f8fa; }
+        td.pos { font-family: monospace; white-space: nowrap; }
+        summary { cursor: pointer; }
+        pre { background: #f6f8fa; padding: 8px 0; overflow-x: auto; margin: 8px 0 0; }
+        pre span.line { display: block; padding: 0 8px; }
+        pre span.line.issue { background: #fff8c5; }
+        pre span.number { display: inline-block; width: 4em; color: #8c959f; user-select: none; }
+        .kw { color: #cf222e; } .str { color: #0a3069; } .com { color: #6e7781; } .num { color: #0550ae; }
+        .none { padding: 12px; background: #dafbe1; border-radius: 6px; }
+    </style>
 </head>
 <body>
-<section class="section">
-    <div class="container">
-        <div id="content"></div>
+<header>
+    <h1>golangci-lint: {{ len .Issues }} issue(s) in {{ .Files }} file(s)</h1>
+</header>
+<main>
+{{- if .Issues }}
+    <div class="tabs">
+        <button class="active" data-linter="">All ({{ len .Issues }})</button>
+        {{- range .Linters }}
+        <button data-linter="{{ .Name }}">{{ .Name }} ({{ .Count }})</button>
+        {{- end }}
     </div>
-</section>
+    <input id="filter" type="search" placeholder="Filter by file, text, severity or owner">
+    <table>
+        <thead>
+        <tr><th>Position</th><th>Linter</th><th>Severity</th>{{ if .Owners }}<th>Owners</th>{{ end }}<th>Issue</th></tr>
+        </thead>
+        <tbody>
+        {{- range .Issues }}
+        <tr class="issue" data-linter="{{ .Linter }}">
+            <td class="pos">{{ .Pos }}</td>
+            <td>{{ .Linter }}</td>
+            <td>{{ .Severity }}</td>
+            {{- if $.Owners }}
+            <td>{{ .Owners }}</td>
+            {{- end }}
+            <td>
+                <details>
+                    <summary>{{ .Title }}</summary>
+                    <pre>
+                    {{- range .Code -}}
+                    <span class="line{{ if .Issue }} issue{{ end }}"><span class="number">{{ .Number }}</span>{{ .Code }}</span>
+                    {{- end -}}
+                    </pre>
+                </details>
+            </td>
+        </tr>
+        {{- end }}
+        </tbody>
+    </table>
+{{- else }}
+    <div class="none">No issues found!</div>
+{{- end }}
+</main>
 <script>
-    const data = {{ . }};
-</script>
-<script type="text/babel">
-  class Highlight extends React.Component {
-    componentDidMount() {
-      hljs.highlightElement(ReactDOM.findDOMNode(this));
-    }
-
-    render() {
-      return <pre className="go"><code>{this.props.code}</code></pre>;
-    }
-  }
-
-  class Issue extends React.Component {
-    render() {
-      return (
-        <div className="issue box">
-          <div>
-            <div className="columns">
-              <div className="column is-four-fifths">
-                <h5 className="title is-5 has-text-danger-dark">{this.props.data.Title}</h5>
-              </div>
-              <div className="column is-one-fifth">
-                <h6 className="title is-6">{this.props.data.Linter}</h6>
-              </div>
-            </div>
-            <strong>{this.props.data.Pos}</strong>
-          </div>
-          <div className="highlight">
-            <Highlight code={this.props.data.Code}/>
-          </div>
-        </div>
-      );
-    }
-  }
-
-  class Issues extends React.Component {
-    render() {
-      if (!this.props.data.Issues || this.props.data.Issues.length === 0) {
-        return (
-          <div>
-            <div className="notification">
-              No issues found!
-            </div>
-          </div>
-        );
-      }
-
-      return (
-        <div className="issues">
-          {this.props.data.Issues.map(issue => (<Issue data={issue}/>))}
-        </div>
-      );
-    }
-  }
-
-  ReactDOM.render(
-    <div className="content">
-      <div className="columns is-centered">
-        <div className="column is-three-quarters">
-          <Issues data={data}/>
-        </div>
-      </div>
-    </div>,
-    document.getElementById("content")
-  );
+    (function () {
+        var linter = "";
+        var filter = document.getElementById("filter");
+
+        function update() {
+            var text = filter ? filter.value.toLowerCase() : "";
+            document.querySelectorAll("tr.issue").forEach(function (row) {
+                var visible = (linter === "" || row.dataset.linter === linter) &&
+                    (text === "" || row.textContent.toLowerCase().indexOf(text) !== -1);
+                row.style.display = visible ? "" : "none";
+            });
+        }
+
+        document.querySelectorAll(".tabs button").forEach(function (button) {
+            button.addEventListener("click", function () {
+                document.querySelectorAll(".tabs button").forEach(function (b) { b.classList.remove("active"); });
+                button.classList.add("active");
+                linter = button.dataset.linter;
+                update();
+            });
+        });
+
+        if (filter) {
+            filter.addEventListener("input", update);
+        }
+    })();
 </script>
 </body>
-</html>`
+</html>
+`
+
+type htmlReport struct {
+	Issues  []htmlIssue
+	Linters []htmlLinter
+	Files   int
+	Owners  bool // the issues are annotated with their code owners
+}
+
+type htmlLinter struct {
+	Name  string
+	Count int
+}
 
 type htmlIssue struct {
-	Title  string
-	Pos    string
-	Linter string
-	Code   string
+	Title    string
+	Pos      string
+	Linter   string
+	Severity string
+	Owners   string
+	Code     []htmlLine
+}
+
+type htmlLine struct {
+	Number int
+	Code   template.HTML
+	Issue  bool
 }
 
 type HTML struct {
-	w io.Writer
+	lineCache *fsutils.LineCache
+	w         io.Writer
 }
 
-func NewHTML(w io.Writer) *HTML {
-	return &HTML{w: w}
+// NewHTML creates a printer of a standalone HTML report.
+// The excerpts of the source code around the issues are read from the line cache,
+// without it (or when a file can't be read) only the lines of the issues are printed.
+func NewHTML(lineCache *fsutils.LineCache, w io.Writer) *HTML {
+	return &HTML{lineCache: lineCache, w: w}
 }
 
 func (p HTML) Print(_ context.Context, issues []result.Issue) error {
-	var htmlIssues []htmlIssue
+	var report htmlReport
+
+	files := map[string]bool{}
+	linters := map[string]int{}
 
 	for i := range issues {
-		pos := fmt.Sprintf("%s:%d", issues[i].FilePath(), issues[i].Line())
-		if issues[i].Pos.Column != 0 {
-			pos += fmt.Sprintf(":%d", issues[i].Pos.Column)
+		issue := &issues[i]
+
+		pos := fmt.Sprintf("%s:%d", issue.FilePath(), issue.Line())
+		if issue.Pos.Column != 0 {
+			pos += fmt.Sprintf(":%d", issue.Pos.Column)
 		}
 
-		htmlIssues = append(htmlIssues, htmlIssue{
-			Title:  strings.TrimSpace(issues[i].Text),
-			Pos:    pos,
-			Linter: issues[i].FromLinter,
-			Code:   strings.Join(issues[i].SourceLines, "\n"),
+		report.Issues = append(report.Issues, htmlIssue{
+			Title:    strings.TrimSpace(issue.Text),
+			Pos:      pos,
+			Linter:   issue.FromLinter,
+			Severity: issue.Severity,
+			Owners:   strings.Join(issue.Owners, " "),
+			Code:     p.excerpt(issue),
 		})
+
+		if len(issue.Owners) != 0 {
+			report.Owners = true
+		}
+
+		files[issue.FilePath()] = true
+		linters[issue.FromLinter]++
+	}
+
+	for name, count := range linters {
+		report.Linters = append(report.Linters, htmlLinter{Name: name, Count: count})
 	}
+	sort.Slice(report.Linters, func(i, j int) bool { return report.Linters[i].Name < report.Linters[j].Name })
+	report.Files = len(files)
 
 	t, err := template.New("golangci-lint").Parse(templateContent)
 	if err != nil {
 		return err
 	}
 
-	return t.Execute(p.w, struct{ Issues []htmlIssue }{Issues: htmlIssues})
+	return t.Execute(p.w, report)
+}
+
+// excerpt returns the lines around the issue, or its source lines if the file can't be read.
+func (p HTML) excerpt(issue *result.Issue) []htmlLine {
+	line := issue.Line()
+	if line == 0 {
+		line = 1
+	}
+
+	var lines []htmlLine
+
+	if p.lineCache != nil {
+		first := line - htmlContextLines
+		if first < 1 {
+			first = 1
+		}
+
+		for n := first; n <= line+htmlContextLines; n++ {
+			code, err := p.lineCache.GetLine(issue.FilePath(), n)
+			if err != nil {
+				break // the end of the file, or the file can't be read
+			}
+			lines = append(lines, htmlLine{Number: n, Code: highlightGo(code), Issue: n == line})
+		}
+
+		if len(lines) != 0 {
+			return lines
+		}
+	}
+
+	for i, code := range issue.SourceLines {
+		lines = append(lines, htmlLine{Number: line + i, Code: highlightGo(code), Issue: true})
+	}
+
+	return lines
+}
+
+// highlightGo escapes a line of Go code and wraps its keywords, literals and comments in spans.
+// The lines are scanned one by one: the lines inside a multi-line comment or raw string are approximate.
+func highlightGo(line string) template.HTML {
+	src := []byte(line)
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+
+	var s scanner.Scanner
+	s.Init(file, src, func(token.Position, string) {}, scanner.ScanComments)
+
+	var b strings.Builder
+	offset := 0
+
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue // automatically inserted
+		}
+
+		start := file.Offset(pos)
+		if start < offset || start > len(src) {
+			continue
+		}
+
+		end := start + len(tok.String())
+		if lit != "" {
+			end = start + len(lit)
+		}
+		if end > len(src) {
+			end = len(src)
+		}
+
+		b.WriteString(template.HTMLEscapeString(line[offset:start]))
+
+		text := template.HTMLEscapeString(line[start:end])
+		switch class := highlightClass(tok); class {
+		case "":
+			b.WriteString(text)
+		default:
+			fmt.Fprintf(&b, `<span class="%s">%s</span>`, class, text)
+		}
+
+		offset = end
+	}
+
+	b.WriteString(template.HTMLEscapeString(line[offset:]))
+
+	return template.HTML(b.String()) //nolint:gosec // the code is escaped
+}
+
+func highlightClass(tok token.Token) string {
+	switch {
+	case tok.IsKeyword():
+		return "kw"
+	case tok == token.STRING || tok == token.CHAR:
+		return "str"
+	case tok == token.COMMENT:
+		return "com"
+	case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+		return "num"
+	default:
+		return ""
+	}
 }
@@ -4,121 +4,18 @@ import (
 	"bytes"
 	"context"
 	"go/token"
+	"html/template"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
-//nolint:lll
-const expectedHTML = `<!doctype html>
-<html lang="en">
-<head>
-    <meta charset="utf-8">
-    <title>golangci-lint</title>
-    <link rel="shortcut icon" type="image/png" href="https://golangci-lint.run/favicon-32x32.png">
-    <link rel="stylesheet" href="https://cdnjs.cloudflare.com/ajax/libs/bulma/0.9.2/css/bulma.min.css"
-          integrity="sha512-byErQdWdTqREz6DLAA9pCnLbdoGGhXfU6gm1c8bkf7F51JVmUBlayGe2A31VpXWQP+eiJ3ilTAZHCR3vmMyybA=="
-          crossorigin="anonymous" referrerpolicy="no-referrer"/>
-    <link rel="stylesheet" href="https://cdnjs.cloudflare.com/ajax/libs/highlight.js/10.7.2/styles/default.min.css"
-          integrity="sha512-kZqGbhf9JTB4bVJ0G8HCkqmaPcRgo88F0dneK30yku5Y/dep7CZfCnNml2Je/sY4lBoqoksXz4PtVXS4GHSUzQ=="
-          crossorigin="anonymous" referrerpolicy="no-referrer"/>
-    <script type="text/javascript" src="https://cdnjs.cloudflare.com/ajax/libs/highlight.js/10.7.2/highlight.min.js"
-            integrity="sha512-s+tOYYcC3Jybgr9mVsdAxsRYlGNq4mlAurOrfNuGMQ/SCofNPu92tjE7YRZCsdEtWL1yGkqk15fU/ark206YTg=="
-            crossorigin="anonymous" referrerpolicy="no-referrer"></script>
-    <script type="text/javascript" src="https://cdnjs.cloudflare.com/ajax/libs/highlight.js/10.7.2/languages/go.min.js"
-            integrity="sha512-+UYV2NyyynWEQcZ4sMTKmeppyV331gqvMOGZ61/dqc89Tn1H40lF05ACd03RSD9EWwGutNwKj256mIR8waEJBQ=="
-            crossorigin="anonymous" referrerpolicy="no-referrer"></script>
-    <script type="text/javascript" src="https://cdnjs.cloudflare.com/ajax/libs/react/17.0.2/umd/react.production.min.js"
-            integrity="sha512-qlzIeUtTg7eBpmEaS12NZgxz52YYZVF5myj89mjJEesBd/oE9UPsYOX2QAXzvOAZYEvQohKdcY8zKE02ifXDmA=="
-            crossorigin="anonymous" referrerpolicy="no-referrer"></script>
-    <script type="text/javascript"
-            src="https://cdnjs.cloudflare.com/ajax/libs/react-dom/17.0.2/umd/react-dom.production.min.js"
-            integrity="sha512-9jGNr5Piwe8nzLLYTk8QrEMPfjGU0px80GYzKZUxi7lmCfrBjtyCc1V5kkS5vxVwwIB7Qpzc7UxLiQxfAN30dw=="
-            crossorigin="anonymous" referrerpolicy="no-referrer"></script>
-    <script type="text/javascript" src="https://cdnjs.cloudflare.com/ajax/libs/babel-standalone/6.26.0/babel.min.js"
-            integrity="sha512-kp7YHLxuJDJcOzStgd6vtpxr4ZU9kjn77e6dBsivSz+pUuAuMlE2UTdKB7jjsWT84qbS8kdCWHPETnP/ctrFsA=="
-            crossorigin="anonymous" referrerpolicy="no-referrer"></script>
-</head>
-<body>
-<section class="section">
-    <div class="container">
-        <div id="content"></div>
-    </div>
-</section>
-<script>
-    const data = {"Issues":[{"Title":"some issue","Pos":"path/to/filea.go:10:4","Linter":"linter-a","Code":""},{"Title":"another issue","Pos":"path/to/fileb.go:300:9","Linter":"linter-b","Code":"func foo() {\n\tfmt.Println(\"bar\")\n}"}]};
-</script>
-<script type="text/babel">
-  class Highlight extends React.Component {
-    componentDidMount() {
-      hljs.highlightElement(ReactDOM.findDOMNode(this));
-    }
-
-    render() {
-      return <pre className="go"><code>{this.props.code}</code></pre>;
-    }
-  }
-
-  class Issue extends React.Component {
-    render() {
-      return (
-        <div className="issue box">
-          <div>
-            <div className="columns">
-              <div className="column is-four-fifths">
-                <h5 className="title is-5 has-text-danger-dark">{this.props.data.Title}</h5>
-              </div>
-              <div className="column is-one-fifth">
-                <h6 className="title is-6">{this.props.data.Linter}</h6>
-              </div>
-            </div>
-            <strong>{this.props.data.Pos}</strong>
-          </div>
-          <div className="highlight">
-            <Highlight code={this.props.data.Code}/>
-          </div>
-        </div>
-      );
-    }
-  }
-
-  class Issues extends React.Component {
-    render() {
-      if (!this.props.data.Issues || this.props.data.Issues.length === 0) {
-        return (
-          <div>
-            <div className="notification">
-              No issues found!
-            </div>
-          </div>
-        );
-      }
-
-      return (
-        <div className="issues">
-          {this.props.data.Issues.map(issue => (<Issue data={issue}/>))}
-        </div>
-      );
-    }
-  }
-
-  ReactDOM.render(
-    <div className="content">
-      <div className="columns is-centered">
-        <div className="column is-three-quarters">
-          <Issues data={data}/>
-        </div>
-      </div>
-    </div>,
-    document.getElementById("content")
-  );
-</script>
-</body>
-</html>`
-
 func TestHTML_Print(t *testing.T) {
 	issues := []result.Issue{
 		{
@@ -135,7 +32,7 @@ func TestHTML_Print(t *testing.T) {
 		{
 			FromLinter: "linter-b",
 			Severity:   "error",
-			Text:       "another issue",
+			Text:       "another <issue>",
 			SourceLines: []string{
 				"func foo() {",
 				"\tfmt.Println(\"bar\")",
@@ -151,10 +48,105 @@ func TestHTML_Print(t *testing.T) {
 	}
 
 	buf := new(bytes.Buffer)
-	printer := NewHTML(buf)
+	printer := NewHTML(nil, buf)
 
 	err := printer.Print(context.Background(), issues)
 	require.NoError(t, err)
 
-	assert.Equal(t, expectedHTML, buf.String())
+	out := buf.String()
+
+	assert.NotContains(t, out, "<script type=\"text/javascript\" src=", "the report must be standalone")
+	assert.NotContains(t, out, "<link rel=\"stylesheet\"", "the report must be standalone")
+
+	assert.Contains(t, out, "<h1>golangci-lint: 2 issue(s) in 2 file(s)</h1>")
+	assert.Contains(t, out, `<button data-linter="linter-a">linter-a (1)</button>`)
+	assert.Contains(t, out, `<button data-linter="linter-b">linter-b (1)</button>`)
+	assert.Contains(t, out, `<td class="pos">path/to/filea.go:10:4</td>`)
+	assert.Contains(t, out, "<summary>another &lt;issue&gt;</summary>")
+	assert.NotContains(t, out, "<th>Owners</th>")
+	assert.Contains(t, out,
+		`<span class="line issue"><span class="number">300</span><span class="kw">func</span> foo() {</span>`)
+	assert.Contains(t, out,
+		`<span class="line issue"><span class="number">301</span>	fmt.Println(<span class="str">&#34;bar&#34;</span>)</span>`)
+}
+
+func TestHTML_Print_owners(t *testing.T) {
+	issues := []result.Issue{
+		{
+			FromLinter: "linter-a",
+			Text:       "some issue",
+			Owners:     []string{"@org/payments", "@alice"},
+			Pos:        token.Position{Filename: "payments/a.go", Line: 10},
+		},
+		{
+			FromLinter: "linter-a",
+			Text:       "unowned issue",
+			Pos:        token.Position{Filename: "b.go", Line: 3},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	err := NewHTML(nil, buf).Print(context.Background(), issues)
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "<th>Owners</th>")
+	assert.Contains(t, out, "<td>@org/payments @alice</td>")
+	assert.Contains(t, out, "<td></td>")
+}
+
+func TestHTML_Print_noIssues(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	err := NewHTML(nil, buf).Print(context.Background(), nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "No issues found!")
+	assert.NotContains(t, buf.String(), "<table>")
+}
+
+func TestHTML_excerpt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.go")
+	err := os.WriteFile(path, []byte("package a\n\nimport \"os\"\n\nfunc f() {\n\tos.Exit(1)\n}\n"), 0o600)
+	require.NoError(t, err)
+
+	printer := NewHTML(fsutils.NewLineCache(fsutils.NewFileCache()), new(bytes.Buffer))
+
+	lines := printer.excerpt(&result.Issue{Pos: token.Position{Filename: path, Line: 6}})
+
+	var numbers []int
+	var issueLines []int
+	for _, line := range lines {
+		numbers = append(numbers, line.Number)
+		if line.Issue {
+			issueLines = append(issueLines, line.Number)
+		}
+	}
+
+	assert.Equal(t, []int{3, 4, 5, 6, 7, 8}, numbers)
+	assert.Equal(t, []int{6}, issueLines)
+
+	// the file can't be read: the source lines of the issue
+	lines = printer.excerpt(&result.Issue{
+		Pos:         token.Position{Filename: filepath.Join(t.TempDir(), "missing.go"), Line: 2},
+		SourceLines: []string{"x := 1"},
+	})
+	assert.Equal(t, []htmlLine{{Number: 2, Code: `x := <span class="num">1</span>`, Issue: true}}, lines)
+}
+
+func TestHighlightGo(t *testing.T) {
+	testCases := []struct {
+		line     string
+		expected template.HTML
+	}{
+		{line: "", expected: ""},
+		{line: "\treturn nil // <done>", expected: "\t<span class=\"kw\">return</span> nil <span class=\"com\">// &lt;done&gt;</span>"},
+		{line: "s := `raw` + 'c'", expected: "s := <span class=\"str\">`raw`</span> + <span class=\"str\">&#39;c&#39;</span>"},
+		{line: "x := 1.5 & y", expected: "x := <span class=\"num\">1.5</span> &amp; y"},
+		{line: "/* unterminated", expected: "<span class=\"com\">/* unterminated</span>"},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.expected, highlightGo(test.line), test.line)
+	}
 }
@@ -0,0 +1,29 @@
+package printers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// JSONStream prints each issue as a JSON object on its own line (NDJSON):
+// it can print the issues of each linter as soon as they're found.
+type JSONStream struct {
+	w io.Writer
+}
+
+func NewJSONStream(w io.Writer) *JSONStream {
+	return &JSONStream{w: w}
+}
+
+func (p JSONStream) Print(ctx context.Context, issues []result.Issue) error {
+	enc := json.NewEncoder(p.w)
+	for i := range issues {
+		if err := enc.Encode(&issues[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
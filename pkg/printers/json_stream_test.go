@@ -0,0 +1,52 @@
+package printers
+
+import (
+	"bytes"
+	"context"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestJSONStream_Print(t *testing.T) {
+	issues := []result.Issue{
+		{
+			FromLinter: "linter-a",
+			Severity:   "warning",
+			Text:       "some issue",
+			Pos: token.Position{
+				Filename: "path/to/filea.go",
+				Offset:   2,
+				Line:     10,
+				Column:   4,
+			},
+		},
+		{
+			FromLinter: "linter-b",
+			Text:       "another issue",
+			Pos: token.Position{
+				Filename: "path/to/fileb.go",
+				Line:     300,
+			},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+
+	printer := NewJSONStream(buf)
+
+	require.NoError(t, printer.Print(context.Background(), issues[:1]))
+	require.NoError(t, printer.Print(context.Background(), nil))
+	require.NoError(t, printer.Print(context.Background(), issues[1:]))
+
+	//nolint:lll
+	expected := `{"FromLinter":"linter-a","Text":"some issue","Severity":"warning","SourceLines":null,"Replacement":null,"Pos":{"Filename":"path/to/filea.go","Offset":2,"Line":10,"Column":4},"ExpectNoLint":false,"ExpectedNoLintLinter":""}
+{"FromLinter":"linter-b","Text":"another issue","Severity":"","SourceLines":null,"Replacement":null,"Pos":{"Filename":"path/to/fileb.go","Offset":0,"Line":300,"Column":0},"ExpectNoLint":false,"ExpectedNoLintLinter":""}
+`
+
+	assert.Equal(t, expected, buf.String())
+}
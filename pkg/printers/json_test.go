@@ -26,9 +26,11 @@ func TestJSON_Print(t *testing.T) {
 			},
 		},
 		{
-			FromLinter: "linter-b",
-			Severity:   "error",
-			Text:       "another issue",
+			FromLinter:       "linter-b",
+			RuleID:           "B1",
+			DocumentationURL: "https://example.com/B1",
+			Severity:         "error",
+			Text:             "another issue",
 			SourceLines: []string{
 				"func foo() {",
 				"\tfmt.Println(\"bar\")",
@@ -51,7 +53,7 @@ func TestJSON_Print(t *testing.T) {
 	require.NoError(t, err)
 
 	//nolint:lll
-	expected := `{"Issues":[{"FromLinter":"linter-a","Text":"some issue","Severity":"warning","SourceLines":null,"Replacement":null,"Pos":{"Filename":"path/to/filea.go","Offset":2,"Line":10,"Column":4},"ExpectNoLint":false,"ExpectedNoLintLinter":""},{"FromLinter":"linter-b","Text":"another issue","Severity":"error","SourceLines":["func foo() {","\tfmt.Println(\"bar\")","}"],"Replacement":null,"Pos":{"Filename":"path/to/fileb.go","Offset":5,"Line":300,"Column":9},"ExpectNoLint":false,"ExpectedNoLintLinter":""}],"Report":null}
+	expected := `{"Issues":[{"FromLinter":"linter-a","Text":"some issue","Severity":"warning","SourceLines":null,"Replacement":null,"Pos":{"Filename":"path/to/filea.go","Offset":2,"Line":10,"Column":4},"ExpectNoLint":false,"ExpectedNoLintLinter":""},{"FromLinter":"linter-b","RuleID":"B1","DocumentationURL":"https://example.com/B1","Text":"another issue","Severity":"error","SourceLines":["func foo() {","\tfmt.Println(\"bar\")","}"],"Replacement":null,"Pos":{"Filename":"path/to/fileb.go","Offset":5,"Line":300,"Column":9},"ExpectNoLint":false,"ExpectedNoLintLinter":""}],"Report":null}
 `
 
 	assert.Equal(t, expected, buf.String())
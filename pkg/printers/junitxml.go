@@ -6,13 +6,21 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/golangci/golangci-lint/pkg/report"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
 type testSuitesXML struct {
 	XMLName    xml.Name `xml:"testsuites"`
+	Name       string   `xml:"name,attr"`
+	Tests      int      `xml:"tests,attr"`
+	Errors     int      `xml:"errors,attr"`
+	Failures   int      `xml:"failures,attr"`
+	Skipped    *int     `xml:"skipped,attr"`
+	Time       string   `xml:"time,attr"`
 	TestSuites []testSuiteXML
 }
 
@@ -22,13 +30,29 @@ type testSuiteXML struct {
 	Tests     int           `xml:"tests,attr"`
 	Errors    int           `xml:"errors,attr"`
 	Failures  int           `xml:"failures,attr"`
+	Skipped   *int          `xml:"skipped,attr"`
+	Time      string        `xml:"time,attr"`
 	TestCases []testCaseXML `xml:"testcase"`
+
+	durationMs int64
 }
 
 type testCaseXML struct {
-	Name      string     `xml:"name,attr"`
-	ClassName string     `xml:"classname,attr"`
-	Failure   failureXML `xml:"failure"`
+	Name       string         `xml:"name,attr"`
+	ClassName  string         `xml:"classname,attr"`
+	File       string         `xml:"file,attr,omitempty"`
+	Line       int            `xml:"line,attr,omitempty"`
+	Properties *propertiesXML `xml:"properties"`
+	Failure    failureXML     `xml:"failure"`
+}
+
+type propertiesXML struct {
+	Properties []propertyXML `xml:"property"`
+}
+
+type propertyXML struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
 }
 
 type failureXML struct {
@@ -37,43 +61,80 @@ type failureXML struct {
 	Content string `xml:",cdata"`
 }
 
+// JunitXML prints a test suite per linter, and a failed test case per issue.
 type JunitXML struct {
-	w io.Writer
+	reportData *report.Data
+	strict     bool
+	w          io.Writer
 }
 
-func NewJunitXML(w io.Writer) *JunitXML {
-	return &JunitXML{w: w}
+// NewJunitXML creates a printer of JUnit XML.
+// The enabled linters of the report data have a test suite, even without issues,
+// with their duration and the count of their excluded issues as skipped tests.
+// In strict mode, only the elements and attributes of the JUnit schema of Ant are printed:
+// no skipped counts, and the file and the line of the issues only in the failures.
+func NewJunitXML(reportData *report.Data, strict bool, w io.Writer) *JunitXML {
+	return &JunitXML{reportData: reportData, strict: strict, w: w}
 }
 
 func (p JunitXML) Print(ctx context.Context, issues []result.Issue) error {
-	suites := make(map[string]testSuiteXML) // use a map to group by file
+	suites := make(map[string]*testSuiteXML) // use a map to group by linter
+
+	suite := func(name string) *testSuiteXML {
+		ts, ok := suites[name]
+		if !ok {
+			ts = &testSuiteXML{Suite: name}
+			suites[name] = ts
+		}
+		return ts
+	}
+
+	if p.reportData != nil {
+		for _, ld := range p.reportData.Linters {
+			if !ld.Enabled {
+				continue
+			}
+
+			ts := suite(ld.Name)
+			ts.durationMs = ld.DurationMs
+
+			if !p.strict && ld.Excluded != 0 {
+				skipped := ld.Excluded
+				ts.Skipped = &skipped
+				ts.Tests += skipped
+			}
+		}
+	}
 
 	for ind := range issues {
 		i := &issues[ind]
-		suiteName := i.FilePath()
-		testSuite := suites[suiteName]
-		testSuite.Suite = i.FilePath()
-		testSuite.Tests++
-		testSuite.Failures++
-
-		tc := testCaseXML{
-			Name:      i.FromLinter,
-			ClassName: i.Pos.String(),
-			Failure: failureXML{
-				Type:    i.Severity,
-				Message: i.Pos.String() + ": " + i.Text,
-				Content: fmt.Sprintf("%s: %s\nCategory: %s\nFile: %s\nLine: %d\nDetails: %s",
-					i.Severity, i.Text, i.FromLinter, i.Pos.Filename, i.Pos.Line, strings.Join(i.SourceLines, "\n")),
-			},
+
+		ts := suite(i.FromLinter)
+		ts.Tests++
+		ts.Failures++
+		ts.TestCases = append(ts.TestCases, p.testCase(i))
+	}
+
+	res := testSuitesXML{Name: "golangci-lint"}
+	var durationMs int64
+	var skipped int
+
+	for _, ts := range suites {
+		ts.Time = formatJunitTime(ts.durationMs)
+		durationMs += ts.durationMs
+
+		res.Tests += ts.Tests
+		res.Failures += ts.Failures
+		if ts.Skipped != nil {
+			skipped += *ts.Skipped
 		}
 
-		testSuite.TestCases = append(testSuite.TestCases, tc)
-		suites[suiteName] = testSuite
+		res.TestSuites = append(res.TestSuites, *ts)
 	}
 
-	var res testSuitesXML
-	for _, val := range suites {
-		res.TestSuites = append(res.TestSuites, val)
+	res.Time = formatJunitTime(durationMs)
+	if !p.strict {
+		res.Skipped = &skipped
 	}
 
 	sort.Slice(res.TestSuites, func(i, j int) bool {
@@ -87,3 +148,43 @@ func (p JunitXML) Print(ctx context.Context, issues []result.Issue) error {
 	}
 	return nil
 }
+
+func (p JunitXML) testCase(i *result.Issue) testCaseXML {
+	tc := testCaseXML{
+		Name:      i.Pos.String(),
+		ClassName: i.FilePath(),
+		Failure: failureXML{
+			Type:    i.Severity,
+			Message: i.Pos.String() + ": " + i.Text,
+			Content: fmt.Sprintf("%s: %s\nCategory: %s\nFile: %s\nLine: %d\nDetails: %s",
+				i.Severity, i.Text, i.FromLinter, i.Pos.Filename, i.Pos.Line, strings.Join(i.SourceLines, "\n")),
+		},
+	}
+
+	if p.strict {
+		return tc
+	}
+
+	tc.File, tc.Line = i.FilePath(), i.Line()
+
+	props := []propertyXML{
+		{Name: "file", Value: i.FilePath()},
+		{Name: "line", Value: strconv.Itoa(i.Line())},
+		{Name: "column", Value: strconv.Itoa(i.Column())},
+		{Name: "linter", Value: i.FromLinter},
+	}
+	if i.Severity != "" {
+		props = append(props, propertyXML{Name: "severity", Value: i.Severity})
+	}
+	if i.RuleID != "" {
+		props = append(props, propertyXML{Name: "rule", Value: i.RuleID})
+	}
+	tc.Properties = &propertiesXML{Properties: props}
+
+	return tc
+}
+
+// formatJunitTime formats a duration in seconds.
+func formatJunitTime(durationMs int64) string {
+	return strconv.FormatFloat(float64(durationMs)/1000, 'f', 3, 64)
+}
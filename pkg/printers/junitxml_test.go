@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/golangci/golangci-lint/pkg/report"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
@@ -29,6 +30,7 @@ func TestJunitXML_Print(t *testing.T) {
 		{
 			FromLinter: "linter-b",
 			Severity:   "error",
+			RuleID:     "B1",
 			Text:       "another issue",
 			SourceLines: []string{
 				"func foo() {",
@@ -44,15 +46,71 @@ func TestJunitXML_Print(t *testing.T) {
 		},
 	}
 
+	data := &report.Data{
+		Linters: []report.LinterData{
+			{Name: "linter-a", Enabled: true, DurationMs: 1500, Excluded: 2},
+			{Name: "linter-b", Enabled: true},
+			{Name: "linter-c", Enabled: true, DurationMs: 10},
+			{Name: "linter-d"},
+		},
+	}
+
 	buf := new(bytes.Buffer)
-	printer := NewJunitXML(buf)
+	printer := NewJunitXML(data, false, buf)
 
 	err := printer.Print(context.Background(), issues)
 	require.NoError(t, err)
 
-	expected := `<testsuites>
-  <testsuite name="path/to/filea.go" tests="1" errors="0" failures="1">
-    <testcase name="linter-a" classname="path/to/filea.go:10:4">
+	expected := `<testsuites name="golangci-lint" tests="4" errors="0" failures="2" skipped="2" time="1.510">
+  <testsuite name="linter-a" tests="3" errors="0" failures="1" skipped="2" time="1.500">
+    <testcase name="path/to/filea.go:10:4" classname="path/to/filea.go" file="path/to/filea.go" line="10">
+      <properties>
+        <property name="file" value="path/to/filea.go"></property>
+        <property name="line" value="10"></property>
+        <property name="column" value="4"></property>
+        <property name="linter" value="linter-a"></property>
+        <property name="severity" value="warning"></property>
+      </properties>
+      <failure message="path/to/filea.go:10:4: some issue" type="warning"><![CDATA[warning: some issue
+Category: linter-a
+File: path/to/filea.go
+Line: 10
+Details: ]]></failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="linter-b" tests="1" errors="0" failures="1" time="0.000">
+    <testcase name="path/to/fileb.go:300:9" classname="path/to/fileb.go" file="path/to/fileb.go" line="300">
+      <properties>
+        <property name="file" value="path/to/fileb.go"></property>
+        <property name="line" value="300"></property>
+        <property name="column" value="9"></property>
+        <property name="linter" value="linter-b"></property>
+        <property name="severity" value="error"></property>
+        <property name="rule" value="B1"></property>
+      </properties>
+      <failure message="path/to/fileb.go:300:9: another issue" type="error"><![CDATA[error: another issue
+Category: linter-b
+File: path/to/fileb.go
+Line: 300
+Details: func foo() {
+	fmt.Println("bar")
+}]]></failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="linter-c" tests="0" errors="0" failures="0" time="0.010"></testsuite>
+</testsuites>`
+
+	assert.Equal(t, expected, buf.String())
+
+	buf.Reset()
+	printer = NewJunitXML(data, true, buf)
+
+	err = printer.Print(context.Background(), issues)
+	require.NoError(t, err)
+
+	expected = `<testsuites name="golangci-lint" tests="2" errors="0" failures="2" time="1.510">
+  <testsuite name="linter-a" tests="1" errors="0" failures="1" time="1.500">
+    <testcase name="path/to/filea.go:10:4" classname="path/to/filea.go">
       <failure message="path/to/filea.go:10:4: some issue" type="warning"><![CDATA[warning: some issue
 Category: linter-a
 File: path/to/filea.go
@@ -60,8 +118,8 @@ Line: 10
 Details: ]]></failure>
     </testcase>
   </testsuite>
-  <testsuite name="path/to/fileb.go" tests="1" errors="0" failures="1">
-    <testcase name="linter-b" classname="path/to/fileb.go:300:9">
+  <testsuite name="linter-b" tests="1" errors="0" failures="1" time="0.000">
+    <testcase name="path/to/fileb.go:300:9" classname="path/to/fileb.go">
       <failure message="path/to/fileb.go:300:9: another issue" type="error"><![CDATA[error: another issue
 Category: linter-b
 File: path/to/fileb.go
@@ -71,6 +129,7 @@ Details: func foo() {
 }]]></failure>
     </testcase>
   </testsuite>
+  <testsuite name="linter-c" tests="0" errors="0" failures="0" time="0.010"></testsuite>
 </testsuites>`
 
 	assert.Equal(t, expected, buf.String())
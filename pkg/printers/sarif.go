@@ -0,0 +1,129 @@
+package printers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const (
+	sarifVersion      = "2.1.0"
+	sarifSchemaURI    = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolName     = "golangci-lint"
+	defaultSarifLevel = "error"
+)
+
+// sarifLevels are the levels of SARIF, by level of severity.
+var sarifLevels = []string{"none", "note", "warning", "error", "error", "error"}
+
+// SarifOutput is a subset of the SARIF spec: https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+// It's enough for the code scanning of GitHub and for the SARIF viewers.
+type SarifOutput struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver struct {
+		Name  string      `json:"name"`
+		Rules []sarifRule `json:"rules,omitempty"`
+	} `json:"driver"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID  string `json:"ruleId"`
+	Level   string `json:"level"`
+	Message struct {
+		Text string `json:"text"`
+	} `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region struct {
+			StartLine   int `json:"startLine"`
+			StartColumn int `json:"startColumn,omitempty"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+}
+
+// Sarif prints the issues as a SARIF log with a single run: a rule per linter, or per rule of the linters reporting them.
+type Sarif struct {
+	w io.Writer
+}
+
+func NewSarif(w io.Writer) *Sarif {
+	return &Sarif{w: w}
+}
+
+func (p Sarif) Print(_ context.Context, issues []result.Issue) error {
+	run := sarifRun{Results: make([]sarifResult, 0, len(issues))}
+	run.Tool.Driver.Name = sarifToolName
+
+	rules := map[string]bool{}
+	for i := range issues {
+		issue := &issues[i]
+
+		ruleID := sarifRuleID(issue)
+		rules[ruleID] = true
+
+		res := sarifResult{RuleID: ruleID, Level: sarifLevel(issue.Severity)}
+		res.Message.Text = issue.Text
+
+		var location sarifLocation
+		location.PhysicalLocation.ArtifactLocation.URI = issue.FilePath()
+		location.PhysicalLocation.Region.StartLine = issue.Line()
+		location.PhysicalLocation.Region.StartColumn = issue.Column()
+		res.Locations = []sarifLocation{location}
+
+		run.Results = append(run.Results, res)
+	}
+
+	for id := range rules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: id})
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool {
+		return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID
+	})
+
+	return json.NewEncoder(p.w).Encode(SarifOutput{
+		Version: sarifVersion,
+		Schema:  sarifSchemaURI,
+		Runs:    []sarifRun{run},
+	})
+}
+
+// sarifRuleID returns the rule of the issue, prefixed by its linter: the rules of the linters can have the same ids.
+func sarifRuleID(issue *result.Issue) string {
+	if issue.RuleID != "" && issue.RuleID != issue.FromLinter {
+		return issue.FromLinter + "/" + issue.RuleID
+	}
+	return issue.FromLinter
+}
+
+// sarifLevel maps the severity of the issue to a level of SARIF, the issues without known severity are errors.
+func sarifLevel(severity string) string {
+	level, ok := config.SeverityLevel(severity)
+	if !ok || level >= len(sarifLevels) {
+		return defaultSarifLevel
+	}
+	return sarifLevels[level]
+}
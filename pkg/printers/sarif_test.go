@@ -0,0 +1,60 @@
+package printers
+
+import (
+	"bytes"
+	"context"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestSarif_Print(t *testing.T) {
+	issues := []result.Issue{
+		{
+			FromLinter: "linter-b",
+			Severity:   "warning",
+			Text:       "some issue",
+			Pos:        token.Position{Filename: "path/to/filea.go", Line: 10, Column: 4},
+		},
+		{
+			FromLinter: "linter-a",
+			RuleID:     "A001",
+			Text:       "another issue",
+			Pos:        token.Position{Filename: "path/to/fileb.go", Line: 300},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	err := NewSarif(buf).Print(context.Background(), issues)
+	require.NoError(t, err)
+
+	//nolint:lll
+	expected := `{"version":"2.1.0","$schema":"https://json.schemastore.org/sarif-2.1.0.json","runs":[{"tool":{"driver":{"name":"golangci-lint","rules":[{"id":"linter-a/A001"},{"id":"linter-b"}]}},"results":[{"ruleId":"linter-b","level":"warning","message":{"text":"some issue"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"path/to/filea.go"},"region":{"startLine":10,"startColumn":4}}}]},{"ruleId":"linter-a/A001","level":"error","message":{"text":"another issue"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"path/to/fileb.go"},"region":{"startLine":300}}}]}]}]}
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestSarif_Print_noIssues(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, NewSarif(buf).Print(context.Background(), nil))
+
+	assert.Contains(t, buf.String(), `"results":[]`)
+}
+
+func TestSarifRuleID(t *testing.T) {
+	assert.Equal(t, "gosec/G404", sarifRuleID(&result.Issue{FromLinter: "gosec", RuleID: "G404"}))
+	assert.Equal(t, "dogsled", sarifRuleID(&result.Issue{FromLinter: "dogsled", RuleID: "dogsled"}))
+	assert.Equal(t, "govet", sarifRuleID(&result.Issue{FromLinter: "govet"}))
+}
+
+func TestSarifLevel(t *testing.T) {
+	assert.Equal(t, "error", sarifLevel(""))
+	assert.Equal(t, "error", sarifLevel("unknown"))
+	assert.Equal(t, "note", sarifLevel("info"))
+	assert.Equal(t, "none", sarifLevel("ignore"))
+	assert.Equal(t, "error", sarifLevel("blocker"))
+}
@@ -0,0 +1,187 @@
+// Package sink sends the issues of the run to HTTP endpoints, in addition to the printed reports.
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/printers"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+	defaultBackoff = time.Second
+	maxBackoff     = time.Minute
+)
+
+// Sink POSTs the issues to an HTTP endpoint.
+type Sink struct {
+	rd      *report.Data
+	url     string
+	format  string
+	headers map[string]string
+	retries int
+	backoff time.Duration
+
+	client *http.Client
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+var _ printers.Printer = &Sink{}
+
+// New creates the sink of the configuration, the headers are the expanded ones.
+func New(rd *report.Data, cfg *config.ReportSink, headers map[string]string) *Sink {
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	backoff := cfg.Backoff
+	if backoff == 0 {
+		backoff = defaultBackoff
+	}
+
+	format := cfg.Format
+	if format == "" {
+		format = config.SinkFormatJSON
+	}
+
+	return &Sink{
+		rd:      rd,
+		url:     cfg.URL,
+		format:  format,
+		headers: headers,
+		retries: cfg.Retries,
+		backoff: backoff,
+		client:  &http.Client{Timeout: timeout},
+		sleep:   sleep,
+	}
+}
+
+func (s *Sink) Print(ctx context.Context, issues []result.Issue) error {
+	body, contentType, err := s.encode(issues)
+	if err != nil {
+		return err
+	}
+
+	backoff := s.backoff
+	for attempt := 0; ; attempt++ {
+		retryAfter, err := s.post(ctx, body, contentType)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= s.retries || retryAfter < 0 {
+			return err
+		}
+
+		wait := backoff
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		if err = s.sleep(ctx, wait); err != nil {
+			return err
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (s *Sink) encode(issues []result.Issue) ([]byte, string, error) {
+	if issues == nil {
+		issues = []result.Issue{}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+
+	if s.format == config.SinkFormatNDJSON {
+		for i := range issues {
+			if err := enc.Encode(&issues[i]); err != nil {
+				return nil, "", err
+			}
+		}
+		return buf.Bytes(), "application/x-ndjson", nil
+	}
+
+	if err := enc.Encode(printers.JSONResult{Issues: issues, Report: s.rd}); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "application/json", nil
+}
+
+// post sends the issues: on failure, a negative retryAfter means that the request must not be retried,
+// a positive one is the delay requested by the server.
+func (s *Sink) post(ctx context.Context, body []byte, contentType string) (retryAfter time.Duration, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return -1, err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return -1, err
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return 0, nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("POST %s: %s: %s", s.url, resp.Status, bytes.TrimSpace(msg))
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+		return parseRetryAfter(resp.Header.Get("Retry-After")), err
+	default:
+		return -1, err
+	}
+}
+
+// parseRetryAfter parses the delay in seconds of the Retry-After header, the HTTP dates are ignored.
+func parseRetryAfter(v string) time.Duration {
+	seconds, err := strconv.Atoi(v)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+
+	d := time.Duration(seconds) * time.Second
+	if d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
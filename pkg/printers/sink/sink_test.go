@@ -0,0 +1,171 @@
+package sink
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"go/token"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/printers"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+var testIssues = []result.Issue{
+	{FromLinter: "linter-a", Text: "some issue", Pos: token.Position{Filename: "path/to/filea.go", Line: 10}},
+	{FromLinter: "linter-b", Text: "another issue", Pos: token.Position{Filename: "path/to/fileb.go", Line: 30}},
+}
+
+type request struct {
+	contentType string
+	auth        string
+	body        []byte
+}
+
+// newTestServer replies with the statuses in order, then with 204.
+func newTestServer(t *testing.T, statuses ...int) (*httptest.Server, *[]request) {
+	t.Helper()
+
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		requests = append(requests, request{
+			contentType: req.Header.Get("Content-Type"),
+			auth:        req.Header.Get("Authorization"),
+			body:        body,
+		})
+
+		if len(requests) <= len(statuses) {
+			if statuses[len(requests)-1] == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "7")
+			}
+			w.WriteHeader(statuses[len(requests)-1])
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func newTestSink(cfg *config.ReportSink, headers map[string]string) (*Sink, *[]time.Duration) {
+	s := New(&report.Data{}, cfg, headers)
+
+	var waits []time.Duration
+	s.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	return s, &waits
+}
+
+func TestSink_Print_json(t *testing.T) {
+	server, requests := newTestServer(t)
+
+	s, _ := newTestSink(&config.ReportSink{URL: server.URL}, map[string]string{"Authorization": "Bearer token"})
+	require.NoError(t, s.Print(context.Background(), testIssues))
+
+	require.Len(t, *requests, 1)
+	req := (*requests)[0]
+	assert.Equal(t, "application/json", req.contentType)
+	assert.Equal(t, "Bearer token", req.auth)
+
+	var res printers.JSONResult
+	require.NoError(t, json.Unmarshal(req.body, &res))
+	assert.Len(t, res.Issues, 2)
+	assert.NotNil(t, res.Report)
+}
+
+func TestSink_Print_ndjson(t *testing.T) {
+	server, requests := newTestServer(t)
+
+	s, _ := newTestSink(&config.ReportSink{URL: server.URL, Format: config.SinkFormatNDJSON}, nil)
+	require.NoError(t, s.Print(context.Background(), testIssues))
+
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "application/x-ndjson", (*requests)[0].contentType)
+
+	var linters []string
+	scanner := bufio.NewScanner(bytes.NewReader((*requests)[0].body))
+	for scanner.Scan() {
+		var issue result.Issue
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &issue))
+		linters = append(linters, issue.FromLinter)
+	}
+	assert.Equal(t, []string{"linter-a", "linter-b"}, linters)
+}
+
+func TestSink_Print_retries(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		statuses      []int
+		retries       int
+		expectedCalls int
+		expectedWaits []time.Duration
+		expectedErr   string
+	}{
+		{
+			desc:          "server errors",
+			statuses:      []int{http.StatusServiceUnavailable, http.StatusBadGateway},
+			retries:       3,
+			expectedCalls: 3,
+			expectedWaits: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			desc:          "retry after",
+			statuses:      []int{http.StatusTooManyRequests},
+			retries:       1,
+			expectedCalls: 2,
+			expectedWaits: []time.Duration{7 * time.Second},
+		},
+		{
+			desc:          "client error",
+			statuses:      []int{http.StatusUnauthorized},
+			retries:       3,
+			expectedCalls: 1,
+			expectedErr:   "401 Unauthorized",
+		},
+		{
+			desc:          "retries exhausted",
+			statuses:      []int{http.StatusInternalServerError, http.StatusInternalServerError},
+			retries:       1,
+			expectedCalls: 2,
+			expectedWaits: []time.Duration{time.Second},
+			expectedErr:   "500 Internal Server Error",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			server, requests := newTestServer(t, test.statuses...)
+
+			s, waits := newTestSink(&config.ReportSink{URL: server.URL, Retries: test.retries}, nil)
+			err := s.Print(context.Background(), testIssues)
+
+			if test.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Len(t, *requests, test.expectedCalls)
+			assert.Equal(t, test.expectedWaits, *waits)
+		})
+	}
+}
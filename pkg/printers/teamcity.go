@@ -0,0 +1,79 @@
+package printers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const (
+	teamCityInspectionCategory = "golangci-lint"
+	defaultTeamCitySeverity    = "ERROR"
+)
+
+// teamCitySeverities are the severities of the TeamCity inspections, by level of severity.
+var teamCitySeverities = []string{"INFO", "INFO", "WARNING", "ERROR", "ERROR", "ERROR"}
+
+// TeamCity prints the issues as TeamCity service messages:
+// an inspection type per linter, then the inspections.
+// The inspection types are printed once, even if the issues are printed in several times (streaming output).
+// https://www.jetbrains.com/help/teamcity/service-messages.html#Reporting+Inspections
+type TeamCity struct {
+	w        io.Writer
+	reported map[string]bool
+}
+
+func NewTeamCity(w io.Writer) *TeamCity {
+	return &TeamCity{w: w, reported: map[string]bool{}}
+}
+
+func (p *TeamCity) Print(_ context.Context, issues []result.Issue) error {
+	for ind := range issues {
+		issue := &issues[ind]
+
+		if !p.reported[issue.FromLinter] {
+			p.reported[issue.FromLinter] = true
+
+			_, err := fmt.Fprintf(p.w, "##teamcity[inspectionType id='%s' name='%s' description='%s' category='%s']\n",
+				escapeTeamCity(issue.FromLinter), escapeTeamCity(issue.FromLinter), escapeTeamCity(issue.FromLinter),
+				teamCityInspectionCategory)
+			if err != nil {
+				return err
+			}
+		}
+
+		severity := defaultTeamCitySeverity
+		if level, ok := config.SeverityLevel(issue.Severity); ok && level < len(teamCitySeverities) {
+			severity = teamCitySeverities[level]
+		}
+
+		_, err := fmt.Fprintf(p.w, "##teamcity[inspection typeId='%s' message='%s' file='%s' line='%d' SEVERITY='%s']\n",
+			escapeTeamCity(issue.FromLinter), escapeTeamCity(issue.Text), escapeTeamCity(issue.FilePath()),
+			issue.Line(), severity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var teamCityReplacer = strings.NewReplacer(
+	"|", "||",
+	"'", "|'",
+	"\n", "|n",
+	"\r", "|r",
+	"[", "|[",
+	"]", "|]",
+	"\u0085", "|x",
+	"\u2028", "|l",
+	"\u2029", "|p",
+)
+
+func escapeTeamCity(s string) string {
+	return teamCityReplacer.Replace(s)
+}
@@ -0,0 +1,61 @@
+package printers
+
+import (
+	"bytes"
+	"context"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestTeamCity_Print(t *testing.T) {
+	issues := []result.Issue{
+		{
+			FromLinter: "linter-a",
+			Severity:   "warning",
+			Text:       "some 'issue' [x]",
+			Pos: token.Position{
+				Filename: "path/to/filea.go",
+				Line:     10,
+				Column:   4,
+			},
+		},
+		{
+			FromLinter: "linter-b",
+			Text:       "another issue\nwith | pipes",
+			Pos: token.Position{
+				Filename: "path/to/fileb.go",
+				Line:     300,
+			},
+		},
+		{
+			FromLinter: "linter-a",
+			Severity:   "info",
+			Text:       "third issue",
+			Pos: token.Position{
+				Filename: "path/to/filec.go",
+				Line:     5,
+			},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	printer := NewTeamCity(buf)
+
+	err := printer.Print(context.Background(), issues)
+	require.NoError(t, err)
+
+	//nolint:lll
+	expected := `##teamcity[inspectionType id='linter-a' name='linter-a' description='linter-a' category='golangci-lint']
+##teamcity[inspection typeId='linter-a' message='some |'issue|' |[x|]' file='path/to/filea.go' line='10' SEVERITY='WARNING']
+##teamcity[inspectionType id='linter-b' name='linter-b' description='linter-b' category='golangci-lint']
+##teamcity[inspection typeId='linter-b' message='another issue|nwith || pipes' file='path/to/fileb.go' line='300' SEVERITY='ERROR']
+##teamcity[inspection typeId='linter-a' message='third issue' file='path/to/filec.go' line='5' SEVERITY='INFO']
+`
+
+	assert.Equal(t, expected, buf.String())
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/fatih/color"
 
+	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/report"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
@@ -16,6 +19,8 @@ type Text struct {
 	printIssuedLine bool
 	useColors       bool
 	printLinterName bool
+	printRuleID     bool
+	groupBy         string
 
 	log logutils.Log
 	w   io.Writer
@@ -31,6 +36,18 @@ func NewText(printIssuedLine, useColors, printLinterName bool, log logutils.Log,
 	}
 }
 
+// WithRuleID prints the rule of the issues after the name of their linter, as accepted by the explain command: govet:printf.
+func (p *Text) WithRuleID(printRuleID bool) *Text {
+	p.printRuleID = printRuleID
+	return p
+}
+
+// WithGroupBy groups the issues by file, linter or severity, each group starts with its summary.
+func (p *Text) WithGroupBy(groupBy string) *Text {
+	p.groupBy = groupBy
+	return p
+}
+
 func (p Text) SprintfColored(ca color.Attribute, format string, args ...interface{}) string {
 	if !p.useColors {
 		return fmt.Sprintf(format, args...)
@@ -41,6 +58,20 @@ func (p Text) SprintfColored(ca color.Attribute, format string, args ...interfac
 }
 
 func (p *Text) Print(ctx context.Context, issues []result.Issue) error {
+	if p.groupBy != "" {
+		for _, g := range groupIssues(issues, p.groupBy) {
+			p.printGroupSummary(g)
+			p.printIssues(g.issues)
+		}
+		return nil
+	}
+
+	p.printIssues(issues)
+
+	return nil
+}
+
+func (p *Text) printIssues(issues []result.Issue) {
 	for i := range issues {
 		p.printIssue(&issues[i])
 
@@ -51,14 +82,56 @@ func (p *Text) Print(ctx context.Context, issues []result.Issue) error {
 		p.printSourceCode(&issues[i])
 		p.printUnderLinePointer(&issues[i])
 	}
+}
 
-	return nil
+// printGroupSummary prints the name of the group, its count of issues,
+// and the count of files of a linter or severity, or the linters of a file.
+func (p Text) printGroupSummary(g *issuesGroup) {
+	files, linters := map[string]bool{}, map[string]int{}
+	for i := range g.issues {
+		files[g.issues[i].FilePath()] = true
+		linters[g.issues[i].FromLinter]++
+	}
+
+	var details string
+	switch p.groupBy {
+	case config.GroupByFile:
+		names := make([]string, 0, len(linters))
+		for name := range linters {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		parts := make([]string, 0, len(names))
+		for _, name := range names {
+			parts = append(parts, fmt.Sprintf("%s: %d", name, linters[name]))
+		}
+		details = strings.Join(parts, ", ")
+	default:
+		details = fmt.Sprintf("%d file(s)", len(files))
+	}
+
+	fmt.Fprintf(p.w, "%s %s\n", p.SprintfColored(color.FgCyan, "%s: %d issue(s)", g.name, len(g.issues)),
+		p.SprintfColored(color.Faint, "(%s)", details))
 }
 
 func (p Text) printIssue(i *result.Issue) {
 	text := p.SprintfColored(color.FgRed, "%s", strings.TrimSpace(i.Text))
 	if p.printLinterName {
-		text += fmt.Sprintf(" (%s)", i.FromLinter)
+		name := i.FromLinter
+		if p.printRuleID && i.RuleID != "" && i.RuleID != i.FromLinter {
+			name += ":" + i.RuleID
+		}
+		if len(i.Duplicates) != 0 {
+			name += ", also " + strings.Join(i.Duplicates, ", ")
+		}
+		text += fmt.Sprintf(" (%s)", name)
+	}
+	if i.WarnOnly {
+		text += " " + p.SprintfColored(color.FgYellow, "[warn-only]")
+	}
+	if len(i.Variants) != 0 {
+		text += " " + p.SprintfColored(color.FgCyan, "[%s]", strings.Join(i.Variants, " | "))
 	}
 	pos := p.SprintfColored(color.Bold, "%s:%d", i.FilePath(), i.Line())
 	if i.Pos.Column != 0 {
@@ -92,3 +165,44 @@ func (p Text) printUnderLinePointer(i *result.Issue) {
 
 	fmt.Fprintf(p.w, "%s%s\n", string(prefixRunes), p.SprintfColored(color.FgYellow, "^"))
 }
+
+// PrintFixed prints the issues of the previous run fixed since.
+func (p Text) PrintFixed(fixed []report.IssueRef) {
+	p.printIssueRefs("Fixed since last run", fixed)
+}
+
+// PrintRemovedByCanary prints the issues of the current config not reported with the canary config.
+func (p Text) PrintRemovedByCanary(removed []report.IssueRef) {
+	p.printIssueRefs("Removed by the canary config", removed)
+}
+
+// PrintRunWarnings prints the panics of the linters recovered during the run.
+func (p Text) PrintRunWarnings(warnings []report.RunWarning) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.w, p.SprintfColored(color.FgYellow, "Run warnings: %d linter panic(s), the issues are incomplete", len(warnings)))
+	for _, w := range warnings {
+		where := p.SprintfColored(color.Bold, "%s", w.Linter)
+		if w.Package != "" {
+			where += fmt.Sprintf(" on %s", w.Package)
+		}
+		fmt.Fprintf(p.w, "  %s: %s\n", where, strings.TrimSpace(w.Message))
+	}
+}
+
+func (p Text) printIssueRefs(title string, refs []report.IssueRef) {
+	if len(refs) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.w, p.SprintfColored(color.FgGreen, "%s: %d issue(s)", title, len(refs)))
+	for _, r := range refs {
+		text := strings.TrimSpace(r.Text)
+		if p.printLinterName {
+			text += fmt.Sprintf(" (%s)", r.FromLinter)
+		}
+		fmt.Fprintf(p.w, "  %s: %s\n", p.SprintfColored(color.Bold, "%s:%d", r.Path, r.Line), text)
+	}
+}
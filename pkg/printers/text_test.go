@@ -9,7 +9,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/report"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
@@ -19,6 +21,8 @@ func TestText_Print(t *testing.T) {
 			FromLinter: "linter-a",
 			Severity:   "warning",
 			Text:       "some issue",
+			Duplicates: []string{"linter-c:C1"},
+			WarnOnly:   true,
 			Pos: token.Position{
 				Filename: "path/to/filea.go",
 				Offset:   2,
@@ -28,8 +32,10 @@ func TestText_Print(t *testing.T) {
 		},
 		{
 			FromLinter: "linter-b",
+			RuleID:     "B1",
 			Severity:   "error",
 			Text:       "another issue",
+			Variants:   []string{"linux", "windows,integration"},
 			SourceLines: []string{
 				"func foo() {",
 				"\tfmt.Println(\"bar\")",
@@ -51,12 +57,112 @@ func TestText_Print(t *testing.T) {
 	err := printer.Print(context.Background(), issues)
 	require.NoError(t, err)
 
-	expected := `path/to/filea.go:10:4: some issue (linter-a)
-path/to/fileb.go:300:9: another issue (linter-b)
+	expected := `path/to/filea.go:10:4: some issue (linter-a, also linter-c:C1) [warn-only]
+path/to/fileb.go:300:9: another issue (linter-b) [linux | windows,integration]
 func foo() {
 	fmt.Println("bar")
 }
 `
 
 	assert.Equal(t, expected, buf.String())
+
+	buf.Reset()
+	require.NoError(t, NewText(false, false, true, logutils.NewStderrLog(""), buf).WithRuleID(true).Print(context.Background(), issues[1:]))
+	assert.Equal(t, "path/to/fileb.go:300:9: another issue (linter-b:B1) [linux | windows,integration]\n", buf.String())
+}
+
+func TestText_PrintFixed(t *testing.T) {
+	fixed := []report.IssueRef{
+		{FromLinter: "linter-a", Text: "some issue", Path: "path/to/filea.go", Line: 10},
+		{FromLinter: "linter-b", Text: "another issue", Path: "path/to/fileb.go", Line: 300},
+	}
+
+	buf := new(bytes.Buffer)
+
+	printer := NewText(true, false, true, logutils.NewStderrLog(""), buf)
+	printer.PrintFixed(fixed)
+
+	expected := `Fixed since last run: 2 issue(s)
+  path/to/filea.go:10: some issue (linter-a)
+  path/to/fileb.go:300: another issue (linter-b)
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestText_PrintRunWarnings(t *testing.T) {
+	warnings := []report.RunWarning{
+		{Linter: "linter-a", Package: "example.com/p", Message: "boom\n", Stack: "goroutine 1"},
+		{Linter: "linter-b", Message: "runtime error: index out of range"},
+	}
+
+	buf := new(bytes.Buffer)
+
+	printer := NewText(true, false, true, logutils.NewStderrLog(""), buf)
+	printer.PrintRunWarnings(warnings)
+
+	expected := `Run warnings: 2 linter panic(s), the issues are incomplete
+  linter-a on example.com/p: boom
+  linter-b: runtime error: index out of range
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestText_Print_groupBy(t *testing.T) {
+	issues := []result.Issue{
+		{FromLinter: "linter-b", Severity: "warning", Text: "issue 1", Pos: token.Position{Filename: "a.go", Line: 1, Column: 1}},
+		{FromLinter: "linter-a", Severity: "error", Text: "issue 2", Pos: token.Position{Filename: "b.go", Line: 2, Column: 1}},
+		{FromLinter: "linter-b", Text: "issue 3", Pos: token.Position{Filename: "b.go", Line: 3, Column: 1}},
+	}
+
+	testCases := []struct {
+		groupBy  string
+		expected string
+	}{
+		{
+			groupBy: config.GroupByLinter,
+			expected: `linter-a: 1 issue(s) (1 file(s))
+b.go:2:1: issue 2 (linter-a)
+linter-b: 2 issue(s) (2 file(s))
+a.go:1:1: issue 1 (linter-b)
+b.go:3:1: issue 3 (linter-b)
+`,
+		},
+		{
+			groupBy: config.GroupByFile,
+			expected: `a.go: 1 issue(s) (linter-b: 1)
+a.go:1:1: issue 1 (linter-b)
+b.go: 2 issue(s) (linter-a: 1, linter-b: 1)
+b.go:2:1: issue 2 (linter-a)
+b.go:3:1: issue 3 (linter-b)
+`,
+		},
+		{
+			groupBy: config.GroupBySeverity,
+			expected: `error: 1 issue(s) (1 file(s))
+b.go:2:1: issue 2 (linter-a)
+warning: 1 issue(s) (1 file(s))
+a.go:1:1: issue 1 (linter-b)
+(no severity): 1 issue(s) (1 file(s))
+b.go:3:1: issue 3 (linter-b)
+`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.groupBy, func(t *testing.T) {
+			t.Parallel()
+
+			buf := new(bytes.Buffer)
+
+			printer := NewText(false, false, true, logutils.NewStderrLog(""), buf).WithGroupBy(test.groupBy)
+
+			err := printer.Print(context.Background(), issues)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
 }
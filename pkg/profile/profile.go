@@ -0,0 +1,282 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sort"
+	"sync"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+// The files of the profiling bundle.
+const (
+	CPUFile     = "cpu.pprof"
+	HeapFile    = "heap.pprof"
+	TraceFile   = "trace.out"
+	TimingsFile = "timings.json"
+	// ReportFile is the run report, if no other report file is set.
+	ReportFile = "report.json"
+)
+
+// Timings are the timings and the statistics of a run, in the file TimingsFile of the bundle.
+type Timings struct {
+	Version     string   `json:"version"`
+	GoVersion   string   `json:"goVersion"`
+	Platform    string   `json:"platform"`
+	NumCPU      int      `json:"numCPU"`
+	Concurrency int      `json:"concurrency"`
+	Args        []string `json:"args"`
+
+	DurationMs float64 `json:"durationMs"`
+
+	// Linters are the durations of the linters run by the runner, the go/analysis linters run as one linter.
+	Linters []Timing `json:"linters"`
+	// Stopwatches are the durations of the stages of the run, e.g. the analyzers or the processors.
+	Stopwatches []Stopwatch `json:"stopwatches"`
+
+	Loading    []Timing `json:"loading"`    // by load mode
+	GoCommands []Timing `json:"goCommands"` // the calls of go/packages running the go command
+
+	Cache  Cache   `json:"cache"`
+	Memory *Memory `json:"memory,omitempty"`
+}
+
+// Timing is a duration in milliseconds.
+type Timing struct {
+	Name       string  `json:"name"`
+	DurationMs float64 `json:"durationMs"`
+}
+
+// Stopwatch is the duration of a step and of its stages.
+type Stopwatch struct {
+	Name       string   `json:"name"`
+	DurationMs float64  `json:"durationMs"`
+	Stages     []Timing `json:"stages"`
+}
+
+// Cache are the statistics of the caches: the cache of the files read by the processors, and the cache of the packages.
+type Cache struct {
+	FileEntries   int      `json:"fileEntries"`
+	FileBytes     int64    `json:"fileBytes"`
+	PackageHits   int64    `json:"packageHits"`
+	PackageMisses int64    `json:"packageMisses"`
+	PackageStages []Timing `json:"packageStages"`
+}
+
+// Memory is the memory used by the process, sampled during the run.
+type Memory struct {
+	AvgMB float64 `json:"avgMB"`
+	MaxMB float64 `json:"maxMB"`
+}
+
+// Bundle writes the CPU profile, the heap profile, the trace and the timings of a run into a directory.
+// The timings are recorded from the structured events of the logs.
+type Bundle struct {
+	dir       string
+	cpuFile   *os.File
+	traceFile *os.File
+
+	mu          sync.Mutex
+	timings     Timings
+	stopwatches map[string]*Stopwatch
+	loading     map[string]float64
+}
+
+// Start creates the directory and starts the CPU profile and the trace.
+func Start(dir string) (*Bundle, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("can't create the profile directory: %w", err)
+	}
+
+	b := &Bundle{
+		dir:         dir,
+		stopwatches: map[string]*Stopwatch{},
+		loading:     map[string]float64{},
+	}
+
+	var err error
+	b.cpuFile, err = os.Create(filepath.Join(dir, CPUFile))
+	if err != nil {
+		return nil, err
+	}
+	if err = pprof.StartCPUProfile(b.cpuFile); err != nil {
+		_ = b.cpuFile.Close()
+		return nil, fmt.Errorf("can't start CPU profiling: %w", err)
+	}
+
+	b.traceFile, err = os.Create(filepath.Join(dir, TraceFile))
+	if err == nil {
+		err = trace.Start(b.traceFile)
+	}
+	if err != nil {
+		pprof.StopCPUProfile()
+		_ = b.cpuFile.Close()
+		return nil, fmt.Errorf("can't start tracing: %w", err)
+	}
+
+	logutils.SetEventRecorder(b)
+
+	return b, nil
+}
+
+// RecordEvent records the timings of the events of the logs.
+func (b *Bundle) RecordEvent(event string, fields logutils.Fields) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	duration, _ := fields["duration_ms"].(float64)
+
+	switch event {
+	case "timing":
+		name, _ := fields["stopwatch"].(string)
+		sw, ok := b.stopwatches[name]
+		if !ok {
+			sw = &Stopwatch{Name: name}
+			b.stopwatches[name] = sw
+		}
+		sw.DurationMs += duration
+
+		stages, _ := fields["stages_ms"].(map[string]float64)
+		sw.Stages = addTimings(sw.Stages, stages)
+
+	case "packages_loading":
+		mode, _ := fields["mode"].(string)
+		b.loading[mode] += duration
+
+	case "go_command":
+		command, _ := fields["command"].(string)
+		b.timings.GoCommands = append(b.timings.GoCommands, Timing{Name: command, DurationMs: duration})
+
+	case "memory":
+		avg, _ := fields["avg_mb"].(float64)
+		maxMB, _ := fields["max_mb"].(float64)
+		b.timings.Memory = &Memory{AvgMB: avg, MaxMB: maxMB}
+
+	case "execution":
+		b.timings.DurationMs = duration
+	}
+}
+
+// Stop stops the profiles, and writes the heap profile and the timings completed by fill.
+func (b *Bundle) Stop(fill func(t *Timings)) error {
+	logutils.SetEventRecorder(nil)
+
+	pprof.StopCPUProfile()
+	trace.Stop()
+
+	err := b.cpuFile.Close()
+	if cerr := b.traceFile.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := b.writeHeapProfile(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.timings
+	t.GoVersion = runtime.Version()
+	t.Platform = runtime.GOOS + "/" + runtime.GOARCH
+	t.NumCPU = runtime.NumCPU()
+
+	for _, sw := range b.stopwatches {
+		t.Stopwatches = append(t.Stopwatches, *sw)
+	}
+	for mode, d := range b.loading {
+		t.Loading = append(t.Loading, Timing{Name: mode, DurationMs: d})
+	}
+
+	if fill != nil {
+		fill(&t)
+	}
+
+	t.sort()
+
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(b.dir, TimingsFile), data, 0o644)
+}
+
+func (b *Bundle) writeHeapProfile() error {
+	f, err := os.Create(filepath.Join(b.dir, HeapFile))
+	if err != nil {
+		return err
+	}
+
+	runtime.GC() // up-to-date statistics
+	err = pprof.WriteHeapProfile(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Read reads the timings of the bundle in the directory.
+func Read(dir string) (*Timings, error) {
+	data, err := os.ReadFile(filepath.Join(dir, TimingsFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var t Timings
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("can't parse %s: %w", TimingsFile, err)
+	}
+	return &t, nil
+}
+
+// sort sorts the timings by decreasing duration.
+func (t *Timings) sort() {
+	sortTimings(t.Linters)
+	sortTimings(t.Loading)
+	sortTimings(t.GoCommands)
+	sortTimings(t.Cache.PackageStages)
+
+	sort.Slice(t.Stopwatches, func(i, j int) bool {
+		return t.Stopwatches[i].Name < t.Stopwatches[j].Name
+	})
+	for i := range t.Stopwatches {
+		sortTimings(t.Stopwatches[i].Stages)
+	}
+}
+
+func sortTimings(timings []Timing) {
+	sort.SliceStable(timings, func(i, j int) bool {
+		if timings[i].DurationMs != timings[j].DurationMs {
+			return timings[i].DurationMs > timings[j].DurationMs
+		}
+		return timings[i].Name < timings[j].Name
+	})
+}
+
+// addTimings adds the durations by name to the timings.
+func addTimings(timings []Timing, durations map[string]float64) []Timing {
+	for name, d := range durations {
+		found := false
+		for i := range timings {
+			if timings[i].Name == name {
+				timings[i].DurationMs += d
+				found = true
+				break
+			}
+		}
+		if !found {
+			timings = append(timings, Timing{Name: name, DurationMs: d})
+		}
+	}
+	return timings
+}
@@ -0,0 +1,87 @@
+package profile
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func TestBundle(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "profile")
+
+	b, err := Start(dir)
+	require.NoError(t, err)
+
+	logutils.RecordEvent("timing", logutils.Fields{
+		"stopwatch":   "analyzers",
+		"duration_ms": 30.0,
+		"stages_ms":   map[string]float64{"buildir": 20, "printf": 10},
+	})
+	logutils.RecordEvent("timing", logutils.Fields{
+		"stopwatch":   "analyzers",
+		"duration_ms": 5.0,
+		"stages_ms":   map[string]float64{"printf": 5},
+	})
+	logutils.RecordEvent("packages_loading", logutils.Fields{"mode": "575 (files)", "duration_ms": 100.0})
+	logutils.RecordEvent("go_command", logutils.Fields{"command": "go list ./...", "duration_ms": 80.0})
+	logutils.RecordEvent("memory", logutils.Fields{"avg_mb": 10.5, "max_mb": 20.0})
+	logutils.RecordEvent("execution", logutils.Fields{"duration_ms": 200.0})
+
+	err = b.Stop(func(t *Timings) {
+		t.Version = "1.2.3"
+		t.Linters = []Timing{{Name: "lll", DurationMs: 1}, {Name: "gocritic", DurationMs: 12}}
+		t.Cache.PackageHits = 4
+	})
+	require.NoError(t, err)
+
+	// the events after the end of the bundle aren't recorded
+	logutils.RecordEvent("execution", logutils.Fields{"duration_ms": 1.0})
+
+	for _, name := range []string{CPUFile, HeapFile, TraceFile, TimingsFile} {
+		assert.FileExists(t, filepath.Join(dir, name))
+	}
+
+	timings, err := Read(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", timings.Version)
+	assert.Equal(t, 200.0, timings.DurationMs)
+	assert.Equal(t, []Timing{{Name: "gocritic", DurationMs: 12}, {Name: "lll", DurationMs: 1}}, timings.Linters)
+	assert.Equal(t, []Stopwatch{{
+		Name:       "analyzers",
+		DurationMs: 35,
+		Stages:     []Timing{{Name: "buildir", DurationMs: 20}, {Name: "printf", DurationMs: 15}},
+	}}, timings.Stopwatches)
+	assert.Equal(t, []Timing{{Name: "575 (files)", DurationMs: 100}}, timings.Loading)
+	assert.Equal(t, []Timing{{Name: "go list ./...", DurationMs: 80}}, timings.GoCommands)
+	assert.Equal(t, &Memory{AvgMB: 10.5, MaxMB: 20}, timings.Memory)
+	assert.Equal(t, int64(4), timings.Cache.PackageHits)
+}
+
+func TestRead_missing(t *testing.T) {
+	_, err := Read(t.TempDir())
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestTimings_PrintSummary(t *testing.T) {
+	timings := &Timings{
+		Version:    "1.2.3",
+		DurationMs: float64(1500 * time.Millisecond / time.Millisecond),
+		Linters:    []Timing{{Name: "gocritic", DurationMs: 900}, {Name: "lll", DurationMs: 12}, {Name: "misspell", DurationMs: 3}},
+	}
+
+	buf := new(bytes.Buffer)
+	timings.PrintSummary(buf, 2)
+
+	out := buf.String()
+	assert.Contains(t, out, "Execution: 1.5s\n")
+	assert.Contains(t, out, "Linters:\n       900ms  gocritic\n        12ms  lll\n  ... and 1 more\n")
+	assert.NotContains(t, out, "Go commands:")
+}
@@ -0,0 +1,56 @@
+package profile
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// PrintSummary prints the biggest costs of the run: the top n timings of each kind, and the statistics.
+func (t *Timings) PrintSummary(w io.Writer, n int) {
+	fmt.Fprintf(w, "golangci-lint %s (%s, %s, %d CPUs, concurrency %d)\n",
+		t.Version, t.GoVersion, t.Platform, t.NumCPU, t.Concurrency)
+	if len(t.Args) != 0 {
+		fmt.Fprintf(w, "Arguments: %s\n", strings.Join(t.Args, " "))
+	}
+	fmt.Fprintf(w, "Execution: %s\n", formatMs(t.DurationMs))
+	if t.Memory != nil {
+		fmt.Fprintf(w, "Memory: avg %.1fMB, max %.1fMB\n", t.Memory.AvgMB, t.Memory.MaxMB)
+	}
+
+	printTimings(w, "Packages loading", t.Loading, n)
+	printTimings(w, "Go commands", t.GoCommands, n)
+	printTimings(w, "Linters", t.Linters, n)
+
+	for _, sw := range t.Stopwatches {
+		printTimings(w, fmt.Sprintf("Stages of %s (%s)", sw.Name, formatMs(sw.DurationMs)), sw.Stages, n)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Cache:")
+	fmt.Fprintf(w, "  files: %d entries, %d bytes\n", t.Cache.FileEntries, t.Cache.FileBytes)
+	fmt.Fprintf(w, "  packages: %d hits, %d misses\n", t.Cache.PackageHits, t.Cache.PackageMisses)
+	for i := 0; i < len(t.Cache.PackageStages) && i < n; i++ {
+		fmt.Fprintf(w, "  %10s  %s\n", formatMs(t.Cache.PackageStages[i].DurationMs), t.Cache.PackageStages[i].Name)
+	}
+}
+
+func printTimings(w io.Writer, title string, timings []Timing, n int) {
+	if len(timings) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s:\n", title)
+	for i := 0; i < len(timings) && i < n; i++ {
+		fmt.Fprintf(w, "  %10s  %s\n", formatMs(timings[i].DurationMs), timings[i].Name)
+	}
+	if len(timings) > n {
+		fmt.Fprintf(w, "  ... and %d more\n", len(timings)-n)
+	}
+}
+
+func formatMs(ms float64) string {
+	return time.Duration(ms * float64(time.Millisecond)).Round(time.Millisecond).String()
+}
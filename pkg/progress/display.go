@@ -0,0 +1,324 @@
+// Package progress shows the live status of a run on a terminal.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The stages of a run.
+const (
+	StageLoad    = "load"
+	StageAnalyze = "analyze"
+	StageProcess = "process"
+	StagePrint   = "print"
+)
+
+var stages = []string{StageLoad, StageAnalyze, StageProcess, StagePrint}
+
+const (
+	refreshInterval = 100 * time.Millisecond
+	// maxLintersWidth is the width of the names of the linters of a line, the other names are counted.
+	maxLintersWidth = 60
+	// maxDoneLinters is the count of the finished linters shown, the last ones.
+	maxDoneLinters = 5
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+type stageStatus struct {
+	startedAt time.Time
+	duration  time.Duration
+	done      bool
+	detail    string
+}
+
+type linterStatus struct {
+	names     []string
+	startedAt time.Time
+	duration  time.Duration
+	done      bool
+}
+
+// Display is a multi-line status of the run redrawn in place: the stages, the running and finished linters,
+// the analyzed packages and the estimated remaining time of the running linter.
+// The logs written with Writer are printed above the status.
+type Display struct {
+	w   io.Writer
+	now func() time.Time
+
+	mu      sync.Mutex
+	stages  map[string]*stageStatus
+	linters []*linterStatus
+	// packagesDone and packagesTotal count the packages analyzed by the running linter.
+	packagesDone, packagesTotal int
+
+	frame int
+	lines int // the count of lines drawn, erased by the next draw
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New creates the display writing to the terminal w.
+func New(w io.Writer) *Display {
+	return &Display{
+		w:      w,
+		now:    time.Now,
+		stages: map[string]*stageStatus{},
+	}
+}
+
+// Start starts the periodic redraw.
+func (d *Display) Start() {
+	d.stop, d.done = make(chan struct{}), make(chan struct{})
+
+	go func() {
+		defer close(d.done)
+
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-d.stop:
+				return
+			case <-ticker.C:
+				d.mu.Lock()
+				d.frame++
+				d.draw()
+				d.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// Stop stops the redraw, erases the status and prints the durations of the finished stages.
+func (d *Display) Stop() {
+	if d.stop != nil {
+		close(d.stop)
+		<-d.done
+		d.stop = nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.erase()
+
+	var parts []string
+	for _, name := range stages {
+		if s := d.stages[name]; s != nil && s.done {
+			parts = append(parts, fmt.Sprintf("%s %s", name, formatDuration(s.duration)))
+		}
+	}
+	if len(parts) != 0 {
+		fmt.Fprintf(d.w, "%s\n", strings.Join(parts, ", "))
+	}
+}
+
+// StartStage marks the stage as running, and the previous running stage as finished.
+func (d *Display) StartStage(name string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	for _, s := range d.stages {
+		if !s.done {
+			s.done, s.duration = true, now.Sub(s.startedAt)
+		}
+	}
+	d.stages[name] = &stageStatus{startedAt: now}
+}
+
+// PackagesLoaded sets the count of loaded packages.
+func (d *Display) PackagesLoaded(count int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if s := d.stages[StageLoad]; s != nil {
+		s.detail = fmt.Sprintf("%d packages", count)
+	}
+}
+
+// LinterStarted marks the linters run together as running.
+func (d *Display) LinterStarted(names []string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.linters = append(d.linters, &linterStatus{names: names, startedAt: d.now()})
+	d.packagesDone, d.packagesTotal = 0, 0
+}
+
+// LinterDone marks the linters run together as finished.
+func (d *Display) LinterDone(names []string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, l := range d.linters {
+		if !l.done && sameNames(l.names, names) {
+			l.done, l.duration = true, d.now().Sub(l.startedAt)
+			break
+		}
+	}
+}
+
+// PackageAnalyzed counts a package analyzed by the running linter, out of total.
+func (d *Display) PackageAnalyzed(total int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.packagesDone++
+	d.packagesTotal = total
+}
+
+// Writer returns a writer printing above the status: the logs don't overwrite it.
+func (d *Display) Writer() io.Writer {
+	return displayWriter{d: d}
+}
+
+type displayWriter struct {
+	d *Display
+}
+
+func (w displayWriter) Write(p []byte) (int, error) {
+	w.d.mu.Lock()
+	defer w.d.mu.Unlock()
+
+	w.d.erase()
+	n, err := w.d.w.Write(p)
+	w.d.draw()
+	return n, err
+}
+
+// draw erases the previous status and draws the current one.
+func (d *Display) draw() {
+	var b strings.Builder
+	lines := 0
+	line := func(format string, args ...interface{}) {
+		fmt.Fprintf(&b, format, args...)
+		b.WriteString("\x1b[K\n") // erase the rest of the previous line
+		lines++
+	}
+
+	now := d.now()
+	for _, name := range stages {
+		s := d.stages[name]
+		switch {
+		case s == nil:
+			line("  %-8s", name)
+		case s.done:
+			line("✓ %-8s %6s  %s", name, formatDuration(s.duration), s.detail)
+		default:
+			line("%s %-8s %6s  %s", spinnerFrames[d.frame%len(spinnerFrames)], name,
+				formatDuration(now.Sub(s.startedAt)), d.runningDetail(name, now))
+		}
+
+		if name == StageAnalyze && s != nil {
+			for _, l := range d.visibleLinters() {
+				if l.done {
+					line("    ✓ %-60s %6s", formatNames(l.names), formatDuration(l.duration))
+				} else {
+					line("    %s %-60s %6s", spinnerFrames[d.frame%len(spinnerFrames)], formatNames(l.names),
+						formatDuration(now.Sub(l.startedAt)))
+				}
+			}
+		}
+	}
+
+	d.erase()
+	fmt.Fprint(d.w, b.String())
+	d.lines = lines
+}
+
+// erase moves the cursor to the beginning of the status and erases it.
+func (d *Display) erase() {
+	if d.lines == 0 {
+		return
+	}
+	fmt.Fprintf(d.w, "\x1b[%dA\x1b[J", d.lines)
+	d.lines = 0
+}
+
+func (d *Display) runningDetail(stage string, now time.Time) string {
+	if stage != StageAnalyze {
+		return ""
+	}
+
+	done := 0
+	for _, l := range d.linters {
+		if l.done {
+			done++
+		}
+	}
+	detail := fmt.Sprintf("%d/%d linters", done, len(d.linters))
+
+	if d.packagesTotal == 0 {
+		return detail
+	}
+
+	detail += fmt.Sprintf(", %d/%d packages", d.packagesDone, d.packagesTotal)
+
+	running := d.linters[len(d.linters)-1]
+	if d.packagesDone != 0 && !running.done {
+		elapsed := now.Sub(running.startedAt)
+		eta := elapsed * time.Duration(d.packagesTotal-d.packagesDone) / time.Duration(d.packagesDone)
+		detail += fmt.Sprintf(", ETA %s", formatDuration(eta))
+	}
+	return detail
+}
+
+// visibleLinters returns the last finished linters, and the running ones.
+func (d *Display) visibleLinters() []*linterStatus {
+	var done, running []*linterStatus
+	for _, l := range d.linters {
+		if l.done {
+			done = append(done, l)
+		} else {
+			running = append(running, l)
+		}
+	}
+	if len(done) > maxDoneLinters {
+		done = done[len(done)-maxDoneLinters:]
+	}
+	return append(done, running...)
+}
+
+// formatNames joins the names of the linters, the names beyond the maximum width are counted.
+func formatNames(names []string) string {
+	var b strings.Builder
+	for i, name := range names {
+		sep := ""
+		if i != 0 {
+			sep = ", "
+		}
+		if b.Len()+len(sep)+len(name) > maxLintersWidth-10 && i != len(names)-1 {
+			fmt.Fprintf(&b, "%s+%d more", sep, len(names)-i)
+			break
+		}
+		b.WriteString(sep + name)
+	}
+	return b.String()
+}
+
+func formatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return d.Round(time.Second).String()
+}
+
+func sameNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
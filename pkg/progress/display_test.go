@@ -0,0 +1,107 @@
+package progress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+func newTestDisplay() (*Display, *bytes.Buffer, *fakeClock) {
+	buf := &bytes.Buffer{}
+	clock := &fakeClock{t: time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)}
+
+	d := New(buf)
+	d.now = clock.now
+	return d, buf, clock
+}
+
+func TestDisplay_Stop(t *testing.T) {
+	d, buf, clock := newTestDisplay()
+
+	d.StartStage(StageLoad)
+	clock.advance(1200 * time.Millisecond)
+	d.StartStage(StageAnalyze)
+	clock.advance(3400 * time.Millisecond)
+	d.StartStage(StageProcess)
+	clock.advance(100 * time.Millisecond)
+	d.StartStage(StagePrint)
+
+	d.Stop()
+
+	assert.Equal(t, "load 1.2s, analyze 3.4s, process 0.1s\n", buf.String())
+}
+
+func TestDisplay_draw(t *testing.T) {
+	d, buf, clock := newTestDisplay()
+
+	d.StartStage(StageLoad)
+	clock.advance(time.Second)
+	d.PackagesLoaded(4)
+	d.StartStage(StageAnalyze)
+
+	d.LinterStarted([]string{"gofmt"})
+	clock.advance(time.Second)
+	d.LinterDone([]string{"gofmt"})
+
+	d.LinterStarted([]string{"govet", "errcheck"})
+	d.PackageAnalyzed(4)
+	clock.advance(2 * time.Second)
+
+	d.draw()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\x1b[K\n"), "\x1b[K\n")
+	assert.Equal(t, []string{
+		"✓ load       1.0s  4 packages",
+		"⠋ analyze    3.0s  1/2 linters, 1/4 packages, ETA 6.0s",
+		"    ✓ gofmt                                                          1.0s",
+		"    ⠋ govet, errcheck                                                2.0s",
+		"  process ",
+		"  print   ",
+	}, lines)
+	assert.Equal(t, 6, d.lines)
+
+	buf.Reset()
+	_, err := d.Writer().Write([]byte("WARN something\n"))
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(buf.String(), "\x1b[6A\x1b[JWARN something\n✓ load"), buf.String())
+}
+
+func TestDisplay_visibleLinters(t *testing.T) {
+	d, _, _ := newTestDisplay()
+
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		d.LinterStarted([]string{name})
+		d.LinterDone([]string{name})
+	}
+	d.LinterStarted([]string{"h"})
+
+	var names []string
+	for _, l := range d.visibleLinters() {
+		names = append(names, l.names[0])
+	}
+	assert.Equal(t, []string{"c", "d", "e", "f", "g", "h"}, names)
+}
+
+func TestFormatNames(t *testing.T) {
+	assert.Equal(t, "govet", formatNames([]string{"govet"}))
+	assert.Equal(t, "govet, errcheck", formatNames([]string{"govet", "errcheck"}))
+
+	names := []string{"asciicheck", "bodyclose", "errcheck", "gosimple", "govet", "ineffassign", "staticcheck", "unused"}
+	assert.Equal(t, "asciicheck, bodyclose, errcheck, gosimple, govet, +3 more", formatNames(names))
+}
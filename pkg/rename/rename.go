@@ -0,0 +1,350 @@
+// Package rename finds all the identifiers to replace to rename a declaration,
+// with the type information of the analyzed packages.
+package rename
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Renamer renames the declarations of the analyzed packages.
+// The references from packages which aren't analyzed, e.g. the other modules, can't be renamed.
+type Renamer struct {
+	pkgs []*packages.Package
+}
+
+func New(pkgs []*packages.Package) *Renamer {
+	var withTypes []*packages.Package
+	for _, pkg := range pkgs {
+		if pkg.TypesInfo != nil && pkg.Types != nil && pkg.Fset != nil {
+			withTypes = append(withTypes, pkg)
+		}
+	}
+
+	return &Renamer{pkgs: withTypes}
+}
+
+// objectKey identifies an object in all the variants of its package, e.g. with and without the tests.
+type objectKey struct {
+	pos  token.Position
+	name string
+}
+
+type reference struct {
+	pkg   *packages.Package
+	ident *ast.Ident
+}
+
+// Rename returns the positions of the identifiers to replace by newName,
+// to rename the declaration of the identifier containing pos, including the identifier itself.
+// It fails if the identifier isn't found, if its declaration can't be renamed,
+// or if newName conflicts with another declaration.
+func (r *Renamer) Rename(pos token.Position, newName string) ([]token.Position, error) {
+	if !token.IsIdentifier(newName) {
+		return nil, fmt.Errorf("%q is not an identifier", newName)
+	}
+
+	pkg, ident := r.findIdent(pos)
+	if ident == nil {
+		return nil, fmt.Errorf("no identifier at %s", pos)
+	}
+
+	obj := pkg.TypesInfo.ObjectOf(ident)
+	if obj == nil {
+		return nil, fmt.Errorf("no declaration for %s", ident.Name)
+	}
+
+	if err := checkRenamable(obj); err != nil {
+		return nil, err
+	}
+
+	key := keyOf(pkg.Fset, obj)
+	refs := r.findReferences(key)
+	if !declaredIn(refs, key) {
+		return nil, fmt.Errorf("%s is declared outside of the analyzed packages", ident.Name)
+	}
+
+	if err := r.checkConflicts(obj, key, refs, newName); err != nil {
+		return nil, err
+	}
+
+	seen := map[token.Position]bool{}
+	var ret []token.Position
+	for _, ref := range refs {
+		p := ref.pkg.Fset.Position(ref.ident.Pos())
+		if !seen[p] {
+			seen[p] = true
+			ret = append(ret, p)
+		}
+	}
+
+	return ret, nil
+}
+
+// Ident returns the name and the position of the identifier containing the position.
+func (r *Renamer) Ident(pos token.Position) (string, token.Position, bool) {
+	pkg, ident := r.findIdent(pos)
+	if ident == nil {
+		return "", token.Position{}, false
+	}
+
+	return ident.Name, pkg.Fset.Position(ident.Pos()), true
+}
+
+// findIdent returns the identifier containing the position:
+// its file name is absolute or relative to the working directory.
+func (r *Renamer) findIdent(pos token.Position) (*packages.Package, *ast.Ident) {
+	filename, err := filepath.Abs(pos.Filename)
+	if err != nil {
+		return nil, nil
+	}
+
+	for _, pkg := range r.pkgs {
+		for _, f := range pkg.Syntax {
+			tf := pkg.Fset.File(f.Pos())
+			if tf == nil || filepath.Clean(tf.Name()) != filename {
+				continue
+			}
+
+			var found *ast.Ident
+			ast.Inspect(f, func(n ast.Node) bool {
+				ident, ok := n.(*ast.Ident)
+				if found != nil {
+					return false
+				}
+				if ok {
+					p := pkg.Fset.Position(ident.Pos())
+					if p.Line == pos.Line && p.Column <= pos.Column && pos.Column < p.Column+len(ident.Name) {
+						found = ident
+					}
+				}
+				return true
+			})
+
+			if found != nil {
+				return pkg, found
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func checkRenamable(obj types.Object) error {
+	switch o := obj.(type) {
+	case *types.PkgName:
+		return fmt.Errorf("%s is an imported package", o.Name())
+	case *types.Builtin, *types.Nil, *types.Label:
+		return fmt.Errorf("%s can't be renamed", o.Name())
+	case *types.Func:
+		if o.Type().(*types.Signature).Recv() != nil {
+			return fmt.Errorf("%s is a method: renaming it can break the implementation of interfaces", o.Name())
+		}
+	case *types.Var:
+		if o.Embedded() {
+			return fmt.Errorf("%s is an embedded field", o.Name())
+		}
+	}
+
+	if obj.Pkg() == nil {
+		return fmt.Errorf("%s is predeclared", obj.Name())
+	}
+
+	return nil
+}
+
+func keyOf(fset *token.FileSet, obj types.Object) objectKey {
+	return objectKey{pos: fset.Position(obj.Pos()), name: obj.Name()}
+}
+
+// findReferences returns the declaration and the uses of the object in all the packages.
+func (r *Renamer) findReferences(key objectKey) []reference {
+	var refs []reference
+	for _, pkg := range r.pkgs {
+		for _, idents := range []map[*ast.Ident]types.Object{pkg.TypesInfo.Defs, pkg.TypesInfo.Uses} {
+			for ident, obj := range idents {
+				if obj != nil && obj.Name() == key.name && keyOf(pkg.Fset, obj) == key {
+					refs = append(refs, reference{pkg: pkg, ident: ident})
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
+func declaredIn(refs []reference, key objectKey) bool {
+	for _, ref := range refs {
+		if ref.pkg.Fset.Position(ref.ident.Pos()) == key.pos {
+			return true
+		}
+	}
+	return false
+}
+
+// checkConflicts checks that the renamed references still resolve to the object,
+// and that the references to other objects named newName aren't captured by the object.
+func (r *Renamer) checkConflicts(obj types.Object, key objectKey, refs []reference, newName string) error {
+	if obj.Exported() && !token.IsExported(newName) {
+		for _, ref := range refs {
+			if ref.pkg.Types.Path() != obj.Pkg().Path() {
+				return fmt.Errorf("%s is used by the package %s: it can't be unexported", obj.Name(), ref.pkg.PkgPath)
+			}
+		}
+	}
+
+	if v, ok := obj.(*types.Var); ok && v.IsField() {
+		return r.checkFieldConflict(key, newName)
+	}
+
+	for _, pkg := range r.pkgs {
+		if pkg.Types.Path() != obj.Pkg().Path() {
+			continue // only qualified references
+		}
+
+		// The scope of the object in this variant of the package.
+		declScope := scopeAt(pkg, key.pos)
+		if declScope == nil {
+			continue
+		}
+
+		if other := declScope.Lookup(newName); other != nil {
+			return fmt.Errorf("%s conflicts with the declaration of %s at %s", obj.Name(), newName, pkg.Fset.Position(other.Pos()))
+		}
+
+		if declScope == pkg.Types.Scope() {
+			for i := 0; i < declScope.NumChildren(); i++ {
+				if other := declScope.Child(i).Lookup(newName); other != nil {
+					return fmt.Errorf("%s conflicts with the import %s at %s", obj.Name(), newName, pkg.Fset.Position(other.Pos()))
+				}
+			}
+		}
+
+		for _, ref := range refs {
+			if ref.pkg != pkg {
+				continue
+			}
+
+			scope := pkg.Types.Scope().Innermost(ref.ident.Pos())
+			if scope == nil {
+				continue
+			}
+
+			_, other := scope.LookupParent(newName, ref.ident.Pos())
+			if other != nil && isInside(other.Parent(), declScope) {
+				return fmt.Errorf("%s would be shadowed by the declaration of %s at %s",
+					obj.Name(), newName, pkg.Fset.Position(other.Pos()))
+			}
+		}
+
+		for ident, other := range pkg.TypesInfo.Uses {
+			if ident.Name != newName || other == nil || isSelected(pkg, other) {
+				continue
+			}
+
+			if !isInside(other.Parent(), declScope) && isInside(pkg.Types.Scope().Innermost(ident.Pos()), declScope) {
+				return fmt.Errorf("%s would capture the use of %s at %s", obj.Name(), newName, pkg.Fset.Position(ident.Pos()))
+			}
+		}
+	}
+
+	return nil
+}
+
+// isInside checks if the scope is the parent scope or one of its descendants.
+func isInside(scope, parent *types.Scope) bool {
+	for s := scope; s != nil; s = s.Parent() {
+		if s == parent {
+			return true
+		}
+	}
+	return false
+}
+
+// isSelected checks if the object is only used through selectors: fields, methods and declarations of other packages.
+func isSelected(pkg *packages.Package, obj types.Object) bool {
+	switch o := obj.(type) {
+	case *types.Var:
+		if o.IsField() {
+			return true
+		}
+	case *types.Func:
+		if o.Type().(*types.Signature).Recv() != nil {
+			return true
+		}
+	}
+
+	return obj.Pkg() != nil && obj.Pkg() != pkg.Types && obj.Parent() == obj.Pkg().Scope()
+}
+
+// scopeAt returns the scope declaring the object at the position in the package.
+func scopeAt(pkg *packages.Package, pos token.Position) *types.Scope {
+	for ident, obj := range pkg.TypesInfo.Defs {
+		if obj != nil && obj.Parent() != nil && pkg.Fset.Position(ident.Pos()) == pos {
+			return obj.Parent()
+		}
+	}
+	return nil
+}
+
+// checkFieldConflict checks that the types having the field don't have a field or a method named newName.
+func (r *Renamer) checkFieldConflict(key objectKey, newName string) error {
+	for _, pkg := range r.pkgs {
+		var candidates []types.Type
+		for _, obj := range pkg.TypesInfo.Defs {
+			if tn, ok := obj.(*types.TypeName); ok {
+				candidates = append(candidates, tn.Type())
+			}
+		}
+		for _, tv := range pkg.TypesInfo.Types {
+			candidates = append(candidates, tv.Type)
+		}
+
+		for _, typ := range candidates {
+			st, ok := typ.Underlying().(*types.Struct)
+			if !ok || !hasField(pkg.Fset, st, key) {
+				continue
+			}
+
+			if obj, _, _ := types.LookupFieldOrMethod(typ, true, pkg.Types, newName); obj != nil {
+				return fmt.Errorf("%s conflicts with the field or method %s of %s", key.name, newName, typ)
+			}
+		}
+	}
+
+	return nil
+}
+
+func hasField(fset *token.FileSet, st *types.Struct, key objectKey) bool {
+	for i := 0; i < st.NumFields(); i++ {
+		if keyOf(fset, st.Field(i)) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadMode is the mode to load the packages of the renamer.
+const LoadMode = packages.NeedName | packages.NeedFiles | packages.NeedImports |
+	packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo
+
+// Load loads the packages of the patterns from the sources, to rename their declarations.
+func Load(patterns []string, tests bool, buildTags []string) (*Renamer, error) {
+	cfg := &packages.Config{Mode: LoadMode, Tests: tests}
+	if len(buildTags) != 0 {
+		cfg.BuildFlags = []string{"-tags", strings.Join(buildTags, " ")}
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(pkgs), nil
+}
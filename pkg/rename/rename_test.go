@@ -0,0 +1,167 @@
+package rename
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+const srcA = `package a
+
+var userId = 1
+
+type Config struct {
+	HostName string
+	Port     int
+}
+
+func (c Config) Addr() string { return c.HostName }
+
+func Lookup(key string) int {
+	if key == "" {
+		return userId
+	}
+	goodName := 2
+	return userId + goodName
+}
+
+func Shadowing() int {
+	count := 1
+	{
+		total := 2
+		return count + total
+	}
+}
+`
+
+const srcB = `package b
+
+import "example.com/a"
+
+func Use() int {
+	c := a.Config{HostName: "localhost"}
+	_ = c.HostName
+	return a.Lookup("x")
+}
+`
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
+
+func newTestPackage(t *testing.T, fset *token.FileSet, imp types.Importer, path, file, src string) *packages.Package {
+	t.Helper()
+
+	f, err := parser.ParseFile(fset, file, src, 0)
+	require.NoError(t, err)
+
+	info := &types.Info{
+		Types:  map[ast.Expr]types.TypeAndValue{},
+		Defs:   map[*ast.Ident]types.Object{},
+		Uses:   map[*ast.Ident]types.Object{},
+		Scopes: map[ast.Node]*types.Scope{},
+	}
+
+	pkg, err := (&types.Config{Importer: imp}).Check(path, fset, []*ast.File{f}, info)
+	require.NoError(t, err)
+
+	return &packages.Package{PkgPath: path, Fset: fset, Syntax: []*ast.File{f}, Types: pkg, TypesInfo: info}
+}
+
+func newTestRenamer(t *testing.T) (*Renamer, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	fset := token.NewFileSet()
+
+	a := newTestPackage(t, fset, importer.Default(), "example.com/a", filepath.Join(dir, "a", "a.go"), srcA)
+	b := newTestPackage(t, fset, importerFunc(func(path string) (*types.Package, error) {
+		return a.Types, nil
+	}), "example.com/b", filepath.Join(dir, "b", "b.go"), srcB)
+
+	return New([]*packages.Package{a, b}), dir
+}
+
+// position returns the position of the nth occurrence (1-based) of the identifier in the source.
+func position(t *testing.T, file, src, ident string, nth int) token.Position {
+	t.Helper()
+
+	offset := -1
+	for i := 0; i < nth; i++ {
+		next := strings.Index(src[offset+1:], ident)
+		require.NotEqual(t, -1, next)
+		offset += next + 1
+	}
+
+	line := strings.Count(src[:offset], "\n") + 1
+	column := offset - strings.LastIndex(src[:offset], "\n")
+
+	return token.Position{Filename: file, Line: line, Column: column}
+}
+
+func lines(positions []token.Position) []string {
+	var ret []string
+	for _, p := range positions {
+		ret = append(ret, filepath.Base(p.Filename)+":"+strings.Split(p.String(), ":")[1])
+	}
+	return ret
+}
+
+func TestRenamer_Rename(t *testing.T) {
+	r, dir := newTestRenamer(t)
+	fileA := filepath.Join(dir, "a", "a.go")
+
+	positions, err := r.Rename(position(t, fileA, srcA, "userId", 2), "userID")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a.go:3", "a.go:14", "a.go:17"}, lines(positions))
+
+	name, start, ok := r.Ident(position(t, fileA, srcA, "Name string", 1))
+	require.True(t, ok)
+	assert.Equal(t, "HostName", name)
+	assert.Equal(t, 6, start.Line)
+	assert.Equal(t, 2, start.Column)
+
+	positions, err = r.Rename(position(t, fileA, srcA, "HostName", 1), "Host")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a.go:6", "a.go:10", "b.go:6", "b.go:7"}, lines(positions))
+}
+
+func TestRenamer_Rename_conflicts(t *testing.T) {
+	r, dir := newTestRenamer(t)
+	fileA := filepath.Join(dir, "a", "a.go")
+
+	testCases := []struct {
+		desc     string
+		ident    string
+		nth      int
+		newName  string
+		expected string
+	}{
+		{desc: "same scope", ident: "userId", nth: 1, newName: "Lookup", expected: "conflicts with the declaration"},
+		{desc: "shadowed", ident: "userId", nth: 1, newName: "goodName", expected: "would be shadowed"},
+		{desc: "shadowed in block", ident: "count", nth: 1, newName: "total", expected: "would be shadowed"},
+		{desc: "captured builtin", ident: "goodName", nth: 1, newName: "string", expected: "would capture"},
+		{desc: "field", ident: "HostName", nth: 1, newName: "Addr", expected: "conflicts with the field or method"},
+		{desc: "unexported", ident: "Lookup", nth: 1, newName: "lookup", expected: "it can't be unexported"},
+		{desc: "method", ident: "Addr", nth: 1, newName: "Address", expected: "is a method"},
+		{desc: "not an identifier", ident: "userId", nth: 1, newName: "user-id", expected: "is not an identifier"},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := r.Rename(position(t, fileA, srcA, test.ident, test.nth), test.newName)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expected)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package report
+
+import (
+	"time"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// Analytics is the aggregated usage of the linters during a run, to collect centrally across repositories.
+// It contains only counts and durations: no paths, no source code, no issue texts.
+type Analytics struct {
+	Version string
+	// DurationMs is the duration of the linters and of the processing of their issues.
+	DurationMs int64
+	Linters    map[string]*LinterAnalytics
+	// StagesMs are the durations of the linters and of the processors.
+	StagesMs map[string]int64
+}
+
+type LinterAnalytics struct {
+	// Found is the count of issues found by the linter.
+	Found int
+	// Reported is the count of issues remaining after the processing.
+	Reported int
+	// Suppressed is the count of issues removed by each processor: nolint, exclude, exclude-rules, baseline...
+	Suppressed map[string]int `json:",omitempty"`
+}
+
+func NewAnalytics(version string, enabledLinters []string) *Analytics {
+	a := &Analytics{
+		Version:  version,
+		Linters:  map[string]*LinterAnalytics{},
+		StagesMs: map[string]int64{},
+	}
+
+	for _, name := range enabledLinters {
+		a.linter(name)
+	}
+
+	return a
+}
+
+func (a *Analytics) linter(name string) *LinterAnalytics {
+	la, ok := a.Linters[name]
+	if !ok {
+		la = &LinterAnalytics{Suppressed: map[string]int{}}
+		a.Linters[name] = la
+	}
+	return la
+}
+
+// AddFound counts the issues found by the linters.
+func (a *Analytics) AddFound(issues []result.Issue) {
+	for linter, count := range countByLinter(issues) {
+		a.linter(linter).Found += count
+	}
+}
+
+// AddProcessed counts the issues removed by a processor.
+func (a *Analytics) AddProcessed(processor string, in, out []result.Issue) {
+	if len(in) == len(out) {
+		return
+	}
+
+	outCounts := countByLinter(out)
+	for linter, inCount := range countByLinter(in) {
+		if removed := inCount - outCounts[linter]; removed > 0 {
+			a.linter(linter).Suppressed[processor] += removed
+		}
+	}
+}
+
+// SetReported counts the issues remaining after the processing.
+func (a *Analytics) SetReported(issues []result.Issue) {
+	for _, la := range a.Linters {
+		la.Reported = 0
+	}
+	for linter, count := range countByLinter(issues) {
+		a.linter(linter).Reported = count
+	}
+}
+
+func (a *Analytics) AddStages(stages map[string]time.Duration) {
+	for name, d := range stages {
+		a.StagesMs[name] += d.Milliseconds()
+	}
+}
+
+func countByLinter(issues []result.Issue) map[string]int {
+	counts := map[string]int{}
+	for i := range issues {
+		counts[issues[i].FromLinter]++
+	}
+	return counts
+}
@@ -0,0 +1,43 @@
+package report
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestAnalytics(t *testing.T) {
+	errcheck := result.Issue{FromLinter: "errcheck", Text: "Error return value is not checked"}
+	govet := result.Issue{FromLinter: "govet", Text: "unreachable code"}
+
+	found := []result.Issue{errcheck, errcheck, errcheck, govet}
+
+	a := NewAnalytics("1.50.0", []string{"errcheck", "govet", "unused"})
+	a.AddFound(found)
+	a.AddProcessed("path_prettifier", found, found)
+	a.AddProcessed("nolint", found, found[1:])
+	a.AddProcessed("exclude-rules", found[1:], found[2:3])
+	a.SetReported(found[2:3])
+	a.AddStages(map[string]time.Duration{"errcheck": 2 * time.Second, "nolint": time.Millisecond})
+
+	expected := &Analytics{
+		Version: "1.50.0",
+		Linters: map[string]*LinterAnalytics{
+			"errcheck": {Found: 3, Reported: 1, Suppressed: map[string]int{"nolint": 1, "exclude-rules": 1}},
+			"govet":    {Found: 1, Reported: 0, Suppressed: map[string]int{"exclude-rules": 1}},
+			"unused":   {Suppressed: map[string]int{}},
+		},
+		StagesMs: map[string]int64{"errcheck": 2000, "nolint": 1},
+	}
+
+	assert.Equal(t, expected, a)
+
+	data, err := json.Marshal(a)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), errcheck.Text)
+}
@@ -1,20 +1,52 @@
 package report
 
+import (
+	"time"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
 type Warning struct {
 	Tag  string `json:",omitempty"`
 	Text string
+	// Event and Fields are the stable name and the structured data of the warnings logged as events.
+	Event  string          `json:",omitempty"`
+	Fields logutils.Fields `json:",omitempty"`
 }
 
 type LinterData struct {
 	Name             string
 	Enabled          bool `json:",omitempty"`
 	EnabledByDefault bool `json:",omitempty"`
+	// TimedOut is set if the linter was cancelled at its timeout: its issues are incomplete.
+	TimedOut bool `json:",omitempty"`
+	// Degraded is set if the linter was run one package at a time, or alone, over the memory budget.
+	Degraded bool `json:",omitempty"`
+	// DurationMs is the duration of the run of the linter, the linters combined in one analysis share its duration evenly.
+	DurationMs int64 `json:",omitempty"`
+	// Excluded is the count of issues of the linter removed by the exclusions: exclude, exclude-rules and nolint.
+	Excluded int `json:",omitempty"`
+}
+
+// RunWarning is a linter which panicked on a package: its issues of the package are missing, the run continued.
+type RunWarning struct {
+	Linter  string
+	Package string `json:",omitempty"`
+	Message string
+	Stack   string `json:",omitempty"`
 }
 
 type Data struct {
 	Warnings []Warning    `json:",omitempty"`
 	Linters  []LinterData `json:",omitempty"`
 	Error    string       `json:",omitempty"`
+	// Fixed are the issues of the previous run fixed since.
+	Fixed []IssueRef `json:",omitempty"`
+	// RemovedByCanary are the issues of the current config not reported with the canary config.
+	RemovedByCanary []IssueRef `json:",omitempty"`
+	// RunWarnings are the panics of the linters recovered during the run.
+	RunWarnings []RunWarning `json:",omitempty"`
 }
 
 func (d *Data) AddLinter(name string, enabled, enabledByDefault bool) {
@@ -24,3 +56,102 @@ func (d *Data) AddLinter(name string, enabled, enabledByDefault bool) {
 		EnabledByDefault: enabledByDefault,
 	})
 }
+
+// SetTimedOut marks the linter as cancelled at its timeout.
+func (d *Data) SetTimedOut(name string) {
+	d.linter(name).TimedOut = true
+}
+
+// SetDegraded marks the linter as run in degraded mode over the memory budget.
+func (d *Data) SetDegraded(name string) {
+	d.linter(name).Degraded = true
+}
+
+// AddDuration adds the duration of a run of the linter.
+func (d *Data) AddDuration(name string, duration time.Duration) {
+	d.linter(name).DurationMs += duration.Milliseconds()
+}
+
+// AddRunWarning records the panic of a linter.
+func (d *Data) AddRunWarning(w RunWarning) {
+	d.RunWarnings = append(d.RunWarnings, w)
+}
+
+// AddExcluded counts the issues removed by an exclusion processor.
+func (d *Data) AddExcluded(in, out []result.Issue) {
+	if len(in) == len(out) {
+		return
+	}
+
+	outCounts := countByLinter(out)
+	for name, inCount := range countByLinter(in) {
+		if removed := inCount - outCounts[name]; removed > 0 {
+			d.linter(name).Excluded += removed
+		}
+	}
+}
+
+// Merge adds the data of a run on other packages, like a shard of the packages:
+// the durations and the excluded issues are summed, the identical warnings are kept once.
+func (d *Data) Merge(other *Data) {
+	for _, w := range other.Warnings {
+		if !containsWarning(d.Warnings, w) {
+			d.Warnings = append(d.Warnings, w)
+		}
+	}
+
+	for _, l := range other.Linters {
+		i := d.linterIndex(l.Name)
+		if i == -1 {
+			d.Linters = append(d.Linters, l)
+			continue
+		}
+
+		ld := &d.Linters[i]
+		ld.Enabled = ld.Enabled || l.Enabled
+		ld.EnabledByDefault = ld.EnabledByDefault || l.EnabledByDefault
+		ld.TimedOut = ld.TimedOut || l.TimedOut
+		ld.Degraded = ld.Degraded || l.Degraded
+		ld.DurationMs += l.DurationMs
+		ld.Excluded += l.Excluded
+	}
+
+	if other.Error != "" && other.Error != d.Error {
+		if d.Error != "" {
+			d.Error += "; "
+		}
+		d.Error += other.Error
+	}
+
+	d.Fixed = append(d.Fixed, other.Fixed...)
+	d.RemovedByCanary = append(d.RemovedByCanary, other.RemovedByCanary...)
+	d.RunWarnings = append(d.RunWarnings, other.RunWarnings...)
+}
+
+func containsWarning(warnings []Warning, w Warning) bool {
+	for _, ww := range warnings {
+		if ww.Tag == w.Tag && ww.Text == w.Text && ww.Event == w.Event { // the fields are in the text
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Data) linterIndex(name string) int {
+	for i := range d.Linters {
+		if d.Linters[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// linter returns the data of the linter, added as enabled if missing.
+func (d *Data) linter(name string) *LinterData {
+	if i := d.linterIndex(name); i != -1 {
+		return &d.Linters[i]
+	}
+
+	d.Linters = append(d.Linters, LinterData{Name: name, Enabled: true})
+	return &d.Linters[len(d.Linters)-1]
+}
@@ -0,0 +1,65 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestData_durationsAndExcluded(t *testing.T) {
+	errcheck := result.Issue{FromLinter: "errcheck"}
+	govet := result.Issue{FromLinter: "govet"}
+
+	found := []result.Issue{errcheck, errcheck, govet}
+
+	d := &Data{}
+	d.AddLinter("errcheck", true, true)
+	d.AddLinter("govet", true, true)
+
+	d.AddDuration("errcheck", 1500*time.Millisecond)
+	d.AddDuration("errcheck", 500*time.Millisecond)
+	d.AddExcluded(found, found)
+	d.AddExcluded(found, found[1:])
+	d.AddExcluded(found[1:], nil)
+
+	expected := []LinterData{
+		{Name: "errcheck", Enabled: true, EnabledByDefault: true, DurationMs: 2000, Excluded: 2},
+		{Name: "govet", Enabled: true, EnabledByDefault: true, Excluded: 1},
+	}
+	assert.Equal(t, expected, d.Linters)
+}
+
+func TestData_Merge(t *testing.T) {
+	d := &Data{
+		Warnings:    []Warning{{Tag: "runner", Text: "deprecated"}},
+		Linters:     []LinterData{{Name: "errcheck", Enabled: true, DurationMs: 100, Excluded: 1}},
+		RunWarnings: []RunWarning{{Linter: "errcheck", Package: "example.com/a", Message: "boom"}},
+	}
+
+	d.Merge(&Data{
+		Warnings: []Warning{{Tag: "runner", Text: "deprecated"}, {Tag: "loader", Text: "no go files"}},
+		Linters: []LinterData{
+			{Name: "errcheck", Enabled: true, TimedOut: true, DurationMs: 50, Excluded: 2},
+			{Name: "govet", Enabled: true, DurationMs: 10},
+		},
+		Error:       "typecheck failed",
+		RunWarnings: []RunWarning{{Linter: "govet", Package: "example.com/b", Message: "boom"}},
+	})
+
+	expected := &Data{
+		Warnings: []Warning{{Tag: "runner", Text: "deprecated"}, {Tag: "loader", Text: "no go files"}},
+		Linters: []LinterData{
+			{Name: "errcheck", Enabled: true, TimedOut: true, DurationMs: 150, Excluded: 3},
+			{Name: "govet", Enabled: true, DurationMs: 10},
+		},
+		Error: "typecheck failed",
+		RunWarnings: []RunWarning{
+			{Linter: "errcheck", Package: "example.com/a", Message: "boom"},
+			{Linter: "govet", Package: "example.com/b", Message: "boom"},
+		},
+	}
+	assert.Equal(t, expected, d)
+}
@@ -0,0 +1,123 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// IssuesDiff are the changes of the issues between two reports.
+type IssuesDiff struct {
+	// Introduced are the issues of the new report only, Fixed the issues of the old report only.
+	Introduced []result.Issue `json:"introduced"`
+	Fixed      []result.Issue `json:"fixed"`
+	// Moved are the issues of both reports on different lines.
+	Moved []MovedIssue `json:"moved"`
+	// Unchanged counts the issues of both reports on the same lines.
+	Unchanged int `json:"unchanged"`
+}
+
+// MovedIssue is an issue of the new report moved from a line of the old report.
+type MovedIssue struct {
+	Issue    result.Issue `json:"issue"`
+	FromLine int          `json:"fromLine"`
+}
+
+// DiffIssues compares the issues of two reports: the issues are matched by their identity without their lines,
+// the linter, the file, the text and the source of the issue, which don't change when the code around the issue is edited.
+// The issues with the same identity are matched on the same lines first, then by their order in the file.
+func DiffIssues(old, current []result.Issue) IssuesDiff {
+	type group struct {
+		old, current []*result.Issue
+	}
+
+	groups := map[result.Identity]*group{}
+	var keys []result.Identity
+	groupOf := func(i *result.Issue) *group {
+		key := i.Identity().WithoutLine()
+		g := groups[key]
+		if g == nil {
+			g = &group{}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+		return g
+	}
+
+	for i := range old {
+		g := groupOf(&old[i])
+		g.old = append(g.old, &old[i])
+	}
+	for i := range current {
+		g := groupOf(&current[i])
+		g.current = append(g.current, &current[i])
+	}
+
+	var d IssuesDiff
+	for _, key := range keys {
+		g := groups[key]
+
+		// The issues on the same lines are unchanged.
+		oldLines := map[int]int{}
+		for _, i := range g.old {
+			oldLines[i.Line()]++
+		}
+		var currentLeft []*result.Issue
+		for _, i := range g.current {
+			if oldLines[i.Line()] > 0 {
+				oldLines[i.Line()]--
+				d.Unchanged++
+				continue
+			}
+			currentLeft = append(currentLeft, i)
+		}
+		var oldLeft []*result.Issue
+		for _, i := range g.old {
+			if oldLines[i.Line()] > 0 {
+				oldLines[i.Line()]--
+				oldLeft = append(oldLeft, i)
+			}
+		}
+
+		sortByLine(oldLeft)
+		sortByLine(currentLeft)
+
+		for len(oldLeft) != 0 && len(currentLeft) != 0 {
+			d.Moved = append(d.Moved, MovedIssue{Issue: *currentLeft[0], FromLine: oldLeft[0].Line()})
+			oldLeft, currentLeft = oldLeft[1:], currentLeft[1:]
+		}
+		for _, i := range oldLeft {
+			d.Fixed = append(d.Fixed, *i)
+		}
+		for _, i := range currentLeft {
+			d.Introduced = append(d.Introduced, *i)
+		}
+	}
+
+	sortByPosition(d.Introduced)
+	sortByPosition(d.Fixed)
+	sort.SliceStable(d.Moved, func(i, j int) bool {
+		return lessPosition(&d.Moved[i].Issue, &d.Moved[j].Issue)
+	})
+
+	return d
+}
+
+func sortByPosition(issues []result.Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		return lessPosition(&issues[i], &issues[j])
+	})
+}
+
+func lessPosition(a, b *result.Issue) bool {
+	if a.FilePath() != b.FilePath() {
+		return a.FilePath() < b.FilePath()
+	}
+	return a.Line() < b.Line()
+}
+
+func sortByLine(issues []*result.Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line() < issues[j].Line()
+	})
+}
@@ -0,0 +1,59 @@
+package report
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestDiffIssues(t *testing.T) {
+	issue := func(linter, path string, line int, source string) result.Issue {
+		return result.Issue{
+			FromLinter:  linter,
+			Text:        "unchecked error",
+			SourceLines: []string{source},
+			Pos:         token.Position{Filename: path, Line: line},
+		}
+	}
+
+	old := []result.Issue{
+		issue("errcheck", "a.go", 10, "f.Close()"),
+		issue("errcheck", "a.go", 20, "w.Flush()"),
+		issue("errcheck", "a.go", 30, "f.Close()"),
+		issue("errcheck", "b.go", 5, "f.Close()"),
+	}
+
+	current := []result.Issue{
+		issue("errcheck", "a.go", 10, "f.Close()"), // unchanged
+		issue("errcheck", "a.go", 25, "w.Flush()"), // moved
+		issue("errcheck", "a.go", 40, "f.Close()"), // moved
+		issue("gosec", "a.go", 10, "f.Close()"),    // introduced: another linter
+		issue("errcheck", "c.go", 1, "f.Close()"),  // introduced
+	}
+
+	d := DiffIssues(old, current)
+
+	assert.Equal(t, 1, d.Unchanged)
+	assert.Equal(t, []result.Issue{current[3], current[4]}, d.Introduced)
+	assert.Equal(t, []result.Issue{old[3]}, d.Fixed)
+	assert.Equal(t, []MovedIssue{
+		{Issue: current[1], FromLine: 20},
+		{Issue: current[2], FromLine: 30},
+	}, d.Moved)
+}
+
+func TestDiffIssues_duplicates(t *testing.T) {
+	issue := func(line int) result.Issue {
+		return result.Issue{FromLinter: "govet", Text: "unreachable code", Pos: token.Position{Filename: "a.go", Line: line}}
+	}
+
+	d := DiffIssues([]result.Issue{issue(1), issue(5)}, []result.Issue{issue(5), issue(5), issue(9)})
+
+	assert.Equal(t, 1, d.Unchanged)
+	assert.Equal(t, []MovedIssue{{Issue: issue(5), FromLine: 1}}, d.Moved)
+	assert.Equal(t, []result.Issue{issue(9)}, d.Introduced)
+	assert.Empty(t, d.Fixed)
+}
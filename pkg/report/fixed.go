@@ -0,0 +1,74 @@
+package report
+
+import (
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// IssueRef identifies an issue between two runs.
+type IssueRef result.Identity
+
+func (r IssueRef) key() IssueRef {
+	return IssueRef(result.Identity(r).WithoutLine())
+}
+
+func NewIssueRefs(issues []result.Issue) []IssueRef {
+	refs := make([]IssueRef, 0, len(issues))
+	for i := range issues {
+		refs = append(refs, IssueRef(issues[i].Identity()))
+	}
+
+	return refs
+}
+
+// CanaryDelta returns the issues reported only with the canary config,
+// and the issues reported only with the current config.
+// The code is the same for both configs: the issues are matched with their lines.
+func CanaryDelta(current, canary []result.Issue) (added []result.Issue, removed []IssueRef) {
+	currentRefs, canaryRefs := NewIssueRefs(current), NewIssueRefs(canary)
+
+	remaining := map[IssueRef]int{}
+	for _, r := range currentRefs {
+		remaining[r]++
+	}
+	for i, r := range canaryRefs {
+		if remaining[r] > 0 {
+			remaining[r]--
+			continue
+		}
+		added = append(added, canary[i])
+	}
+
+	remaining = map[IssueRef]int{}
+	for _, r := range canaryRefs {
+		remaining[r]++
+	}
+	for _, r := range currentRefs {
+		if remaining[r] > 0 {
+			remaining[r]--
+			continue
+		}
+		removed = append(removed, r)
+	}
+
+	return added, removed
+}
+
+// Fixed returns the issues of the previous run which are not reported by the current run.
+func Fixed(previous, current []IssueRef) []IssueRef {
+	remaining := map[IssueRef]int{}
+	for _, r := range current {
+		remaining[r.key()]++
+	}
+
+	var fixed []IssueRef
+	for _, r := range previous {
+		if remaining[r.key()] > 0 {
+			remaining[r.key()]--
+			continue
+		}
+
+		fixed = append(fixed, r)
+	}
+
+	return fixed
+}
@@ -0,0 +1,62 @@
+package report
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestFixed(t *testing.T) {
+	previous := []IssueRef{
+		{FromLinter: "errcheck", Text: "unchecked error", Path: "a.go", Line: 10},
+		{FromLinter: "errcheck", Text: "unchecked error", Path: "a.go", Line: 20},
+		{FromLinter: "govet", Text: "unreachable code", Path: "b.go", Line: 5},
+		{FromLinter: "misspell", Text: "typo", Path: "c.go", Line: 1},
+	}
+
+	current := []IssueRef{
+		// moved
+		{FromLinter: "errcheck", Text: "unchecked error", Path: "a.go", Line: 12},
+		{FromLinter: "misspell", Text: "typo", Path: "c.go", Line: 3},
+		// new
+		{FromLinter: "govet", Text: "unreachable code", Path: "d.go", Line: 5},
+	}
+
+	expected := []IssueRef{
+		{FromLinter: "errcheck", Text: "unchecked error", Path: "a.go", Line: 20},
+		{FromLinter: "govet", Text: "unreachable code", Path: "b.go", Line: 5},
+	}
+
+	assert.Equal(t, expected, Fixed(previous, current))
+}
+
+func TestFixed_noPrevious(t *testing.T) {
+	assert.Empty(t, Fixed(nil, []IssueRef{{FromLinter: "govet", Text: "x", Path: "a.go", Line: 1}}))
+}
+
+func TestCanaryDelta(t *testing.T) {
+	newIssue := func(linter, file string, line int) result.Issue {
+		return result.Issue{FromLinter: linter, Text: "text", Pos: token.Position{Filename: file, Line: line}}
+	}
+
+	current := []result.Issue{newIssue("errcheck", "a.go", 1), newIssue("errcheck", "a.go", 2), newIssue("govet", "b.go", 1)}
+	canary := []result.Issue{newIssue("errcheck", "a.go", 2), newIssue("govet", "b.go", 1), newIssue("revive", "b.go", 3)}
+
+	added, removed := CanaryDelta(current, canary)
+	assert.Equal(t, []result.Issue{newIssue("revive", "b.go", 3)}, added)
+	assert.Equal(t, []IssueRef{{FromLinter: "errcheck", Text: "text", Path: "a.go", Line: 1}}, removed)
+}
+
+func TestFixed_source(t *testing.T) {
+	newIssue := func(source string, line int) result.Issue {
+		return result.Issue{FromLinter: "errcheck", Text: "text", Pos: token.Position{Filename: "a.go", Line: line}, SourceLines: []string{source}}
+	}
+
+	previous := NewIssueRefs([]result.Issue{newIssue("f()", 1), newIssue("g()", 2)})
+	current := NewIssueRefs([]result.Issue{newIssue("f()", 3), newIssue("h()", 2)})
+
+	assert.Equal(t, []IssueRef{{FromLinter: "errcheck", Text: "text", Path: "a.go", Line: 2, Source: "g()"}}, Fixed(previous, current))
+}
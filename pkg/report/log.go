@@ -43,10 +43,26 @@ func (lw LogWrapper) Warnf(format string, args ...interface{}) {
 	lw.rd.Warnings = append(lw.rd.Warnings, w)
 }
 
+func (lw LogWrapper) WarnEvent(event string, fields logutils.Fields, format string, args ...interface{}) {
+	logutils.WarnEvent(lw.origLog, event, fields, format, args...)
+	w := Warning{
+		Tag:    strings.Join(lw.tags, "/"),
+		Text:   fmt.Sprintf(format, args...),
+		Event:  event,
+		Fields: fields,
+	}
+
+	lw.rd.Warnings = append(lw.rd.Warnings, w)
+}
+
 func (lw LogWrapper) Infof(format string, args ...interface{}) {
 	lw.origLog.Infof(format, args...)
 }
 
+func (lw LogWrapper) InfoEvent(event string, fields logutils.Fields, format string, args ...interface{}) {
+	logutils.InfoEvent(lw.origLog, event, fields, format, args...)
+}
+
 func (lw LogWrapper) Child(name string) logutils.Log {
 	c := lw
 	c.origLog = lw.origLog.Child(name)
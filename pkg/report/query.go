@@ -0,0 +1,390 @@
+package report
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gobwas/glob"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const (
+	QueryFieldLinter   = "linter"
+	QueryFieldRule     = "rule"
+	QueryFieldSeverity = "severity"
+	QueryFieldPath     = "path"
+	QueryFieldText     = "text"
+	QueryFieldOwner    = "owner"
+	QueryFieldCovered  = "covered"
+)
+
+var queryFields = []string{QueryFieldLinter, QueryFieldRule, QueryFieldSeverity, QueryFieldPath, QueryFieldText, QueryFieldOwner, QueryFieldCovered}
+
+const (
+	queryOpEqual    = "="
+	queryOpNotEqual = "!="
+	queryOpMatch    = "~"
+)
+
+// Query filters, groups and counts the issues of a report.
+//
+//	linter=errcheck,govet severity!=info path=pkg/** text~"^unused" | group-by owner
+//
+// Filters are separated by spaces and must all match:
+// `=` and `!=` compare to one of the comma-separated values (glob patterns for `path`),
+// `~` matches a regular expression.
+// Stages follow the filters after `|`: `group-by <field>[,<field>]` counts the issues per values, `count` counts them.
+type Query struct {
+	filters []queryFilter
+	groupBy []string
+	count   bool
+}
+
+type queryFilter struct {
+	field   string
+	op      string
+	values  []string
+	globs   []glob.Glob
+	pattern *regexp.Regexp
+}
+
+// QueryGroup is the count of the issues for the values of the group-by fields.
+type QueryGroup struct {
+	Values map[string]string
+	Count  int
+}
+
+// QueryResult contains the matching issues, or their count, or their groups.
+type QueryResult struct {
+	Issues []result.Issue `json:",omitempty"`
+	Groups []QueryGroup   `json:",omitempty"`
+	Count  int
+}
+
+// OwnersFunc returns the owners of a file.
+type OwnersFunc func(path string) []string
+
+func ParseQuery(expr string) (*Query, error) {
+	stages, err := splitQueryStages(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{}
+
+	tokens, err := splitQuery(stages[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		f, err := parseQueryFilter(token)
+		if err != nil {
+			return nil, err
+		}
+		q.filters = append(q.filters, *f)
+	}
+
+	for _, stage := range stages[1:] {
+		fields := strings.Fields(stage)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("empty query stage")
+		}
+
+		switch fields[0] {
+		case "count":
+			if len(fields) != 1 {
+				return nil, fmt.Errorf("count has no arguments")
+			}
+			q.count = true
+		case "group-by":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("group-by needs one list of fields")
+			}
+			for _, field := range strings.Split(fields[1], ",") {
+				if !isQueryField(field) {
+					return nil, fmt.Errorf("unknown field %q: must be one of %s", field, strings.Join(queryFields, ", "))
+				}
+				q.groupBy = append(q.groupBy, field)
+			}
+		default:
+			return nil, fmt.Errorf("unknown query stage %q: must be count or group-by", fields[0])
+		}
+	}
+
+	return q, nil
+}
+
+// splitQueryStages splits the query on the pipes outside the quoted values.
+func splitQueryStages(expr string) ([]string, error) {
+	var stages []string
+	var cur strings.Builder
+	inQuotes := false
+
+	for _, r := range expr {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			cur.WriteRune(r)
+		case r == '|' && !inQuotes:
+			stages = append(stages, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quoted value")
+	}
+
+	return append(stages, cur.String()), nil
+}
+
+// splitQuery splits the filters on spaces, the values can be quoted.
+func splitQuery(s string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	inQuotes := false
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == ' ' && !inQuotes:
+			if cur.Len() != 0 {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quoted value")
+	}
+
+	if cur.Len() != 0 {
+		tokens = append(tokens, cur.String())
+	}
+
+	return tokens, nil
+}
+
+func parseQueryFilter(token string) (*queryFilter, error) {
+	if op := queryFilterOp(token); op != "" {
+		field, value, _ := strings.Cut(token, op)
+
+		if !isQueryField(field) {
+			return nil, fmt.Errorf("unknown field %q: must be one of %s", field, strings.Join(queryFields, ", "))
+		}
+
+		f := &queryFilter{field: field, op: op}
+
+		if op == queryOpMatch {
+			re, err := regexp.Compile(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression for %s: %w", field, err)
+			}
+			f.pattern = re
+			return f, nil
+		}
+
+		f.values = strings.Split(value, ",")
+		if field == QueryFieldPath {
+			for _, v := range f.values {
+				g, err := glob.Compile(v, '/')
+				if err != nil {
+					return nil, fmt.Errorf("invalid path pattern %q: %w", v, err)
+				}
+				f.globs = append(f.globs, g)
+			}
+		}
+
+		return f, nil
+	}
+
+	return nil, fmt.Errorf("invalid filter %q: must be <field>=<values>, <field>!=<values> or <field>~<regexp>", token)
+}
+
+// queryFilterOp returns the first operator of the filter: the values can contain the other operators.
+func queryFilterOp(token string) string {
+	op, index := "", -1
+	// `!=` before `=` to not split `!=` as `!` and `=`.
+	for _, o := range []string{queryOpNotEqual, queryOpEqual, queryOpMatch} {
+		i := strings.Index(token, o)
+		if i != -1 && (index == -1 || i < index) {
+			op, index = o, i
+		}
+	}
+	return op
+}
+
+func isQueryField(field string) bool {
+	for _, f := range queryFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Run applies the query to the issues: owners is used only by the owner field, it can be nil.
+func (q *Query) Run(issues []result.Issue, owners OwnersFunc) QueryResult {
+	if owners == nil {
+		owners = func(string) []string { return nil }
+	}
+
+	var res QueryResult
+
+	groups := map[string]*QueryGroup{}
+	for i := range issues {
+		issue := &issues[i]
+		if !q.match(issue, owners) {
+			continue
+		}
+
+		res.Count++
+
+		switch {
+		case len(q.groupBy) != 0:
+			for _, values := range q.groupValues(issue, owners) {
+				key := fmt.Sprint(values)
+				g, ok := groups[key]
+				if !ok {
+					g = &QueryGroup{Values: values}
+					groups[key] = g
+				}
+				g.Count++
+			}
+		case !q.count:
+			res.Issues = append(res.Issues, *issue)
+		}
+	}
+
+	for _, g := range groups {
+		res.Groups = append(res.Groups, *g)
+	}
+
+	sort.Slice(res.Groups, func(i, j int) bool {
+		if res.Groups[i].Count != res.Groups[j].Count {
+			return res.Groups[i].Count > res.Groups[j].Count
+		}
+		return fmt.Sprint(res.Groups[i].Values) < fmt.Sprint(res.Groups[j].Values)
+	})
+
+	return res
+}
+
+// Grouped checks if the query has a group-by stage.
+func (q *Query) Grouped() bool {
+	return len(q.groupBy) != 0
+}
+
+// Counted checks if the query has a count stage.
+func (q *Query) Counted() bool {
+	return q.count
+}
+
+func (q *Query) match(issue *result.Issue, owners OwnersFunc) bool {
+	for i := range q.filters {
+		if !q.filters[i].match(fieldValues(issue, q.filters[i].field, owners)) {
+			return false
+		}
+	}
+	return true
+}
+
+// groupValues returns the combinations of the values of the group-by fields:
+// a file can have several owners.
+func (q *Query) groupValues(issue *result.Issue, owners OwnersFunc) []map[string]string {
+	combinations := []map[string]string{{}}
+
+	for _, field := range q.groupBy {
+		values := fieldValues(issue, field, owners)
+		if len(values) == 0 {
+			values = []string{""}
+		}
+
+		var next []map[string]string
+		for _, c := range combinations {
+			for _, v := range values {
+				m := map[string]string{field: v}
+				for k, cv := range c {
+					m[k] = cv
+				}
+				next = append(next, m)
+			}
+		}
+		combinations = next
+	}
+
+	return combinations
+}
+
+func fieldValues(issue *result.Issue, field string, owners OwnersFunc) []string {
+	switch field {
+	case QueryFieldLinter:
+		return []string{issue.FromLinter}
+	case QueryFieldRule:
+		return []string{issue.RuleID}
+	case QueryFieldSeverity:
+		return []string{issue.Severity}
+	case QueryFieldPath:
+		return []string{issue.FilePath()}
+	case QueryFieldText:
+		return []string{issue.Text}
+	case QueryFieldOwner:
+		return owners(issue.FilePath())
+	case QueryFieldCovered:
+		if issue.Covered == nil {
+			return []string{"unknown"}
+		}
+		return []string{strconv.FormatBool(*issue.Covered)}
+	default:
+		return nil
+	}
+}
+
+// match checks if one of the values of the field matches the filter.
+func (f *queryFilter) match(values []string) bool {
+	matched := false
+	for _, v := range values {
+		if f.matchValue(v) {
+			matched = true
+			break
+		}
+	}
+
+	if f.op == queryOpNotEqual {
+		return !matched
+	}
+	return matched
+}
+
+func (f *queryFilter) matchValue(v string) bool {
+	if f.pattern != nil {
+		return f.pattern.MatchString(v)
+	}
+
+	if f.globs != nil {
+		for _, g := range f.globs {
+			if g.Match(v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, expected := range f.values {
+		if strings.EqualFold(expected, v) {
+			return true
+		}
+	}
+	return false
+}
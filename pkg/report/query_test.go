@@ -0,0 +1,147 @@
+package report
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newQueryIssue(linter, severity, path, text string) result.Issue {
+	return result.Issue{FromLinter: linter, Severity: severity, Text: text, Pos: token.Position{Filename: path}}
+}
+
+func TestQuery(t *testing.T) {
+	issues := []result.Issue{
+		newQueryIssue("errcheck", "error", "pkg/a/a.go", "Error return value is not checked"),
+		newQueryIssue("errcheck", "error", "pkg/b/b.go", "Error return value is not checked"),
+		newQueryIssue("govet", "warning", "pkg/a/a.go", "unreachable code"),
+		newQueryIssue("misspell", "info", "cmd/main.go", "`teh` is a misspelling of `the`"),
+	}
+
+	covered, uncovered := true, false
+	issues[0].Covered = &uncovered
+	issues[2].Covered = &covered
+	issues[2].RuleID = "unreachable"
+
+	owners := func(path string) []string {
+		switch path {
+		case "pkg/a/a.go":
+			return []string{"@team-a"}
+		case "pkg/b/b.go":
+			return []string{"@team-a", "@team-b"}
+		default:
+			return nil
+		}
+	}
+
+	testCases := []struct {
+		desc     string
+		expr     string
+		expected QueryResult
+	}{
+		{
+			desc:     "linters",
+			expr:     "linter=govet,misspell | count",
+			expected: QueryResult{Count: 2},
+		},
+		{
+			desc:     "rule",
+			expr:     "rule=unreachable | count",
+			expected: QueryResult{Count: 1},
+		},
+		{
+			desc:     "not equal",
+			expr:     "severity!=error",
+			expected: QueryResult{Count: 2, Issues: []result.Issue{issues[2], issues[3]}},
+		},
+		{
+			desc:     "path glob",
+			expr:     "path=pkg/** linter=errcheck | count",
+			expected: QueryResult{Count: 2},
+		},
+		{
+			desc:     "path glob separator",
+			expr:     "path=pkg/*.go | count",
+			expected: QueryResult{Count: 0},
+		},
+		{
+			desc:     "quoted regexp",
+			expr:     `text~"not checked$" | count`,
+			expected: QueryResult{Count: 2},
+		},
+		{
+			desc:     "quoted pipe",
+			expr:     `text~"code|checked$" | count`,
+			expected: QueryResult{Count: 3},
+		},
+		{
+			desc:     "quoted operator",
+			expr:     `text~"linter=errcheck" | count`,
+			expected: QueryResult{Count: 0},
+		},
+		{
+			desc:     "covered",
+			expr:     "covered!=true linter=errcheck | count",
+			expected: QueryResult{Count: 2},
+		},
+		{
+			desc:     "uncovered",
+			expr:     "covered=false | count",
+			expected: QueryResult{Count: 1},
+		},
+		{
+			desc:     "owner",
+			expr:     "owner=@team-b | count",
+			expected: QueryResult{Count: 1},
+		},
+		{
+			desc: "group-by",
+			expr: "| group-by owner",
+			expected: QueryResult{Count: 4, Groups: []QueryGroup{
+				{Values: map[string]string{"owner": "@team-a"}, Count: 3},
+				{Values: map[string]string{"owner": "@team-b"}, Count: 1},
+				{Values: map[string]string{"owner": ""}, Count: 1},
+			}},
+		},
+		{
+			desc: "group-by fields",
+			expr: "path=pkg/** | group-by linter,severity",
+			expected: QueryResult{Count: 3, Groups: []QueryGroup{
+				{Values: map[string]string{"linter": "errcheck", "severity": "error"}, Count: 2},
+				{Values: map[string]string{"linter": "govet", "severity": "warning"}, Count: 1},
+			}},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := ParseQuery(test.expr)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, q.Run(issues, owners))
+		})
+	}
+}
+
+func TestParseQuery_errors(t *testing.T) {
+	for _, expr := range []string{
+		"foo=bar",
+		"linter",
+		`text~"(`,
+		`text~"unterminated`,
+		`text~"a|b`,
+		"linter=govet | sort",
+		"linter=govet | group-by foo",
+		"linter=govet |",
+	} {
+		_, err := ParseQuery(expr)
+		assert.Error(t, err, expr)
+	}
+}
@@ -0,0 +1,76 @@
+package report
+
+import (
+	"sort"
+	"time"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// RunReport is the performance of a run, to track its regressions in CI.
+type RunReport struct {
+	Version   string
+	StartedAt time.Time
+	// DurationMs is the duration of the loading of the packages, of the linters and of the processing of their issues.
+	DurationMs int64
+	// LoadDurationMs is the duration of the loading of the packages.
+	LoadDurationMs int64
+	Packages       PackagesReport
+	Cache          CacheReport
+	// Linters are the runs of the linters, in the order of the runs.
+	Linters []LinterRunReport
+	// Issues is the count of issues remaining after the processing.
+	Issues int
+}
+
+// PackagesReport are the counts of the loaded packages.
+type PackagesReport struct {
+	// Analyzed is the count of the analyzed packages, the test variants included.
+	Analyzed int
+	// Total is the count of the packages and of their dependencies.
+	Total int
+}
+
+// CacheReport are the counts of the data found and missing in the cache: the facts and the issues of the packages.
+type CacheReport struct {
+	Hits     int64
+	Misses   int64
+	HitRatio float64
+}
+
+// LinterRunReport is the run of a linter.
+type LinterRunReport struct {
+	Name string
+	// Linters are the linters combined in the run, if several.
+	Linters    []string `json:",omitempty"`
+	DurationMs int64
+	// LinterDurationsMs are the shares of the duration of the combined linters: they share it evenly.
+	LinterDurationsMs map[string]int64 `json:",omitempty"`
+	// AllocatedMB is the memory allocated by the process during the run.
+	AllocatedMB uint64
+	// HeapMB is the memory of the heap at the end of the run.
+	HeapMB uint64
+	// Issues is the count of issues found, before the processing.
+	Issues   int
+	TimedOut bool `json:",omitempty"`
+	Degraded bool `json:",omitempty"`
+}
+
+func NewRunReport(version string, startedAt time.Time) *RunReport {
+	return &RunReport{Version: version, StartedAt: startedAt.UTC()}
+}
+
+// SetCache sets the counts of the cache and computes the hit ratio.
+func (r *RunReport) SetCache(hits, misses int64) {
+	r.Cache = CacheReport{Hits: hits, Misses: misses}
+	if hits+misses != 0 {
+		r.Cache.HitRatio = float64(hits) / float64(hits+misses)
+	}
+}
+
+// AddLinter adds the run of a linter, the issues found by the combined linters are attributed to the run.
+func (r *RunReport) AddLinter(lr LinterRunReport, issues []result.Issue) {
+	lr.Issues = len(issues)
+	sort.Strings(lr.Linters)
+	r.Linters = append(r.Linters, lr)
+}
@@ -0,0 +1,33 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestRunReport(t *testing.T) {
+	r := NewRunReport("1.2.3", time.Now())
+
+	r.SetCache(3, 1)
+	assert.Equal(t, CacheReport{Hits: 3, Misses: 1, HitRatio: 0.75}, r.Cache)
+
+	r.AddLinter(LinterRunReport{Name: "goanalysis_metalinter", Linters: []string{"govet", "errcheck"}},
+		[]result.Issue{newStatsIssue("errcheck", "", "a.go"), newStatsIssue("govet", "", "a.go")})
+	r.AddLinter(LinterRunReport{Name: "gocritic", TimedOut: true}, nil)
+
+	assert.Equal(t, []LinterRunReport{
+		{Name: "goanalysis_metalinter", Linters: []string{"errcheck", "govet"}, Issues: 2},
+		{Name: "gocritic", TimedOut: true},
+	}, r.Linters)
+}
+
+func TestRunReport_SetCache_empty(t *testing.T) {
+	r := NewRunReport("1.2.3", time.Now())
+
+	r.SetCache(0, 0)
+	assert.Equal(t, CacheReport{}, r.Cache)
+}
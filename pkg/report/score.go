@@ -0,0 +1,173 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// The components of the health score.
+const (
+	ScoreDensity      = "density"
+	ScoreSuppressions = "suppressions"
+	ScoreTrend        = "trend"
+)
+
+// Grades are the grades of the health score, from the best one.
+var Grades = []string{"A", "B", "C", "D", "F"}
+
+// gradeMinScores are the minimal scores of the grades, except the last one.
+var gradeMinScores = []float64{90, 80, 70, 60}
+
+// debtWeights are the debt points of an issue, by level of severity.
+// The issues without severity, or with an unknown severity, count as warnings.
+var debtWeights = []int{1, 1, 3, 5, 5, 5}
+
+const defaultDebtWeight = 3
+
+const (
+	linesPerKLOC = 1000
+	// debtPerKLOCToZero is the density of debt scored 0.
+	debtPerKLOCToZero = 10
+	// suppressionsPerKLOCToZero is the density of nolint directives scored 0.
+	suppressionsPerKLOCToZero = 5
+	// TrendRuns is the count of the last runs of the history compared by the trend.
+	TrendRuns = 5
+)
+
+// ScoreInput are the measures of the repository.
+type ScoreInput struct {
+	Issues []result.Issue
+	// Lines is the count of lines of the Go files.
+	Lines int
+	// Suppressions is the count of nolint directives.
+	Suppressions int
+	// History is the history of the counts of issues, for the trend: the trend isn't scored without 2 runs.
+	History *Stats
+}
+
+// ScoreComponent is a scored measure: its score is between 0 and 100.
+type ScoreComponent struct {
+	Name   string
+	Score  float64
+	Weight float64
+	Detail string
+}
+
+// Score is the health score of a repository: the weighted average of its components, between 0 and 100.
+type Score struct {
+	Grade        string
+	Score        float64
+	Debt         int
+	Lines        int
+	Suppressions int
+	Components   []ScoreComponent
+}
+
+// NewScore combines the density of the debt, the density of the suppressions and the trend of the counts of issues.
+func NewScore(in *ScoreInput) *Score {
+	s := &Score{Debt: Debt(in.Issues), Lines: in.Lines, Suppressions: in.Suppressions}
+
+	kloc := math.Max(float64(in.Lines)/linesPerKLOC, 1)
+
+	debtDensity := float64(s.Debt) / kloc
+	s.Components = append(s.Components, ScoreComponent{
+		Name:   ScoreDensity,
+		Score:  clampScore(100 - 100*debtDensity/debtPerKLOCToZero),
+		Weight: 0.5,
+		Detail: fmt.Sprintf("%.1f debt per KLOC (%d issues, %d debt, %d lines)", debtDensity, len(in.Issues), s.Debt, in.Lines),
+	})
+
+	suppressionDensity := float64(in.Suppressions) / kloc
+	s.Components = append(s.Components, ScoreComponent{
+		Name:   ScoreSuppressions,
+		Score:  clampScore(100 - 100*suppressionDensity/suppressionsPerKLOCToZero),
+		Weight: 0.25,
+		Detail: fmt.Sprintf("%.1f nolint per KLOC (%d directives)", suppressionDensity, in.Suppressions),
+	})
+
+	if change, runs, ok := trend(in.History); ok {
+		s.Components = append(s.Components, ScoreComponent{
+			Name: ScoreTrend,
+			// Stable is 75, -25% is 100, +50% is 25.
+			Score:  clampScore(75 - 100*change),
+			Weight: 0.25,
+			Detail: fmt.Sprintf("%+.0f%% issues over the %d last runs", 100*change, runs),
+		})
+	}
+
+	var total, weights float64
+	for _, c := range s.Components {
+		total += c.Score * c.Weight
+		weights += c.Weight
+	}
+
+	s.Score = math.Round(total / weights)
+	s.Grade = GradeOf(s.Score)
+
+	return s
+}
+
+// Debt sums the debt points of the issues, see debtWeights.
+func Debt(issues []result.Issue) int {
+	debt := 0
+	for i := range issues {
+		weight := defaultDebtWeight
+		if level, ok := config.SeverityLevel(issues[i].Severity); ok && level < len(debtWeights) {
+			weight = debtWeights[level]
+		}
+		debt += weight
+	}
+	return debt
+}
+
+// GradeOf returns the grade of a score.
+func GradeOf(score float64) string {
+	for i, minScore := range gradeMinScores {
+		if score >= minScore {
+			return Grades[i]
+		}
+	}
+	return Grades[len(Grades)-1]
+}
+
+// IsGrade checks if the grade is known.
+func IsGrade(grade string) bool {
+	return gradeIndex(grade) >= 0
+}
+
+// MeetsGrade checks if the grade is the minimal grade or a better one.
+func MeetsGrade(grade, minGrade string) bool {
+	return gradeIndex(grade) <= gradeIndex(minGrade)
+}
+
+func gradeIndex(grade string) int {
+	for i, g := range Grades {
+		if strings.EqualFold(g, grade) {
+			return i
+		}
+	}
+	return -1
+}
+
+// trend returns the relative change of the total count of issues over the last runs of the history.
+func trend(s *Stats) (change float64, runs int, ok bool) {
+	if s == nil || len(s.Runs) < 2 {
+		return 0, 0, false
+	}
+
+	recent := s.Runs
+	if len(recent) > TrendRuns {
+		recent = recent[len(recent)-TrendRuns:]
+	}
+
+	from, to := recent[0].Total, recent[len(recent)-1].Total
+	return float64(to-from) / math.Max(float64(from), 1), len(recent), true
+}
+
+func clampScore(score float64) float64 {
+	return math.Round(math.Max(0, math.Min(100, score)))
+}
@@ -0,0 +1,64 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestNewScore(t *testing.T) {
+	issues := []result.Issue{
+		newStatsIssue("errcheck", "error", "a.go"),
+		newStatsIssue("govet", "", "a.go"),
+		newStatsIssue("godot", "info", "a.go"),
+	}
+	assert.Equal(t, 9, Debt(issues))
+	assert.Equal(t, 9, Debt([]result.Issue{
+		newStatsIssue("gosec", "Critical", "a.go"),
+		newStatsIssue("govet", "unknown", "a.go"),
+		newStatsIssue("godot", "low", "a.go"),
+	}), "by severity level")
+
+	s := NewScore(&ScoreInput{Issues: issues, Lines: 3000, Suppressions: 3})
+
+	// 3 debt per KLOC: 70, 1 nolint per KLOC: 80.
+	assert.Equal(t, []string{ScoreDensity, ScoreSuppressions}, componentNames(s))
+	assert.Equal(t, 70.0, s.Components[0].Score)
+	assert.Equal(t, 80.0, s.Components[1].Score)
+	assert.Equal(t, 73.0, s.Score)
+	assert.Equal(t, "C", s.Grade)
+
+	history := &Stats{Runs: []RunStats{{Total: 100}, {Total: 10}, {Total: 6}, {Total: 4}, {Total: 4}, {Total: 5}, {Total: 3}}}
+	s = NewScore(&ScoreInput{Issues: issues, Lines: 3000, Suppressions: 3, History: history})
+
+	// -50% over the 5 last runs: 100.
+	assert.Equal(t, []string{ScoreDensity, ScoreSuppressions, ScoreTrend}, componentNames(s))
+	assert.Equal(t, 100.0, s.Components[2].Score)
+	assert.Equal(t, 80.0, s.Score)
+	assert.Equal(t, "B", s.Grade)
+}
+
+func TestNewScore_empty(t *testing.T) {
+	s := NewScore(&ScoreInput{})
+
+	assert.Equal(t, 100.0, s.Score)
+	assert.Equal(t, "A", s.Grade)
+}
+
+func TestMeetsGrade(t *testing.T) {
+	assert.True(t, MeetsGrade("A", "B"))
+	assert.True(t, MeetsGrade("B", "b"))
+	assert.False(t, MeetsGrade("C", "B"))
+	assert.True(t, IsGrade("f"))
+	assert.False(t, IsGrade("E"))
+}
+
+func componentNames(s *Score) []string {
+	var names []string
+	for _, c := range s.Components {
+		names = append(names, c.Name)
+	}
+	return names
+}
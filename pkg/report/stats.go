@@ -0,0 +1,176 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// The dimensions of the counts of issues of the runs.
+const (
+	StatsByLinter   = "linter"
+	StatsBySeverity = "severity"
+	StatsByPackage  = "package"
+)
+
+// StatsDimensions are the dimensions of the counts, in the order of the outputs.
+var StatsDimensions = []string{StatsByLinter, StatsBySeverity, StatsByPackage}
+
+// Stats is the history of the counts of issues of the runs, to track the evolution of the lint debt.
+type Stats struct {
+	Runs []RunStats
+}
+
+// RunStats are the counts of issues of a run.
+type RunStats struct {
+	Time time.Time
+	// Revision is the commit of the analyzed code, if known.
+	Revision string `json:",omitempty"`
+	Total    int
+	// Counts are the counts of issues by dimension and by value: linter name, severity, package directory.
+	Counts map[string]map[string]int
+}
+
+// NewRunStats counts the issues of a run: the issues without severity are not counted by severity.
+func NewRunStats(t time.Time, revision string, issues []result.Issue) RunStats {
+	rs := RunStats{
+		Time:     t.UTC(),
+		Revision: revision,
+		Total:    len(issues),
+		Counts:   map[string]map[string]int{},
+	}
+
+	for _, dim := range StatsDimensions {
+		rs.Counts[dim] = map[string]int{}
+	}
+
+	for i := range issues {
+		issue := &issues[i]
+
+		rs.Counts[StatsByLinter][issue.FromLinter]++
+		if issue.Severity != "" {
+			rs.Counts[StatsBySeverity][issue.Severity]++
+		}
+		rs.Counts[StatsByPackage][filepath.ToSlash(filepath.Dir(issue.FilePath()))]++
+	}
+
+	return rs
+}
+
+// ReadStats reads the history file: the history is empty if the file doesn't exist.
+func ReadStats(path string) (*Stats, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Stats{}, nil
+		}
+		return nil, err
+	}
+
+	var s Stats
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// WriteStats writes the history file, through a temporary file to not corrupt it on failure.
+func WriteStats(path string, s *Stats) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// StatsDelta is the change of a count between two runs.
+type StatsDelta struct {
+	Dimension string
+	Value     string
+	From      int
+	To        int
+}
+
+func (d StatsDelta) Delta() int {
+	return d.To - d.From
+}
+
+// DiffStats returns the changed counts between two runs, sorted by dimension then by value.
+func DiffStats(from, to *RunStats) []StatsDelta {
+	var deltas []StatsDelta
+	for _, dim := range StatsDimensions {
+		values := map[string]bool{}
+		for v := range from.Counts[dim] {
+			values[v] = true
+		}
+		for v := range to.Counts[dim] {
+			values[v] = true
+		}
+
+		sorted := make([]string, 0, len(values))
+		for v := range values {
+			sorted = append(sorted, v)
+		}
+		sort.Strings(sorted)
+
+		for _, v := range sorted {
+			d := StatsDelta{Dimension: dim, Value: v, From: from.Counts[dim][v], To: to.Counts[dim][v]}
+			if d.Delta() != 0 {
+				deltas = append(deltas, d)
+			}
+		}
+	}
+
+	return deltas
+}
+
+// WriteCSV writes a row per run, dimension and value: time,revision,dimension,value,count.
+// The totals of the runs have the dimension "total".
+func (s *Stats) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"time", "revision", "dimension", "value", "count"}); err != nil {
+		return err
+	}
+
+	for i := range s.Runs {
+		rs := &s.Runs[i]
+		t := rs.Time.Format(time.RFC3339)
+
+		if err := cw.Write([]string{t, rs.Revision, "total", "", strconv.Itoa(rs.Total)}); err != nil {
+			return err
+		}
+
+		for _, dim := range StatsDimensions {
+			values := make([]string, 0, len(rs.Counts[dim]))
+			for v := range rs.Counts[dim] {
+				values = append(values, v)
+			}
+			sort.Strings(values)
+
+			for _, v := range values {
+				if err := cw.Write([]string{t, rs.Revision, dim, v, strconv.Itoa(rs.Counts[dim][v])}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
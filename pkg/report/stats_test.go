@@ -0,0 +1,70 @@
+package report
+
+import (
+	"bytes"
+	"go/token"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newStatsIssue(linter, severity, file string) result.Issue {
+	return result.Issue{FromLinter: linter, Severity: severity, Pos: token.Position{Filename: file}}
+}
+
+func TestStats(t *testing.T) {
+	first := NewRunStats(time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC), "abc", []result.Issue{
+		newStatsIssue("errcheck", "error", "pkg/a/a.go"),
+		newStatsIssue("errcheck", "error", "pkg/a/b.go"),
+		newStatsIssue("govet", "", "pkg/b/b.go"),
+	})
+	second := NewRunStats(time.Date(2022, 10, 2, 12, 0, 0, 0, time.UTC), "def", []result.Issue{
+		newStatsIssue("errcheck", "error", "pkg/a/a.go"),
+		newStatsIssue("govet", "", "pkg/b/b.go"),
+		newStatsIssue("unused", "warning", "pkg/b/b.go"),
+	})
+
+	assert.Equal(t, map[string]map[string]int{
+		StatsByLinter:   {"errcheck": 2, "govet": 1},
+		StatsBySeverity: {"error": 2},
+		StatsByPackage:  {"pkg/a": 2, "pkg/b": 1},
+	}, first.Counts)
+
+	assert.Equal(t, []StatsDelta{
+		{Dimension: StatsByLinter, Value: "errcheck", From: 2, To: 1},
+		{Dimension: StatsByLinter, Value: "unused", From: 0, To: 1},
+		{Dimension: StatsBySeverity, Value: "error", From: 2, To: 1},
+		{Dimension: StatsBySeverity, Value: "warning", From: 0, To: 1},
+		{Dimension: StatsByPackage, Value: "pkg/a", From: 2, To: 1},
+		{Dimension: StatsByPackage, Value: "pkg/b", From: 1, To: 2},
+	}, DiffStats(&first, &second))
+
+	path := filepath.Join(t.TempDir(), "stats.json")
+
+	s, err := ReadStats(path)
+	require.NoError(t, err)
+	assert.Empty(t, s.Runs)
+
+	s.Runs = append(s.Runs, first, second)
+	require.NoError(t, WriteStats(path, s))
+
+	s, err = ReadStats(path)
+	require.NoError(t, err)
+	assert.Equal(t, []RunStats{first, second}, s.Runs)
+
+	var buf bytes.Buffer
+	require.NoError(t, (&Stats{Runs: []RunStats{first}}).WriteCSV(&buf))
+	assert.Equal(t, `time,revision,dimension,value,count
+2022-10-01T12:00:00Z,abc,total,,3
+2022-10-01T12:00:00Z,abc,linter,errcheck,2
+2022-10-01T12:00:00Z,abc,linter,govet,1
+2022-10-01T12:00:00Z,abc,severity,error,2
+2022-10-01T12:00:00Z,abc,package,pkg/a,2
+2022-10-01T12:00:00Z,abc,package,pkg/b,1
+`, buf.String())
+}
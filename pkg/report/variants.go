@@ -0,0 +1,50 @@
+package report
+
+import (
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// MergeVariants merges the issues of the runs of the build tag variants, in the order of the variants:
+// the identical issues are reported once, and the issues not reported by all the variants are labeled with their variants.
+func MergeVariants(variants []string, runs [][]result.Issue) []result.Issue {
+	type found struct {
+		index    int // in merged
+		variants []string
+	}
+
+	// The same issue can be reported several times by a run, e.g. on several columns of a line:
+	// the n-th occurrences of the runs are the same issue.
+	type key struct {
+		ref IssueRef
+		n   int
+	}
+
+	var merged []result.Issue
+	seen := map[key]*found{}
+
+	for v, issues := range runs {
+		refs := NewIssueRefs(issues)
+
+		occurrences := map[IssueRef]int{}
+		for i, ref := range refs {
+			occurrences[ref]++
+			k := key{ref: ref, n: occurrences[ref]}
+
+			f := seen[k]
+			if f == nil {
+				f = &found{index: len(merged)}
+				seen[k] = f
+				merged = append(merged, issues[i])
+			}
+			f.variants = append(f.variants, variants[v])
+		}
+	}
+
+	for _, f := range seen {
+		if len(f.variants) != len(variants) {
+			merged[f.index].Variants = f.variants
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,41 @@
+package report
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestMergeVariants(t *testing.T) {
+	issue := func(path string, line int) result.Issue {
+		return result.Issue{FromLinter: "govet", Text: "unreachable code", Pos: token.Position{Filename: path, Line: line}}
+	}
+
+	runs := [][]result.Issue{
+		{issue("a.go", 1), issue("linux.go", 2), issue("dup.go", 3), issue("dup.go", 3)},
+		{issue("a.go", 1), issue("windows.go", 2), issue("dup.go", 3)},
+		{issue("a.go", 1), issue("linux.go", 2)},
+	}
+
+	merged := MergeVariants([]string{"linux", "windows", "linux,integration"}, runs)
+
+	type labeled struct {
+		path     string
+		variants []string
+	}
+	var got []labeled
+	for i := range merged {
+		got = append(got, labeled{path: merged[i].FilePath(), variants: merged[i].Variants})
+	}
+
+	assert.Equal(t, []labeled{
+		{path: "a.go"},
+		{path: "linux.go", variants: []string{"linux", "linux,integration"}},
+		{path: "dup.go", variants: []string{"linux", "windows"}},
+		{path: "dup.go", variants: []string{"linux"}},
+		{path: "windows.go", variants: []string{"windows"}},
+	}, got)
+}
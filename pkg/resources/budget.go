@@ -0,0 +1,93 @@
+package resources
+
+import (
+	"context"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+)
+
+// MemoryBudget monitors the memory used by the process: once the budget is exceeded,
+// the analysis is degraded to use less memory instead of being killed by the OOM killer.
+// The methods of a nil budget are valid: it's never exceeded.
+type MemoryBudget struct {
+	limit    uint64
+	used     uint64 // at the breach, accessed atomically
+	exceeded chan struct{}
+}
+
+// NewMemoryBudget returns the budget of limitMB MiB, or nil if limitMB isn't positive.
+func NewMemoryBudget(limitMB int) *MemoryBudget {
+	if limitMB <= 0 {
+		return nil
+	}
+
+	return &MemoryBudget{limit: uint64(limitMB) * BytesPerMB, exceeded: make(chan struct{})}
+}
+
+// Monitor checks the memory used by the process at each interval,
+// until the budget is exceeded or the context is done.
+// It returns true if the budget was exceeded.
+func (b *MemoryBudget) Monitor(ctx context.Context, interval time.Duration) bool {
+	if b == nil {
+		return false
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if used := usedMemory(); used > b.limit {
+			atomic.StoreUint64(&b.used, used)
+			close(b.exceeded)
+
+			// Return the freed memory to the OS now, the degraded analysis allocates less.
+			debug.FreeOSMemory()
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// Exceeded returns a channel closed once the budget is exceeded, nil for a nil budget.
+func (b *MemoryBudget) Exceeded() <-chan struct{} {
+	if b == nil {
+		return nil
+	}
+	return b.exceeded
+}
+
+// IsExceeded checks if the budget was exceeded.
+func (b *MemoryBudget) IsExceeded() bool {
+	if b == nil {
+		return false
+	}
+
+	select {
+	case <-b.exceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// LimitMB returns the budget in MiB.
+func (b *MemoryBudget) LimitMB() uint64 {
+	if b == nil {
+		return 0
+	}
+	return b.limit / BytesPerMB
+}
+
+// UsedMB returns the memory used at the breach of the budget in MiB.
+func (b *MemoryBudget) UsedMB() uint64 {
+	if b == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&b.used) / BytesPerMB
+}
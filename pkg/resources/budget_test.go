@@ -0,0 +1,43 @@
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryBudget_Monitor(t *testing.T) {
+	b := NewMemoryBudget(1)
+	assert.False(t, b.IsExceeded())
+
+	assert.True(t, b.Monitor(context.Background(), time.Millisecond))
+	assert.True(t, b.IsExceeded())
+	assert.Greater(t, b.UsedMB(), b.LimitMB())
+
+	select {
+	case <-b.Exceeded():
+	default:
+		t.Fatal("the channel should be closed")
+	}
+}
+
+func TestMemoryBudget_Monitor_notExceeded(t *testing.T) {
+	b := NewMemoryBudget(1 << 20)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.False(t, b.Monitor(ctx, time.Millisecond))
+	assert.False(t, b.IsExceeded())
+}
+
+func TestMemoryBudget_nil(t *testing.T) {
+	b := NewMemoryBudget(0)
+	assert.Nil(t, b)
+
+	assert.False(t, b.Monitor(context.Background(), time.Millisecond))
+	assert.False(t, b.IsExceeded())
+	assert.Nil(t, b.Exceeded())
+}
@@ -0,0 +1,11 @@
+//go:build go1.19
+
+package resources
+
+import "runtime/debug"
+
+// SetMemoryLimit sets the soft memory limit of the runtime, like `GOMEMLIMIT`.
+func SetMemoryLimit(limit int64) bool {
+	debug.SetMemoryLimit(limit)
+	return true
+}
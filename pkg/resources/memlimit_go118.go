@@ -0,0 +1,8 @@
+//go:build !go1.19
+
+package resources
+
+// SetMemoryLimit is not supported before go1.19: the limit is ignored.
+func SetMemoryLimit(_ int64) bool {
+	return false
+}
@@ -0,0 +1,51 @@
+package resources
+
+import "runtime/metrics"
+
+const (
+	totalMemoryMetric    = "/memory/classes/total:bytes"
+	releasedMemoryMetric = "/memory/classes/heap/released:bytes"
+	heapObjectsMetric    = "/memory/classes/heap/objects:bytes"
+	heapAllocsMetric     = "/gc/heap/allocs:bytes"
+)
+
+// BytesPerMB is the count of bytes of a MiB.
+const BytesPerMB = 1 << 20
+
+// HeapAllocs returns the cumulative bytes allocated by the process.
+func HeapAllocs() uint64 {
+	return readMetrics(heapAllocsMetric)[0]
+}
+
+// AllocatedMB returns the cumulative memory allocated by the process, in MiB.
+func AllocatedMB() uint64 {
+	return HeapAllocs() / BytesPerMB
+}
+
+// HeapMB returns the memory of the live and not yet collected objects of the heap, in MiB.
+func HeapMB() uint64 {
+	return readMetrics(heapObjectsMetric)[0] / BytesPerMB
+}
+
+// usedMemory returns the memory mapped by the Go runtime and not released to the OS.
+func usedMemory() uint64 {
+	values := readMetrics(totalMemoryMetric, releasedMemoryMetric)
+	return values[0] - values[1]
+}
+
+// readMetrics returns the values of the runtime metrics, 0 for the unsupported ones.
+func readMetrics(names ...string) []uint64 {
+	samples := make([]metrics.Sample, len(names))
+	for i, name := range names {
+		samples[i].Name = name
+	}
+	metrics.Read(samples)
+
+	values := make([]uint64, len(samples))
+	for i, s := range samples {
+		if s.Value.Kind() == metrics.KindUint64 {
+			values[i] = s.Value.Uint64()
+		}
+	}
+	return values
+}
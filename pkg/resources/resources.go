@@ -0,0 +1,188 @@
+// Package resources detects the CPU and memory limits of the container golangci-lint runs in.
+package resources
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	cgroupRoot = "/sys/fs/cgroup"
+	procCgroup = "/proc/self/cgroup"
+)
+
+// Limits are the resources available to the process: zero values mean no limit.
+type Limits struct {
+	// Memory is the memory limit in bytes.
+	Memory int64
+	// CPU is the CPU quota in count of CPUs.
+	CPU float64
+}
+
+// Detect reads the limits set by cgroups v2 or cgroups v1 on the cgroup of the process and on its parents.
+func Detect() Limits {
+	data, _ := os.ReadFile(procCgroup)
+	return detect(cgroupRoot, string(data))
+}
+
+// Procs returns the count of threads making use of the CPU quota.
+func (l Limits) Procs() int {
+	if l.CPU <= 0 {
+		return 0
+	}
+
+	return int(math.Max(1, math.Floor(l.CPU)))
+}
+
+func detect(root, procCgroupContent string) Limits {
+	paths := parseProcCgroup(procCgroupContent)
+
+	// cgroups v2: a single hierarchy.
+	if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err == nil {
+		var limits Limits
+		for _, dir := range cgroupDirs(root, paths[""]) {
+			limits.Memory = lowest(limits.Memory, readMemoryV2(filepath.Join(dir, "memory.max")))
+			limits.CPU = lowestCPU(limits.CPU, readCPUV2(filepath.Join(dir, "cpu.max")))
+		}
+		return limits
+	}
+
+	var limits Limits
+	for _, dir := range cgroupDirs(filepath.Join(root, "memory"), paths["memory"]) {
+		limits.Memory = lowest(limits.Memory, readMemoryV1(filepath.Join(dir, "memory.limit_in_bytes")))
+	}
+	for _, dir := range cgroupDirs(filepath.Join(root, "cpu"), paths["cpu"]) {
+		limits.CPU = lowestCPU(limits.CPU, readCPUV1(filepath.Join(dir, "cpu.cfs_quota_us"), filepath.Join(dir, "cpu.cfs_period_us")))
+	}
+	return limits
+}
+
+// parseProcCgroup parses `/proc/self/cgroup`: `$ID:$CONTROLLERS:$PATH` lines.
+// It returns the paths of the cgroups of the process by controller, the empty controller is the cgroups v2 hierarchy.
+func parseProcCgroup(content string) map[string]string {
+	paths := map[string]string{}
+	for _, line := range strings.Split(content, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		for _, controller := range strings.Split(parts[1], ",") {
+			paths[controller] = parts[2]
+		}
+	}
+	return paths
+}
+
+// cgroupDirs returns the directory of the cgroup of the process in the hierarchy mounted at mount, then its parents.
+// Only the mount is returned if the cgroup isn't in the mount, e.g. the mount is the cgroup of a container.
+func cgroupDirs(mount, path string) []string {
+	dir := filepath.Join(mount, filepath.FromSlash(path))
+	if !strings.HasPrefix(dir, mount+string(filepath.Separator)) {
+		return []string{mount}
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return []string{mount}
+	}
+
+	dirs := []string{dir}
+	for dir != mount {
+		dir = filepath.Dir(dir)
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// lowest returns the lowest limit, the zero values are no limit.
+func lowest(a, b int64) int64 {
+	if a == 0 || b != 0 && b < a {
+		return b
+	}
+	return a
+}
+
+func lowestCPU(a, b float64) float64 {
+	if a == 0 || b != 0 && b < a {
+		return b
+	}
+	return a
+}
+
+// readMemoryV2 parses `memory.max`: `max` or a count of bytes.
+func readMemoryV2(path string) int64 {
+	v, ok := readInt(path)
+	if !ok || v <= 0 {
+		return 0
+	}
+
+	return v
+}
+
+// readCPUV2 parses `cpu.max`: `$MAX $PERIOD`, `$MAX` is `max` without quota.
+func readCPUV2(path string) float64 {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 {
+		return 0
+	}
+
+	quota, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || quota <= 0 {
+		return 0
+	}
+
+	period, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil || period <= 0 {
+		return 0
+	}
+
+	return float64(quota) / float64(period)
+}
+
+// readMemoryV1 parses `memory.limit_in_bytes`: a huge value is set without limit.
+func readMemoryV1(path string) int64 {
+	const noLimit = math.MaxInt64 / 2
+
+	v, ok := readInt(path)
+	if !ok || v <= 0 || v >= noLimit {
+		return 0
+	}
+
+	return v
+}
+
+// readCPUV1 parses `cpu.cfs_quota_us` and `cpu.cfs_period_us`: the quota is -1 without limit.
+func readCPUV1(quotaPath, periodPath string) float64 {
+	quota, ok := readInt(quotaPath)
+	if !ok || quota <= 0 {
+		return 0
+	}
+
+	period, ok := readInt(periodPath)
+	if !ok || period <= 0 {
+		return 0
+	}
+
+	return float64(quota) / float64(period)
+}
+
+func readInt(path string) (int64, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+
+	v, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return v, true
+}
@@ -0,0 +1,129 @@
+package resources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	return root
+}
+
+func TestDetect(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		files      map[string]string
+		procCgroup string
+		expected   Limits
+	}{
+		{
+			desc: "v2 limits",
+			files: map[string]string{
+				"cgroup.controllers": "cpu memory",
+				"memory.max":         "2147483648\n",
+				"cpu.max":            "250000 100000\n",
+			},
+			expected: Limits{Memory: 2147483648, CPU: 2.5},
+		},
+		{
+			desc: "v2 no limits",
+			files: map[string]string{
+				"cgroup.controllers": "cpu memory",
+				"memory.max":         "max\n",
+				"cpu.max":            "max 100000\n",
+			},
+		},
+		{
+			desc: "v1 limits",
+			files: map[string]string{
+				"memory/memory.limit_in_bytes": "1073741824\n",
+				"cpu/cpu.cfs_quota_us":         "50000\n",
+				"cpu/cpu.cfs_period_us":        "100000\n",
+			},
+			expected: Limits{Memory: 1073741824, CPU: 0.5},
+		},
+		{
+			desc: "v1 no limits",
+			files: map[string]string{
+				"memory/memory.limit_in_bytes": "9223372036854771712\n",
+				"cpu/cpu.cfs_quota_us":         "-1\n",
+				"cpu/cpu.cfs_period_us":        "100000\n",
+			},
+		},
+		{
+			desc: "v2 nested",
+			files: map[string]string{
+				"cgroup.controllers": "cpu memory",
+				"memory.max":         "max\n",
+				"cpu.max":            "max 100000\n",
+				"ci/memory.max":      "4294967296\n",
+				"ci/cpu.max":         "max 100000\n",
+				"ci/job/memory.max":  "max\n",
+				"ci/job/cpu.max":     "150000 100000\n",
+				"other/memory.max":   "1073741824\n",
+			},
+			procCgroup: "0::/ci/job\n",
+			expected:   Limits{Memory: 4294967296, CPU: 1.5},
+		},
+		{
+			desc: "v2 cgroup namespace",
+			files: map[string]string{
+				"cgroup.controllers": "cpu memory",
+				"memory.max":         "2147483648\n",
+				"cpu.max":            "max 100000\n",
+			},
+			procCgroup: "0::/container\n",
+			expected:   Limits{Memory: 2147483648},
+		},
+		{
+			desc: "v1 nested",
+			files: map[string]string{
+				"memory/memory.limit_in_bytes":            "9223372036854771712\n",
+				"memory/docker/abc/memory.limit_in_bytes": "536870912\n",
+				"cpu/cpu.cfs_quota_us":                    "-1\n",
+				"cpu/cpu.cfs_period_us":                   "100000\n",
+				"cpu/docker/abc/cpu.cfs_quota_us":         "200000\n",
+				"cpu/docker/abc/cpu.cfs_period_us":        "100000\n",
+			},
+			procCgroup: "5:memory:/docker/abc\n3:cpu,cpuacct:/docker/abc\n0::/\n",
+			expected:   Limits{Memory: 536870912, CPU: 2},
+		},
+		{
+			desc:       "v1 escaping path",
+			files:      map[string]string{"memory/memory.limit_in_bytes": "1073741824\n"},
+			procCgroup: "5:memory:/../..\n",
+			expected:   Limits{Memory: 1073741824},
+		},
+		{
+			desc: "no cgroups",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, detect(writeFiles(t, test.files), test.procCgroup))
+		})
+	}
+}
+
+func TestLimitsProcs(t *testing.T) {
+	assert.Equal(t, 0, Limits{}.Procs())
+	assert.Equal(t, 1, Limits{CPU: 0.5}.Procs())
+	assert.Equal(t, 2, Limits{CPU: 2.5}.Procs())
+}
@@ -2,8 +2,10 @@ package result
 
 import (
 	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
 	"fmt"
 	"go/token"
+	"strings"
 
 	"golang.org/x/tools/go/packages"
 )
@@ -24,15 +26,30 @@ type InlineFix struct {
 	NewString string
 }
 
+// SourceContext are the source lines From-To (1-based, inclusive) around an issue.
+type SourceContext struct {
+	From  int
+	To    int
+	Lines []string
+}
+
 type Issue struct {
 	FromLinter string
-	Text       string
+	// RuleID is the stable identifier of the check of the linter reporting the issue, e.g. SA4006 for staticcheck.
+	// It's the name of the linter for the linters without rules.
+	RuleID string `json:",omitempty"`
+	// DocumentationURL is the URL of the documentation of the rule, or of the linter.
+	DocumentationURL string `json:",omitempty"`
+	Text             string
 
 	Severity string
 
 	// Source lines of a code with the issue to show
 	SourceLines []string
 
+	// SourceContext are the source lines around the issue, with output.print-issued-lines-context.
+	SourceContext *SourceContext `json:",omitempty"`
+
 	// If we know how to fix the issue we can provide replacement lines
 	Replacement *Replacement
 
@@ -49,6 +66,22 @@ type Issue struct {
 	// If we are expecting a nolint (because this is from nolintlint), record the expected linter
 	ExpectNoLint         bool
 	ExpectedNoLintLinter string
+
+	// Duplicates are the checks of other linters reporting the same issue, "linter:rule", removed by issues.dedup.
+	Duplicates []string `json:",omitempty"`
+
+	// WarnOnly tells if the issue is reported by a linter of linters.warn-only: it doesn't fail the run.
+	WarnOnly bool `json:",omitempty"`
+
+	// Covered tells if the line of the issue is executed by the tests of the coverage profile,
+	// it's nil without profile or if the line isn't a statement.
+	Covered *bool `json:",omitempty"`
+
+	// Owners are the owners of the file of the issue in the CODEOWNERS file, set by issues.code-owners.
+	Owners []string `json:",omitempty"`
+
+	// Variants are the build tag sets of run.build-tag-sets reporting the issue, if not all of them report it.
+	Variants []string `json:",omitempty"`
 }
 
 func (i *Issue) FilePath() string {
@@ -85,14 +118,53 @@ func (i *Issue) Description() string {
 	return fmt.Sprintf("%s: %s", i.FromLinter, i.Text)
 }
 
-func (i *Issue) Fingerprint() string {
-	firstLine := ""
+// Identity identifies an issue between two runs or two reports.
+type Identity struct {
+	FromLinter string
+	Text       string
+	Path       string
+	Line       int
+	// Source is the first source line of the issue, if the source lines are set.
+	Source string `json:",omitempty"`
+}
+
+// WithoutLine returns the identity without the line: the lines shift with the edits of the file.
+func (id Identity) WithoutLine() Identity {
+	id.Line = 0
+	return id
+}
+
+// Identity returns the linter, the path, the text, the line and the source line of the issue.
+func (i *Issue) Identity() Identity {
+	id := Identity{
+		FromLinter: i.FromLinter,
+		Text:       i.Text,
+		Path:       i.FilePath(),
+		Line:       i.Line(),
+	}
 	if len(i.SourceLines) > 0 {
-		firstLine = i.SourceLines[0]
+		id.Source = i.SourceLines[0]
 	}
 
+	return id
+}
+
+// Fingerprint hashes the identity of the issue without its linter and its line.
+func (i *Issue) Fingerprint() string {
+	id := i.Identity()
+
 	hash := md5.New() //nolint:gosec
-	_, _ = fmt.Fprintf(hash, "%s%s%s", i.Pos.Filename, i.Text, firstLine)
+	_, _ = fmt.Fprintf(hash, "%s%s%s", id.Path, id.Text, id.Source)
 
 	return fmt.Sprintf("%X", hash.Sum(nil))
 }
+
+// StableHash hashes the linter, the path, the text and the source line of the issue, without its line number:
+// the hash doesn't change with the unrelated edits of the file. The line is the source line of the issue.
+// It identifies the issues of the baselines and of the suppressions.
+func (i *Issue) StableHash(line string) string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s", i.FromLinter, i.FilePath(), i.Text, strings.TrimSpace(line))
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -2,13 +2,18 @@ package processors
 
 import (
 	"fmt"
+	"go/ast"
 	"go/parser"
 	"go/token"
 	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/gobwas/glob"
 	"github.com/pkg/errors"
 
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
@@ -21,14 +26,42 @@ type ageFileSummary struct {
 
 type ageFileSummaryCache map[string]*ageFileSummary
 
+// strictGeneratedRe is the comment of the generated files of https://golang.org/s/generatedcode.
+var strictGeneratedRe = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
 type AutogeneratedExclude struct {
+	mode    string
+	markers []string
+	paths   []glob.Glob
+
 	fileSummaryCache ageFileSummaryCache
 }
 
-func NewAutogeneratedExclude() *AutogeneratedExclude {
-	return &AutogeneratedExclude{
+// NewAutogeneratedExclude excludes the issues of the generated files detected by the mode
+// (config.ExcludeGeneratedLax by default), by the additional markers of their comments, or by the globs of their paths.
+func NewAutogeneratedExclude(mode string, markers, paths []string) (*AutogeneratedExclude, error) {
+	if mode == "" {
+		mode = config.ExcludeGeneratedLax
+	}
+
+	p := &AutogeneratedExclude{
+		mode:             mode,
 		fileSummaryCache: ageFileSummaryCache{},
 	}
+
+	for _, marker := range markers {
+		p.markers = append(p.markers, strings.ToLower(marker))
+	}
+
+	for _, path := range paths {
+		g, err := glob.Compile(filepath.ToSlash(path), '/')
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid generated path glob %q", path)
+		}
+		p.paths = append(p.paths, g)
+	}
+
+	return p, nil
 }
 
 var _ Processor = &AutogeneratedExclude{}
@@ -53,10 +86,18 @@ func (p *AutogeneratedExclude) shouldPassIssue(i *result.Issue) (bool, error) {
 		return true, nil
 	}
 
+	if p.mode == config.ExcludeGeneratedDisable {
+		return true, nil
+	}
+
 	if filepath.Base(i.FilePath()) == "go.mod" {
 		return true, nil
 	}
 
+	if p.matchPath(i.FilePath()) {
+		return false, nil
+	}
+
 	if isSpecialAutogeneratedFile(i.FilePath()) {
 		return false, nil
 	}
@@ -70,6 +111,17 @@ func (p *AutogeneratedExclude) shouldPassIssue(i *result.Issue) (bool, error) {
 	return !fs.isGenerated, nil
 }
 
+func (p *AutogeneratedExclude) matchPath(path string) bool {
+	path = filepath.ToSlash(path)
+	for _, g := range p.paths {
+		if g.Match(path) {
+			autogenDebugf("path %q matches a generated path glob: file is generated", path)
+			return true
+		}
+	}
+	return false
+}
+
 // isGenerated reports whether the source file is generated code.
 // Using a bit laxer rules than https://golang.org/s/generatedcode to
 // match more generated code. See #48 and #72.
@@ -106,29 +158,77 @@ func (p *AutogeneratedExclude) getOrCreateFileSummary(i *result.Issue) (*ageFile
 		return nil, fmt.Errorf("no file path for issue")
 	}
 
-	doc, err := getDoc(i.FilePath())
+	syntax, err := parseFileComments(i.FilePath())
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get doc of file %s", i.FilePath())
 	}
 
-	fs.isGenerated = isGeneratedFileByComment(doc)
+	doc := docOfComments(syntax)
+	if p.mode == config.ExcludeGeneratedStrict {
+		fs.isGenerated = hasStrictGeneratedComment(syntax)
+	} else {
+		fs.isGenerated = isGeneratedFileByComment(doc)
+	}
+	fs.isGenerated = fs.isGenerated || p.hasMarker(doc)
 	autogenDebugf("file %q is generated: %t", i.FilePath(), fs.isGenerated)
 	return fs, nil
 }
 
+// hasMarker checks if the doc contains one of the additional markers of the generated files.
+func (p *AutogeneratedExclude) hasMarker(doc string) bool {
+	doc = strings.ToLower(doc)
+	for _, marker := range p.markers {
+		if strings.Contains(doc, marker) {
+			autogenDebugf("doc contains custom marker %q: file is generated", marker)
+			return true
+		}
+	}
+	return false
+}
+
+// hasStrictGeneratedComment checks if a line comment before the package clause
+// matches https://golang.org/s/generatedcode exactly.
+func hasStrictGeneratedComment(syntax *ast.File) bool {
+	for _, cg := range syntax.Comments {
+		if cg.Pos() > syntax.Package {
+			break
+		}
+		for _, c := range cg.List {
+			if strictGeneratedRe.MatchString(c.Text) {
+				autogenDebugf("comment %q matches the generated code convention: file is generated", c.Text)
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func getDoc(filePath string) (string, error) {
+	syntax, err := parseFileComments(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	return docOfComments(syntax), nil
+}
+
+func parseFileComments(filePath string) (*ast.File, error) {
 	fset := token.NewFileSet()
-	syntax, err := parser.ParseFile(fset, filePath, nil, parser.PackageClauseOnly|parser.ParseComments)
+	syntax, err := parser.ParseFile(fset, filePath, fsutils.ParserSource(filePath), parser.PackageClauseOnly|parser.ParseComments)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to parse file")
+		return nil, errors.Wrap(err, "failed to parse file")
 	}
 
+	return syntax, nil
+}
+
+func docOfComments(syntax *ast.File) string {
 	var docLines []string
 	for _, c := range syntax.Comments {
 		docLines = append(docLines, strings.TrimSpace(c.Text()))
 	}
 
-	return strings.Join(docLines, "\n"), nil
+	return strings.Join(docLines, "\n")
 }
 
 func (p AutogeneratedExclude) Finish() {}
@@ -1,11 +1,17 @@
 package processors
 
 import (
+	"go/token"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/result"
 )
 
 func TestIsAutogeneratedDetection(t *testing.T) {
@@ -111,3 +117,51 @@ func TestGetDocFileWithLongLine(t *testing.T) {
 	_, err := getDoc(fpath)
 	assert.NoError(t, err)
 }
+
+func TestAutogeneratedExclude_modes(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+		return p
+	}
+
+	strict := write("strict.go", "// Code generated by stringer; DO NOT EDIT.\n\npackage p\n")
+	lax := write("lax.go", "// This file was generated, do not edit.\n\npackage p\n")
+	custom := write("custom.go", "// @generated by protoc.\n\npackage p\n")
+	afterPackage := write("after.go", "package p\n\n// Code generated by stringer; DO NOT EDIT.\n")
+	mock := write(filepath.Join("mocks", "store.go"), "package mocks\n")
+
+	testCases := []struct {
+		mode      string
+		generated map[string]bool
+	}{
+		{
+			mode:      "",
+			generated: map[string]bool{strict: true, lax: true, custom: true, afterPackage: true, mock: true},
+		},
+		{
+			mode:      config.ExcludeGeneratedStrict,
+			generated: map[string]bool{strict: true, lax: false, custom: true, afterPackage: false, mock: true},
+		},
+		{
+			mode:      config.ExcludeGeneratedDisable,
+			generated: map[string]bool{strict: false, lax: false, custom: false, afterPackage: false, mock: false},
+		},
+	}
+
+	for _, test := range testCases {
+		p, err := NewAutogeneratedExclude(test.mode, []string{"@Generated"}, []string{filepath.Join(dir, "mocks", "*.go")})
+		require.NoError(t, err)
+
+		for path, generated := range test.generated {
+			pass, err := p.shouldPassIssue(&result.Issue{FromLinter: "errcheck", Pos: token.Position{Filename: path}})
+			require.NoError(t, err)
+			assert.Equal(t, !generated, pass, "mode %q, file %s", test.mode, filepath.Base(path))
+		}
+	}
+
+	_, err := NewAutogeneratedExclude("", nil, []string{"mocks/[*.go"})
+	assert.Error(t, err)
+}
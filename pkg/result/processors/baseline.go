@@ -0,0 +1,139 @@
+package processors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const baselineFileMode = 0o644
+
+// BaselineEntry is an issue accepted by the baseline.
+// The hash doesn't depend on the line number to survive unrelated edits of the file.
+type BaselineEntry struct {
+	FromLinter string `json:"linter"`
+	Path       string `json:"path"`
+	Text       string `json:"text"`
+	Hash       string `json:"hash"`
+}
+
+type baselineFile struct {
+	Issues []BaselineEntry `json:"issues"`
+}
+
+// Baseline hides issues recorded in a baseline file
+// and keeps track of the other ones to be able to create a new baseline.
+type Baseline struct {
+	path      string
+	lineCache *fsutils.LineCache
+	log       logutils.Log
+
+	known   map[string]int
+	matched int
+	unknown []BaselineEntry
+}
+
+var _ Processor = &Baseline{}
+
+func NewBaseline(path string, lineCache *fsutils.LineCache, log logutils.Log) (*Baseline, error) {
+	p := &Baseline{
+		path:      path,
+		lineCache: lineCache,
+		log:       log,
+		known:     map[string]int{},
+	}
+
+	if path == "" {
+		return p, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			log.Warnf("Baseline file %s doesn't exist: all issues are reported", path)
+			return p, nil
+		}
+		return nil, fmt.Errorf("can't read baseline file: %w", err)
+	}
+
+	var f baselineFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("can't parse baseline file %s: %w", path, err)
+	}
+
+	for _, e := range f.Issues {
+		p.known[e.Hash]++
+	}
+
+	return p, nil
+}
+
+func (p Baseline) Name() string {
+	return "baseline"
+}
+
+func (p *Baseline) Process(issues []result.Issue) ([]result.Issue, error) {
+	return filterIssues(issues, func(i *result.Issue) bool {
+		e := p.entry(i)
+
+		// The same issue can appear several times in a file:
+		// every occurrence consumes one slot of the baseline.
+		if p.known[e.Hash] > 0 {
+			p.known[e.Hash]--
+			p.matched++
+			return false
+		}
+
+		p.unknown = append(p.unknown, e)
+		return true
+	}), nil
+}
+
+func (p Baseline) Finish() {
+	if p.matched != 0 {
+		p.log.Infof("%d issues were hidden by the baseline %s", p.matched, p.path)
+	}
+}
+
+// Unknown returns the count of issues not recorded in the baseline.
+func (p Baseline) Unknown() int {
+	return len(p.unknown)
+}
+
+// Save writes all issues not recorded in the baseline to the file on path.
+func (p Baseline) Save(path string) error {
+	entries := append([]BaselineEntry{}, p.unknown...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Path != entries[j].Path {
+			return entries[i].Path < entries[j].Path
+		}
+		return entries[i].Hash < entries[j].Hash
+	})
+
+	data, err := json.MarshalIndent(baselineFile{Issues: entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), baselineFileMode)
+}
+
+func (p Baseline) entry(i *result.Issue) BaselineEntry {
+	line, err := p.lineCache.GetLine(i.FilePath(), i.Line())
+	if err != nil {
+		p.log.Infof("Failed to get line %d for file %s: %s", i.Line(), i.FilePath(), err)
+	}
+
+	return BaselineEntry{
+		FromLinter: i.FromLinter,
+		Path:       i.FilePath(),
+		Text:       i.Text,
+		Hash:       i.StableHash(line),
+	}
+}
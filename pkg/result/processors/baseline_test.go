@@ -0,0 +1,41 @@
+package processors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestBaseline(t *testing.T) {
+	lineCache := fsutils.NewLineCache(fsutils.NewFileCache())
+	log := logutils.NewStderrLog("")
+	file := filepath.Join("testdata", "exclude_rules.go")
+
+	issues := []result.Issue{
+		newIssueFromIssueTestCase(issueTestCase{Path: file, Line: 3, Linter: "lll", Text: "line too long"}),
+		newIssueFromIssueTestCase(issueTestCase{Path: file, Line: 3, Linter: "errcheck", Text: "unchecked"}),
+	}
+
+	empty, err := NewBaseline("", lineCache, log)
+	require.NoError(t, err)
+	processAssertSame(t, empty, issues...)
+	assert.Equal(t, 2, empty.Unknown())
+
+	basePath := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, empty.Save(basePath))
+
+	p, err := NewBaseline(basePath, lineCache, log)
+	require.NoError(t, err)
+
+	newIssue := newIssueFromIssueTestCase(issueTestCase{Path: file, Line: 3, Linter: "lll", Text: "other"})
+
+	processedIssues := process(t, p, issues[0], issues[1], issues[1], newIssue)
+	assert.Equal(t, []result.Issue{issues[1], newIssue}, processedIssues)
+	assert.Equal(t, 2, p.Unknown())
+}
@@ -0,0 +1,91 @@
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/codeowners"
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+var _ Processor = &CodeOwners{}
+
+// CodeOwners annotates the issues with the owners of their files in the CODEOWNERS file,
+// and keeps only the issues of some owners if needed.
+// The paths of the CODEOWNERS file are relative to the current directory, the root of the repository.
+type CodeOwners struct {
+	owners *codeowners.Owners // nil if disabled
+	filter []string
+}
+
+// NewCodeOwners reads the CODEOWNERS file of the settings: if disabled, the issues are unchanged.
+func NewCodeOwners(settings *config.CodeOwnersSettings, log logutils.Log) (*CodeOwners, error) {
+	p := &CodeOwners{filter: settings.Owners}
+	if !settings.Annotate && len(settings.Owners) == 0 {
+		return p, nil
+	}
+
+	owners, err := readCodeOwners(settings.File)
+	if err != nil {
+		return nil, fmt.Errorf("can't read CODEOWNERS: %w", err)
+	}
+
+	if owners == nil {
+		if len(settings.Owners) != 0 {
+			return nil, errors.New("can't filter the issues by owner: no CODEOWNERS file found")
+		}
+
+		log.Warnf("No CODEOWNERS file found: the issues aren't annotated with their owners")
+		return p, nil
+	}
+
+	p.owners = owners
+	return p, nil
+}
+
+func readCodeOwners(path string) (*codeowners.Owners, error) {
+	if path == "" {
+		return codeowners.Find(".")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return codeowners.Parse(f)
+}
+
+func (p CodeOwners) Name() string {
+	return "code_owners"
+}
+
+func (p *CodeOwners) Process(issues []result.Issue) ([]result.Issue, error) {
+	if p.owners == nil {
+		return issues, nil
+	}
+
+	return filterIssues(issues, func(issue *result.Issue) bool {
+		issue.Owners = p.owners.Of(issue.FilePath())
+		return len(p.filter) == 0 || p.isOwned(issue)
+	}), nil
+}
+
+// isOwned checks if the issue is owned by one of the owners of the filter: the names of teams and users are case-insensitive.
+func (p *CodeOwners) isOwned(issue *result.Issue) bool {
+	for _, owner := range issue.Owners {
+		for _, f := range p.filter {
+			if strings.EqualFold(owner, f) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (CodeOwners) Finish() {}
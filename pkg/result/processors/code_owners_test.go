@@ -0,0 +1,68 @@
+package processors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func TestCodeOwners(t *testing.T) {
+	settings := &config.CodeOwnersSettings{
+		Annotate: true,
+		File:     filepath.Join("testdata", "codeowners", "CODEOWNERS"),
+	}
+
+	p, err := NewCodeOwners(settings, logutils.NewStderrLog(""))
+	require.NoError(t, err)
+
+	processed := process(t, p,
+		newFileIssue(filepath.Join("payments", "charge.go")),
+		newFileIssue(filepath.Join("payments", "charge_test.go")),
+		newFileIssue("main.go"),
+	)
+	require.Len(t, processed, 3)
+
+	assert.Equal(t, []string{"@org/Team-Payments", "@alice"}, processed[0].Owners)
+	assert.Equal(t, []string{"@org/qa"}, processed[1].Owners)
+	assert.Equal(t, []string{"@org/platform"}, processed[2].Owners)
+}
+
+func TestCodeOwners_filter(t *testing.T) {
+	settings := &config.CodeOwnersSettings{
+		File:   filepath.Join("testdata", "codeowners", "CODEOWNERS"),
+		Owners: []string{"@org/team-payments"},
+	}
+
+	p, err := NewCodeOwners(settings, logutils.NewStderrLog(""))
+	require.NoError(t, err)
+
+	processed := process(t, p,
+		newFileIssue(filepath.Join("payments", "charge.go")),
+		newFileIssue(filepath.Join("payments", "charge_test.go")),
+		newFileIssue("main.go"),
+	)
+	require.Len(t, processed, 1)
+	assert.Equal(t, filepath.Join("payments", "charge.go"), processed[0].FilePath())
+}
+
+func TestCodeOwners_disabled(t *testing.T) {
+	p, err := NewCodeOwners(&config.CodeOwnersSettings{}, logutils.NewStderrLog(""))
+	require.NoError(t, err)
+
+	processAssertSame(t, p, newFileIssue("main.go"))
+}
+
+func TestCodeOwners_filterWithoutFile(t *testing.T) {
+	settings := &config.CodeOwnersSettings{
+		File:   filepath.Join("testdata", "codeowners", "missing"),
+		Owners: []string{"@org/team-payments"},
+	}
+
+	_, err := NewCodeOwners(settings, logutils.NewStderrLog(""))
+	require.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package processors
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/cover"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+var _ Processor = &Coverage{}
+
+// Coverage annotates the issues with the coverage of their lines by the tests,
+// and hides the covered ones if needed.
+type Coverage struct {
+	settings *config.CoverageSettings
+
+	profiles map[string]*cover.Profile // by import path of the file
+	byBase   map[string][]string       // import paths of the files by base name
+}
+
+// NewCoverage reads the coverage profile of the settings: without profile, the issues are unchanged.
+func NewCoverage(settings *config.CoverageSettings) (*Coverage, error) {
+	p := &Coverage{settings: settings}
+	if settings.Profile == "" {
+		return p, nil
+	}
+
+	profiles, err := cover.ParseProfiles(settings.Profile)
+	if err != nil {
+		return nil, fmt.Errorf("can't read coverage profile: %w", err)
+	}
+
+	p.profiles = map[string]*cover.Profile{}
+	p.byBase = map[string][]string{}
+	for _, profile := range profiles {
+		p.profiles[profile.FileName] = profile
+		base := path.Base(profile.FileName)
+		p.byBase[base] = append(p.byBase[base], profile.FileName)
+	}
+
+	return p, nil
+}
+
+func (p Coverage) Name() string {
+	return "coverage"
+}
+
+func (p *Coverage) Process(issues []result.Issue) ([]result.Issue, error) {
+	if p.profiles == nil {
+		return issues, nil
+	}
+
+	return filterIssues(issues, func(issue *result.Issue) bool {
+		issue.Covered = p.isCovered(issue)
+		return !p.settings.UncoveredOnly || issue.Covered == nil || !*issue.Covered
+	}), nil
+}
+
+// isCovered checks if a statement of the line of the issue is executed, nil is returned if there is no statement.
+func (p *Coverage) isCovered(issue *result.Issue) *bool {
+	profile := p.findProfile(issue)
+	if profile == nil {
+		return nil
+	}
+
+	var covered *bool
+	for _, b := range profile.Blocks {
+		if issue.Line() < b.StartLine || issue.Line() > b.EndLine {
+			continue
+		}
+
+		executed := b.Count > 0
+		if executed {
+			return &executed
+		}
+		covered = &executed
+	}
+
+	return covered
+}
+
+// findProfile returns the profile of the file of the issue: the profiles use the import paths of the files.
+func (p *Coverage) findProfile(issue *result.Issue) *cover.Profile {
+	file := filepath.ToSlash(issue.FilePath())
+	base := path.Base(file)
+
+	if issue.Pkg != nil {
+		if profile := p.profiles[issue.Pkg.PkgPath+"/"+base]; profile != nil {
+			return profile
+		}
+	}
+
+	var found *cover.Profile
+	for _, name := range p.byBase[base] {
+		if name == file || strings.HasSuffix(name, "/"+strings.TrimPrefix(file, "./")) {
+			if found != nil {
+				return nil // ambiguous
+			}
+			found = p.profiles[name]
+		}
+	}
+
+	return found
+}
+
+func (p Coverage) Finish() {}
@@ -0,0 +1,64 @@
+package processors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestCoverage(t *testing.T) {
+	file := filepath.Join("pkg", "a.go")
+	newIssue := func(line int) result.Issue {
+		return newIssueFromIssueTestCase(issueTestCase{Path: file, Line: line, Linter: "errcheck"})
+	}
+
+	settings := &config.CoverageSettings{Profile: filepath.Join("testdata", "coverage", "cover.out")}
+	p, err := NewCoverage(settings)
+	require.NoError(t, err)
+
+	withPkg := newIssue(8)
+	withPkg.Pos.Filename = filepath.Join("renamed", "a.go")
+	withPkg.Pkg = &packages.Package{PkgPath: "example.com/m/pkg"}
+
+	testCases := []struct {
+		desc     string
+		issue    result.Issue
+		expected *bool
+	}{
+		{desc: "covered", issue: newIssue(4), expected: newBool(true)},
+		{desc: "uncovered", issue: newIssue(8), expected: newBool(false)},
+		{desc: "covered by a nested block", issue: newIssue(12), expected: newBool(true)},
+		{desc: "no statement", issue: newIssue(1)},
+		{desc: "file of the package", issue: withPkg, expected: newBool(false)},
+		{desc: "unknown file", issue: newIssueFromIssueTestCase(issueTestCase{Path: "c.go", Line: 4})},
+	}
+
+	for _, test := range testCases {
+		processed := process(t, p, test.issue)
+		require.Len(t, processed, 1, test.desc)
+		assert.Equal(t, test.expected, processed[0].Covered, test.desc)
+	}
+
+	settings.UncoveredOnly = true
+	processed := process(t, p, newIssue(4), newIssue(8), newIssue(1))
+	require.Len(t, processed, 2)
+	assert.Equal(t, 8, processed[0].Line())
+	assert.Equal(t, 1, processed[1].Line())
+}
+
+func TestCoverage_noProfile(t *testing.T) {
+	p, err := NewCoverage(&config.CoverageSettings{UncoveredOnly: true})
+	require.NoError(t, err)
+
+	processAssertSame(t, p, newIssueFromIssueTestCase(issueTestCase{Path: "a.go", Line: 1}))
+}
+
+func newBool(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,144 @@
+package processors
+
+import (
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// dedupCheck is the position of a check in its equivalence: the canonical check has the rank 0.
+type dedupCheck struct {
+	equivalence int
+	rank        int
+}
+
+type dedupKey struct {
+	file        string
+	line        int
+	equivalence int
+}
+
+// Dedup keeps one issue of the issues of equivalent checks on the same line: the issue of the canonical check.
+// The checks of the removed duplicates are recorded in the kept issue.
+type Dedup struct {
+	checks  map[string]dedupCheck
+	log     logutils.Log
+	removed int
+}
+
+var _ Processor = (*Dedup)(nil)
+
+func NewDedup(cfg *config.DedupSettings, log logutils.Log) *Dedup {
+	p := &Dedup{checks: map[string]dedupCheck{}, log: log}
+	if !cfg.Enable {
+		return p
+	}
+
+	userChecks := map[string]bool{}
+	for _, e := range cfg.Equivalences {
+		for _, check := range e.Checks {
+			userChecks[check] = true
+		}
+	}
+
+	equivalences := append([]config.DedupEquivalence{}, cfg.Equivalences...)
+	for _, e := range config.DefaultDedupEquivalences {
+		var checks []string
+		for _, check := range e.Checks {
+			if !userChecks[check] {
+				checks = append(checks, check)
+			}
+		}
+		if len(checks) > 1 {
+			equivalences = append(equivalences, config.DedupEquivalence{Checks: checks})
+		}
+	}
+
+	for i, e := range equivalences {
+		for rank, check := range e.Checks {
+			p.checks[check] = dedupCheck{equivalence: i, rank: rank}
+		}
+	}
+
+	return p
+}
+
+func (Dedup) Name() string {
+	return "dedup"
+}
+
+func (p *Dedup) Process(issues []result.Issue) ([]result.Issue, error) {
+	if len(p.checks) == 0 {
+		return issues, nil
+	}
+
+	// the index of the issue of the best ranked check, by line and equivalence
+	kept := map[dedupKey]int{}
+	for i := range issues {
+		key, check, ok := p.key(&issues[i])
+		if !ok {
+			continue
+		}
+
+		if k, found := kept[key]; found {
+			if _, keptCheck, _ := p.key(&issues[k]); keptCheck.rank <= check.rank {
+				continue
+			}
+		}
+		kept[key] = i
+	}
+
+	duplicates := map[int][]string{}
+	for i := range issues {
+		key, _, ok := p.key(&issues[i])
+		if !ok || kept[key] == i {
+			continue
+		}
+		duplicates[kept[key]] = append(duplicates[kept[key]], dedupCheckName(&issues[i]))
+	}
+
+	var res []result.Issue
+	for i := range issues {
+		key, _, ok := p.key(&issues[i])
+		if ok && kept[key] != i {
+			p.removed++
+			continue
+		}
+
+		issue := issues[i]
+		if dups := duplicates[i]; len(dups) != 0 {
+			issue.Duplicates = append(append([]string{}, issue.Duplicates...), dups...)
+		}
+		res = append(res, issue)
+	}
+
+	return res, nil
+}
+
+func (p *Dedup) key(issue *result.Issue) (dedupKey, dedupCheck, bool) {
+	check, ok := p.checks[dedupCheckName(issue)]
+	if !ok {
+		check, ok = p.checks[issue.FromLinter]
+	}
+	if !ok {
+		return dedupKey{}, dedupCheck{}, false
+	}
+
+	return dedupKey{file: issue.FilePath(), line: issue.Line(), equivalence: check.equivalence}, check, true
+}
+
+// dedupCheckName returns the check of the issue: linter:rule, or the linter for the linters without rules.
+func dedupCheckName(issue *result.Issue) string {
+	if issue.RuleID == "" || strings.EqualFold(issue.RuleID, issue.FromLinter) {
+		return issue.FromLinter
+	}
+	return issue.FromLinter + ":" + issue.RuleID
+}
+
+func (p Dedup) Finish() {
+	if p.removed != 0 {
+		p.log.Infof("%d duplicated issues of equivalent checks were removed", p.removed)
+	}
+}
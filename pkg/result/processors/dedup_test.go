@@ -0,0 +1,76 @@
+package processors
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newDedupIssue(linter, rule string, line int) result.Issue {
+	return result.Issue{
+		FromLinter: linter,
+		RuleID:     rule,
+		Pos:        token.Position{Filename: "a.go", Line: line},
+	}
+}
+
+func TestDedup_Process(t *testing.T) {
+	p := NewDedup(&config.DedupSettings{Enable: true}, logutils.NewStderrLog(""))
+
+	issues := []result.Issue{
+		newDedupIssue("gosec", "G104", 10),
+		newDedupIssue("revive", "unhandled-error", 10),
+		newDedupIssue("errcheck", "errcheck", 10),
+		newDedupIssue("gosec", "G104", 11),
+		newDedupIssue("gosec", "G101", 10),
+		newDedupIssue("staticcheck", "SA4006", 10),
+	}
+
+	res, err := p.Process(issues)
+	require.NoError(t, err)
+
+	errcheck := newDedupIssue("errcheck", "errcheck", 10)
+	errcheck.Duplicates = []string{"gosec:G104", "revive:unhandled-error"}
+
+	expected := []result.Issue{
+		errcheck,
+		newDedupIssue("gosec", "G104", 11),
+		newDedupIssue("gosec", "G101", 10),
+		newDedupIssue("staticcheck", "SA4006", 10),
+	}
+	assert.Equal(t, expected, res)
+}
+
+func TestDedup_Process_equivalences(t *testing.T) {
+	p := NewDedup(&config.DedupSettings{
+		Enable: true,
+		Equivalences: []config.DedupEquivalence{
+			{Checks: []string{"gosec:G104", "errcheck"}},
+		},
+	}, logutils.NewStderrLog(""))
+
+	res, err := p.Process([]result.Issue{
+		newDedupIssue("errcheck", "", 10),
+		newDedupIssue("gosec", "G104", 10),
+		newDedupIssue("revive", "unhandled-error", 10),
+	})
+	require.NoError(t, err)
+
+	gosec := newDedupIssue("gosec", "G104", 10)
+	gosec.Duplicates = []string{"errcheck"}
+
+	// revive:unhandled-error stays in the default equivalence, without the checks of the configuration
+	assert.Equal(t, []result.Issue{gosec, newDedupIssue("revive", "unhandled-error", 10)}, res)
+}
+
+func TestDedup_Process_disabled(t *testing.T) {
+	p := NewDedup(&config.DedupSettings{}, logutils.NewStderrLog(""))
+
+	processAssertSame(t, p, newDedupIssue("errcheck", "", 10), newDedupIssue("gosec", "G104", 10))
+}
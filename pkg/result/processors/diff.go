@@ -2,6 +2,7 @@ package processors
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"github.com/golangci/revgrep"
 
 	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/vcs"
 )
 
 type Diff struct {
@@ -18,9 +20,13 @@ type Diff struct {
 	patchFilePath string
 	wholeFiles    bool
 	patch         string
+
+	// checker is prepared once: the issues are processed in batches by the streaming output.
+	checker    *revgrep.Checker
+	checkerErr error
 }
 
-var _ Processor = Diff{}
+var _ Processor = (*Diff)(nil)
 
 func NewDiff(onlyNew bool, fromRev, patchFilePath string, wholeFiles bool) *Diff {
 	return &Diff{
@@ -32,16 +38,37 @@ func NewDiff(onlyNew bool, fromRev, patchFilePath string, wholeFiles bool) *Diff
 	}
 }
 
-func (p Diff) Name() string {
+func (p *Diff) Name() string {
 	return "diff"
 }
 
-func (p Diff) Process(issues []result.Issue) ([]result.Issue, error) {
+func (p *Diff) Process(issues []result.Issue) ([]result.Issue, error) {
 	if !p.onlyNew && p.fromRev == "" && p.patchFilePath == "" && p.patch == "" { // no need to work
 		return issues, nil
 	}
 
+	if p.checker == nil && p.checkerErr == nil {
+		p.checker, p.checkerErr = p.prepareChecker()
+	}
+	if p.checkerErr != nil {
+		return nil, p.checkerErr
+	}
+
+	return transformIssues(issues, func(i *result.Issue) *result.Issue {
+		hunkPos, isNew := p.checker.IsNewIssue(i)
+		if !isNew {
+			return nil
+		}
+
+		newI := *i
+		newI.HunkPos = hunkPos
+		return &newI
+	}), nil
+}
+
+func (p *Diff) prepareChecker() (*revgrep.Checker, error) {
 	var patchReader io.Reader
+	var newFiles []string
 	if p.patchFilePath != "" {
 		patch, err := os.ReadFile(p.patchFilePath)
 		if err != nil {
@@ -50,10 +77,21 @@ func (p Diff) Process(issues []result.Issue) ([]result.Issue, error) {
 		patchReader = bytes.NewReader(patch)
 	} else if p.patch != "" {
 		patchReader = strings.NewReader(p.patch)
+	} else {
+		repo, err := vcs.Detect(".")
+		if err != nil {
+			return nil, fmt.Errorf("can't prepare diff: %w", err)
+		}
+
+		patchReader, newFiles, err = repo.Patch(context.Background(), p.fromRev)
+		if err != nil {
+			return nil, fmt.Errorf("can't get the patch from %s: %w", repo.Name(), err)
+		}
 	}
 
 	c := revgrep.Checker{
 		Patch:        patchReader,
+		NewFiles:     newFiles,
 		RevisionFrom: p.fromRev,
 		WholeFiles:   p.wholeFiles,
 	}
@@ -61,16 +99,7 @@ func (p Diff) Process(issues []result.Issue) ([]result.Issue, error) {
 		return nil, fmt.Errorf("can't prepare diff by revgrep: %s", err)
 	}
 
-	return transformIssues(issues, func(i *result.Issue) *result.Issue {
-		hunkPos, isNew := c.IsNewIssue(i)
-		if !isNew {
-			return nil
-		}
-
-		newI := *i
-		newI.HunkPos = hunkPos
-		return &newI
-	}), nil
+	return &c, nil
 }
 
-func (Diff) Finish() {}
+func (*Diff) Finish() {}
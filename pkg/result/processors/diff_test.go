@@ -0,0 +1,43 @@
+package processors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const diffTestPatch = `diff --git a/f1.go b/f1.go
+index 0000000..1111111 100644
+--- a/f1.go
++++ b/f1.go
+@@ -1,2 +1,3 @@
+ package p
++var x = 1
+ var y = 2
+`
+
+func TestDiff_batches(t *testing.T) {
+	patchPath := filepath.Join(t.TempDir(), "patch.diff")
+	require.NoError(t, os.WriteFile(patchPath, []byte(diffTestPatch), 0o600))
+
+	p := NewDiff(false, "", patchPath, false)
+
+	newIssue := newFLIssue("f1.go", 2)
+	newIssue.HunkPos = 2
+
+	processAssertEmpty(t, p, newFLIssue("f1.go", 3))
+	assert.Equal(t, []result.Issue{newIssue}, process(t, p, newFLIssue("f1.go", 2)))
+
+	// The patch is read once for the batches of the streaming output.
+	require.NoError(t, os.Remove(patchPath))
+
+	issues, err := p.Process(nil)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+	assert.Equal(t, []result.Issue{newIssue}, process(t, p, newFLIssue("f1.go", 2)))
+}
@@ -16,22 +16,37 @@ type ExcludeRule struct {
 	BaseRule
 }
 
+// IncludeRule restricts its linters: their issues are kept only if they match one of their include rules.
+type IncludeRule struct {
+	BaseRule
+}
+
 type ExcludeRules struct {
-	rules     []excludeRule
-	lineCache *fsutils.LineCache
-	log       logutils.Log
+	rules        []excludeRule
+	includeRules []excludeRule
+	lineCache    *fsutils.LineCache
+	log          logutils.Log
 }
 
-func NewExcludeRules(rules []ExcludeRule, lineCache *fsutils.LineCache, log logutils.Log) *ExcludeRules {
+func NewExcludeRules(rules []ExcludeRule, includeRules []IncludeRule, lineCache *fsutils.LineCache, log logutils.Log) *ExcludeRules {
 	r := &ExcludeRules{
 		lineCache: lineCache,
 		log:       log,
 	}
 	r.rules = createRules(rules, "(?i)")
+	r.includeRules = createRules(includeToExcludeRules(includeRules), "(?i)")
 
 	return r
 }
 
+func includeToExcludeRules(rules []IncludeRule) []ExcludeRule {
+	ret := make([]ExcludeRule, 0, len(rules))
+	for _, rule := range rules {
+		ret = append(ret, ExcludeRule(rule))
+	}
+	return ret
+}
+
 func createRules(rules []ExcludeRule, prefix string) []excludeRule {
 	parsedRules := make([]excludeRule, 0, len(rules))
 	for _, rule := range rules {
@@ -52,10 +67,14 @@ func createRules(rules []ExcludeRule, prefix string) []excludeRule {
 }
 
 func (p ExcludeRules) Process(issues []result.Issue) ([]result.Issue, error) {
-	if len(p.rules) == 0 {
+	if len(p.rules) == 0 && len(p.includeRules) == 0 {
 		return issues, nil
 	}
 	return filterIssues(issues, func(i *result.Issue) bool {
+		if !p.included(i) {
+			return false
+		}
+
 		for _, rule := range p.rules {
 			rule := rule
 			if rule.match(i, p.lineCache, p.log) {
@@ -66,6 +85,24 @@ func (p ExcludeRules) Process(issues []result.Issue) ([]result.Issue, error) {
 	}), nil
 }
 
+// included checks if the issue matches one of the include rules of its linter, if any.
+func (p ExcludeRules) included(i *result.Issue) bool {
+	restricted := false
+	for _, rule := range p.includeRules {
+		rule := rule
+		if !rule.matchLinter(i) {
+			continue
+		}
+
+		restricted = true
+		if rule.match(i, p.lineCache, p.log) {
+			return true
+		}
+	}
+
+	return !restricted
+}
+
 func (ExcludeRules) Name() string { return "exclude-rules" }
 func (ExcludeRules) Finish()      {}
 
@@ -75,12 +112,14 @@ type ExcludeRulesCaseSensitive struct {
 	*ExcludeRules
 }
 
-func NewExcludeRulesCaseSensitive(rules []ExcludeRule, lineCache *fsutils.LineCache, log logutils.Log) *ExcludeRulesCaseSensitive {
+func NewExcludeRulesCaseSensitive(rules []ExcludeRule, includeRules []IncludeRule,
+	lineCache *fsutils.LineCache, log logutils.Log) *ExcludeRulesCaseSensitive {
 	r := &ExcludeRules{
 		lineCache: lineCache,
 		log:       log,
 	}
 	r.rules = createRules(rules, "")
+	r.includeRules = createRules(includeToExcludeRules(includeRules), "")
 
 	return &ExcludeRulesCaseSensitive{r}
 }
@@ -37,7 +37,7 @@ func TestExcludeRulesMultiple(t *testing.T) {
 				Linters: []string{"lll"},
 			},
 		},
-	}, lineCache, nil)
+	}, nil, lineCache, nil)
 
 	cases := []issueTestCase{
 		{Path: "e.go", Text: "exclude", Linter: "linter"},
@@ -78,7 +78,7 @@ func TestExcludeRulesText(t *testing.T) {
 				Linters: []string{"linter"},
 			},
 		},
-	}, nil, nil)
+	}, nil, nil, nil)
 	texts := []string{"excLude", "1", "", "exclud", "notexclude"}
 	var issues []result.Issue
 	for _, t := range texts {
@@ -98,8 +98,48 @@ func TestExcludeRulesText(t *testing.T) {
 	assert.Equal(t, texts[1:], processedTexts)
 }
 
+func TestExcludeRulesInclude(t *testing.T) {
+	p := NewExcludeRules(nil, []IncludeRule{
+		{
+			BaseRule: BaseRule{
+				Path:    `^pkg/api/`,
+				Linters: []string{"forbidigo"},
+			},
+		},
+		{
+			BaseRule: BaseRule{
+				Text:    "^allowed",
+				Linters: []string{"forbidigo"},
+			},
+		},
+	}, nil, nil)
+
+	cases := []issueTestCase{
+		{Path: "pkg/api/a.go", Text: "use of fmt.Println", Linter: "forbidigo"},
+		{Path: "pkg/other/a.go", Text: "use of fmt.Println", Linter: "forbidigo"},
+		{Path: "pkg/other/a.go", Text: "Allowed everywhere", Linter: "forbidigo"},
+		{Path: "pkg/other/a.go", Text: "use of fmt.Println", Linter: "govet"},
+	}
+	var issues []result.Issue
+	for _, c := range cases {
+		issues = append(issues, newIssueFromIssueTestCase(c))
+	}
+
+	processedIssues := process(t, p, issues...)
+	var resultingCases []issueTestCase
+	for _, i := range processedIssues {
+		resultingCases = append(resultingCases, issueTestCase{
+			Path:   i.FilePath(),
+			Linter: i.FromLinter,
+			Text:   i.Text,
+			Line:   i.Line(),
+		})
+	}
+	assert.Equal(t, []issueTestCase{cases[0], cases[2], cases[3]}, resultingCases)
+}
+
 func TestExcludeRulesEmpty(t *testing.T) {
-	processAssertSame(t, NewExcludeRules(nil, nil, nil), newIssueFromTextTestCase("test"))
+	processAssertSame(t, NewExcludeRules(nil, nil, nil, nil), newIssueFromTextTestCase("test"))
 }
 
 func TestExcludeRulesCaseSensitiveMultiple(t *testing.T) {
@@ -129,7 +169,7 @@ func TestExcludeRulesCaseSensitiveMultiple(t *testing.T) {
 				Linters: []string{"lll"},
 			},
 		},
-	}, lineCache, nil)
+	}, nil, lineCache, nil)
 
 	cases := []issueTestCase{
 		{Path: "e.go", Text: "exclude", Linter: "linter"},
@@ -175,7 +215,7 @@ func TestExcludeRulesCaseSensitiveText(t *testing.T) {
 				Linters: []string{"linter"},
 			},
 		},
-	}, nil, nil)
+	}, nil, nil, nil)
 	texts := []string{"exclude", "excLude", "1", "", "exclud", "notexclude"}
 	var issues []result.Issue
 	for _, t := range texts {
@@ -196,5 +236,5 @@ func TestExcludeRulesCaseSensitiveText(t *testing.T) {
 }
 
 func TestExcludeRulesCaseSensitiveEmpty(t *testing.T) {
-	processAssertSame(t, NewExcludeRulesCaseSensitive(nil, nil, nil), newIssueFromTextTestCase("test"))
+	processAssertSame(t, NewExcludeRulesCaseSensitive(nil, nil, nil, nil), newIssueFromTextTestCase("test"))
 }
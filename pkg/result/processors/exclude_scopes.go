@@ -0,0 +1,291 @@
+package processors
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+type excludeScope struct {
+	linter  string
+	path    *regexp.Regexp
+	pattern config.ScopePattern
+}
+
+func (s *excludeScope) matchIssue(issue *result.Issue) bool {
+	if s.linter != "" && s.linter != issue.FromLinter {
+		return false
+	}
+	return s.path == nil || s.path.MatchString(issue.FilePath())
+}
+
+// scopeRange is the lines of a declaration or of a call matching the scope of the index.
+type scopeRange struct {
+	scope int
+	result.Range
+}
+
+// ExcludeScopes excludes the issues inside the declarations or the calls matching the scopes of issues.exclude-scopes,
+// e.g. the test methods of a suite or the blocks of Describe.
+type ExcludeScopes struct {
+	scopes []excludeScope
+	// cache is the ranges of the files, parsed on their first issue matching a scope.
+	cache map[string][]scopeRange
+}
+
+var _ Processor = (*ExcludeScopes)(nil)
+
+func NewExcludeScopes(scopes []config.ExcludeScope) (*ExcludeScopes, error) {
+	p := &ExcludeScopes{cache: map[string][]scopeRange{}}
+
+	for _, s := range scopes {
+		pattern, err := config.ParseScopePattern(s.Scope)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed := excludeScope{linter: s.Linter, pattern: pattern}
+		if s.Path != "" {
+			parsed.path, err = regexp.Compile(s.Path)
+			if err != nil {
+				return nil, err
+			}
+		}
+		p.scopes = append(p.scopes, parsed)
+	}
+
+	return p, nil
+}
+
+func (ExcludeScopes) Name() string { return "exclude_scopes" }
+func (ExcludeScopes) Finish()      {}
+
+func (p *ExcludeScopes) Process(issues []result.Issue) ([]result.Issue, error) {
+	if len(p.scopes) == 0 {
+		return issues, nil
+	}
+
+	return filterIssues(issues, func(issue *result.Issue) bool {
+		return !p.excluded(issue)
+	}), nil
+}
+
+func (p *ExcludeScopes) excluded(issue *result.Issue) bool {
+	matched := map[int]bool{}
+	for i := range p.scopes {
+		if p.scopes[i].matchIssue(issue) {
+			matched[i] = true
+		}
+	}
+	if len(matched) == 0 || issue.FilePath() == "" {
+		return false
+	}
+
+	for _, r := range p.getRanges(issue.FilePath()) {
+		if matched[r.scope] && issue.Line() >= r.From && issue.Line() <= r.To {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *ExcludeScopes) getRanges(filePath string) []scopeRange {
+	ranges, ok := p.cache[filePath]
+	if ok {
+		return ranges
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filePath, fsutils.ParserSource(filePath), parser.ParseComments)
+	if err == nil {
+		ranges = p.buildRanges(fset, f)
+	}
+	// Don't report the error: it's already reported by typecheck.
+
+	p.cache[filePath] = ranges
+	return ranges
+}
+
+func (p *ExcludeScopes) buildRanges(fset *token.FileSet, f *ast.File) []scopeRange {
+	var ranges []scopeRange
+	add := func(scope int, from, to token.Pos) {
+		ranges = append(ranges, scopeRange{
+			scope: scope,
+			Range: result.Range{From: fset.Position(from).Line, To: fset.Position(to).Line},
+		})
+	}
+
+	ast.Inspect(f, func(node ast.Node) bool {
+		for i := range p.scopes {
+			pattern := &p.scopes[i].pattern
+			switch n := node.(type) {
+			case *ast.FuncDecl:
+				if pattern.Kind == config.ScopeKindFunc && matchFuncScope(pattern, n) {
+					add(i, startWithDoc(n.Pos(), n.Doc), n.End())
+				}
+			case *ast.GenDecl:
+				for _, spec := range n.Specs {
+					if matchSpecScope(pattern, n.Tok, spec) {
+						from, to := specRange(n, spec)
+						add(i, from, to)
+					}
+				}
+			case *ast.CallExpr:
+				if pattern.Kind == config.ScopeKindCall && matchCallScope(pattern.Name, n) {
+					add(i, n.Pos(), n.End())
+				}
+			}
+		}
+		return true
+	})
+
+	return ranges
+}
+
+func matchFuncScope(pattern *config.ScopePattern, fn *ast.FuncDecl) bool {
+	if !matchGlob(pattern.Name, fn.Name.Name) {
+		return false
+	}
+
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return pattern.Receiver == ""
+	}
+	if pattern.Receiver == "" {
+		return false
+	}
+
+	typ := fn.Recv.List[0].Type
+	star, pointer := typ.(*ast.StarExpr)
+	if pointer {
+		typ = star.X
+	}
+
+	// A receiver without * matches the pointer receivers too.
+	glob := pattern.Receiver
+	if strings.HasPrefix(glob, "*") {
+		if !pointer {
+			return false
+		}
+		glob = glob[1:]
+	}
+
+	return matchGlob(glob, receiverTypeName(typ))
+}
+
+// receiverTypeName returns the name of the type of a receiver, without its type parameters.
+func receiverTypeName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.IndexExpr:
+		return receiverTypeName(e.X)
+	case *ast.IndexListExpr:
+		return receiverTypeName(e.X)
+	case *ast.ParenExpr:
+		return receiverTypeName(e.X)
+	}
+	return ""
+}
+
+func matchSpecScope(pattern *config.ScopePattern, tok token.Token, spec ast.Spec) bool {
+	switch s := spec.(type) {
+	case *ast.TypeSpec:
+		return pattern.Kind == config.ScopeKindType && matchGlob(pattern.Name, s.Name.Name)
+
+	case *ast.ValueSpec:
+		if (tok != token.VAR || pattern.Kind != config.ScopeKindVar) && (tok != token.CONST || pattern.Kind != config.ScopeKindConst) {
+			return false
+		}
+
+		for i, name := range s.Names {
+			if !matchGlob(pattern.Name, name.Name) {
+				continue
+			}
+			if pattern.Call == "" {
+				return true
+			}
+
+			// The values of a, b = f() are the results of the single call.
+			value := i
+			if len(s.Values) == 1 {
+				value = 0
+			}
+			if value < len(s.Values) {
+				if call, ok := s.Values[value].(*ast.CallExpr); ok && matchCallScope(pattern.Call, call) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// specRange returns the range of the spec, with the declaration if it's its only spec, e.g. var _ = Describe().
+func specRange(decl *ast.GenDecl, spec ast.Spec) (from, to token.Pos) {
+	if !decl.Lparen.IsValid() {
+		return startWithDoc(decl.Pos(), decl.Doc), decl.End()
+	}
+
+	switch s := spec.(type) {
+	case *ast.TypeSpec:
+		return startWithDoc(s.Pos(), s.Doc), s.End()
+	case *ast.ValueSpec:
+		return startWithDoc(s.Pos(), s.Doc), s.End()
+	}
+	return spec.Pos(), spec.End()
+}
+
+func startWithDoc(pos token.Pos, doc *ast.CommentGroup) token.Pos {
+	if doc != nil {
+		return doc.Pos()
+	}
+	return pos
+}
+
+// matchCallScope matches the function of the call: a glob with a package or a receiver, e.g. ginkgo.Describe,
+// is matched against the selector, otherwise against the name of the function.
+func matchCallScope(glob string, call *ast.CallExpr) bool {
+	fun := call.Fun
+	for {
+		switch f := fun.(type) {
+		case *ast.IndexExpr: // generic calls
+			fun = f.X
+			continue
+		case *ast.IndexListExpr:
+			fun = f.X
+			continue
+		case *ast.ParenExpr:
+			fun = f.X
+			continue
+		}
+		break
+	}
+
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return !strings.Contains(glob, ".") && matchGlob(glob, f.Name)
+	case *ast.SelectorExpr:
+		if !strings.Contains(glob, ".") {
+			return matchGlob(glob, f.Sel.Name)
+		}
+		if x, ok := f.X.(*ast.Ident); ok {
+			return matchGlob(glob, x.Name+"."+f.Sel.Name)
+		}
+	}
+
+	return false
+}
+
+func matchGlob(glob, name string) bool {
+	ok, _ := path.Match(glob, name) // the globs are validated by the config
+	return ok
+}
@@ -0,0 +1,82 @@
+package processors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestExcludeScopes(t *testing.T) {
+	path := filepath.Join("testdata", "exclude_scopes.go")
+	lines := []int{5, 7, 11, 15, 19, 23, 27, 30, 41}
+
+	testCases := []struct {
+		scope    string
+		expected []int // the remaining lines
+	}{
+		{scope: "func (*suite) Test*", expected: []int{11, 15, 19, 23, 27, 30, 41}},
+		{scope: "func (suite) Test*", expected: []int{15, 19, 23, 27, 30, 41}},
+		{scope: "func (*_ suite) *", expected: []int{11, 19, 23, 27, 30, 41}},
+		{scope: "func Test*", expected: []int{5, 7, 11, 15, 23, 27, 30, 41}},
+		{scope: "func run", expected: []int{5, 7, 11, 15, 19, 23, 27, 30}},
+		{scope: "var _ = Describe", expected: []int{5, 7, 11, 15, 19, 27, 30, 41}},
+		{scope: "var * = Describe", expected: []int{5, 7, 11, 15, 19, 27, 41}},
+		{scope: "var a", expected: []int{5, 7, 11, 15, 19, 23, 30, 41}},
+		{scope: "call Describe", expected: []int{5, 7, 11, 15, 19, 27}},
+		{scope: "call ginkgo.Describe", expected: lines},
+		{scope: "type suite", expected: lines},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.scope, func(t *testing.T) {
+			p, err := NewExcludeScopes([]config.ExcludeScope{{Linter: "dupl", Scope: test.scope}})
+			require.NoError(t, err)
+
+			var issues []result.Issue
+			for _, line := range lines {
+				issues = append(issues, newIssueFromIssueTestCase(issueTestCase{Path: path, Line: line, Linter: "dupl"}))
+			}
+
+			var remaining []int
+			for _, i := range process(t, p, issues...) {
+				remaining = append(remaining, i.Line())
+			}
+			assert.Equal(t, test.expected, remaining)
+		})
+	}
+}
+
+func TestExcludeScopes_linterAndPath(t *testing.T) {
+	p, err := NewExcludeScopes([]config.ExcludeScope{
+		{Linter: "dupl", Path: `scopes\.go`, Scope: "func Test*"},
+	})
+	require.NoError(t, err)
+
+	cases := []issueTestCase{
+		{Path: filepath.Join("testdata", "exclude_scopes.go"), Line: 19, Linter: "dupl"},
+		{Path: filepath.Join("testdata", "exclude_scopes.go"), Line: 19, Linter: "lll"},
+		{Path: filepath.Join("testdata", "exclude_rules.go"), Line: 3, Linter: "dupl"},
+		{Path: filepath.Join("testdata", "missing.go"), Line: 3, Linter: "dupl"},
+	}
+	var issues []result.Issue
+	for _, c := range cases {
+		issues = append(issues, newIssueFromIssueTestCase(c))
+	}
+
+	var remaining []issueTestCase
+	for _, i := range process(t, p, issues...) {
+		remaining = append(remaining, issueTestCase{Path: i.FilePath(), Line: i.Line(), Linter: i.FromLinter})
+	}
+	assert.Equal(t, cases[1:], remaining)
+}
+
+func TestNewExcludeScopes_error(t *testing.T) {
+	_, err := NewExcludeScopes([]config.ExcludeScope{{Scope: "method Test*"}})
+	assert.EqualError(t, err, `unknown kind "method": must be func, var, const, type or call`)
+}
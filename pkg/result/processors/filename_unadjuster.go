@@ -10,6 +10,7 @@ import (
 
 	"golang.org/x/tools/go/packages"
 
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
@@ -42,7 +43,7 @@ func processUnadjusterPkg(m *adjustMap, pkg *packages.Package, log logutils.Log)
 }
 
 func processUnadjusterFile(filename string, m *adjustMap, log logutils.Log, fset *token.FileSet) {
-	syntax, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+	syntax, err := parser.ParseFile(fset, filename, fsutils.ParserSource(filename), parser.ParseComments)
 	if err != nil {
 		// Error will be reported by typecheck
 		return
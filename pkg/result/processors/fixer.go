@@ -3,16 +3,22 @@ package processors
 import (
 	"bytes"
 	"fmt"
+	"go/token"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
 
 	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/rename"
 	"github.com/golangci/golangci-lint/pkg/result"
 	"github.com/golangci/golangci-lint/pkg/timeutils"
 )
@@ -22,28 +28,48 @@ type Fixer struct {
 	log       logutils.Log
 	fileCache *fsutils.FileCache
 	sw        *timeutils.Stopwatch
+
+	// pkgs are the analyzed packages, loaded again with their types to rename identifiers.
+	pkgs       []*packages.Package
+	renamer    *rename.Renamer
+	renamerErr error
+
+	// formatters format the fixed files, after gofmt if gofmt is set.
+	formatters []fixFormatter
+	gofmt      bool
 }
 
-func NewFixer(cfg *config.Config, log logutils.Log, fileCache *fsutils.FileCache) *Fixer {
+func NewFixer(cfg *config.Config, log logutils.Log, fileCache *fsutils.FileCache, pkgs []*packages.Package) *Fixer {
 	return &Fixer{
 		cfg:       cfg,
 		log:       log,
 		fileCache: fileCache,
 		sw:        timeutils.NewStopwatch("fixer", log),
+		pkgs:      pkgs,
 	}
 }
 
+// WithEnabledLinters formats the fixed files with the enabled formatters: gofmt, goimports, gci and gofumpt.
+func (f *Fixer) WithEnabledLinters(enabledLinters map[string]*linter.Config) *Fixer {
+	f.formatters = newFixFormatters(f.cfg, enabledLinters)
+	f.gofmt = enabledLinters["gofmt"] != nil
+	return f
+}
+
 func (f Fixer) printStat() {
 	f.sw.PrintStages()
 }
 
-func (f Fixer) Process(issues []result.Issue) []result.Issue {
+func (f *Fixer) Process(issues []result.Issue) []result.Issue {
 	if !f.cfg.Issues.NeedFix {
 		return issues
 	}
 
 	outIssues := make([]result.Issue, 0, len(issues))
 	issuesToFixPerFile := map[string][]result.Issue{}
+	// the other references of the renamed identifiers: they aren't reported
+	renamesPerFile := map[string][]result.Issue{}
+	renamed := map[token.Position]string{}
 	for i := range issues {
 		issue := &issues[i]
 		if issue.Replacement == nil {
@@ -51,19 +77,53 @@ func (f Fixer) Process(issues []result.Issue) []result.Issue {
 			continue
 		}
 
+		if f.cfg.Issues.UnsafeFix {
+			var report bool
+			issue, report = f.rename(issue, renamed, renamesPerFile)
+			if report {
+				outIssues = append(outIssues, issues[i])
+				continue
+			}
+			if issue == nil {
+				continue // renamed with another issue
+			}
+		}
+
 		issuesToFixPerFile[issue.FilePath()] = append(issuesToFixPerFile[issue.FilePath()], *issue)
 	}
 
+	for file := range renamesPerFile {
+		if _, ok := issuesToFixPerFile[file]; !ok {
+			issuesToFixPerFile[file] = nil
+		}
+	}
+
 	for file, issuesToFix := range issuesToFixPerFile {
+		toFix := make([]result.Issue, 0, len(issuesToFix)+len(renamesPerFile[file]))
+		toFix = append(toFix, issuesToFix...)
+		toFix = append(toFix, renamesPerFile[file]...)
+
+		var notFixed []result.Issue
 		var err error
 		f.sw.TrackStage("all", func() {
-			err = f.fixIssuesInFile(file, issuesToFix)
+			notFixed, err = f.fixIssuesInFile(file, toFix)
 		})
 		if err != nil {
 			f.log.Errorf("Failed to fix issues in file %s: %s", file, err)
 
 			// show issues only if can't fix them
 			outIssues = append(outIssues, issuesToFix...)
+			continue
+		}
+
+		// The conflicting fixes are applied by the next run: their issues are shown.
+		for i := range notFixed {
+			if isRenameReference(&notFixed[i], renamesPerFile[file]) {
+				f.log.Warnf("The reference at %s of a renamed identifier isn't renamed: it conflicts with another fix",
+					notFixed[i].Pos)
+				continue
+			}
+			outIssues = append(outIssues, notFixed[i])
 		}
 	}
 
@@ -71,21 +131,169 @@ func (f Fixer) Process(issues []result.Issue) []result.Issue {
 	return outIssues
 }
 
-func (f Fixer) fixIssuesInFile(filePath string, issues []result.Issue) error {
+// rename returns the issue to fix if its inline fix renames an identifier: the fix replaces the whole identifier,
+// and the other references of the identifier are added to renamesPerFile.
+// The issue is returned as is if it doesn't rename an identifier, and nil if it's renamed by another issue.
+// If the identifier can't be renamed, the issue must be reported without fixing it.
+func (f *Fixer) rename(issue *result.Issue, renamed map[token.Position]string,
+	renamesPerFile map[string][]result.Issue) (toFix *result.Issue, report bool) {
+	fix := issue.Replacement.Inline
+	if fix == nil || !f.mayRenameIdent(issue) {
+		return issue, false
+	}
+
+	renamer, err := f.getRenamer()
+	if err != nil {
+		f.log.Warnf("Can't load the packages to rename identifiers: %s", err)
+		return nil, true
+	}
+
+	pos := token.Position{Filename: issue.FilePath(), Line: issue.Line(), Column: fix.StartCol + 1}
+	name, start, found := renamer.Ident(pos)
+	if !found {
+		return issue, false // e.g. in a comment
+	}
+
+	offset := fix.StartCol - (start.Column - 1)
+	if offset+fix.Length > len(name) {
+		return issue, false // not only in the identifier
+	}
+
+	newName := name[:offset] + fix.NewString + name[offset+fix.Length:]
+	if prev, ok := renamed[start]; ok {
+		if prev != newName {
+			f.log.Warnf("Can't rename %s to %s at %s: it's renamed to %s", name, newName, start, prev)
+			return nil, true
+		}
+		return nil, false
+	}
+
+	positions, err := renamer.Rename(start, newName)
+	if err != nil {
+		f.log.Warnf("Can't rename %s to %s at %s: %s", name, newName, start, err)
+		return nil, true
+	}
+
+	for _, p := range positions {
+		renamed[p] = newName
+		if p == start {
+			continue
+		}
+
+		file := f.relativePath(p.Filename)
+		renamesPerFile[file] = append(renamesPerFile[file], result.Issue{
+			FromLinter:  issue.FromLinter,
+			Text:        fmt.Sprintf("rename %s to %s", name, newName),
+			Pos:         token.Position{Filename: file, Line: p.Line, Column: p.Column},
+			Replacement: &result.Replacement{Inline: renameFix(p, name, newName)},
+		})
+	}
+
+	f.log.Infof("Rename %s to %s: %d references", name, newName, len(positions))
+
+	ret := *issue
+	ret.Replacement = &result.Replacement{Inline: renameFix(start, name, newName)}
+	return &ret, false
+}
+
+func isRenameReference(issue *result.Issue, renames []result.Issue) bool {
+	for i := range renames {
+		if renames[i].Pos == issue.Pos && renames[i].Text == issue.Text {
+			return true
+		}
+	}
+	return false
+}
+
+func renameFix(p token.Position, name, newName string) *result.InlineFix {
+	return &result.InlineFix{StartCol: p.Column - 1, Length: len(name), NewString: newName}
+}
+
+// mayRenameIdent checks lexically if the inline fix of the issue changes an identifier into another one,
+// to load the packages only if needed.
+func (f *Fixer) mayRenameIdent(issue *result.Issue) bool {
+	fix := issue.Replacement.Inline
+
+	lines, err := f.fileCache.GetFileBytes(issue.FilePath())
+	if err != nil {
+		return false
+	}
+
+	fileLines := bytes.Split(lines, []byte("\n"))
+	if issue.Line() < 1 || issue.Line() > len(fileLines) {
+		return false
+	}
+
+	line := fileLines[issue.Line()-1]
+	if fix.StartCol < 0 || fix.StartCol+fix.Length > len(line) {
+		return false
+	}
+
+	from, to := fix.StartCol, fix.StartCol+fix.Length
+	for from > 0 && isIdentByte(line[from-1]) {
+		from--
+	}
+	for to < len(line) && isIdentByte(line[to]) {
+		to++
+	}
+
+	ident := string(line[from:to])
+	newIdent := ident[:fix.StartCol-from] + fix.NewString + ident[fix.StartCol-from+fix.Length:]
+
+	return ident != newIdent && token.IsIdentifier(ident) && token.IsIdentifier(newIdent)
+}
+
+func isIdentByte(b byte) bool {
+	return b == '_' || b >= utf8.RuneSelf || unicode.IsLetter(rune(b)) || unicode.IsDigit(rune(b))
+}
+
+func (f *Fixer) getRenamer() (*rename.Renamer, error) {
+	if f.renamer != nil || f.renamerErr != nil {
+		return f.renamer, f.renamerErr
+	}
+
+	seen := map[string]bool{}
+	var patterns []string
+	for _, pkg := range f.pkgs {
+		if !seen[pkg.PkgPath] {
+			seen[pkg.PkgPath] = true
+			patterns = append(patterns, pkg.PkgPath)
+		}
+	}
+
+	f.sw.TrackStage("load", func() {
+		f.renamer, f.renamerErr = rename.Load(patterns, f.cfg.Run.AnalyzeTests, f.cfg.Run.BuildTags)
+	})
+
+	return f.renamer, f.renamerErr
+}
+
+// relativePath makes the absolute path of a renamed reference relative to the working directory, like the issues.
+func (f *Fixer) relativePath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+
+	return rel
+}
+
+// fixIssuesInFile applies the fixes of the issues to the file, and formats it: the file is kept if the fixes break its syntax.
+// The issues whose fixes conflict with other fixes are returned, they aren't fixed.
+func (f Fixer) fixIssuesInFile(filePath string, issues []result.Issue) ([]result.Issue, error) {
 	// TODO: don't read the whole file into memory: read line by line;
 	// can't just use bufio.scanner: it has a line length limit
 	origFileData, err := f.fileCache.GetFileBytes(filePath)
 	if err != nil {
-		return errors.Wrapf(err, "failed to get file bytes for %s", filePath)
+		return nil, errors.Wrapf(err, "failed to get file bytes for %s", filePath)
 	}
 	origFileLines := bytes.Split(origFileData, []byte("\n"))
 
-	tmpFileName := filepath.Join(filepath.Dir(filePath), fmt.Sprintf(".%s.golangci_fix", filepath.Base(filePath)))
-	tmpOutFile, err := os.Create(tmpFileName)
-	if err != nil {
-		return errors.Wrapf(err, "failed to make file %s", tmpFileName)
-	}
-
 	// merge multiple issues per line into one issue
 	issuesPerLine := map[int][]result.Issue{}
 	for i := range issues {
@@ -93,35 +301,59 @@ func (f Fixer) fixIssuesInFile(filePath string, issues []result.Issue) error {
 		issuesPerLine[issue.Line()] = append(issuesPerLine[issue.Line()], *issue)
 	}
 
+	var notFixed []result.Issue
+	mergedPerLine := map[int][]result.Issue{}
 	issues = issues[:0] // reuse the same memory
 	for line, lineIssues := range issuesPerLine {
-		if mergedIssue := f.mergeLineIssues(line, lineIssues, origFileLines); mergedIssue != nil {
+		mergedIssue, merged := f.mergeLineIssues(line, lineIssues, origFileLines)
+		notFixed = append(notFixed, lineIssues[len(merged):]...)
+		if mergedIssue != nil {
 			issues = append(issues, *mergedIssue)
+			mergedPerLine[mergedIssue.Line()] = merged
 		}
 	}
 
-	issues = f.findNotIntersectingIssues(issues)
+	issues, skipped := f.findNotIntersectingIssues(issues)
+	for i := range skipped {
+		notFixed = append(notFixed, mergedPerLine[skipped[i].Line()]...)
+	}
+
+	var fixed bytes.Buffer
+	if err = f.writeFixedFile(origFileLines, issues, &fixed); err != nil {
+		return nil, err
+	}
 
-	if err = f.writeFixedFile(origFileLines, issues, tmpOutFile); err != nil {
-		tmpOutFile.Close()
-		os.Remove(tmpOutFile.Name())
-		return err
+	formatted, err := f.formatFixedFile(filePath, origFileData, fixed.Bytes())
+	if err != nil {
+		return nil, err
 	}
 
-	tmpOutFile.Close()
-	if err = os.Rename(tmpOutFile.Name(), filePath); err != nil {
-		os.Remove(tmpOutFile.Name())
-		return errors.Wrapf(err, "failed to rename %s -> %s", tmpOutFile.Name(), filePath)
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	tmpFileName := filepath.Join(filepath.Dir(filePath), fmt.Sprintf(".%s.golangci_fix", filepath.Base(filePath)))
+	if err = os.WriteFile(tmpFileName, formatted, info.Mode().Perm()); err != nil {
+		os.Remove(tmpFileName)
+		return nil, errors.Wrapf(err, "failed to write file %s", tmpFileName)
+	}
+
+	if err = os.Rename(tmpFileName, filePath); err != nil {
+		os.Remove(tmpFileName)
+		return nil, errors.Wrapf(err, "failed to rename %s -> %s", tmpFileName, filePath)
+	}
+
+	return notFixed, nil
 }
 
-func (f Fixer) mergeLineIssues(lineNum int, lineIssues []result.Issue, origFileLines [][]byte) *result.Issue {
+// mergeLineIssues merges the issues of the line into one issue, and returns the issues merged into it:
+// the first issues of the line, the next ones aren't fixed.
+func (f Fixer) mergeLineIssues(lineNum int, lineIssues []result.Issue, origFileLines [][]byte) (*result.Issue, []result.Issue) {
 	origLine := origFileLines[lineNum-1] // lineNum is 1-based
 
 	if len(lineIssues) == 1 && lineIssues[0].Replacement.Inline == nil {
-		return &lineIssues[0]
+		return &lineIssues[0], lineIssues
 	}
 
 	// check issues first
@@ -129,22 +361,26 @@ func (f Fixer) mergeLineIssues(lineNum int, lineIssues []result.Issue, origFileL
 		i := &lineIssues[ind]
 		if i.LineRange != nil {
 			f.log.Infof("Line %d has multiple issues but at least one of them is ranged: %#v", lineNum, lineIssues)
-			return &lineIssues[0]
+			return &lineIssues[0], lineIssues[:1]
 		}
 
 		r := i.Replacement
 		if r.Inline == nil || len(r.NewLines) != 0 || r.NeedOnlyDelete {
 			f.log.Infof("Line %d has multiple issues but at least one of them isn't inline: %#v", lineNum, lineIssues)
-			return &lineIssues[0]
+			return &lineIssues[0], lineIssues[:1]
 		}
 
 		if r.Inline.StartCol < 0 || r.Inline.Length <= 0 || r.Inline.StartCol+r.Inline.Length > len(origLine) {
 			f.log.Warnf("Line %d (%q) has invalid inline fix: %#v, %#v", lineNum, origLine, i, r.Inline)
-			return nil
+			return nil, nil
 		}
 	}
 
-	return f.applyInlineFixes(lineIssues, origLine, lineNum)
+	mergedIssue := f.applyInlineFixes(lineIssues, origLine, lineNum)
+	if mergedIssue == nil {
+		return nil, nil
+	}
+	return mergedIssue, lineIssues
 }
 
 func (f Fixer) applyInlineFixes(lineIssues []result.Issue, origLine []byte, lineNum int) *result.Issue {
@@ -183,30 +419,31 @@ func (f Fixer) applyInlineFixes(lineIssues []result.Issue, origLine []byte, line
 	return &mergedIssue
 }
 
-func (f Fixer) findNotIntersectingIssues(issues []result.Issue) []result.Issue {
+// findNotIntersectingIssues returns the issues to fix, and the issues intersecting them, skipped.
+func (f Fixer) findNotIntersectingIssues(issues []result.Issue) (toFix, skipped []result.Issue) {
 	sort.SliceStable(issues, func(i, j int) bool {
 		a, b := issues[i], issues[j]
 		return a.Line() < b.Line()
 	})
 
-	var ret []result.Issue
 	var currentEnd int
 	for i := range issues {
 		issue := &issues[i]
 		rng := issue.GetLineRange()
 		if rng.From <= currentEnd {
 			f.log.Infof("Skip issue %#v: intersects with end %d", issue, currentEnd)
+			skipped = append(skipped, *issue)
 			continue // skip intersecting issue
 		}
 		f.log.Infof("Fix issue %#v with range %v", issue, issue.GetLineRange())
-		ret = append(ret, *issue)
+		toFix = append(toFix, *issue)
 		currentEnd = rng.To
 	}
 
-	return ret
+	return toFix, skipped
 }
 
-func (f Fixer) writeFixedFile(origFileLines [][]byte, issues []result.Issue, tmpOutFile *os.File) error {
+func (f Fixer) writeFixedFile(origFileLines [][]byte, issues []result.Issue, out *bytes.Buffer) error {
 	// issues aren't intersecting
 
 	nextIssueIndex := 0
@@ -239,7 +476,7 @@ func (f Fixer) writeFixedFile(origFileLines [][]byte, issues []result.Issue, tmp
 		if i < len(origFileLines)-1 {
 			outLine += "\n"
 		}
-		if _, err := tmpOutFile.WriteString(outLine); err != nil {
+		if _, err := out.WriteString(outLine); err != nil {
 			return errors.Wrap(err, "failed to write output line")
 		}
 	}
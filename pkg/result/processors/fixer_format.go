@@ -0,0 +1,78 @@
+package processors
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+
+	gofumpt "mvdan.cc/gofumpt/format"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/golinters"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+)
+
+// fixFormatter is a formatter of a fixed file.
+type fixFormatter struct {
+	name   string
+	format func(path string, src []byte) ([]byte, error)
+}
+
+// newFixFormatters creates the formatters of the fixed files: the formatters enabled as linters, with their settings.
+func newFixFormatters(cfg *config.Config, enabledLinters map[string]*linter.Config) []fixFormatter {
+	var formatters []fixFormatter
+
+	if enabledLinters["goimports"] != nil {
+		formatters = append(formatters, fixFormatter{name: "goimports", format: func(path string, src []byte) ([]byte, error) {
+			return golinters.GoimportsSource(path, src, &cfg.LintersSettings.Goimports)
+		}})
+	}
+
+	if enabledLinters["gci"] != nil {
+		if gciCfg, err := golinters.GciConfig(&cfg.LintersSettings.Gci); err == nil {
+			formatters = append(formatters, fixFormatter{name: "gci", format: func(path string, src []byte) ([]byte, error) {
+				return golinters.GciSource(path, src, gciCfg)
+			}})
+		}
+	}
+
+	if enabledLinters["gofumpt"] != nil {
+		options := golinters.GofumptOptions(&cfg.LintersSettings.Gofumpt)
+		formatters = append(formatters, fixFormatter{name: "gofumpt", format: func(_ string, src []byte) ([]byte, error) {
+			return gofumpt.Source(src, options)
+		}})
+	}
+
+	return formatters
+}
+
+// formatFixedFile formats the fixed content of the file, and checks it still parses:
+// an error is returned if the fixes broke the syntax of the file, the failures of the formatters are only warnings.
+func (f Fixer) formatFixedFile(path string, orig, fixed []byte) ([]byte, error) {
+	if _, err := parser.ParseFile(token.NewFileSet(), path, fixed, parser.ParseComments); err != nil {
+		if _, origErr := parser.ParseFile(token.NewFileSet(), path, orig, parser.ParseComments); origErr == nil {
+			return nil, fmt.Errorf("the fixed file doesn't parse: %w", err)
+		}
+		return fixed, nil // the syntax was already broken
+	}
+
+	if f.gofmt {
+		formatted, err := golinters.GofmtSource(path, fixed, f.cfg.LintersSettings.Gofmt.Simplify)
+		if err != nil {
+			f.log.Warnf("Can't format the fixed file %s with gofmt: %s", path, err)
+		} else {
+			fixed = formatted
+		}
+	}
+
+	for _, formatter := range f.formatters {
+		formatted, err := formatter.format(path, fixed)
+		if err != nil {
+			f.log.Warnf("Can't format the fixed file %s with %s: %s", path, formatter.name, err)
+			continue
+		}
+		fixed = formatted
+	}
+
+	return fixed, nil
+}
@@ -0,0 +1,141 @@
+package processors
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const fixerTestFile = `package p
+
+import (
+	"fmt"
+	"strings"
+)
+
+func f() string {
+	fmt.Println("debug")
+	return strings.ToUpper("a")
+}
+`
+
+func newTestFixer(enabledLinters ...string) *Fixer {
+	cfg := &config.Config{Issues: config.Issues{NeedFix: true}}
+
+	enabled := map[string]*linter.Config{}
+	for _, name := range enabledLinters {
+		enabled[name] = &linter.Config{}
+	}
+
+	return NewFixer(cfg, logutils.NewStderrLog("fixer"), fsutils.NewFileCache(), nil).WithEnabledLinters(enabled)
+}
+
+func writeFixerTestFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "p.go")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func fixerTestIssue(path string, line int, newLines ...string) result.Issue {
+	return result.Issue{
+		FromLinter:  "linter",
+		Text:        "issue",
+		Pos:         token.Position{Filename: path, Line: line},
+		Replacement: &result.Replacement{NewLines: newLines, NeedOnlyDelete: len(newLines) == 0},
+	}
+}
+
+func TestFixer_Process_goimports(t *testing.T) {
+	path := writeFixerTestFile(t, fixerTestFile)
+
+	issues := newTestFixer("goimports").Process([]result.Issue{fixerTestIssue(path, 9)})
+	assert.Empty(t, issues)
+
+	expected := `package p
+
+import (
+	"strings"
+)
+
+func f() string {
+	return strings.ToUpper("a")
+}
+`
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(content))
+}
+
+func TestFixer_Process_gofmt(t *testing.T) {
+	path := writeFixerTestFile(t, fixerTestFile)
+
+	// The unused import isn't removed without goimports.
+	issues := newTestFixer("gofmt").Process([]result.Issue{fixerTestIssue(path, 9, `        fmt.Println( "fixed" )`)})
+	assert.Empty(t, issues)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "\n\tfmt.Println(\"fixed\")\n")
+}
+
+func TestFixer_Process_noFormatter(t *testing.T) {
+	path := writeFixerTestFile(t, fixerTestFile)
+
+	issues := newTestFixer().Process([]result.Issue{fixerTestIssue(path, 9, `        fmt.Println( "fixed" )`)})
+	assert.Empty(t, issues)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "\n        fmt.Println( \"fixed\" )\n", "the fixed file isn't formatted")
+}
+
+func TestFixer_Process_gofmtSimplify(t *testing.T) {
+	path := writeFixerTestFile(t, fixerTestFile)
+
+	fixer := newTestFixer("gofmt")
+	fixer.cfg.LintersSettings.Gofmt.Simplify = true
+
+	issues := fixer.Process([]result.Issue{fixerTestIssue(path, 9, `	fmt.Println([]struct{ a int }{struct{ a int }{1}})`)})
+	assert.Empty(t, issues)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "\n\tfmt.Println([]struct{ a int }{{1}})\n")
+}
+
+func TestFixer_Process_brokenSyntax(t *testing.T) {
+	path := writeFixerTestFile(t, fixerTestFile)
+
+	issue := fixerTestIssue(path, 9, `	fmt.Println("debug"`)
+	issues := newTestFixer("goimports").Process([]result.Issue{issue})
+	assert.Equal(t, []result.Issue{issue}, issues)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, fixerTestFile, string(content), "the file is kept")
+}
+
+func TestFixer_Process_conflicts(t *testing.T) {
+	path := writeFixerTestFile(t, fixerTestFile)
+
+	first := fixerTestIssue(path, 9, `	fmt.Println("first")`, `	fmt.Println("second")`)
+	first.LineRange = &result.Range{From: 9, To: 10}
+	conflicting := fixerTestIssue(path, 10, `	return strings.ToLower("a")`)
+
+	issues := newTestFixer().Process([]result.Issue{first, conflicting})
+	assert.Equal(t, []result.Issue{conflicting}, issues, "the conflicting fix is reported")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "\tfmt.Println(\"first\")\n\tfmt.Println(\"second\")\n}\n")
+}
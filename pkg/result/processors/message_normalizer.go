@@ -0,0 +1,115 @@
+package processors
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+type normalizeTemplate struct {
+	linter string
+	re     *regexp.Regexp
+	repl   string
+}
+
+// MessageNormalizer rewrites issue texts to a consistent style:
+// per-linter templates are applied first, then capitalization and trailing period rules.
+type MessageNormalizer struct {
+	templates           []normalizeTemplate
+	textCase            string
+	stripTrailingPeriod bool
+}
+
+var _ Processor = &MessageNormalizer{}
+
+func NewMessageNormalizer(cfg *config.NormalizeSettings) *MessageNormalizer {
+	p := &MessageNormalizer{}
+	if !cfg.Enable {
+		return p
+	}
+
+	p.textCase = cfg.Case
+	p.stripTrailingPeriod = cfg.StripTrailingPeriod
+	for _, t := range cfg.Templates {
+		p.templates = append(p.templates, normalizeTemplate{
+			linter: t.Linter,
+			re:     regexp.MustCompile(t.Pattern),
+			repl:   t.Replacement,
+		})
+	}
+
+	return p
+}
+
+func (p MessageNormalizer) Name() string {
+	return "message_normalizer"
+}
+
+func (p MessageNormalizer) Process(issues []result.Issue) ([]result.Issue, error) {
+	if len(p.templates) == 0 && p.textCase == config.NormalizeCaseKeep && !p.stripTrailingPeriod {
+		return issues, nil
+	}
+
+	return transformIssues(issues, func(i *result.Issue) *result.Issue {
+		iCopy := *i
+		iCopy.Text = p.normalize(i.FromLinter, i.Text)
+		return &iCopy
+	}), nil
+}
+
+func (p MessageNormalizer) normalize(linter, text string) string {
+	for _, t := range p.templates {
+		if t.linter != "" && t.linter != linter {
+			continue
+		}
+
+		if t.re.MatchString(text) {
+			text = t.re.ReplaceAllString(text, t.repl)
+			break
+		}
+	}
+
+	if p.stripTrailingPeriod && !strings.HasSuffix(text, "...") {
+		text = strings.TrimSuffix(text, ".")
+	}
+
+	switch p.textCase {
+	case config.NormalizeCaseLower:
+		text = lowerFirst(text)
+	case config.NormalizeCaseUpper:
+		text = upperFirst(text)
+	}
+
+	return text
+}
+
+func (p MessageNormalizer) Finish() {}
+
+// lowerFirst lowercases the first letter only if it starts a plain word:
+// acronyms and check codes (`ID`, `SA4006`) are kept as is.
+func lowerFirst(s string) string {
+	first, size := utf8.DecodeRuneInString(s)
+	if !unicode.IsUpper(first) {
+		return s
+	}
+
+	next, _ := utf8.DecodeRuneInString(s[size:])
+	if !unicode.IsLower(next) {
+		return s
+	}
+
+	return string(unicode.ToLower(first)) + s[size:]
+}
+
+func upperFirst(s string) string {
+	first, size := utf8.DecodeRuneInString(s)
+	if !unicode.IsLower(first) {
+		return s
+	}
+
+	return string(unicode.ToUpper(first)) + s[size:]
+}
@@ -0,0 +1,44 @@
+package processors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestMessageNormalizer(t *testing.T) {
+	p := NewMessageNormalizer(&config.NormalizeSettings{
+		Enable:              true,
+		Case:                config.NormalizeCaseLower,
+		StripTrailingPeriod: true,
+		Templates: []config.NormalizeTemplate{
+			{Linter: "errcheck", Pattern: `^Error return value of (\S+) is not checked$`, Replacement: "error return value of `${1}` is not checked"},
+		},
+	})
+
+	cases := []struct{ linter, in, out string }{
+		{"errcheck", "Error return value of f.Close is not checked", "error return value of `f.Close` is not checked"},
+		{"other", "Error return value of f.Close is not checked", "error return value of f.Close is not checked"},
+		{"godot", "Comment should end in a period.", "comment should end in a period"},
+		{"gosec", "G104: Errors unhandled.", "G104: Errors unhandled"},
+		{"stylecheck", "ST1003: should not use underscores", "ST1003: should not use underscores"},
+		{"lint", "ID should be capitalized...", "ID should be capitalized..."},
+		{"other", "Trailing space ", "trailing space "},
+		{"other", "Two dots..", "two dots."},
+	}
+
+	for _, c := range cases {
+		out, err := p.Process([]result.Issue{{FromLinter: c.linter, Text: c.in}})
+		assert.NoError(t, err)
+		assert.Equal(t, []result.Issue{{FromLinter: c.linter, Text: c.out}}, out)
+	}
+}
+
+func TestMessageNormalizerDisabled(t *testing.T) {
+	p := NewMessageNormalizer(&config.NormalizeSettings{Case: config.NormalizeCaseUpper})
+
+	processAssertSame(t, p, newIssueFromTextTestCase("some text."))
+}
@@ -8,7 +8,9 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/golinters"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
@@ -25,6 +27,14 @@ type ignoredRange struct {
 	result.Range
 	col           int
 	originalRange *ignoredRange // pre-expanded range (used to match nolintlint issues)
+	directive     *NolintDirective
+	comment       nolintComment
+}
+
+// nolintComment is the position of the comment of a directive, to delete it.
+type nolintComment struct {
+	text       string
+	start, end token.Position
 }
 
 func (i *ignoredRange) doesMatch(issue *result.Issue) bool {
@@ -71,6 +81,14 @@ type Nolint struct {
 	log            logutils.Log
 
 	unknownLintersSet map[string]bool
+	// expired are the positions of the expired directives matching issues.
+	expired map[string]bool
+	// reasonRequired are the linters whose issues are suppressed only by the directives with a reason,
+	// unjustified the positions of the directives without reason matching their issues.
+	reasonRequired map[string]bool
+	unjustified    map[string]bool
+
+	now func() time.Time
 }
 
 func NewNolint(log logutils.Log, dbManager *lintersdb.Manager, enabledLinters map[string]*linter.Config) *Nolint {
@@ -80,9 +98,19 @@ func NewNolint(log logutils.Log, dbManager *lintersdb.Manager, enabledLinters ma
 		enabledLinters:    enabledLinters,
 		log:               log,
 		unknownLintersSet: map[string]bool{},
+		expired:           map[string]bool{},
+		unjustified:       map[string]bool{},
+		now:               time.Now,
 	}
 }
 
+// WithReasonRequired suppresses the issues of the linters, by canonical name, only by the directives with a reason:
+// the nolint-require-reason option of the policy.
+func (p *Nolint) WithReasonRequired(linters map[string]bool) *Nolint {
+	p.reasonRequired = linters
+	return p
+}
+
 var _ Processor = &Nolint{}
 
 func (p Nolint) Name() string {
@@ -95,16 +123,16 @@ func (p *Nolint) Process(issues []result.Issue) ([]result.Issue, error) {
 	return filterIssuesErr(issues, p.shouldPassIssue)
 }
 
-func (p *Nolint) getOrCreateFileData(i *result.Issue) (*fileData, error) {
-	fd := p.cache[i.FilePath()]
+func (p *Nolint) getOrCreateFileData(filePath string) (*fileData, error) {
+	fd := p.cache[filePath]
 	if fd != nil {
 		return fd, nil
 	}
 
 	fd = &fileData{}
-	p.cache[i.FilePath()] = fd
+	p.cache[filePath] = fd
 
-	if i.FilePath() == "" {
+	if filePath == "" {
 		return nil, fmt.Errorf("no file path for issue")
 	}
 
@@ -113,14 +141,14 @@ func (p *Nolint) getOrCreateFileData(i *result.Issue) (*fileData, error) {
 
 	// Don't use cached AST because they consume a lot of memory on large projects.
 	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, i.FilePath(), nil, parser.ParseComments)
+	f, err := parser.ParseFile(fset, filePath, fsutils.ParserSource(filePath), parser.ParseComments)
 	if err != nil {
 		// Don't report error because it's already must be reporter by typecheck or go/analysis.
 		return fd, nil
 	}
 
-	fd.ignoredRanges = p.buildIgnoredRangesForFile(f, fset, i.FilePath())
-	nolintDebugf("file %s: built nolint ranges are %+v", i.FilePath(), fd.ignoredRanges)
+	fd.ignoredRanges = p.buildIgnoredRangesForFile(f, fset, filePath)
+	nolintDebugf("file %s: built nolint ranges are %+v", filePath, fd.ignoredRanges)
 	return fd, nil
 }
 
@@ -157,13 +185,25 @@ func (p *Nolint) shouldPassIssue(i *result.Issue) (bool, error) {
 		nolintDebugf("checking that lint issue was used for %s: %v", i.ExpectedNoLintLinter, i)
 	}
 
-	fd, err := p.getOrCreateFileData(i)
+	fd, err := p.getOrCreateFileData(i.FilePath())
 	if err != nil {
 		return false, err
 	}
 
 	for _, ir := range fd.ignoredRanges {
 		if ir.doesMatch(i) {
+			if ir.directive != nil && ir.directive.IsExpired(p.now()) {
+				// The issue is reported again: it fails the run.
+				p.expired[fmt.Sprintf("%s:%d", i.FilePath(), ir.From)] = true
+				continue
+			}
+
+			if ir.directive != nil && ir.directive.Reason == "" && p.reasonRequired[i.FromLinter] {
+				// The policy requires a reason: the issue is reported again.
+				p.unjustified[fmt.Sprintf("%s:%d", i.FilePath(), ir.From)] = true
+				continue
+			}
+
 			nolintDebugf("found ignored range for issue %v: %v", i, ir)
 			ir.matchedIssueFromLinter[i.FromLinter] = true
 			if ir.originalRange != nil {
@@ -225,6 +265,7 @@ func (p *Nolint) extractFileCommentsInlineRanges(fset *token.FileSet, comments .
 		for _, c := range g.List {
 			ir := p.extractInlineRangeFromComment(c.Text, g, fset)
 			if ir != nil {
+				ir.comment = nolintComment{text: c.Text, start: fset.Position(c.Pos()), end: fset.Position(c.End())}
 				ret = append(ret, *ir)
 			}
 		}
@@ -234,8 +275,8 @@ func (p *Nolint) extractFileCommentsInlineRanges(fset *token.FileSet, comments .
 }
 
 func (p *Nolint) extractInlineRangeFromComment(text string, g ast.Node, fset *token.FileSet) *ignoredRange {
-	text = strings.TrimLeft(text, "/ ")
-	if !nolintRe.MatchString(text) {
+	directive := ParseNolintDirective(text)
+	if directive == nil {
 		return nil
 	}
 
@@ -249,20 +290,17 @@ func (p *Nolint) extractInlineRangeFromComment(text string, g ast.Node, fset *to
 			col:                    pos.Column,
 			linters:                linters,
 			matchedIssueFromLinter: make(map[string]bool),
+			directive:              directive,
 		}
 	}
 
-	if !strings.HasPrefix(text, "nolint:") {
+	if len(directive.Linters) == 0 {
 		return buildRange(nil) // ignore all linters
 	}
 
 	// ignore specific linters
 	var linters []string
-	text = strings.Split(text, "//")[0] // allow another comment after this comment
-	linterItems := strings.Split(strings.TrimPrefix(text, "nolint:"), ",")
-	for _, linter := range linterItems {
-		linterName := strings.ToLower(strings.TrimSpace(linter))
-
+	for _, linterName := range directive.Linters {
 		lcs := p.dbManager.GetLinterConfigs(linterName)
 		if lcs == nil {
 			p.unknownLintersSet[linterName] = true
@@ -281,6 +319,15 @@ func (p *Nolint) extractInlineRangeFromComment(text string, g ast.Node, fset *to
 }
 
 func (p Nolint) Finish() {
+	if len(p.expired) != 0 {
+		p.log.Warnf("Found expired //nolint directives, their issues are reported: %s", sortedKeys(p.expired))
+	}
+
+	if len(p.unjustified) != 0 {
+		p.log.Warnf("Found //nolint directives without reason for linters requiring one by the policy, their issues are reported: %s",
+			sortedKeys(p.unjustified))
+	}
+
 	if len(p.unknownLintersSet) == 0 {
 		return
 	}
@@ -294,6 +341,16 @@ func (p Nolint) Finish() {
 	p.log.Warnf("Found unknown linters in //nolint directives: %s", strings.Join(unknownLinters, ", "))
 }
 
+// sortedKeys joins the sorted keys of the set.
+func sortedKeys(set map[string]bool) string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
+
 // put nolintlint last
 type sortWithNolintlintLast []result.Issue
 
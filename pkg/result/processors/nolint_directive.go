@@ -0,0 +1,74 @@
+package processors
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+const nolintExpiresLayout = "2006-01-02"
+
+var (
+	nolintReasonRe  = regexp.MustCompile(`reason="([^"]*)"`)
+	nolintExpiresRe = regexp.MustCompile(`expires=(\S+)`)
+)
+
+// NolintDirective is a parsed `//nolint` comment:
+//
+//	//nolint:linter1,linter2 // reason="false positive" expires=2025-06-01
+//
+// Without `reason=`, the explanation after the second `//` is the reason.
+type NolintDirective struct {
+	// Linters are the names (or aliases) of the linters, all linters are ignored if it's empty.
+	Linters []string
+	Reason  string
+	// Expires is the day from which the directive doesn't suppress issues anymore, zero if not set.
+	Expires time.Time
+	// InvalidExpires is set if the expiration date can't be parsed: the directive is considered as expired.
+	InvalidExpires string
+}
+
+// ParseNolintDirective parses a comment, it returns nil if the comment is not a `nolint` directive.
+func ParseNolintDirective(comment string) *NolintDirective {
+	text := strings.TrimLeft(comment, "/ ")
+	if !nolintRe.MatchString(text) {
+		return nil
+	}
+
+	d := &NolintDirective{}
+
+	directive, explanation, _ := strings.Cut(text, "//")
+
+	if strings.HasPrefix(directive, "nolint:") {
+		for _, item := range strings.Split(strings.TrimPrefix(directive, "nolint:"), ",") {
+			d.Linters = append(d.Linters, strings.ToLower(strings.TrimSpace(item)))
+		}
+	}
+
+	if m := nolintExpiresRe.FindStringSubmatch(explanation); m != nil {
+		expires, err := time.ParseInLocation(nolintExpiresLayout, m[1], time.Local)
+		if err != nil {
+			d.InvalidExpires = m[1]
+		} else {
+			d.Expires = expires
+		}
+		explanation = strings.Replace(explanation, m[0], "", 1)
+	}
+
+	if m := nolintReasonRe.FindStringSubmatch(explanation); m != nil {
+		d.Reason = m[1]
+	} else {
+		d.Reason = strings.TrimSpace(explanation)
+	}
+
+	return d
+}
+
+// IsExpired checks if the directive doesn't suppress issues anymore at the given time.
+func (d *NolintDirective) IsExpired(now time.Time) bool {
+	if d.InvalidExpires != "" {
+		return true
+	}
+
+	return !d.Expires.IsZero() && !now.Before(d.Expires)
+}
@@ -5,6 +5,7 @@ import (
 	"go/token"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -345,3 +346,90 @@ func TestNolintUnused(t *testing.T) {
 		processAssertEmpty(t, p, nolintlintIssueVarcheck)
 	})
 }
+
+func TestParseNolintDirective(t *testing.T) {
+	testCases := []struct {
+		comment  string
+		expected *NolintDirective
+	}{
+		{comment: "// some comment"},
+		{comment: "//nolint", expected: &NolintDirective{}},
+		{
+			comment:  "//nolint:errcheck, Gofmt // not important",
+			expected: &NolintDirective{Linters: []string{"errcheck", "gofmt"}, Reason: "not important"},
+		},
+		{
+			comment: `//nolint:errcheck // reason="wrapped by the caller" expires=2025-06-01`,
+			expected: &NolintDirective{
+				Linters: []string{"errcheck"},
+				Reason:  "wrapped by the caller",
+				Expires: time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local),
+			},
+		},
+		{
+			comment:  "//nolint:errcheck // expires=tomorrow",
+			expected: &NolintDirective{Linters: []string{"errcheck"}, InvalidExpires: "tomorrow"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.comment, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, ParseNolintDirective(test.comment))
+		})
+	}
+}
+
+func TestNolintExpiry(t *testing.T) {
+	fileName := filepath.Join("testdata", "nolint_expiry.go")
+
+	log := getMockLog()
+	log.On("Warnf", "Found expired //nolint directives, their issues are reported: %s",
+		fileName+":3, "+fileName+":7")
+
+	p := newTestNolintProcessor(log)
+	p.now = func() time.Time {
+		return time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	}
+
+	issue := func(line int) result.Issue {
+		return result.Issue{
+			Pos:        token.Position{Filename: fileName, Line: line},
+			FromLinter: "varcheck",
+		}
+	}
+
+	processAssertSame(t, p, issue(3))
+	processAssertEmpty(t, p, issue(5))
+	processAssertSame(t, p, issue(7))
+
+	p.Finish()
+	log.AssertExpectations(t)
+}
+
+func TestNolintReasonRequired(t *testing.T) {
+	fileName := filepath.Join("testdata", "nolint_reason.go")
+
+	log := getMockLog()
+	log.On("Warnf", "Found //nolint directives without reason for linters requiring one by the policy, their issues are reported: %s",
+		fileName+":3, "+fileName+":7")
+
+	p := newTestNolintProcessor(log).WithReasonRequired(map[string]bool{"varcheck": true})
+
+	issue := func(line int, linter string) result.Issue {
+		return result.Issue{
+			Pos:        token.Position{Filename: fileName, Line: line},
+			FromLinter: linter,
+		}
+	}
+
+	processAssertSame(t, p, issue(3, "varcheck"))
+	processAssertEmpty(t, p, issue(5, "varcheck"))
+	processAssertSame(t, p, issue(7, "varcheck"))
+	processAssertEmpty(t, p, issue(7, "gofmt"))
+
+	p.Finish()
+	log.AssertExpectations(t)
+}
@@ -0,0 +1,104 @@
+package processors
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+type LintersOverride struct {
+	Path       string
+	Enable     []string
+	Disable    []string
+	DisableAll bool
+}
+
+type lintersOverride struct {
+	path       *regexp.Regexp
+	enable     map[string]bool
+	disable    map[string]bool
+	disableAll bool
+}
+
+// Overrides filters issues of linters enabled or disabled for specific paths.
+type Overrides struct {
+	overrides []lintersOverride
+	// onlyInOverrides are the linters enabled only by overrides: they are disabled outside them.
+	onlyInOverrides map[string]bool
+}
+
+var _ Processor = (*Overrides)(nil)
+
+// NewOverrides creates the processor: enabledLinters are the linters enabled by the main configuration.
+func NewOverrides(overrides []LintersOverride, enabledLinters []string) (*Overrides, error) {
+	enabled := toSet(enabledLinters)
+	p := &Overrides{onlyInOverrides: map[string]bool{}}
+
+	for _, o := range overrides {
+		path := normalizePathInRegex(o.Path)
+		pathRe, err := regexp.Compile(path)
+		if err != nil {
+			return nil, fmt.Errorf("can't compile regexp %q: %s", path, err)
+		}
+
+		p.overrides = append(p.overrides, lintersOverride{
+			path:       pathRe,
+			enable:     toSet(o.Enable),
+			disable:    toSet(o.Disable),
+			disableAll: o.DisableAll,
+		})
+
+		for _, name := range o.Enable {
+			if !enabled[name] {
+				p.onlyInOverrides[name] = true
+			}
+		}
+	}
+
+	return p, nil
+}
+
+func (p Overrides) Name() string {
+	return "overrides"
+}
+
+func (p Overrides) Process(issues []result.Issue) ([]result.Issue, error) {
+	if len(p.overrides) == 0 {
+		return issues, nil
+	}
+
+	return filterIssues(issues, p.isEnabled), nil
+}
+
+func (p Overrides) isEnabled(i *result.Issue) bool {
+	enabled := !p.onlyInOverrides[i.FromLinter]
+
+	for _, o := range p.overrides {
+		if !o.path.MatchString(i.FilePath()) {
+			continue
+		}
+
+		switch {
+		case o.enable[i.FromLinter]:
+			enabled = true
+		case o.disableAll && i.FromLinter != "typecheck":
+			// The type errors are kept, like with linters.disable-all: they must be disabled explicitly.
+			enabled = false
+		case o.disable[i.FromLinter]:
+			enabled = false
+		}
+	}
+
+	return enabled
+}
+
+func (p Overrides) Finish() {}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,46 @@
+package processors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestOverrides(t *testing.T) {
+	p, err := NewOverrides([]LintersOverride{
+		{Path: `^internal/legacy/`, DisableAll: true, Enable: []string{"govet"}},
+		{Path: `^internal/legacy/new/`, Enable: []string{"gocyclo"}},
+		{Path: `^cmd/`, Enable: []string{"lll"}, Disable: []string{"gocyclo"}},
+		{Path: `^gen/`, DisableAll: true, Disable: []string{"typecheck"}},
+	}, []string{"govet", "gocyclo"})
+	require.NoError(t, err)
+
+	cases := []struct {
+		path, linter string
+		kept         bool
+	}{
+		{path: "pkg/a.go", linter: "gocyclo", kept: true},
+		{path: "pkg/a.go", linter: "lll", kept: false},
+		{path: "pkg/a.go", linter: "typecheck", kept: true},
+		{path: "internal/legacy/a.go", linter: "gocyclo", kept: false},
+		{path: "internal/legacy/a.go", linter: "govet", kept: true},
+		{path: "internal/legacy/new/a.go", linter: "gocyclo", kept: true},
+		{path: "internal/legacy/new/a.go", linter: "typecheck", kept: true},
+		{path: "cmd/a.go", linter: "lll", kept: true},
+		{path: "cmd/a.go", linter: "gocyclo", kept: false},
+		{path: "gen/a.go", linter: "typecheck", kept: false},
+	}
+
+	for _, c := range cases {
+		i := newIssueFromIssueTestCase(issueTestCase{Path: c.path, Linter: c.linter})
+		out := process(t, p, i)
+		if c.kept {
+			assert.Equal(t, []result.Issue{i}, out, "%s %s", c.path, c.linter)
+		} else {
+			assert.Empty(t, out, "%s %s", c.path, c.linter)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package processors
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/vcs"
+)
+
+// Patch keeps the issues on the lines added or modified by a unified diff, without version control system:
+// the diffs of git, Mercurial, Gerrit or mailed patches are accepted.
+type Patch struct {
+	wholeFiles bool
+
+	// files are the changed lines of the files of the patch, with the positions of the lines in the diffs of the files.
+	files map[string]map[int]int
+	// matches are the files of the patch matching the paths of the issues, relative to the current directory.
+	matches map[string]string
+}
+
+var _ Processor = &Patch{}
+
+// NewPatch reads the patch file, the processor keeps all the issues if the path is empty.
+func NewPatch(path string, wholeFiles bool) (*Patch, error) {
+	p := &Patch{wholeFiles: wholeFiles, matches: map[string]string{}}
+	if path == "" {
+		return p, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read diff file %s: %w", path, err)
+	}
+
+	p.files, err = parsePatch(data)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse diff file %s: %w", path, err)
+	}
+
+	return p, nil
+}
+
+func (p Patch) Name() string {
+	return "patch"
+}
+
+func (p *Patch) Process(issues []result.Issue) ([]result.Issue, error) {
+	if p.files == nil {
+		return issues, nil
+	}
+
+	return transformIssues(issues, func(i *result.Issue) *result.Issue {
+		lines, ok := p.files[p.match(i.FilePath())]
+		if !ok {
+			return nil
+		}
+
+		hunkPos, ok := lines[i.Line()]
+		if p.wholeFiles {
+			hunkPos, ok = i.Line(), true
+		}
+		if !ok {
+			return nil
+		}
+
+		newI := *i
+		newI.HunkPos = hunkPos
+		return &newI
+	}), nil
+}
+
+func (Patch) Finish() {}
+
+// match returns the file of the patch of the path of an issue:
+// the paths of the patch are relative to the root of the repository, which can be a parent of the current directory.
+func (p *Patch) match(path string) string {
+	if m, ok := p.matches[path]; ok {
+		return m
+	}
+
+	path = filepath.ToSlash(filepath.Clean(path))
+
+	match := ""
+	if _, ok := p.files[path]; ok {
+		match = path
+	} else {
+		for file := range p.files {
+			if strings.HasSuffix(file, "/"+path) && len(file) > len(match) {
+				match = file
+			}
+		}
+	}
+
+	p.matches[path] = match
+	return match
+}
+
+// parsePatch returns the added lines of the files of the unified diff, with their positions in the diffs of the files:
+// the position of the first line after the first hunk header is 1.
+// The lines outside the files diffs are ignored, e.g. the headers of a mail.
+func parsePatch(data []byte) (map[string]map[int]int, error) {
+	files := map[string]map[int]int{}
+
+	var (
+		oldPath string
+		lines   map[int]int // nil for a deleted file
+		hunkPos int
+
+		line, oldLeft, newLeft int
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<20)
+
+	for n := 1; scanner.Scan(); n++ {
+		text := scanner.Text()
+
+		if oldLeft > 0 || newLeft > 0 {
+			hunkPos++
+
+			switch {
+			case strings.HasPrefix(text, "+"):
+				if lines != nil {
+					lines[line] = hunkPos
+				}
+				line++
+				newLeft--
+			case strings.HasPrefix(text, "-"):
+				oldLeft--
+			case strings.HasPrefix(text, `\`):
+				// No newline at end of file.
+			default:
+				// The trailing spaces of the context lines can be stripped by the mailers.
+				line++
+				oldLeft--
+				newLeft--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "--- "):
+			oldPath = patchPath(text[4:])
+		case strings.HasPrefix(text, "+++ "):
+			newPath := patchPath(text[4:])
+			if newPath == "/dev/null" {
+				lines = nil
+				continue
+			}
+
+			// Strip the prefixes of git and Mercurial, unless the diff was made with --no-prefix.
+			if (strings.HasPrefix(oldPath, "a/") || oldPath == "/dev/null") && strings.HasPrefix(newPath, "b/") {
+				newPath = newPath[2:]
+			}
+
+			lines = map[int]int{}
+			files[filepath.ToSlash(filepath.Clean(newPath))] = lines
+			hunkPos = 0
+		case strings.HasPrefix(text, "@@ "):
+			var err error
+			line, oldLeft, newLeft, err = vcs.ParseHunkHeader(text)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			if hunkPos != 0 {
+				hunkPos++ // the next hunk headers count as lines of the diff
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// patchPath returns the path of the header of a file, without a timestamp.
+func patchPath(header string) string {
+	path, _, _ := strings.Cut(header, "\t")
+	return strings.TrimSpace(path)
+}
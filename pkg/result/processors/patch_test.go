@@ -0,0 +1,109 @@
+package processors
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const testPatch = `From 1234 Mon Sep 17 00:00:00 2001
+Subject: [PATCH] Change a and b
+
+---
+diff --git a/pkg/a.go b/pkg/a.go
+index 1111111..2222222 100644
+--- a/pkg/a.go
++++ b/pkg/a.go
+@@ -1,4 +1,5 @@
+ package pkg
+
+-func a() {}
++func a() {
++}
+
+@@ -10,2 +11,3 @@ func b() {
+ 	x := 1
++	y := 2
+ 	_ = x
+diff --git a/pkg/new.go b/pkg/new.go
+new file mode 100644
+--- /dev/null
++++ b/pkg/new.go
+@@ -0,0 +1,2 @@
++package pkg
++var v = 1
+diff --git a/pkg/old.go b/pkg/old.go
+deleted file mode 100644
+--- a/pkg/old.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package pkg
+--
+2.30.0
+`
+
+func newPatchIssue(path string, line int) result.Issue {
+	return result.Issue{FromLinter: "linter", Text: "text", Pos: token.Position{Filename: path, Line: line}}
+}
+
+func TestParsePatch(t *testing.T) {
+	files, err := parsePatch([]byte(testPatch))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]map[int]int{
+		"pkg/a.go":   {3: 4, 4: 5, 12: 9},
+		"pkg/new.go": {1: 1, 2: 2},
+	}, files)
+}
+
+func TestParsePatch_invalid(t *testing.T) {
+	_, err := parsePatch([]byte("--- a/a.go\n+++ b/a.go\n@@ -1 +x @@\n"))
+	assert.EqualError(t, err, `line 3: invalid hunk header "@@ -1 +x @@": strconv.Atoi: parsing "x": invalid syntax`)
+}
+
+func TestPatch_Process(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "diff.patch")
+	require.NoError(t, os.WriteFile(path, []byte(testPatch), 0o600))
+
+	p, err := NewPatch(path, false)
+	require.NoError(t, err)
+
+	// The current directory is pkg/.
+	issues := []result.Issue{
+		newPatchIssue("a.go", 3),
+		newPatchIssue("a.go", 5),
+		newPatchIssue("a.go", 12),
+		newPatchIssue("new.go", 2),
+		newPatchIssue("other.go", 1),
+	}
+
+	processed, err := p.Process(issues)
+	require.NoError(t, err)
+
+	want := []result.Issue{issues[0], issues[2], issues[3]}
+	want[0].HunkPos, want[1].HunkPos, want[2].HunkPos = 4, 9, 2
+	assert.Equal(t, want, processed)
+
+	p, err = NewPatch(path, true)
+	require.NoError(t, err)
+
+	processed, err = p.Process(issues)
+	require.NoError(t, err)
+	assert.Len(t, processed, 4)
+}
+
+func TestPatch_Process_noPatch(t *testing.T) {
+	p, err := NewPatch("", false)
+	require.NoError(t, err)
+
+	issues := []result.Issue{newPatchIssue("a.go", 1)}
+	processed, err := p.Process(issues)
+	require.NoError(t, err)
+	assert.Equal(t, issues, processed)
+}
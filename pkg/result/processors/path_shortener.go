@@ -31,10 +31,15 @@ func (p PathShortener) Name() string {
 func (p PathShortener) Process(issues []result.Issue) ([]result.Issue, error) {
 	return transformIssues(issues, func(i *result.Issue) *result.Issue {
 		newI := i
-		newI.Text = strings.Replace(newI.Text, p.wd+"/", "", -1)
-		newI.Text = strings.Replace(newI.Text, p.wd, "", -1)
+		newI.Text = p.shorten(newI.Text)
 		return newI
 	}), nil
 }
 
+// shorten removes the working directory from the paths of the text.
+func (p PathShortener) shorten(text string) string {
+	text = strings.Replace(text, p.wd+"/", "", -1)
+	return strings.Replace(text, p.wd, "", -1)
+}
+
 func (p PathShortener) Finish() {}
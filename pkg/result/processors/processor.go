@@ -9,3 +9,10 @@ type Processor interface {
 	Name() string
 	Finish()
 }
+
+// RunIssuesProcessor is a processor adding issues computed from all the issues of the run:
+// when the issues are processed in batches, for the streaming output, only the last batch, without issues, is processed by it.
+type RunIssuesProcessor interface {
+	Processor
+	ProcessesRunIssues()
+}
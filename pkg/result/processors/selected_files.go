@@ -0,0 +1,61 @@
+package processors
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// SelectedFiles keeps only the issues of the files given as arguments in their directories:
+// the whole package of a file is loaded and analyzed, but only the issues of the file are reported.
+// The issues of the other directories (e.g. of the packages given as arguments) are kept.
+type SelectedFiles struct {
+	files map[string]bool
+	dirs  map[string]bool
+}
+
+var _ Processor = (*SelectedFiles)(nil)
+
+func NewSelectedFiles(runArgs []string) (*SelectedFiles, error) {
+	p := &SelectedFiles{files: map[string]bool{}, dirs: map[string]bool{}}
+
+	for _, arg := range runArgs {
+		if !strings.HasSuffix(arg, goFileSuffix) {
+			continue
+		}
+
+		absArg, err := filepath.Abs(arg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to abs-ify arg %q", arg)
+		}
+
+		p.files[absArg] = true
+		p.dirs[filepath.Dir(absArg)] = true
+	}
+
+	return p, nil
+}
+
+func (p SelectedFiles) Name() string {
+	return "selected_files"
+}
+
+func (p SelectedFiles) Process(issues []result.Issue) ([]result.Issue, error) {
+	if len(p.files) == 0 {
+		return issues, nil
+	}
+
+	return filterIssues(issues, func(i *result.Issue) bool {
+		path, err := filepath.Abs(i.FilePath())
+		if err != nil {
+			return true
+		}
+
+		return !p.dirs[filepath.Dir(path)] || p.files[path]
+	}), nil
+}
+
+func (p SelectedFiles) Finish() {}
@@ -0,0 +1,24 @@
+package processors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectedFiles(t *testing.T) {
+	p, err := NewSelectedFiles([]string{"a/b.go", "./c/..."})
+	require.NoError(t, err)
+
+	abs, err := filepath.Abs("a/b.go")
+	require.NoError(t, err)
+
+	processAssertSame(t, p, newFileIssue("a/b.go"), newFileIssue(abs), newFileIssue("c/d.go"), newFileIssue("e.go"))
+	processAssertEmpty(t, p, newFileIssue("a/c.go"), newFileIssue(filepath.Join(filepath.Dir(abs), "d.go")))
+
+	p, err = NewSelectedFiles([]string{"./..."})
+	require.NoError(t, err)
+
+	processAssertSame(t, p, newFileIssue("a/c.go"))
+}
@@ -1,8 +1,13 @@
 package processors
 
 import (
+	"path/filepath"
 	"regexp"
+	"strings"
 
+	"github.com/gobwas/glob"
+
+	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
@@ -11,15 +16,23 @@ import (
 type severityRule struct {
 	baseRule
 	severity string
+	ruleIDs  []string
+	pathGlob glob.Glob
+	scope    string
 }
 
 type SeverityRule struct {
 	BaseRule
 	Severity string
+	RuleIDs  []string
+	PathGlob string
+	Scope    string
 }
 
 type SeverityRules struct {
 	defaultSeverity string
+	lintersDefault  map[string]string
+	matchAll        bool
 	rules           []severityRule
 	lineCache       *fsutils.LineCache
 	log             logutils.Log
@@ -36,12 +49,25 @@ func NewSeverityRules(defaultSeverity string, rules []SeverityRule, lineCache *f
 	return r
 }
 
+// WithDefaults sets the match mode of the rules (config.SeverityMatchFirst or config.SeverityMatchAll)
+// and the default severities of the linters, used instead of the default severity.
+func (p *SeverityRules) WithDefaults(match string, lintersDefault map[string]string) *SeverityRules {
+	p.matchAll = match == config.SeverityMatchAll
+	p.lintersDefault = lintersDefault
+	return p
+}
+
 func createSeverityRules(rules []SeverityRule, prefix string) []severityRule {
 	parsedRules := make([]severityRule, 0, len(rules))
 	for _, rule := range rules {
 		parsedRule := severityRule{}
 		parsedRule.linters = rule.Linters
 		parsedRule.severity = rule.Severity
+		parsedRule.ruleIDs = rule.RuleIDs
+		parsedRule.scope = rule.Scope
+		if rule.PathGlob != "" {
+			parsedRule.pathGlob = glob.MustCompile(filepath.ToSlash(rule.PathGlob), '/')
+		}
 		if rule.Text != "" {
 			parsedRule.text = regexp.MustCompile(prefix + rule.Text)
 		}
@@ -56,25 +82,67 @@ func createSeverityRules(rules []SeverityRule, prefix string) []severityRule {
 	return parsedRules
 }
 
+func (r *severityRule) match(issue *result.Issue, lineCache *fsutils.LineCache, log logutils.Log) bool {
+	if r.baseRule.isEmpty() && len(r.ruleIDs) == 0 && r.pathGlob == nil && r.scope == "" {
+		return false
+	}
+	if len(r.ruleIDs) != 0 && !r.matchRuleID(issue) {
+		return false
+	}
+	if r.pathGlob != nil && !r.pathGlob.Match(filepath.ToSlash(issue.FilePath())) {
+		return false
+	}
+	if r.scope != "" && isTestFile(issue.FilePath()) != (r.scope == config.SeverityScopeTests) {
+		return false
+	}
+
+	return r.baseRule.isEmpty() || r.baseRule.match(issue, lineCache, log)
+}
+
+func (r *severityRule) matchRuleID(issue *result.Issue) bool {
+	for _, id := range r.ruleIDs {
+		if strings.EqualFold(id, issue.RuleID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isTestFile(path string) bool {
+	return strings.HasSuffix(path, "_test.go")
+}
+
 func (p SeverityRules) Process(issues []result.Issue) ([]result.Issue, error) {
-	if len(p.rules) == 0 && p.defaultSeverity == "" {
+	if len(p.rules) == 0 && p.defaultSeverity == "" && len(p.lintersDefault) == 0 {
 		return issues, nil
 	}
 	return transformIssues(issues, func(i *result.Issue) *result.Issue {
+		defaultSeverity := p.defaultSeverity
+		if severity, ok := p.lintersDefault[i.FromLinter]; ok {
+			defaultSeverity = severity
+		}
+
+		matched := false
 		for _, rule := range p.rules {
 			rule := rule
 
-			ruleSeverity := p.defaultSeverity
+			ruleSeverity := defaultSeverity
 			if rule.severity != "" {
 				ruleSeverity = rule.severity
 			}
 
 			if rule.match(i, p.lineCache, p.log) {
 				i.Severity = ruleSeverity
-				return i
+				if !p.matchAll {
+					return i
+				}
+				matched = true
 			}
 		}
-		i.Severity = p.defaultSeverity
+		if !matched {
+			i.Severity = defaultSeverity
+		}
 		return i
 	}), nil
 }
@@ -1,11 +1,13 @@
 package processors
 
 import (
+	"go/token"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/report"
@@ -202,3 +204,53 @@ func TestSeverityRulesCaseSensitive(t *testing.T) {
 	}
 	assert.Equal(t, expectedCases, resultingCases)
 }
+
+func TestSeverityRules_ruleIDsAndScope(t *testing.T) {
+	rules := []SeverityRule{
+		{Severity: "info", RuleIDs: []string{"G404"}, Scope: config.SeverityScopeTests},
+		{Severity: "error", RuleIDs: []string{"G404"}},
+		{Severity: "critical", PathGlob: "internal/**/*.go", BaseRule: BaseRule{Linters: []string{"gosec"}}},
+	}
+	newIssue := func(path, rule string) result.Issue {
+		return result.Issue{FromLinter: "gosec", RuleID: rule, Pos: token.Position{Filename: path}}
+	}
+	severities := func(issues []result.Issue) []string {
+		var res []string
+		for _, i := range issues {
+			res = append(res, i.Severity)
+		}
+		return res
+	}
+
+	issues := []result.Issue{
+		newIssue("a_test.go", "G404"),
+		newIssue("a.go", "G404"),
+		newIssue("internal/a/b.go", "G404"),
+		newIssue("internal/a/b.go", "G101"),
+		newIssue("a.go", "G101"),
+	}
+
+	p := NewSeverityRules("warning", rules, nil, nil).WithDefaults(config.SeverityMatchFirst, nil)
+	assert.Equal(t, []string{"info", "error", "error", "critical", "warning"}, severities(process(t, p, issues...)))
+
+	// the last matching rule sets the severity
+	p = NewSeverityRules("warning", rules, nil, nil).WithDefaults(config.SeverityMatchAll, nil)
+	assert.Equal(t, []string{"error", "error", "critical", "critical", "warning"}, severities(process(t, p, issues...)))
+}
+
+func TestSeverityRules_lintersDefault(t *testing.T) {
+	p := NewSeverityRules("error", []SeverityRule{
+		{RuleIDs: []string{"G404"}},
+	}, nil, nil).WithDefaults("", map[string]string{"gosec": "warning"})
+
+	issues := process(t, p,
+		result.Issue{FromLinter: "gosec", RuleID: "G404"},
+		result.Issue{FromLinter: "gosec", RuleID: "G101"},
+		result.Issue{FromLinter: "errcheck", RuleID: "errcheck"},
+	)
+
+	// the rules without severity use the default severity of the linter
+	assert.Equal(t, "warning", issues[0].Severity)
+	assert.Equal(t, "warning", issues[1].Severity)
+	assert.Equal(t, "error", issues[2].Severity)
+}
@@ -138,6 +138,7 @@ func (p *SkipDirs) shouldPassIssueDirs(issueRelDir, issueAbsDir string) bool {
 
 func (p *SkipDirs) Finish() {
 	for dir, stat := range p.skippedDirs {
-		p.log.Infof("Skipped %d issues from dir %s by pattern %s", stat.count, dir, stat.pattern)
+		logutils.InfoEvent(p.log, "issues_skipped", logutils.Fields{"dir": dir, "pattern": stat.pattern, "count": stat.count},
+			"Skipped %d issues from dir %s by pattern %s", stat.count, dir, stat.pattern)
 	}
 }
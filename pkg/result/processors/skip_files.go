@@ -4,16 +4,20 @@ import (
 	"fmt"
 	"regexp"
 
+	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
 type SkipFiles struct {
 	patterns []*regexp.Regexp
+	log      logutils.Log
+	// skipped are the counts of the skipped issues by file, with the pattern of the file.
+	skipped map[string]*skipStat
 }
 
 var _ Processor = (*SkipFiles)(nil)
 
-func NewSkipFiles(patterns []string) (*SkipFiles, error) {
+func NewSkipFiles(patterns []string, log logutils.Log) (*SkipFiles, error) {
 	var patternsRe []*regexp.Regexp
 	for _, p := range patterns {
 		p = normalizePathInRegex(p)
@@ -26,6 +30,8 @@ func NewSkipFiles(patterns []string) (*SkipFiles, error) {
 
 	return &SkipFiles{
 		patterns: patternsRe,
+		log:      log,
+		skipped:  map[string]*skipStat{},
 	}, nil
 }
 
@@ -39,8 +45,12 @@ func (p SkipFiles) Process(issues []result.Issue) ([]result.Issue, error) {
 	}
 
 	return filterIssues(issues, func(i *result.Issue) bool {
-		for _, p := range p.patterns {
-			if p.MatchString(i.FilePath()) {
+		for _, pattern := range p.patterns {
+			if pattern.MatchString(i.FilePath()) {
+				if p.skipped[i.FilePath()] == nil {
+					p.skipped[i.FilePath()] = &skipStat{pattern: pattern.String()}
+				}
+				p.skipped[i.FilePath()].count++
 				return false
 			}
 		}
@@ -49,4 +59,9 @@ func (p SkipFiles) Process(issues []result.Issue) ([]result.Issue, error) {
 	}), nil
 }
 
-func (p SkipFiles) Finish() {}
+func (p SkipFiles) Finish() {
+	for file, stat := range p.skipped {
+		logutils.InfoEvent(p.log, "issues_skipped", logutils.Fields{"file": file, "pattern": stat.pattern, "count": stat.count},
+			"Skipped %d issues from file %s by pattern %s", stat.count, file, stat.pattern)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/sliceutil"
 )
 
 // Base propose of this functionality to sort results (issues)
@@ -21,25 +22,58 @@ type SortResults struct {
 }
 
 func NewSortResults(cfg *config.Config) *SortResults {
-	// For sorting we are comparing (in next order): file names, line numbers,
-	// position, and finally - giving up.
+	// For sorting we are comparing (in next order): the keys of the sort order (file names, line numbers
+	// and columns by default), the positions, and finally - giving up.
+	var cmp comparator
+	if cfg.Output.SortResults {
+		cmp = sortOrderComparator(cfg.Output.SortOrder)
+	}
+
+	// The issues not covered by the tests can be sorted first, alone or before the positions.
+	if cfg.Issues.Coverage.UncoveredFirst {
+		cmp = ByCoverage{next: cmp}
+	}
+
 	return &SortResults{
-		cmp: ByName{
-			next: ByLine{
-				next: ByColumn{},
-			},
-		},
+		cmp: cmp,
 		cfg: cfg,
 	}
 }
 
+// sortOrderComparator chains the comparators of the keys of the sort order,
+// then the comparators of the positions not in the sort order.
+func sortOrderComparator(order []string) comparator {
+	keys := append([]string{}, order...)
+	for _, key := range []string{config.SortOrderFile, config.SortOrderLine} {
+		if !sliceutil.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	var cmp comparator
+	for i := len(keys) - 1; i >= 0; i-- {
+		switch keys[i] {
+		case config.SortOrderFile:
+			cmp = ByName{next: cmp}
+		case config.SortOrderLine:
+			cmp = ByLine{next: ByColumn{next: cmp}}
+		case config.SortOrderLinter:
+			cmp = ByLinter{next: cmp}
+		case config.SortOrderSeverity:
+			cmp = BySeverity{next: cmp}
+		}
+	}
+
+	return cmp
+}
+
 // Process is performing sorting of the result issues.
 func (sr SortResults) Process(issues []result.Issue) ([]result.Issue, error) {
-	if !sr.cfg.Output.SortResults {
+	if !sr.cfg.Output.SortResults && !sr.cfg.Issues.Coverage.UncoveredFirst {
 		return issues, nil
 	}
 
-	sort.Slice(issues, func(i, j int) bool {
+	sort.SliceStable(issues, func(i, j int) bool {
 		return sr.cmp.Compare(&issues[i], &issues[j]) == Less
 	})
 
@@ -86,6 +120,9 @@ var (
 	_ comparator = (*ByName)(nil)
 	_ comparator = (*ByLine)(nil)
 	_ comparator = (*ByColumn)(nil)
+	_ comparator = (*ByCoverage)(nil)
+	_ comparator = (*ByLinter)(nil)
+	_ comparator = (*BySeverity)(nil)
 )
 
 type ByName struct{ next comparator }
@@ -148,6 +185,91 @@ func (cmp ByColumn) Compare(a, b *result.Issue) compareResult {
 	return res
 }
 
+type ByCoverage struct{ next comparator }
+
+//nolint:golint
+func (cmp ByCoverage) Next() comparator { return cmp.next }
+
+//nolint:golint
+func (cmp ByCoverage) Compare(a, b *result.Issue) compareResult {
+	var res compareResult
+
+	if res = numericCompare(coverageRank(a), coverageRank(b)); !res.isNeutral() {
+		return res
+	}
+
+	if next := cmp.Next(); next != nil {
+		return next.Compare(a, b)
+	}
+
+	return res
+}
+
+type ByLinter struct{ next comparator }
+
+//nolint:golint
+func (cmp ByLinter) Next() comparator { return cmp.next }
+
+//nolint:golint
+func (cmp ByLinter) Compare(a, b *result.Issue) compareResult {
+	var res compareResult
+
+	if res = compareResult(strings.Compare(a.FromLinter, b.FromLinter)); !res.isNeutral() {
+		return res
+	}
+
+	if next := cmp.Next(); next != nil {
+		return next.Compare(a, b)
+	}
+
+	return res
+}
+
+// BySeverity orders the most severe issues first, then the issues with an unknown severity.
+type BySeverity struct{ next comparator }
+
+//nolint:golint
+func (cmp BySeverity) Next() comparator { return cmp.next }
+
+//nolint:golint
+func (cmp BySeverity) Compare(a, b *result.Issue) compareResult {
+	var res compareResult
+
+	if res = numericCompare(severityRank(a), severityRank(b)); !res.isNeutral() {
+		return res
+	}
+
+	if next := cmp.Next(); next != nil {
+		return next.Compare(a, b)
+	}
+
+	return res
+}
+
+// severityRank is 1 for the most severe issues: the ranks must be positive to be compared.
+func severityRank(issue *result.Issue) int {
+	const unknownRank = 100
+
+	level, ok := config.SeverityLevel(issue.Severity)
+	if !ok {
+		return unknownRank
+	}
+
+	return unknownRank - 1 - level
+}
+
+// coverageRank orders the uncovered issues, then the issues without coverage, then the covered issues.
+func coverageRank(issue *result.Issue) int {
+	switch {
+	case issue.Covered == nil:
+		return 2
+	case *issue.Covered:
+		return 3
+	default:
+		return 1
+	}
+}
+
 func numericCompare(a, b int) compareResult {
 	var (
 		isValuesInvalid  = a < 0 || b < 0
@@ -180,3 +180,74 @@ func TestSorting(t *testing.T) {
 	assert.Equal(t, results, expected)
 	assert.Nil(t, err, nil)
 }
+
+func TestSorting_uncoveredFirst(t *testing.T) {
+	covered, uncovered := true, false
+
+	var tests = make([]result.Issue, len(issues))
+	copy(tests, issues)
+	tests[0].Covered = &covered
+	tests[2].Covered = &uncovered
+
+	var expected = []result.Issue{tests[2], tests[3], tests[1], tests[0]}
+
+	var cfg = config.Config{}
+	cfg.Output.SortResults = true
+	cfg.Issues.Coverage.UncoveredFirst = true
+	var sr = NewSortResults(&cfg)
+
+	results, err := sr.Process(tests)
+	assert.Nil(t, err, nil)
+	assert.Equal(t, expected, results)
+
+	// without sort-results, only the coverage changes the order
+	copy(tests, issues)
+	tests[0].Covered = &covered
+	tests[2].Covered = &uncovered
+
+	expected = []result.Issue{tests[2], tests[1], tests[3], tests[0]}
+
+	cfg.Output.SortResults = false
+	sr = NewSortResults(&cfg)
+
+	results, err = sr.Process(tests)
+	assert.Nil(t, err, nil)
+	assert.Equal(t, expected, results)
+}
+
+func TestSorting_sortOrder(t *testing.T) {
+	tests := []result.Issue{
+		{FromLinter: "b", Severity: "info", Pos: token.Position{Filename: "file_a.go", Line: 2}},
+		{FromLinter: "a", Severity: "warning", Pos: token.Position{Filename: "file_b.go", Line: 1}},
+		{FromLinter: "b", Severity: "error", Pos: token.Position{Filename: "file_a.go", Line: 1}},
+		{FromLinter: "a", Pos: token.Position{Filename: "file_a.go", Line: 3}},
+	}
+
+	testCases := []struct {
+		order    []string
+		expected []int
+	}{
+		{order: nil, expected: []int{2, 0, 3, 1}},
+		{order: []string{config.SortOrderLinter}, expected: []int{3, 1, 2, 0}},
+		{order: []string{config.SortOrderSeverity}, expected: []int{2, 1, 0, 3}},
+		{order: []string{config.SortOrderLine, config.SortOrderFile}, expected: []int{2, 1, 0, 3}},
+	}
+
+	for _, test := range testCases {
+		var cfg = config.Config{}
+		cfg.Output.SortResults = true
+		cfg.Output.SortOrder = test.order
+
+		issues := make([]result.Issue, len(tests))
+		copy(issues, tests)
+
+		results, err := NewSortResults(&cfg).Process(issues)
+		assert.NoError(t, err)
+
+		var expected []result.Issue
+		for _, i := range test.expected {
+			expected = append(expected, tests[i])
+		}
+		assert.Equal(t, expected, results, "sort-order %v", test.order)
+	}
+}
@@ -7,16 +7,20 @@ import (
 )
 
 type SourceCode struct {
-	lineCache *fsutils.LineCache
-	log       logutils.Log
+	lineCache    *fsutils.LineCache
+	contextLines int
+	log          logutils.Log
 }
 
 var _ Processor = SourceCode{}
 
-func NewSourceCode(lc *fsutils.LineCache, log logutils.Log) *SourceCode {
+// NewSourceCode creates a processor adding the source lines of the issues,
+// and their contextLines lines before and after if contextLines isn't 0.
+func NewSourceCode(lc *fsutils.LineCache, contextLines int, log logutils.Log) *SourceCode {
 	return &SourceCode{
-		lineCache: lc,
-		log:       log,
+		lineCache:    lc,
+		contextLines: contextLines,
+		log:          log,
 	}
 }
 
@@ -40,8 +44,33 @@ func (p SourceCode) Process(issues []result.Issue) ([]result.Issue, error) {
 			newI.SourceLines = append(newI.SourceLines, line)
 		}
 
+		if p.contextLines > 0 {
+			newI.SourceContext = p.sourceContext(i.FilePath(), lineRange)
+		}
+
 		return &newI
 	}), nil
 }
 
 func (p SourceCode) Finish() {}
+
+// sourceContext returns the lines around the line range, until the end of the file.
+func (p SourceCode) sourceContext(filePath string, lineRange result.Range) *result.SourceContext {
+	from := lineRange.From - p.contextLines
+	if from < 1 {
+		from = 1
+	}
+
+	sc := &result.SourceContext{From: from}
+	for lineNumber := from; lineNumber <= lineRange.To+p.contextLines; lineNumber++ {
+		line, err := p.lineCache.GetLine(filePath, lineNumber)
+		if err != nil {
+			break // the end of the file
+		}
+
+		sc.Lines = append(sc.Lines, line)
+		sc.To = lineNumber
+	}
+
+	return sc
+}
@@ -0,0 +1,44 @@
+package processors
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestSourceCode_context(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "a.go")
+	err := os.WriteFile(fileName, []byte("package a\n\nfunc f() {\n\tf()\n}"), 0o600)
+	require.NoError(t, err)
+
+	lineCache := fsutils.NewLineCache(fsutils.NewFileCache())
+
+	issues := []result.Issue{
+		{FromLinter: "a", Pos: token.Position{Filename: fileName, Line: 4}},
+		{FromLinter: "b", Pos: token.Position{Filename: fileName, Line: 1}},
+	}
+
+	processed, err := NewSourceCode(lineCache, 2, logutils.NewStderrLog("")).Process(issues)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"\tf()"}, processed[0].SourceLines)
+	assert.Equal(t, &result.SourceContext{From: 2, To: 5, Lines: []string{"", "func f() {", "\tf()", "}"}},
+		processed[0].SourceContext)
+	assert.Equal(t, &result.SourceContext{From: 1, To: 3, Lines: []string{"package a", "", "func f() {"}},
+		processed[1].SourceContext)
+
+	// without context lines
+	processed, err = NewSourceCode(lineCache, 0, logutils.NewStderrLog("")).Process(issues)
+	require.NoError(t, err)
+
+	assert.Nil(t, processed[0].SourceContext)
+	assert.Equal(t, []string{"\tf()"}, processed[0].SourceLines)
+}
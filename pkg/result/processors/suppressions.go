@@ -0,0 +1,93 @@
+package processors
+
+import (
+	"context"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/suppressions"
+)
+
+var _ Processor = &Suppressions{}
+
+// Suppressions hides the issues with the fingerprints of a store of suppressions, managed by `golangci-lint suppress`.
+type Suppressions struct {
+	lineCache *fsutils.LineCache
+	log       logutils.Log
+	// shortener shortens the texts as in the reports of `suppress add`: the processor runs before PathShortener.
+	shortener *PathShortener
+
+	store      string
+	byPrint    map[string]suppressions.Suppression
+	suppressed int
+}
+
+func NewSuppressions(lineCache *fsutils.LineCache, log logutils.Log) *Suppressions {
+	return &Suppressions{
+		lineCache: lineCache,
+		log:       log,
+		shortener: NewPathShortener(),
+	}
+}
+
+// Load reads the suppressions of the store: the issues aren't suppressed before.
+func (p *Suppressions) Load(ctx context.Context, store suppressions.Store) error {
+	list, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.store = store.String()
+	p.byPrint = make(map[string]suppressions.Suppression, len(list))
+	for _, s := range list {
+		p.byPrint[s.Fingerprint] = s
+	}
+
+	p.log.Infof("Loaded %d suppressions from %s", len(list), p.store)
+	return nil
+}
+
+func (p Suppressions) Name() string {
+	return "suppressions"
+}
+
+func (p *Suppressions) Process(issues []result.Issue) ([]result.Issue, error) {
+	if len(p.byPrint) == 0 {
+		return issues, nil
+	}
+
+	return filterIssues(issues, func(i *result.Issue) bool {
+		line, err := p.lineCache.GetLine(i.FilePath(), i.Line())
+		if err != nil {
+			p.log.Infof("Failed to get line %d for file %s: %s", i.Line(), i.FilePath(), err)
+		}
+
+		reported := *i
+		reported.Text = p.shortener.shorten(i.Text)
+
+		s, ok := p.byPrint[reported.StableHash(line)]
+		if !ok {
+			return true
+		}
+
+		p.suppressed++
+
+		by := ""
+		if s.Author != "" {
+			by = " by " + s.Author
+		}
+		logutils.InfoEvent(p.log, "issue_suppressed",
+			logutils.Fields{"fingerprint": s.Fingerprint, "linter": i.FromLinter, "path": i.FilePath(), "line": i.Line(),
+				"reason": s.Reason, "author": s.Author},
+			"%s:%d: %s (%s): suppressed(remote)%s: %s", i.FilePath(), i.Line(), i.Text, i.FromLinter, by, s.Reason)
+
+		return false
+	}), nil
+}
+
+func (p Suppressions) Finish() {
+	if p.suppressed != 0 {
+		p.log.Infof("%d issues were suppressed by the suppressions of %s", p.suppressed, p.store)
+	}
+}
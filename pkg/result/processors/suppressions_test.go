@@ -0,0 +1,64 @@
+package processors
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/suppressions"
+)
+
+func TestSuppressions(t *testing.T) {
+	lineCache := fsutils.NewLineCache(fsutils.NewFileCache())
+	log := logutils.NewStderrLog("")
+	file := filepath.Join("testdata", "exclude_rules.go")
+
+	suppressed := newIssueFromIssueTestCase(issueTestCase{Path: file, Line: 3, Linter: "lll", Text: "line too long"})
+	other := newIssueFromIssueTestCase(issueTestCase{Path: file, Line: 5, Linter: "lll", Text: "line too long"})
+
+	p := NewSuppressions(lineCache, log)
+	processAssertSame(t, p, suppressed, other)
+
+	line, err := lineCache.GetLine(file, 3)
+	require.NoError(t, err)
+
+	store := &suppressions.FileStore{Path: filepath.Join(t.TempDir(), "suppressions.json")}
+	require.NoError(t, store.Add(context.Background(), []suppressions.Suppression{
+		suppressions.New(&suppressed, line, "generated", "alice"),
+	}))
+	require.NoError(t, p.Load(context.Background(), store))
+
+	assert.Equal(t, []result.Issue{other}, process(t, p, suppressed, other))
+}
+
+func TestSuppressions_shortenedText(t *testing.T) {
+	lineCache := fsutils.NewLineCache(fsutils.NewFileCache())
+	file := filepath.Join("testdata", "exclude_rules.go")
+
+	wd, err := fsutils.Getwd()
+	require.NoError(t, err)
+
+	// The text of the issue of the report is shortened.
+	reported := newIssueFromIssueTestCase(issueTestCase{Path: file, Line: 3, Linter: "gosec", Text: "G304: reading a.go"})
+	issue := newIssueFromIssueTestCase(issueTestCase{Path: file, Line: 3, Linter: "gosec", Text: "G304: reading " + wd + "/a.go"})
+
+	line, err := lineCache.GetLine(file, 3)
+	require.NoError(t, err)
+
+	store := &suppressions.FileStore{Path: filepath.Join(t.TempDir(), "suppressions.json")}
+	require.NoError(t, store.Add(context.Background(), []suppressions.Suppression{
+		suppressions.New(&reported, line, "generated", "alice"),
+	}))
+
+	p := NewSuppressions(lineCache, logutils.NewStderrLog(""))
+	require.NoError(t, p.Load(context.Background(), store))
+
+	assert.Empty(t, process(t, p, issue))
+	assert.Equal(t, "G304: reading "+wd+"/a.go", issue.Text, "the text of the issue isn't changed")
+}
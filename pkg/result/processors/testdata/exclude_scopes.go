@@ -0,0 +1,43 @@
+package testdata
+
+type suite struct{}
+
+// TestCreate is a test of the suite.
+func (s *suite) TestCreate() {
+	_ = 1
+}
+
+func (s suite) TestDelete() {
+	_ = 1
+}
+
+func (s *suite) SetupTest() {
+	_ = 1
+}
+
+func TestHelper() {
+	_ = 1
+}
+
+var _ = Describe("thing", func() {
+	_ = 1
+})
+
+var (
+	a = 1
+
+	b = Describe("other", func() {
+		_ = 1
+	})
+)
+
+func Describe(_ string, body func()) bool {
+	body()
+	return true
+}
+
+func run() {
+	Describe("inner", func() {
+		_ = 1
+	})
+}
@@ -0,0 +1,7 @@
+package testdata
+
+var nolintExpired int //nolint:varcheck // reason="legacy API" expires=2020-01-01
+
+var nolintNotExpired int //nolint:varcheck // reason="legacy API" expires=2100-01-01
+
+var nolintInvalidExpiry int //nolint:varcheck // expires=soon
@@ -0,0 +1,7 @@
+package testdata
+
+var nolintWithoutReason int //nolint:varcheck
+
+var nolintWithReason int //nolint:varcheck // kept for the plugins
+
+var nolintAllWithoutReason int //nolint
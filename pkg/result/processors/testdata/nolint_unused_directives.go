@@ -0,0 +1,18 @@
+package testdata
+
+import "os"
+
+func usedDirective() {
+	os.Remove("a") //nolint:errcheck
+}
+
+func unusedDirective() {
+	_ = os.Remove("b") //nolint:errcheck // the error is checked now
+}
+
+//nolint:errcheck
+func unusedDirectiveOnItsLine() {}
+
+func disabledLinterDirective() {
+	_ = os.Remove("c") //nolint:gosec
+}
@@ -0,0 +1,183 @@
+package processors
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// UnusedNolintName is the linter name of the issues of the unused //nolint directives.
+const UnusedNolintName = "unused-nolint"
+
+// UnusedNolint reports the //nolint directives which didn't suppress any issue during the run,
+// with a fix deleting them.
+// It must be after the nolint processor: it uses the directives matched by it.
+type UnusedNolint struct {
+	enabled   bool
+	nolint    *Nolint
+	files     []string
+	filters   []Processor
+	lineCache *fsutils.LineCache
+	log       logutils.Log
+
+	now func() time.Time
+}
+
+var _ Processor = &UnusedNolint{}
+
+// NewUnusedNolint creates the processor for the directives of the files of the analyzed packages.
+// The reported directives are filtered by the filters like the issues of the linters: skipped files, generated files...
+func NewUnusedNolint(enabled bool, nolint *Nolint, files []string, filters []Processor,
+	lineCache *fsutils.LineCache, log logutils.Log) *UnusedNolint {
+	return &UnusedNolint{
+		enabled:   enabled,
+		nolint:    nolint,
+		files:     sortedFiles(files),
+		filters:   filters,
+		lineCache: lineCache,
+		log:       log,
+		now:       time.Now,
+	}
+}
+
+func (p UnusedNolint) Name() string {
+	return "unused_nolint"
+}
+
+func (p *UnusedNolint) Process(issues []result.Issue) ([]result.Issue, error) {
+	if !p.enabled {
+		return issues, nil
+	}
+
+	var unused []result.Issue
+	for _, file := range p.files {
+		fileIssues, err := p.fileUnusedDirectives(file)
+		if err != nil {
+			p.log.Warnf("Can't check the //nolint directives of %s: %s", file, err)
+			continue
+		}
+		unused = append(unused, fileIssues...)
+	}
+
+	for _, f := range p.filters {
+		var err error
+		unused, err = f.Process(unused)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name(), err)
+		}
+	}
+
+	return append(issues, unused...), nil
+}
+
+func (p *UnusedNolint) fileUnusedDirectives(file string) ([]result.Issue, error) {
+	if filepath.IsAbs(file) {
+		if rel, err := fsutils.ShortestRelPath(file, ""); err == nil {
+			file = rel // same path as the issues matched by the nolint processor
+		}
+	}
+
+	fd, err := p.nolint.getOrCreateFileData(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []result.Issue
+	for i := range fd.ignoredRanges {
+		ir := &fd.ignoredRanges[i]
+		if ir.originalRange != nil {
+			continue // expanded range: the inline range shares its matches
+		}
+
+		if !p.isUnused(ir) {
+			continue
+		}
+
+		issues = append(issues, result.Issue{
+			FromLinter:  UnusedNolintName,
+			Text:        fmt.Sprintf("directive `%s` is unused", strings.TrimSpace(ir.comment.text)),
+			Pos:         ir.comment.start,
+			Replacement: p.deleteComment(file, ir.comment),
+		})
+	}
+
+	return issues, nil
+}
+
+// isUnused checks if the directive didn't suppress any issue,
+// while all its linters were enabled: a disabled linter could need it.
+func (p *UnusedNolint) isUnused(ir *ignoredRange) bool {
+	if len(ir.matchedIssueFromLinter) != 0 {
+		return false
+	}
+
+	if ir.directive != nil && ir.directive.IsExpired(p.now()) {
+		return false // already reported as expired
+	}
+
+	for _, name := range ir.linters {
+		if p.nolint.enabledLinters[name] == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// deleteComment returns the fix deleting the comment: the whole line if the comment is alone on its line.
+func (p *UnusedNolint) deleteComment(file string, c nolintComment) *result.Replacement {
+	if c.start.Line != c.end.Line {
+		return nil
+	}
+
+	line, err := p.lineCache.GetLine(file, c.start.Line)
+	if err != nil {
+		return nil
+	}
+
+	// columns are 1-based bytes offsets
+	startCol, endCol := c.start.Column-1, c.end.Column-1
+	if startCol < 0 || endCol > len(line) || startCol >= endCol {
+		return nil
+	}
+
+	before := strings.TrimRight(line[:startCol], " \t")
+	if before == "" && strings.TrimSpace(line[endCol:]) == "" {
+		return &result.Replacement{NeedOnlyDelete: true}
+	}
+
+	return &result.Replacement{
+		Inline: &result.InlineFix{
+			StartCol:  len(before),
+			Length:    endCol - len(before),
+			NewString: "",
+		},
+	}
+}
+
+func (p UnusedNolint) Finish() {}
+
+// ProcessesRunIssues marks the processor: the unused directives are known once all the issues are processed by nolint.
+func (p UnusedNolint) ProcessesRunIssues() {}
+
+// sortedFiles returns the unique files.
+func sortedFiles(files []string) []string {
+	set := map[string]bool{}
+	for _, f := range files {
+		set[f] = true
+	}
+
+	ret := make([]string, 0, len(set))
+	for f := range set {
+		ret = append(ret, f)
+	}
+	sort.Strings(ret)
+
+	return ret
+}
@@ -0,0 +1,67 @@
+package processors
+
+import (
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestUnusedNolint(t *testing.T) {
+	fileName := filepath.Join("testdata", "nolint_unused_directives.go")
+
+	enabledSetLog := logutils.NewMockLog()
+	enabledSetLog.On("Infof", "Active %d linters: %s", 1, []string{"errcheck"})
+	cfg := &config.Config{Linters: config.Linters{DisableAll: true, Enable: []string{"errcheck"}}}
+	dbManager := lintersdb.NewManager(cfg, nil)
+	enabledLinters, err := lintersdb.NewEnabledSet(dbManager, lintersdb.NewValidator(dbManager), enabledSetLog, cfg).
+		GetEnabledLintersMap()
+	require.NoError(t, err)
+
+	nolint := NewNolint(getMockLog(), dbManager, enabledLinters)
+	lineCache := fsutils.NewLineCache(fsutils.NewFileCache())
+	p := NewUnusedNolint(true, nolint, []string{fileName, fileName}, nil, lineCache, getMockLog())
+
+	issue := result.Issue{FromLinter: "errcheck", Pos: token.Position{Filename: fileName, Line: 6}}
+
+	issues, err := nolint.Process([]result.Issue{issue})
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+
+	issues, err = p.Process(issues)
+	require.NoError(t, err)
+
+	expected := []result.Issue{
+		{
+			FromLinter: UnusedNolintName,
+			Text:       "directive `//nolint:errcheck // the error is checked now` is unused",
+			Pos:        token.Position{Filename: fileName, Offset: 136, Line: 10, Column: 21},
+			Replacement: &result.Replacement{
+				Inline: &result.InlineFix{StartCol: 19, Length: 46},
+			},
+		},
+		{
+			FromLinter:  UnusedNolintName,
+			Text:        "directive `//nolint:errcheck` is unused",
+			Pos:         token.Position{Filename: fileName, Offset: 185, Line: 13, Column: 1},
+			Replacement: &result.Replacement{NeedOnlyDelete: true},
+		},
+	}
+	assert.Equal(t, expected, issues)
+}
+
+func TestUnusedNolint_disabled(t *testing.T) {
+	fileName := filepath.Join("testdata", "nolint_unused_directives.go")
+
+	p := NewUnusedNolint(false, newTestNolintProcessor(nil), []string{fileName}, nil, nil, nil)
+
+	processAssertEmpty(t, p)
+}
@@ -0,0 +1,42 @@
+package processors
+
+import (
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// warnOnlySeverity is the severity of the issues of the warn-only linters.
+const warnOnlySeverity = "warning"
+
+// WarnOnly reports the issues of the linters of linters.warn-only as warnings not failing the run.
+type WarnOnly struct {
+	linters map[string]bool
+}
+
+var _ Processor = (*WarnOnly)(nil)
+
+// NewWarnOnly creates the processor of the warn-only linters, by canonical name.
+func NewWarnOnly(linters map[string]bool) *WarnOnly {
+	return &WarnOnly{linters: linters}
+}
+
+func (p WarnOnly) Name() string {
+	return "warn_only"
+}
+
+func (p WarnOnly) Process(issues []result.Issue) ([]result.Issue, error) {
+	if len(p.linters) == 0 {
+		return issues, nil
+	}
+
+	return transformIssues(issues, func(i *result.Issue) *result.Issue {
+		if !p.linters[i.FromLinter] {
+			return i
+		}
+
+		i.Severity = warnOnlySeverity
+		i.WarnOnly = true
+		return i
+	}), nil
+}
+
+func (WarnOnly) Finish() {}
@@ -0,0 +1,31 @@
+package processors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestWarnOnly(t *testing.T) {
+	p := NewWarnOnly(map[string]bool{"gocritic": true})
+
+	issues := []result.Issue{
+		{FromLinter: "gocritic", Severity: "error", Text: "a"},
+		{FromLinter: "govet", Severity: "error", Text: "b"},
+		{FromLinter: "gocritic", Text: "c"},
+	}
+
+	expected := []result.Issue{
+		{FromLinter: "gocritic", Severity: "warning", Text: "a", WarnOnly: true},
+		{FromLinter: "govet", Severity: "error", Text: "b"},
+		{FromLinter: "gocritic", Severity: "warning", Text: "c", WarnOnly: true},
+	}
+
+	assert.Equal(t, expected, process(t, p, issues...))
+}
+
+func TestWarnOnly_noLinters(t *testing.T) {
+	processAssertSame(t, NewWarnOnly(map[string]bool{}), result.Issue{FromLinter: "gocritic", Severity: "error"})
+}
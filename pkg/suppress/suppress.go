@@ -0,0 +1,289 @@
+// Package suppress inserts the //nolint directives of the issues in their files, e.g. to enable a linter
+// without fixing the existing issues first.
+package suppress
+
+import (
+	"bytes"
+	"go/format"
+	"go/scanner"
+	"go/token"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
+	"github.com/golangci/golangci-lint/pkg/sliceutil"
+)
+
+// The placeholders of the reason template.
+const (
+	// LinterPlaceholder is replaced by the linters of the directive.
+	LinterPlaceholder = "{linter}"
+	// DatePlaceholder is replaced by the day of the suppression.
+	DatePlaceholder = "{date}"
+)
+
+const typecheckLinterName = "typecheck"
+
+// Summary is the result of a suppression.
+type Summary struct {
+	Suppressed int
+	// Files is the count of the changed files.
+	Files int
+	// Remaining are the issues which can't be suppressed, in the order of the issues.
+	Remaining []result.Issue
+}
+
+// Suppressor inserts a directive at the end of the line of each issue: the issues of a line share a directive,
+// and the linters are added to the existing directive of the line.
+//
+// An issue is not suppressed if its line changed since the analysis, if it's a comment line,
+// or if the line ends inside a multi-line string or comment.
+type Suppressor struct {
+	reason string
+	now    func() time.Time
+
+	readFile  func(path string) ([]byte, error)
+	writeFile func(path string, data []byte) error
+}
+
+// New returns a suppressor explaining the directives by the reason template.
+func New(reason string) *Suppressor {
+	return &Suppressor{
+		reason: reason,
+		now:    time.Now,
+
+		readFile: os.ReadFile,
+		writeFile: func(path string, data []byte) error {
+			return os.WriteFile(path, data, 0o644) //nolint:gosec // the file exists, its mode is kept
+		},
+	}
+}
+
+// Run suppresses the issues.
+func (s *Suppressor) Run(issues []result.Issue) (*Summary, error) {
+	var paths []string
+	byFile := map[string][]*result.Issue{}
+	for i := range issues {
+		issue := &issues[i]
+		// The type errors can't be suppressed.
+		if issue.FromLinter == typecheckLinterName || issue.Line() <= 0 {
+			continue
+		}
+
+		path := issue.FilePath()
+		if byFile[path] == nil {
+			paths = append(paths, path)
+		}
+		byFile[path] = append(byFile[path], issue)
+	}
+
+	sum := &Summary{}
+	suppressed := map[*result.Issue]bool{}
+	for _, path := range paths {
+		n, err := s.suppressFile(path, byFile[path], suppressed)
+		if err != nil {
+			return nil, err
+		}
+		if n != 0 {
+			sum.Files++
+			sum.Suppressed += n
+		}
+	}
+
+	for i := range issues {
+		if !suppressed[&issues[i]] {
+			sum.Remaining = append(sum.Remaining, issues[i])
+		}
+	}
+
+	return sum, nil
+}
+
+// lineDirective is the directive inserted at a line.
+type lineDirective struct {
+	line    int
+	linters []string
+	issues  []*result.Issue
+}
+
+func (d *lineDirective) addLinter(name string) {
+	for _, l := range d.linters {
+		if l == name {
+			return
+		}
+	}
+	d.linters = append(d.linters, name)
+}
+
+// suppressFile inserts the directives of the issues of a file, it returns the count of the suppressed issues.
+func (s *Suppressor) suppressFile(path string, issues []*result.Issue, suppressed map[*result.Issue]bool) (int, error) {
+	src, err := s.readFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	tokens, ok := scanLines(src)
+	if !ok {
+		return 0, nil
+	}
+
+	lines := strings.Split(string(src), "\n")
+
+	var directives []*lineDirective
+	byLine := map[int]*lineDirective{}
+	for _, issue := range issues {
+		line := issue.Line()
+		if line > len(lines) || tokens.multiline[line] {
+			continue
+		}
+		if len(issue.SourceLines) != 0 && issue.SourceLines[0] != strings.TrimSuffix(lines[line-1], "\r") {
+			continue
+		}
+
+		d := byLine[line]
+		if d == nil {
+			d = &lineDirective{line: line}
+			byLine[line] = d
+			directives = append(directives, d)
+		}
+		d.addLinter(issue.FromLinter)
+		d.issues = append(d.issues, issue)
+	}
+
+	count := 0
+	for _, d := range directives {
+		comment, hasComment := tokens.comments[d.line]
+		changed, ok := s.insert(lines[d.line-1], comment, hasComment, d.linters)
+		if !ok {
+			continue
+		}
+
+		lines[d.line-1] = changed
+		for _, issue := range d.issues {
+			suppressed[issue] = true
+		}
+		count += len(d.issues)
+	}
+
+	if count == 0 {
+		return 0, nil
+	}
+
+	changed := []byte(strings.Join(lines, "\n"))
+
+	// The comments are aligned like gofmt does, if the file was formatted.
+	if formatted, err := format.Source(src); err == nil && bytes.Equal(formatted, src) {
+		if formatted, err = format.Source(changed); err == nil {
+			changed = formatted
+		}
+	}
+
+	if err := s.writeFile(path, changed); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// insert inserts the directive of the linters in the line, the trailing comment of the line starts at the column.
+func (s *Suppressor) insert(line string, column int, hasComment bool, linters []string) (string, bool) {
+	eol := ""
+	if strings.HasSuffix(line, "\r") {
+		line, eol = strings.TrimSuffix(line, "\r"), "\r"
+	}
+
+	if !hasComment {
+		return strings.TrimRight(line, " \t") + " " + s.directive(linters) + eol, true
+	}
+
+	code, comment := strings.TrimRight(line[:column], " \t"), line[column:]
+	if code == "" {
+		// A comment line: a directive would apply to the next statement.
+		return "", false
+	}
+
+	existing := processors.ParseNolintDirective(comment)
+	if existing == nil {
+		return code + " " + s.directive(linters) + " " + comment + eol, true
+	}
+
+	// The issues of a directive for all the linters, or of an expired directive, are reported anyway.
+	if len(existing.Linters) == 0 || existing.IsExpired(s.now()) {
+		return "", false
+	}
+
+	all := existing.Linters
+	for _, name := range linters {
+		if !sliceutil.Contains(all, strings.ToLower(name)) {
+			all = append(all, name)
+		}
+	}
+	if len(all) == len(existing.Linters) {
+		return "", false
+	}
+
+	directive := "//nolint:" + strings.Join(all, ",")
+	if _, explanation, ok := strings.Cut(strings.TrimLeft(comment, "/ "), "//"); ok {
+		directive += " //" + explanation
+	}
+
+	return code + " " + directive + eol, true
+}
+
+func (s *Suppressor) directive(linters []string) string {
+	directive := "//nolint:" + strings.Join(linters, ",")
+	if s.reason == "" {
+		return directive
+	}
+
+	reason := strings.NewReplacer(
+		LinterPlaceholder, strings.Join(linters, ", "),
+		DatePlaceholder, s.now().Format("2006-01-02"),
+	).Replace(s.reason)
+
+	return directive + " // " + reason
+}
+
+// lineTokens are the tokens of the lines relevant to the insertion of the directives.
+type lineTokens struct {
+	// comments are the columns of the trailing // comments of the lines.
+	comments map[int]int
+	// multiline are the lines ending inside a string or a comment.
+	multiline map[int]bool
+}
+
+// scanLines scans the tokens of the source, it returns false if the source can't be scanned.
+func scanLines(src []byte) (lineTokens, bool) {
+	ret := lineTokens{comments: map[int]int{}, multiline: map[int]bool{}}
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(src))
+
+	failed := false
+	var sc scanner.Scanner
+	sc.Init(file, src, func(token.Position, string) { failed = true }, scanner.ScanComments)
+
+	for {
+		pos, tok, lit := sc.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok != token.COMMENT && tok != token.STRING {
+			continue
+		}
+
+		start := file.Position(pos)
+		if tok == token.COMMENT && strings.HasPrefix(lit, "//") {
+			ret.comments[start.Line] = start.Column - 1
+			continue
+		}
+
+		for line := start.Line; line < start.Line+strings.Count(lit, "\n"); line++ {
+			ret.multiline[line] = true
+		}
+	}
+
+	return ret, !failed
+}
@@ -0,0 +1,183 @@
+package suppress
+
+import (
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const source = `package a
+
+func a() {
+	x := 1
+	y := 2 // the second value
+	_ = x == true
+	z := 3 //nolint:ineffassign // legacy
+
+	s := ` + "`" + `a
+b` + "`" + `
+	_, _, _ = y, z, s
+}
+`
+
+func newTestSuppressor(t *testing.T, reason, src string) (*Suppressor, map[string]string) {
+	t.Helper()
+
+	files := map[string]string{"a.go": src}
+
+	s := New(reason)
+	s.now = func() time.Time { return time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC) }
+	s.readFile = func(path string) ([]byte, error) {
+		data, ok := files[path]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return []byte(data), nil
+	}
+	s.writeFile = func(path string, data []byte) error {
+		files[path] = string(data)
+		return nil
+	}
+
+	return s, files
+}
+
+func newIssue(linter string, line int) result.Issue {
+	return result.Issue{
+		FromLinter:  linter,
+		Text:        "issue",
+		Pos:         token.Position{Filename: "a.go", Line: line},
+		SourceLines: []string{strings.Split(source, "\n")[line-1]},
+	}
+}
+
+func TestSuppressor_Run(t *testing.T) {
+	s, files := newTestSuppressor(t, "legacy, tracked in JIRA-123", source)
+
+	issues := []result.Issue{
+		newIssue("govet", 4),
+		newIssue("gosimple", 6),
+		newIssue("staticcheck", 6),
+		newIssue("gocritic", 5),
+		newIssue("wastedassign", 7),
+		newIssue("lll", 9), // inside the raw string
+		newIssue("typecheck", 10),
+	}
+
+	sum, err := s.Run(issues)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, sum.Suppressed)
+	assert.Equal(t, 1, sum.Files)
+	assert.Equal(t, []result.Issue{issues[5], issues[6]}, sum.Remaining)
+
+	expected := `package a
+
+func a() {
+	x := 1        //nolint:govet // legacy, tracked in JIRA-123
+	y := 2        //nolint:gocritic // legacy, tracked in JIRA-123 // the second value
+	_ = x == true //nolint:gosimple,staticcheck // legacy, tracked in JIRA-123
+	z := 3        //nolint:ineffassign,wastedassign // legacy
+
+	s := ` + "`" + `a
+b` + "`" + `
+	_, _, _ = y, z, s
+}
+`
+	assert.Equal(t, expected, files["a.go"])
+}
+
+func TestSuppressor_Run_changedLine(t *testing.T) {
+	s, files := newTestSuppressor(t, "", source)
+
+	issue := newIssue("govet", 4)
+	issue.SourceLines = []string{"\tx := 0"}
+
+	sum, err := s.Run([]result.Issue{issue})
+	require.NoError(t, err)
+
+	assert.Zero(t, sum.Suppressed)
+	assert.Len(t, sum.Remaining, 1)
+	assert.Equal(t, source, files["a.go"])
+}
+
+func TestSuppressor_Run_notFormatted(t *testing.T) {
+	src := "package a\nvar  x = 1\nvar y = 2 // nolint:unused\n"
+	s, files := newTestSuppressor(t, "", src)
+
+	sum, err := s.Run([]result.Issue{
+		{FromLinter: "gofmt", Pos: token.Position{Filename: "a.go", Line: 2}},
+		{FromLinter: "deadcode", Pos: token.Position{Filename: "a.go", Line: 3}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, sum.Suppressed)
+	assert.Equal(t, "package a\nvar  x = 1 //nolint:gofmt\nvar y = 2 //nolint:unused,deadcode\n", files["a.go"])
+}
+
+func TestSuppressor_insert(t *testing.T) {
+	s, _ := newTestSuppressor(t, "suppressed on {date}: {linter}", source)
+
+	testCases := []struct {
+		desc     string
+		line     string
+		column   int
+		comment  bool
+		expected string
+	}{
+		{
+			desc:     "no comment",
+			line:     "\tx := 1  ",
+			expected: "\tx := 1 //nolint:errcheck,gosec // suppressed on 2026-03-01: errcheck, gosec",
+		},
+		{
+			desc:     "crlf",
+			line:     "\tx := 1\r",
+			expected: "\tx := 1 //nolint:errcheck,gosec // suppressed on 2026-03-01: errcheck, gosec\r",
+		},
+		{
+			desc:     "directive for the linter",
+			line:     "\tx := 1 //nolint:gosec,errcheck",
+			column:   8,
+			comment:  true,
+			expected: "",
+		},
+		{
+			desc:     "directive for all the linters",
+			line:     "\tx := 1 //nolint",
+			column:   8,
+			comment:  true,
+			expected: "",
+		},
+		{
+			desc:     "expired directive",
+			line:     "\tx := 1 //nolint:lll // expires=2026-01-01",
+			column:   8,
+			comment:  true,
+			expected: "",
+		},
+		{
+			desc:     "comment line",
+			line:     "\t// x is 1",
+			column:   1,
+			comment:  true,
+			expected: "",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			changed, ok := s.insert(test.line, test.column, test.comment, []string{"errcheck", "gosec"})
+			assert.Equal(t, test.expected != "", ok)
+			assert.Equal(t, test.expected, changed)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package suppressions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const fileMode = 0o644
+
+// document is the content of a suppressions file, and the body of the requests of the HTTP service.
+type document struct {
+	Suppressions []Suppression `json:"suppressions"`
+}
+
+// FileStore stores the suppressions in a JSON file, e.g. committed in a repository owned by the security team.
+type FileStore struct {
+	Path string
+}
+
+var _ Store = &FileStore{}
+
+func (s *FileStore) String() string {
+	return s.Path
+}
+
+// List returns the suppressions of the file, none if the file doesn't exist.
+func (s *FileStore) List(_ context.Context) ([]Suppression, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("can't parse %s: %w", s.Path, err)
+	}
+
+	return doc.Suppressions, nil
+}
+
+func (s *FileStore) Add(ctx context.Context, suppressions []Suppression) error {
+	current, err := s.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	added := map[string]bool{}
+	for _, sup := range suppressions {
+		added[sup.Fingerprint] = true
+	}
+
+	kept := append([]Suppression{}, suppressions...)
+	for _, sup := range current {
+		if !added[sup.Fingerprint] {
+			kept = append(kept, sup)
+		}
+	}
+
+	return s.write(kept)
+}
+
+func (s *FileStore) Remove(ctx context.Context, fingerprints []string) ([]string, error) {
+	current, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := map[string]bool{}
+	for _, fp := range fingerprints {
+		removed[fp] = false
+	}
+
+	var kept []Suppression
+	var ret []string
+	for _, sup := range current {
+		if done, ok := removed[sup.Fingerprint]; ok {
+			if !done {
+				removed[sup.Fingerprint] = true
+				ret = append(ret, sup.Fingerprint)
+			}
+			continue
+		}
+		kept = append(kept, sup)
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret, s.write(kept)
+}
+
+func (s *FileStore) write(suppressions []Suppression) error {
+	if suppressions == nil {
+		suppressions = []Suppression{}
+	}
+	sortSuppressions(suppressions)
+
+	data, err := json.MarshalIndent(document{Suppressions: suppressions}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.Path, append(data, '\n'), fileMode)
+}
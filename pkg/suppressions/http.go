@@ -0,0 +1,140 @@
+package suppressions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// HTTPStore stores the suppressions in an HTTP service, e.g. to audit them centrally:
+//
+//	GET    <url>               returns the suppressions: {"suppressions": [...]}
+//	POST   <url>               adds the suppressions of the body: {"suppressions": [...]}
+//	DELETE <url>/<fingerprint> removes a suppression, 404 if it doesn't exist
+type HTTPStore struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+var _ Store = &HTTPStore{}
+
+// NewHTTPStore returns the store of the service, the headers are added to every request, e.g. for authentication.
+func NewHTTPStore(rawURL string, headers map[string]string, timeout time.Duration) *HTTPStore {
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	return &HTTPStore{
+		url:     strings.TrimSuffix(rawURL, "/"),
+		headers: headers,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *HTTPStore) String() string {
+	return s.url
+}
+
+func (s *HTTPStore) List(ctx context.Context) ([]Suppression, error) {
+	resp, err := s.do(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(http.MethodGet, s.url, resp)
+	}
+
+	var doc document
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("can't parse the suppressions of %s: %w", s.url, err)
+	}
+
+	return doc.Suppressions, nil
+}
+
+func (s *HTTPStore) Add(ctx context.Context, suppressions []Suppression) error {
+	body, err := json.Marshal(document{Suppressions: suppressions})
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(ctx, http.MethodPost, s.url, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return statusError(http.MethodPost, s.url, resp)
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func (s *HTTPStore) Remove(ctx context.Context, fingerprints []string) ([]string, error) {
+	var removed []string
+	for _, fp := range fingerprints {
+		ok, err := s.remove(ctx, fp)
+		if err != nil {
+			return removed, err
+		}
+		if ok {
+			removed = append(removed, fp)
+		}
+	}
+
+	return removed, nil
+}
+
+func (s *HTTPStore) remove(ctx context.Context, fingerprint string) (bool, error) {
+	u := s.url + "/" + url.PathEscape(fingerprint)
+
+	resp, err := s.do(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return false, nil
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return false, statusError(http.MethodDelete, u, resp)
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return true, nil
+}
+
+func (s *HTTPStore) do(ctx context.Context, method, u string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	return s.client.Do(req)
+}
+
+func statusError(method, u string, resp *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("%s %s: %s: %s", method, u, resp.Status, bytes.TrimSpace(msg))
+}
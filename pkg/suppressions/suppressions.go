@@ -0,0 +1,83 @@
+// Package suppressions stores the suppressed issues outside of the code, in a JSON file or an HTTP service:
+// the issues are matched by fingerprint, instead of `//nolint` directives.
+package suppressions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/credentials"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// Suppression is a suppressed issue.
+type Suppression struct {
+	// Fingerprint identifies the issue: see result.Issue.StableHash.
+	Fingerprint string `json:"fingerprint"`
+	// Linter, Path and Text describe the issue for the audit, they're not matched.
+	Linter string `json:"linter"`
+	Path   string `json:"path"`
+	Text   string `json:"text"`
+
+	Reason  string    `json:"reason"`
+	Author  string    `json:"author,omitempty"`
+	Created time.Time `json:"created"`
+}
+
+// Store is the storage of the suppressions.
+type Store interface {
+	// List returns all the suppressions.
+	List(ctx context.Context) ([]Suppression, error)
+	// Add stores the suppressions, replacing the ones with the same fingerprints.
+	Add(ctx context.Context, suppressions []Suppression) error
+	// Remove removes the suppressions of the fingerprints, and returns the fingerprints of the removed ones.
+	Remove(ctx context.Context, fingerprints []string) ([]string, error)
+	// String describes the store in the logs.
+	String() string
+}
+
+// NewStore returns the store of the settings, nil if none is configured:
+// the credentials of the headers are expanded by the resolver.
+func NewStore(ctx context.Context, settings *config.SuppressionsSettings, resolver *credentials.Resolver) (Store, error) {
+	switch {
+	case settings.File != "":
+		return &FileStore{Path: settings.File}, nil
+	case settings.URL != "":
+		headers := make(map[string]string, len(settings.Headers))
+		for k, v := range settings.Headers {
+			value, err := resolver.Expand(ctx, v)
+			if err != nil {
+				return nil, fmt.Errorf("header %s: %w", k, err)
+			}
+			headers[k] = value
+		}
+		return NewHTTPStore(settings.URL, headers, settings.Timeout), nil
+	default:
+		return nil, nil
+	}
+}
+
+// New returns the suppression of the issue.
+func New(issue *result.Issue, line, reason, author string) Suppression {
+	return Suppression{
+		Fingerprint: issue.StableHash(line),
+		Linter:      issue.FromLinter,
+		Path:        issue.FilePath(),
+		Text:        issue.Text,
+		Reason:      reason,
+		Author:      author,
+		Created:     time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func sortSuppressions(suppressions []Suppression) {
+	sort.SliceStable(suppressions, func(i, j int) bool {
+		if suppressions[i].Path != suppressions[j].Path {
+			return suppressions[i].Path < suppressions[j].Path
+		}
+		return suppressions[i].Fingerprint < suppressions[j].Fingerprint
+	})
+}
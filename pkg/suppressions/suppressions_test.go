@@ -0,0 +1,131 @@
+package suppressions
+
+import (
+	"context"
+	"encoding/json"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newTestIssue(line int, text string) *result.Issue {
+	return &result.Issue{FromLinter: "gosec", Text: text, Pos: token.Position{Filename: "pkg/a.go", Line: line}}
+}
+
+func TestNew_fingerprint(t *testing.T) {
+	fp := New(newTestIssue(10, "G104: errors unhandled"), "\tf.Close()", "", "").Fingerprint
+	assert.Len(t, fp, 64)
+
+	fingerprint := func(line int, text, source string) string {
+		return New(newTestIssue(line, text), source, "", "").Fingerprint
+	}
+	assert.Equal(t, fp, fingerprint(42, "G104: errors unhandled", "f.Close() "), "the line number isn't matched")
+	assert.NotEqual(t, fp, fingerprint(10, "G104: errors unhandled", "g.Close()"))
+	assert.NotEqual(t, fp, fingerprint(10, "G307: deferred close", "f.Close()"))
+}
+
+func testStore(t *testing.T, store Store) {
+	t.Helper()
+	ctx := context.Background()
+
+	list, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	a := New(newTestIssue(10, "a"), "a()", "false positive", "alice")
+	b := New(newTestIssue(20, "b"), "b()", "accepted risk", "bob")
+	require.NoError(t, store.Add(ctx, []Suppression{a, b}))
+
+	list, err = store.List(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Suppression{a, b}, list)
+
+	a.Reason = "false positive, see SEC-42"
+	require.NoError(t, store.Add(ctx, []Suppression{a}))
+
+	list, err = store.List(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Suppression{a, b}, list, "replaced")
+
+	removed, err := store.Remove(ctx, []string{b.Fingerprint, "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{b.Fingerprint}, removed)
+
+	list, err = store.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []Suppression{a}, list)
+}
+
+func TestFileStore(t *testing.T) {
+	testStore(t, &FileStore{Path: filepath.Join(t.TempDir(), "suppressions.json")})
+}
+
+// newTestServer is an HTTP service of the suppressions, in memory.
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var mu sync.Mutex
+	stored := map[string]Suppression{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/api":
+			doc := document{Suppressions: []Suppression{}}
+			for _, s := range stored {
+				doc.Suppressions = append(doc.Suppressions, s)
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(doc))
+		case req.Method == http.MethodPost && req.URL.Path == "/api":
+			var doc document
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&doc))
+			for _, s := range doc.Suppressions {
+				stored[s.Fingerprint] = s
+			}
+			w.WriteHeader(http.StatusCreated)
+		case req.Method == http.MethodDelete && strings.HasPrefix(req.URL.Path, "/api/"):
+			fp := strings.TrimPrefix(req.URL.Path, "/api/")
+			if _, ok := stored[fp]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(stored, fp)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestHTTPStore(t *testing.T) {
+	server := newTestServer(t)
+
+	testStore(t, NewHTTPStore(server.URL+"/api/", map[string]string{"Authorization": "Bearer secret"}, 0))
+}
+
+func TestHTTPStore_error(t *testing.T) {
+	server := newTestServer(t)
+
+	_, err := NewHTTPStore(server.URL+"/api", nil, 0).List(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401 Unauthorized")
+}
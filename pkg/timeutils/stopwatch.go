@@ -81,13 +81,14 @@ func (s *Stopwatch) sprintTopStages(n int) string {
 }
 
 func (s *Stopwatch) Print() {
-	p := fmt.Sprintf("%s took %s", s.name, time.Since(s.startedAt))
+	took := time.Since(s.startedAt)
+	p := fmt.Sprintf("%s took %s", s.name, took)
 	if len(s.stages) == 0 {
-		s.log.Infof("%s", p)
+		logutils.InfoEvent(s.log, "timing", s.fields(took), "%s", p)
 		return
 	}
 
-	s.log.Infof("%s with %s", p, s.sprintStages())
+	logutils.InfoEvent(s.log, "timing", s.fields(took), "%s with %s", p, s.sprintStages())
 }
 
 func (s *Stopwatch) PrintStages() {
@@ -95,7 +96,8 @@ func (s *Stopwatch) PrintStages() {
 	for _, s := range s.stages {
 		stagesDuration += s
 	}
-	s.log.Infof("%s took %s with %s", s.name, stagesDuration, s.sprintStages())
+	logutils.InfoEvent(s.log, "timing", s.fields(stagesDuration),
+		"%s took %s with %s", s.name, stagesDuration, s.sprintStages())
 }
 
 func (s *Stopwatch) PrintTopStages(n int) {
@@ -103,7 +105,22 @@ func (s *Stopwatch) PrintTopStages(n int) {
 	for _, s := range s.stages {
 		stagesDuration += s
 	}
-	s.log.Infof("%s took %s with %s", s.name, stagesDuration, s.sprintTopStages(n))
+	logutils.InfoEvent(s.log, "timing", s.fields(stagesDuration),
+		"%s took %s with %s", s.name, stagesDuration, s.sprintTopStages(n))
+}
+
+// fields returns the timings in milliseconds for the structured logs.
+func (s *Stopwatch) fields(took time.Duration) logutils.Fields {
+	stages := make(map[string]float64, len(s.stages))
+	for name, d := range s.stages {
+		stages[name] = logutils.DurationField(d)
+	}
+
+	return logutils.Fields{
+		"stopwatch":   s.name,
+		"duration_ms": logutils.DurationField(took),
+		"stages_ms":   stages,
+	}
 }
 
 func (s *Stopwatch) TrackStage(name string, f func()) {
@@ -114,3 +131,15 @@ func (s *Stopwatch) TrackStage(name string, f func()) {
 	s.stages[name] += time.Since(startedAt)
 	s.mu.Unlock()
 }
+
+// Stages returns a copy of the durations of the stages.
+func (s *Stopwatch) Stages() map[string]time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stages := make(map[string]time.Duration, len(s.stages))
+	for name, d := range s.stages {
+		stages[name] = d
+	}
+	return stages
+}
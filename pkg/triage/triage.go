@@ -0,0 +1,452 @@
+// Package triage browses the issues of a run in the terminal, to fix them or to suppress them one by one.
+package triage
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/fixpreview"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const (
+	GroupByFile   = "file"
+	GroupByLinter = "linter"
+
+	contextLines = 3
+)
+
+// Group is a group of issues of the same file or linter.
+type Group struct {
+	Name   string
+	Issues []*result.Issue
+}
+
+// GroupIssues groups the issues by file or by linter, the groups are sorted by name.
+func GroupIssues(issues []*result.Issue, by string) []Group {
+	index := map[string]int{}
+	var groups []Group
+
+	for _, issue := range issues {
+		name := issue.FilePath()
+		if by == GroupByLinter {
+			name = issue.FromLinter
+		}
+
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, Group{Name: name})
+		}
+		groups[i].Issues = append(groups[i].Issues, issue)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+
+	return groups
+}
+
+// Summary counts the issues triaged by a session.
+type Summary struct {
+	Fixed      int
+	Suppressed int
+	// Remaining are the issues neither fixed nor suppressed.
+	Remaining []result.Issue
+}
+
+type state int
+
+const (
+	stateOpen state = iota
+	stateFixed
+	stateSuppressed
+)
+
+// Session is an interactive triage of issues: the commands are read line by line.
+type Session struct {
+	in  *bufio.Reader
+	out io.Writer
+
+	issues []*result.Issue
+	states map[*result.Issue]state
+	by     string
+	groups []Group
+
+	// order is the list of the issues in the order of the groups, pos the current issue in it.
+	order []*result.Issue
+	pos   int
+
+	readFile  func(path string) ([]byte, error)
+	writeFile func(path string, data []byte) error
+}
+
+func NewSession(issues []result.Issue, in io.Reader, out io.Writer) *Session {
+	s := &Session{
+		in:     bufio.NewReader(in),
+		out:    out,
+		states: map[*result.Issue]state{},
+		by:     GroupByFile,
+
+		readFile: os.ReadFile,
+		writeFile: func(path string, data []byte) error {
+			return os.WriteFile(path, data, 0o644) //nolint:gosec // the file exists, its mode is kept
+		},
+	}
+
+	for i := range issues {
+		issue := issues[i]
+		s.issues = append(s.issues, &issue)
+	}
+
+	s.regroup(GroupByFile)
+
+	return s
+}
+
+const help = `Commands:
+  n, <enter>      next issue
+  p               previous issue
+  f               apply the fix of the issue
+  i <reason>      suppress the issue with a //nolint directive explained by the reason
+  g [<number>]    list the groups, or go to the first issue of a group
+  by file|linter  group the issues by file or by linter
+  ?               this help
+  q               quit
+`
+
+// Run reads the commands until the end of the input or the quit command.
+func (s *Session) Run() (*Summary, error) {
+	if len(s.order) == 0 {
+		fmt.Fprintln(s.out, "No issues")
+		return s.summary(), nil
+	}
+
+	fmt.Fprintf(s.out, "%d issues in %d %s groups, ? for the help\n", len(s.order), len(s.groups), s.by)
+	s.show()
+
+	for {
+		fmt.Fprint(s.out, "> ")
+
+		line, err := s.in.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if errors.Is(err, io.EOF) && line == "" {
+			fmt.Fprintln(s.out)
+			return s.summary(), nil
+		}
+
+		cmd, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+		arg = strings.TrimSpace(arg)
+
+		if cmd == "q" {
+			return s.summary(), nil
+		}
+
+		if err := s.exec(cmd, arg); err != nil {
+			fmt.Fprintf(s.out, "Error: %s\n", err)
+		}
+	}
+}
+
+func (s *Session) exec(cmd, arg string) error {
+	switch cmd {
+	case "", "n":
+		s.move(1)
+	case "p":
+		s.move(-1)
+	case "f":
+		if err := s.fix(s.current()); err != nil {
+			return err
+		}
+		s.move(1)
+	case "i":
+		if arg == "" {
+			return errors.New("a reason is required: i <reason>")
+		}
+		if err := s.suppress(s.current(), arg); err != nil {
+			return err
+		}
+		s.move(1)
+	case "g":
+		return s.goToGroup(arg)
+	case "by":
+		if arg != GroupByFile && arg != GroupByLinter {
+			return fmt.Errorf("unknown grouping %q: must be %s or %s", arg, GroupByFile, GroupByLinter)
+		}
+		s.regroup(arg)
+		s.listGroups()
+		s.show()
+	case "?", "h", "help":
+		fmt.Fprint(s.out, help)
+	default:
+		return fmt.Errorf("unknown command %q, ? for the help", cmd)
+	}
+
+	return nil
+}
+
+func (s *Session) current() *result.Issue {
+	return s.order[s.pos]
+}
+
+func (s *Session) move(delta int) {
+	next := s.pos + delta
+	if next < 0 || next >= len(s.order) {
+		fmt.Fprintln(s.out, "No more issues, q to quit")
+		return
+	}
+
+	s.pos = next
+	s.show()
+}
+
+func (s *Session) regroup(by string) {
+	var current *result.Issue
+	if len(s.order) != 0 {
+		current = s.current()
+	}
+
+	s.by = by
+	s.groups = GroupIssues(s.issues, by)
+
+	s.order = s.order[:0]
+	s.pos = 0
+	for _, g := range s.groups {
+		for _, issue := range g.Issues {
+			if issue == current {
+				s.pos = len(s.order)
+			}
+			s.order = append(s.order, issue)
+		}
+	}
+}
+
+func (s *Session) listGroups() {
+	for i, g := range s.groups {
+		open := 0
+		for _, issue := range g.Issues {
+			if s.states[issue] == stateOpen {
+				open++
+			}
+		}
+		fmt.Fprintf(s.out, "%3d. %s: %d issues, %d open\n", i+1, g.Name, len(g.Issues), open)
+	}
+}
+
+func (s *Session) goToGroup(arg string) error {
+	if arg == "" {
+		s.listGroups()
+		return nil
+	}
+
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 1 || n > len(s.groups) {
+		return fmt.Errorf("invalid group %q: must be between 1 and %d", arg, len(s.groups))
+	}
+
+	first := s.groups[n-1].Issues[0]
+	for i, issue := range s.order {
+		if issue == first {
+			s.pos = i
+		}
+	}
+
+	s.show()
+	return nil
+}
+
+// show prints the current issue with the lines around it.
+func (s *Session) show() {
+	issue := s.current()
+
+	status := ""
+	switch s.states[issue] {
+	case stateFixed:
+		status = " [fixed]"
+	case stateSuppressed:
+		status = " [suppressed]"
+	case stateOpen:
+		if issue.Replacement != nil {
+			status = " [fix available]"
+		}
+	}
+
+	fmt.Fprintf(s.out, "\n[%d/%d] %s:%d: %s (%s)%s\n", s.pos+1, len(s.order),
+		issue.FilePath(), issue.Line(), strings.TrimSpace(issue.Text), issue.FromLinter, status)
+
+	data, err := s.readFile(issue.FilePath())
+	if err != nil {
+		fmt.Fprintf(s.out, "Can't read the file: %s\n", err)
+		return
+	}
+
+	lines := strings.Split(string(data), "\n")
+	from := max(1, issue.Line()-contextLines)
+	to := min(len(lines), issue.Line()+contextLines)
+	for n := from; n <= to; n++ {
+		marker := " "
+		if n == issue.Line() {
+			marker = ">"
+		}
+		fmt.Fprintf(s.out, "%s %5d | %s\n", marker, n, lines[n-1])
+	}
+}
+
+// fix applies the fix of the issue to its file, the lines of the next issues of the file are shifted.
+func (s *Session) fix(issue *result.Issue) error {
+	if s.states[issue] != stateOpen {
+		return errors.New("the issue is already triaged")
+	}
+
+	lines, err := s.readLines(issue)
+	if err != nil {
+		return err
+	}
+
+	// The preview validates the fix against the lines of the file.
+	preview, err := fixpreview.NewPreview(issue, lines)
+	if err != nil {
+		return err
+	}
+
+	edit := preview.Edit
+	fixed := append([]string{}, lines[:edit.StartLine-1]...)
+
+	switch r := issue.Replacement; {
+	case r.Inline != nil:
+		line := lines[edit.StartLine-1]
+		fixed = append(fixed, line[:edit.StartCol]+edit.NewText+line[edit.EndCol:])
+		fixed = append(fixed, lines[edit.StartLine:]...)
+	case r.NeedOnlyDelete:
+		fixed = append(fixed, lines[edit.EndLine-1:]...)
+	default:
+		fixed = append(fixed, r.NewLines...)
+		fixed = append(fixed, lines[edit.EndLine-1:]...)
+	}
+
+	if err := s.writeFile(issue.FilePath(), []byte(strings.Join(fixed, "\n"))); err != nil {
+		return err
+	}
+
+	s.shiftLines(issue, len(fixed)-len(lines))
+
+	s.states[issue] = stateFixed
+	fmt.Fprintln(s.out, "Fixed")
+
+	return nil
+}
+
+// suppress adds a nolint directive for the linter of the issue, explained by the reason, at the end of the line of the issue.
+func (s *Session) suppress(issue *result.Issue, reason string) error {
+	if s.states[issue] != stateOpen {
+		return errors.New("the issue is already triaged")
+	}
+
+	lines, err := s.readLines(issue)
+	if err != nil {
+		return err
+	}
+
+	line := lines[issue.Line()-1]
+	if strings.Contains(line, "//nolint") {
+		return errors.New("the line already has a nolint directive: edit it")
+	}
+
+	lines[issue.Line()-1] = fmt.Sprintf("%s //nolint:%s // %s", strings.TrimRight(line, " \t"), issue.FromLinter, reason)
+
+	if err := s.writeFile(issue.FilePath(), []byte(strings.Join(lines, "\n"))); err != nil {
+		return err
+	}
+
+	for _, other := range s.issues {
+		if other.FilePath() != issue.FilePath() || other.Line() != issue.Line() {
+			continue
+		}
+
+		if len(other.SourceLines) != 0 {
+			other.SourceLines = append([]string{lines[issue.Line()-1]}, other.SourceLines[1:]...)
+		}
+
+		// The directive suppresses the other issues of the linter on the line.
+		if other.FromLinter == issue.FromLinter && s.states[other] == stateOpen {
+			s.states[other] = stateSuppressed
+		}
+	}
+
+	fmt.Fprintln(s.out, "Suppressed")
+
+	return nil
+}
+
+func (s *Session) readLines(issue *result.Issue) ([]string, error) {
+	data, err := s.readFile(issue.FilePath())
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	if issue.Line() < 1 || issue.Line() > len(lines) {
+		return nil, fmt.Errorf("invalid line %d: the file has %d lines", issue.Line(), len(lines))
+	}
+
+	if len(issue.SourceLines) != 0 && lines[issue.Line()-1] != issue.SourceLines[0] {
+		return nil, errors.New("the line changed since the analysis")
+	}
+
+	return lines, nil
+}
+
+// shiftLines moves the issues after the fixed one in the same file by delta lines.
+func (s *Session) shiftLines(fixed *result.Issue, delta int) {
+	if delta == 0 {
+		return
+	}
+
+	for _, issue := range s.issues {
+		if issue == fixed || issue.FilePath() != fixed.FilePath() || issue.Line() <= fixed.GetLineRange().To {
+			continue
+		}
+
+		issue.Pos.Line += delta
+		if issue.LineRange != nil {
+			issue.LineRange = &result.Range{From: issue.LineRange.From + delta, To: issue.LineRange.To + delta}
+		}
+	}
+}
+
+func (s *Session) summary() *Summary {
+	sum := &Summary{}
+	for _, issue := range s.issues {
+		switch s.states[issue] {
+		case stateFixed:
+			sum.Fixed++
+		case stateSuppressed:
+			sum.Suppressed++
+		case stateOpen:
+			sum.Remaining = append(sum.Remaining, *issue)
+		}
+	}
+	return sum
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
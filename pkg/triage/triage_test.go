@@ -0,0 +1,121 @@
+package triage
+
+import (
+	"bytes"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const source = `package a
+
+func a() {
+	x := 1
+	y := 2
+	_ = x == true
+}
+`
+
+func newTestSession(t *testing.T, issues []result.Issue, commands string) (*Session, map[string]string, *bytes.Buffer) {
+	t.Helper()
+
+	files := map[string]string{"a.go": source}
+	out := new(bytes.Buffer)
+
+	s := NewSession(issues, strings.NewReader(commands), out)
+	s.readFile = func(path string) ([]byte, error) {
+		data, ok := files[path]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return []byte(data), nil
+	}
+	s.writeFile = func(path string, data []byte) error {
+		files[path] = string(data)
+		return nil
+	}
+
+	return s, files, out
+}
+
+func newIssue(linter string, line int, text string) result.Issue {
+	return result.Issue{
+		FromLinter:  linter,
+		Text:        text,
+		Pos:         token.Position{Filename: "a.go", Line: line},
+		SourceLines: []string{strings.Split(source, "\n")[line-1]},
+	}
+}
+
+func TestSession(t *testing.T) {
+	deleteY := newIssue("ineffassign", 5, "y is never used")
+	deleteY.Replacement = &result.Replacement{NeedOnlyDelete: true}
+
+	simplify := newIssue("gosimple", 6, "S1002: should omit comparison to bool constant")
+	simplify.Replacement = &result.Replacement{Inline: &result.InlineFix{StartCol: 5, Length: 9, NewString: "x"}}
+
+	issues := []result.Issue{
+		newIssue("govet", 4, "x declared but not used"),
+		deleteY,
+		simplify,
+	}
+
+	s, files, out := newTestSession(t, issues, "i too noisy\nf\nf\n")
+
+	sum, err := s.Run()
+	require.NoError(t, err)
+
+	assert.Equal(t, `package a
+
+func a() {
+	x := 1 //nolint:govet // too noisy
+	_ = x
+}
+`, files["a.go"])
+
+	assert.Equal(t, 1, sum.Suppressed)
+	assert.Equal(t, 2, sum.Fixed)
+	assert.Empty(t, sum.Remaining)
+	assert.Contains(t, out.String(), ">     5 | \ty := 2")
+}
+
+func TestSession_navigation(t *testing.T) {
+	issues := []result.Issue{
+		newIssue("govet", 4, "x declared but not used"),
+		newIssue("ineffassign", 5, "y is never used"),
+	}
+
+	s, files, out := newTestSession(t, issues, "by linter\ng 2\np\nf\ni\nq\n")
+
+	sum, err := s.Run()
+	require.NoError(t, err)
+
+	assert.Equal(t, source, files["a.go"])
+	assert.Len(t, sum.Remaining, 2)
+
+	output := out.String()
+	assert.Contains(t, output, "  1. govet: 1 issues, 1 open")
+	assert.Contains(t, output, "  2. ineffassign: 1 issues, 1 open")
+	assert.Contains(t, output, "Error: the issue has no fix")
+	assert.Contains(t, output, "Error: a reason is required")
+}
+
+func TestGroupIssues(t *testing.T) {
+	a, b, c := newIssue("govet", 4, "a"), newIssue("errcheck", 5, "b"), newIssue("govet", 6, "c")
+	c.Pos.Filename = "b.go"
+
+	groups := GroupIssues([]*result.Issue{&a, &b, &c}, GroupByLinter)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "errcheck", groups[0].Name)
+	assert.Equal(t, []*result.Issue{&a, &c}, groups[1].Issues)
+
+	groups = GroupIssues([]*result.Issue{&a, &b, &c}, GroupByFile)
+	require.Len(t, groups, 2)
+	assert.Equal(t, []*result.Issue{&a, &b}, groups[0].Issues)
+}
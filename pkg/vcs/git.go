@@ -0,0 +1,61 @@
+package vcs
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/golangci/revgrep"
+)
+
+type git struct {
+	repo
+}
+
+func (*git) Name() string {
+	return "git"
+}
+
+func (g *git) Patch(_ context.Context, rev string) (io.Reader, []string, error) {
+	// The paths of the patch and of the untracked files are relative to the current directory.
+	patch, newFiles, err := revgrep.GitPatch(rev, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	if patch == nil {
+		return nil, nil, ErrNoRepository
+	}
+
+	return patch, newFiles, nil
+}
+
+func (g *git) ChangedFiles(ctx context.Context, rev string) ([]string, error) {
+	var changed []string
+	for _, args := range [][]string{
+		{"diff", "--name-only", "--relative", rev, "--"},
+		{"ls-files", "--others", "--exclude-standard"},
+	} {
+		out, err := g.run(ctx, nil, "git", args...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, line := range strings.Split(string(out), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				changed = append(changed, filepath.Join(g.wd, filepath.FromSlash(line)))
+			}
+		}
+	}
+
+	return changed, nil
+}
+
+func (g *git) Revision(ctx context.Context) (string, error) {
+	out, err := g.run(ctx, nil, "git", "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
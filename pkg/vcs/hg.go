@@ -0,0 +1,71 @@
+package vcs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+)
+
+// hgEnv disables the user configuration changing the output of the commands, e.g. the relative paths.
+var hgEnv = []string{"HGPLAIN=1"}
+
+// hg is a Mercurial repository: the paths of its commands are relative to the root of the repository.
+type hg struct {
+	repo
+}
+
+func (*hg) Name() string {
+	return "hg"
+}
+
+func (h *hg) Patch(ctx context.Context, rev string) (io.Reader, []string, error) {
+	out, err := h.run(ctx, hgEnv, "hg", "status", "--unknown", "--no-status")
+	if err != nil {
+		return nil, nil, err
+	}
+	newFiles := h.relPaths(out)
+
+	args := []string{"diff", "--git"}
+	if rev != "" {
+		args = append(args, "--rev", rev)
+	}
+
+	patch, err := h.run(ctx, hgEnv, "hg", args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Without changes in the working directory, the changes of its parent.
+	if rev == "" && len(patch) == 0 && len(newFiles) == 0 {
+		patch, err = h.run(ctx, hgEnv, "hg", "diff", "--git", "--change", ".")
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	patch, err = h.relativize(patch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bytes.NewReader(patch), newFiles, nil
+}
+
+func (h *hg) ChangedFiles(ctx context.Context, rev string) ([]string, error) {
+	out, err := h.run(ctx, hgEnv, "hg", "status", "--rev", rev, "--modified", "--added", "--unknown", "--no-status")
+	if err != nil {
+		return nil, err
+	}
+
+	return h.absPaths(out), nil
+}
+
+func (h *hg) Revision(ctx context.Context) (string, error) {
+	out, err := h.run(ctx, hgEnv, "hg", "log", "--rev", ".", "--template", "{node}")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
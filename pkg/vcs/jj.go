@@ -0,0 +1,63 @@
+package vcs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+)
+
+// jj is a Jujutsu repository: the paths of its commands are relative to the root of the repository.
+// The working copy is a commit, there are no untracked files: the new files are in its changes.
+type jj struct {
+	repo
+}
+
+func (*jj) Name() string {
+	return "jj"
+}
+
+func (j *jj) Patch(ctx context.Context, rev string) (io.Reader, []string, error) {
+	args := []string{"diff", "--git"}
+	if rev != "" {
+		args = append(args, "--from", rev)
+	}
+
+	patch, err := j.run(ctx, nil, "jj", args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Without changes in the working copy, the changes of its parent.
+	if rev == "" && len(patch) == 0 {
+		patch, err = j.run(ctx, nil, "jj", "diff", "--git", "-r", "@-")
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	patch, err = j.relativize(patch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bytes.NewReader(patch), nil, nil
+}
+
+func (j *jj) ChangedFiles(ctx context.Context, rev string) ([]string, error) {
+	out, err := j.run(ctx, nil, "jj", "diff", "--name-only", "--from", rev)
+	if err != nil {
+		return nil, err
+	}
+
+	return j.absPaths(out), nil
+}
+
+func (j *jj) Revision(ctx context.Context) (string, error) {
+	out, err := j.run(ctx, nil, "jj", "log", "-r", "@", "--no-graph", "--template", "commit_id")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
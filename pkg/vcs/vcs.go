@@ -0,0 +1,210 @@
+// Package vcs reads the changes of the repository for the options reporting only the new issues:
+// git, Mercurial and Jujutsu repositories are detected from the current directory.
+package vcs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var ErrNoRepository = errors.New("no version control repository found")
+
+// VCS is the version control system of a repository.
+type VCS interface {
+	Name() string
+
+	// Patch returns the unified diff of the changes since the revision, and the untracked files,
+	// with paths relative to the current directory.
+	// Without revision, it returns the uncommitted changes, or the changes of the last commit if there are none.
+	Patch(ctx context.Context, rev string) (io.Reader, []string, error)
+
+	// ChangedFiles returns the absolute paths of the files changed since the revision, including the untracked files.
+	ChangedFiles(ctx context.Context, rev string) ([]string, error)
+
+	// Revision returns the identifier of the current commit.
+	Revision(ctx context.Context) (string, error)
+}
+
+// Detect returns the VCS of the closest repository containing the directory.
+// A Jujutsu repository colocated with a git one is a Jujutsu repository.
+func Detect(dir string) (VCS, error) {
+	wd, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for root := wd; ; {
+		switch {
+		case exists(filepath.Join(root, ".jj")):
+			return &jj{repo{root: root, wd: wd}}, nil
+		case exists(filepath.Join(root, ".hg")):
+			return &hg{repo{root: root, wd: wd}}, nil
+		case exists(filepath.Join(root, ".git")): // a directory, or a file for the worktrees
+			return &git{repo{root: root, wd: wd}}, nil
+		}
+
+		parent := filepath.Dir(root)
+		if parent == root {
+			return nil, ErrNoRepository
+		}
+		root = parent
+	}
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// repo is a repository with its root and the current directory.
+type repo struct {
+	root string
+	wd   string
+}
+
+// run runs the command of the VCS in the current directory.
+func (r repo) run(ctx context.Context, env []string, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = r.wd
+	if len(env) != 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w: %s", name, args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return out, nil
+}
+
+// relPath converts a path relative to the root of the repository to a path relative to the current directory.
+func (r repo) relPath(path string) string {
+	rel, err := filepath.Rel(r.wd, filepath.Join(r.root, filepath.FromSlash(path)))
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// absPaths converts the lines of the output of a command, paths relative to the root of the repository, to absolute paths.
+func (r repo) absPaths(out []byte) []string {
+	var paths []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			paths = append(paths, filepath.Join(r.root, filepath.FromSlash(line)))
+		}
+	}
+	return paths
+}
+
+// relPaths converts the lines of the output of a command, paths relative to the root of the repository,
+// to paths relative to the current directory.
+func (r repo) relPaths(out []byte) []string {
+	var paths []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			paths = append(paths, r.relPath(line))
+		}
+	}
+	return paths
+}
+
+// relativize rewrites the paths of the headers of the files of a git-style patch,
+// relative to the root of the repository, to paths relative to the current directory.
+func (r repo) relativize(patch []byte) ([]byte, error) {
+	var out bytes.Buffer
+
+	var oldLeft, newLeft int
+
+	scanner := bufio.NewScanner(bytes.NewReader(patch))
+	scanner.Buffer(nil, 1<<20)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case oldLeft > 0 || newLeft > 0:
+			switch {
+			case strings.HasPrefix(line, "+"):
+				newLeft--
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+			case strings.HasPrefix(line, `\`):
+			default:
+				oldLeft--
+				newLeft--
+			}
+		case strings.HasPrefix(line, "--- a/"):
+			line = "--- a/" + r.relPath(line[len("--- a/"):])
+		case strings.HasPrefix(line, "+++ b/"):
+			line = "+++ b/" + r.relPath(line[len("+++ b/"):])
+		case strings.HasPrefix(line, "@@ "):
+			var err error
+			_, oldLeft, newLeft, err = ParseHunkHeader(line)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// ParseHunkHeader parses the header "@@ -l,s +l,s @@" of a hunk of a unified diff: the counts are 1 if omitted.
+func ParseHunkHeader(header string) (newStart, oldCount, newCount int, err error) {
+	fields := strings.Fields(header)
+	if len(fields) < 4 || fields[3] != "@@" || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return 0, 0, 0, fmt.Errorf("invalid hunk header %q", header)
+	}
+
+	_, oldCount, err = parseHunkRange(fields[1][1:])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid hunk header %q: %w", header, err)
+	}
+
+	newStart, newCount, err = parseHunkRange(fields[2][1:])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid hunk header %q: %w", header, err)
+	}
+
+	return newStart, oldCount, newCount, nil
+}
+
+func parseHunkRange(r string) (start, count int, err error) {
+	startStr, countStr, hasCount := strings.Cut(r, ",")
+
+	start, err = strconv.Atoi(startStr)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	count = 1
+	if hasCount {
+		count, err = strconv.Atoi(countStr)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return start, count, nil
+}
@@ -0,0 +1,105 @@
+package vcs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	root := t.TempDir()
+
+	mkdir := func(path string) string {
+		t.Helper()
+		dir := filepath.Join(root, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(dir, 0o700))
+		return dir
+	}
+
+	mkdir("git/.git")
+	mkdir("git/hg/.hg")
+	mkdir("colocated/.git")
+	mkdir("colocated/.jj")
+	require.NoError(t, os.WriteFile(filepath.Join(mkdir("worktree"), ".git"), []byte("gitdir: ../git/.git\n"), 0o600))
+
+	testCases := []struct {
+		dir  string
+		name string
+	}{
+		{dir: mkdir("git/pkg/a"), name: "git"},
+		{dir: mkdir("git/hg/pkg"), name: "hg"},
+		{dir: mkdir("colocated/pkg"), name: "jj"},
+		{dir: mkdir("worktree/pkg"), name: "git"},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.dir, func(t *testing.T) {
+			v, err := Detect(test.dir)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.name, v.Name())
+		})
+	}
+}
+
+func TestDetect_noRepository(t *testing.T) {
+	_, err := Detect(t.TempDir())
+	if err == nil {
+		t.Skip("the temporary directory is in a repository")
+	}
+
+	assert.ErrorIs(t, err, ErrNoRepository)
+}
+
+func TestRepo_relativize(t *testing.T) {
+	r := repo{root: "/repo", wd: "/repo/pkg"}
+
+	patch := `diff --git a/pkg/a.go b/pkg/a.go
+--- a/pkg/a.go
++++ b/pkg/a.go
+@@ -1,3 +1,3 @@
+ package pkg
+--- a/pkg/a.go
++++ b/pkg/a.go
+ var v = 1
+diff --git a/b.go b/b.go
+new file mode 100644
+--- /dev/null
++++ b/b.go
+@@ -0,0 +1 @@
++package repo
+`
+
+	want := `diff --git a/pkg/a.go b/pkg/a.go
+--- a/a.go
++++ b/a.go
+@@ -1,3 +1,3 @@
+ package pkg
+--- a/pkg/a.go
++++ b/pkg/a.go
+ var v = 1
+diff --git a/b.go b/b.go
+new file mode 100644
+--- /dev/null
++++ b/../b.go
+@@ -0,0 +1 @@
++package repo
+`
+
+	got, err := r.relativize([]byte(patch))
+	require.NoError(t, err)
+	assert.Equal(t, want, string(got))
+}
+
+func TestParseHunkHeader(t *testing.T) {
+	newStart, oldCount, newCount, err := ParseHunkHeader("@@ -10 +11,3 @@ func b() {")
+	require.NoError(t, err)
+	assert.Equal(t, []int{11, 1, 3}, []int{newStart, oldCount, newCount})
+
+	_, _, _, err = ParseHunkHeader("@@ -1 @@")
+	assert.EqualError(t, err, `invalid hunk header "@@ -1 @@"`)
+}
@@ -7,8 +7,13 @@ import (
 	"log"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/hexops/gotextdiff"
+	"github.com/hexops/gotextdiff/myers"
+	"github.com/hexops/gotextdiff/span"
 )
 
 var errorLineRx = regexp.MustCompile(`^\S+?: (.*)\((\S+?)\)$`)
@@ -22,6 +27,17 @@ var errorLineRx = regexp.MustCompile(`^\S+?: (.*)\((\S+?)\)$`)
 // or if the error message does not match the <regexp>.
 // The <regexp> syntax is Perl but it's best to stick to egrep.
 //
+// The comment can expect the column of the error, // ERROR:col=12 "regexp",
+// and the count of the errors matching the <regexp>, // ERROR 2 "regexp".
+// Without count, at least one error must match.
+//
+// The comment can expect the errors of several linters on the same line,
+// // ERROR gosec "regexp" | errcheck "regexp": each expectation is matched independently,
+// and the column applies to all of them.
+//
+// The comment // NOERROR "regexp" (or // NOERROR linter "regexp") forbids the errors matching
+// the <regexp> on its line, after the ERROR comment of the line if any.
+//
 // Sources files are supplied as fullshort slice.
 // It consists of pairs: full path to source file and its base name.
 //
@@ -37,10 +53,23 @@ func errorCheck(outStr string, wantAuto bool, defaultWantedLinter string, fullsh
 		}
 	}
 
-	var want []wantedError
+	var want, forbidden []wantedError
 	for j := 0; j < len(fullshort); j += 2 {
 		full, short := fullshort[j], fullshort[j+1]
-		want = append(want, wantedErrors(full, short, defaultWantedLinter)...)
+		for _, we := range wantedErrors(full, short, defaultWantedLinter) {
+			if we.forbidden {
+				forbidden = append(forbidden, we)
+			} else {
+				want = append(want, we)
+			}
+		}
+	}
+	for _, we := range forbidden {
+		var unexpected []string
+		unexpected, out = partitionForbidden(we, out)
+		for _, errmsg := range unexpected {
+			errs = append(errs, fmt.Errorf("%s:%d: unexpected error matching %s: %s", we.file, we.lineNum, we.describe(), errmsg))
+		}
 	}
 	for _, we := range want {
 		if we.linter == "" {
@@ -60,8 +89,9 @@ func errorCheck(outStr string, wantAuto bool, defaultWantedLinter string, fullsh
 			errs = append(errs, fmt.Errorf("%s:%d: missing error %q", we.file, we.lineNum, we.reStr))
 			continue
 		}
-		matched := false
+		matched := 0
 		var textsToMatch []string
+		var otherLinter string
 		for _, errmsg := range errmsgs {
 			// Assume errmsg says "file:line: foo (<linter>)".
 			matches := errorLineRx.FindStringSubmatch(errmsg)
@@ -74,25 +104,36 @@ func errorCheck(outStr string, wantAuto bool, defaultWantedLinter string, fullsh
 
 			text, actualLinter := matches[1], matches[2]
 
-			if we.re.MatchString(text) {
-				matched = true
-			} else {
-				out = append(out, errmsg)
-				textsToMatch = append(textsToMatch, text)
+			textMatched := we.re.MatchString(text) && (we.col == 0 || errorColumn(errmsg, we.prefix) == we.col)
+			if textMatched && actualLinter == we.linter {
+				matched++
+				continue
 			}
 
-			if actualLinter != we.linter {
-				err := fmt.Errorf("%s:%d: expected error from %q but got error from %q in:\n\t%s",
-					we.file, we.lineNum, we.linter, actualLinter, strings.Join(out, "\n\t"))
-				errs = append(errs, err)
+			// The error can be expected for another linter of the line.
+			out = append(out, errmsg)
+			textsToMatch = append(textsToMatch, text)
+			if textMatched {
+				otherLinter = actualLinter
 			}
 		}
-		if !matched {
-			err := fmt.Errorf("%s:%d: no match for %#q vs %q in:\n\t%s",
-				we.file, we.lineNum, we.reStr, textsToMatch, strings.Join(out, "\n\t"))
+		if matched == 0 && otherLinter != "" {
+			err := fmt.Errorf("%s:%d: expected error from %q but got error from %q in:\n\t%s",
+				we.file, we.lineNum, we.linter, otherLinter, strings.Join(out, "\n\t"))
+			errs = append(errs, err)
+			continue
+		}
+		if matched == 0 {
+			err := fmt.Errorf("%s:%d: no match for %s vs %q in:\n\t%s",
+				we.file, we.lineNum, we.describe(), textsToMatch, strings.Join(out, "\n\t"))
 			errs = append(errs, err)
 			continue
 		}
+		if we.count != 0 && matched != we.count {
+			err := fmt.Errorf("%s:%d: expected %d errors matching %s but got %d",
+				we.file, we.lineNum, we.count, we.describe(), matched)
+			errs = append(errs, err)
+		}
 	}
 
 	if len(out) > 0 {
@@ -116,6 +157,76 @@ func errorCheck(outStr string, wantAuto bool, defaultWantedLinter string, fullsh
 	return errors.New(buf.String())
 }
 
+// partitionForbidden returns the errors of the line of the NOERROR expectation matching it, and the other errors.
+func partitionForbidden(we wantedError, out []string) (matched, unmatched []string) {
+	for _, errmsg := range out {
+		matches := errorLineRx.FindStringSubmatch(errmsg)
+		if matchPrefix(errmsg, we.prefix) && len(matches) != 0 && matches[2] == we.linter && we.re.MatchString(matches[1]) {
+			matched = append(matched, errmsg)
+		} else {
+			unmatched = append(unmatched, errmsg)
+		}
+	}
+	return matched, unmatched
+}
+
+// expectedCheck compares the errors of the output against the complete errors of the source expected by its sidecar file,
+// in any order: an error per line, `line:col: text (linter)`, the continuation lines start with a tab.
+// The expected file is overwritten with the errors of the output if update is true.
+func expectedCheck(outStr, expectedPath string, update bool, full, short string) error {
+	var actual []string
+	for _, errmsg := range splitOutput(outStr, false) {
+		errmsg = strings.Replace(errmsg, full, short, -1)
+		actual = append(actual, strings.TrimPrefix(errmsg, short+":"))
+	}
+	sort.Strings(actual)
+
+	if update {
+		var b strings.Builder
+		for _, errmsg := range actual {
+			b.WriteString(errmsg + "\n")
+		}
+		return os.WriteFile(expectedPath, []byte(b.String()), 0o600)
+	}
+
+	content, err := os.ReadFile(expectedPath)
+	if err != nil {
+		return err
+	}
+	expected := splitOutput(string(content), false)
+	sort.Strings(expected)
+
+	want, got := strings.Join(expected, "\n")+"\n", strings.Join(actual, "\n")+"\n"
+	if want == got {
+		return nil
+	}
+
+	edits := myers.ComputeEdits(span.URIFromPath(expectedPath), want, got)
+	diff := gotextdiff.ToUnified(expectedPath, "actual", want, edits)
+	return fmt.Errorf("the errors don't match %s (run the tests with -update to regenerate it):\n%s", expectedPath, diff)
+}
+
+// goldenCheck compares the source fixed by --fix against the expected source of the golden file.
+// The golden file is overwritten with the fixed source if update is true.
+func goldenCheck(fixed []byte, goldenPath string, update bool) error {
+	if update {
+		return os.WriteFile(goldenPath, fixed, 0o600)
+	}
+
+	golden, err := os.ReadFile(goldenPath)
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(golden, fixed) {
+		return nil
+	}
+
+	edits := myers.ComputeEdits(span.URIFromPath(goldenPath), string(golden), string(fixed))
+	diff := gotextdiff.ToUnified(goldenPath, "fixed", string(golden), edits)
+	return fmt.Errorf("the fixed source doesn't match %s (run the tests with -update to regenerate it):\n%s", goldenPath, diff)
+}
+
 func splitOutput(out string, wantAuto bool) []string {
 	// gc error messages continue onto additional lines with leading tabs.
 	// Split the output at the beginning of each line that doesn't begin with a tab.
@@ -152,6 +263,25 @@ func matchPrefix(s, prefix string) bool {
 	return false
 }
 
+// errorColumn returns the column of the error line starting with the file name prefix, 0 without column.
+func errorColumn(s, prefix string) int {
+	i := strings.Index(s, prefix+":")
+	if i < 0 {
+		return 0
+	}
+	s = s[i+len(prefix)+1:]
+
+	j := strings.Index(s, ":")
+	if j < 0 {
+		return 0
+	}
+	col, err := strconv.Atoi(s[:j])
+	if err != nil {
+		return 0
+	}
+	return col
+}
+
 func partitionStrings(prefix string, strs []string) (matched, unmatched []string) {
 	for _, s := range strs {
 		if matchPrefix(s, prefix) {
@@ -171,11 +301,24 @@ type wantedError struct {
 	file    string
 	prefix  string
 	linter  string
+	col     int // expected column, 0 if any
+	count   int // expected count of matching errors, 0 if at least one
+	// forbidden is set by NOERROR: the matching errors are unexpected.
+	forbidden bool
+}
+
+func (we wantedError) describe() string {
+	if we.col == 0 {
+		return fmt.Sprintf("%#q", we.reStr)
+	}
+	return fmt.Sprintf("%#q at column %d", we.reStr, we.col)
 }
 
 var (
-	errRx          = regexp.MustCompile(`// (?:GC_)?ERROR (.*)`)
-	errAutoRx      = regexp.MustCompile(`// (?:GC_)?ERRORAUTO (.*)`)
+	errRx          = regexp.MustCompile(`// (?:GC_)?ERROR(?::col=(\d+))? (.*)`)
+	errAutoRx      = regexp.MustCompile(`// (?:GC_)?ERRORAUTO(?::col=(\d+))? (.*)`)
+	noErrRx        = regexp.MustCompile(`// NOERROR (.*)`)
+	countPrefixRx  = regexp.MustCompile(`^\s*(\d+)\s`)
 	linterPrefixRx = regexp.MustCompile("^\\s*([^\\s\"`]+)")
 )
 
@@ -195,6 +338,26 @@ func wantedErrors(file, short, defaultLinter string) (errs []wantedError) {
 			// double comment disables ERROR
 			continue
 		}
+		if nm := noErrRx.FindStringSubmatchIndex(line); nm != nil {
+			rest := line[nm[2]:nm[3]]
+			for rest != "" {
+				var we wantedError
+				we, rest = parseWantedError(file, lineNum, line, rest, defaultLinter, cache)
+				we.prefix = fmt.Sprintf("%s:%d", short, lineNum)
+				we.lineNum = lineNum
+				we.file = short
+				we.forbidden = true
+				errs = append(errs, we)
+
+				rest = strings.TrimSpace(rest)
+				if rest != "" && !strings.HasPrefix(rest, "|") {
+					log.Fatalf("%s:%d: invalid errchk line: %s, unexpected %q after the regexp", file, lineNum, line, rest)
+				}
+				rest = strings.TrimPrefix(rest, "|")
+			}
+			line = line[:nm[0]] // the ERROR comment is before
+		}
+
 		var auto bool
 		m := errAutoRx.FindStringSubmatch(line)
 		if m != nil {
@@ -205,36 +368,69 @@ func wantedErrors(file, short, defaultLinter string) (errs []wantedError) {
 		if m == nil {
 			continue
 		}
-		rest := m[1]
-		linter := defaultLinter
-		if lm := linterPrefixRx.FindStringSubmatch(rest); lm != nil {
-			linter = lm[1]
-			rest = rest[len(lm[0]):]
+		var col int
+		if m[1] != "" {
+			col, _ = strconv.Atoi(m[1])
 		}
-		rx, err := strconv.Unquote(strings.TrimSpace(rest))
-		if err != nil {
-			log.Fatalf("%s:%d: invalid errchk line: %s, %v", file, lineNum, line, err)
-		}
-		re := cache[rx]
-		if re == nil {
-			var err error
-			re, err = regexp.Compile(rx)
-			if err != nil {
-				log.Fatalf("%s:%d: invalid regexp \"%#q\" in ERROR line: %v", file, lineNum, rx, err)
+		rest := m[2]
+		for {
+			var we wantedError
+			we, rest = parseWantedError(file, lineNum, line, rest, defaultLinter, cache)
+			we.prefix = fmt.Sprintf("%s:%d", short, lineNum)
+			we.auto = auto
+			we.lineNum = lineNum
+			we.file = short
+			we.col = col
+			errs = append(errs, we)
+
+			rest = strings.TrimSpace(rest)
+			if rest == "" {
+				break
 			}
-			cache[rx] = re
+			if !strings.HasPrefix(rest, "|") {
+				log.Fatalf("%s:%d: invalid errchk line: %s, unexpected %q after the regexp", file, lineNum, line, rest)
+			}
+			rest = rest[1:]
 		}
-		prefix := fmt.Sprintf("%s:%d", short, lineNum)
-		errs = append(errs, wantedError{
-			reStr:   rx,
-			re:      re,
-			prefix:  prefix,
-			auto:    auto,
-			lineNum: lineNum,
-			file:    short,
-			linter:  linter,
-		})
 	}
 
 	return
 }
+
+// parseWantedError parses an expectation of an ERROR comment: [count] [linter] "regexp".
+// It returns the rest of the comment, after the regexp.
+func parseWantedError(file string, lineNum int, line, rest, defaultLinter string,
+	cache map[string]*regexp.Regexp) (wantedError, string) {
+	var count int
+	if cm := countPrefixRx.FindStringSubmatch(rest); cm != nil {
+		count, _ = strconv.Atoi(cm[1])
+		if count == 0 {
+			log.Fatalf("%s:%d: invalid count of errors in ERROR line: %s", file, lineNum, line)
+		}
+		rest = rest[len(cm[0]):]
+	}
+	linter := defaultLinter
+	if lm := linterPrefixRx.FindStringSubmatch(rest); lm != nil {
+		linter = lm[1]
+		rest = rest[len(lm[0]):]
+	}
+	rest = strings.TrimSpace(rest)
+	quoted, err := strconv.QuotedPrefix(rest)
+	if err != nil {
+		log.Fatalf("%s:%d: invalid errchk line: %s, %v", file, lineNum, line, err)
+	}
+	rx, err := strconv.Unquote(quoted)
+	if err != nil {
+		log.Fatalf("%s:%d: invalid errchk line: %s, %v", file, lineNum, line, err)
+	}
+	re := cache[rx]
+	if re == nil {
+		var err error
+		re, err = regexp.Compile(rx)
+		if err != nil {
+			log.Fatalf("%s:%d: invalid regexp \"%#q\" in ERROR line: %v", file, lineNum, rx, err)
+		}
+		cache[rx] = re
+	}
+	return wantedError{reStr: rx, re: re, linter: linter, count: count}, rest[len(quoted):]
+}
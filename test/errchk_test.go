@@ -0,0 +1,75 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeErrchkSource(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestErrorCheck_noError(t *testing.T) {
+	src := writeErrchkSource(t, "a.go", `package a
+
+var a = 1 // ERROR "first" // NOERROR "second"
+var b = 2 // NOERROR "third" | other "fourth"
+`)
+
+	testCases := []struct {
+		desc string
+		out  string
+		err  string
+	}{
+		{desc: "expected", out: "a.go:3:1: first issue (linter)\n"},
+		{desc: "other linter", out: "a.go:3:1: first issue (linter)\na.go:4:1: third issue (another)\n", err: "unmatched errors"},
+		{
+			desc: "forbidden",
+			out:  "a.go:3:1: first issue (linter)\na.go:3:1: second issue (linter)\n",
+			err:  "a.go:3: unexpected error matching `second`: a.go:3:1: second issue (linter)",
+		},
+		{
+			desc: "forbidden for the linter",
+			out:  "a.go:3:1: first issue (linter)\na.go:4:1: fourth issue (other)\n",
+			err:  "a.go:4: unexpected error matching `fourth`: a.go:4:1: fourth issue (other)",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			err := errorCheck(test.out, false, "linter", src, "a.go")
+			if test.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestExpectedCheck(t *testing.T) {
+	src := writeErrchkSource(t, "a.go", "package a\n")
+	expectedPath := src + ".expected"
+	require.NoError(t, os.WriteFile(expectedPath, []byte("4:1: second (linter)\n3:1: first (linter)\n\tdetails\n"), 0o600))
+
+	out := src + ":3:1: first (linter)\n\tdetails\n" + src + ":4:1: second (linter)\n"
+	assert.NoError(t, expectedCheck(out, expectedPath, false, src, "a.go"))
+
+	out = src + ":3:1: first (linter)\n\tdetails\n"
+	assert.ErrorContains(t, expectedCheck(out, expectedPath, false, src, "a.go"), "-4:1: second (linter)")
+
+	out = src + ":3:1: first (linter)\n\tdetails\n" + src + ":4:1: second (linter)\n" + src + ":5:1: third (linter)\n"
+	assert.ErrorContains(t, expectedCheck(out, expectedPath, false, src, "a.go"), "+5:1: third (linter)")
+
+	require.NoError(t, expectedCheck(out, expectedPath, true, src, "a.go"))
+	content, err := os.ReadFile(expectedPath)
+	require.NoError(t, err)
+	assert.Equal(t, "3:1: first (linter)\n\tdetails\n4:1: second (linter)\n5:1: third (linter)\n", string(content))
+}
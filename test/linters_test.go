@@ -2,6 +2,7 @@ package test
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"go/build/constraint"
 	"os"
@@ -20,6 +21,10 @@ import (
 	"github.com/golangci/golangci-lint/test/testshared"
 )
 
+var update = flag.Bool("update", false, "update the golden files of the fixes and the expected files of the errors")
+
+// runGoErrchk checks the errors of the command against the ERROR comments of the files,
+// or against the complete expected errors of the sidecar file `<file>.expected` of a single file.
 func runGoErrchk(c *exec.Cmd, defaultExpectedLinter string, files []string, t *testing.T) {
 	output, err := c.CombinedOutput()
 	// The returned error will be nil if the test file does not have any issues
@@ -31,6 +36,14 @@ func runGoErrchk(c *exec.Cmd, defaultExpectedLinter string, files []string, t *t
 		require.Equal(t, exitcodes.IssuesFound, exitErr.ExitCode(), "Unexpected exit code: %s", string(output))
 	}
 
+	if len(files) == 1 {
+		expectedPath := files[0] + ".expected"
+		if _, err := os.Stat(expectedPath); err == nil {
+			require.NoError(t, expectedCheck(string(output), expectedPath, *update, files[0], filepath.Base(files[0])))
+			return
+		}
+	}
+
 	fullshort := make([]string, 0, len(files)*2)
 	for _, f := range files {
 		fullshort = append(fullshort, f, filepath.Base(f))
@@ -237,6 +250,52 @@ func testOneSource(t *testing.T, sourcePath string) {
 		t.Log(caseArgs)
 		runGoErrchk(cmd, rc.expectedLinter, []string{sourcePath}, t)
 	}
+
+	goldenPath := sourcePath + ".golden"
+	if _, err := os.Stat(goldenPath); err == nil {
+		testGoldenFix(t, sourcePath, goldenPath, append(args, rc.args...), cfgPath)
+	}
+}
+
+// testGoldenFix applies --fix to a copy of the source and compares the result against the golden file.
+func testGoldenFix(t *testing.T, sourcePath, goldenPath string, args []string, cfgPath string) {
+	tmpDir, err := os.MkdirTemp(testdataDir, "golden.tmp")
+	require.NoError(t, err)
+
+	if os.Getenv("GL_KEEP_TEMP_FILES") == "1" {
+		t.Logf("Temp dir for golden test: %s", tmpDir)
+	} else {
+		t.Cleanup(func() {
+			os.RemoveAll(tmpDir)
+		})
+	}
+
+	src, err := os.ReadFile(sourcePath)
+	require.NoError(t, err)
+
+	fixedPath := filepath.Join(tmpDir, filepath.Base(sourcePath))
+	require.NoError(t, os.WriteFile(fixedPath, src, 0o600))
+
+	caseArgs := append([]string{}, args...)
+	if cfgPath == "" {
+		caseArgs = append(caseArgs, "--no-config")
+	} else {
+		caseArgs = append(caseArgs, "-c", cfgPath)
+	}
+	caseArgs = append(caseArgs, "--fix", fixedPath)
+
+	t.Log(caseArgs)
+	output, err := exec.Command(binName, caseArgs...).CombinedOutput()
+	if err != nil {
+		var exitErr *exec.ExitError
+		require.ErrorAs(t, err, &exitErr)
+		require.Equal(t, exitcodes.IssuesFound, exitErr.ExitCode(), "Unexpected exit code: %s", string(output))
+	}
+
+	fixed, err := os.ReadFile(fixedPath)
+	require.NoError(t, err)
+
+	require.NoError(t, goldenCheck(fixed, goldenPath, *update))
 }
 
 type runContext struct {
@@ -269,6 +269,9 @@ func TestDisallowedOptionsInConfig(t *testing.T) {
 		option string
 	}
 
+	// The profiles and the trace of the runs with the options are written to a temporary directory.
+	tmpPath := filepath.Join(t.TempDir(), "path")
+
 	cases := []tc{
 		{
 			cfg: `
@@ -282,21 +285,21 @@ func TestDisallowedOptionsInConfig(t *testing.T) {
 				run:
 					CPUProfilePath: path
 			`,
-			option: "--cpu-profile-path=path",
+			option: "--cpu-profile-path=" + tmpPath,
 		},
 		{
 			cfg: `
 				run:
 					MemProfilePath: path
 			`,
-			option: "--mem-profile-path=path",
+			option: "--mem-profile-path=" + tmpPath,
 		},
 		{
 			cfg: `
 				run:
 					TracePath: path
 			`,
-			option: "--trace-path=path",
+			option: "--trace-path=" + tmpPath,
 		},
 		{
 			cfg: `
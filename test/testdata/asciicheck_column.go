@@ -0,0 +1,13 @@
+//golangcitest:args -Easciicheck
+package testdata
+
+type AsciicheckColumnField struct{}
+
+type AsciicheckColumnStruct struct {
+	Fiеld AsciicheckColumnField // ERROR:col=2 `identifier "Fiеld" contain non-ASCII character: U\+0435 'е'`
+}
+
+func AsciicheckColumnFunc() int {
+	var vаlue int // ERROR:col=6 `identifier "vаlue" contain non-ASCII character: U\+0430 'а'`
+	return vаlue
+}
@@ -0,0 +1,22 @@
+//golangcitest:args -Edogsled
+package testdata
+
+// The issues of the file are checked by dogsled_expected.go.expected.
+func DogsledExpected() {
+	_, _ = dogsledExpectedRet2()
+	_, _, _ = dogsledExpectedRet3()
+	_, _, b, _ := dogsledExpectedRet4()
+	_ = b
+}
+
+func dogsledExpectedRet2() (a, b int) {
+	return 1, 2
+}
+
+func dogsledExpectedRet3() (a, b, c int) {
+	return 1, 2, 3
+}
+
+func dogsledExpectedRet4() (a, b, c, d int) {
+	return 1, 2, 3, 4
+}
@@ -0,0 +1,21 @@
+//golangcitest:args -Edogsled
+package testdata
+
+func DogsledNoError() {
+	_, _ = dogsledRet2()        // NOERROR "blank identifiers"
+	_, _, _ = dogsledRet3()     // ERROR "declaration has 3 blank identifiers"
+	_, _, a, _ := dogsledRet4() // ERROR "declaration has 3 blank identifiers"
+	_ = a
+}
+
+func dogsledRet2() (a, b int) {
+	return 1, 2
+}
+
+func dogsledRet3() (a, b, c int) {
+	return 1, 2, 3
+}
+
+func dogsledRet4() (a, b, c, d int) {
+	return 1, 2, 3, 4
+}
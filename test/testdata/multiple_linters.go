@@ -0,0 +1,11 @@
+//golangcitest:args -Edogsled,predeclared --uniq-by-line=false
+package testdata
+
+func ManyValues() (int, int, int, int) {
+	return 1, 2, 3, 4
+}
+
+func MultipleLinters() {
+	real, _, _, _ := ManyValues() // ERROR predeclared "variable real has same name as predeclared identifier" | dogsled "declaration has 3 blank identifiers"
+	_ = real
+}
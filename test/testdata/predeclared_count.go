@@ -0,0 +1,10 @@
+//golangcitest:args -Epredeclared --uniq-by-line=false
+package testdata
+
+func predeclaredCount() {
+	var real, imag int // ERROR 2 "variable (real|imag) has same name as predeclared identifier"
+
+	// suppress any "declared but not used" errors
+	_ = real
+	_ = imag
+}